@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/base"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/juju/permission"
 	"github.com/juju/juju/storage"
 )
 
@@ -271,3 +273,47 @@ func (c *Client) DestroyRelation(endpoints ...string) error {
 	params := params.DestroyRelation{Endpoints: endpoints}
 	return c.facade.FacadeCall("DestroyRelation", params, nil)
 }
+
+// GrantApplication grants a model user access to the specified
+// applications.
+func (c *Client) GrantApplication(user, access string, applications ...string) error {
+	return c.modifyApplicationUser(params.GrantApplicationAccess, user, access, applications)
+}
+
+// RevokeApplication revokes a model user's access to the specified
+// applications.
+func (c *Client) RevokeApplication(user, access string, applications ...string) error {
+	return c.modifyApplicationUser(params.RevokeApplicationAccess, user, access, applications)
+}
+
+func (c *Client) modifyApplicationUser(action params.ApplicationAction, user, access string, applications []string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	userTag := names.NewUserTag(user)
+	if _, err := permission.ParseApplicationAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	var args params.ModifyApplicationAccessRequest
+	for _, application := range applications {
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("invalid application name %q", application)
+		}
+		args.Changes = append(args.Changes, params.ModifyApplicationAccess{
+			UserTag:        userTag.String(),
+			Action:         action,
+			Access:         params.ApplicationAccessPermission(access),
+			ApplicationTag: names.NewApplicationTag(application).String(),
+		})
+	}
+
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyApplicationAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
@@ -163,3 +163,35 @@ func (s *serviceSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
+
+func (s *serviceSuite) TestGrantApplication(c *gc.C) {
+	var called bool
+	application.PatchFacadeCall(s, s.client, func(request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ModifyApplicationAccess")
+		args, ok := a.(params.ModifyApplicationAccessRequest)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.Changes, jc.DeepEquals, []params.ModifyApplicationAccess{{
+			UserTag:        "user-bob",
+			Action:         params.GrantApplicationAccess,
+			Access:         params.ApplicationOperateAccess,
+			ApplicationTag: "application-mysql",
+		}, {
+			UserTag:        "user-bob",
+			Action:         params.GrantApplicationAccess,
+			Access:         params.ApplicationOperateAccess,
+			ApplicationTag: "application-wordpress",
+		}})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 2)
+		return nil
+	})
+	err := s.client.GrantApplication("bob", "operate", "mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *serviceSuite) TestRevokeApplicationInvalidAccess(c *gc.C) {
+	err := s.client.RevokeApplication("bob", "admin", "mysql")
+	c.Assert(err, gc.ErrorMatches, `invalid application access permission "admin"`)
+}
@@ -10,10 +10,11 @@ import (
 )
 
 // Create sends a request to create a backup of juju's state.  It
-// returns the metadata associated with the resulting backup.
-func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
+// returns the metadata associated with the resulting backup. If key
+// is not nil the backup archive is encrypted with it.
+func (c *Client) Create(notes string, key *params.BackupsArchiveKey) (*params.BackupsMetadataResult, error) {
 	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{Notes: notes}
+	args := params.BackupsCreateArgs{Notes: notes, Key: key}
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
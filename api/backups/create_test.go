@@ -27,6 +27,7 @@ func (s *createSuite) TestCreate(c *gc.C) {
 			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
 			p := paramsIn.(params.BackupsCreateArgs)
 			c.Check(p.Notes, gc.Equals, "important")
+			c.Check(p.Key, gc.IsNil)
 
 			if result, ok := resp.(*params.BackupsMetadataResult); ok {
 				*result = apiserverbackups.ResultFromMetadata(s.Meta)
@@ -39,9 +40,25 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important")
+	result, err := s.client.Create("important", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	key := &params.BackupsArchiveKey{Passphrase: "sekrit"}
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			c.Check(paramsIn.(params.BackupsCreateArgs).Key, jc.DeepEquals, key)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create("", key)
+	c.Assert(err, jc.ErrorIsNil)
+}
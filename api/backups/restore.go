@@ -88,16 +88,17 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		logger.Errorf("could not clean up after failed backup upload: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, nil, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// The key is used to decrypt the backup archive if it is encrypted.
+func (c *Client) Restore(backupId string, key *params.BackupsArchiveKey, newClient ClientConnection) error {
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, key, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -113,12 +114,13 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // It takes backupId as the identifier for the remote backup file and a
 // client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId string, key *params.BackupsArchiveKey, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
 		BackupId: backupId,
+		Key:      key,
 	}
 
 	cleanExit := false
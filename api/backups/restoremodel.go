@@ -0,0 +1,28 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// RestoreModel restores a single model from the stored backup as a new
+// model on the controller. If newName is empty the model keeps the
+// name it had when the backup was made. If key is not nil it is used
+// to decrypt the backup archive.
+func (c *Client) RestoreModel(backupId, modelUUID, newName string, key *params.BackupsArchiveKey) (*params.RestoreModelResult, error) {
+	var result params.RestoreModelResult
+	args := params.RestoreModelArgs{
+		BackupId:  backupId,
+		ModelUUID: modelUUID,
+		NewName:   newName,
+		Key:       key,
+	}
+	if err := c.facade.FacadeCall("RestoreModel", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
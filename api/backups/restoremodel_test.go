@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type restoreModelSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&restoreModelSuite{})
+
+func (s *restoreModelSuite) TestRestoreModel(c *gc.C) {
+	key := &params.BackupsArchiveKey{Passphrase: "sekrit"}
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "RestoreModel")
+			c.Check(paramsIn, jc.DeepEquals, params.RestoreModelArgs{
+				BackupId:  "spam",
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				NewName:   "restored",
+				Key:       key,
+			})
+
+			if result, ok := resp.(*params.RestoreModelResult); ok {
+				*result = params.RestoreModelResult{
+					ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					Name:      "restored",
+					Owner:     "admin",
+				}
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.RestoreModel("spam", "deadbeef-0bad-400d-8000-4b1d0d06f00d", "restored", key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, &params.RestoreModelResult{
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:      "restored",
+		Owner:     "admin",
+	})
+}
+
+func (s *restoreModelSuite) TestRestoreModelError(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			return errors.New("failed!")
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.RestoreModel("spam", "deadbeef-0bad-400d-8000-4b1d0d06f00d", "", nil)
+	c.Check(err, gc.ErrorMatches, "failed!")
+}
@@ -0,0 +1,41 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const charmRepositoryPath = "/charm-repository"
+
+// PublishCharm uploads a charm archive to the charm repository hosted by
+// the controller over HTTPS, as a new revision of the charm.
+func (c *Client) PublishCharm(r io.ReadSeeker, hash string, size int64) (params.RepositoryCharm, error) {
+	// Prepare the request.
+	v := url.Values{}
+	v.Set("hash", hash)
+	req, err := http.NewRequest("POST", charmRepositoryPath+"?"+v.Encode(), nil)
+	if err != nil {
+		return params.RepositoryCharm{}, errors.Annotate(err, "cannot create upload request")
+	}
+	req.Header.Set("Content-Type", "application/zip")
+	req.ContentLength = size
+
+	// Retrieve a client and send the request.
+	httpClient, err := c.st.RootHTTPClient()
+	if err != nil {
+		return params.RepositoryCharm{}, errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	var resp params.RepositoryCharm
+	if err = httpClient.Do(req, r, &resp); err != nil {
+		return params.RepositoryCharm{}, errors.Annotate(err, "cannot publish charm")
+	}
+	return resp, nil
+}
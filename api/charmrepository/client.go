@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrepository provides a client for working with the charm
+// repository hosted by the controller. Charms are published with
+// api.Client.PublishCharm.
+package charmrepository
+
+import (
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the CharmRepository API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the CharmRepository API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "CharmRepository")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListCharms returns the revisions of the charms in the repository. If
+// name is not empty, only the revisions of the charm with that name are
+// returned.
+func (c *Client) ListCharms(name string) ([]params.RepositoryCharm, error) {
+	args := params.RepositoryCharmsFilter{Name: name}
+	var result params.RepositoryCharmsResult
+	if err := c.facade.FacadeCall("ListCharms", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Charms, nil
+}
+
+// ResolveCharm returns the revision of the charm identified by the
+// charm repository URL. A URL without a revision identifies the revision
+// in the channel, or in the stable channel if none is given.
+func (c *Client) ResolveCharm(url string, channel csparams.Channel) (params.RepositoryCharm, error) {
+	args := params.RepositoryCharmURL{URL: url, Channel: string(channel)}
+	var result params.RepositoryCharmResult
+	if err := c.facade.FacadeCall("ResolveCharm", args, &result); err != nil {
+		return params.RepositoryCharm{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.RepositoryCharm{}, errors.Trace(result.Error)
+	}
+	return *result.Result, nil
+}
+
+// PromoteCharm puts the revision of the charm identified by the charm
+// repository URL in the channel.
+func (c *Client) PromoteCharm(url string, channel csparams.Channel) error {
+	args := params.PromoteRepositoryCharm{URL: url, Channel: string(channel)}
+	return errors.Trace(c.facade.FacadeCall("PromoteCharm", args, nil))
+}
+
+// AddCharm copies the charm identified by the charm repository URL into
+// the model as a local charm. It returns the URL of the local charm and
+// the URL of the charm revision that was copied.
+func (c *Client) AddCharm(url string, channel csparams.Channel) (params.AddRepositoryCharmResult, error) {
+	args := params.RepositoryCharmURL{URL: url, Channel: string(channel)}
+	var result params.AddRepositoryCharmResult
+	if err := c.facade.FacadeCall("AddCharm", args, &result); err != nil {
+		return params.AddRepositoryCharmResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// CharmOrigin returns the charm repository URL the model charm was
+// copied from, or the empty string if it did not come from the
+// repository.
+func (c *Client) CharmOrigin(charmURL string) (string, error) {
+	args := params.CharmURL{URL: charmURL}
+	var result params.RepositoryCharmOrigin
+	if err := c.facade.FacadeCall("CharmOrigin", args, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	return result.RepositoryURL, nil
+}
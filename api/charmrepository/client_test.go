@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmrepository"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) apiCaller(c *gc.C, request string, check func(args interface{}), response interface{}) basetesting.APICallerFunc {
+	return basetesting.APICallerFunc(
+		func(objType string, version int, id, req string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "CharmRepository")
+			c.Check(id, gc.Equals, "")
+			c.Check(req, gc.Equals, request)
+			check(args)
+			switch result := result.(type) {
+			case *params.RepositoryCharmsResult:
+				*result = response.(params.RepositoryCharmsResult)
+			case *params.RepositoryCharmResult:
+				*result = response.(params.RepositoryCharmResult)
+			case *params.AddRepositoryCharmResult:
+				*result = response.(params.AddRepositoryCharmResult)
+			case *params.RepositoryCharmOrigin:
+				*result = response.(params.RepositoryCharmOrigin)
+			}
+			return nil
+		},
+	)
+}
+
+func (s *clientSuite) TestListCharms(c *gc.C) {
+	caller := s.apiCaller(c, "ListCharms", func(args interface{}) {
+		c.Check(args, jc.DeepEquals, params.RepositoryCharmsFilter{Name: "mysql"})
+	}, params.RepositoryCharmsResult{
+		Charms: []params.RepositoryCharm{{URL: "repo:mysql-0"}},
+	})
+	charms, err := charmrepository.NewClient(caller).ListCharms("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charms, jc.DeepEquals, []params.RepositoryCharm{{URL: "repo:mysql-0"}})
+}
+
+func (s *clientSuite) TestResolveCharm(c *gc.C) {
+	caller := s.apiCaller(c, "ResolveCharm", func(args interface{}) {
+		c.Check(args, jc.DeepEquals, params.RepositoryCharmURL{URL: "repo:mysql", Channel: "edge"})
+	}, params.RepositoryCharmResult{
+		Result: &params.RepositoryCharm{URL: "repo:mysql-3"},
+	})
+	ch, err := charmrepository.NewClient(caller).ResolveCharm("repo:mysql", csparams.EdgeChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.URL, gc.Equals, "repo:mysql-3")
+}
+
+func (s *clientSuite) TestResolveCharmError(c *gc.C) {
+	caller := s.apiCaller(c, "ResolveCharm", func(interface{}) {}, params.RepositoryCharmResult{
+		Error: &params.Error{Message: "not found", Code: params.CodeNotFound},
+	})
+	_, err := charmrepository.NewClient(caller).ResolveCharm("repo:mysql", csparams.NoChannel)
+	c.Assert(err, gc.ErrorMatches, "not found")
+	c.Assert(params.IsCodeNotFound(err), jc.IsTrue)
+}
+
+func (s *clientSuite) TestPromoteCharm(c *gc.C) {
+	caller := s.apiCaller(c, "PromoteCharm", func(args interface{}) {
+		c.Check(args, jc.DeepEquals, params.PromoteRepositoryCharm{URL: "repo:mysql-3", Channel: "stable"})
+	}, nil)
+	err := charmrepository.NewClient(caller).PromoteCharm("repo:mysql-3", csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestAddCharm(c *gc.C) {
+	caller := s.apiCaller(c, "AddCharm", func(args interface{}) {
+		c.Check(args, jc.DeepEquals, params.RepositoryCharmURL{URL: "repo:trusty/mysql"})
+	}, params.AddRepositoryCharmResult{
+		CharmURL:      "local:trusty/mysql-3",
+		RepositoryURL: "repo:mysql-3",
+	})
+	result, err := charmrepository.NewClient(caller).AddCharm("repo:trusty/mysql", csparams.NoChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.CharmURL, gc.Equals, "local:trusty/mysql-3")
+	c.Assert(result.RepositoryURL, gc.Equals, "repo:mysql-3")
+}
+
+func (s *clientSuite) TestCharmOrigin(c *gc.C) {
+	caller := s.apiCaller(c, "CharmOrigin", func(args interface{}) {
+		c.Check(args, jc.DeepEquals, params.CharmURL{URL: "local:trusty/mysql-3"})
+	}, params.RepositoryCharmOrigin{RepositoryURL: "repo:mysql-3"})
+	origin, err := charmrepository.NewClient(caller).CharmOrigin("local:trusty/mysql-3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(origin, gc.Equals, "repo:mysql-3")
+}
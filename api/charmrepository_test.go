@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *clientSuite) TestPublishCharm(c *gc.C) {
+	client := s.APIState.Client()
+	called := false
+	archive := []byte("archive content")
+	hash, size := "archive-hash", int64(len(archive))
+
+	// Set up a fake endpoint for tests.
+	defer fakeAPIEndpoint(c, client, "/charm-repository", "POST",
+		func(w http.ResponseWriter, req *http.Request) {
+			defer req.Body.Close()
+			called = true
+			err := req.ParseForm()
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(req.Header.Get("Content-Type"), gc.Equals, "application/zip")
+			c.Assert(req.Form.Get("hash"), gc.Equals, hash)
+			c.Assert(req.ContentLength, gc.Equals, size)
+			obtainedArchive, err := ioutil.ReadAll(req.Body)
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(obtainedArchive, gc.DeepEquals, archive)
+			sendJSONResponse(c, w, params.RepositoryCharm{
+				URL:      "repo:mysql-2",
+				Name:     "mysql",
+				Revision: 2,
+			})
+		},
+	).Close()
+
+	ch, err := client.PublishCharm(bytes.NewReader(archive), hash, size)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.URL, gc.Equals, "repo:mysql-2")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *clientSuite) TestPublishCharmError(c *gc.C) {
+	client := s.APIState.Client()
+	archive := []byte("archive content")
+
+	// Set up a fake endpoint for tests.
+	defer fakeAPIEndpoint(c, client, "/charm-repository", "POST",
+		func(w http.ResponseWriter, req *http.Request) {
+			defer req.Body.Close()
+			w.WriteHeader(http.StatusBadRequest)
+		},
+	).Close()
+
+	_, err := client.PublishCharm(bytes.NewReader(archive), "archive-hash", int64(len(archive)))
+	c.Assert(err, gc.ErrorMatches, "cannot publish charm: .*")
+}
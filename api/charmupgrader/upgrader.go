@@ -0,0 +1,53 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrader provides the API client used by the charm
+// upgrader worker.
+package charmupgrader
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Facade provides access to the CharmUpgrader API end point.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns a Facade backed by the given APICaller.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{base.NewFacadeCaller(caller, "CharmUpgrader")}
+}
+
+// UpgradesAvailable returns the charm, upgrade policy and newer charm
+// revision, if any, of every application in the model.
+func (f *Facade) UpgradesAvailable() ([]params.CharmUpgradeInfo, error) {
+	var result params.CharmUpgradeInfoResults
+	if err := f.facade.FacadeCall("UpgradesAvailable", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// UpgradeCharms upgrades the applications to the given charms. It
+// returns one error, possibly nil, for each upgrade.
+func (f *Facade) UpgradeCharms(upgrades []params.UpgradeApplicationCharm) ([]error, error) {
+	args := params.UpgradeApplicationCharms{Args: upgrades}
+	var result params.ErrorResults
+	if err := f.facade.FacadeCall("UpgradeCharms", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(upgrades) {
+		return nil, errors.Errorf("expected %d results, got %d", len(upgrades), len(result.Results))
+	}
+	errs := make([]error, len(result.Results))
+	for i, r := range result.Results {
+		if r.Error != nil {
+			errs[i] = r.Error
+		}
+	}
+	return errs, nil
+}
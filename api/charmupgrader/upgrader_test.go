@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmupgrader"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type upgraderSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&upgraderSuite{})
+
+func (s *upgraderSuite) TestUpgradesAvailable(c *gc.C) {
+	caller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "CharmUpgrader")
+			c.Check(request, gc.Equals, "UpgradesAvailable")
+			*(result.(*params.CharmUpgradeInfoResults)) = params.CharmUpgradeInfoResults{
+				Results: []params.CharmUpgradeInfo{{ApplicationTag: "application-mysql"}},
+			}
+			return nil
+		},
+	)
+	infos, err := charmupgrader.NewFacade(caller).UpgradesAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []params.CharmUpgradeInfo{{ApplicationTag: "application-mysql"}})
+}
+
+func (s *upgraderSuite) TestUpgradeCharms(c *gc.C) {
+	upgrades := []params.UpgradeApplicationCharm{{
+		ApplicationTag: "application-mysql",
+		CharmURL:       "cs:trusty/mysql-4",
+	}, {
+		ApplicationTag: "application-wordpress",
+		CharmURL:       "cs:trusty/wordpress-2",
+	}}
+	caller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "CharmUpgrader")
+			c.Check(request, gc.Equals, "UpgradeCharms")
+			c.Check(args, jc.DeepEquals, params.UpgradeApplicationCharms{Args: upgrades})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	)
+	errs, err := charmupgrader.NewFacade(caller).UpgradeCharms(upgrades)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], gc.ErrorMatches, "boom")
+}
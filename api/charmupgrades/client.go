@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrades provides a client for listing the charm
+// upgrades available to the applications of a model, and for setting
+// their charm upgrade policies.
+package charmupgrades
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the CharmUpgrades API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the CharmUpgrades API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "CharmUpgrades")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// UpgradesAvailable returns the charm, upgrade policy and newer charm
+// revision, if any, of every application in the model.
+func (c *Client) UpgradesAvailable() ([]params.CharmUpgradeInfo, error) {
+	var result params.CharmUpgradeInfoResults
+	if err := c.facade.FacadeCall("UpgradesAvailable", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// SetUpgradePolicy sets the charm upgrade policy of the application.
+func (c *Client) SetUpgradePolicy(application string, policy params.CharmUpgradePolicy) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.SetCharmUpgradePolicies{
+		Args: []params.SetCharmUpgradePolicy{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("SetUpgradePolicies", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/charmupgrades"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestUpgradesAvailable(c *gc.C) {
+	caller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "CharmUpgrades")
+			c.Check(request, gc.Equals, "UpgradesAvailable")
+			c.Check(args, gc.IsNil)
+			*(result.(*params.CharmUpgradeInfoResults)) = params.CharmUpgradeInfoResults{
+				Results: []params.CharmUpgradeInfo{{
+					ApplicationTag: "application-mysql",
+					CharmURL:       "cs:trusty/mysql-3",
+					Available:      "cs:trusty/mysql-4",
+				}},
+			}
+			return nil
+		},
+	)
+	infos, err := charmupgrades.NewClient(caller).UpgradesAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []params.CharmUpgradeInfo{{
+		ApplicationTag: "application-mysql",
+		CharmURL:       "cs:trusty/mysql-3",
+		Available:      "cs:trusty/mysql-4",
+	}})
+}
+
+func (s *clientSuite) TestSetUpgradePolicy(c *gc.C) {
+	policy := params.CharmUpgradePolicy{Mode: "auto-in-window", Window: "02:00-04:00"}
+	caller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "CharmUpgrades")
+			c.Check(request, gc.Equals, "SetUpgradePolicies")
+			c.Check(args, jc.DeepEquals, params.SetCharmUpgradePolicies{
+				Args: []params.SetCharmUpgradePolicy{{
+					ApplicationTag: "application-mysql",
+					Policy:         policy,
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	)
+	err := charmupgrades.NewClient(caller).SetUpgradePolicy("mysql", policy)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestSetUpgradePolicyInvalidApplication(c *gc.C) {
+	caller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	)
+	err := charmupgrades.NewClient(caller).SetUpgradePolicy("no/good", params.CharmUpgradePolicy{})
+	c.Assert(err, gc.ErrorMatches, `application name "no/good" not valid`)
+}
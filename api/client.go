@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -281,12 +282,48 @@ func (c *Client) SetModelAgentVersion(version version.Number) error {
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// SetUpgradePlan plans the rollout of an upgrade of the model to the
+// given version: the canary machines are upgraded first, then the
+// remaining machines in batches of batchSize, pausing between batches.
+// The rollout starts once the model's agent version is set.
+func (c *Client) SetUpgradePlan(version version.Number, canaries []string, batchSize int, pause time.Duration) error {
+	args := params.UpgradePlanArgs{
+		Version:   version,
+		Canaries:  canaries,
+		BatchSize: batchSize,
+		Pause:     pause,
+	}
+	return c.facade.FacadeCall("SetUpgradePlan", args, nil)
+}
+
+// RemoveUpgradePlan removes the model's upgrade plan, releasing any
+// machines it still holds back to upgrade.
+func (c *Client) RemoveUpgradePlan() error {
+	return c.facade.FacadeCall("RemoveUpgradePlan", nil, nil)
+}
+
+// CheckUpgrade reports whether an upgrade of the model to the given
+// version could be expected to complete, and the upgrade steps it would
+// run, without changing anything.
+func (c *Client) CheckUpgrade(version version.Number) (params.UpgradeCheckResult, error) {
+	var result params.UpgradeCheckResult
+	args := params.SetModelAgentVersion{Version: version}
+	err := c.facade.FacadeCall("CheckUpgrade", args, &result)
+	return result, err
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
 	return c.facade.FacadeCall("AbortCurrentUpgrade", nil, nil)
 }
 
+// RollbackCurrentUpgrade rolls back the current upgrade after its
+// controller upgrade steps have failed.
+func (c *Client) RollbackCurrentUpgrade() error {
+	return c.facade.FacadeCall("RollbackCurrentUpgrade", nil, nil)
+}
+
 // FindTools returns a List containing all tools matching the specified parameters.
 func (c *Client) FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error) {
 	args := params.FindToolsParams{
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/httprequest"
@@ -481,6 +482,28 @@ func (s *clientSuite) TestSetEnvironAgentVersionDuringUpgrade(c *gc.C) {
 	c.Assert(params.IsCodeUpgradeInProgress(err), jc.IsTrue)
 }
 
+func (s *clientSuite) TestCheckUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "CheckUpgrade")
+			c.Assert(args, jc.DeepEquals, params.SetModelAgentVersion{
+				Version: version.MustParse("9.8.7"),
+			})
+			result := response.(*params.UpgradeCheckResult)
+			result.DownAgents = []string{"machine-1"}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := client.CheckUpgrade(version.MustParse("9.8.7"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.UpgradeCheckResult{
+		DownAgents: []string{"machine-1"},
+	})
+}
+
 func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	client := s.APIState.Client()
 	someErr := errors.New("random")
@@ -498,6 +521,47 @@ func (s *clientSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
 }
 
+func (s *clientSuite) TestRollbackCurrentUpgrade(c *gc.C) {
+	client := s.APIState.Client()
+	someErr := errors.New("random")
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			c.Assert(request, gc.Equals, "RollbackCurrentUpgrade")
+			c.Assert(args, gc.IsNil)
+			c.Assert(response, gc.IsNil)
+			return someErr
+		},
+	)
+	defer cleanup()
+
+	err := client.RollbackCurrentUpgrade()
+	c.Assert(err, gc.Equals, someErr) // Confirms that the correct facade was called
+}
+
+func (s *clientSuite) TestSetUpgradePlan(c *gc.C) {
+	client := s.APIState.Client()
+	var called bool
+	cleanup := api.PatchClientFacadeCall(client,
+		func(request string, args interface{}, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetUpgradePlan")
+			c.Assert(args, jc.DeepEquals, params.UpgradePlanArgs{
+				Version:   version.MustParse("2.1.0"),
+				Canaries:  []string{"3"},
+				BatchSize: 5,
+				Pause:     time.Hour,
+			})
+			c.Assert(response, gc.IsNil)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	err := client.SetUpgradePlan(version.MustParse("2.1.0"), []string{"3"}, 5, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *clientSuite) TestEnvironmentGet(c *gc.C) {
 	client := s.APIState.Client()
 	env, err := client.ModelGet()
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju/permission"
 )
 
 // Client provides methods that the Juju client command uses to interact
@@ -93,6 +94,80 @@ func (c *Client) RemoveBlocks() error {
 	return c.facade.FacadeCall("RemoveBlocks", args, nil)
 }
 
+// MongoHealth returns the replication state of the controller's
+// mongo replica set, along with oplog coverage and database sizes.
+func (c *Client) MongoHealth() (params.MongoHealthResult, error) {
+	var result params.MongoHealthResult
+	err := c.facade.FacadeCall("MongoHealth", nil, &result)
+	return result, errors.Trace(err)
+}
+
+// GrantController grants a user access to the controller.
+func (c *Client) GrantController(user, access string) error {
+	return c.modifyControllerUser(params.GrantControllerAccess, user, access)
+}
+
+// RevokeController revokes a user's access to the controller.
+func (c *Client) RevokeController(user, access string) error {
+	return c.modifyControllerUser(params.RevokeControllerAccess, user, access)
+}
+
+func (c *Client) modifyControllerUser(action params.ControllerAction, user, access string) error {
+	if !names.IsValidUser(user) {
+		return errors.Errorf("invalid username: %q", user)
+	}
+	if _, err := permission.ParseControllerAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: names.NewUserTag(user).String(),
+			Action:  action,
+			Access:  params.ControllerAccessPermission(access),
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyControllerAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// GrantControllerGroup grants the members of a user group access to
+// the controller.
+func (c *Client) GrantControllerGroup(group, access string) error {
+	return c.modifyControllerGroup(params.GrantControllerAccess, group, access)
+}
+
+// RevokeControllerGroup revokes a user group's access to the
+// controller.
+func (c *Client) RevokeControllerGroup(group, access string) error {
+	return c.modifyControllerGroup(params.RevokeControllerAccess, group, access)
+}
+
+func (c *Client) modifyControllerGroup(action params.ControllerAction, group, access string) error {
+	if !names.IsValidUser(group) {
+		return errors.Errorf("invalid group name: %q", group)
+	}
+	if _, err := permission.ParseControllerAccess(access); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			GroupName: group,
+			Action:    action,
+			Access:    params.ControllerAccessPermission(access),
+		}},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("ModifyControllerAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
 // WatchAllModels returns an AllWatcher, from which you can request
 // the Next collection of Deltas (for all models).
 func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
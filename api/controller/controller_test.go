@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/controller"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
 	"github.com/juju/juju/apiserver/params"
@@ -220,6 +221,99 @@ func (s *controllerSuite) TestInitiateModelMigrationError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestMongoHealth(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Controller")
+		c.Check(request, gc.Equals, "MongoHealth")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.MongoHealthResult)) = params.MongoHealthResult{
+			ReplicaSet: "juju",
+			Primary:    "10.0.0.1:37017",
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	result, err := client.MongoHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, jc.DeepEquals, params.MongoHealthResult{
+		ReplicaSet: "juju",
+		Primary:    "10.0.0.1:37017",
+	})
+}
+
+func (s *controllerSuite) TestGrantController(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Controller")
+		c.Check(request, gc.Equals, "ModifyControllerAccess")
+		c.Check(arg, jc.DeepEquals, params.ModifyControllerAccessRequest{
+			Changes: []params.ModifyControllerAccess{{
+				UserTag: "user-bob@local",
+				Action:  params.GrantControllerAccess,
+				Access:  params.ControllerAddModelAccess,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	err := client.GrantController("bob@local", "add-model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *controllerSuite) TestRevokeControllerError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(arg.(params.ModifyControllerAccessRequest).Changes[0].Action, gc.Equals, params.RevokeControllerAccess)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	err := client.RevokeController("bob", "superuser")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *controllerSuite) TestGrantControllerInvalidAccess(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	err := client.GrantController("bob", "write")
+	c.Assert(err, gc.ErrorMatches, `invalid controller access permission "write"`)
+}
+
+func (s *controllerSuite) TestGrantControllerGroup(c *gc.C) {
+	var called bool
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		called = true
+		c.Check(request, gc.Equals, "ModifyControllerAccess")
+		c.Check(arg, jc.DeepEquals, params.ModifyControllerAccessRequest{
+			Changes: []params.ModifyControllerAccess{{
+				GroupName: "admins",
+				Action:    params.GrantControllerAccess,
+				Access:    params.ControllerSuperuserAccess,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	err := client.GrantControllerGroup("admins", "superuser")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func randomUUID() string {
 	return utils.MustNewUUID().String()
 }
@@ -21,7 +21,10 @@ var facadeVersions = map[string]int{
 	"ApplicationScaler":            1,
 	"Backups":                      1,
 	"Block":                        2,
+	"CharmRepository":              1,
 	"CharmRevisionUpdater":         2,
+	"CharmUpgrader":                1,
+	"CharmUpgrades":                1,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
@@ -76,7 +79,7 @@ var facadeVersions = map[string]int{
 	"Storage":                      2,
 	"StorageProvisioner":           2,
 	"StringsWatcher":               1,
-	"Subnets":                      2,
+	"Subnets":                      3,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
 	"Uniter":                       4,
@@ -63,6 +63,26 @@ func (c *Client) EnableHA(
 	return result.Result, nil
 }
 
+// RemoveControllerMember removes a lost controller machine from the
+// controller, optionally providing a replacement for it.
+func (c *Client) RemoveControllerMember(member params.RemoveControllerMember) (params.ControllersChanges, error) {
+	var results params.ControllersChangeResults
+	arg := params.RemoveControllerMembers{
+		Members: []params.RemoveControllerMember{member},
+	}
+	if err := c.facade.FacadeCall("RemoveControllerMembers", arg, &results); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ControllersChanges{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ControllersChanges{}, result.Error
+	}
+	return result.Result, nil
+}
+
 // MongoUpgradeMode will make all Slave members of the HA
 // to shut down their mongo server.
 func (c *Client) MongoUpgradeMode(v mongo.Version) (params.MongoUpgradeResults, error) {
@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -79,3 +80,19 @@ func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
 	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
 }
+
+func (s *clientSuite) TestClientRemoveControllerMember(c *gc.C) {
+	assertEnableHA(c, &s.JujuConnSuite)
+
+	// Machine 2 never got a vote, so it is removed at once.
+	client := highavailability.NewClient(s.APIState)
+	result, err := client.RemoveControllerMember(params.RemoveControllerMember{
+		MachineTag: "machine-2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Removed, gc.DeepEquals, []string{"machine-2"})
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.MachineIds, jc.SameContents, []string{"0", "1"})
+}
@@ -190,3 +190,26 @@ func (s *accessSuite) TestInvalidResultCount(c *gc.C) {
 	err := client.GrantModel("bob", "write", someModelUUID, someModelUUID)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 0")
 }
+
+func (s *accessSuite) TestGrantModelGroup(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			checkCall(c, objType, id, request)
+
+			req := assertRequest(c, a)
+			c.Assert(req.Changes, jc.DeepEquals, []params.ModifyModelAccess{{
+				GroupName: "devs",
+				Action:    params.GrantModelAccess,
+				Access:    params.ModelWriteAccess,
+				ModelTag:  someModelTag,
+			}})
+
+			resp := assertResponse(c, result)
+			*resp = params.ErrorResults{Results: []params.ErrorResult{{Error: nil}}}
+
+			return nil
+		})
+	client := modelmanager.NewClient(apiCaller)
+	err := client.GrantModelGroup("devs", "write", someModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+}
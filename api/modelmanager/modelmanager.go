@@ -188,3 +188,48 @@ func (c *Client) modifyModelUser(action params.ModelAction, user, access string,
 	}
 	return result.Combine()
 }
+
+// GrantModelGroup grants the members of a user group access to the
+// specified models.
+func (c *Client) GrantModelGroup(group, access string, modelUUIDs ...string) error {
+	return c.modifyModelGroup(params.GrantModelAccess, group, access, modelUUIDs)
+}
+
+// RevokeModelGroup revokes a user group's access to the specified
+// models.
+func (c *Client) RevokeModelGroup(group, access string, modelUUIDs ...string) error {
+	return c.modifyModelGroup(params.RevokeModelAccess, group, access, modelUUIDs)
+}
+
+func (c *Client) modifyModelGroup(action params.ModelAction, group, access string, modelUUIDs []string) error {
+	var args params.ModifyModelAccessRequest
+
+	if !names.IsValidUser(group) {
+		return errors.Errorf("invalid group name: %q", group)
+	}
+	accessPermission, err := ParseModelAccess(access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, model := range modelUUIDs {
+		if !names.IsValidModel(model) {
+			return errors.Errorf("invalid model: %q", model)
+		}
+		args.Changes = append(args.Changes, params.ModifyModelAccess{
+			GroupName: group,
+			Action:    action,
+			Access:    accessPermission,
+			ModelTag:  names.NewModelTag(model).String(),
+		})
+	}
+
+	var result params.ErrorResults
+	err = c.facade.FacadeCall("ModifyModelAccess", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(result.Results))
+	}
+	return result.Combine()
+}
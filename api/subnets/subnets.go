@@ -91,3 +91,38 @@ func (api *API) ListSubnets(spaceTag *names.SpaceTag, zone string) ([]params.Sub
 	}
 	return response.Results, nil
 }
+
+// ReserveAddress reserves an IP address in the given subnet of a space,
+// for the given machine when not nil.
+func (api *API) ReserveAddress(address string, subnet names.SubnetTag, machine *names.MachineTag) error {
+	var response params.ErrorResults
+	var machineTag string
+	if machine != nil {
+		machineTag = machine.String()
+	}
+	args := params.ReserveAddressesParams{
+		Addresses: []params.ReserveAddressParams{{
+			Address:    address,
+			SubnetTag:  subnet.String(),
+			MachineTag: machineTag,
+		}},
+	}
+	err := api.facade.FacadeCall("ReserveAddresses", args, &response)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return response.OneError()
+}
+
+// ReleaseAddress releases a previously reserved IP address.
+func (api *API) ReleaseAddress(address string) error {
+	var response params.ErrorResults
+	args := params.ReleaseAddressesParams{
+		Addresses: []string{address},
+	}
+	err := api.facade.FacadeCall("ReleaseAddresses", args, &response)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return response.OneError()
+}
@@ -215,3 +215,42 @@ func (s *SubnetsSuite) TestListSubnetsFails(c *gc.C) {
 	var expectedResults []params.Subnet
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *SubnetsSuite) TestReserveAddress(c *gc.C) {
+	machine := names.NewMachineTag("0/lxd/1")
+	args := apitesting.CheckArgs{
+		Facade: "Subnets",
+		Method: "ReserveAddresses",
+		Args: params.ReserveAddressesParams{
+			Addresses: []params.ReserveAddressParams{{
+				Address:    "10.10.0.4",
+				SubnetTag:  "subnet-10.10.0.0/24",
+				MachineTag: "machine-0-lxd-1",
+			}},
+		},
+		Results: params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		},
+	}
+	s.prepareAPICall(c, &args, nil)
+	err := s.api.ReserveAddress("10.10.0.4", names.NewSubnetTag("10.10.0.0/24"), &machine)
+	c.Assert(s.called, gc.Equals, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SubnetsSuite) TestReleaseAddressFails(c *gc.C) {
+	args := apitesting.CheckArgs{
+		Facade: "Subnets",
+		Method: "ReleaseAddresses",
+		Args: params.ReleaseAddressesParams{
+			Addresses: []string{"10.10.0.4"},
+		},
+		Results: params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		},
+	}
+	s.prepareAPICall(c, &args, errors.New("bang"))
+	err := s.api.ReleaseAddress("10.10.0.4")
+	c.Assert(s.called, gc.Equals, 1)
+	c.Assert(err, gc.ErrorMatches, "bang")
+}
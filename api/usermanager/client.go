@@ -112,6 +112,13 @@ func (c *Client) EnableUser(username string) error {
 	return c.userCall(username, "EnableUser")
 }
 
+// UnlockUser allows a user locked out after too many failed logins to
+// log in again. If the user is not locked out, the action is considered
+// a success.
+func (c *Client) UnlockUser(username string) error {
+	return c.userCall(username, "UnlockUser")
+}
+
 // IncludeDisabled is a type alias to avoid bare true/false values
 // in calls to the client method.
 type IncludeDisabled bool
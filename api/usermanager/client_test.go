@@ -135,6 +135,19 @@ func (s *usermanagerSuite) TestEnableUserBadName(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
 }
 
+func (s *usermanagerSuite) TestUnlockUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar"})
+	err := user.RecordFailedLogin(1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.usermanager.UnlockUser(user.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.IsLockedOut(), jc.IsFalse)
+}
+
 func (s *usermanagerSuite) TestCantRemoveAdminUser(c *gc.C) {
 	err := s.usermanager.DisableUser(s.AdminUserTag(c).Name())
 	c.Assert(err, gc.ErrorMatches, "failed to disable user: cannot disable controller model owner")
@@ -148,10 +161,11 @@ func (s *usermanagerSuite) TestUserInfo(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	expected := []params.UserInfo{
 		{
-			Username:    "foobar",
-			DisplayName: "Foo Bar",
-			CreatedBy:   s.AdminUserTag(c).Name(),
-			DateCreated: user.DateCreated(),
+			Username:         "foobar",
+			DisplayName:      "Foo Bar",
+			CreatedBy:        s.AdminUserTag(c).Name(),
+			DateCreated:      user.DateCreated(),
+			ControllerAccess: params.ControllerLoginAccess,
 		},
 	}
 
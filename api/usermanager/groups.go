@@ -0,0 +1,74 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddUserGroup adds a user group with the given name.
+func (c *Client) AddUserGroup(name string) error {
+	return c.userGroupCall(name, "AddUserGroups")
+}
+
+// RemoveUserGroup removes the named user group, along with the access
+// granted to it.
+func (c *Client) RemoveUserGroup(name string) error {
+	return c.userGroupCall(name, "RemoveUserGroups")
+}
+
+func (c *Client) userGroupCall(name, methodCall string) error {
+	if !names.IsValidUser(name) {
+		return errors.Errorf("%q is not a valid group name", name)
+	}
+	var results params.ErrorResults
+	args := params.UserGroupNames{Names: []string{name}}
+	if err := c.facade.FacadeCall(methodCall, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AddUserGroupMembers adds users to the named user group.
+func (c *Client) AddUserGroupMembers(group string, usernames ...string) error {
+	return c.userGroupMembersCall(group, usernames, "AddUserGroupMembers")
+}
+
+// RemoveUserGroupMembers removes users from the named user group.
+func (c *Client) RemoveUserGroupMembers(group string, usernames ...string) error {
+	return c.userGroupMembersCall(group, usernames, "RemoveUserGroupMembers")
+}
+
+func (c *Client) userGroupMembersCall(group string, usernames []string, methodCall string) error {
+	var args params.UserGroupMembers
+	for _, username := range usernames {
+		if !names.IsValidUser(username) {
+			return errors.Errorf("%q is not a valid username", username)
+		}
+		args.Changes = append(args.Changes, params.UserGroupMember{
+			GroupName: group,
+			UserTag:   names.NewUserTag(username).String(),
+		})
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(methodCall, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(args.Changes) {
+		return errors.Errorf("expected %d results, got %d", len(args.Changes), len(results.Results))
+	}
+	return results.Combine()
+}
+
+// UserGroups returns information about all the user groups.
+func (c *Client) UserGroups() ([]params.UserGroupInfo, error) {
+	var result params.UserGroupsResult
+	if err := c.facade.FacadeCall("UserGroups", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Groups, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *usermanagerSuite) TestUserGroups(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+
+	err := s.usermanager.AddUserGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.usermanager.AddUserGroupMembers("devs", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	groups, err := s.usermanager.UserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(groups[0].Name, gc.Equals, "devs")
+	c.Assert(groups[0].Members, jc.DeepEquals, []string{"bob@local"})
+
+	err = s.usermanager.RemoveUserGroupMembers("devs", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.usermanager.RemoveUserGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	groups, err = s.usermanager.UserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 0)
+}
+
+func (s *usermanagerSuite) TestAddUserGroupInvalidName(c *gc.C) {
+	err := s.usermanager.AddUserGroup("not/valid")
+	c.Assert(err, gc.ErrorMatches, `"not/valid" is not a valid group name`)
+}
+
+func (s *usermanagerSuite) TestAddUserGroupMembersUnknownGroup(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	err := s.usermanager.AddUserGroupMembers("ops", "bob")
+	c.Assert(err, gc.ErrorMatches, `group "ops" not found`)
+}
@@ -0,0 +1,65 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// SSHKeys returns the public SSH keys of the named user, either as full
+// keys or as fingerprints.
+func (c *Client) SSHKeys(username string, mode ssh.ListMode) ([]string, error) {
+	if !names.IsValidUser(username) {
+		return nil, errors.Errorf("%q is not a valid username", username)
+	}
+	args := params.ListSSHKeys{
+		Entities: params.Entities{Entities: []params.Entity{{names.NewUserTag(username).String()}}},
+		Mode:     mode,
+	}
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("SSHKeys", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// AddSSHKeys adds public SSH keys to the named user. No key is added if
+// any of them is invalid or already belongs to the user.
+func (c *Client) AddSSHKeys(username string, keys ...string) error {
+	return c.sshKeysCall(username, keys, "AddSSHKeys")
+}
+
+// RemoveSSHKeys removes the public SSH keys, identified by fingerprint
+// or comment, from the named user.
+func (c *Client) RemoveSSHKeys(username string, keyIds ...string) error {
+	return c.sshKeysCall(username, keyIds, "RemoveSSHKeys")
+}
+
+func (c *Client) sshKeysCall(username string, keys []string, methodCall string) error {
+	if !names.IsValidUser(username) {
+		return errors.Errorf("%q is not a valid username", username)
+	}
+	args := params.UserSSHKeysArgs{
+		Changes: []params.UserSSHKeys{{
+			Tag:  names.NewUserTag(username).String(),
+			Keys: keys,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(methodCall, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
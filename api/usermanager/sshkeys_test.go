@@ -0,0 +1,35 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/ssh"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *usermanagerSuite) TestSSHKeys(c *gc.C) {
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	key := sshtesting.ValidKeyOne.Key + " bob@laptop"
+
+	err := s.usermanager.AddSSHKeys("bob", key)
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err := s.usermanager.SSHKeys("bob", ssh.FullKeys)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, []string{key})
+
+	err = s.usermanager.RemoveSSHKeys("bob", sshtesting.ValidKeyOne.Fingerprint)
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err = s.usermanager.SSHKeys("bob", ssh.Fingerprints)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, gc.HasLen, 0)
+}
+
+func (s *usermanagerSuite) TestSSHKeysInvalidUsername(c *gc.C) {
+	err := s.usermanager.AddSSHKeys("not!good", "key")
+	c.Assert(err, gc.ErrorMatches, `"not!good" is not a valid username`)
+}
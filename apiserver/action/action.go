@@ -22,6 +22,7 @@ type ActionAPI struct {
 	resources  *common.Resources
 	authorizer common.Authorizer
 	check      *common.BlockChecker
+	access     common.ApplicationAuthFunc
 }
 
 // NewActionAPI returns an initialized ActionAPI
@@ -35,6 +36,7 @@ func NewActionAPI(st *state.State, resources *common.Resources, authorizer commo
 		resources:  resources,
 		authorizer: authorizer,
 		check:      common.NewBlockChecker(st),
+		access:     common.NewApplicationAuthFunc(st, authorizer),
 	}, nil
 }
 
@@ -111,7 +113,8 @@ func (a *ActionAPI) FindActionsByNames(arg params.FindActionsByNames) (params.Ac
 // Enqueue takes a list of Actions and queues them up to be executed by
 // the designated ActionReceiver, returning the params.Action for each
 // enqueued Action, or an error if there was a problem enqueueing the
-// Action.
+// Action. Users with read only access to the model may only enqueue
+// Actions on units of applications they have operate access to.
 func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	if err := a.check.ChangeAllowed(); err != nil {
 		return params.ActionResults{}, errors.Trace(err)
@@ -126,6 +129,10 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		if err := a.access(receiver.Tag(), state.OperateAccess); err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
 		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
@@ -273,6 +273,28 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueApplicationAccess(c *gc.C) {
+	user := jujuFactory.NewFactory(s.State).MakeUser(c, &jujuFactory.UserParams{Access: state.ReadAccess})
+	err := s.State.SetApplicationAccess("wordpress", user.UserTag(), state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	api, err := action.NewActionAPI(s.State, nil, apiservertesting.FakeAuthorizer{Tag: user.UserTag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.machine0.Tag().String(), Name: "juju-run"},
+		},
+	}
+	res, err := api.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Assert(res.Results[2].Error, jc.Satisfies, params.IsCodeUnauthorized)
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -130,7 +130,19 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		// worker for the controller model.
 		agentPingerNeeded = false
 	}
+	if isUser && serverOnlyLogin {
+		// Logging in to the controller itself requires login access
+		// to it; logging in to a model requires access to the model,
+		// which is checked below.
+		userTag := entity.Tag().(names.UserTag)
+		if _, err := authentication.ResolveControllerAccess(a.root.state, userTag); err != nil {
+			return fail, errors.Annotatef(err, "missing controller access for user %s", userTag)
+		}
+	}
 	a.root.entity = entity
+	if isUser {
+		a.recordLogin(entity)
+	}
 
 	a.apiObserver.Login(entity.Tag().String())
 
@@ -145,18 +157,18 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 	}
 
 	var maybeUserInfo *params.AuthUserInfo
-	var modelUser *state.ModelUser
+	var modelAccess state.Access
 	// Send back user info if user
 	if isUser && !serverOnlyLogin {
 		maybeUserInfo = &params.AuthUserInfo{
 			Identity:       entity.Tag().String(),
 			LastConnection: lastConnection,
 		}
-		modelUser, err = a.root.state.ModelUser(entity.Tag().(names.UserTag))
+		modelAccess, err = authentication.ResolveModelAccess(a.root.state, entity.Tag().(names.UserTag))
 		if err != nil {
-			return fail, errors.Annotatef(err, "missing ModelUser for logged in user %s", entity.Tag())
+			return fail, errors.Annotatef(err, "missing model access for logged in user %s", entity.Tag())
 		}
-		maybeUserInfo.ReadOnly = modelUser.IsReadOnly()
+		maybeUserInfo.ReadOnly = modelAccess == state.ReadAccess
 		if maybeUserInfo.ReadOnly {
 			logger.Debugf("model user %s is READ ONLY", entity.Tag())
 		}
@@ -200,8 +212,8 @@ func (a *admin) doLogin(req params.LoginRequest, loginVersion int) (params.Login
 		loginResult.Facades = facades
 	}
 
-	if modelUser != nil {
-		authedApi = newClientAuthRoot(authedApi, modelUser)
+	if modelAccess != state.UndefinedAccess {
+		authedApi = newClientAuthRoot(authedApi, modelAccess)
 	}
 
 	a.root.rpcConn.ServeFinder(authedApi, serverError)
@@ -320,12 +332,18 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 	if !ok {
 		return f.st.FindEntity(tag)
 	}
+	u := &modelUserEntity{tag: utag}
 	modelUser, err := f.st.ModelUser(utag)
-	if err != nil {
+	if errors.IsNotFound(err) {
+		// The user may still have been granted access to the model
+		// through one of their groups.
+		if _, accessErr := f.st.EffectiveModelAccess(utag); accessErr != nil {
+			return nil, err
+		}
+	} else if err != nil {
 		return nil, err
-	}
-	u := &modelUserEntity{
-		modelUser: modelUser,
+	} else {
+		u.modelUser = modelUser
 	}
 	if utag.IsLocal() {
 		user, err := f.st.User(utag)
@@ -337,14 +355,21 @@ func (f modelUserEntityFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 	return u, nil
 }
 
-var _ loginEntity = &modelUserEntity{}
+var (
+	_ loginEntity                 = &modelUserEntity{}
+	_ authentication.LockableUser = &modelUserEntity{}
+	_ loginRecorder               = &modelUserEntity{}
+)
 
 // modelUserEntity encapsulates an model user
 // and, if the user is local, the local state user
 // as well. This enables us to implement FindEntity
 // in such a way that the authentication mechanisms
-// can work without knowing these details.
+// can work without knowing these details. The model
+// user is nil when the user's access to the model
+// is granted only through a group.
 type modelUserEntity struct {
+	tag       names.UserTag
 	modelUser *state.ModelUser
 	user      *state.User
 }
@@ -376,13 +401,19 @@ func (u *modelUserEntity) PasswordValid(pass string) bool {
 
 // Tag implements state.Entity.Tag.
 func (u *modelUserEntity) Tag() names.Tag {
-	return u.modelUser.UserTag()
+	return u.tag
 }
 
 // LastLogin implements loginEntity.LastLogin.
 func (u *modelUserEntity) LastLogin() (time.Time, error) {
 	// The last connection for the model takes precedence over
 	// the local user last login time.
+	if u.modelUser == nil {
+		if u.user != nil {
+			return u.user.LastLogin()
+		}
+		return time.Time{}, state.NeverLoggedInError(u.tag.Canonical())
+	}
 	t, err := u.modelUser.LastConnection()
 	if state.IsNeverConnectedError(err) {
 		if u.user != nil {
@@ -398,7 +429,10 @@ func (u *modelUserEntity) LastLogin() (time.Time, error) {
 
 // UpdateLastLogin implements loginEntity.UpdateLastLogin.
 func (u *modelUserEntity) UpdateLastLogin() error {
-	err := u.modelUser.UpdateLastConnection()
+	var err error
+	if u.modelUser != nil {
+		err = u.modelUser.UpdateLastConnection()
+	}
 	if u.user != nil {
 		err1 := u.user.UpdateLastLogin()
 		if err == nil {
@@ -408,6 +442,69 @@ func (u *modelUserEntity) UpdateLastLogin() error {
 	return err
 }
 
+// IsLockedOut implements authentication.LockableUser.IsLockedOut.
+func (u *modelUserEntity) IsLockedOut() bool {
+	if u.user == nil {
+		return false
+	}
+	return u.user.IsLockedOut()
+}
+
+// RecordFailedLogin implements authentication.LockableUser.RecordFailedLogin.
+func (u *modelUserEntity) RecordFailedLogin(maxAttempts int) error {
+	if u.user == nil {
+		return nil
+	}
+	return u.user.RecordFailedLogin(maxAttempts)
+}
+
+// ResetFailedLogins implements authentication.LockableUser.ResetFailedLogins.
+func (u *modelUserEntity) ResetFailedLogins() error {
+	if u.user == nil {
+		return nil
+	}
+	return u.user.ResetFailedLogins()
+}
+
+// PasswordExpired implements authentication.LockableUser.PasswordExpired.
+func (u *modelUserEntity) PasswordExpired(maxAge time.Duration) (bool, error) {
+	if u.user == nil {
+		return false, nil
+	}
+	return u.user.PasswordExpired(maxAge)
+}
+
+// RecordLogin implements loginRecorder.RecordLogin.
+func (u *modelUserEntity) RecordLogin(address string, historySize int) error {
+	if u.user == nil {
+		return nil
+	}
+	return u.user.RecordLogin(address, historySize)
+}
+
+// loginRecorder is implemented by local users, whose logins are
+// recorded in their login history.
+type loginRecorder interface {
+	RecordLogin(address string, historySize int) error
+}
+
+// recordLogin adds the login to the entity's login history, if it has
+// one. Failing to record the login does not fail the login itself.
+func (a *admin) recordLogin(entity state.Entity) {
+	recorder, ok := entity.(loginRecorder)
+	if !ok {
+		return
+	}
+	cfg, err := a.srv.state.ControllerConfig()
+	if err != nil {
+		logger.Warningf("cannot record login of %s: %v", entity.Tag(), err)
+		return
+	}
+	if err := recorder.RecordLogin(a.root.remoteAddr, cfg.LoginHistorySize()); err != nil {
+		logger.Warningf("cannot record login of %s: %v", entity.Tag(), err)
+	}
+}
+
 // presenceShim exists to represent a statepresence.Agent in a form
 // convenient to the apiserver/presence package, which exists to work
 // around the common.Resources infrastructure's lack of handling for
@@ -482,6 +482,27 @@ func (s *loginSuite) TestNonEnvironUserLoginFails(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestGroupModelUserLogin(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "dummy-password", NoModelUser: true})
+	group, err := s.State.AddUserGroup("devs", s.AdminUserTag(c).Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(user.UserTag()), jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("devs", state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info.Password = "dummy-password"
+	info.Tag = user.UserTag()
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	// Access granted through the group is read only.
+	err = st.APICall("Client", 1, "", "ModelSet", params.ModelSet{}, nil)
+	c.Assert(errors.Cause(err), gc.ErrorMatches, "permission denied")
+}
+
 func (s *loginSuite) TestLoginValidationSuccess(c *gc.C) {
 	validator := func(params.LoginRequest) error {
 		return nil
@@ -626,6 +647,29 @@ func (s *loginSuite) TestControllerModel(c *gc.C) {
 	s.assertRemoteEnvironment(c, st, s.State.ModelTag())
 }
 
+func (s *loginSuite) TestControllerLoginRequiresLoginAccess(c *gc.C) {
+	info, cleanup := s.setupServerWithValidator(c, nil)
+	defer cleanup()
+	info.ModelTag = names.ModelTag{}
+
+	password := "password"
+	u := s.Factory.MakeUser(c, &factory.UserParams{Password: password})
+	st := s.openAPIWithoutLogin(c, info)
+	err := st.Login(u.Tag(), password, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	st.Close()
+
+	err = s.State.RemoveControllerAccess(u.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	st = s.openAPIWithoutLogin(c, info)
+	defer st.Close()
+	err = st.Login(u.Tag(), password, "", nil)
+	c.Assert(errors.Cause(err), gc.DeepEquals, &rpc.RequestError{
+		Message: "missing controller access for user " + u.Tag().String() + ": permission denied",
+		Code:    "unauthorized access",
+	})
+}
+
 func (s *loginSuite) TestControllerModelBadCreds(c *gc.C) {
 	info, cleanup := s.setupServerWithValidator(c, nil)
 	defer cleanup()
@@ -18,8 +18,11 @@ import (
 	_ "github.com/juju/juju/apiserver/applicationscaler"
 	_ "github.com/juju/juju/apiserver/backups"
 	_ "github.com/juju/juju/apiserver/block"
+	_ "github.com/juju/juju/apiserver/charmrepository"
 	_ "github.com/juju/juju/apiserver/charmrevisionupdater"
 	_ "github.com/juju/juju/apiserver/charms"
+	_ "github.com/juju/juju/apiserver/charmupgrader"
+	_ "github.com/juju/juju/apiserver/charmupgrades"
 	_ "github.com/juju/juju/apiserver/cleaner"
 	_ "github.com/juju/juju/apiserver/client"
 	_ "github.com/juju/juju/apiserver/cloud"
@@ -375,6 +375,9 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/gui-version", &guiVersionHandler{
 		ctxt: httpCtxt,
 	})
+	add("/charm-repository", &charmRepositoryHandler{
+		ctxt: httpCtxt,
+	})
 
 	// For backwards compatibility we register all the old paths
 	add("/log", debugLogHandler)
@@ -508,7 +511,7 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserv
 
 	conn := rpc.NewConn(codec, apiObserver)
 
-	h, err := srv.newAPIHandler(conn, modelUUID)
+	h, err := srv.newAPIHandler(conn, modelUUID, wsConn.Request().RemoteAddr)
 	if err != nil {
 		conn.ServeFinder(&errRoot{err}, serverError)
 	} else {
@@ -526,7 +529,7 @@ func (srv *Server) serveConn(wsConn *websocket.Conn, modelUUID string, apiObserv
 	return conn.Close()
 }
 
-func (srv *Server) newAPIHandler(conn *rpc.Conn, modelUUID string) (*apiHandler, error) {
+func (srv *Server) newAPIHandler(conn *rpc.Conn, modelUUID, remoteAddr string) (*apiHandler, error) {
 	// Note that we don't overwrite modelUUID here because
 	// newAPIHandler treats an empty modelUUID as signifying
 	// the API version used.
@@ -541,7 +544,7 @@ func (srv *Server) newAPIHandler(conn *rpc.Conn, modelUUID string) (*apiHandler,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return newApiHandler(srv, st, conn, modelUUID)
+	return newApiHandler(srv, st, conn, modelUUID, remoteAddr)
 }
 
 func (srv *Server) mongoPinger() error {
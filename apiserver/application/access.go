@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModifyApplicationAccess changes the access model users have been
+// granted on applications. Only model and controller administrators
+// may change application access.
+func (api *API) ModifyApplicationAccess(args params.ModifyApplicationAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	if len(args.Changes) == 0 {
+		return result, nil
+	}
+	if err := api.checkCanModifyAccess(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Changes {
+		access, err := fromApplicationAccessParam(arg.Access)
+		if err != nil {
+			err = errors.Annotate(err, "could not modify application access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		targetUserTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			err = errors.Annotate(err, "could not modify application access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		applicationTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			err = errors.Annotate(err, "could not modify application access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(
+			changeApplicationAccess(api.state, applicationTag.Id(), targetUserTag, arg.Action, access))
+	}
+	return result, nil
+}
+
+// checkCanModifyAccess returns ErrPerm unless the authenticated user is
+// an administrator of the model or of the controller.
+func (api *API) checkCanModifyAccess() error {
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	isAdmin, err := api.state.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	modelUser, err := api.state.ModelUser(user)
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if !modelUser.IsAdmin() {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// changeApplicationAccess performs the requested access grant or revoke
+// action for the specified user on the named application.
+func changeApplicationAccess(st *state.State, application string, targetUserTag names.UserTag, action params.ApplicationAction, access state.Access) error {
+	current, err := st.ApplicationAccess(application, targetUserTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up application access for user")
+	}
+	hasAccess := err == nil
+
+	switch action {
+	case params.GrantApplicationAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.Includes(access) {
+			return errors.Errorf("user already has %q access or greater", access)
+		}
+		err := st.SetApplicationAccess(application, targetUserTag, access)
+		return errors.Annotate(err, "could not grant application access")
+
+	case params.RevokeApplicationAccess:
+		if !hasAccess {
+			return errors.NotFoundf("access to application %q for %q", application, targetUserTag.Canonical())
+		}
+		switch access {
+		case state.OperateAccess:
+			// Revoking operate access removes all access.
+			err := st.RemoveApplicationAccess(application, targetUserTag)
+			return errors.Annotate(err, "could not revoke application access")
+		case state.ManageAccess:
+			// Revoking manage access leaves operate access.
+			err := st.SetApplicationAccess(application, targetUserTag, state.OperateAccess)
+			return errors.Annotate(err, "could not set application access to operate")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
+// fromApplicationAccessParam returns the state application access type
+// from the API wireformat type.
+func fromApplicationAccessParam(paramAccess params.ApplicationAccessPermission) (state.Access, error) {
+	switch paramAccess {
+	case params.ApplicationOperateAccess:
+		return state.OperateAccess, nil
+	case params.ApplicationManageAccess:
+		return state.ManageAccess, nil
+	}
+	return state.UndefinedAccess, errors.Errorf("invalid application access permission %q", paramAccess)
+}
@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type applicationAccessSuite struct {
+	jujutesting.JujuConnSuite
+
+	application *state.Application
+	user        names.UserTag
+	userAPI     *application.API
+	adminAPI    *application.API
+}
+
+var _ = gc.Suite(&applicationAccessSuite{})
+
+func (s *applicationAccessSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.application = s.Factory.MakeApplication(c, nil)
+	s.user = s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", Access: state.ReadAccess}).UserTag()
+
+	var err error
+	s.userAPI, err = application.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{Tag: s.user})
+	c.Assert(err, jc.ErrorIsNil)
+	s.adminAPI, err = application.NewAPI(s.State, nil, apiservertesting.FakeAuthorizer{Tag: s.AdminUserTag(c)})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationAccessSuite) modify(c *gc.C, api *application.API, action params.ApplicationAction, access params.ApplicationAccessPermission) error {
+	results, err := api.ModifyApplicationAccess(params.ModifyApplicationAccessRequest{
+		Changes: []params.ModifyApplicationAccess{{
+			UserTag:        s.user.String(),
+			Action:         action,
+			Access:         access,
+			ApplicationTag: s.application.Tag().String(),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	c.Assert(results.Results, gc.HasLen, 1)
+	if results.Results[0].Error != nil {
+		return results.Results[0].Error
+	}
+	return nil
+}
+
+func (s *applicationAccessSuite) TestReadOnlyUserDenied(c *gc.C) {
+	err := s.userAPI.Set(params.ApplicationSet{ApplicationName: "mysql"})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	err = s.userAPI.Expose(params.ApplicationExpose{ApplicationName: "mysql"})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationAccessSuite) TestOperateAccess(c *gc.C) {
+	err := s.modify(c, s.adminAPI, params.GrantApplicationAccess, params.ApplicationOperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.userAPI.Unset(params.ApplicationUnset{ApplicationName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.userAPI.Expose(params.ApplicationExpose{ApplicationName: "mysql"})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationAccessSuite) TestManageAccess(c *gc.C) {
+	err := s.modify(c, s.adminAPI, params.GrantApplicationAccess, params.ApplicationManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.userAPI.Unset(params.ApplicationUnset{ApplicationName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.userAPI.Expose(params.ApplicationExpose{ApplicationName: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.IsExposed(), jc.IsTrue)
+}
+
+func (s *applicationAccessSuite) TestGrantAlreadyGranted(c *gc.C) {
+	err := s.modify(c, s.adminAPI, params.GrantApplicationAccess, params.ApplicationManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modify(c, s.adminAPI, params.GrantApplicationAccess, params.ApplicationOperateAccess)
+	c.Assert(err, gc.ErrorMatches, `user already has "operate" access or greater`)
+}
+
+func (s *applicationAccessSuite) TestRevokeManageLeavesOperate(c *gc.C) {
+	err := s.modify(c, s.adminAPI, params.GrantApplicationAccess, params.ApplicationManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.modify(c, s.adminAPI, params.RevokeApplicationAccess, params.ApplicationManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.ApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.OperateAccess)
+
+	err = s.modify(c, s.adminAPI, params.RevokeApplicationAccess, params.ApplicationOperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationAccess("mysql", s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *applicationAccessSuite) TestNonAdminCannotModifyAccess(c *gc.C) {
+	err := s.modify(c, s.userAPI, params.GrantApplicationAccess, params.ApplicationManageAccess)
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
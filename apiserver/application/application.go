@@ -11,6 +11,7 @@ import (
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
 	goyaml "gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -34,6 +35,7 @@ func init() {
 // Application defines the methods on the application API end point.
 type Application interface {
 	SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error)
+	ModifyApplicationAccess(args params.ModifyApplicationAccessRequest) (params.ErrorResults, error)
 }
 
 // API implements the application interface and is the concrete
@@ -42,6 +44,7 @@ type API struct {
 	check      *common.BlockChecker
 	state      *state.State
 	authorizer common.Authorizer
+	access     common.ApplicationAuthFunc
 }
 
 // NewAPI returns a new application API facade.
@@ -58,9 +61,20 @@ func NewAPI(
 		state:      st,
 		authorizer: authorizer,
 		check:      common.NewBlockChecker(st),
+		access:     common.NewApplicationAuthFunc(st, authorizer),
 	}, nil
 }
 
+// checkAccess returns an error unless the authenticated user has the
+// given access to the named application. Invalid names are left for
+// the application lookup to report.
+func (api *API) checkAccess(application string, access state.Access) error {
+	if !names.IsValidApplication(application) {
+		return nil
+	}
+	return api.access(names.NewApplicationTag(application), access)
+}
+
 // SetMetricCredentials sets credentials on the application.
 func (api *API) SetMetricCredentials(args params.ApplicationMetricCredentials) (params.ErrorResults, error) {
 	result := params.ErrorResults{
@@ -219,6 +233,9 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -263,6 +280,9 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 			return errors.Trace(err)
 		}
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
 	application, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -366,6 +386,9 @@ func (api *API) Set(p params.ApplicationSet) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkAccess(p.ApplicationName, state.OperateAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(p.ApplicationName)
 	if err != nil {
 		return err
@@ -389,6 +412,9 @@ func (api *API) Unset(p params.ApplicationUnset) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkAccess(p.ApplicationName, state.OperateAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(p.ApplicationName)
 	if err != nil {
 		return err
@@ -424,6 +450,9 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return err
@@ -437,6 +466,9 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return err
@@ -461,6 +493,9 @@ func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplication
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
 	units, err := addApplicationUnits(api.state, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, err
@@ -479,6 +514,13 @@ func (api *API) DestroyUnits(args params.DestroyApplicationUnits) error {
 	}
 	var errs []string
 	for _, name := range args.UnitNames {
+		if names.IsValidUnit(name) {
+			err := api.access(names.NewUnitTag(name), state.ManageAccess)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+		}
 		unit, err := api.state.Unit(name)
 		switch {
 		case errors.IsNotFound(err):
@@ -503,6 +545,9 @@ func (api *API) Destroy(args params.ApplicationDestroy) error {
 	if err := api.check.RemoveAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return err
@@ -525,6 +570,9 @@ func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
+	if err := api.checkAccess(args.ApplicationName, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
 	svc, err := api.state.Application(args.ApplicationName)
 	if err != nil {
 		return err
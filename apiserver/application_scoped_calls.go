@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/utils/set"
+)
+
+// applicationScopedCalls specify the API calls that a read only user
+// may make if they have been granted access to individual applications.
+// The facades implementing them are responsible for checking that access
+// on each application they act upon; adding a charm acts on no
+// application, and needs manage access on any of them. The format of
+// the calls is "<facade>.<method>".
+var applicationScopedCalls = set.NewStrings(
+	// Calls requiring operate access.
+	"Action.Enqueue",
+	"Action.Run",
+	"Application.Set",
+	"Application.Unset",
+	// Calls requiring manage access.
+	"Application.AddUnits",
+	"Application.Destroy",
+	"Application.DestroyUnits",
+	"Application.Expose",
+	"Application.SetCharm",
+	"Application.SetConstraints",
+	"Application.Unexpose",
+	"Application.Update",
+	"CharmUpgrades.SetUpgradePolicies",
+	"Client.AddCharm",
+	"Client.AddCharmWithAuthorization",
+	"Resources.AddPendingResources",
+	"Resources.RollbackResources",
+	"Resources.SetResourcesFromURL",
+)
+
+// isCallApplicationScoped returns whether or not the method on the facade
+// checks application-scoped access itself.
+func isCallApplicationScoped(facade, method string) bool {
+	return applicationScopedCalls.Contains(facade + "." + method)
+}
@@ -51,6 +51,7 @@ func newAuthContext(st *state.State) (*authContext, error) {
 	ctxt.userAuth.Service = &expirableStorageBakeryService{bakeryService, key, store, nil}
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	ctxt.userAuth.Clock = state.GetClock()
+	ctxt.userAuth.ControllerConfig = st
 	return ctxt, nil
 }
 
@@ -132,7 +133,7 @@ func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonA
 	// TODO(axw) we should store the key in mongo, so that multiple servers
 	// can authenticate. That will require that we encode the server's ID
 	// in the macaroon ID so that servers don't overwrite each others' keys.
-	svc, _, err := newBakeryService(st, nil, bakery.PublicKeyLocatorMap{idURL: idPK})
+	svc, key, err := newBakeryService(st, nil, bakery.PublicKeyLocatorMap{idURL: idPK})
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot make bakery service")
 	}
@@ -143,6 +144,8 @@ func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonA
 		return nil, errors.Annotate(err, "cannot make macaroon")
 	}
 	auth.IdentityLocation = idURL
+	auth.Groups = authentication.NewIdentityGroupFetcher(idURL, key)
+	auth.GroupRecorder = st
 	return &auth, nil
 }
 
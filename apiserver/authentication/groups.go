@@ -0,0 +1,126 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// ExternalGroupFetcher retrieves the groups an external user belongs to
+// from the identity provider.
+type ExternalGroupFetcher interface {
+	// UserGroups returns the names of the groups the given user
+	// belongs to, without a domain.
+	UserGroups(user names.UserTag) ([]string, error)
+}
+
+// UserGroupRecorder records the groups an external user belongs to.
+type UserGroupRecorder interface {
+	SetExternalUserGroups(user names.UserTag, groups []string) error
+}
+
+// IdentityRequestTimeout is the time allowed for a request to the
+// identity manager to complete.
+const IdentityRequestTimeout = 10 * time.Second
+
+// IdentityGroupFetcher implements ExternalGroupFetcher by querying the
+// identity manager at the configured identity-url.
+type IdentityGroupFetcher struct {
+	// URL holds the location of the identity manager.
+	URL string
+
+	// Client is used to make requests to the identity manager,
+	// acquiring any discharges the identity manager requires.
+	Client *httpbakery.Client
+}
+
+// NewIdentityGroupFetcher returns an IdentityGroupFetcher that queries
+// the identity manager at the given URL, authenticating with the given
+// key, and gives up on requests that take longer than
+// IdentityRequestTimeout.
+func NewIdentityGroupFetcher(url string, key *bakery.KeyPair) *IdentityGroupFetcher {
+	client := httpbakery.NewClient()
+	client.Client.Timeout = IdentityRequestTimeout
+	client.Key = key
+	return &IdentityGroupFetcher{
+		URL:    url,
+		Client: client,
+	}
+}
+
+// UserGroups implements ExternalGroupFetcher.
+func (f *IdentityGroupFetcher) UserGroups(user names.UserTag) ([]string, error) {
+	u := fmt.Sprintf("%s/v1/u/%s/groups", strings.TrimRight(f.URL, "/"), url.QueryEscape(user.Name()))
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := f.Client.Do(req)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get groups for %q", user.Canonical())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("cannot get groups for %q: %s", user.Canonical(), resp.Status)
+	}
+	var groups []string
+	if err := json.NewDecoder(resp.Body).Decode(&groups); err != nil {
+		return nil, errors.Annotatef(err, "cannot decode groups for %q", user.Canonical())
+	}
+	return groups, nil
+}
+
+// ModelAccessResolver returns the greatest level of access a user has
+// on a model, taking into account the groups the user belongs to.
+// *state.State implements ModelAccessResolver.
+type ModelAccessResolver interface {
+	EffectiveModelAccess(user names.UserTag) (state.Access, error)
+}
+
+// ResolveModelAccess returns the effective access the given user has on
+// the model, across the user's own grant and those of all the user's
+// groups. common.ErrPerm is returned if the user has no access at all.
+func ResolveModelAccess(resolver ModelAccessResolver, user names.UserTag) (state.Access, error) {
+	access, err := resolver.EffectiveModelAccess(user)
+	if errors.IsNotFound(err) {
+		return state.UndefinedAccess, errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return state.UndefinedAccess, errors.Trace(err)
+	}
+	return access, nil
+}
+
+// ControllerAccessResolver returns the greatest level of access a user
+// has on the controller, taking into account the groups the user
+// belongs to. *state.State implements ControllerAccessResolver.
+type ControllerAccessResolver interface {
+	EffectiveControllerAccess(user names.UserTag) (state.Access, error)
+}
+
+// ResolveControllerAccess returns the effective access the given user
+// has on the controller, across the user's own grant and those of all
+// the user's groups. common.ErrPerm is returned if the user has no
+// access at all, which means the user may not log in to the controller.
+func ResolveControllerAccess(resolver ControllerAccessResolver, user names.UserTag) (state.Access, error) {
+	access, err := resolver.EffectiveControllerAccess(user)
+	if errors.IsNotFound(err) {
+		return state.UndefinedAccess, errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return state.UndefinedAccess, errors.Trace(err)
+	}
+	return access, nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon-bakery.v1/bakery"
+	"gopkg.in/macaroon-bakery.v1/bakerytest"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type groupsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&groupsSuite{})
+
+func (s *groupsSuite) TestIdentityGroupFetcher(c *gc.C) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		path = req.URL.Path
+		fmt.Fprint(w, `["devs", "ops"]`)
+	}))
+	defer srv.Close()
+
+	fetcher := authentication.NewIdentityGroupFetcher(srv.URL+"/", nil)
+	c.Assert(fetcher.Client.Client.Timeout, gc.Equals, authentication.IdentityRequestTimeout)
+	groups, err := fetcher.UserGroups(names.NewUserTag("bob@external"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, "/v1/u/bob/groups")
+	c.Assert(groups, jc.DeepEquals, []string{"devs", "ops"})
+}
+
+func (s *groupsSuite) TestIdentityGroupFetcherError(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "no such user", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	fetcher := authentication.NewIdentityGroupFetcher(srv.URL, nil)
+	_, err := fetcher.UserGroups(names.NewUserTag("bob@external"))
+	c.Assert(err, gc.ErrorMatches, `cannot get groups for "bob@external": 404 Not Found`)
+}
+
+func (s *groupsSuite) TestResolveModelAccess(c *gc.C) {
+	bob := names.NewUserTag("bob@external")
+	access, err := authentication.ResolveModelAccess(fakeAccessResolver{access: state.WriteAccess}, bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.WriteAccess)
+
+	_, err = authentication.ResolveModelAccess(fakeAccessResolver{err: errors.NotFoundf("access")}, bob)
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	_, err = authentication.ResolveModelAccess(fakeAccessResolver{err: errors.New("boom")}, bob)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *macaroonAuthenticatorSuite) TestMacaroonAuthenticationRecordsGroups(c *gc.C) {
+	discharger := bakerytest.NewDischarger(nil, s.Checker)
+	defer discharger.Close()
+	s.username = "bobbrown@somewhere"
+
+	svc, err := bakery.NewService(bakery.NewServiceParams{
+		Locator: discharger,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mac, err := svc.NewMacaroon("", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	recorder := &fakeGroupRecorder{}
+	fetcher := &fakeGroupFetcher{groups: []string{"devs"}}
+	clock := coretesting.NewClock(time.Now())
+	authenticator := &authentication.ExternalMacaroonAuthenticator{
+		Service:          svc,
+		IdentityLocation: discharger.Location(),
+		Macaroon:         mac,
+		Groups:           fetcher,
+		GroupRecorder:    recorder,
+		Clock:            clock,
+	}
+	finder := simpleEntityFinder{"user-bobbrown@somewhere": true}
+
+	_, err = authenticator.Authenticate(finder, nil, params.LoginRequest{})
+	dischargeErr := errors.Cause(err).(*common.DischargeRequiredError)
+	ms, err := httpbakery.NewClient().DischargeAll(dischargeErr.Macaroon)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = authenticator.Authenticate(finder, nil, params.LoginRequest{
+		Macaroons: []macaroon.Slice{ms},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorder.user, gc.Equals, names.NewUserTag("bobbrown@somewhere"))
+	c.Assert(recorder.groups, jc.DeepEquals, []string{"devs"})
+	c.Assert(fetcher.calls, gc.Equals, 1)
+
+	// The groups are not retrieved again until they are due to be
+	// refreshed.
+	login := params.LoginRequest{Macaroons: []macaroon.Slice{ms}}
+	_, err = authenticator.Authenticate(finder, nil, login)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetcher.calls, gc.Equals, 1)
+
+	clock.Advance(time.Hour)
+	_, err = authenticator.Authenticate(finder, nil, login)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fetcher.calls, gc.Equals, 2)
+}
+
+func (s *macaroonAuthenticatorSuite) TestMacaroonAuthenticationGroupsFailureRemovesGroups(c *gc.C) {
+	discharger := bakerytest.NewDischarger(nil, s.Checker)
+	defer discharger.Close()
+	s.username = "bobbrown@somewhere"
+
+	svc, err := bakery.NewService(bakery.NewServiceParams{
+		Locator: discharger,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	mac, err := svc.NewMacaroon("", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	recorder := &fakeGroupRecorder{}
+	fetcher := &fakeGroupFetcher{groups: []string{"devs"}}
+	clock := coretesting.NewClock(time.Now())
+	authenticator := &authentication.ExternalMacaroonAuthenticator{
+		Service:          svc,
+		IdentityLocation: discharger.Location(),
+		Macaroon:         mac,
+		Groups:           fetcher,
+		GroupRecorder:    recorder,
+		Clock:            clock,
+	}
+	finder := simpleEntityFinder{"user-bobbrown@somewhere": true}
+
+	_, err = authenticator.Authenticate(finder, nil, params.LoginRequest{})
+	dischargeErr := errors.Cause(err).(*common.DischargeRequiredError)
+	ms, err := httpbakery.NewClient().DischargeAll(dischargeErr.Macaroon)
+	c.Assert(err, jc.ErrorIsNil)
+	login := params.LoginRequest{Macaroons: []macaroon.Slice{ms}}
+	_, err = authenticator.Authenticate(finder, nil, login)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorder.groups, jc.DeepEquals, []string{"devs"})
+
+	// When the groups cannot be retrieved, the user keeps no group
+	// access, and the groups are retrieved again at the next login.
+	fetcher.err = errors.New("identity manager unavailable")
+	clock.Advance(time.Hour)
+	_, err = authenticator.Authenticate(finder, nil, login)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorder.groups, gc.HasLen, 0)
+	c.Assert(fetcher.calls, gc.Equals, 2)
+
+	fetcher.err = nil
+	_, err = authenticator.Authenticate(finder, nil, login)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorder.groups, jc.DeepEquals, []string{"devs"})
+	c.Assert(fetcher.calls, gc.Equals, 3)
+}
+
+type fakeAccessResolver struct {
+	access state.Access
+	err    error
+}
+
+func (r fakeAccessResolver) EffectiveModelAccess(names.UserTag) (state.Access, error) {
+	return r.access, r.err
+}
+
+type fakeGroupFetcher struct {
+	groups []string
+	err    error
+	calls  int
+}
+
+func (f *fakeGroupFetcher) UserGroups(names.UserTag) ([]string, error) {
+	f.calls++
+	return f.groups, f.err
+}
+
+type fakeGroupRecorder struct {
+	user   names.UserTag
+	groups []string
+}
+
+func (r *fakeGroupRecorder) SetExternalUserGroups(user names.UserTag, groups []string) error {
+	r.user = user
+	r.groups = groups
+	return nil
+}
@@ -4,6 +4,7 @@
 package authentication
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -30,6 +32,25 @@ type UserAuthenticator struct {
 
 	// Clock is used to calculate the expiry time for macaroons.
 	Clock clock.Clock
+
+	// ControllerConfig, if not nil, supplies the lockout and password
+	// expiry settings applied to local users logging in with a
+	// password.
+	ControllerConfig ControllerConfigGetter
+}
+
+// ControllerConfigGetter provides the controller configuration.
+type ControllerConfigGetter interface {
+	ControllerConfig() (controller.Config, error)
+}
+
+// LockableUser is implemented by local user entities whose password
+// logins are subject to lockout and password expiry.
+type LockableUser interface {
+	IsLockedOut() bool
+	RecordFailedLogin(maxAttempts int) error
+	ResetFailedLogins() error
+	PasswordExpired(maxAge time.Duration) (bool, error)
 }
 
 const (
@@ -59,10 +80,59 @@ func (u *UserAuthenticator) Authenticate(
 	if !ok {
 		return nil, errors.Errorf("invalid request")
 	}
-	if req.Credentials == "" && userTag.IsLocal() {
+	if !userTag.IsLocal() {
+		return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
+	}
+	if req.Credentials == "" {
 		return u.authenticateMacaroons(entityFinder, userTag, req)
 	}
-	return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
+	return u.authenticatePassword(entityFinder, userTag, req)
+}
+
+// authenticatePassword checks the password of a local user. Users
+// are locked out after too many consecutive failures, and may not log
+// in once their password has expired.
+func (u *UserAuthenticator) authenticatePassword(
+	entityFinder EntityFinder, tag names.UserTag, req params.LoginRequest,
+) (state.Entity, error) {
+	entity, err := entityFinder.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	user, ok := entity.(LockableUser)
+	if !ok || u.ControllerConfig == nil {
+		return u.AgentAuthenticator.Authenticate(entityFinder, tag, req)
+	}
+	cfg, err := u.ControllerConfig.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if user.IsLockedOut() {
+		return nil, errors.Trace(common.ErrUserLockedOut)
+	}
+	authenticator, ok := entity.(taggedAuthenticator)
+	if !ok {
+		return nil, errors.Trace(common.ErrBadRequest)
+	}
+	if !authenticator.PasswordValid(req.Credentials) {
+		if err := user.RecordFailedLogin(cfg.LoginLockoutAttempts()); err != nil {
+			logger.Warningf("cannot record failed login of %s: %v", tag, err)
+		}
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if err := user.ResetFailedLogins(); err != nil {
+		logger.Warningf("cannot reset failed logins of %s: %v", tag, err)
+	}
+	expired, err := user.PasswordExpired(cfg.PasswordMaxAge())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if expired {
+		return nil, errors.Trace(common.ErrPasswordExpired)
+	}
+	return entity, nil
 }
 
 // CreateLocalLoginMacaroon creates a time-limited macaroon for a local user
@@ -120,6 +190,11 @@ func (u *UserAuthenticator) authenticateMacaroons(
 	} else if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// Macaroons issued before the user was locked out are no
+	// longer accepted.
+	if user, ok := entity.(LockableUser); ok && user.IsLockedOut() {
+		return nil, errors.Trace(common.ErrUserLockedOut)
+	}
 	return entity, nil
 }
 
@@ -176,8 +251,28 @@ type ExternalMacaroonAuthenticator struct {
 	// that is used to address the is-authenticated-user
 	// third party caveat to.
 	IdentityLocation string
+
+	// Groups, if not nil, is used to retrieve the groups an
+	// authenticated user belongs to from the identity provider.
+	Groups ExternalGroupFetcher
+
+	// GroupRecorder records the groups retrieved with Groups, so
+	// that access granted to those groups applies to the user.
+	GroupRecorder UserGroupRecorder
+
+	// Clock is used to decide when the groups recorded for a user
+	// are refreshed. If it is nil, the wall clock is used.
+	Clock clock.Clock
+
+	groupsMu       sync.Mutex
+	groupsRecorded map[string]time.Time
 }
 
+// groupsRefreshInterval is how long the groups recorded for an external
+// user are trusted before they are retrieved again at login, so that
+// the identity manager is not queried on every login.
+const groupsRefreshInterval = 10 * time.Minute
+
 var _ EntityAuthenticator = (*ExternalMacaroonAuthenticator)(nil)
 
 func (m *ExternalMacaroonAuthenticator) newDischargeRequiredError(cause error) error {
@@ -237,6 +332,7 @@ func (m *ExternalMacaroonAuthenticator) Authenticate(entityFinder EntityFinder,
 			return nil, errors.Errorf("external identity provider has provided ostensibly local name %q", username)
 		}
 	}
+	m.recordGroups(tag)
 	entity, err := entityFinder.FindEntity(tag)
 	if errors.IsNotFound(err) {
 		return nil, errors.Trace(common.ErrBadCreds)
@@ -246,6 +342,46 @@ func (m *ExternalMacaroonAuthenticator) Authenticate(entityFinder EntityFinder,
 	return entity, nil
 }
 
+// recordGroups records the groups the identity provider reports the
+// user as belonging to, unless they were recorded recently. Failure is
+// not fatal, but the user is removed from all groups, so that access
+// granted to groups they may have since left is not kept; the groups
+// are retrieved again at their next login.
+func (m *ExternalMacaroonAuthenticator) recordGroups(tag names.UserTag) {
+	if m.Groups == nil || m.GroupRecorder == nil {
+		return
+	}
+	clk := m.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	now := clk.Now()
+	m.groupsMu.Lock()
+	recorded, ok := m.groupsRecorded[tag.Canonical()]
+	m.groupsMu.Unlock()
+	if ok && now.Before(recorded.Add(groupsRefreshInterval)) {
+		return
+	}
+
+	groups, err := m.Groups.UserGroups(tag)
+	if err == nil {
+		err = m.GroupRecorder.SetExternalUserGroups(tag, groups)
+	}
+	if err != nil {
+		logger.Warningf("cannot update groups for %q: %v", tag.Canonical(), err)
+		if err := m.GroupRecorder.SetExternalUserGroups(tag, nil); err != nil {
+			logger.Warningf("cannot remove groups for %q: %v", tag.Canonical(), err)
+		}
+		return
+	}
+	m.groupsMu.Lock()
+	defer m.groupsMu.Unlock()
+	if m.groupsRecorded == nil {
+		m.groupsRecorded = make(map[string]time.Time)
+	}
+	m.groupsRecorded[tag.Canonical()] = now
+}
+
 func addMacaroonTimeBeforeCaveat(svc BakeryService, m *macaroon.Macaroon, t time.Time) error {
 	return svc.AddCaveat(m, checkers.TimeBeforeCaveat(t))
 }
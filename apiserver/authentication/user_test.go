@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/authentication"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
@@ -121,6 +122,100 @@ func (s *userAuthenticatorSuite) TestUserLoginWrongPassword(c *gc.C) {
 
 }
 
+type fakeControllerConfig controller.Config
+
+func (f fakeControllerConfig) ControllerConfig() (controller.Config, error) {
+	return controller.Config(f), nil
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginLockout(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	authenticator := &authentication.UserAuthenticator{
+		ControllerConfig: fakeControllerConfig{
+			controller.LoginLockoutAttempts: 2,
+		},
+	}
+	for i := 0; i < 2; i++ {
+		_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+			Credentials: "wrongpassword",
+		})
+		c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	}
+
+	// The right password is refused once the user is locked out.
+	_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Credentials: "password",
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrUserLockedOut)
+
+	err = user.Unlock()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Credentials: "password",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginResetsFailedLogins(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	authenticator := &authentication.UserAuthenticator{
+		ControllerConfig: fakeControllerConfig{
+			controller.LoginLockoutAttempts: 2,
+		},
+	}
+	_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Credentials: "wrongpassword",
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+	_, err = authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+		Credentials: "password",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+}
+
+// expiredPasswordUser is a local user whose password has expired.
+type expiredPasswordUser struct {
+	*state.User
+}
+
+func (expiredPasswordUser) PasswordExpired(maxAge time.Duration) (bool, error) {
+	return maxAge > 0, nil
+}
+
+func (s *userAuthenticatorSuite) TestUserLoginPasswordExpired(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:     "bobbrown",
+		Password: "password",
+	})
+	finder := entityFinder{expiredPasswordUser{user}}
+
+	authenticator := &authentication.UserAuthenticator{
+		ControllerConfig: fakeControllerConfig{
+			controller.PasswordMaxAge: "24h",
+		},
+	}
+	_, err := authenticator.Authenticate(finder, user.Tag(), params.LoginRequest{
+		Credentials: "password",
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPasswordExpired)
+
+	// A wrong password is reported as such.
+	_, err = authenticator.Authenticate(finder, user.Tag(), params.LoginRequest{
+		Credentials: "wrongpassword",
+	})
+	c.Assert(err, gc.ErrorMatches, "invalid entity name or password")
+}
+
 func (s *userAuthenticatorSuite) TestInvalidRelationLogin(c *gc.C) {
 
 	// add relation
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
@@ -34,6 +35,9 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
 	RestoreInfo() *state.RestoreInfo
+	LastScheduledBackupRun() (state.ScheduledBackupRun, error)
+	ExportFromDatabase(dbName string, modelTag names.ModelTag) (description.Model, error)
+	ImportModel(model description.Model) (*state.Model, error)
 }
 
 // API serves backup-specific API methods.
@@ -126,6 +130,8 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 	result.Hostname = meta.Origin.Hostname
 	result.Version = meta.Origin.Version
 	result.Series = meta.Origin.Series
+	result.Storage = meta.StorageTarget
+	result.Encryption = meta.Encryption
 
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
@@ -154,6 +160,20 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Encryption = result.Encryption
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
+
+// ArchiveKeyFromParams returns the backups archive key corresponding to
+// the API key, or nil if there is none.
+func ArchiveKeyFromParams(key *params.BackupsArchiveKey) *backups.ArchiveKey {
+	if key == nil {
+		return nil
+	}
+	return &backups.ArchiveKey{
+		Passphrase: key.Passphrase,
+		PublicKey:  key.PublicKey,
+		PrivateKey: key.PrivateKey,
+	}
+}
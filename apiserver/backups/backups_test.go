@@ -27,6 +27,7 @@ type backupsSuite struct {
 	authorizer *apiservertesting.FakeAuthorizer
 	api        *backupsAPI.API
 	meta       *backups.Metadata
+	shim       *stateShim
 }
 
 var _ = gc.Suite(&backupsSuite{})
@@ -37,8 +38,9 @@ func (s *backupsSuite) SetUpTest(c *gc.C) {
 	s.resources.RegisterNamed("dataDir", common.StringResource("/var/lib/juju"))
 	tag := names.NewLocalUserTag("spam")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
+	s.shim = &stateShim{State: s.State}
 	var err error
-	s.api, err = backupsAPI.NewAPI(&stateShim{s.State}, s.resources, s.authorizer)
+	s.api, err = backupsAPI.NewAPI(s.shim, s.resources, s.authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	s.meta = backupstesting.NewMetadataStarted()
 }
@@ -67,13 +69,13 @@ func (s *backupsSuite) TestRegistered(c *gc.C) {
 }
 
 func (s *backupsSuite) TestNewAPIOkay(c *gc.C) {
-	_, err := backupsAPI.NewAPI(&stateShim{s.State}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State}, s.resources, s.authorizer)
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *backupsSuite) TestNewAPINotAuthorized(c *gc.C) {
 	s.authorizer.Tag = names.NewApplicationTag("eggs")
-	_, err := backupsAPI.NewAPI(&stateShim{s.State}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: s.State}, s.resources, s.authorizer)
 
 	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
 }
@@ -81,6 +83,6 @@ func (s *backupsSuite) TestNewAPINotAuthorized(c *gc.C) {
 func (s *backupsSuite) TestNewAPIHostedEnvironmentFails(c *gc.C) {
 	otherState := factory.NewFactory(s.State).MakeModel(c, nil)
 	defer otherState.Close()
-	_, err := backupsAPI.NewAPI(&stateShim{otherState}, s.resources, s.authorizer)
+	_, err := backupsAPI.NewAPI(&stateShim{State: otherState}, s.resources, s.authorizer)
 	c.Check(err, gc.ErrorMatches, "backups are not supported for hosted models")
 }
@@ -44,7 +44,16 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	}
 	meta.Notes = args.Notes
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	key := ArchiveKeyFromParams(args.Key)
+	if key != nil {
+		if err := key.Validate(); err != nil {
+			return p, errors.Trace(err)
+		}
+		if key.PrivateKey != "" {
+			return p, errors.NotValidf("private key for encrypting a backup")
+		}
+	}
+	err = backupsMethods.Create(meta, a.paths, dbInfo, key)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -42,6 +43,33 @@ func (s *backupsSuite) TestCreateNotes(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		Key: &params.BackupsArchiveKey{Passphrase: "sekrit"},
+	}
+	_, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(fake.KeyArg, jc.DeepEquals, &statebackups.ArchiveKey{Passphrase: "sekrit"})
+}
+
+func (s *backupsSuite) TestCreatePrivateKey(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		Key: &params.BackupsArchiveKey{PrivateKey: "<private key>"},
+	}
+	_, err := s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, "private key for encrypting a backup not valid")
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
 func (s *backupsSuite) TestCreateError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	s.PatchValue(backups.WaitUntilReady,
@@ -26,5 +26,43 @@ func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error
 		result.List[i] = ResultFromMetadata(meta)
 	}
 
+	result.Schedule, err = a.schedule()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	return result, nil
 }
+
+// schedule returns the controller's backup schedule and the outcome of the
+// last scheduled backup, or nil if backups were never scheduled.
+func (a *API) schedule() (*params.BackupsScheduleResult, error) {
+	controllerConfig, err := a.backend.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var schedule params.BackupsScheduleResult
+	if interval := controllerConfig.BackupInterval(); interval > 0 {
+		schedule.Interval = interval.String()
+		schedule.KeepLast = controllerConfig.BackupKeepLast()
+		schedule.KeepDaily = controllerConfig.BackupKeepDaily()
+		schedule.KeepWeekly = controllerConfig.BackupKeepWeekly()
+	}
+
+	lastRun, err := a.backend.LastScheduledBackupRun()
+	if errors.IsNotFound(err) {
+		if schedule.Interval == "" {
+			return nil, nil
+		}
+		return &schedule, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedule.LastRun = &params.BackupsScheduledRunResult{
+		Started:  lastRun.Started,
+		Finished: lastRun.Finished,
+		BackupID: lastRun.BackupID,
+		Removed:  lastRun.Removed,
+		Error:    lastRun.Error,
+	}
+	return &schedule, nil
+}
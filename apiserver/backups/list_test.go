@@ -6,12 +6,14 @@ package backups_test
 import (
 	"bytes"
 	"io/ioutil"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
 )
 
 func (s *backupsSuite) TestListOkay(c *gc.C) {
@@ -29,6 +31,29 @@ func (s *backupsSuite) TestListOkay(c *gc.C) {
 	c.Check(result, gc.DeepEquals, expected)
 }
 
+func (s *backupsSuite) TestListLastScheduledRun(c *gc.C) {
+	s.setBackups(c, s.meta, "")
+	started := time.Date(2016, time.August, 8, 1, 0, 0, 0, time.UTC)
+	err := s.State.SetLastScheduledBackupRun(state.ScheduledBackupRun{
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		BackupID: s.meta.ID(),
+		Removed:  []string{"old-backup"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result.Schedule, gc.NotNil)
+	c.Check(result.Schedule.Interval, gc.Equals, "")
+	lastRun := result.Schedule.LastRun
+	c.Assert(lastRun, gc.NotNil)
+	c.Check(lastRun.Started.Equal(started), jc.IsTrue)
+	c.Check(lastRun.BackupID, gc.Equals, s.meta.ID())
+	c.Check(lastRun.Removed, jc.DeepEquals, []string{"old-backup"})
+}
+
 func (s *backupsSuite) TestListError(c *gc.C) {
 	s.setBackups(c, nil, "failed!")
 	args := params.BackupsListArgs{}
@@ -3,12 +3,21 @@
 
 package backups_test
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/state"
+)
 
 type stateShim struct {
 	*state.State
+	imported description.Model
 }
 
 func (s *stateShim) MachineSeries(id string) (string, error) {
 	return "xenial", nil
 }
+
+func (s *stateShim) ImportModel(model description.Model) (*state.Model, error) {
+	s.imported = model
+	return s.State.Model()
+}
@@ -60,6 +60,16 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		return errors.Annotate(err, "cannot obtain instance id for machine to be restored")
 	}
 
+	// The backup archive must have been signed by this controller's CA.
+	controllerConfig, err := a.backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	caCert, ok := controllerConfig.CACert()
+	if !ok {
+		return errors.New("controller has no CA certificate")
+	}
+
 	logger.Infof("beginning server side restore of backup %q", p.BackupId)
 	// Restore
 	restoreArgs := backups.RestoreArgs{
@@ -68,6 +78,8 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		CACert:         caCert,
+		Key:            ArchiveKeyFromParams(p.Key),
 	}
 
 	session := a.backend.MongoSession().Copy()
@@ -0,0 +1,62 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+)
+
+// RestoreModel implements the server side of Backups.RestoreModel. It
+// extracts a single model from a stored backup and imports it into the
+// controller through the model migration import path. Unlike Restore,
+// the controller keeps running, and no other model is affected.
+func (a *API) RestoreModel(args params.RestoreModelArgs) (params.RestoreModelResult, error) {
+	var result params.RestoreModelResult
+	if !names.IsValidModel(args.ModelUUID) {
+		return result, errors.NotValidf("model UUID %q", args.ModelUUID)
+	}
+	if args.NewName != "" && !names.IsValidModelName(args.NewName) {
+		return result, errors.NotValidf("model name %q", args.NewName)
+	}
+
+	// The backup archive must have been signed by this controller's CA.
+	controllerConfig, err := a.backend.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	caCert, ok := controllerConfig.CACert()
+	if !ok {
+		return result, errors.New("controller has no CA certificate")
+	}
+
+	backup, closer := newBackups(a.backend)
+	defer closer.Close()
+
+	logger.Infof("restoring model %q from backup %q", args.ModelUUID, args.BackupId)
+	model, err := backup.ExportModel(args.BackupId, backups.ExportModelArgs{
+		ModelTag: names.NewModelTag(args.ModelUUID),
+		CACert:   caCert,
+		Key:      ArchiveKeyFromParams(args.Key),
+		Exporter: a.backend,
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.NewName != "" {
+		model.UpdateConfig(map[string]interface{}{"name": args.NewName})
+	}
+
+	dbModel, err := a.backend.ImportModel(model)
+	if err != nil {
+		return result, errors.Annotatef(err, "cannot import model %q", args.ModelUUID)
+	}
+	result.ModelUUID = dbModel.UUID()
+	result.Name = dbModel.Name()
+	result.Owner = dbModel.Owner().Id()
+	return result, nil
+}
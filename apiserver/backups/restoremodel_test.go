@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/description"
+	statebackups "github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+func (s *backupsSuite) TestRestoreModel(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	fake.Model = description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"uuid": s.State.ModelUUID(),
+			"name": "old-name",
+		},
+	})
+	args := params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: s.State.ModelUUID(),
+		NewName:   "new-name",
+		Key:       &params.BackupsArchiveKey{Passphrase: "sekrit"},
+	}
+	result, err := s.api.RestoreModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.RestoreModelResult{
+		ModelUUID: model.UUID(),
+		Name:      model.Name(),
+		Owner:     model.Owner().Id(),
+	})
+
+	c.Check(fake.Calls, jc.DeepEquals, []string{"ExportModel"})
+	c.Check(fake.IDArg, gc.Equals, "some-id")
+	c.Check(fake.ExportModelArgs.ModelTag, gc.Equals, s.State.ModelTag())
+	c.Check(fake.ExportModelArgs.CACert, gc.Equals, testing.CACert)
+	c.Check(fake.ExportModelArgs.Key, jc.DeepEquals, &statebackups.ArchiveKey{Passphrase: "sekrit"})
+	c.Check(fake.ExportModelArgs.Exporter, gc.Equals, s.shim)
+
+	c.Assert(s.shim.imported, gc.NotNil)
+	c.Check(s.shim.imported.Config()["name"], gc.Equals, "new-name")
+}
+
+func (s *backupsSuite) TestRestoreModelKeepsName(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	fake.Model = description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("bob"),
+		Config: map[string]interface{}{
+			"uuid": s.State.ModelUUID(),
+			"name": "old-name",
+		},
+	})
+	args := params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: s.State.ModelUUID(),
+	}
+	_, err := s.api.RestoreModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.shim.imported.Config()["name"], gc.Equals, "old-name")
+}
+
+func (s *backupsSuite) TestRestoreModelInvalidUUID(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	args := params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: "not-a-uuid",
+	}
+	_, err := s.api.RestoreModel(args)
+	c.Check(err, gc.ErrorMatches, `model UUID "not-a-uuid" not valid`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreModelInvalidName(c *gc.C) {
+	fake := s.setBackups(c, s.meta, "")
+	args := params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: s.State.ModelUUID(),
+		NewName:   "Not A Name",
+	}
+	_, err := s.api.RestoreModel(args)
+	c.Check(err, gc.ErrorMatches, `model name "Not A Name" not valid`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestRestoreModelExportFailed(c *gc.C) {
+	s.setBackups(c, s.meta, "failed!")
+	args := params.RestoreModelArgs{
+		BackupId:  "some-id",
+		ModelUUID: s.State.ModelUUID(),
+	}
+	_, err := s.api.RestoreModel(args)
+	c.Check(err, gc.ErrorMatches, "failed!")
+	c.Check(s.shim.imported, gc.IsNil)
+}
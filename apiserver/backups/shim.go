@@ -5,7 +5,10 @@ package backups
 
 import (
 	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/description"
+	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
 
@@ -31,6 +34,23 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
+// ImportModel implements backups.Backend
+func (s *stateShim) ImportModel(model description.Model) (*state.Model, error) {
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbModel, dbState, err := migration.ImportModel(s.State, bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer dbState.Close()
+	if err := dbModel.SetMigrationMode(state.MigrationModeActive); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return dbModel, nil
+}
+
 func newAPI(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
 	return NewAPI(&stateShim{st}, resources, authorizer)
 }
@@ -0,0 +1,120 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/charmrepository"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/state"
+)
+
+// maxRepositoryCharmSize is the largest charm archive that may be
+// published to the charm repository.
+var maxRepositoryCharmSize int64 = 1 << 30
+
+// charmRepositoryHandler handles the publishing of charms to the charm
+// repository hosted by the controller.
+type charmRepositoryHandler struct {
+	ctxt httpContext
+}
+
+// ServeHTTP implements http.Handler.
+func (h *charmRepositoryHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
+		return
+	}
+	if err := h.handlePost(w, req); err != nil {
+		sendError(w, errors.Trace(err))
+	}
+}
+
+// handlePost publishes the charm archive in the request body as a new
+// revision of the charm. Only controller administrators may publish
+// charms.
+func (h *charmRepositoryHandler) handlePost(w http.ResponseWriter, req *http.Request) error {
+	// Validate the request.
+	if ctype := req.Header.Get("Content-Type"); ctype != "application/zip" {
+		return errors.BadRequestf("invalid content type %q: expected %q", ctype, "application/zip")
+	}
+	if err := req.ParseForm(); err != nil {
+		return errors.Annotate(err, "cannot parse form")
+	}
+	hashParam := req.Form.Get("hash")
+	if hashParam == "" {
+		return errors.BadRequestf("hash parameter not provided")
+	}
+	if req.ContentLength == -1 {
+		return errors.BadRequestf("content length not provided")
+	}
+	if req.ContentLength > maxRepositoryCharmSize {
+		return errors.BadRequestf("archive larger than %d bytes", maxRepositoryCharmSize)
+	}
+
+	st, entity, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+	if err != nil {
+		return errors.Annotate(err, "cannot open state")
+	}
+	user := entity.Tag().(names.UserTag)
+	isAdmin, err := st.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+
+	// Store the archive data in a temporary file while hashing it, so
+	// that it is not held in memory, then validate it.
+	tempFile, err := ioutil.TempFile("", "charm")
+	if err != nil {
+		return errors.Annotate(err, "cannot create temp file")
+	}
+	defer tempFile.Close()
+	defer os.Remove(tempFile.Name())
+	hasher := sha256.New()
+	body := http.MaxBytesReader(w, req.Body, maxRepositoryCharmSize)
+	size, err := io.Copy(io.MultiWriter(tempFile, hasher), body)
+	if err != nil {
+		return errors.Annotate(err, "error processing file upload")
+	}
+	if size != req.ContentLength {
+		return errors.BadRequestf("archive does not match provided content length")
+	}
+	hash := fmt.Sprintf("%x", hasher.Sum(nil))
+	if hash != hashParam {
+		return errors.BadRequestf("archive does not match provided hash")
+	}
+	archive, err := charm.ReadCharmArchive(tempFile.Name())
+	if err != nil {
+		return errors.BadRequestf("invalid charm archive: %v", err)
+	}
+	if _, err := tempFile.Seek(0, os.SEEK_SET); err != nil {
+		return errors.Trace(err)
+	}
+
+	ch, err := st.PublishRepositoryCharm(state.PublishRepositoryCharmArgs{
+		Charm:     archive,
+		Archive:   tempFile,
+		Size:      size,
+		SHA256:    hash,
+		Publisher: user.Canonical(),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sendStatusAndJSON(w, http.StatusOK, charmrepository.RepositoryCharmParams(ch))
+	return nil
+}
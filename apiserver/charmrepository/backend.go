@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the charm repository hosted by the controller and the
+// charms of the model.
+type Backend interface {
+	RepositoryCharms(name string) ([]RepositoryCharm, error)
+	ResolveRepositoryCharm(*charmrepository.URL, csparams.Channel) (RepositoryCharm, error)
+	PromoteRepositoryCharm(name string, revision int, channel csparams.Channel) error
+	AddRepositoryCharm(*charmrepository.URL, csparams.Channel) (Charm, error)
+	Charm(*charm.URL) (Charm, error)
+
+	IsControllerAdministrator(names.UserTag) (bool, error)
+}
+
+// RepositoryCharm is a revision of a charm in the charm repository.
+type RepositoryCharm interface {
+	URL() *charmrepository.URL
+	Name() string
+	Revision() int
+	Summary() string
+	Series() []string
+	Channels() []csparams.Channel
+	Publisher() string
+	Published() time.Time
+	Size() int64
+	SHA256() string
+}
+
+// Charm is a charm in the model.
+type Charm interface {
+	URL() *charm.URL
+	RepositoryURL() string
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend returns a Backend backed by the given state.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) RepositoryCharms(name string) ([]RepositoryCharm, error) {
+	charms, err := s.State.RepositoryCharms(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]RepositoryCharm, len(charms))
+	for i, ch := range charms {
+		result[i] = ch
+	}
+	return result, nil
+}
+
+func (s stateShim) ResolveRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (RepositoryCharm, error) {
+	ch, err := s.State.ResolveRepositoryCharm(curl, channel)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+func (s stateShim) AddRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (Charm, error) {
+	ch, err := s.State.AddRepositoryCharm(curl, channel)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+func (s stateShim) Charm(curl *charm.URL) (Charm, error) {
+	ch, err := s.State.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrepository defines an API end point for working with the
+// charm repository hosted by the controller from a model. Charms are
+// published to the repository over HTTP; see the apiserver package.
+package charmrepository
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("CharmRepository", 1, newFacade)
+}
+
+// API implements the CharmRepository facade.
+type API struct {
+	backend    Backend
+	authorizer common.Authorizer
+}
+
+func newFacade(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
+	return NewAPI(NewStateBackend(st), authorizer)
+}
+
+// NewAPI returns a new CharmRepository API facade.
+func NewAPI(backend Backend, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// ListCharms returns the revisions of the charms in the repository,
+// optionally restricted to the charm with the given name.
+func (api *API) ListCharms(args params.RepositoryCharmsFilter) (params.RepositoryCharmsResult, error) {
+	charms, err := api.backend.RepositoryCharms(args.Name)
+	if err != nil {
+		return params.RepositoryCharmsResult{}, errors.Trace(err)
+	}
+	result := params.RepositoryCharmsResult{
+		Charms: make([]params.RepositoryCharm, len(charms)),
+	}
+	for i, ch := range charms {
+		result.Charms[i] = RepositoryCharmParams(ch)
+	}
+	return result, nil
+}
+
+// ResolveCharm returns the revision of the charm in the repository
+// identified by the URL. A URL without a revision identifies the
+// revision in the given channel.
+func (api *API) ResolveCharm(args params.RepositoryCharmURL) (params.RepositoryCharmResult, error) {
+	curl, err := charmrepository.ParseURL(args.URL)
+	if err != nil {
+		return params.RepositoryCharmResult{}, errors.Trace(err)
+	}
+	ch, err := api.backend.ResolveRepositoryCharm(curl, csparams.Channel(args.Channel))
+	if err != nil {
+		return params.RepositoryCharmResult{Error: common.ServerError(err)}, nil
+	}
+	result := RepositoryCharmParams(ch)
+	return params.RepositoryCharmResult{Result: &result}, nil
+}
+
+// PromoteCharm puts the revision of the charm identified by the URL in
+// the channel. Only controller administrators may promote charms.
+func (api *API) PromoteCharm(args params.PromoteRepositoryCharm) error {
+	if err := api.checkControllerAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	curl, err := charmrepository.ParseURL(args.URL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return errors.Errorf("charm URL %q has no revision", args.URL)
+	}
+	return api.backend.PromoteRepositoryCharm(curl.Name, curl.Revision, csparams.Channel(args.Channel))
+}
+
+// AddCharm copies the charm identified by the URL into the model as a
+// local charm, returning the URL of the local charm.
+func (api *API) AddCharm(args params.RepositoryCharmURL) (params.AddRepositoryCharmResult, error) {
+	curl, err := charmrepository.ParseURL(args.URL)
+	if err != nil {
+		return params.AddRepositoryCharmResult{}, errors.Trace(err)
+	}
+	ch, err := api.backend.AddRepositoryCharm(curl, csparams.Channel(args.Channel))
+	if err != nil {
+		return params.AddRepositoryCharmResult{}, errors.Trace(err)
+	}
+	return params.AddRepositoryCharmResult{
+		CharmURL:      ch.URL().String(),
+		RepositoryURL: ch.RepositoryURL(),
+	}, nil
+}
+
+// CharmOrigin returns the charm repository URL the model charm was
+// copied from. The result is empty for charms that did not come from
+// the repository.
+func (api *API) CharmOrigin(args params.CharmURL) (params.RepositoryCharmOrigin, error) {
+	curl, err := charm.ParseURL(args.URL)
+	if err != nil {
+		return params.RepositoryCharmOrigin{}, errors.Trace(err)
+	}
+	ch, err := api.backend.Charm(curl)
+	if err != nil {
+		return params.RepositoryCharmOrigin{}, errors.Trace(err)
+	}
+	return params.RepositoryCharmOrigin{RepositoryURL: ch.RepositoryURL()}, nil
+}
+
+func (api *API) checkControllerAdmin() error {
+	user, ok := api.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	isAdmin, err := api.backend.IsControllerAdministrator(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// RepositoryCharmParams returns the API representation of the charm
+// repository charm.
+func RepositoryCharmParams(ch RepositoryCharm) params.RepositoryCharm {
+	result := params.RepositoryCharm{
+		URL:       ch.URL().String(),
+		Name:      ch.Name(),
+		Revision:  ch.Revision(),
+		Summary:   ch.Summary(),
+		Series:    ch.Series(),
+		Publisher: ch.Publisher(),
+		Published: ch.Published(),
+		Size:      ch.Size(),
+		SHA256:    ch.SHA256(),
+	}
+	for _, channel := range ch.Channels() {
+		result.Channels = append(result.Channels, string(channel))
+	}
+	return result
+}
@@ -0,0 +1,202 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	facade "github.com/juju/juju/apiserver/charmrepository"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmrepository"
+)
+
+type charmRepositorySuite struct {
+	gitjujutesting.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *facade.API
+}
+
+var _ = gc.Suite(&charmRepositorySuite{})
+
+var published = time.Date(2016, time.August, 8, 12, 0, 0, 0, time.UTC)
+
+func (s *charmRepositorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin@local"),
+	}
+	s.backend = mockBackend{
+		charms: []facade.RepositoryCharm{&mockRepositoryCharm{
+			name:     "mysql",
+			revision: 3,
+			channels: []csparams.Channel{csparams.StableChannel},
+		}},
+		charm: &mockCharm{
+			url:           charm.MustParseURL("local:trusty/mysql-3"),
+			repositoryURL: "repo:mysql-3",
+		},
+	}
+	var err error
+	s.api, err = facade.NewAPI(&s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmRepositorySuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := facade.NewAPI(&s.backend, &s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmRepositorySuite) TestListCharms(c *gc.C) {
+	result, err := s.api.ListCharms(params.RepositoryCharmsFilter{Name: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "RepositoryCharms", "mysql")
+	c.Assert(result, jc.DeepEquals, params.RepositoryCharmsResult{
+		Charms: []params.RepositoryCharm{{
+			URL:       "repo:mysql-3",
+			Name:      "mysql",
+			Revision:  3,
+			Summary:   "A database.",
+			Series:    []string{"trusty"},
+			Channels:  []string{"stable"},
+			Publisher: "admin",
+			Published: published,
+			Size:      42,
+			SHA256:    "abc",
+		}},
+	})
+}
+
+func (s *charmRepositorySuite) TestResolveCharm(c *gc.C) {
+	result, err := s.api.ResolveCharm(params.RepositoryCharmURL{URL: "repo:mysql", Channel: "stable"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "ResolveRepositoryCharm", charmrepository.MustParseURL("repo:mysql"), csparams.StableChannel)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result.URL, gc.Equals, "repo:mysql-3")
+}
+
+func (s *charmRepositorySuite) TestResolveCharmNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("charm"))
+	result, err := s.api.ResolveCharm(params.RepositoryCharmURL{URL: "repo:mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.IsCodeNotFound(result.Error), jc.IsTrue)
+}
+
+func (s *charmRepositorySuite) TestResolveCharmInvalidURL(c *gc.C) {
+	_, err := s.api.ResolveCharm(params.RepositoryCharmURL{URL: "cs:mysql"})
+	c.Assert(err, gc.ErrorMatches, `charm repository URL "cs:mysql" not valid`)
+}
+
+func (s *charmRepositorySuite) TestPromoteCharm(c *gc.C) {
+	err := s.api.PromoteCharm(params.PromoteRepositoryCharm{URL: "repo:mysql-3", Channel: "edge"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "IsControllerAdministrator", "PromoteRepositoryCharm")
+	s.backend.CheckCall(c, 1, "PromoteRepositoryCharm", "mysql", 3, csparams.EdgeChannel)
+}
+
+func (s *charmRepositorySuite) TestPromoteCharmNoRevision(c *gc.C) {
+	err := s.api.PromoteCharm(params.PromoteRepositoryCharm{URL: "repo:mysql", Channel: "edge"})
+	c.Assert(err, gc.ErrorMatches, `charm URL "repo:mysql" has no revision`)
+}
+
+func (s *charmRepositorySuite) TestPromoteCharmNotAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob@local")
+	err := s.api.PromoteCharm(params.PromoteRepositoryCharm{URL: "repo:mysql-3", Channel: "edge"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "IsControllerAdministrator")
+}
+
+func (s *charmRepositorySuite) TestAddCharm(c *gc.C) {
+	result, err := s.api.AddCharm(params.RepositoryCharmURL{URL: "repo:trusty/mysql", Channel: "stable"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "AddRepositoryCharm", charmrepository.MustParseURL("repo:trusty/mysql"), csparams.StableChannel)
+	c.Assert(result, jc.DeepEquals, params.AddRepositoryCharmResult{
+		CharmURL:      "local:trusty/mysql-3",
+		RepositoryURL: "repo:mysql-3",
+	})
+}
+
+func (s *charmRepositorySuite) TestCharmOrigin(c *gc.C) {
+	result, err := s.api.CharmOrigin(params.CharmURL{URL: "local:trusty/mysql-3"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "Charm", charm.MustParseURL("local:trusty/mysql-3"))
+	c.Assert(result.RepositoryURL, gc.Equals, "repo:mysql-3")
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	charms []facade.RepositoryCharm
+	charm  facade.Charm
+}
+
+func (b *mockBackend) RepositoryCharms(name string) ([]facade.RepositoryCharm, error) {
+	b.MethodCall(b, "RepositoryCharms", name)
+	return b.charms, b.NextErr()
+}
+
+func (b *mockBackend) ResolveRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (facade.RepositoryCharm, error) {
+	b.MethodCall(b, "ResolveRepositoryCharm", curl, channel)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.charms[0], nil
+}
+
+func (b *mockBackend) PromoteRepositoryCharm(name string, revision int, channel csparams.Channel) error {
+	b.MethodCall(b, "PromoteRepositoryCharm", name, revision, channel)
+	return b.NextErr()
+}
+
+func (b *mockBackend) AddRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (facade.Charm, error) {
+	b.MethodCall(b, "AddRepositoryCharm", curl, channel)
+	return b.charm, b.NextErr()
+}
+
+func (b *mockBackend) Charm(curl *charm.URL) (facade.Charm, error) {
+	b.MethodCall(b, "Charm", curl)
+	return b.charm, b.NextErr()
+}
+
+func (b *mockBackend) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	b.MethodCall(b, "IsControllerAdministrator", user)
+	return user.Canonical() == "admin@local", b.NextErr()
+}
+
+type mockRepositoryCharm struct {
+	name     string
+	revision int
+	channels []csparams.Channel
+}
+
+func (ch *mockRepositoryCharm) URL() *charmrepository.URL {
+	return &charmrepository.URL{Name: ch.name, Revision: ch.revision}
+}
+
+func (ch *mockRepositoryCharm) Name() string                 { return ch.name }
+func (ch *mockRepositoryCharm) Revision() int                { return ch.revision }
+func (ch *mockRepositoryCharm) Summary() string              { return "A database." }
+func (ch *mockRepositoryCharm) Series() []string             { return []string{"trusty"} }
+func (ch *mockRepositoryCharm) Channels() []csparams.Channel { return ch.channels }
+func (ch *mockRepositoryCharm) Publisher() string            { return "admin" }
+func (ch *mockRepositoryCharm) Published() time.Time         { return published }
+func (ch *mockRepositoryCharm) Size() int64                  { return 42 }
+func (ch *mockRepositoryCharm) SHA256() string               { return "abc" }
+
+type mockCharm struct {
+	url           *charm.URL
+	repositoryURL string
+}
+
+func (ch *mockCharm) URL() *charm.URL       { return ch.url }
+func (ch *mockCharm) RepositoryURL() string { return ch.repositoryURL }
@@ -0,0 +1,115 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testcharms"
+)
+
+type charmRepositorySuite struct {
+	authHttpSuite
+}
+
+var _ = gc.Suite(&charmRepositorySuite{})
+
+func (s *charmRepositorySuite) repositoryURL(c *gc.C, hash string) string {
+	u := s.baseURL(c)
+	u.Path = "/charm-repository"
+	u.RawQuery = url.Values{"hash": {hash}}.Encode()
+	return u.String()
+}
+
+func (s *charmRepositorySuite) TestMethodNotAllowed(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method: "GET",
+		url:    s.repositoryURL(c, ""),
+	})
+	body := assertResponse(c, resp, http.StatusMethodNotAllowed, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, `unsupported method: "GET"`)
+}
+
+func (s *charmRepositorySuite) TestPostUnauthorized(c *gc.C) {
+	resp := s.sendRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.repositoryURL(c, "sha"),
+		contentType: "application/zip",
+		body:        strings.NewReader("archive contents"),
+	})
+	body := assertResponse(c, resp, http.StatusUnauthorized, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, "cannot open state: no credentials provided")
+}
+
+func (s *charmRepositorySuite) TestPostHashMismatch(c *gc.C) {
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.repositoryURL(c, "sha"),
+		contentType: "application/zip",
+		body:        strings.NewReader("archive contents"),
+	})
+	body := assertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, "archive does not match provided hash")
+}
+
+func (s *charmRepositorySuite) TestPostTooLarge(c *gc.C) {
+	s.PatchValue(apiserver.MaxRepositoryCharmSize, int64(10))
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.repositoryURL(c, "sha"),
+		contentType: "application/zip",
+		body:        strings.NewReader("archive contents"),
+	})
+	body := assertResponse(c, resp, http.StatusBadRequest, params.ContentTypeJSON)
+	var jsonResp params.ErrorResult
+	err := json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.Error.Message, gc.Matches, "archive larger than 10 bytes")
+}
+
+func (s *charmRepositorySuite) TestPostSuccess(c *gc.C) {
+	path := testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+
+	resp := s.authRequest(c, httpRequestParams{
+		method:      "POST",
+		url:         s.repositoryURL(c, hash),
+		contentType: "application/zip",
+		body:        bytes.NewReader(data),
+	})
+	body := assertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var jsonResp params.RepositoryCharm
+	err = json.Unmarshal(body, &jsonResp)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("body: %s", body))
+	c.Assert(jsonResp.URL, gc.Equals, "repo:dummy-0")
+	c.Assert(jsonResp.SHA256, gc.Equals, hash)
+	c.Assert(jsonResp.Publisher, gc.Equals, s.userTag.Canonical())
+
+	ch, err := s.State.RepositoryCharm("dummy", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Size(), gc.Equals, int64(len(data)))
+}
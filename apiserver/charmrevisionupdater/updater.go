@@ -130,9 +130,19 @@ func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
 			continue
 		}
 
+		// An upgrade policy may look for new revisions in a
+		// channel other than the one the charm was deployed from.
+		policy, err := service.CharmUpgradePolicy()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		channel := service.Channel()
+		if policy.Channel != "" {
+			channel = policy.Channel
+		}
 		cid := charmstore.CharmID{
 			URL:     curl,
-			Channel: service.Channel(),
+			Channel: channel,
 		}
 		charms = append(charms, cid)
 		resultsIndexedServices = append(resultsIndexedServices, service)
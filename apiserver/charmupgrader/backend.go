@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/macaroon.v1"
+
+	"github.com/juju/juju/apiserver/application"
+	"github.com/juju/juju/apiserver/charmupgrades"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/resource"
+	resourceapi "github.com/juju/juju/resource/api"
+	resourceserver "github.com/juju/juju/resource/api/server"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the applications of a model and the means of adding
+// the charms, and their resources, they are upgraded to.
+type Backend interface {
+	common.BlockGetter
+	AllApplications() ([]charmupgrades.Application, error)
+	Application(name string) (Application, error)
+	AddStoreCharm(curl *charm.URL, channel csparams.Channel, current *charm.URL) (*state.Charm, error)
+	AddRepositoryCharm(*charmrepository.URL, csparams.Channel) (*state.Charm, error)
+	AddPendingResources(applicationID string, ch *state.Charm, channel csparams.Channel) (map[string]string, error)
+}
+
+// Application is an application whose charm may be upgraded.
+type Application interface {
+	charmupgrades.Application
+	Channel() csparams.Channel
+	SetCharm(state.SetCharmConfig) error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend returns a Backend backed by the given state.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) AllApplications() ([]charmupgrades.Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]charmupgrades.Application, len(apps))
+	for i, app := range apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
+
+// AddStoreCharm downloads the charm from the charm store into the
+// model, unless it is already there. The download is authorized with
+// the macaroon stored with the application's current charm when it
+// was deployed, so private charms can be upgraded for as long as that
+// macaroon grants access to them.
+func (s stateShim) AddStoreCharm(curl *charm.URL, channel csparams.Channel, current *charm.URL) (*state.Charm, error) {
+	ms, err := state.MacaroonCache{s.State}.Get(current)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var mac *macaroon.Macaroon
+	if len(ms) > 0 {
+		mac = ms[0]
+	}
+	err = application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL:                curl.String(),
+		Channel:            string(channel),
+		CharmStoreMacaroon: mac,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.State.Charm(curl)
+}
+
+// AddPendingResources adds the resources of the given charm to the
+// application as pending resources, ready to be resolved when the
+// application is upgraded to the charm, and returns their pending IDs
+// by name. As with upgrade-charm, the latest revisions of charm store
+// resources are used, and resources the user uploaded are left alone.
+func (s stateShim) AddPendingResources(applicationID string, ch *state.Charm, channel csparams.Channel) (map[string]string, error) {
+	metas := ch.Meta().Resources
+	if len(metas) == 0 {
+		return nil, nil
+	}
+	resources, err := s.State.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current, err := resources.ListResources(applicationID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	currentByName := resource.AsMap(current.Resources)
+
+	var pendingNames []string
+	var pending []resourceapi.CharmResource
+	for name, meta := range metas {
+		if res, ok := currentByName[name]; ok && res.Origin == charmresource.OriginUpload {
+			continue
+		}
+		pendingNames = append(pendingNames, name)
+		pending = append(pending, resourceapi.CharmResource2API(charmresource.Resource{
+			Meta:     meta,
+			Origin:   charmresource.OriginStore,
+			Revision: -1,
+		}))
+	}
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	newClient := func() (resourceserver.CharmStore, error) {
+		return charmstore.NewCachingClient(state.MacaroonCache{s.State}, nil)
+	}
+	facade, err := resourceserver.NewFacade(resources, newClient, "", nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result, err := facade.AddPendingResources(resourceapi.AddPendingResourcesArgs{
+		Entity: params.Entity{Tag: names.NewApplicationTag(applicationID).String()},
+		AddCharmWithAuthorization: params.AddCharmWithAuthorization{
+			URL:     ch.URL().String(),
+			Channel: string(channel),
+		},
+		Resources: pending,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	ids := make(map[string]string)
+	for i, id := range result.PendingIDs {
+		ids[pendingNames[i]] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrader defines the API end point used by the charm
+// upgrader worker to apply newer charm revisions to the applications
+// whose upgrade policy allows it.
+package charmupgrader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/charmupgrades"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("CharmUpgrader", 1, newFacade)
+}
+
+// API implements the CharmUpgrader facade.
+type API struct {
+	backend Backend
+	check   *common.BlockChecker
+	clock   clock.Clock
+}
+
+func newFacade(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
+	return NewAPI(NewStateBackend(st), authorizer, clock.WallClock)
+}
+
+// NewAPI returns a new CharmUpgrader API facade. Only the model's
+// controller machines may use it.
+func NewAPI(backend Backend, authorizer common.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthModelManager() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		check:   common.NewBlockChecker(backend),
+		clock:   clock,
+	}, nil
+}
+
+// UpgradesAvailable returns the charm, upgrade policy and newer charm
+// revision, if any, of every application in the model.
+func (api *API) UpgradesAvailable() (params.CharmUpgradeInfoResults, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.CharmUpgradeInfoResults{}, errors.Trace(err)
+	}
+	results := make([]params.CharmUpgradeInfo, len(apps))
+	for i, app := range apps {
+		info, err := charmupgrades.UpgradeInfo(app)
+		if err != nil {
+			return params.CharmUpgradeInfoResults{}, errors.Trace(err)
+		}
+		results[i] = info
+	}
+	return params.CharmUpgradeInfoResults{Results: results}, nil
+}
+
+// UpgradeCharms adds the given charms, and the latest revisions of
+// their charm store resources, to the model and upgrades the
+// applications to them. Only applications with the auto-in-window
+// policy may be upgraded, only while their maintenance window is open
+// and changes to the model are not blocked, and only to the newer
+// revision of their charm reported by UpgradesAvailable.
+func (api *API) UpgradeCharms(args params.UpgradeApplicationCharms) (params.ErrorResults, error) {
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.upgradeCharm(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) upgradeCharm(arg params.UpgradeApplicationCharm) error {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy, err := app.CharmUpgradePolicy()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if policy.Mode != charmupgrade.AutoInWindow {
+		return errors.Errorf("application %q does not allow automatic charm upgrades", app.Name())
+	}
+	if !policy.Window.Contains(api.clock.Now()) {
+		return errors.Errorf("maintenance window %s of application %q is not open", policy.Window, app.Name())
+	}
+	// The charm is always the upgrade recorded for the application's
+	// own charm; the caller only confirms which one it expects.
+	available, err := app.AvailableCharmUpgrade()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if available == "" {
+		return errors.Errorf("no charm upgrade available for application %q", app.Name())
+	}
+	if arg.CharmURL != available {
+		return errors.Errorf("charm %q is not the available upgrade %q of application %q", arg.CharmURL, available, app.Name())
+	}
+
+	var ch *state.Charm
+	var channel csparams.Channel
+	if charmrepository.IsURL(available) {
+		rurl, err := charmrepository.ParseURL(available)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		current, _ := app.CharmURL()
+		ch, err = api.backend.AddRepositoryCharm(rurl.WithSeries(current.Series), policy.Channel)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		curl, err := charm.ParseURL(available)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		channel = app.Channel()
+		if policy.Channel != "" {
+			channel = policy.Channel
+		}
+		current, _ := app.CharmURL()
+		ch, err = api.backend.AddStoreCharm(curl, channel, current)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	resourceIDs, err := api.backend.AddPendingResources(app.Name(), ch, channel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetCharm(state.SetCharmConfig{
+		Charm:       ch,
+		Channel:     channel,
+		ResourceIDs: resourceIDs,
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/charmupgrader"
+	"github.com/juju/juju/apiserver/charmupgrades"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmUpgraderSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	clock      *coretesting.Clock
+	api        *charmupgrader.API
+}
+
+var _ = gc.Suite(&charmUpgraderSuite{})
+
+var autoPolicy = charmupgrade.Policy{
+	Mode:    charmupgrade.AutoInWindow,
+	Channel: csparams.CandidateChannel,
+	Window:  charmupgrade.Window{Start: 2 * time.Hour, Duration: time.Hour},
+}
+
+func (s *charmUpgraderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:            names.NewMachineTag("0"),
+		EnvironManager: true,
+	}
+	s.backend = mockBackend{
+		apps: []*mockApplication{{
+			name:      "mysql",
+			url:       charm.MustParseURL("cs:trusty/mysql-3"),
+			policy:    autoPolicy,
+			available: "cs:trusty/mysql-5",
+		}, {
+			name:      "dummy",
+			url:       charm.MustParseURL("local:quantal/dummy-0"),
+			policy:    autoPolicy,
+			available: "repo:dummy-1",
+		}, {
+			name:   "wordpress",
+			url:    charm.MustParseURL("cs:trusty/wordpress-1"),
+			policy: charmupgrade.DefaultPolicy,
+		}},
+	}
+	// Half an hour into the maintenance window.
+	s.clock = coretesting.NewClock(time.Date(2016, 10, 1, 2, 30, 0, 0, time.UTC))
+	var err error
+	s.api, err = charmupgrader.NewAPI(&s.backend, &s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmUpgraderSuite) TestNewAPIRequiresModelManager(c *gc.C) {
+	for _, tag := range []names.Tag{
+		names.NewUserTag("admin"),
+		names.NewMachineTag("1"),
+		names.NewUnitTag("mysql/0"),
+	} {
+		c.Logf("%s", tag)
+		s.authorizer = apiservertesting.FakeAuthorizer{Tag: tag}
+		_, err := charmupgrader.NewAPI(&s.backend, &s.authorizer, s.clock)
+		c.Check(err, gc.ErrorMatches, "permission denied")
+	}
+}
+
+func (s *charmUpgraderSuite) TestUpgradesAvailable(c *gc.C) {
+	result, err := s.api.UpgradesAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0], jc.DeepEquals, params.CharmUpgradeInfo{
+		ApplicationTag: "application-mysql",
+		CharmURL:       "cs:trusty/mysql-3",
+		Available:      "cs:trusty/mysql-5",
+		Policy:         charmupgrades.PolicyParams(autoPolicy),
+	})
+}
+
+func (s *charmUpgraderSuite) TestUpgradeCharms(c *gc.C) {
+	result, err := s.api.UpgradeCharms(params.UpgradeApplicationCharms{
+		Args: []params.UpgradeApplicationCharm{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-5",
+		}, {
+			ApplicationTag: "application-dummy",
+			CharmURL:       "repo:dummy-1",
+		}, {
+			ApplicationTag: "application-wordpress",
+			CharmURL:       "cs:trusty/wordpress-2",
+		}, {
+			ApplicationTag: "application-missing",
+			CharmURL:       "cs:trusty/missing-2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.IsNil)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `application "wordpress" does not allow automatic charm upgrades`)
+	c.Check(result.Results[3].Error, gc.ErrorMatches, `application "missing" not found`)
+
+	s.backend.CheckCallNames(c,
+		"Application", "AddStoreCharm", "AddPendingResources",
+		"Application", "AddRepositoryCharm", "AddPendingResources",
+		"Application",
+		"Application",
+	)
+	s.backend.CheckCall(c, 1, "AddStoreCharm", charm.MustParseURL("cs:trusty/mysql-5"), csparams.CandidateChannel, charm.MustParseURL("cs:trusty/mysql-3"))
+	s.backend.CheckCall(c, 2, "AddPendingResources", "mysql", (*state.Charm)(nil), csparams.CandidateChannel)
+	s.backend.CheckCall(c, 4, "AddRepositoryCharm", charmrepository.MustParseURL("repo:quantal/dummy-1"), csparams.CandidateChannel)
+	c.Check(s.backend.apps[0].setCharm, jc.DeepEquals, &state.SetCharmConfig{
+		Channel:     csparams.CandidateChannel,
+		ResourceIDs: map[string]string{"data": "pending-mysql"},
+	})
+	c.Check(s.backend.apps[1].setCharm, jc.DeepEquals, &state.SetCharmConfig{
+		ResourceIDs: map[string]string{"data": "pending-dummy"},
+	})
+	c.Check(s.backend.apps[2].setCharm, gc.IsNil)
+}
+
+func (s *charmUpgraderSuite) TestUpgradeCharmsBlocked(c *gc.C) {
+	s.backend.block = mockBlock{}
+	result, err := s.api.UpgradeCharms(params.UpgradeApplicationCharms{
+		Args: []params.UpgradeApplicationCharm{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-5",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.backend.CheckNoCalls(c)
+	c.Check(s.backend.apps[0].setCharm, gc.IsNil)
+}
+
+func (s *charmUpgraderSuite) TestUpgradeCharmsRefusesOtherCharms(c *gc.C) {
+	result, err := s.api.UpgradeCharms(params.UpgradeApplicationCharms{
+		Args: []params.UpgradeApplicationCharm{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/evil-9",
+		}, {
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-4",
+		}, {
+			ApplicationTag: "application-dummy",
+			CharmURL:       "repo:evil-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.ErrorMatches,
+		`charm "cs:trusty/evil-9" is not the available upgrade "cs:trusty/mysql-5" of application "mysql"`)
+	c.Check(result.Results[1].Error, gc.ErrorMatches,
+		`charm "cs:trusty/mysql-4" is not the available upgrade "cs:trusty/mysql-5" of application "mysql"`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches,
+		`charm "repo:evil-1" is not the available upgrade "repo:dummy-1" of application "dummy"`)
+	s.backend.CheckCallNames(c, "Application", "Application", "Application")
+	c.Check(s.backend.apps[0].setCharm, gc.IsNil)
+	c.Check(s.backend.apps[1].setCharm, gc.IsNil)
+}
+
+func (s *charmUpgraderSuite) TestUpgradeCharmsNoUpgradeAvailable(c *gc.C) {
+	s.backend.apps[0].available = ""
+	result, err := s.api.UpgradeCharms(params.UpgradeApplicationCharms{
+		Args: []params.UpgradeApplicationCharm{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-5",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `no charm upgrade available for application "mysql"`)
+	s.backend.CheckCallNames(c, "Application")
+}
+
+func (s *charmUpgraderSuite) TestUpgradeCharmsOutsideWindow(c *gc.C) {
+	s.clock.Advance(time.Hour)
+	result, err := s.api.UpgradeCharms(params.UpgradeApplicationCharms{
+		Args: []params.UpgradeApplicationCharm{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-5",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `maintenance window 02:00-03:00 of application "mysql" is not open`)
+	s.backend.CheckCallNames(c, "Application")
+	c.Check(s.backend.apps[0].setCharm, gc.IsNil)
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	apps  []*mockApplication
+	block state.Block
+}
+
+func (b *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	if b.block != nil && t == state.ChangeBlock {
+		return b.block, true, nil
+	}
+	return nil, false, nil
+}
+
+func (b *mockBackend) AllApplications() ([]charmupgrades.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	result := make([]charmupgrades.Application, len(b.apps))
+	for i, app := range b.apps {
+		result[i] = app
+	}
+	return result, b.NextErr()
+}
+
+func (b *mockBackend) Application(name string) (charmupgrader.Application, error) {
+	b.MethodCall(b, "Application", name)
+	for _, app := range b.apps {
+		if app.name == name {
+			return app, nil
+		}
+	}
+	return nil, errors.NotFoundf("application %q", name)
+}
+
+func (b *mockBackend) AddStoreCharm(curl *charm.URL, channel csparams.Channel, current *charm.URL) (*state.Charm, error) {
+	b.MethodCall(b, "AddStoreCharm", curl, channel, current)
+	return nil, b.NextErr()
+}
+
+func (b *mockBackend) AddRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (*state.Charm, error) {
+	b.MethodCall(b, "AddRepositoryCharm", curl, channel)
+	return nil, b.NextErr()
+}
+
+func (b *mockBackend) AddPendingResources(applicationID string, ch *state.Charm, channel csparams.Channel) (map[string]string, error) {
+	b.MethodCall(b, "AddPendingResources", applicationID, ch, channel)
+	return map[string]string{"data": "pending-" + applicationID}, b.NextErr()
+}
+
+type mockBlock struct {
+	state.Block
+}
+
+func (mockBlock) Message() string { return "no changes" }
+
+type mockApplication struct {
+	name      string
+	url       *charm.URL
+	policy    charmupgrade.Policy
+	available string
+	setCharm  *state.SetCharmConfig
+}
+
+func (a *mockApplication) Name() string                 { return a.name }
+func (a *mockApplication) CharmURL() (*charm.URL, bool) { return a.url, false }
+func (a *mockApplication) Channel() csparams.Channel    { return csparams.StableChannel }
+
+func (a *mockApplication) CharmUpgradePolicy() (charmupgrade.Policy, error) {
+	return a.policy, nil
+}
+
+func (a *mockApplication) SetCharmUpgradePolicy(policy charmupgrade.Policy) error {
+	a.policy = policy
+	return nil
+}
+
+func (a *mockApplication) AvailableCharmUpgrade() (string, error) {
+	return a.available, nil
+}
+
+func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
+	a.setCharm = &cfg
+	return nil
+}
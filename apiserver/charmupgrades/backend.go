@@ -0,0 +1,60 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the applications of a model and their charm upgrade
+// policies.
+type Backend interface {
+	common.ApplicationAccessBackend
+	common.BlockGetter
+	AllApplications() ([]Application, error)
+	Application(name string) (Application, error)
+}
+
+// Application is an application whose charm may be upgraded.
+type Application interface {
+	Name() string
+	CharmURL() (*charm.URL, bool)
+	CharmUpgradePolicy() (charmupgrade.Policy, error)
+	SetCharmUpgradePolicy(charmupgrade.Policy) error
+	AvailableCharmUpgrade() (string, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend returns a Backend backed by the given state.
+func NewStateBackend(st *state.State) Backend {
+	return stateShim{st}
+}
+
+func (s stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
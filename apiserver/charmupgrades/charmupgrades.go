@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmupgrades defines an API end point for reporting newer
+// revisions of the charms of a model's applications, and for setting
+// the policies that decide whether they are applied automatically.
+package charmupgrades
+
+import (
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/state"
+)
+
+func init() {
+	common.RegisterStandardFacade("CharmUpgrades", 1, newFacade)
+}
+
+// API implements the CharmUpgrades facade.
+type API struct {
+	backend Backend
+	check   *common.BlockChecker
+	access  common.ApplicationAuthFunc
+}
+
+func newFacade(st *state.State, resources *common.Resources, authorizer common.Authorizer) (*API, error) {
+	return NewAPI(NewStateBackend(st), authorizer)
+}
+
+// NewAPI returns a new CharmUpgrades API facade.
+func NewAPI(backend Backend, authorizer common.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		check:   common.NewBlockChecker(backend),
+		access:  common.NewApplicationAuthFunc(backend, authorizer),
+	}, nil
+}
+
+// UpgradesAvailable returns the charm, upgrade policy and newer charm
+// revision, if any, of every application in the model.
+func (api *API) UpgradesAvailable() (params.CharmUpgradeInfoResults, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.CharmUpgradeInfoResults{}, errors.Trace(err)
+	}
+	results := make([]params.CharmUpgradeInfo, len(apps))
+	for i, app := range apps {
+		info, err := UpgradeInfo(app)
+		if err != nil {
+			return params.CharmUpgradeInfoResults{}, errors.Trace(err)
+		}
+		results[i] = info
+	}
+	return params.CharmUpgradeInfoResults{Results: results}, nil
+}
+
+// SetUpgradePolicies sets the charm upgrade policies of applications.
+// Setting a policy requires manage access to the application.
+func (api *API) SetUpgradePolicies(args params.SetCharmUpgradePolicies) (params.ErrorResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := api.setUpgradePolicy(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+func (api *API) setUpgradePolicy(arg params.SetCharmUpgradePolicy) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.access(tag, state.ManageAccess); err != nil {
+		return errors.Trace(err)
+	}
+	policy, err := PolicyFromParams(arg.Policy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetCharmUpgradePolicy(policy)
+}
+
+// UpgradeInfo returns the charm upgrade information for the
+// application. The newer revision is only reported if the
+// application's policy asks for it.
+func UpgradeInfo(app Application) (params.CharmUpgradeInfo, error) {
+	curl, _ := app.CharmURL()
+	policy, err := app.CharmUpgradePolicy()
+	if err != nil {
+		return params.CharmUpgradeInfo{}, errors.Trace(err)
+	}
+	info := params.CharmUpgradeInfo{
+		ApplicationTag: names.NewApplicationTag(app.Name()).String(),
+		CharmURL:       curl.String(),
+		Policy:         PolicyParams(policy),
+	}
+	if policy.Reports() {
+		info.Available, err = app.AvailableCharmUpgrade()
+		if err != nil {
+			return params.CharmUpgradeInfo{}, errors.Trace(err)
+		}
+	}
+	return info, nil
+}
+
+// PolicyParams returns the API representation of the policy.
+func PolicyParams(policy charmupgrade.Policy) params.CharmUpgradePolicy {
+	return params.CharmUpgradePolicy{
+		Mode:    string(policy.Mode),
+		Channel: string(policy.Channel),
+		Window:  policy.Window.String(),
+	}
+}
+
+// PolicyFromParams returns the policy described by p.
+func PolicyFromParams(p params.CharmUpgradePolicy) (charmupgrade.Policy, error) {
+	window, err := charmupgrade.ParseWindow(p.Window)
+	if err != nil {
+		return charmupgrade.Policy{}, errors.Trace(err)
+	}
+	policy := charmupgrade.Policy{
+		Mode:    charmupgrade.Mode(p.Mode),
+		Channel: csparams.Channel(p.Channel),
+		Window:  window,
+	}
+	if err := policy.Validate(); err != nil {
+		return charmupgrade.Policy{}, errors.Trace(err)
+	}
+	return policy, nil
+}
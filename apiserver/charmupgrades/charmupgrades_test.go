@@ -0,0 +1,193 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrades_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	facade "github.com/juju/juju/apiserver/charmupgrades"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/state"
+)
+
+type charmUpgradesSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *facade.API
+}
+
+var _ = gc.Suite(&charmUpgradesSuite{})
+
+func (s *charmUpgradesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin@local"),
+	}
+	s.backend = mockBackend{
+		access: state.AdminAccess,
+		apps: []*mockApplication{{
+			name:      "mysql",
+			url:       charm.MustParseURL("cs:trusty/mysql-3"),
+			policy:    charmupgrade.DefaultPolicy,
+			available: "cs:trusty/mysql-5",
+		}, {
+			name:      "wordpress",
+			url:       charm.MustParseURL("cs:trusty/wordpress-1"),
+			policy:    charmupgrade.Policy{Mode: charmupgrade.None},
+			available: "cs:trusty/wordpress-2",
+		}},
+	}
+	var err error
+	s.api, err = facade.NewAPI(&s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *charmUpgradesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := facade.NewAPI(&s.backend, &s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *charmUpgradesSuite) TestUpgradesAvailable(c *gc.C) {
+	result, err := s.api.UpgradesAvailable()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmUpgradeInfoResults{
+		Results: []params.CharmUpgradeInfo{{
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-3",
+			Available:      "cs:trusty/mysql-5",
+			Policy:         params.CharmUpgradePolicy{Mode: "notify"},
+		}, {
+			// Applications that ignore upgrades have none reported.
+			ApplicationTag: "application-wordpress",
+			CharmURL:       "cs:trusty/wordpress-1",
+			Policy:         params.CharmUpgradePolicy{Mode: "none"},
+		}},
+	})
+}
+
+func (s *charmUpgradesSuite) TestSetUpgradePolicies(c *gc.C) {
+	result, err := s.api.SetUpgradePolicies(params.SetCharmUpgradePolicies{
+		Args: []params.SetCharmUpgradePolicy{{
+			ApplicationTag: "application-mysql",
+			Policy: params.CharmUpgradePolicy{
+				Mode:    "auto-in-window",
+				Channel: "candidate",
+				Window:  "02:00-03:30",
+			},
+		}, {
+			ApplicationTag: "application-mysql",
+			Policy:         params.CharmUpgradePolicy{Mode: "auto-in-window"},
+		}, {
+			ApplicationTag: "application-mysql",
+			Policy:         params.CharmUpgradePolicy{Mode: "notify", Window: "nightly"},
+		}, {
+			ApplicationTag: "machine-0",
+			Policy:         params.CharmUpgradePolicy{Mode: "none"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `"auto-in-window" policy without maintenance window not valid`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `maintenance window "nightly" not valid`)
+	c.Check(result.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+
+	c.Assert(s.backend.apps[0].policy, gc.Equals, charmupgrade.Policy{
+		Mode:    charmupgrade.AutoInWindow,
+		Channel: "candidate",
+		Window:  charmupgrade.Window{Start: 2 * time.Hour, Duration: 90 * time.Minute},
+	})
+}
+
+func (s *charmUpgradesSuite) TestSetUpgradePoliciesReadOnlyUser(c *gc.C) {
+	s.backend.access = state.ReadAccess
+	result, err := s.api.SetUpgradePolicies(params.SetCharmUpgradePolicies{
+		Args: []params.SetCharmUpgradePolicy{{
+			ApplicationTag: "application-mysql",
+			Policy:         params.CharmUpgradePolicy{Mode: "none"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "permission denied")
+	c.Assert(s.backend.apps[0].policy, gc.Equals, charmupgrade.DefaultPolicy)
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	access state.Access
+	apps   []*mockApplication
+}
+
+func (b *mockBackend) EffectiveModelAccess(user names.UserTag) (state.Access, error) {
+	b.MethodCall(b, "EffectiveModelAccess", user)
+	return b.access, b.NextErr()
+}
+
+func (b *mockBackend) ApplicationAccess(application string, user names.UserTag) (state.Access, error) {
+	b.MethodCall(b, "ApplicationAccess", application, user)
+	return "", errors.NotFoundf("access")
+}
+
+func (b *mockBackend) ApplicationHasGrants(application string) (bool, error) {
+	b.MethodCall(b, "ApplicationHasGrants", application)
+	return false, b.NextErr()
+}
+
+func (b *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	b.MethodCall(b, "GetBlockForType", t)
+	return nil, false, b.NextErr()
+}
+
+func (b *mockBackend) AllApplications() ([]facade.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	result := make([]facade.Application, len(b.apps))
+	for i, app := range b.apps {
+		result[i] = app
+	}
+	return result, b.NextErr()
+}
+
+func (b *mockBackend) Application(name string) (facade.Application, error) {
+	b.MethodCall(b, "Application", name)
+	for _, app := range b.apps {
+		if app.name == name {
+			return app, nil
+		}
+	}
+	return nil, errors.NotFoundf("application %q", name)
+}
+
+type mockApplication struct {
+	name      string
+	url       *charm.URL
+	policy    charmupgrade.Policy
+	available string
+}
+
+func (a *mockApplication) Name() string                 { return a.name }
+func (a *mockApplication) CharmURL() (*charm.URL, bool) { return a.url, false }
+
+func (a *mockApplication) CharmUpgradePolicy() (charmupgrade.Policy, error) {
+	return a.policy, nil
+}
+
+func (a *mockApplication) SetCharmUpgradePolicy(policy charmupgrade.Policy) error {
+	a.policy = policy
+	return nil
+}
+
+func (a *mockApplication) AvailableCharmUpgrade() (string, error) {
+	return a.available, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/application"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/upgrades"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -462,12 +464,204 @@ func (c *Client) AbortCurrentUpgrade() error {
 	return c.api.stateAccessor.AbortCurrentUpgrade()
 }
 
+// SetUpgradePlan plans the rollout of an upgrade of the model to the
+// given version. The canary machines are upgraded first, followed by
+// the remaining machines in batches of the given size, pausing between
+// batches. Controllers are always upgraded first, and containers are
+// upgraded with their host. The rollout starts once the model's agent
+// version is set to the planned version.
+func (c *Client) SetUpgradePlan(args params.UpgradePlanArgs) error {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	if args.BatchSize < 0 {
+		return errors.NotValidf("negative batch size %d", args.BatchSize)
+	}
+	machines, err := c.api.stateAccessor.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	canaries := set.NewStrings(args.Canaries...)
+	var remaining []string
+	for _, machine := range machines {
+		id := machine.Id()
+		if machine.IsManager() || state.ParentId(id) != "" || canaries.Contains(id) {
+			continue
+		}
+		remaining = append(remaining, id)
+	}
+	return c.api.stateAccessor.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: args.Version,
+		Batches:       upgradeBatches(args.Canaries, remaining, args.BatchSize),
+		Pause:         args.Pause,
+	})
+}
+
+// upgradeBatches returns the batches in which the canary machines, and
+// then the other machines, are upgraded.
+func upgradeBatches(canaries, machineIds []string, batchSize int) [][]string {
+	var batches [][]string
+	if len(canaries) > 0 {
+		batches = append(batches, canaries)
+	}
+	if batchSize <= 0 {
+		batchSize = len(machineIds)
+	}
+	for len(machineIds) > 0 {
+		size := batchSize
+		if size > len(machineIds) {
+			size = len(machineIds)
+		}
+		batches = append(batches, machineIds[:size])
+		machineIds = machineIds[size:]
+	}
+	return batches
+}
+
+// RemoveUpgradePlan removes the model's upgrade plan, releasing any
+// machines it still holds back to upgrade.
+func (c *Client) RemoveUpgradePlan() error {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.RemoveUpgradePlan()
+}
+
+// RollbackCurrentUpgrade asks the master controller to roll back the
+// current upgrade, whose controller upgrade steps have failed, to the
+// state it was in before the upgrade.
+func (c *Client) RollbackCurrentUpgrade() error {
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.RollbackCurrentUpgrade()
+}
+
+// CheckUpgrade reports whether an upgrade of the model to the given
+// version could be expected to complete, and the upgrade steps it would
+// run. Nothing is changed.
+//
+// The pre-upgrade checks that the agents make are run against the
+// controller machine serving the request, so clients of HA controllers
+// need to make the request to each controller; the agents of all
+// machines and units in the model are checked for presence.
+//
+// Upgrade steps are defined by the version of Juju that introduces
+// them, so when the controller is running an earlier version than the
+// one being upgraded to, only the steps it knows of are reported.
+func (c *Client) CheckUpgrade(args params.SetModelAgentVersion) (params.UpgradeCheckResult, error) {
+	var result params.UpgradeCheckResult
+	cfg, err := c.api.stateAccessor.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	current, ok := cfg.AgentVersion()
+	if !ok {
+		return result, errors.New("incomplete model configuration")
+	}
+
+	mongoVersion, err := c.api.stateAccessor.MongoVersion()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := upgrades.CheckMongoVersion(mongoVersion); err != nil {
+		result.Problems = append(result.Problems, err.Error())
+	}
+	if dataDir, ok := c.api.resources.Get("dataDir").(common.StringResource); ok {
+		if err := upgrades.PreUpgradeChecks(dataDir.String()); err != nil {
+			result.Problems = append(result.Problems, err.Error())
+		}
+	}
+	if machineID, ok := c.api.resources.Get("machineID").(common.StringResource); ok {
+		result.Controller = machineID.String()
+	}
+
+	result.DownAgents, err = c.downAgents()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.StepsVersion = args.Version
+	if args.Version.Compare(jujuversion.Current) > 0 {
+		result.StepsVersion = jujuversion.Current
+	}
+	for _, step := range upgrades.PlannedSteps(current, args.Version) {
+		targets := make([]string, len(step.Targets))
+		for i, target := range step.Targets {
+			targets[i] = string(target)
+		}
+		result.Steps = append(result.Steps, params.UpgradeStep{
+			Version:     step.Version,
+			Description: step.Description,
+			Targets:     targets,
+		})
+	}
+	return result, nil
+}
+
+// downAgents returns the tags of the agents of the model's provisioned
+// machines, and of the units assigned to them, that are not running.
+func (c *Client) downAgents() ([]string, error) {
+	machines, err := c.api.stateAccessor.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var down []string
+	provisioned := make(map[string]bool)
+	for _, m := range machines {
+		if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		provisioned[m.Id()] = true
+		alive, err := m.AgentPresence()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !alive {
+			down = append(down, m.Tag().String())
+		}
+	}
+	applications, err := c.api.stateAccessor.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, application := range applications {
+		units, err := application.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			machineId, err := unit.AssignedMachineId()
+			if errors.IsNotAssigned(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !provisioned[machineId] {
+				continue
+			}
+			alive, err := unit.AgentPresence()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !alive {
+				down = append(down, unit.Tag().String())
+			}
+		}
+	}
+	return down, nil
+}
+
 // FindTools returns a List containing all tools matching the given parameters.
 func (c *Client) FindTools(args params.FindToolsParams) (params.FindToolsResult, error) {
 	return c.api.toolsFinder.FindTools(args)
 }
 
 func (c *Client) AddCharm(args params.AddCharm) error {
+	if err := c.checkCanAddCharm(); err != nil {
+		return errors.Trace(err)
+	}
 	return application.AddCharmWithAuthorization(c.api.state(), params.AddCharmWithAuthorization{
 		URL:     args.URL,
 		Channel: args.Channel,
@@ -481,9 +675,43 @@ func (c *Client) AddCharm(args params.AddCharm) error {
 // The authorization macaroon, args.CharmStoreMacaroon, may be
 // omitted, in which case this call is equivalent to AddCharm.
 func (c *Client) AddCharmWithAuthorization(args params.AddCharmWithAuthorization) error {
+	if err := c.checkCanAddCharm(); err != nil {
+		return errors.Trace(err)
+	}
 	return application.AddCharmWithAuthorization(c.api.state(), args)
 }
 
+// checkCanAddCharm returns an error unless the authenticated user may
+// add charms to the model. Read only users may do so only if they
+// have been granted manage access on an application, so that they can
+// upgrade its charm.
+func (c *Client) checkCanAddCharm() error {
+	user, ok := c.api.auth.GetAuthTag().(names.UserTag)
+	if !ok {
+		return common.ErrPerm
+	}
+	st := c.api.state()
+	access, err := st.EffectiveModelAccess(user)
+	if errors.IsNotFound(err) {
+		// Users without model access, such as controller
+		// administrators, were authorised when they logged in.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if access != state.ReadAccess {
+		return nil
+	}
+	manages, err := st.ManagesApplications(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !manages {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // ResolveCharm resolves the best available charm URLs with series, for charm
 // locations without a series specified.
 func (c *Client) ResolveCharms(args params.ResolveCharms) (params.ResolveCharmResults, error) {
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -38,6 +39,7 @@ import (
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	"github.com/juju/juju/upgrades"
 	jujuversion "github.com/juju/juju/version"
 	"github.com/juju/juju/worker"
 )
@@ -179,6 +181,140 @@ func (s *serverSuite) TestSetEnvironAgentVersion(c *gc.C) {
 	c.Assert(agentVersion, gc.Equals, "9.8.7")
 }
 
+func (s *serverSuite) TestCheckUpgrade(c *gc.C) {
+	running := s.Factory.MakeMachine(c, nil)
+	s.setAgentPresence(c, running.Id())
+	down := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: down})
+	// Agents are not expected on machines that are not provisioned yet.
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.CheckUpgrade(params.SetModelAgentVersion{
+		Version: version.MustParse("9.8.7"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Problems, gc.HasLen, 0)
+	c.Assert(result.DownAgents, jc.SameContents, []string{
+		down.Tag().String(), unit.Tag().String(),
+	})
+	// The controller lists the steps it knows of; those added by
+	// later versions than its own are not.
+	c.Assert(result.StepsVersion, gc.Equals, jujuversion.Current)
+	c.Assert(result.Steps, jc.DeepEquals, plannedSteps(c, s.State, version.MustParse("9.8.7")))
+
+	// Nothing has changed.
+	modelConfig, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, _ := modelConfig.AgentVersion()
+	c.Assert(agentVersion, gc.Not(gc.Equals), version.MustParse("9.8.7"))
+}
+
+func (s *serverSuite) TestSetUpgradePlan(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	m1 := s.Factory.MakeMachine(c, nil)
+	m2 := s.Factory.MakeMachine(c, nil)
+	m3 := s.Factory.MakeMachine(c, nil)
+	_, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m2.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.client.SetUpgradePlan(params.UpgradePlanArgs{
+		Version:   version.MustParse("9.8.7"),
+		Canaries:  []string{m3.Id()},
+		BatchSize: 1,
+		Pause:     time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The controller and the container are not planned.
+	plan, err := s.State.UpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.TargetVersion(), gc.Equals, version.MustParse("9.8.7"))
+	c.Check(plan.Batches(), jc.DeepEquals, [][]string{{m3.Id()}, {m1.Id()}, {m2.Id()}})
+	c.Check(plan.Pause(), gc.Equals, time.Hour)
+
+	err = s.client.RemoveUpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UpgradePlan()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *serverSuite) TestSetUpgradePlanNegativeBatchSize(c *gc.C) {
+	err := s.client.SetUpgradePlan(params.UpgradePlanArgs{
+		Version:   version.MustParse("9.8.7"),
+		BatchSize: -1,
+	})
+	c.Assert(err, gc.ErrorMatches, "negative batch size -1 not valid")
+}
+
+func (s *serverSuite) TestCheckUpgradeDiskSpace(c *gc.C) {
+	resources := common.NewResources()
+	resources.RegisterNamed("dataDir", common.StringResource(c.MkDir()))
+	resources.RegisterNamed("machineID", common.StringResource("1"))
+	apiClient, err := client.NewClient(s.State, resources, testing.FakeAuthorizer{
+		Tag:            s.AdminUserTag(c),
+		EnvironManager: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&upgrades.MinDiskSpaceMib, uint64(humanize.PiByte/humanize.MiByte))
+
+	result, err := apiClient.CheckUpgrade(params.SetModelAgentVersion{
+		Version: version.MustParse("9.8.7"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Controller, gc.Equals, "1")
+	c.Assert(result.Problems, gc.HasLen, 1)
+	c.Assert(result.Problems[0], gc.Matches, "not enough free disk space for upgrade: .*")
+}
+
+func (s *serverSuite) TestCheckUpgradeStepsToCurrentVersion(c *gc.C) {
+	result, err := s.client.CheckUpgrade(params.SetModelAgentVersion{
+		Version: jujuversion.Current,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.StepsVersion, gc.Equals, jujuversion.Current)
+	c.Assert(result.Steps, jc.DeepEquals, plannedSteps(c, s.State, jujuversion.Current))
+}
+
+func (s *serverSuite) TestCheckUpgradeStepsFromEarlierVersion(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{"agent-version": "1.25.0"}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.client.CheckUpgrade(params.SetModelAgentVersion{
+		Version: version.MustParse("9.8.7"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.StepsVersion, gc.Equals, jujuversion.Current)
+	c.Assert(result.Steps, gc.Not(gc.HasLen), 0)
+	c.Assert(result.Steps, jc.DeepEquals, plannedSteps(c, s.State, version.MustParse("9.8.7")))
+}
+
+// plannedSteps returns the steps that CheckUpgrade is expected to
+// report for an upgrade of the model to the given version.
+func plannedSteps(c *gc.C, st *state.State, to version.Number) []params.UpgradeStep {
+	cfg, err := st.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	from, _ := cfg.AgentVersion()
+	var steps []params.UpgradeStep
+	for _, step := range upgrades.PlannedSteps(from, to) {
+		targets := make([]string, len(step.Targets))
+		for i, target := range step.Targets {
+			targets[i] = string(target)
+		}
+		steps = append(steps, params.UpgradeStep{
+			Version:     step.Version,
+			Description: step.Description,
+			Targets:     targets,
+		})
+	}
+	return steps
+}
+
 type mockEnviron struct {
 	environs.Environ
 	allInstancesCalled bool
@@ -280,6 +416,37 @@ func (s *serverSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	c.Assert(isUpgrading, jc.IsFalse)
 }
 
+func (s *serverSuite) TestRollbackCurrentUpgrade(c *gc.C) {
+	machine, err := s.State.AddMachine("series", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned(instance.Id("i-blah"), "fake-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Start an upgrade whose steps fail.
+	info, err := s.State.EnsureUpgradeInfo(
+		machine.Id(),
+		version.MustParse("1.2.3"),
+		version.MustParse("9.8.7"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetSnapshot(machine.Id(), "/var/lib/juju/upgrade-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetStatus(state.UpgradeRunning)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.client.RollbackCurrentUpgrade()
+	c.Assert(err, gc.ErrorMatches, `cannot roll back upgrade with status "running"`)
+
+	err = info.SetStatus(state.UpgradeFailed)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.RollbackCurrentUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status(), gc.Equals, state.UpgradeRollingBack)
+}
+
 func (s *serverSuite) assertAbortCurrentUpgradeBlocked(c *gc.C, msg string) {
 	err := s.client.AbortCurrentUpgrade()
 	s.AssertBlocked(c, err, msg)
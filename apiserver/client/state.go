@@ -62,6 +62,10 @@ type stateInterface interface {
 	RemoveModelUser(names.UserTag) error
 	Watch() *state.Multiwatcher
 	AbortCurrentUpgrade() error
+	RollbackCurrentUpgrade() error
+	SetUpgradePlan(state.UpgradePlanArgs) error
+	RemoveUpgradePlan() error
+	MongoVersion() (string, error)
 	APIHostPorts() ([][]network.HostPort, error)
 }
 
@@ -17,12 +17,13 @@ import (
 // near future, full ACL support is desirable.
 type clientAuthRoot struct {
 	finder rpc.MethodFinder
-	user   *state.ModelUser
+	access state.Access
 }
 
-// newClientAuthRoot returns a new restrictedRoot.
-func newClientAuthRoot(finder rpc.MethodFinder, user *state.ModelUser) *clientAuthRoot {
-	return &clientAuthRoot{finder, user}
+// newClientAuthRoot returns a new restrictedRoot for a user with the
+// given effective access to the model.
+func newClientAuthRoot(finder rpc.MethodFinder, access state.Access) *clientAuthRoot {
+	return &clientAuthRoot{finder, access}
 }
 
 // FindMethod returns a not supported error if the rootName is not one of the
@@ -35,16 +36,17 @@ func (r *clientAuthRoot) FindMethod(rootName string, version int, methodName str
 		return nil, err
 	}
 	// ReadOnly User
-	if r.user.IsReadOnly() {
+	if r.access == state.ReadAccess {
 		canCall := isCallAllowableByReadOnlyUser(rootName, methodName) ||
-			isCallReadOnly(rootName, methodName)
+			isCallReadOnly(rootName, methodName) ||
+			isCallApplicationScoped(rootName, methodName)
 		if !canCall {
 			return nil, errors.Trace(common.ErrPerm)
 		}
 	}
 
 	// Check if our call requires higher access than the user has.
-	if doesCallRequireAdmin(rootName, methodName) && !r.user.IsAdmin() {
+	if doesCallRequireAdmin(rootName, methodName) && r.access != state.AdminAccess {
 		return nil, errors.Trace(common.ErrPerm)
 	}
 
@@ -8,7 +8,6 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/common"
-	"github.com/juju/juju/testing/factory"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -43,8 +42,7 @@ func (s *clientAuthRootSuite) AssertCallErrPerm(c *gc.C, client *clientAuthRoot,
 }
 
 func (s *clientAuthRootSuite) TestNormalUser(c *gc.C) {
-	modelUser := s.Factory.MakeModelUser(c, nil)
-	client := newClientAuthRoot(&fakeFinder{}, modelUser)
+	client := newClientAuthRoot(&fakeFinder{}, state.WriteAccess)
 	s.AssertCallGood(c, client, "Application", 1, "Deploy")
 	s.AssertCallGood(c, client, "UserManager", 1, "UserInfo")
 	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
@@ -52,14 +50,19 @@ func (s *clientAuthRootSuite) TestNormalUser(c *gc.C) {
 }
 
 func (s *clientAuthRootSuite) TestReadOnlyUser(c *gc.C) {
-	modelUser := s.Factory.MakeModelUser(c, &factory.ModelUserParams{Access: state.ReadAccess})
-	client := newClientAuthRoot(&fakeFinder{}, modelUser)
+	client := newClientAuthRoot(&fakeFinder{}, state.ReadAccess)
 	// deploys are bad
 	s.AssertCallErrPerm(c, client, "Application", 1, "Deploy")
 	// read only commands are fine
 	s.AssertCallGood(c, client, "Client", 1, "FullStatus")
 	// calls on the restricted root is also fine
 	s.AssertCallGood(c, client, "UserManager", 1, "AddUser")
+	// application-scoped calls are checked by the facade
+	s.AssertCallGood(c, client, "Application", 1, "Set")
+	s.AssertCallGood(c, client, "Action", 2, "Enqueue")
+	s.AssertCallErrPerm(c, client, "Action", 2, "RunOnAllMachines")
+	s.AssertCallGood(c, client, "Client", 1, "AddCharm")
+	s.AssertCallGood(c, client, "Resources", 1, "AddPendingResources")
 	s.AssertCallNotImplemented(c, client, "Client", 1, "Unknown")
 	s.AssertCallNotImplemented(c, client, "Unknown", 1, "Method")
 }
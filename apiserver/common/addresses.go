@@ -4,7 +4,10 @@
 package common
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
@@ -19,6 +22,7 @@ type AddressAndCertGetter interface {
 	ModelUUID() string
 	APIHostPorts() ([][]network.HostPort, error)
 	WatchAPIHostPorts() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
 }
 
 // APIAddresser implements the APIAddresses method
@@ -36,9 +40,10 @@ func NewAPIAddresser(getter AddressAndCertGetter, resources *Resources) *APIAddr
 	}
 }
 
-// APIHostPorts returns the API server addresses.
+// APIHostPorts returns the API server addresses. When the controller has
+// a management space configured, only addresses in that space are returned.
 func (api *APIAddresser) APIHostPorts() (params.APIHostPortsResult, error) {
-	servers, err := api.getter.APIHostPorts()
+	servers, err := apiHostPortsInManagementSpace(api.getter)
 	if err != nil {
 		return params.APIHostPortsResult{}, err
 	}
@@ -59,8 +64,10 @@ func (api *APIAddresser) WatchAPIHostPorts() (params.NotifyWatchResult, error) {
 }
 
 // APIAddresses returns the list of addresses used to connect to the API.
+// When the controller has a management space configured, only addresses in
+// that space are returned.
 func (api *APIAddresser) APIAddresses() (params.StringsResult, error) {
-	addrs, err := apiAddresses(api.getter)
+	addrs, err := apiAddresses(managementSpaceGetter{api.getter})
 	if err != nil {
 		return params.StringsResult{}, err
 	}
@@ -86,6 +93,49 @@ func apiAddresses(getter APIHostPortsGetter) ([]string, error) {
 	return addrs, nil
 }
 
+// managementSpaceGetter wraps an AddressAndCertGetter, filtering the API
+// host ports it returns by the controller's management space.
+type managementSpaceGetter struct {
+	AddressAndCertGetter
+}
+
+// APIHostPorts is part of the APIHostPortsGetter interface.
+func (g managementSpaceGetter) APIHostPorts() ([][]network.HostPort, error) {
+	return apiHostPortsInManagementSpace(g.AddressAndCertGetter)
+}
+
+// apiHostPortsInManagementSpace returns the API host ports of all
+// controllers, keeping only addresses in the juju-mgmt-space, if set.
+// Controllers without any addresses in that space are omitted. If no
+// controller has an address in the space, all host ports are returned
+// unfiltered, so agents can still connect.
+func apiHostPortsInManagementSpace(getter AddressAndCertGetter) ([][]network.HostPort, error) {
+	servers, err := getter.APIHostPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config, err := getter.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	space := network.SpaceName(config.JujuManagementSpace())
+	if space == "" {
+		return servers, nil
+	}
+
+	filtered := make([][]network.HostPort, 0, len(servers))
+	for _, hostPorts := range servers {
+		if inSpace, ok := network.SelectHostsPortBySpaces(hostPorts, space); ok {
+			filtered = append(filtered, inSpace)
+		}
+	}
+	if len(filtered) == 0 {
+		logger.Warningf("no API addresses in %s %q, using all addresses", controller.JujuManagementSpace, space)
+		return servers, nil
+	}
+	return filtered, nil
+}
+
 // CACert returns the certificate used to validate the state connection.
 func (a *APIAddresser) CACert() params.BytesResult {
 	return params.BytesResult{
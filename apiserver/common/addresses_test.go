@@ -8,6 +8,8 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -88,6 +90,43 @@ func (s *apiAddresserSuite) TestAPIAddressesPrivateFirst(c *gc.C) {
 	})
 }
 
+func (s *apiAddresserSuite) setHostPortsInSpaces() {
+	s.fake.hostPorts = [][]network.HostPort{{
+		hostPortInSpace("mgmt", "10.0.0.1"),
+		hostPortInSpace("public", "52.7.1.1"),
+	}, {
+		hostPortInSpace("public", "53.51.121.17"),
+	}, {
+		hostPortInSpace("mgmt", "10.0.0.2"),
+	}}
+}
+
+func (s *apiAddresserSuite) TestAPIHostPortsInManagementSpace(c *gc.C) {
+	s.setHostPortsInSpaces()
+	s.fake.mgmtSpace = "mgmt"
+
+	result, err := s.addresser.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.NetworkHostsPorts(result.Servers), gc.DeepEquals, [][]network.HostPort{{
+		hostPortInSpace("mgmt", "10.0.0.1"),
+	}, {
+		hostPortInSpace("mgmt", "10.0.0.2"),
+	}})
+
+	addrs, err := s.addresser.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs.Result, gc.DeepEquals, []string{"10.0.0.1:17070", "10.0.0.2:17070"})
+}
+
+func (s *apiAddresserSuite) TestAPIHostPortsWithoutAddressesInManagementSpace(c *gc.C) {
+	s.setHostPortsInSpaces()
+	s.fake.mgmtSpace = "other"
+
+	result, err := s.addresser.APIHostPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.NetworkHostsPorts(result.Servers), gc.DeepEquals, s.fake.hostPorts)
+}
+
 func (s *apiAddresserSuite) TestCACert(c *gc.C) {
 	result := s.addresser.CACert()
 	c.Assert(string(result.Result), gc.Equals, "a cert")
@@ -102,6 +141,7 @@ var _ common.AddressAndCertGetter = fakeAddresses{}
 
 type fakeAddresses struct {
 	hostPorts [][]network.HostPort
+	mgmtSpace string
 }
 
 func (fakeAddresses) Addresses() ([]string, error) {
@@ -127,3 +167,14 @@ func (f fakeAddresses) APIHostPorts() ([][]network.HostPort, error) {
 func (fakeAddresses) WatchAPIHostPorts() state.NotifyWatcher {
 	panic("should never be called")
 }
+
+func (f fakeAddresses) ControllerConfig() (controller.Config, error) {
+	return controller.Config{controller.JujuManagementSpace: f.mgmtSpace}, nil
+}
+
+func hostPortInSpace(space, value string) network.HostPort {
+	return network.HostPort{
+		Address: network.NewAddressOnSpace(space, value),
+		Port:    17070,
+	}
+}
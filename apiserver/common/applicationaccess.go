@@ -0,0 +1,88 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// ApplicationAccessBackend defines the state methods required to check
+// application-scoped access.
+type ApplicationAccessBackend interface {
+	EffectiveModelAccess(names.UserTag) (state.Access, error)
+	ApplicationAccess(string, names.UserTag) (state.Access, error)
+	ApplicationHasGrants(string) (bool, error)
+}
+
+// ApplicationAuthFunc returns nil if the authenticated user may perform
+// an operation requiring the given access on the entity with the given
+// tag, and ErrPerm otherwise.
+type ApplicationAuthFunc func(tag names.Tag, access state.Access) error
+
+// NewApplicationAuthFunc returns an ApplicationAuthFunc for the user
+// authenticated by authorizer. Model administrators may operate on any
+// entity. Users with write access to the model, granted directly or
+// through a group, may operate on any entity other than applications,
+// and their units, on which access has been granted to anyone; those
+// need a grant of their own. Read only users may only operate on
+// applications, and their units, on which they have been granted
+// access. ManageAccess implies OperateAccess.
+func NewApplicationAuthFunc(st ApplicationAccessBackend, authorizer Authorizer) ApplicationAuthFunc {
+	return func(tag names.Tag, access state.Access) error {
+		user, ok := authorizer.GetAuthTag().(names.UserTag)
+		if !ok {
+			return ErrPerm
+		}
+		modelAccess, err := st.EffectiveModelAccess(user)
+		if errors.IsNotFound(err) {
+			// Users without model access, such as controller
+			// administrators, were authorised when they logged in.
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if modelAccess == state.AdminAccess {
+			return nil
+		}
+		canWrite := modelAccess != state.ReadAccess
+
+		var application string
+		switch tag := tag.(type) {
+		case names.ApplicationTag:
+			application = tag.Id()
+		case names.UnitTag:
+			application, err = names.UnitApplication(tag.Id())
+			if err != nil {
+				return errors.Trace(err)
+			}
+		default:
+			if canWrite {
+				return nil
+			}
+			return ErrPerm
+		}
+		if canWrite {
+			restricted, err := st.ApplicationHasGrants(application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !restricted {
+				return nil
+			}
+		}
+		granted, err := st.ApplicationAccess(application, user)
+		if errors.IsNotFound(err) {
+			return ErrPerm
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if granted == access || granted == state.ManageAccess {
+			return nil
+		}
+		return ErrPerm
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type applicationAccessSuite struct {
+	testing.JujuConnSuite
+}
+
+var _ = gc.Suite(&applicationAccessSuite{})
+
+func (s *applicationAccessSuite) authFunc(c *gc.C, access state.Access) (common.ApplicationAuthFunc, names.UserTag) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Access: access})
+	authorizer := apiservertesting.FakeAuthorizer{Tag: user.UserTag()}
+	return common.NewApplicationAuthFunc(s.State, authorizer), user.UserTag()
+}
+
+func (s *applicationAccessSuite) TestWriteUser(c *gc.C) {
+	auth, _ := s.authFunc(c, state.WriteAccess)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.ManageAccess), jc.ErrorIsNil)
+	c.Check(auth(names.NewMachineTag("0"), state.OperateAccess), jc.ErrorIsNil)
+}
+
+func (s *applicationAccessSuite) TestWriteUserApplicationWithGrants(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	other := s.Factory.MakeUser(c, &factory.UserParams{Access: state.ReadAccess})
+	err := s.State.SetApplicationAccess("mysql", other.UserTag(), state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once access to an application has been granted, write users
+	// need a grant of their own.
+	auth, user := s.authFunc(c, state.WriteAccess)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.OperateAccess), gc.Equals, common.ErrPerm)
+	c.Check(auth(names.NewUnitTag("mysql/0"), state.OperateAccess), gc.Equals, common.ErrPerm)
+	c.Check(auth(names.NewApplicationTag("wordpress"), state.ManageAccess), jc.ErrorIsNil)
+	c.Check(auth(names.NewMachineTag("0"), state.OperateAccess), jc.ErrorIsNil)
+
+	err = s.State.SetApplicationAccess("mysql", user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.OperateAccess), jc.ErrorIsNil)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.ManageAccess), gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationAccessSuite) TestAdminUserApplicationWithGrants(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	other := s.Factory.MakeUser(c, &factory.UserParams{Access: state.ReadAccess})
+	err := s.State.SetApplicationAccess("mysql", other.UserTag(), state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	auth, _ := s.authFunc(c, state.AdminAccess)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.ManageAccess), jc.ErrorIsNil)
+}
+
+func (s *applicationAccessSuite) TestReadOnlyUserWithoutGrant(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	auth, _ := s.authFunc(c, state.ReadAccess)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.OperateAccess), gc.Equals, common.ErrPerm)
+	c.Check(auth(names.NewMachineTag("0"), state.OperateAccess), gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationAccessSuite) TestReadOnlyUserWithOperate(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	auth, user := s.authFunc(c, state.ReadAccess)
+	err := s.State.SetApplicationAccess("mysql", user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(auth(names.NewApplicationTag("mysql"), state.OperateAccess), jc.ErrorIsNil)
+	c.Check(auth(names.NewUnitTag("mysql/0"), state.OperateAccess), jc.ErrorIsNil)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.ManageAccess), gc.Equals, common.ErrPerm)
+	c.Check(auth(names.NewApplicationTag("wordpress"), state.OperateAccess), gc.Equals, common.ErrPerm)
+	c.Check(auth(names.NewMachineTag("0"), state.OperateAccess), gc.Equals, common.ErrPerm)
+}
+
+func (s *applicationAccessSuite) TestReadOnlyUserWithManage(c *gc.C) {
+	s.Factory.MakeApplication(c, nil)
+	auth, user := s.authFunc(c, state.ReadAccess)
+	err := s.State.SetApplicationAccess("mysql", user, state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(auth(names.NewApplicationTag("mysql"), state.OperateAccess), jc.ErrorIsNil)
+	c.Check(auth(names.NewApplicationTag("mysql"), state.ManageAccess), jc.ErrorIsNil)
+}
+
+func (s *applicationAccessSuite) TestReadOnlyUserWithGroupWriteAccess(c *gc.C) {
+	auth, user := s.authFunc(c, state.ReadAccess)
+	group, err := s.State.AddUserGroup("devs", s.AdminUserTag(c).Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(user), jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("devs", state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(auth(names.NewMachineTag("0"), state.OperateAccess), jc.ErrorIsNil)
+}
@@ -90,6 +90,8 @@ var (
 	ErrBadId              = errors.New("id not found")
 	ErrBadCreds           = errors.New("invalid entity name or password")
 	ErrLoginExpired       = errors.New("login expired")
+	ErrUserLockedOut      = errors.New("user locked out after too many failed logins")
+	ErrPasswordExpired    = errors.New("password expired; ask a controller administrator to reset it")
 	ErrPerm               = errors.New("permission denied")
 	ErrNotLoggedIn        = errors.New("not logged in")
 	ErrUnknownWatcher     = errors.New("unknown watcher id")
@@ -124,6 +126,8 @@ var singletonErrorCodes = map[error]string{
 	ErrBadId:                     params.CodeNotFound,
 	ErrBadCreds:                  params.CodeUnauthorized,
 	ErrLoginExpired:              params.CodeLoginExpired,
+	ErrUserLockedOut:             params.CodeUserLockedOut,
+	ErrPasswordExpired:           params.CodePasswordExpired,
 	ErrPerm:                      params.CodeUnauthorized,
 	ErrNotLoggedIn:               params.CodeUnauthorized,
 	ErrUnknownWatcher:            params.CodeNotFound,
@@ -163,7 +167,7 @@ func ServerErrorAndStatus(err error) (*params.Error, int) {
 	}
 	status := http.StatusInternalServerError
 	switch err1.Code {
-	case params.CodeUnauthorized:
+	case params.CodeUnauthorized, params.CodeUserLockedOut, params.CodePasswordExpired:
 		status = http.StatusUnauthorized
 	case params.CodeNotFound:
 		status = http.StatusNotFound
@@ -83,6 +83,16 @@ var errorTransformTests = []struct {
 	code:       params.CodeUnauthorized,
 	status:     http.StatusUnauthorized,
 	helperFunc: params.IsCodeUnauthorized,
+}, {
+	err:        common.ErrUserLockedOut,
+	code:       params.CodeUserLockedOut,
+	status:     http.StatusUnauthorized,
+	helperFunc: params.IsCodeUserLockedOut,
+}, {
+	err:        common.ErrPasswordExpired,
+	code:       params.CodePasswordExpired,
+	status:     http.StatusUnauthorized,
+	helperFunc: params.IsCodePasswordExpired,
 }, {
 	err:        common.ErrPerm,
 	code:       params.CodeUnauthorized,
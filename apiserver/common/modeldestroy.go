@@ -10,8 +10,13 @@ import (
 	"github.com/juju/juju/apiserver/metricsender"
 )
 
-var sendMetrics = func(st metricsender.MetricsSenderBackend) error {
-	err := metricsender.SendMetrics(st, metricsender.DefaultMetricSender(), metricsender.DefaultMaxBatchesPerSend())
+var sendMetrics = func(st ModelManagerBackend) error {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sender := metricsender.SenderForTarget(cfg.MetricsExportTarget(), metricsender.DefaultMetricSender())
+	err = metricsender.SendMetrics(st, sender, metricsender.DefaultMaxBatchesPerSend())
 	return errors.Trace(err)
 }
 
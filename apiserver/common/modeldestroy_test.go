@@ -16,7 +16,6 @@ import (
 	"github.com/juju/juju/apiserver/client"
 	"github.com/juju/juju/apiserver/common"
 	commontesting "github.com/juju/juju/apiserver/common/testing"
-	"github.com/juju/juju/apiserver/metricsender"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/juju/testing"
@@ -85,7 +84,7 @@ type testMetricSender struct {
 	jtesting.Stub
 }
 
-func (t *testMetricSender) SendMetrics(st metricsender.MetricsSenderBackend) error {
+func (t *testMetricSender) SendMetrics(st common.ModelManagerBackend) error {
 	t.AddCall("SendMetrics")
 	return nil
 }
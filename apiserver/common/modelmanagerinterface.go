@@ -31,6 +31,7 @@ type ModelManagerBackend interface {
 	ModelUUID() string
 	ModelsForUser(names.UserTag) ([]*state.UserModel, error)
 	IsControllerAdministrator(user names.UserTag) (bool, error)
+	EffectiveControllerAccess(user names.UserTag) (state.Access, error)
 	NewModel(state.ModelArgs) (Model, ModelManagerBackend, error)
 
 	// TODO(wallyworld) - we won't need this once cloud name is stored on model
@@ -44,6 +45,9 @@ type ModelManagerBackend interface {
 	AddModelUser(state.ModelUserSpec) (*state.ModelUser, error)
 	RemoveModelUser(names.UserTag) error
 	ModelUser(names.UserTag) (*state.ModelUser, error)
+	ModelGroupAccess(group string) (state.Access, error)
+	SetModelGroupAccess(group string, access state.Access) error
+	RemoveModelGroupAccess(group string) error
 	ModelTag() names.ModelTag
 	Close() error
 }
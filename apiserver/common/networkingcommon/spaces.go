@@ -83,3 +83,28 @@ func createOneSpace(backing NetworkBacking, args params.CreateSpaceParams) error
 	}
 	return nil
 }
+
+// SupportsContainerAddresses checks if the environment supports
+// allocating static addresses for containers, returning an error
+// satisfying errors.IsNotSupported() when it does not.
+func SupportsContainerAddresses(backing environs.EnvironConfigGetter) error {
+	config, err := backing.ModelConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting model config")
+	}
+	env, err := environs.New(config)
+	if err != nil {
+		return errors.Annotate(err, "validating model config")
+	}
+	netEnv, ok := environs.SupportsNetworking(env)
+	if ok {
+		ok, err = netEnv.SupportsContainerAddresses()
+	}
+	if !ok {
+		if err != nil && !errors.IsNotSupported(err) {
+			logger.Errorf("checking container addresses support failed with: %v", err)
+		}
+		return errors.NotSupportedf("container address allocation")
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ModifyControllerAccess changes the controller access granted to users.
+func (c *ControllerAPI) ModifyControllerAccess(args params.ModifyControllerAccessRequest) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		access, err := fromControllerAccessParam(arg.Access)
+		if err != nil {
+			err = errors.Annotate(err, "could not modify controller access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if arg.GroupName != "" {
+			result.Results[i].Error = common.ServerError(
+				changeControllerGroupAccess(c.state, arg.GroupName, arg.Action, access))
+			continue
+		}
+		targetUserTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			err = errors.Annotate(err, "could not modify controller access")
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(
+			changeControllerAccess(c.state, targetUserTag, arg.Action, access))
+	}
+	return result, nil
+}
+
+// changeControllerAccess performs the requested access grant or revoke
+// action for the specified user on the controller.
+func changeControllerAccess(st *state.State, targetUserTag names.UserTag, action params.ControllerAction, access state.Access) error {
+	current, err := st.ControllerAccess(targetUserTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up controller access for user")
+	}
+	hasAccess := err == nil
+
+	switch action {
+	case params.GrantControllerAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.Includes(access) {
+			return errors.Errorf("user already has %q access or greater", access)
+		}
+		err := st.SetControllerAccess(targetUserTag, access)
+		return errors.Annotate(err, "could not grant controller access")
+
+	case params.RevokeControllerAccess:
+		if !hasAccess {
+			return errors.NotFoundf("controller access for %q", targetUserTag.Canonical())
+		}
+		switch access {
+		case state.LoginAccess:
+			// Revoking login access removes all access.
+			err := st.RemoveControllerAccess(targetUserTag)
+			return errors.Annotate(err, "could not revoke controller access")
+		case state.AddModelAccess:
+			// Revoking add-model access leaves login access.
+			err := st.SetControllerAccess(targetUserTag, state.LoginAccess)
+			return errors.Annotate(err, "could not set controller access to login")
+		case state.SuperuserAccess:
+			// Revoking superuser access leaves add-model access.
+			err := st.SetControllerAccess(targetUserTag, state.AddModelAccess)
+			return errors.Annotate(err, "could not set controller access to add-model")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
+// changeControllerGroupAccess performs the requested access grant or
+// revoke action for the named user group on the controller.
+func changeControllerGroupAccess(st *state.State, group string, action params.ControllerAction, access state.Access) error {
+	current, err := st.ControllerGroupAccess(group)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up controller access for group")
+	}
+	hasAccess := err == nil
+
+	switch action {
+	case params.GrantControllerAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.Includes(access) {
+			return errors.Errorf("group already has %q access or greater", current)
+		}
+		err := st.SetControllerGroupAccess(group, access)
+		return errors.Annotate(err, "could not grant controller access")
+
+	case params.RevokeControllerAccess:
+		if !hasAccess {
+			return errors.NotFoundf("controller access for group %q", group)
+		}
+		switch access {
+		case state.LoginAccess:
+			// Revoking login access removes all access.
+			err := st.RemoveControllerGroupAccess(group)
+			return errors.Annotate(err, "could not revoke controller access")
+		case state.AddModelAccess:
+			// Revoking add-model access leaves login access.
+			err := st.SetControllerGroupAccess(group, state.LoginAccess)
+			return errors.Annotate(err, "could not set controller access to login")
+		case state.SuperuserAccess:
+			// Revoking superuser access leaves add-model access.
+			err := st.SetControllerGroupAccess(group, state.AddModelAccess)
+			return errors.Annotate(err, "could not set controller access to add-model")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", access)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
+// fromControllerAccessParam returns the state controller access type
+// from the API wireformat type.
+func fromControllerAccessParam(paramAccess params.ControllerAccessPermission) (state.Access, error) {
+	switch paramAccess {
+	case params.ControllerLoginAccess:
+		return state.LoginAccess, nil
+	case params.ControllerAddModelAccess:
+		return state.AddModelAccess, nil
+	case params.ControllerSuperuserAccess:
+		return state.SuperuserAccess, nil
+	}
+	return state.UndefinedAccess, errors.Errorf("invalid controller access permission %q", paramAccess)
+}
@@ -16,11 +16,18 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
 
+var (
+	currentReplicaSetHealth = mongo.CurrentReplicaSetHealth
+	currentOplogInfo        = mongo.CurrentOplogInfo
+	databaseSizes           = mongo.DatabaseSizes
+)
+
 func init() {
 	common.RegisterStandardFacade("Controller", 3, NewControllerAPI)
 }
@@ -36,6 +43,8 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(req params.Entities) (params.ModelStatusResults, error)
 	InitiateModelMigration(params.InitiateModelMigrationArgs) (params.InitiateModelMigrationResults, error)
+	MongoHealth() (params.MongoHealthResult, error)
+	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -267,6 +276,70 @@ func (c *ControllerAPI) ModelStatus(req params.Entities) (params.ModelStatusResu
 	return results, nil
 }
 
+// MongoHealth reports the replication state of each member of the
+// controller's mongo replica set, the span of time covered by the
+// oplog, and the disk usage of each database and collection.
+func (c *ControllerAPI) MongoHealth() (params.MongoHealthResult, error) {
+	session := c.state.MongoSession().Copy()
+	defer session.Close()
+
+	var result params.MongoHealthResult
+	health, err := currentReplicaSetHealth(session)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.ReplicaSet = health.Name
+	result.Primary = health.Primary()
+	for _, m := range health.Members {
+		result.Members = append(result.Members, params.MongoMemberHealth{
+			Id:      m.Id,
+			Address: m.Address,
+			State:   m.State,
+			Healthy: m.Healthy,
+			Self:    m.Self,
+			Optime:  m.Optime,
+			Uptime:  m.Uptime,
+			Lag:     m.Lag,
+			Message: m.Message,
+		})
+	}
+
+	oplog, err := currentOplogInfo(session)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Oplog = params.MongoOplogInfo{
+		First:   oplog.First,
+		Last:    oplog.Last,
+		Size:    oplog.Size,
+		MaxSize: oplog.MaxSize,
+	}
+
+	databases, err := databaseSizes(session)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, db := range databases {
+		size := params.MongoDatabaseSize{
+			Name:        db.Name,
+			DataSize:    db.DataSize,
+			StorageSize: db.StorageSize,
+			IndexSize:   db.IndexSize,
+		}
+		for _, coll := range db.Collections {
+			size.Collections = append(size.Collections, params.MongoCollectionSize{
+				Name:        coll.Name,
+				Count:       coll.Count,
+				DataSize:    coll.DataSize,
+				StorageSize: coll.StorageSize,
+				IndexSize:   coll.IndexSize,
+			})
+		}
+		result.Databases = append(result.Databases, size)
+	}
+	return result, nil
+}
+
 // InitiateModelMigration attempts to begin the migration of one or
 // more models to other controllers.
 func (c *ControllerAPI) InitiateModelMigration(reqArgs params.InitiateModelMigrationArgs) (
@@ -6,11 +6,13 @@ package controller_test
 import (
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver"
 	"github.com/juju/juju/apiserver/common"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/testing"
@@ -69,6 +72,18 @@ func (s *controllerSuite) TestNewAPIRefusesNonAdmins(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *controllerSuite) TestNewAPIAcceptsSuperusers(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), state.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endPoint, err := controller.NewControllerAPI(s.State, s.resources, anAuthoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(endPoint, gc.NotNil)
+}
+
 func (s *controllerSuite) checkEnvironmentMatches(c *gc.C, env params.Model, expected *state.Model) {
 	c.Check(env.Name, gc.Equals, expected.Name())
 	c.Check(env.UUID, gc.Equals, expected.UUID())
@@ -409,6 +424,193 @@ func (s *controllerSuite) TestInitiateModelMigrationPartialFailure(c *gc.C) {
 	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
 }
 
+func (s *controllerSuite) TestMongoHealth(c *gc.C) {
+	now := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.PatchValue(controller.CurrentReplicaSetHealth, func(*mgo.Session) (*mongo.ReplicaSetHealth, error) {
+		return &mongo.ReplicaSetHealth{
+			Name: "juju",
+			Members: []mongo.MemberHealth{{
+				Id:      1,
+				Address: "10.0.0.1:37017",
+				State:   "PRIMARY",
+				Healthy: true,
+				Self:    true,
+				Optime:  now,
+				Uptime:  time.Hour,
+			}, {
+				Id:      2,
+				Address: "10.0.0.2:37017",
+				State:   "SECONDARY",
+				Healthy: true,
+				Optime:  now.Add(-time.Second),
+				Uptime:  time.Hour,
+				Lag:     time.Second,
+			}},
+		}, nil
+	})
+	s.PatchValue(controller.CurrentOplogInfo, func(*mgo.Session) (*mongo.OplogInfo, error) {
+		return &mongo.OplogInfo{
+			First:   now.Add(-48 * time.Hour),
+			Last:    now,
+			Size:    1024,
+			MaxSize: 4096,
+		}, nil
+	})
+
+	result, err := s.controller.MongoHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ReplicaSet, gc.Equals, "juju")
+	c.Check(result.Primary, gc.Equals, "10.0.0.1:37017")
+	c.Check(result.Members, jc.DeepEquals, []params.MongoMemberHealth{{
+		Id:      1,
+		Address: "10.0.0.1:37017",
+		State:   "PRIMARY",
+		Healthy: true,
+		Self:    true,
+		Optime:  now,
+		Uptime:  time.Hour,
+	}, {
+		Id:      2,
+		Address: "10.0.0.2:37017",
+		State:   "SECONDARY",
+		Healthy: true,
+		Optime:  now.Add(-time.Second),
+		Uptime:  time.Hour,
+		Lag:     time.Second,
+	}})
+	c.Check(result.Oplog, jc.DeepEquals, params.MongoOplogInfo{
+		First:   now.Add(-48 * time.Hour),
+		Last:    now,
+		Size:    1024,
+		MaxSize: 4096,
+	})
+
+	// Database sizes come from the real controller database.
+	var found bool
+	for _, db := range result.Databases {
+		if db.Name == "juju" {
+			found = true
+			c.Check(db.Collections, gc.Not(gc.HasLen), 0)
+		}
+	}
+	c.Check(found, jc.IsTrue)
+}
+
+func (s *controllerSuite) TestMongoHealthError(c *gc.C) {
+	s.PatchValue(controller.CurrentReplicaSetHealth, func(*mgo.Session) (*mongo.ReplicaSetHealth, error) {
+		return nil, errors.New("not running with --replSet")
+	})
+	_, err := s.controller.MongoHealth()
+	c.Assert(err, gc.ErrorMatches, "not running with --replSet")
+}
+
+func (s *controllerSuite) modifyControllerAccess(c *gc.C, user names.UserTag, action params.ControllerAction, access params.ControllerAccessPermission) error {
+	result, err := s.controller.ModifyControllerAccess(params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			UserTag: user.String(),
+			Action:  action,
+			Access:  access,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *controllerSuite) assertControllerAccess(c *gc.C, user names.UserTag, expected state.Access) {
+	access, err := s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, expected)
+}
+
+func (s *controllerSuite) TestGrantControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	err := s.modifyControllerAccess(c, user, params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user, state.AddModelAccess)
+
+	err = s.modifyControllerAccess(c, user, params.GrantControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user, state.SuperuserAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessExternalUser(c *gc.C) {
+	user := names.NewUserTag("bob@external")
+	err := s.modifyControllerAccess(c, user, params.GrantControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user, state.LoginAccess)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessNotGreater(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	err := s.modifyControllerAccess(c, user, params.GrantControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, gc.ErrorMatches, `user already has "login" access or greater`)
+}
+
+func (s *controllerSuite) TestGrantControllerAccessInvalid(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	err := s.modifyControllerAccess(c, user, params.GrantControllerAccess, "admin")
+	c.Assert(err, gc.ErrorMatches, `could not modify controller access: invalid controller access permission "admin"`)
+}
+
+func (s *controllerSuite) TestRevokeControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	err := s.State.SetControllerAccess(user, state.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerAccess(c, user, params.RevokeControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user, state.AddModelAccess)
+
+	err = s.modifyControllerAccess(c, user, params.RevokeControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertControllerAccess(c, user, state.LoginAccess)
+
+	err = s.modifyControllerAccess(c, user, params.RevokeControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerAccess(user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.modifyControllerAccess(c, user, params.RevokeControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, gc.ErrorMatches, `controller access for "`+user.Canonical()+`" not found`)
+}
+
+func (s *controllerSuite) modifyControllerGroupAccess(c *gc.C, group string, action params.ControllerAction, access params.ControllerAccessPermission) error {
+	result, err := s.controller.ModifyControllerAccess(params.ModifyControllerAccessRequest{
+		Changes: []params.ModifyControllerAccess{{
+			GroupName: group,
+			Action:    action,
+			Access:    access,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return result.OneError()
+}
+
+func (s *controllerSuite) TestGrantRevokeControllerGroupAccess(c *gc.C) {
+	_, err := s.State.AddUserGroup("admins", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.modifyControllerGroupAccess(c, "admins", params.GrantControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerGroupAccess("admins")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.SuperuserAccess)
+
+	err = s.modifyControllerGroupAccess(c, "admins", params.GrantControllerAccess, params.ControllerAddModelAccess)
+	c.Assert(err, gc.ErrorMatches, `group already has "superuser" access or greater`)
+
+	err = s.modifyControllerGroupAccess(c, "admins", params.RevokeControllerAccess, params.ControllerSuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ControllerGroupAccess("admins")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.AddModelAccess)
+
+	err = s.modifyControllerGroupAccess(c, "admins", params.RevokeControllerAccess, params.ControllerLoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ControllerGroupAccess("admins")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func randomModelTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewModelTag(uuid).String()
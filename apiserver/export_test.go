@@ -30,6 +30,7 @@ var (
 	BZMimeType                   = bzMimeType
 	JSMimeType                   = jsMimeType
 	SpritePath                   = spritePath
+	MaxRepositoryCharmSize       = &maxRepositoryCharmSize
 )
 
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
@@ -99,7 +100,7 @@ func TestingApiHandler(c *gc.C, srvSt, st *state.State) (*apiHandler, *common.Re
 		state:    srvSt,
 		tag:      names.NewMachineTag("0"),
 	}
-	h, err := newApiHandler(srv, st, nil, st.ModelUUID())
+	h, err := newApiHandler(srv, st, nil, st.ModelUUID(), "")
 	c.Assert(err, jc.ErrorIsNil)
 	return h, h.getResources()
 }
@@ -26,6 +26,7 @@ func init() {
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	RemoveControllerMembers(args params.RemoveControllerMembers) (params.ControllersChangeResults, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -167,6 +168,46 @@ func EnableHASingle(st *state.State, spec params.ControllersSpec) (params.Contro
 	return controllersChanges(changes), nil
 }
 
+// RemoveControllerMembers removes lost controller machines from the
+// controller, optionally providing replacements for them. The mongo
+// replica set and the published API addresses are updated by the
+// peergrouper worker.
+func (api *HighAvailabilityAPI) RemoveControllerMembers(args params.RemoveControllerMembers) (params.ControllersChangeResults, error) {
+	results := params.ControllersChangeResults{Results: make([]params.ControllersChangeResult, len(args.Members))}
+	for i, member := range args.Members {
+		result, err := removeControllerMember(api.state, member)
+		results.Results[i].Result = result
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func removeControllerMember(st *state.State, arg params.RemoveControllerMember) (params.ControllersChanges, error) {
+	if !st.IsController() {
+		return params.ControllersChanges{}, errors.New("unsupported with hosted models")
+	}
+	blockChecker := common.NewBlockChecker(st)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	changes, err := st.RemoveControllerMember(state.RemoveControllerMemberArgs{
+		MachineId:   tag.Id(),
+		Force:       arg.Force,
+		Replace:     arg.Replace,
+		Placement:   arg.Placement,
+		Constraints: arg.Constraints,
+		Series:      arg.Series,
+	})
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	return controllersChanges(changes), nil
+}
+
 // StopHAReplicationForUpgrade will prompt the HA cluster to enter upgrade
 // mongo mode.
 func (api *HighAvailabilityAPI) StopHAReplicationForUpgrade(args params.UpgradeMongoParams) (params.MongoUpgradeResults, error) {
@@ -379,3 +379,75 @@ func (s *clientSuite) TestEnableHAHostedEnvErrors(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(machines, gc.HasLen, 0)
 }
+
+func (s *clientSuite) removeControllerMember(c *gc.C, member params.RemoveControllerMember) (params.ControllersChanges, error) {
+	results, err := s.haServer.RemoveControllerMembers(params.RemoveControllerMembers{
+		Members: []params.RemoveControllerMember{member},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	err = nil
+	if result.Error != nil {
+		err = result.Error
+	}
+	return result.Result, err
+}
+
+// enableHAWithVotes makes 3 controller machines with votes, of which
+// machine 2 has no agent running.
+func (s *clientSuite) enableHAWithVotes(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAgentPresence(c, "1")
+	for _, id := range []string{"0", "1", "2"} {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		err = m.SetHasVote(true)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *clientSuite) TestRemoveControllerMember(c *gc.C) {
+	s.enableHAWithVotes(c)
+
+	result, err := s.removeControllerMember(c, params.RemoveControllerMember{
+		MachineTag: "machine-2",
+		Replace:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Demoted, jc.DeepEquals, []string{"machine-2"})
+	c.Check(result.Added, jc.DeepEquals, []string{"machine-3"})
+
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.VotingMachineIds, jc.SameContents, []string{"0", "1", "3"})
+	c.Check(info.RemovingMachineIds, jc.DeepEquals, []string{"2"})
+
+	// The replacement takes the constraints of the machine it replaces.
+	m3, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	cons, err := m3.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, gc.DeepEquals, controllerCons)
+}
+
+func (s *clientSuite) TestRemoveControllerMemberStillAvailable(c *gc.C) {
+	s.enableHAWithVotes(c)
+
+	_, err := s.removeControllerMember(c, params.RemoveControllerMember{MachineTag: "machine-1"})
+	c.Assert(err, gc.ErrorMatches, "cannot remove controller machine 1: controller machine 1 is still available")
+}
+
+func (s *clientSuite) TestRemoveControllerMemberInvalidTag(c *gc.C) {
+	_, err := s.removeControllerMember(c, params.RemoveControllerMember{MachineTag: "unit-foo-0"})
+	c.Assert(err, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+}
+
+func (s *clientSuite) TestBlockRemoveControllerMember(c *gc.C) {
+	s.enableHAWithVotes(c)
+	s.BlockRemoveObject(c, "TestBlockRemoveControllerMember")
+
+	_, err := s.removeControllerMember(c, params.RemoveControllerMember{MachineTag: "machine-2"})
+	s.AssertBlocked(c, err, "TestBlockRemoveControllerMember")
+}
@@ -5,7 +5,6 @@ package keyupdater
 
 import (
 	"github.com/juju/errors"
-	"github.com/juju/utils/ssh"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -54,8 +53,8 @@ func NewKeyUpdaterAPI(
 
 // WatchAuthorisedKeys starts a watcher to track changes to the authorised ssh keys
 // for the specified machines.
-// The current implementation relies on global authorised keys being stored in the environment config.
-// This will change as new user management and authorisation functionality is added.
+// The keys change when the model config changes, and when users with access to
+// the model, or their keys, change.
 func (api *KeyUpdaterAPI) WatchAuthorisedKeys(arg params.Entities) (params.NotifyWatchResults, error) {
 	results := make([]params.NotifyWatchResult, len(arg.Entities))
 
@@ -84,7 +83,7 @@ func (api *KeyUpdaterAPI) WatchAuthorisedKeys(arg params.Entities) (params.Notif
 			continue
 		}
 		// 3. Watch for changes
-		watch := api.state.WatchForModelConfigChanges()
+		watch := api.state.WatchAuthorisedKeys()
 		// Consume the initial event.
 		if _, ok := <-watch.Changes(); ok {
 			results[i].NotifyWatcherId = api.resources.Register(watch)
@@ -97,20 +96,16 @@ func (api *KeyUpdaterAPI) WatchAuthorisedKeys(arg params.Entities) (params.Notif
 }
 
 // AuthorisedKeys reports the authorised ssh keys for the specified machines.
-// The current implementation relies on global authorised keys being stored in the environment config.
-// This will change as new user management and authorisation functionality is added.
+// These are the keys in the model config, together with the keys of the users
+// allowed to change the model.
 func (api *KeyUpdaterAPI) AuthorisedKeys(arg params.Entities) (params.StringsResults, error) {
 	if len(arg.Entities) == 0 {
 		return params.StringsResults{}, nil
 	}
 	results := make([]params.StringsResult, len(arg.Entities))
 
-	// For now, authorised keys are global, common to all machines.
-	var keys []string
-	config, configErr := api.state.ModelConfig()
-	if configErr == nil {
-		keys = ssh.SplitAuthorisedKeys(config.AuthorizedKeys())
-	}
+	// Authorised keys are common to all machines in the model.
+	keys, keysErr := api.state.AuthorisedKeys()
 
 	canRead, err := api.getCanRead()
 	if err != nil {
@@ -137,10 +132,10 @@ func (api *KeyUpdaterAPI) AuthorisedKeys(arg params.Entities) (params.StringsRes
 			continue
 		}
 		// 3. Get keys
-		if configErr == nil {
+		if keysErr == nil {
 			results[i].Result = keys
 		} else {
-			err = configErr
+			err = keysErr
 		}
 		results[i].Error = common.ServerError(err)
 	}
@@ -5,6 +5,7 @@ package keyupdater_test
 
 import (
 	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/ssh/testing"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
@@ -15,6 +16,7 @@ import (
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type authorisedKeysSuite struct {
@@ -140,3 +142,21 @@ func (s *authorisedKeysSuite) TestAuthorisedKeys(c *gc.C) {
 		},
 	})
 }
+
+func (s *authorisedKeysSuite) TestAuthorisedKeysIncludesUserKeys(c *gc.C) {
+	s.setAuthorizedKeys(c, "key1")
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := user.AddSSHKeys([]string{sshtesting.ValidKeyOne.Key + " bob@laptop"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results, err := s.keyupdater.AuthorisedKeys(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{
+			{Result: []string{"key1", sshtesting.ValidKeyOne.Key + " bob@laptop"}},
+		},
+	})
+}
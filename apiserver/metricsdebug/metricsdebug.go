@@ -110,6 +110,7 @@ func (api *MetricsDebugAPI) GetMetrics(args params.Entities) (params.MetricResul
 					Key:   m.Key,
 					Value: m.Value,
 					Time:  m.Time,
+					Unit:  mb.Unit(),
 				}
 				ix++
 			}
@@ -191,16 +191,19 @@ func (s *metricsDebugSuite) TestGetMetrics(c *gc.C) {
 				Key:   "pings",
 				Value: "5",
 				Time:  newTime,
+				Unit:  "metered/0",
 			},
 			{
 				Key:   "pings",
 				Value: "5",
 				Time:  newTime,
+				Unit:  "metered/0",
 			},
 			{
 				Key:   "pings",
 				Value: "10.5",
 				Time:  newTime,
+				Unit:  "metered/0",
 			},
 		},
 		Error: nil,
@@ -270,8 +273,10 @@ func (s *metricsDebugSuite) TestGetMultipleMetricsNoMocksWithService(c *gc.C) {
 	c.Assert(metrics.Results[0].Metrics[0].Key, gc.Equals, metricUnit0.Metrics()[0].Key)
 	c.Assert(metrics.Results[0].Metrics[0].Value, gc.Equals, metricUnit0.Metrics()[0].Value)
 	c.Assert(metrics.Results[0].Metrics[0].Time, jc.TimeBetween(metricUnit0.Metrics()[0].Time, metricUnit0.Metrics()[0].Time))
+	c.Assert(metrics.Results[0].Metrics[0].Unit, gc.Equals, unit0.Name())
 
 	c.Assert(metrics.Results[0].Metrics[1].Key, gc.Equals, metricUnit1.Metrics()[0].Key)
 	c.Assert(metrics.Results[0].Metrics[1].Value, gc.Equals, metricUnit1.Metrics()[0].Value)
 	c.Assert(metrics.Results[0].Metrics[1].Time, jc.TimeBetween(metricUnit1.Metrics()[0].Time, metricUnit1.Metrics()[0].Time))
+	c.Assert(metrics.Results[0].Metrics[1].Unit, gc.Equals, unit1.Name())
 }
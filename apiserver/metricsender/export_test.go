@@ -4,6 +4,8 @@ package metricsender
 
 import "github.com/juju/testing"
 
+var CheckExportIP = &checkExportIP
+
 func PatchHost(host string) func() {
 	restoreHost := testing.PatchValue(&metricsHost, host)
 	return func() {
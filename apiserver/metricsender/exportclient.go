@@ -0,0 +1,81 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// exportTimeout bounds the time taken to send metrics to an
+	// export target, including connecting and following redirects.
+	// Metrics are also sent while a model is destroyed, which must
+	// not be held up by an unresponsive target.
+	exportTimeout = 30 * time.Second
+
+	// exportDialTimeout bounds the time taken to connect to an
+	// export target.
+	exportDialTimeout = 10 * time.Second
+)
+
+// checkExportIP returns an error if metrics may not be sent to the
+// given IP address. Model operators choose the export target, so the
+// controller refuses to send metrics to its own addresses, and to
+// loopback and link-local addresses, which would give them access to
+// services that are only meant to be reachable from the controller
+// host, such as cloud metadata services.
+var checkExportIP = func(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return errors.Errorf("cannot export metrics to address %s", ip)
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return errors.Errorf("cannot export metrics to controller address %s", ip)
+		}
+	}
+	return nil
+}
+
+// exportClient is the HTTP client used to send metrics to export
+// targets. It only connects to addresses allowed by checkExportIP.
+// Each address is checked when connecting, so redirects, and names
+// that resolve to different addresses over time, are checked too.
+var exportClient = newExportClient()
+
+func newExportClient() *http.Client {
+	dialer := &net.Dialer{Timeout: exportDialTimeout}
+	dial := func(network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ips, err := net.LookupIP(host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ip := range ips {
+			if err := checkExportIP(ip); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Connect to the address just checked, rather than letting
+		// the dialer resolve the name again.
+		return dialer.Dial(network, net.JoinHostPort(ips[0].String(), port))
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Dial:                dial,
+			TLSHandshakeTimeout: exportDialTimeout,
+		},
+		Timeout: exportTimeout,
+	}
+}
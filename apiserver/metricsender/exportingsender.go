@@ -0,0 +1,55 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	"github.com/juju/utils/set"
+)
+
+// ExportingSender sends metric batches both to the charm store metrics
+// collector and to a model's export target.
+type ExportingSender struct {
+	// Collector sends batches to the charm store metrics collector.
+	Collector MetricSender
+
+	// Export sends batches to the model's export target.
+	Export MetricSender
+}
+
+// Send sends the given metric batches to the collector and then to the
+// export target, failing if either fails. The collector's response is
+// returned, but only the batches that both acknowledge are
+// acknowledged, so that the rest are sent to both again later.
+func (s *ExportingSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	resp, err := s.Collector.Send(batches)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	exportResp, err := s.Export.Send(batches)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	exported := set.NewStrings()
+	if exportResp != nil {
+		for _, envResp := range exportResp.EnvResponses {
+			exported = exported.Union(set.NewStrings(envResp.AcknowledgedBatches...))
+		}
+	}
+	for modelUUID, envResp := range resp.EnvResponses {
+		var acknowledged []string
+		for _, batchUUID := range envResp.AcknowledgedBatches {
+			if exported.Contains(batchUUID) {
+				acknowledged = append(acknowledged, batchUUID)
+			}
+		}
+		envResp.AcknowledgedBatches = acknowledged
+		resp.EnvResponses[modelUUID] = envResp
+	}
+	return resp, nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/metricsender"
+	metricsendertesting "github.com/juju/juju/apiserver/metricsender/testing"
+	"github.com/juju/juju/environs/config"
+)
+
+type ExportSenderSuite struct {
+	testing.IsolationSuite
+	checkExportIP func(net.IP) error
+}
+
+var _ = gc.Suite(&ExportSenderSuite{})
+
+func (s *ExportSenderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	// The test servers listen on loopback addresses, which metrics
+	// may not otherwise be exported to.
+	s.checkExportIP = *metricsender.CheckExportIP
+	s.PatchValue(metricsender.CheckExportIP, func(net.IP) error { return nil })
+}
+
+var _ metricsender.MetricSender = (*metricsender.WebhookSender)(nil)
+var _ metricsender.MetricSender = (*metricsender.PrometheusSender)(nil)
+
+const exportModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func exportBatches() []*wireformat.MetricBatch {
+	now := time.Date(2016, 10, 18, 12, 0, 0, 0, time.UTC)
+	return []*wireformat.MetricBatch{{
+		UUID:      "batch-0",
+		ModelUUID: exportModelUUID,
+		UnitName:  "metered/0",
+		CharmUrl:  "cs:quantal/metered-1",
+		Created:   now,
+		Metrics: []wireformat.Metric{
+			{Key: "pings", Value: "5", Time: now},
+			{Key: "juju-units", Value: "1", Time: now},
+		},
+		Credentials: []byte("secret"),
+	}, {
+		UUID:      "batch-1",
+		ModelUUID: exportModelUUID,
+		UnitName:  "metered/0",
+		CharmUrl:  "cs:quantal/metered-1",
+		Created:   now.Add(time.Minute),
+		Metrics: []wireformat.Metric{
+			{Key: "pings", Value: "7.5", Time: now.Add(time.Minute)},
+			{Key: "status", Value: "happy", Time: now.Add(time.Minute)},
+		},
+	}}
+}
+
+func checkAcknowledged(c *gc.C, resp *wireformat.Response) {
+	c.Assert(resp, gc.NotNil)
+	c.Assert(resp.EnvResponses[exportModelUUID].AcknowledgedBatches, jc.SameContents, []string{"batch-0", "batch-1"})
+}
+
+func (s *ExportSenderSuite) TestWebhookSender(c *gc.C) {
+	var received []wireformat.MetricBatch
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		err := json.NewDecoder(r.Body).Decode(&received)
+		c.Check(err, jc.ErrorIsNil)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := &metricsender.WebhookSender{URL: server.URL}
+	resp, err := sender.Send(exportBatches())
+	c.Assert(err, jc.ErrorIsNil)
+	checkAcknowledged(c, resp)
+
+	c.Assert(received, gc.HasLen, 2)
+	c.Assert(received[0].UUID, gc.Equals, "batch-0")
+	c.Assert(received[0].Metrics, gc.HasLen, 2)
+	c.Assert(received[0].Credentials, gc.IsNil)
+}
+
+func (s *ExportSenderSuite) TestWebhookSenderError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sender := &metricsender.WebhookSender{URL: server.URL}
+	_, err := sender.Send(exportBatches())
+	c.Assert(err, gc.ErrorMatches, "failed to send metrics to webhook: http 503")
+}
+
+func (s *ExportSenderSuite) TestWebhookSenderAddressRefused(c *gc.C) {
+	s.PatchValue(metricsender.CheckExportIP, s.checkExportIP)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("metrics sent to refused address")
+	}))
+	defer server.Close()
+
+	sender := &metricsender.WebhookSender{URL: server.URL}
+	_, err := sender.Send(exportBatches())
+	c.Assert(err, gc.ErrorMatches, `.*cannot export metrics to address 127\.0\.0\.1`)
+}
+
+func (s *ExportSenderSuite) TestPrometheusSenderAddressRefused(c *gc.C) {
+	s.PatchValue(metricsender.CheckExportIP, s.checkExportIP)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Errorf("metrics pushed to refused address")
+	}))
+	defer server.Close()
+
+	sender := &metricsender.PrometheusSender{URL: server.URL}
+	_, err := sender.Send(exportBatches())
+	c.Assert(err, gc.ErrorMatches, `.*cannot export metrics to address 127\.0\.0\.1`)
+}
+
+func (s *ExportSenderSuite) TestPrometheusSender(c *gc.C) {
+	var path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		path = r.URL.Path
+		data, err := ioutil.ReadAll(r.Body)
+		c.Check(err, jc.ErrorIsNil)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	sender := &metricsender.PrometheusSender{URL: server.URL + "/"}
+	resp, err := sender.Send(exportBatches())
+	c.Assert(err, jc.ErrorIsNil)
+	checkAcknowledged(c, resp)
+
+	c.Assert(path, gc.Equals, "/metrics/job/juju/model_uuid/"+exportModelUUID)
+	// Only the latest value of each metric is pushed, and values
+	// that are not numbers are dropped.
+	c.Assert(body, gc.Equals, `
+# TYPE juju_juju_units gauge
+juju_juju_units{unit="metered/0",application="metered",charm_url="cs:quantal/metered-1"} 1
+# TYPE juju_pings gauge
+juju_pings{unit="metered/0",application="metered",charm_url="cs:quantal/metered-1"} 7.5
+`[1:])
+}
+
+func (s *ExportSenderSuite) TestPrometheusSenderError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sender := &metricsender.PrometheusSender{URL: server.URL}
+	_, err := sender.Send(exportBatches())
+	c.Assert(err, gc.ErrorMatches, "failed to push metrics to prometheus gateway: http 400")
+}
+
+func (s *ExportSenderSuite) TestSenderForTarget(c *gc.C) {
+	collector := metricsender.NopSender{}
+	sender := metricsender.SenderForTarget(config.MetricsExportTarget{}, collector)
+	c.Assert(sender, gc.Equals, collector)
+
+	sender = metricsender.SenderForTarget(config.MetricsExportTarget{
+		Kind: config.MetricsExportWebhook,
+		URL:  "https://example.com/metrics",
+	}, collector)
+	c.Assert(sender, jc.DeepEquals, &metricsender.ExportingSender{
+		Collector: collector,
+		Export:    &metricsender.WebhookSender{URL: "https://example.com/metrics"},
+	})
+
+	sender = metricsender.SenderForTarget(config.MetricsExportTarget{
+		Kind: config.MetricsExportPrometheus,
+		URL:  "http://10.0.0.1:9091",
+	}, collector)
+	c.Assert(sender, jc.DeepEquals, &metricsender.ExportingSender{
+		Collector: collector,
+		Export:    &metricsender.PrometheusSender{URL: "http://10.0.0.1:9091"},
+	})
+}
+
+func (s *ExportSenderSuite) TestExportingSender(c *gc.C) {
+	var collector, export metricsendertesting.MockSender
+	sender := &metricsender.ExportingSender{Collector: &collector, Export: &export}
+	resp, err := sender.Send(exportBatches())
+	c.Assert(err, jc.ErrorIsNil)
+	checkAcknowledged(c, resp)
+	c.Assert(collector.Data, gc.HasLen, 1)
+	c.Assert(export.Data, gc.HasLen, 1)
+}
+
+func (s *ExportSenderSuite) TestExportingSenderAcknowledgesOnlyExported(c *gc.C) {
+	var collector metricsendertesting.MockSender
+	export := &partialSender{acknowledge: "batch-1"}
+	sender := &metricsender.ExportingSender{Collector: &collector, Export: export}
+	resp, err := sender.Send(exportBatches())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.EnvResponses[exportModelUUID].AcknowledgedBatches, jc.DeepEquals, []string{"batch-1"})
+}
+
+func (s *ExportSenderSuite) TestExportingSenderCollectorError(c *gc.C) {
+	var export metricsendertesting.MockSender
+	sender := &metricsender.ExportingSender{
+		Collector: &metricsendertesting.ErrorSender{Err: errors.New("collector down")},
+		Export:    &export,
+	}
+	_, err := sender.Send(exportBatches())
+	c.Assert(err, gc.ErrorMatches, "collector down")
+	c.Assert(export.Data, gc.HasLen, 0)
+}
+
+func (s *ExportSenderSuite) TestExportingSenderExportError(c *gc.C) {
+	var collector metricsendertesting.MockSender
+	sender := &metricsender.ExportingSender{
+		Collector: &collector,
+		Export:    &metricsendertesting.ErrorSender{Err: errors.New("webhook down")},
+	}
+	_, err := sender.Send(exportBatches())
+	c.Assert(err, gc.ErrorMatches, "webhook down")
+	c.Assert(collector.Data, gc.HasLen, 1)
+}
+
+// partialSender acknowledges only the batch with the given UUID.
+type partialSender struct {
+	acknowledge string
+}
+
+func (s *partialSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	resp := make(wireformat.EnvironmentResponses)
+	for _, batch := range batches {
+		if batch.UUID == s.acknowledge {
+			resp.Ack(batch.ModelUUID, batch.UUID)
+		}
+	}
+	return &wireformat.Response{EnvResponses: resp}, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/loggo"
 	wireformat "github.com/juju/romulus/wireformat/metrics"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -126,6 +127,22 @@ func DefaultMetricSender() MetricSender {
 	return defaultSender
 }
 
+// SenderForTarget returns the MetricSender that sends metrics to the
+// collector and to the given export target, or just to the collector
+// if there is no export target.
+func SenderForTarget(target config.MetricsExportTarget, collector MetricSender) MetricSender {
+	var export MetricSender
+	switch target.Kind {
+	case config.MetricsExportWebhook:
+		export = &WebhookSender{URL: target.URL}
+	case config.MetricsExportPrometheus:
+		export = &PrometheusSender{URL: target.URL}
+	default:
+		return collector
+	}
+	return &ExportingSender{Collector: collector, Export: export}
+}
+
 // ToWire converts the state.MetricBatch into a type
 // that can be sent over the wire to the collector.
 func ToWire(mb *state.MetricBatch) *wireformat.MetricBatch {
@@ -0,0 +1,145 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+	"gopkg.in/juju/names.v2"
+)
+
+// PrometheusSender pushes metric batches to a Prometheus push gateway.
+// Each charm metric becomes a gauge named after its key, prefixed with
+// "juju_", with unit, application and charm labels. The metrics of
+// each model are pushed to their own group, so that models sharing a
+// gateway don't replace each other's metrics.
+type PrometheusSender struct {
+	// URL is the address of the push gateway.
+	URL string
+}
+
+// Send pushes the given metric batches to the push gateway. Only the
+// latest value of each metric of each unit is pushed, and values that
+// are not numbers are dropped. The batches are acknowledged when the
+// gateway accepts all of them.
+func (s *PrometheusSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	byModel := make(map[string][]*wireformat.MetricBatch)
+	for _, batch := range batches {
+		byModel[batch.ModelUUID] = append(byModel[batch.ModelUUID], batch)
+	}
+	for modelUUID, modelBatches := range byModel {
+		if err := s.push(modelUUID, modelBatches); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return acknowledge(batches)
+}
+
+func (s *PrometheusSender) push(modelUUID string, batches []*wireformat.MetricBatch) error {
+	body := prometheusText(batches)
+	if len(body) == 0 {
+		return nil
+	}
+	pushURL := fmt.Sprintf("%s/metrics/job/juju/model_uuid/%s",
+		strings.TrimRight(s.URL, "/"), url.QueryEscape(modelUUID),
+	)
+	// POST only replaces the metrics with the same names in the
+	// model's group, so metrics of units that have not reported
+	// this time are kept.
+	resp, err := exportClient.Post(pushURL, "text/plain; version=0.0.4", bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("failed to push metrics to prometheus gateway: http %v", resp.StatusCode)
+	}
+	return nil
+}
+
+type prometheusSample struct {
+	value float64
+	time  time.Time
+}
+
+// prometheusText renders the batches in the Prometheus text exposition
+// format.
+func prometheusText(batches []*wireformat.MetricBatch) []byte {
+	families := make(map[string]map[string]prometheusSample)
+	for _, batch := range batches {
+		application, _ := names.UnitApplication(batch.UnitName)
+		labels := fmt.Sprintf(`unit="%s",application="%s",charm_url="%s"`,
+			escapeLabelValue(batch.UnitName),
+			escapeLabelValue(application),
+			escapeLabelValue(batch.CharmUrl),
+		)
+		for _, m := range batch.Metrics {
+			value, err := strconv.ParseFloat(m.Value, 64)
+			if err != nil {
+				logger.Warningf("not exporting metric %q of unit %q: value %q is not a number", m.Key, batch.UnitName, m.Value)
+				continue
+			}
+			name := prometheusMetricName(m.Key)
+			samples, ok := families[name]
+			if !ok {
+				samples = make(map[string]prometheusSample)
+				families[name] = samples
+			}
+			if current, ok := samples[labels]; ok && current.time.After(m.Time) {
+				continue
+			}
+			samples[labels] = prometheusSample{value, m.Time}
+		}
+	}
+
+	familyNames := make([]string, 0, len(families))
+	for name := range families {
+		familyNames = append(familyNames, name)
+	}
+	sort.Strings(familyNames)
+	var buf bytes.Buffer
+	for _, name := range familyNames {
+		samples := families[name]
+		labelSets := make([]string, 0, len(samples))
+		for labels := range samples {
+			labelSets = append(labelSets, labels)
+		}
+		sort.Strings(labelSets)
+		fmt.Fprintf(&buf, "# TYPE %s gauge\n", name)
+		for _, labels := range labelSets {
+			value := strconv.FormatFloat(samples[labels].value, 'g', -1, 64)
+			fmt.Fprintf(&buf, "%s{%s} %s\n", name, labels, value)
+		}
+	}
+	return buf.Bytes()
+}
+
+// prometheusMetricName returns the Prometheus metric name for the
+// charm metric key: Prometheus names may only contain letters, digits
+// and underscores.
+func prometheusMetricName(key string) string {
+	name := []byte("juju_" + key)
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+		default:
+			name[i] = '_'
+		}
+	}
+	return string(name)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
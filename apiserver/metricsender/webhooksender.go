@@ -0,0 +1,44 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsender
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
+)
+
+// WebhookSender posts metric batches, as a JSON array, to an HTTP
+// endpoint run by the model's operators.
+type WebhookSender struct {
+	// URL is the address the batches are posted to.
+	URL string
+}
+
+// Send posts the given metric batches to the webhook. The batches are
+// acknowledged when the webhook replies with any 2xx status.
+func (s *WebhookSender) Send(batches []*wireformat.MetricBatch) (*wireformat.Response, error) {
+	exported := make([]wireformat.MetricBatch, len(batches))
+	for i, batch := range batches {
+		exported[i] = *batch
+		// The credentials authorize the batch with the charm
+		// store collector, and are no business of anyone else.
+		exported[i].Credentials = nil
+	}
+	b, err := json.Marshal(exported)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := exportClient.Post(s.URL, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.Errorf("failed to send metrics to webhook: http %v", resp.StatusCode)
+	}
+	return acknowledge(batches)
+}
@@ -103,7 +103,8 @@ func (api *MetricsManagerAPI) CleanupOldMetrics(args params.Entities) (params.Er
 	return result, nil
 }
 
-// SendMetrics will send any unsent metrics onto the metric collection service.
+// SendMetrics will send any unsent metrics onto the metric collection
+// service, or the model's metrics export target if it has one.
 func (api *MetricsManagerAPI) SendMetrics(args params.Entities) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -125,7 +126,13 @@ func (api *MetricsManagerAPI) SendMetrics(args params.Entities) (params.ErrorRes
 			result.Results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		err = metricsender.SendMetrics(api.state, sender, maxBatchesPerSend)
+		cfg, err := api.state.ModelConfig()
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		modelSender := metricsender.SenderForTarget(cfg.MetricsExportTarget(), sender)
+		err = metricsender.SendMetrics(api.state, modelSender, maxBatchesPerSend)
 		if err != nil {
 			err = errors.Annotate(err, "failed to send metrics")
 			logger.Warningf("%v", err)
@@ -4,9 +4,13 @@
 package metricsmanager_test
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/juju/errors"
+	wireformat "github.com/juju/romulus/wireformat/metrics"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -136,6 +140,44 @@ func (s *metricsManagerSuite) TestSendMetrics(c *gc.C) {
 	c.Assert(m.Sent(), jc.IsTrue)
 }
 
+func (s *metricsManagerSuite) TestSendMetricsToExportTarget(c *gc.C) {
+	received := make(chan []wireformat.MetricBatch, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batches []wireformat.MetricBatch
+		err := json.NewDecoder(r.Body).Decode(&batches)
+		c.Check(err, jc.ErrorIsNil)
+		received <- batches
+	}))
+	defer server.Close()
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"metrics-export-target": "webhook:" + server.URL,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var sender testing.MockSender
+	metricsmanager.PatchSender(&sender)
+	now := time.Now()
+	metric := state.Metric{"pings", "5", now}
+	unsent := s.Factory.MakeMetric(c, &factory.MetricParams{Unit: s.unit, Sent: false, Time: &now, Metrics: []state.Metric{metric}})
+	args := params.Entities{Entities: []params.Entity{
+		{s.State.ModelTag().String()},
+	}}
+	result, err := s.metricsmanager.SendMetrics(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(sender.Data, gc.HasLen, 1)
+	c.Assert(sender.Data[0], gc.HasLen, 1)
+	c.Assert(sender.Data[0][0].UUID, gc.Equals, unsent.UUID())
+
+	batches := <-received
+	c.Assert(batches, gc.HasLen, 1)
+	c.Assert(batches[0].UUID, gc.Equals, unsent.UUID())
+	c.Assert(batches[0].Credentials, gc.IsNil)
+	m, err := s.State.MetricBatch(unsent.UUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Sent(), jc.IsTrue)
+}
+
 func (s *metricsManagerSuite) TestSendOldMetricsInvalidArg(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{"invalid"},
@@ -216,6 +216,7 @@ type mockState struct {
 	controllerModel *mockModel
 	users           []*state.ModelUser
 	creds           map[string]cloud.Credential
+	access          map[string]state.Access
 }
 
 func (st *mockState) ModelUUID() string {
@@ -249,6 +250,15 @@ func (st *mockState) IsControllerAdministrator(user names.UserTag) (bool, error)
 	return false, st.NextErr()
 }
 
+func (st *mockState) EffectiveControllerAccess(user names.UserTag) (state.Access, error) {
+	st.MethodCall(st, "EffectiveControllerAccess", user)
+	access, ok := st.access[user.Canonical()]
+	if !ok {
+		return state.UndefinedAccess, errors.NotFoundf("controller access for %q", user.Canonical())
+	}
+	return access, st.NextErr()
+}
+
 func (st *mockState) NewModel(args state.ModelArgs) (common.Model, common.ModelManagerBackend, error) {
 	st.MethodCall(st, "NewModel", args)
 	st.model.tag = names.NewModelTag(args.Config.UUID())
@@ -322,6 +332,21 @@ func (st *mockState) ModelUser(tag names.UserTag) (*state.ModelUser, error) {
 	return nil, st.NextErr()
 }
 
+func (st *mockState) ModelGroupAccess(group string) (state.Access, error) {
+	st.MethodCall(st, "ModelGroupAccess", group)
+	return state.UndefinedAccess, st.NextErr()
+}
+
+func (st *mockState) SetModelGroupAccess(group string, access state.Access) error {
+	st.MethodCall(st, "SetModelGroupAccess", group, access)
+	return st.NextErr()
+}
+
+func (st *mockState) RemoveModelGroupAccess(group string) error {
+	st.MethodCall(st, "RemoveModelGroupAccess", group)
+	return st.NextErr()
+}
+
 type mockModel struct {
 	gitjujutesting.Stub
 	owner  names.UserTag
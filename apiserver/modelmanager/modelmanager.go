@@ -155,10 +155,20 @@ func (mm *ModelManagerAPI) newModelConfig(
 // model config specified in the args.
 func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error) {
 	result := params.ModelInfo{}
-	// TODO(perrito666) this check should be part of the authCheck, without this check
-	// any user in the controller may create models.
+	// Controller superusers may create models for anyone. Other users
+	// need add-model access on the controller, directly or through a
+	// group, and may only create models for themselves, which is
+	// checked by authCheck below.
 	if !mm.isAdmin {
-		return result, errors.Trace(common.ErrPerm)
+		access, err := mm.state.EffectiveControllerAccess(mm.apiUser)
+		if errors.IsNotFound(err) {
+			return result, errors.Trace(common.ErrPerm)
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		if access != state.AddModelAccess {
+			return result, errors.Trace(common.ErrPerm)
+		}
 	}
 	// Get the controller model first. We need it both for the state
 	// server owner and the ability to get the config.
@@ -172,9 +182,9 @@ func (mm *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Mode
 		return result, errors.Trace(err)
 	}
 
-	// Any user is able to create themselves an model (until real fine
-	// grain permissions are available), and admins (the creator of the state
-	// server model) are able to create models for other people.
+	// Users with add-model access are able to create themselves a model,
+	// and controller superusers are able to create models for other
+	// people.
 	err = mm.authCheck(ownerTag)
 	if err != nil {
 		return result, errors.Trace(err)
@@ -435,12 +445,17 @@ func (m *ModelManagerAPI) ModifyModelAccess(args params.ModifyModelAccessRequest
 			continue
 		}
 
-		targetUserTag, err := names.ParseUserTag(arg.UserTag)
+		modelTag, err := names.ParseModelTag(arg.ModelTag)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify model access"))
 			continue
 		}
-		modelTag, err := names.ParseModelTag(arg.ModelTag)
+		if arg.GroupName != "" {
+			result.Results[i].Error = common.ServerError(
+				ChangeModelGroupAccess(m.state, modelTag, m.apiUser, arg.GroupName, arg.Action, modelAccess, m.isAdmin))
+			continue
+		}
+		targetUserTag, err := names.ParseUserTag(arg.UserTag)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(errors.Annotate(err, "could not modify model access"))
 			continue
@@ -570,6 +585,65 @@ func ChangeModelAccess(accessor common.ModelManagerBackend, modelTag names.Model
 	}
 }
 
+// ChangeModelGroupAccess performs the requested access grant or revoke
+// action for the named user group on the specified model.
+func ChangeModelGroupAccess(accessor common.ModelManagerBackend, modelTag names.ModelTag, apiUser names.UserTag, group string, action params.ModelAction, access permission.ModelAccess, userIsAdmin bool) error {
+	st, err := accessor.ForModel(modelTag)
+	if err != nil {
+		return errors.Annotate(err, "could not lookup model")
+	}
+	defer st.Close()
+
+	if err := userAuthorizedToChangeAccess(st, userIsAdmin, apiUser); err != nil {
+		return errors.Trace(err)
+	}
+
+	stateAccess, err := resolveStateAccess(access)
+	if err != nil {
+		return errors.Annotate(err, "could not resolve model access")
+	}
+
+	current, err := st.ModelGroupAccess(group)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "could not look up model access for group")
+	}
+	hasAccess := err == nil
+
+	switch action {
+	case params.GrantModelAccess:
+		// Only set access if greater access is being granted.
+		if hasAccess && current.Includes(stateAccess) {
+			return errors.Errorf("group already has %q access or greater", current)
+		}
+		err := st.SetModelGroupAccess(group, stateAccess)
+		return errors.Annotate(err, "could not grant model access")
+
+	case params.RevokeModelAccess:
+		if !hasAccess {
+			return errors.NotFoundf("model access for group %q", group)
+		}
+		switch stateAccess {
+		case state.ReadAccess:
+			// Revoking read access removes all access.
+			err := st.RemoveModelGroupAccess(group)
+			return errors.Annotate(err, "could not revoke model access")
+		case state.WriteAccess:
+			// Revoking write access sets read-only.
+			err := st.SetModelGroupAccess(group, state.ReadAccess)
+			return errors.Annotate(err, "could not set model access to read-only")
+		case state.AdminAccess:
+			// Revoking admin access sets read-write.
+			err := st.SetModelGroupAccess(group, state.WriteAccess)
+			return errors.Annotate(err, "could not set model access to read-write")
+		default:
+			return errors.Errorf("don't know how to revoke %q access", stateAccess)
+		}
+
+	default:
+		return errors.Errorf("unknown action %q", action)
+	}
+}
+
 // FromModelAccessParam returns the logical model access type from the API wireformat type.
 func FromModelAccessParam(paramAccess params.ModelAccessPermission) (permission.ModelAccess, error) {
 	var fail permission.ModelAccess
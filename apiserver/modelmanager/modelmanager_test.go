@@ -232,6 +232,38 @@ func (s *modelManagerSuite) TestCreateModelUnknownCredential(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `no such credential "bar"`)
 }
 
+func (s *modelManagerSuite) TestCreateModelAddModelAccess(c *gc.C) {
+	s.st.access = map[string]state.Access{"bob@local": state.AddModelAccess}
+	s.authoriser.Tag = names.NewUserTag("bob@local")
+	api, err := modelmanager.NewModelManagerAPI(&s.st, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.CreateModel(params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-bob@local",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.CreateModel(params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-mary@local",
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelManagerSuite) TestCreateModelLoginAccess(c *gc.C) {
+	s.st.access = map[string]state.Access{"bob@local": state.LoginAccess}
+	s.authoriser.Tag = names.NewUserTag("bob@local")
+	api, err := modelmanager.NewModelManagerAPI(&s.st, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.CreateModel(params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-bob@local",
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
@@ -340,6 +372,36 @@ func (s *modelManagerStateSuite) TestNonAdminCannotCreateModelForSelf(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *modelManagerStateSuite) TestAddModelUserCanCreateModelForSelf(c *gc.C) {
+	owner := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	err := s.State.SetControllerAccess(owner, state.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAPIUser(c, owner)
+	model, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.OwnerTag, gc.Equals, owner.String())
+}
+
+func (s *modelManagerStateSuite) TestAddModelGroupMemberCanCreateModelForSelf(c *gc.C) {
+	owner := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	group, err := s.State.AddUserGroup("creators", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(owner), jc.ErrorIsNil)
+	err = s.State.SetControllerGroupAccess("creators", state.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAPIUser(c, owner)
+	model, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.OwnerTag, gc.Equals, owner.String())
+}
+
+func (s *modelManagerStateSuite) TestLoginUserCannotCreateModelForSelf(c *gc.C) {
+	owner := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	s.setAPIUser(c, owner)
+	_, err := s.modelmanager.CreateModel(s.createArgs(c, owner))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *modelManagerStateSuite) TestCreateModelValidatesConfig(c *gc.C) {
 	admin := s.AdminUserTag(c)
 	s.setAPIUser(c, admin)
@@ -655,6 +717,46 @@ func (s *modelManagerStateSuite) TestGrantOnlyGreaterAccess(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `user already has "read" access or greater`)
 }
 
+func (s *modelManagerStateSuite) TestGrantRevokeModelGroupAccess(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err := s.State.AddUserGroup("devs", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	modify := func(action params.ModelAction, access params.ModelAccessPermission) error {
+		result, err := s.modelmanager.ModifyModelAccess(params.ModifyModelAccessRequest{
+			Changes: []params.ModifyModelAccess{{
+				GroupName: "devs",
+				Action:    action,
+				Access:    access,
+				ModelTag:  st.ModelTag().String(),
+			}}})
+		c.Assert(err, jc.ErrorIsNil)
+		return result.OneError()
+	}
+
+	err = modify(params.GrantModelAccess, params.ModelWriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := st.ModelGroupAccess("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.WriteAccess)
+
+	err = modify(params.GrantModelAccess, params.ModelReadAccess)
+	c.Assert(err, gc.ErrorMatches, `group already has "write" access or greater`)
+
+	err = modify(params.RevokeModelAccess, params.ModelWriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = st.ModelGroupAccess("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ReadAccess)
+
+	err = modify(params.RevokeModelAccess, params.ModelReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.ModelGroupAccess("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *modelManagerStateSuite) assertNewUser(c *gc.C, modelUser *state.ModelUser, userTag, creatorTag names.UserTag) {
 	c.Assert(modelUser.UserTag(), gc.Equals, userTag)
 	c.Assert(modelUser.CreatedBy(), gc.Equals, creatorTag.Canonical())
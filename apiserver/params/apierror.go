@@ -60,6 +60,8 @@ const (
 	CodeNotFound                  = "not found"
 	CodeUnauthorized              = "unauthorized access"
 	CodeLoginExpired              = "login expired"
+	CodeUserLockedOut             = "user locked out"
+	CodePasswordExpired           = "password expired"
 	CodeCannotEnterScope          = "cannot enter scope"
 	CodeCannotEnterScopeYet       = "cannot enter scope yet"
 	CodeExcessiveContention       = "excessive contention"
@@ -119,6 +121,14 @@ func IsCodeLoginExpired(err error) bool {
 	return ErrCode(err) == CodeLoginExpired
 }
 
+func IsCodeUserLockedOut(err error) bool {
+	return ErrCode(err) == CodeUserLockedOut
+}
+
+func IsCodePasswordExpired(err error) bool {
+	return ErrCode(err) == CodePasswordExpired
+}
+
 // IsCodeNotFoundOrCodeUnauthorized is used in API clients which,
 // pre-API, used errors.IsNotFound; this is because an API client is
 // not necessarily privileged to know about the existence or otherwise
@@ -12,6 +12,19 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// Key, if set, is used to encrypt the backup archive.
+	Key *BackupsArchiveKey `json:"key,omitempty"`
+}
+
+// BackupsArchiveKey holds the key material used to encrypt or decrypt
+// a backup archive. An archive is encrypted with either a passphrase
+// or an RSA public key, and decrypted with the same passphrase or the
+// matching private key.
+type BackupsArchiveKey struct {
+	Passphrase string `json:"passphrase,omitempty"`
+	PublicKey  string `json:"public-key,omitempty"`
+	PrivateKey string `json:"private-key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -42,6 +55,30 @@ type BackupsRemoveArgs struct {
 // BackupsListResult holds the list of all stored backups.
 type BackupsListResult struct {
 	List []BackupsMetadataResult `json:"list"`
+
+	// Schedule holds the controller's backup schedule, if backups are
+	// scheduled or have been in the past.
+	Schedule *BackupsScheduleResult `json:"schedule,omitempty"`
+}
+
+// BackupsScheduleResult holds the schedule and retention policy of the
+// controller's scheduled backups, and the outcome of the last one.
+type BackupsScheduleResult struct {
+	Interval   string `json:"interval,omitempty"`
+	KeepLast   int    `json:"keep-last,omitempty"`
+	KeepDaily  int    `json:"keep-daily,omitempty"`
+	KeepWeekly int    `json:"keep-weekly,omitempty"`
+
+	LastRun *BackupsScheduledRunResult `json:"last-run,omitempty"`
+}
+
+// BackupsScheduledRunResult holds the outcome of a scheduled backup.
+type BackupsScheduledRunResult struct {
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	BackupID string    `json:"backup-id,omitempty"`
+	Removed  []string  `json:"removed,omitempty"`
+	Error    string    `json:"error,omitempty"`
 }
 
 // BackupsListResult holds the list of all stored backups.
@@ -68,6 +105,14 @@ type BackupsMetadataResult struct {
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
 
+	// Storage is the name of the backups storage target holding the
+	// archive.
+	Storage string `json:"storage,omitempty"`
+
+	// Encryption is the method used to encrypt the archive, if it is
+	// encrypted.
+	Encryption string `json:"encryption,omitempty"`
+
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 }
@@ -76,4 +121,30 @@ type BackupsMetadataResult struct {
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Key is used to decrypt the backup archive, if it is encrypted.
+	Key *BackupsArchiveKey `json:"key,omitempty"`
+}
+
+// RestoreModelArgs holds the arguments for restoring a single model
+// from a stored backup.
+type RestoreModelArgs struct {
+	// BackupId holds the id of the stored backup.
+	BackupId string `json:"backup-id"`
+
+	// ModelUUID identifies the model to restore from the backup.
+	ModelUUID string `json:"model-uuid"`
+
+	// NewName, if set, is the name given to the restored model.
+	NewName string `json:"new-name,omitempty"`
+
+	// Key is used to decrypt the backup archive, if it is encrypted.
+	Key *BackupsArchiveKey `json:"key,omitempty"`
+}
+
+// RestoreModelResult describes a model restored from a backup.
+type RestoreModelResult struct {
+	ModelUUID string `json:"model-uuid"`
+	Name      string `json:"name"`
+	Owner     string `json:"owner"`
 }
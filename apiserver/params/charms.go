@@ -3,6 +3,10 @@
 
 package params
 
+import (
+	"time"
+)
+
 // CharmsList stores parameters for a charms.List call
 type CharmsList struct {
 	Names []string `json:"names"`
@@ -115,3 +119,117 @@ type CharmMetric struct {
 type CharmMetrics struct {
 	Metrics map[string]CharmMetric `json:"metrics"`
 }
+
+// RepositoryCharm holds a revision of a charm published to the charm
+// repository hosted by the controller.
+type RepositoryCharm struct {
+	URL       string    `json:"url"`
+	Name      string    `json:"name"`
+	Revision  int       `json:"revision"`
+	Summary   string    `json:"summary,omitempty"`
+	Series    []string  `json:"series,omitempty"`
+	Channels  []string  `json:"channels,omitempty"`
+	Publisher string    `json:"publisher"`
+	Published time.Time `json:"published"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+}
+
+// RepositoryCharmResult holds a charm repository charm or an error.
+type RepositoryCharmResult struct {
+	Result *RepositoryCharm `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// RepositoryCharmsFilter selects charms in the charm repository by
+// name. An empty name selects all charms.
+type RepositoryCharmsFilter struct {
+	Name string `json:"name,omitempty"`
+}
+
+// RepositoryCharmsResult holds revisions of charms in the charm
+// repository.
+type RepositoryCharmsResult struct {
+	Charms []RepositoryCharm `json:"charms"`
+}
+
+// RepositoryCharmURL holds a charm repository URL and the channel a URL
+// without a revision is resolved against.
+type RepositoryCharmURL struct {
+	URL     string `json:"url"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// PromoteRepositoryCharm holds the arguments for putting a revision of
+// a charm repository charm in a channel.
+type PromoteRepositoryCharm struct {
+	URL     string `json:"url"`
+	Channel string `json:"channel"`
+}
+
+// AddRepositoryCharmResult holds the result of copying a charm
+// repository charm into a model.
+type AddRepositoryCharmResult struct {
+	// CharmURL is the URL of the local charm in the model.
+	CharmURL string `json:"charm-url"`
+
+	// RepositoryURL is the URL of the revision of the charm that was
+	// copied.
+	RepositoryURL string `json:"repository-url"`
+}
+
+// RepositoryCharmOrigin holds the charm repository URL a model charm was
+// copied from, if any.
+type RepositoryCharmOrigin struct {
+	RepositoryURL string `json:"repository-url,omitempty"`
+}
+
+// CharmUpgradePolicy holds how an application is upgraded when newer
+// revisions of its charm become available. Window is a daily period in
+// UTC, in the form "HH:MM-HH:MM".
+type CharmUpgradePolicy struct {
+	Mode    string `json:"mode"`
+	Channel string `json:"channel,omitempty"`
+	Window  string `json:"window,omitempty"`
+}
+
+// SetCharmUpgradePolicy holds the charm upgrade policy to set for an
+// application.
+type SetCharmUpgradePolicy struct {
+	ApplicationTag string             `json:"application-tag"`
+	Policy         CharmUpgradePolicy `json:"policy"`
+}
+
+// SetCharmUpgradePolicies holds the arguments for setting the charm
+// upgrade policies of applications.
+type SetCharmUpgradePolicies struct {
+	Args []SetCharmUpgradePolicy `json:"args"`
+}
+
+// CharmUpgradeInfo describes an application's charm, its upgrade
+// policy and the newer revision of the charm available, if any.
+type CharmUpgradeInfo struct {
+	ApplicationTag string             `json:"application-tag"`
+	CharmURL       string             `json:"charm-url"`
+	Available      string             `json:"available,omitempty"`
+	Policy         CharmUpgradePolicy `json:"policy"`
+}
+
+// CharmUpgradeInfoResults holds the charm upgrade information for the
+// applications in a model.
+type CharmUpgradeInfoResults struct {
+	Results []CharmUpgradeInfo `json:"results"`
+}
+
+// UpgradeApplicationCharm holds the charm an application should be
+// upgraded to.
+type UpgradeApplicationCharm struct {
+	ApplicationTag string `json:"application-tag"`
+	CharmURL       string `json:"charm-url"`
+}
+
+// UpgradeApplicationCharms holds the arguments for upgrading the charms
+// of applications.
+type UpgradeApplicationCharms struct {
+	Args []UpgradeApplicationCharm `json:"args"`
+}
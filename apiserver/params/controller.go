@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -46,3 +48,93 @@ type ModelStatus struct {
 type ModelStatusResults struct {
 	Results []ModelStatus `json:"models"`
 }
+
+// MongoHealthResult holds the state of the controller's mongo
+// replica set and databases.
+type MongoHealthResult struct {
+	ReplicaSet string              `json:"replica-set"`
+	Primary    string              `json:"primary"`
+	Members    []MongoMemberHealth `json:"members"`
+	Oplog      MongoOplogInfo      `json:"oplog"`
+	Databases  []MongoDatabaseSize `json:"databases"`
+}
+
+// MongoMemberHealth holds the replication state of a single
+// replica set member.
+type MongoMemberHealth struct {
+	Id      int           `json:"id"`
+	Address string        `json:"address"`
+	State   string        `json:"state"`
+	Healthy bool          `json:"healthy"`
+	Self    bool          `json:"self"`
+	Optime  time.Time     `json:"optime"`
+	Uptime  time.Duration `json:"uptime"`
+	Lag     time.Duration `json:"lag"`
+	Message string        `json:"message,omitempty"`
+}
+
+// MongoOplogInfo describes the coverage and size of the
+// replication oplog.
+type MongoOplogInfo struct {
+	First   time.Time `json:"first"`
+	Last    time.Time `json:"last"`
+	Size    int64     `json:"size"`
+	MaxSize int64     `json:"max-size"`
+}
+
+// MongoDatabaseSize holds the disk usage of a database.
+type MongoDatabaseSize struct {
+	Name        string                `json:"name"`
+	DataSize    int64                 `json:"data-size"`
+	StorageSize int64                 `json:"storage-size"`
+	IndexSize   int64                 `json:"index-size"`
+	Collections []MongoCollectionSize `json:"collections"`
+}
+
+// MongoCollectionSize holds the disk usage of a collection.
+type MongoCollectionSize struct {
+	Name        string `json:"name"`
+	Count       int64  `json:"count"`
+	DataSize    int64  `json:"data-size"`
+	StorageSize int64  `json:"storage-size"`
+	IndexSize   int64  `json:"index-size"`
+}
+
+// ModifyControllerAccessRequest holds the parameters for making grant
+// and revoke controller calls.
+type ModifyControllerAccessRequest struct {
+	Changes []ModifyControllerAccess `json:"changes"`
+}
+
+// ModifyControllerAccess holds a single change to the access a user
+// has on the controller.
+type ModifyControllerAccess struct {
+	UserTag string                     `json:"user-tag"`
+	Action  ControllerAction           `json:"action"`
+	Access  ControllerAccessPermission `json:"access"`
+
+	// GroupName, if set, names the user group whose access is
+	// changed; UserTag is then ignored.
+	GroupName string `json:"group-name,omitempty"`
+}
+
+// ControllerAction is an action that can be performed on the access
+// a user has to a controller.
+type ControllerAction string
+
+// Actions that can be performed on controller access.
+const (
+	GrantControllerAccess  ControllerAction = "grant"
+	RevokeControllerAccess ControllerAction = "revoke"
+)
+
+// ControllerAccessPermission is the type of permission that a user has
+// on a controller.
+type ControllerAccessPermission string
+
+// Controller access permissions that may be set on a user.
+const (
+	ControllerLoginAccess     ControllerAccessPermission = "login"
+	ControllerAddModelAccess  ControllerAccessPermission = "add-model"
+	ControllerSuperuserAccess ControllerAccessPermission = "superuser"
+)
@@ -35,4 +35,5 @@ type MetricResult struct {
 	Time  time.Time `json:"time"`
 	Key   string    `json:"key"`
 	Value string    `json:"value"`
+	Unit  string    `json:"unit"`
 }
@@ -40,6 +40,57 @@ type SetModelAgentVersion struct {
 	Version version.Number `json:"version"`
 }
 
+// UpgradeStep describes an upgrade step that an upgrade would run.
+type UpgradeStep struct {
+	Version     version.Number `json:"version"`
+	Description string         `json:"description"`
+	Targets     []string       `json:"targets"`
+}
+
+// UpgradeCheckResult holds the result of the CheckUpgrade client API
+// call: anything that would stop an upgrade from completing, and the
+// upgrade steps that it would run.
+type UpgradeCheckResult struct {
+	// Controller holds the id of the controller machine that
+	// served the request.
+	Controller string `json:"controller,omitempty"`
+
+	// Problems holds the failed pre-upgrade checks of the controller
+	// machine that served the request.
+	Problems []string `json:"problems,omitempty"`
+
+	// DownAgents holds the tags of the agents that are not running,
+	// and so would not upgrade.
+	DownAgents []string `json:"down-agents,omitempty"`
+
+	// Steps holds the upgrade steps that would run.
+	Steps []UpgradeStep `json:"steps,omitempty"`
+
+	// StepsVersion holds the version of Juju the controller is
+	// running when that is earlier than the version being upgraded
+	// to, or else the version being upgraded to. Upgrade steps added
+	// by versions later than StepsVersion are not listed in Steps.
+	StepsVersion version.Number `json:"steps-version"`
+}
+
+// UpgradePlanArgs contains the arguments for the SetUpgradePlan client
+// API call, which plans the rollout of a model upgrade in batches.
+type UpgradePlanArgs struct {
+	// Version is the agent version the model is upgraded to.
+	Version version.Number `json:"version"`
+
+	// Canaries holds the ids of the machines upgraded first.
+	Canaries []string `json:"canaries,omitempty"`
+
+	// BatchSize is the number of machines upgraded together after the
+	// canaries. Zero upgrades the remaining machines together.
+	BatchSize int `json:"batch-size,omitempty"`
+
+	// Pause is how long to wait after a batch has upgraded before
+	// upgrading the next one.
+	Pause time.Duration `json:"pause,omitempty"`
+}
+
 // ModelInfo holds information about the Juju model.
 type ModelInfo struct {
 	// The json names for the fields below are as per the older
@@ -129,6 +180,10 @@ type ModifyModelAccess struct {
 	Action   ModelAction           `json:"action"`
 	Access   ModelAccessPermission `json:"access"`
 	ModelTag string                `json:"model-tag"`
+
+	// GroupName, if set, names the user group whose access is
+	// changed; UserTag is then ignored.
+	GroupName string `json:"group-name,omitempty"`
 }
 
 // ModelAction is an action that can be performed on a model.
@@ -522,6 +522,27 @@ type AddSubnetParams struct {
 	Zones            []string `json:"zones,omitempty"`
 }
 
+// ReserveAddressesParams holds the arguments of ReserveAddresses API
+// call.
+type ReserveAddressesParams struct {
+	Addresses []ReserveAddressParams `json:"addresses"`
+}
+
+// ReserveAddressParams holds an IP address to reserve in the subnet
+// with SubnetTag, and an optional tag of the machine to reserve it
+// for.
+type ReserveAddressParams struct {
+	Address    string `json:"address"`
+	SubnetTag  string `json:"subnet-tag"`
+	MachineTag string `json:"machine-tag,omitempty"`
+}
+
+// ReleaseAddressesParams holds the arguments of ReleaseAddresses API
+// call.
+type ReleaseAddressesParams struct {
+	Addresses []string `json:"addresses"`
+}
+
 // CreateSubnetsParams holds the arguments of CreateSubnets API call.
 type CreateSubnetsParams struct {
 	Subnets []CreateSubnetParams `json:"subnets"`
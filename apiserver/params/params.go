@@ -265,6 +265,41 @@ type ApplicationUnset struct {
 	Options         []string `json:"options"`
 }
 
+// ModifyApplicationAccessRequest holds the parameters for making grant
+// and revoke application calls.
+type ModifyApplicationAccessRequest struct {
+	Changes []ModifyApplicationAccess `json:"changes"`
+}
+
+// ModifyApplicationAccess holds a single change to the access a model
+// user has on an application.
+type ModifyApplicationAccess struct {
+	UserTag        string                      `json:"user-tag"`
+	Action         ApplicationAction           `json:"action"`
+	Access         ApplicationAccessPermission `json:"access"`
+	ApplicationTag string                      `json:"application-tag"`
+}
+
+// ApplicationAction is an action that can be performed on the access
+// a user has to an application.
+type ApplicationAction string
+
+// Actions that can be performed on application access.
+const (
+	GrantApplicationAccess  ApplicationAction = "grant"
+	RevokeApplicationAccess ApplicationAction = "revoke"
+)
+
+// ApplicationAccessPermission is the type of permission that a user has
+// on an application.
+type ApplicationAccessPermission string
+
+// Application access permissions that may be set on a user.
+const (
+	ApplicationOperateAccess ApplicationAccessPermission = "operate"
+	ApplicationManageAccess  ApplicationAccessPermission = "manage"
+)
+
 // ApplicationGet holds parameters for making the Get or
 // GetCharmURL calls.
 type ApplicationGet struct {
@@ -460,6 +495,19 @@ type ModifyUserSSHKeys struct {
 	Keys []string `json:"ssh-keys"`
 }
 
+// UserSSHKeys holds the SSH keys, or the ids of SSH keys, to add to or
+// remove from a user account.
+type UserSSHKeys struct {
+	Tag  string   `json:"tag"`
+	Keys []string `json:"ssh-keys"`
+}
+
+// UserSSHKeysArgs holds the parameters for making a UserManager
+// AddSSHKeys or RemoveSSHKeys call.
+type UserSSHKeysArgs struct {
+	Changes []UserSSHKeys `json:"changes"`
+}
+
 // StateServingInfo holds information needed by a state
 // server.
 type StateServingInfo struct {
@@ -674,6 +722,29 @@ type ControllersSpecs struct {
 	Specs []ControllersSpec `json:"specs"`
 }
 
+// RemoveControllerMember contains the arguments for removing a
+// single controller machine from the controller.
+type RemoveControllerMember struct {
+	MachineTag string `json:"machine-tag"`
+	// Force allows a controller machine whose agent is still
+	// alive to be removed.
+	Force bool `json:"force,omitempty"`
+	// Replace asks for a replacement controller machine.
+	Replace bool `json:"replace,omitempty"`
+	// Placement, if set, is where the replacement is placed: an
+	// existing machine or a provider placement directive such as a
+	// zone. It implies Replace.
+	Placement   string            `json:"placement,omitempty"`
+	Constraints constraints.Value `json:"constraints,omitempty"`
+	Series      string            `json:"series,omitempty"`
+}
+
+// RemoveControllerMembers contains all the arguments
+// for the RemoveControllerMembers API call.
+type RemoveControllerMembers struct {
+	Members []RemoveControllerMember `json:"members"`
+}
+
 // ControllersChangeResult contains the results
 // of a single EnableHA API call or
 // an error.
@@ -15,6 +15,23 @@ type UserInfo struct {
 	DateCreated    time.Time  `json:"date-created"`
 	LastConnection *time.Time `json:"last-connection,omitempty"`
 	Disabled       bool       `json:"disabled"`
+
+	// ControllerAccess is the access the user has on the controller,
+	// or empty if they have none.
+	ControllerAccess ControllerAccessPermission `json:"controller-access,omitempty"`
+
+	// LockedOut is true if the user has been locked out after too
+	// many failed logins.
+	LockedOut bool `json:"locked-out,omitempty"`
+
+	// LoginHistory holds the user's most recent logins, newest first.
+	LoginHistory []LoginRecord `json:"login-history,omitempty"`
+}
+
+// LoginRecord describes a successful login of a user.
+type LoginRecord struct {
+	Time    time.Time `json:"time"`
+	Address string    `json:"address"`
 }
 
 // UserInfoResult holds the result of a UserInfo call.
@@ -70,3 +87,33 @@ type AddUserResult struct {
 	SecretKey []byte `json:"secret-key,omitempty"`
 	Error     *Error `json:"error,omitempty"`
 }
+
+// UserGroupNames holds the names of user groups to add or remove.
+type UserGroupNames struct {
+	Names []string `json:"names"`
+}
+
+// UserGroupMembers holds changes to the membership of user groups.
+type UserGroupMembers struct {
+	Changes []UserGroupMember `json:"changes"`
+}
+
+// UserGroupMember identifies a user within a user group.
+type UserGroupMember struct {
+	GroupName string `json:"group-name"`
+	UserTag   string `json:"user-tag"`
+}
+
+// UserGroupInfo holds information on a user group.
+type UserGroupInfo struct {
+	Name        string    `json:"name"`
+	CreatedBy   string    `json:"created-by"`
+	DateCreated time.Time `json:"date-created"`
+	External    bool      `json:"external"`
+	Members     []string  `json:"members"`
+}
+
+// UserGroupsResult holds the result of a UserGroups API call.
+type UserGroupsResult struct {
+	Groups []UserGroupInfo `json:"groups"`
+}
@@ -690,6 +690,11 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 			continue
 		}
 
+		if err := applyAddressReservations(container, preparedInfo); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
 		allocatedInfo, err := netEnviron.AllocateContainerAddresses(instId, machineTag, preparedInfo)
 		if err != nil {
 			result.Results[i].Error = common.ServerError(err)
@@ -705,6 +710,30 @@ func (p *ProvisionerAPI) prepareOrGetContainerInterfaceInfo(args params.Entities
 	return result, nil
 }
 
+// applyAddressReservations sets the address of each NIC in preparedInfo to the
+// address reserved for the container in the NIC's subnet, if any. The provider
+// will then try to allocate the reserved address instead of picking one.
+func applyAddressReservations(container *state.Machine, preparedInfo []network.InterfaceInfo) error {
+	reservations, err := container.IPAddressReservations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, reservation := range reservations {
+		for j, info := range preparedInfo {
+			if info.CIDR != reservation.SubnetCIDR() || info.Address.Value != "" {
+				continue
+			}
+			preparedInfo[j].Address = network.NewAddress(reservation.Value())
+			logger.Debugf(
+				"using reserved address %q for container %q interface %q",
+				reservation.Value(), container.Id(), info.InterfaceName,
+			)
+			break
+		}
+	}
+	return nil
+}
+
 // prepareContainerAccessEnvironment retrieves the environment, host machine, and access
 // for working with containers.
 func (p *ProvisionerAPI) prepareContainerAccessEnvironment() (environs.NetworkingEnviron, *state.Machine, common.AuthFunc, error) {
@@ -119,6 +119,42 @@ func (s *withoutControllerSuite) TestProvisionerFailsWithNonMachineAgentNonManag
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
+func (s *withoutControllerSuite) TestApplyAddressReservations(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, cidr := range []string{"10.20.0.0/16", "10.30.0.0/24"} {
+		_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: cidr, SpaceName: "dmz"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machines[0].Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, args := range []state.IPAddressReservationArgs{
+		{Value: "10.20.0.42", SubnetCIDR: "10.20.0.0/16", MachineID: container.Id()},
+		{Value: "10.30.0.42", SubnetCIDR: "10.30.0.0/24", MachineID: container.Id()},
+		// Reserved for another machine, so not used.
+		{Value: "10.20.0.43", SubnetCIDR: "10.20.0.0/16", MachineID: s.machines[1].Id()},
+	} {
+		_, err = s.State.AddIPAddressReservation(args)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	prepared := []network.InterfaceInfo{
+		{InterfaceName: "eth0", CIDR: "10.20.0.0/16"},
+		// An address set already is kept.
+		{InterfaceName: "eth1", CIDR: "10.30.0.0/24", Address: network.NewAddress("10.30.0.7")},
+		// There is no reservation in this subnet.
+		{InterfaceName: "eth2", CIDR: "10.40.0.0/24"},
+	}
+	err = provisioner.ApplyAddressReservations(container, prepared)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(prepared[0].Address, gc.Equals, network.NewAddress("10.20.0.42"))
+	c.Check(prepared[1].Address, gc.Equals, network.NewAddress("10.30.0.7"))
+	c.Check(prepared[2].Address, gc.Equals, network.Address{})
+}
+
 func (s *withoutControllerSuite) TestSetPasswords(c *gc.C) {
 	args := params.EntityPasswords{
 		Changes: []params.EntityPassword{
@@ -23,6 +23,10 @@ var readOnlyCalls = set.NewStrings(
 	"Application.CharmRelations",
 	"Application.Get",
 	"Block.List",
+	"CharmRepository.CharmOrigin",
+	"CharmRepository.ListCharms",
+	"CharmRepository.ResolveCharm",
+	"CharmUpgrades.UpgradesAvailable",
 	"Charms.CharmInfo",
 	"Charms.IsMetered",
 	"Charms.List",
@@ -58,6 +62,7 @@ var readOnlyCalls = set.NewStrings(
 	"Subnets.AllSpaces",
 	"Subnets.AllZones",
 	"Subnets.ListSubnets",
+	"UserManager.SSHKeys",
 	"UserManager.UserInfo",
 )
 
@@ -14,7 +14,7 @@ import (
 )
 
 func init() {
-	common.RegisterStandardFacade("Subnets", 2, NewAPI)
+	common.RegisterStandardFacade("Subnets", 3, NewAPI)
 }
 
 // SubnetsAPI defines the methods the Subnets API facade implements.
@@ -33,32 +33,52 @@ type SubnetsAPI interface {
 	// ListSubnets returns the matching subnets after applying
 	// optional filters.
 	ListSubnets(args params.SubnetsFilters) (params.ListSubnetsResults, error)
+
+	// ReserveAddresses reserves IP addresses in subnets of a space,
+	// optionally for a specific machine.
+	ReserveAddresses(args params.ReserveAddressesParams) (params.ErrorResults, error)
+
+	// ReleaseAddresses releases previously reserved IP addresses.
+	ReleaseAddresses(args params.ReleaseAddressesParams) (params.ErrorResults, error)
+}
+
+// AddressReservations defines the methods needed by the Subnets
+// facade to reserve and release IP addresses.
+type AddressReservations interface {
+	// ReserveAddress reserves value in the subnet with subnetCIDR,
+	// for the machine with machineID when not empty.
+	ReserveAddress(value, subnetCIDR, machineID string) error
+
+	// ReleaseAddress removes the reservation of value.
+	ReleaseAddress(value string) error
 }
 
 // subnetsAPI implements the SubnetsAPI interface.
 type subnetsAPI struct {
-	backing    networkingcommon.NetworkBacking
-	resources  *common.Resources
-	authorizer common.Authorizer
+	backing      networkingcommon.NetworkBacking
+	reservations AddressReservations
+	resources    *common.Resources
+	authorizer   common.Authorizer
 }
 
 // NewAPI creates a new Subnets API server-side facade with a
 // state.State backing.
 func NewAPI(st *state.State, res *common.Resources, auth common.Authorizer) (SubnetsAPI, error) {
-	return newAPIWithBacking(networkingcommon.NewStateShim(st), res, auth)
+	return newAPIWithBacking(networkingcommon.NewStateShim(st), reservationsShim{st}, res, auth)
 }
 
 // newAPIWithBacking creates a new server-side Subnets API facade with
 // a common.NetworkBacking
-func newAPIWithBacking(backing networkingcommon.NetworkBacking, resources *common.Resources, authorizer common.Authorizer) (SubnetsAPI, error) {
+func newAPIWithBacking(backing networkingcommon.NetworkBacking, reservations AddressReservations, resources *common.Resources, authorizer common.Authorizer) (SubnetsAPI, error) {
 	// Only clients can access the Subnets facade.
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
 	return &subnetsAPI{
-		backing:    backing,
-		resources:  resources,
-		authorizer: authorizer,
+		backing:      backing,
+		reservations: reservations,
+		resources:    resources,
+		authorizer:   authorizer,
 	}, nil
 }
 
@@ -96,3 +116,74 @@ func (api *subnetsAPI) AddSubnets(args params.AddSubnetsParams) (params.ErrorRes
 func (api *subnetsAPI) ListSubnets(args params.SubnetsFilters) (results params.ListSubnetsResults, err error) {
 	return networkingcommon.ListSubnets(api.backing, args)
 }
+
+// ReserveAddresses is defined on the API interface.
+func (api *subnetsAPI) ReserveAddresses(args params.ReserveAddressesParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Addresses)),
+	}
+	for i, arg := range args.Addresses {
+		err := api.reserveOneAddress(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *subnetsAPI) reserveOneAddress(arg params.ReserveAddressParams) error {
+	subnetTag, err := names.ParseSubnetTag(arg.SubnetTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var machineID string
+	if arg.MachineTag != "" {
+		machineTag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineID = machineTag.Id()
+		// Addresses reserved for a machine are only requested by
+		// providers allocating container addresses.
+		if err := networkingcommon.SupportsContainerAddresses(api.backing); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	err = api.reservations.ReserveAddress(arg.Address, subnetTag.Id(), machineID)
+	return errors.Trace(err)
+}
+
+// ReleaseAddresses is defined on the API interface.
+func (api *subnetsAPI) ReleaseAddresses(args params.ReleaseAddressesParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Addresses)),
+	}
+	for i, address := range args.Addresses {
+		err := api.reservations.ReleaseAddress(address)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// reservationsShim implements AddressReservations on top of
+// *state.State.
+type reservationsShim struct {
+	st *state.State
+}
+
+// ReserveAddress is defined on the AddressReservations interface.
+func (s reservationsShim) ReserveAddress(value, subnetCIDR, machineID string) error {
+	_, err := s.st.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      value,
+		SubnetCIDR: subnetCIDR,
+		MachineID:  machineID,
+	})
+	return err
+}
+
+// ReleaseAddress is defined on the AddressReservations interface.
+func (s reservationsShim) ReleaseAddress(value string) error {
+	reservation, err := s.st.IPAddressReservation(value)
+	if err != nil {
+		return err
+	}
+	return reservation.Remove()
+}
@@ -5,6 +5,7 @@ package subnets_test
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
@@ -25,9 +26,10 @@ type SubnetsSuite struct {
 	coretesting.BaseSuite
 	apiservertesting.StubNetwork
 
-	resources  *common.Resources
-	authorizer apiservertesting.FakeAuthorizer
-	facade     subnets.SubnetsAPI
+	resources    *common.Resources
+	authorizer   apiservertesting.FakeAuthorizer
+	reservations stubReservations
+	facade       subnets.SubnetsAPI
 }
 
 var _ = gc.Suite(&SubnetsSuite{})
@@ -46,6 +48,7 @@ func (s *SubnetsSuite) SetUpTest(c *gc.C) {
 	apiservertesting.BackingInstance.SetUp(c, apiservertesting.StubZonedEnvironName, apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets)
 
 	s.resources = common.NewResources()
+	s.reservations = stubReservations{}
 	s.authorizer = apiservertesting.FakeAuthorizer{
 		Tag:            names.NewUserTag("admin"),
 		EnvironManager: false,
@@ -53,7 +56,7 @@ func (s *SubnetsSuite) SetUpTest(c *gc.C) {
 
 	var err error
 	s.facade, err = subnets.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, s.authorizer,
+		apiservertesting.BackingInstance, &s.reservations, s.resources, s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(s.facade, gc.NotNil)
@@ -95,7 +98,7 @@ func (s *SubnetsSuite) AssertAllSpacesResult(c *gc.C, got params.SpaceResults, e
 func (s *SubnetsSuite) TestNewAPIWithBacking(c *gc.C) {
 	// Clients are allowed.
 	facade, err := subnets.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, s.authorizer,
+		apiservertesting.BackingInstance, &s.reservations, s.resources, s.authorizer,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(facade, gc.NotNil)
@@ -106,7 +109,7 @@ func (s *SubnetsSuite) TestNewAPIWithBacking(c *gc.C) {
 	agentAuthorizer := s.authorizer
 	agentAuthorizer.Tag = names.NewMachineTag("42")
 	facade, err = subnets.NewAPIWithBacking(
-		apiservertesting.BackingInstance, s.resources, agentAuthorizer,
+		apiservertesting.BackingInstance, &s.reservations, s.resources, agentAuthorizer,
 	)
 	c.Assert(err, jc.DeepEquals, common.ErrPerm)
 	c.Assert(facade, gc.IsNil)
@@ -831,3 +834,91 @@ func (s *SubnetsSuite) TestListSubnetsAllSubnetError(c *gc.C) {
 	_, err := s.facade.ListSubnets(params.SubnetsFilters{})
 	c.Assert(err, gc.ErrorMatches, "no subnets for you")
 }
+
+func (s *SubnetsSuite) TestReserveAddresses(c *gc.C) {
+	apiservertesting.BackingInstance.SetUp(c, apiservertesting.StubZonedNetworkingEnvironName, apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets)
+	s.reservations.SetErrors(nil, errors.AlreadyExistsf("reservation for address %q", "10.10.0.5"))
+
+	args := params.ReserveAddressesParams{
+		Addresses: []params.ReserveAddressParams{{
+			Address:    "10.10.0.4",
+			SubnetTag:  "subnet-10.10.0.0/24",
+			MachineTag: "machine-0-lxd-1",
+		}, {
+			Address:   "10.10.0.5",
+			SubnetTag: "subnet-10.10.0.0/24",
+		}, {
+			Address:   "10.10.0.6",
+			SubnetTag: "invalid",
+		}},
+	}
+	results, err := s.facade.ReserveAddresses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `reservation for address "10.10.0.5" already exists`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"invalid" is not a valid tag`)
+
+	s.reservations.CheckCalls(c, []testing.StubCall{{
+		FuncName: "ReserveAddress",
+		Args:     []interface{}{"10.10.0.4", "10.10.0.0/24", "0/lxd/1"},
+	}, {
+		FuncName: "ReserveAddress",
+		Args:     []interface{}{"10.10.0.5", "10.10.0.0/24", ""},
+	}})
+}
+
+func (s *SubnetsSuite) TestReserveAddressForMachineNotSupported(c *gc.C) {
+	// The stub zoned environ does not support networking, so cannot
+	// allocate container addresses.
+	args := params.ReserveAddressesParams{
+		Addresses: []params.ReserveAddressParams{{
+			Address:    "10.10.0.4",
+			SubnetTag:  "subnet-10.10.0.0/24",
+			MachineTag: "machine-0-lxd-1",
+		}, {
+			Address:   "10.10.0.5",
+			SubnetTag: "subnet-10.10.0.0/24",
+		}},
+	}
+	results, err := s.facade.ReserveAddresses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "container address allocation not supported")
+	c.Check(results.Results[1].Error, gc.IsNil)
+
+	s.reservations.CheckCalls(c, []testing.StubCall{{
+		FuncName: "ReserveAddress",
+		Args:     []interface{}{"10.10.0.5", "10.10.0.0/24", ""},
+	}})
+}
+
+func (s *SubnetsSuite) TestReleaseAddresses(c *gc.C) {
+	s.reservations.SetErrors(errors.NotFoundf("reservation for address %q", "10.10.0.4"))
+
+	args := params.ReleaseAddressesParams{
+		Addresses: []string{"10.10.0.4", "10.10.0.5"},
+	}
+	results, err := s.facade.ReleaseAddresses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `reservation for address "10.10.0.4" not found`)
+	c.Check(results.Results[1].Error, gc.IsNil)
+
+	s.reservations.CheckCallNames(c, "ReleaseAddress", "ReleaseAddress")
+}
+
+type stubReservations struct {
+	testing.Stub
+}
+
+func (s *stubReservations) ReserveAddress(value, subnetCIDR, machineID string) error {
+	s.AddCall("ReserveAddress", value, subnetCIDR, machineID)
+	return s.NextErr()
+}
+
+func (s *stubReservations) ReleaseAddress(value string) error {
+	s.AddCall("ReleaseAddress", value)
+	return s.NextErr()
+}
@@ -601,6 +601,14 @@ func (se *StubNetworkingEnviron) SupportsSpaces() (bool, error) {
 	return true, nil
 }
 
+func (se *StubNetworkingEnviron) SupportsContainerAddresses() (bool, error) {
+	se.MethodCall(se, "SupportsContainerAddresses")
+	if err := se.NextErr(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // GoString implements fmt.GoStringer.
 func (se *StubNetworkingEnviron) GoString() string {
 	return "&StubNetworkingEnviron{}"
@@ -630,6 +638,14 @@ func (se *StubZonedNetworkingEnviron) SupportsSpaces() (bool, error) {
 	return true, nil
 }
 
+func (se *StubZonedNetworkingEnviron) SupportsContainerAddresses() (bool, error) {
+	se.MethodCall(se, "SupportsContainerAddresses")
+	if err := se.NextErr(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (se *StubZonedNetworkingEnviron) Subnets(instId instance.Id, subIds []network.Id) ([]network.SubnetInfo, error) {
 	se.MethodCall(se, "Subnets", instId, subIds)
 	if err := se.NextErr(); err != nil {
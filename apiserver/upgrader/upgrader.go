@@ -92,7 +92,8 @@ func NewUpgraderAPI(
 }
 
 // WatchAPIVersion starts a watcher to track if there is a new version
-// of the API that we want to upgrade to
+// of the API that we want to upgrade to, either because the model's
+// agent version or its upgrade plan has changed.
 func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchResults, error) {
 	result := params.NotifyWatchResults{
 		Results: make([]params.NotifyWatchResult, len(args.Entities)),
@@ -104,7 +105,10 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			watch := u.st.WatchForModelConfigChanges()
+			watch := common.NewMultiNotifyWatcher(
+				u.st.WatchForModelConfigChanges(),
+				u.st.WatchUpgradePlan(),
+			)
 			// Consume the initial event. Technically, API
 			// calls to Watch 'transmit' the initial event
 			// in the Watch response. But NotifyWatchers
@@ -150,18 +154,35 @@ func (u *UpgraderAPI) entityIsManager(tag names.Tag) bool {
 	}
 }
 
+// upgradePlan returns the model's upgrade plan if it is rolling out
+// the given agent version, or nil.
+func (u *UpgraderAPI) upgradePlan(agentVersion version.Number) (*state.UpgradePlan, error) {
+	plan, err := u.st.UpgradePlan()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if plan.TargetVersion() != agentVersion {
+		return nil, nil
+	}
+	return plan, nil
+}
+
 // DesiredVersion reports the Agent Version that we want that agent to be running
 func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResults, error) {
 	results := make([]params.VersionResult, len(args.Entities))
 	if len(args.Entities) == 0 {
 		return params.VersionResults{}, nil
 	}
-	agentVersion, _, err := u.getGlobalAgentVersion()
+	globalVersion, _, err := u.getGlobalAgentVersion()
+	if err != nil {
+		return params.VersionResults{}, common.ServerError(err)
+	}
+	plan, err := u.upgradePlan(globalVersion)
 	if err != nil {
 		return params.VersionResults{}, common.ServerError(err)
 	}
-	// Is the desired version greater than the current API server version?
-	isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -170,6 +191,21 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 		}
 		err = common.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
+			isManager := u.entityIsManager(tag)
+			// Machines held back by the model's upgrade plan keep
+			// the version they are being upgraded from until their
+			// batch is released. Controllers are always upgraded
+			// first.
+			agentVersion := globalVersion
+			if plan != nil && !isManager {
+				if tag, ok := tag.(names.MachineTag); ok {
+					agentVersion = plan.MachineTargetVersion(tag.Id())
+				}
+			}
+			// Is the desired version greater than the current API
+			// server version?
+			isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
+
 			// Only return the globally desired agent version if the
 			// asking entity is a machine agent with JobManageModel or
 			// if this API server is running the globally desired agent
@@ -180,7 +216,7 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 			// first - once they have restarted and are running the
 			// new version other agents will start to see the new
 			// agent version.
-			if !isNewerVersion || u.entityIsManager(tag) {
+			if !isNewerVersion || isManager {
 				results[i].Version = &agentVersion
 			} else {
 				logger.Debugf("desired version is %s, but current version is %s and agent is not a manager node", agentVersion, jujuversion.Current)
@@ -289,6 +289,66 @@ func (s *upgraderSuite) TestDesiredVersionForAgent(c *gc.C) {
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
 
+func (s *upgraderSuite) TestDesiredVersionFollowsUpgradePlan(c *gc.C) {
+	previous := version.MustParse("1.2.3")
+	err := statetesting.SetAgentVersion(s.State, previous)
+	c.Assert(err, jc.ErrorIsNil)
+	heldMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: jujuversion.Current,
+		Batches:       [][]string{{s.rawMachine.Id()}, {heldMachine.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = statetesting.SetAgentVersion(s.State, jujuversion.Current)
+	c.Assert(err, jc.ErrorIsNil)
+
+	desiredVersion := func(machine *state.Machine) version.Number {
+		authorizer := apiservertesting.FakeAuthorizer{
+			Tag: machine.Tag(),
+		}
+		upgraderAPI, err := upgrader.NewUpgraderAPI(s.State, s.resources, authorizer)
+		c.Assert(err, jc.ErrorIsNil)
+		args := params.Entities{Entities: []params.Entity{{Tag: machine.Tag().String()}}}
+		results, err := upgraderAPI.DesiredVersion(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Assert(results.Results[0].Error, gc.IsNil)
+		c.Assert(results.Results[0].Version, gc.NotNil)
+		return *results.Results[0].Version
+	}
+	// The canary is upgraded, the machine in the next batch is held
+	// back, and the controller is unaffected by the plan.
+	c.Check(desiredVersion(s.rawMachine), gc.Equals, jujuversion.Current)
+	c.Check(desiredVersion(heldMachine), gc.Equals, previous)
+	c.Check(desiredVersion(s.apiMachine), gc.Equals, jujuversion.Current)
+}
+
+func (s *upgraderSuite) TestWatchAPIVersionNoticesUpgradePlan(c *gc.C) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}},
+	}
+	results, err := s.upgrader.WatchAPIVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	w := s.resources.Get(results.Results[0].NotifyWatcherId).(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertNoChange()
+
+	err = statetesting.SetAgentVersion(s.State, version.MustParse("1.2.3"))
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: jujuversion.Current,
+		Batches:       [][]string{{s.rawMachine.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
+
 func (s *upgraderSuite) bumpDesiredAgentVersion(c *gc.C) version.Number {
 	// In order to call SetModelAgentVersion we have to first SetTools on
 	// all the existing machines
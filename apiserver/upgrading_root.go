@@ -30,9 +30,10 @@ func newUpgradingRoot(finder rpc.MethodFinder) *upgradingRoot {
 // facade versions as well.
 var allowedMethodsDuringUpgrades = map[string]set.Strings{
 	"Client": set.NewStrings(
-		"FullStatus",          // for "juju status"
-		"FindTools",           // for "juju upgrade-juju", before we can reset upgrade to re-run
-		"AbortCurrentUpgrade", // for "juju upgrade-juju", so that we can reset upgrade to re-run
+		"FullStatus",             // for "juju status"
+		"FindTools",              // for "juju upgrade-juju", before we can reset upgrade to re-run
+		"AbortCurrentUpgrade",    // for "juju upgrade-juju", so that we can reset upgrade to re-run
+		"RollbackCurrentUpgrade", // for "juju upgrade-juju --abort"
 
 	),
 	"SSHClient": set.NewStrings( // allow all SSH client related calls
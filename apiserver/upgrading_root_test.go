@@ -28,6 +28,7 @@ func (r *upgradingRootSuite) TestAllowedMethods(c *gc.C) {
 	}
 	checkAllowed("Client", "FullStatus")
 	checkAllowed("Client", "AbortCurrentUpgrade")
+	checkAllowed("Client", "RollbackCurrentUpgrade")
 	checkAllowed("SSHClient", "PublicAddress")
 	checkAllowed("SSHClient", "Proxy")
 	checkAllowed("Pinger", "Ping")
@@ -0,0 +1,112 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddUserGroups adds the named user groups.
+func (api *UserManagerAPI) AddUserGroups(args params.UserGroupNames) (params.ErrorResults, error) {
+	return api.userGroupsImpl(args, func(name string) error {
+		_, err := api.state.AddUserGroup(name, api.apiUser.Canonical())
+		return errors.Annotate(err, "failed to add group")
+	})
+}
+
+// RemoveUserGroups removes the named user groups, along with the access
+// granted to them.
+func (api *UserManagerAPI) RemoveUserGroups(args params.UserGroupNames) (params.ErrorResults, error) {
+	return api.userGroupsImpl(args, func(name string) error {
+		return errors.Annotate(api.state.RemoveUserGroup(name), "failed to remove group")
+	})
+}
+
+func (api *UserManagerAPI) userGroupsImpl(args params.UserGroupNames, method func(string) error) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if len(args.Names) == 0 {
+		return result, nil
+	}
+	if !api.isAdmin {
+		return result, common.ErrPerm
+	}
+	for i, name := range args.Names {
+		result.Results[i].Error = common.ServerError(method(name))
+	}
+	return result, nil
+}
+
+// AddUserGroupMembers adds users to local user groups.
+func (api *UserManagerAPI) AddUserGroupMembers(args params.UserGroupMembers) (params.ErrorResults, error) {
+	return api.userGroupMembersImpl(args, (*state.UserGroup).AddMember)
+}
+
+// RemoveUserGroupMembers removes users from local user groups.
+func (api *UserManagerAPI) RemoveUserGroupMembers(args params.UserGroupMembers) (params.ErrorResults, error) {
+	return api.userGroupMembersImpl(args, (*state.UserGroup).RemoveMember)
+}
+
+func (api *UserManagerAPI) userGroupMembersImpl(args params.UserGroupMembers, method func(*state.UserGroup, names.UserTag) error) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if len(args.Changes) == 0 {
+		return result, nil
+	}
+	if !api.isAdmin {
+		return result, common.ErrPerm
+	}
+	for i, arg := range args.Changes {
+		userTag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		group, err := api.state.UserGroup(arg.GroupName)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Error = common.ServerError(method(group, userTag))
+	}
+	return result, nil
+}
+
+// UserGroups returns information on all the user groups.
+func (api *UserManagerAPI) UserGroups() (params.UserGroupsResult, error) {
+	var result params.UserGroupsResult
+	groups, err := api.state.AllUserGroups()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Groups = make([]params.UserGroupInfo, len(groups))
+	for i, group := range groups {
+		members := group.Members()
+		info := params.UserGroupInfo{
+			Name:        group.Name(),
+			CreatedBy:   group.CreatedBy(),
+			DateCreated: group.DateCreated(),
+			External:    group.IsExternal(),
+			Members:     make([]string, len(members)),
+		}
+		for j, member := range members {
+			info.Members[j] = member.Canonical()
+		}
+		result.Groups[i] = info
+	}
+	return result, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/usermanager"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *userManagerSuite) TestAddRemoveUserGroups(c *gc.C) {
+	result, err := s.usermanager.AddUserGroups(params.UserGroupNames{Names: []string{"devs", "not/valid"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `failed to add group: group name "not/valid" not valid`)
+
+	group, err := s.State.UserGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.CreatedBy(), gc.Equals, s.AdminUserTag(c).Canonical())
+
+	result, err = s.usermanager.RemoveUserGroups(params.UserGroupNames{Names: []string{"devs"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	_, err = s.State.UserGroup("devs")
+	c.Assert(err, gc.ErrorMatches, `group "devs" not found`)
+}
+
+func (s *userManagerSuite) TestUserGroupMembers(c *gc.C) {
+	_, err := s.State.AddUserGroup("devs", s.adminName)
+	c.Assert(err, jc.ErrorIsNil)
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+
+	args := params.UserGroupMembers{Changes: []params.UserGroupMember{{
+		GroupName: "devs",
+		UserTag:   bob.String(),
+	}, {
+		GroupName: "ops",
+		UserTag:   bob.String(),
+	}}}
+	result, err := s.usermanager.AddUserGroupMembers(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `group "ops" not found`)
+
+	groups, err := s.usermanager.UserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups.Groups, gc.HasLen, 1)
+	c.Assert(groups.Groups[0].Name, gc.Equals, "devs")
+	c.Assert(groups.Groups[0].External, jc.IsFalse)
+	c.Assert(groups.Groups[0].Members, jc.DeepEquals, []string{"bob@local"})
+
+	result, err = s.usermanager.RemoveUserGroupMembers(params.UserGroupMembers{Changes: args.Changes[:1]})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	group, err := s.State.UserGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestUserGroupsNonAdmin(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"}).UserTag()
+	authorizer := s.authorizer
+	authorizer.Tag = bob
+	api, err := usermanager.NewUserManagerAPI(s.State, s.resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.AddUserGroups(params.UserGroupNames{Names: []string{"devs"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = api.AddUserGroupMembers(params.UserGroupMembers{Changes: []params.UserGroupMember{{
+		GroupName: "devs",
+		UserTag:   bob.String(),
+	}}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *userManagerSuite) TestBlockAddUserGroups(c *gc.C) {
+	s.BlockAllChanges(c, "TestBlockAddUserGroups")
+	_, err := s.usermanager.AddUserGroups(params.UserGroupNames{Names: []string{"devs"}})
+	s.AssertBlocked(c, err, "TestBlockAddUserGroups")
+}
@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SSHKeys returns the public SSH keys of the specified users, either as
+// full keys or as fingerprints, depending on the requested mode. Users
+// may only list their own keys unless they are a controller
+// administrator.
+func (api *UserManagerAPI) SSHKeys(args params.ListSSHKeys) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities.Entities)),
+	}
+	for i, arg := range args.Entities.Entities {
+		user, err := api.getSSHKeysUser(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = formatSSHKeys(user.SSHKeys(), args.Mode)
+	}
+	return results, nil
+}
+
+// AddSSHKeys adds public SSH keys to the specified users. The keys are
+// installed on the machines of every model the users may change.
+func (api *UserManagerAPI) AddSSHKeys(args params.UserSSHKeysArgs) (params.ErrorResults, error) {
+	return api.modifySSHKeys(args, (*state.User).AddSSHKeys)
+}
+
+// RemoveSSHKeys removes the public SSH keys, identified by fingerprint
+// or comment, from the specified users.
+func (api *UserManagerAPI) RemoveSSHKeys(args params.UserSSHKeysArgs) (params.ErrorResults, error) {
+	return api.modifySSHKeys(args, (*state.User).RemoveSSHKeys)
+}
+
+func (api *UserManagerAPI) modifySSHKeys(args params.UserSSHKeysArgs, method func(*state.User, []string) error) (params.ErrorResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, arg := range args.Changes {
+		user, err := api.getSSHKeysUser(arg.Tag)
+		if err == nil {
+			err = method(user, arg.Keys)
+		}
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// getSSHKeysUser returns the user with the given tag if the
+// authenticated user may manage its SSH keys.
+func (api *UserManagerAPI) getSSHKeysUser(tag string) (*state.User, error) {
+	user, err := api.getUser(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if api.apiUser != user.UserTag() && !api.isAdmin {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return user, nil
+}
+
+// formatSSHKeys returns the keys as they should be listed in the given
+// mode.
+func formatSSHKeys(keys []string, mode ssh.ListMode) []string {
+	if mode == ssh.FullKeys {
+		return keys
+	}
+	var keyInfo []string
+	for _, key := range keys {
+		fingerprint, comment, err := ssh.KeyFingerprint(key)
+		if err != nil {
+			keyInfo = append(keyInfo, fmt.Sprintf("Invalid key: %v", key))
+			continue
+		}
+		if comment != "" {
+			fingerprint += fmt.Sprintf(" (%s)", comment)
+		}
+		keyInfo = append(keyInfo, fingerprint)
+	}
+	return keyInfo
+}
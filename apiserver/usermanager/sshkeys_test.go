@@ -0,0 +1,94 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package usermanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/ssh"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/apiserver/usermanager"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *userManagerSuite) TestAddRemoveSSHKeys(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true})
+	key := sshtesting.ValidKeyOne.Key + " bob@laptop"
+
+	result, err := s.usermanager.AddSSHKeys(params.UserSSHKeysArgs{
+		Changes: []params.UserSSHKeys{{
+			Tag:  bob.Tag().String(),
+			Keys: []string{key},
+		}, {
+			Tag:  bob.Tag().String(),
+			Keys: []string{"invalid"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `cannot add ssh keys to user "bob": ssh key "invalid" not valid`)
+
+	keys, err := s.usermanager.SSHKeys(params.ListSSHKeys{
+		Entities: params.Entities{Entities: []params.Entity{{Tag: bob.Tag().String()}}},
+		Mode:     ssh.Fingerprints,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{sshtesting.ValidKeyOne.Fingerprint + " (bob@laptop)"},
+		}},
+	})
+
+	result, err = s.usermanager.RemoveSSHKeys(params.UserSSHKeysArgs{
+		Changes: []params.UserSSHKeys{{
+			Tag:  bob.Tag().String(),
+			Keys: []string{"bob@laptop"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	err = bob.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bob.SSHKeys(), gc.HasLen, 0)
+}
+
+func (s *userManagerSuite) TestSSHKeysAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	key := sshtesting.ValidKeyTwo.Key + " alex@laptop"
+	result, err := usermanager.AddSSHKeys(params.UserSSHKeysArgs{
+		Changes: []params.UserSSHKeys{{
+			Tag:  alex.Tag().String(),
+			Keys: []string{key},
+		}, {
+			Tag:  barb.Tag().String(),
+			Keys: []string{key},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, "permission denied")
+
+	keys, err := usermanager.SSHKeys(params.ListSSHKeys{
+		Entities: params.Entities{Entities: []params.Entity{
+			{Tag: alex.Tag().String()},
+			{Tag: barb.Tag().String()},
+		}},
+		Mode: ssh.FullKeys,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys.Results, gc.HasLen, 2)
+	c.Assert(keys.Results[0].Result, jc.DeepEquals, []string{key})
+	c.Assert(keys.Results[1].Error, gc.ErrorMatches, "permission denied")
+}
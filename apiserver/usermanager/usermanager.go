@@ -168,6 +168,16 @@ func (api *UserManagerAPI) DisableUser(users params.Entities) (params.ErrorResul
 	return api.enableUserImpl(users, "disable", (*state.User).Disable)
 }
 
+// UnlockUser allows one or more users locked out after too many failed
+// logins to log in again. If the user is not locked out, the action is
+// considered a success.
+func (api *UserManagerAPI) UnlockUser(users params.Entities) (params.ErrorResults, error) {
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	return api.enableUserImpl(users, "unlock", (*state.User).Unlock)
+}
+
 func (api *UserManagerAPI) enableUserImpl(args params.Entities, action string, method func(*state.User) error) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -206,14 +216,32 @@ func (api *UserManagerAPI) UserInfo(request params.UserInfoRequest) (params.User
 		} else {
 			lastLogin = &userLastLogin
 		}
+		access, err := api.state.ControllerAccess(user.UserTag())
+		if err != nil && !errors.IsNotFound(err) {
+			logger.Debugf("error getting controller access: %v", err)
+		}
+		var loginHistory []params.LoginRecord
+		history, err := user.LoginHistory()
+		if err != nil {
+			logger.Debugf("error getting login history: %v", err)
+		}
+		for _, record := range history {
+			loginHistory = append(loginHistory, params.LoginRecord{
+				Time:    record.Time,
+				Address: record.Address,
+			})
+		}
 		return params.UserInfoResult{
 			Result: &params.UserInfo{
-				Username:       user.Name(),
-				DisplayName:    user.DisplayName(),
-				CreatedBy:      user.CreatedBy(),
-				DateCreated:    user.DateCreated(),
-				LastConnection: lastLogin,
-				Disabled:       user.IsDisabled(),
+				Username:         user.Name(),
+				DisplayName:      user.DisplayName(),
+				CreatedBy:        user.CreatedBy(),
+				DateCreated:      user.DateCreated(),
+				LastConnection:   lastLogin,
+				Disabled:         user.IsDisabled(),
+				ControllerAccess: params.ControllerAccessPermission(access),
+				LockedOut:        user.IsLockedOut(),
+				LoginHistory:     loginHistory,
 			},
 		}
 	}
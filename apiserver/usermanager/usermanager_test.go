@@ -378,6 +378,77 @@ func (s *userManagerSuite) TestEnableUserAsNormalUser(c *gc.C) {
 	c.Assert(barb.IsDisabled(), jc.IsTrue)
 }
 
+func (s *userManagerSuite) TestUnlockUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	for i := 0; i < 3; i++ {
+		err := alex.RecordFailedLogin(3)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(alex.IsLockedOut(), jc.IsTrue)
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb"})
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{alex.Tag().String()},
+			{barb.Tag().String()},
+			{names.NewLocalUserTag("ellie").String()},
+		}}
+	result, err := s.usermanager.UnlockUser(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{Error: nil},
+			{Error: nil},
+			{Error: &params.Error{
+				Message: "permission denied",
+				Code:    params.CodeUnauthorized,
+			}},
+		}})
+	err = alex.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(alex.IsLockedOut(), jc.IsFalse)
+}
+
+func (s *userManagerSuite) TestUnlockUserAsNormalUser(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex", NoModelUser: true})
+	usermanager, err := usermanager.NewUserManagerAPI(
+		s.State, s.resources, apiservertesting.FakeAuthorizer{Tag: alex.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	barb := s.Factory.MakeUser(c, &factory.UserParams{Name: "barb"})
+	err = barb.RecordFailedLogin(1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		[]params.Entity{{barb.Tag().String()}},
+	}
+	_, err = usermanager.UnlockUser(args)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	err = barb.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(barb.IsLockedOut(), jc.IsTrue)
+}
+
+func (s *userManagerSuite) TestUserInfoLoginHistory(c *gc.C) {
+	alex := s.Factory.MakeUser(c, &factory.UserParams{Name: "alex"})
+	err := alex.RecordLogin("10.0.0.1:34567", 10)
+	c.Assert(err, jc.ErrorIsNil)
+	err = alex.RecordLogin("10.0.0.2:34567", 10)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.usermanager.UserInfo(params.UserInfoRequest{
+		Entities: []params.Entity{{Tag: alex.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	history := results.Results[0].Result.LoginHistory
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Address, gc.Equals, "10.0.0.2:34567")
+	c.Assert(history[1].Address, gc.Equals, "10.0.0.1:34567")
+}
+
 func (s *userManagerSuite) TestUserInfo(c *gc.C) {
 	userFoo := s.Factory.MakeUser(c, &factory.UserParams{Name: "foobar", DisplayName: "Foo Bar"})
 	userBar := s.Factory.MakeUser(c, &factory.UserParams{Name: "barfoo", DisplayName: "Bar Foo", Disabled: true})
@@ -408,15 +479,17 @@ func (s *userManagerSuite) TestUserInfo(c *gc.C) {
 		{
 			user: userFoo,
 			info: &params.UserInfo{
-				Username:    "foobar",
-				DisplayName: "Foo Bar",
+				Username:         "foobar",
+				DisplayName:      "Foo Bar",
+				ControllerAccess: params.ControllerLoginAccess,
 			},
 		}, {
 			user: userBar,
 			info: &params.UserInfo{
-				Username:    "barfoo",
-				DisplayName: "Bar Foo",
-				Disabled:    true,
+				Username:         "barfoo",
+				DisplayName:      "Bar Foo",
+				Disabled:         true,
+				ControllerAccess: params.ControllerLoginAccess,
 			},
 		}, {
 			err: &params.Error{
@@ -461,21 +534,24 @@ func (s *userManagerSuite) TestUserInfoAll(c *gc.C) {
 	}{{
 		user: userAardvark,
 		info: &params.UserInfo{
-			Username:    "aardvark",
-			DisplayName: "Aard Vark",
-			Disabled:    true,
+			Username:         "aardvark",
+			DisplayName:      "Aard Vark",
+			Disabled:         true,
+			ControllerAccess: params.ControllerLoginAccess,
 		},
 	}, {
 		user: admin,
 		info: &params.UserInfo{
-			Username:    s.adminName,
-			DisplayName: admin.DisplayName(),
+			Username:         s.adminName,
+			DisplayName:      admin.DisplayName(),
+			ControllerAccess: params.ControllerSuperuserAccess,
 		},
 	}, {
 		user: userFoo,
 		info: &params.UserInfo{
-			Username:    "foobar",
-			DisplayName: "Foo Bar",
+			Username:         "foobar",
+			DisplayName:      "Foo Bar",
+			ControllerAccess: params.ControllerLoginAccess,
 		},
 	}} {
 		r.info.CreatedBy = s.adminName
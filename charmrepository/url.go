@@ -0,0 +1,129 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrepository holds the types shared by the parts of Juju
+// that deal with the charm repository hosted by the controller.
+//
+// Charms published to the repository are addressed by URLs with the
+// "repo" schema, e.g. "repo:mysql", "repo:mysql-3" or
+// "repo:trusty/mysql-3". A URL without a revision resolves to the
+// revision currently in the requested channel. A series, if present,
+// selects the series the charm is deployed with; it plays no part in
+// identifying the published charm.
+//
+// Models do not refer to repository charms directly: a repository charm
+// is copied into a model as a local charm, which records the repository
+// URL it came from.
+package charmrepository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+)
+
+// Schema is the schema of charm repository URLs.
+const Schema = "repo"
+
+// Channels holds the channels charms may be promoted to, from the most
+// to the least stable.
+var Channels = []csparams.Channel{
+	csparams.StableChannel,
+	csparams.CandidateChannel,
+	csparams.BetaChannel,
+	csparams.EdgeChannel,
+}
+
+// DefaultChannel is the channel URLs without a revision are resolved
+// against when no channel is given.
+const DefaultChannel = csparams.StableChannel
+
+// ValidateChannel returns an error if the channel is not one that
+// charms may be promoted to.
+func ValidateChannel(channel csparams.Channel) error {
+	for _, valid := range Channels {
+		if channel == valid {
+			return nil
+		}
+	}
+	return errors.NotValidf("channel %q", channel)
+}
+
+// URL identifies a charm in the charm repository.
+type URL struct {
+	// Name is the name of the charm.
+	Name string
+
+	// Series is the series the charm is to be deployed with. It is
+	// empty when the series is left to the deployer.
+	Series string
+
+	// Revision is the revision of the charm. It is -1 when the URL
+	// refers to the revision in a channel.
+	Revision int
+}
+
+// IsURL returns whether the string is a charm repository URL, as
+// opposed to a charm store URL or a path.
+func IsURL(s string) bool {
+	return strings.HasPrefix(s, Schema+":")
+}
+
+// ParseURL parses a charm repository URL.
+func ParseURL(s string) (*URL, error) {
+	if !IsURL(s) {
+		return nil, errors.NotValidf("charm repository URL %q", s)
+	}
+	// Apart from the schema, repository URLs follow the same rules as
+	// local charm URLs.
+	curl, err := charm.ParseURL("local:" + strings.TrimPrefix(s, Schema+":"))
+	if err != nil {
+		return nil, errors.Errorf("cannot parse charm repository URL %q: %v", s, err)
+	}
+	return &URL{
+		Name:     curl.Name,
+		Series:   curl.Series,
+		Revision: curl.Revision,
+	}, nil
+}
+
+// MustParseURL works like ParseURL, but panics in case of errors.
+func MustParseURL(s string) *URL {
+	u, err := ParseURL(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// WithRevision returns a URL equivalent to u but with its revision
+// replaced by the given one.
+func (u *URL) WithRevision(revision int) *URL {
+	u2 := *u
+	u2.Revision = revision
+	return &u2
+}
+
+// WithSeries returns a URL equivalent to u but with its series replaced
+// by the given one.
+func (u *URL) WithSeries(series string) *URL {
+	u2 := *u
+	u2.Series = series
+	return &u2
+}
+
+// String returns the string form of the URL.
+func (u *URL) String() string {
+	s := Schema + ":"
+	if u.Series != "" {
+		s += u.Series + "/"
+	}
+	s += u.Name
+	if u.Revision >= 0 {
+		s += fmt.Sprintf("-%d", u.Revision)
+	}
+	return s
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/charmrepository"
+)
+
+type URLSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&URLSuite{})
+
+var parseURLTests = []struct {
+	about  string
+	url    string
+	expect charmrepository.URL
+	err    string
+}{{
+	about:  "name only",
+	url:    "repo:mysql",
+	expect: charmrepository.URL{Name: "mysql", Revision: -1},
+}, {
+	about:  "name and revision",
+	url:    "repo:mysql-3",
+	expect: charmrepository.URL{Name: "mysql", Revision: 3},
+}, {
+	about:  "series, name and revision",
+	url:    "repo:trusty/mysql-3",
+	expect: charmrepository.URL{Name: "mysql", Series: "trusty", Revision: 3},
+}, {
+	about: "charm store URL",
+	url:   "cs:mysql",
+	err:   `charm repository URL "cs:mysql" not valid`,
+}, {
+	about: "user",
+	url:   "repo:~bob/mysql",
+	err:   `cannot parse charm repository URL "repo:~bob/mysql": .*`,
+}}
+
+func (s *URLSuite) TestParseURL(c *gc.C) {
+	for i, test := range parseURLTests {
+		c.Logf("test %d: %s", i, test.about)
+		u, err := charmrepository.ParseURL(test.url)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(*u, jc.DeepEquals, test.expect)
+		c.Check(u.String(), gc.Equals, test.url)
+	}
+}
+
+func (s *URLSuite) TestIsURL(c *gc.C) {
+	c.Check(charmrepository.IsURL("repo:mysql"), jc.IsTrue)
+	c.Check(charmrepository.IsURL("cs:mysql"), jc.IsFalse)
+	c.Check(charmrepository.IsURL("./repo/mysql"), jc.IsFalse)
+}
+
+func (s *URLSuite) TestWith(c *gc.C) {
+	u := charmrepository.MustParseURL("repo:mysql")
+	c.Check(u.WithRevision(2).String(), gc.Equals, "repo:mysql-2")
+	c.Check(u.WithSeries("xenial").String(), gc.Equals, "repo:xenial/mysql")
+	c.Check(u.String(), gc.Equals, "repo:mysql")
+}
+
+func (s *URLSuite) TestValidateChannel(c *gc.C) {
+	for _, channel := range charmrepository.Channels {
+		c.Check(charmrepository.ValidateChannel(channel), jc.ErrorIsNil)
+	}
+	err := charmrepository.ValidateChannel(csparams.UnpublishedChannel)
+	c.Check(err, gc.ErrorMatches, `channel "unpublished" not valid`)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
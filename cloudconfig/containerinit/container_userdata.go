@@ -6,6 +6,7 @@ package containerinit
 
 import (
 	"bytes"
+	"fmt"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
@@ -94,20 +95,20 @@ func GenerateNetworkConfig(networkConfig *container.NetworkConfig) (string, erro
 		}
 
 		address, hasAddress := prepared.NameToAddress[name]
-		if !hasAddress {
+		switch {
+		case !hasAddress:
 			output.WriteString("iface " + name + " inet manual\n")
-			continue
-		} else if address == string(network.ConfigDHCP) {
+		case address == string(network.ConfigDHCP):
 			output.WriteString("iface " + name + " inet dhcp\n")
-			continue
-		}
-
-		output.WriteString("iface " + name + " inet static\n")
-		output.WriteString("  address " + address + "\n")
-		if !gatewayWritten && prepared.GatewayAddress != "" {
-			output.WriteString("  gateway " + prepared.GatewayAddress + "\n")
-			gatewayWritten = true // write it only once
+		default:
+			output.WriteString("iface " + name + " inet static\n")
+			output.WriteString("  address " + address + "\n")
+			if !gatewayWritten && prepared.GatewayAddress != "" {
+				output.WriteString("  gateway " + prepared.GatewayAddress + "\n")
+				gatewayWritten = true // write it only once
+			}
 		}
+		writeENIDeviceOptions(&output, name, address, prepared)
 	}
 
 	generatedConfig := output.String()
@@ -116,6 +117,32 @@ func GenerateNetworkConfig(networkConfig *container.NetworkConfig) (string, erro
 	return generatedConfig, nil
 }
 
+// writeENIDeviceOptions writes the bond, VLAN, and MTU options of the
+// interface with the given name (if any) to output. The address is the
+// one from PreparedConfig.NameToAddress, used to decide how to set the
+// MTU, as the dhcp method of ifupdown does not support the mtu option.
+func writeENIDeviceOptions(output *bytes.Buffer, name, address string, prepared *PreparedConfig) {
+	if bond, isSlave := prepared.SlaveToBond[name]; isSlave {
+		output.WriteString("  bond-master " + bond + "\n")
+	}
+	if _, isBond := prepared.BondToSlaves[name]; isBond {
+		output.WriteString("  bond-slaves none\n")
+		output.WriteString("  bond-mode " + defaultBondMode + "\n")
+		output.WriteString(fmt.Sprintf("  bond-miimon %d\n", defaultBondMIIMonitorInterval))
+	}
+	if vlan, isVLAN := prepared.NameToVLAN[name]; isVLAN {
+		output.WriteString("  vlan-raw-device " + vlan.RawDevice + "\n")
+		output.WriteString(fmt.Sprintf("  vlan-id %d\n", vlan.Tag))
+	}
+	if mtu, hasMTU := prepared.NameToMTU[name]; hasMTU {
+		if address == string(network.ConfigDHCP) {
+			output.WriteString(fmt.Sprintf("  post-up ip link set dev %s mtu %d\n", name, mtu))
+		} else {
+			output.WriteString(fmt.Sprintf("  mtu %d\n", mtu))
+		}
+	}
+}
+
 // PreparedConfig holds all the necessary information to render a persistent
 // network config to a file.
 type PreparedConfig struct {
@@ -124,9 +151,30 @@ type PreparedConfig struct {
 	DNSServers       []string
 	DNSSearchDomains []string
 	NameToAddress    map[string]string
+	NameToMTU        map[string]int
+	NameToVLAN       map[string]PreparedVLAN
+	BondToSlaves     map[string][]string
+	SlaveToBond      map[string]string
 	GatewayAddress   string
 }
 
+// PreparedVLAN describes an 802.1q VLAN device configured on top of another
+// (raw) device of the container.
+type PreparedVLAN struct {
+	RawDevice string
+	Tag       int
+}
+
+const (
+	// defaultBondMode is the bonding mode used for bond devices, as the
+	// link-layer device model does not record the mode of the host bond.
+	defaultBondMode = "active-backup"
+
+	// defaultBondMIIMonitorInterval is the link monitoring frequency (in
+	// milliseconds) used for bond devices.
+	defaultBondMIIMonitorInterval = 100
+)
+
 // PrepareNetworkConfigFromInterfaces collects the necessary information to
 // render a persistent network config from the given slice of
 // network.InterfaceInfo. The result always includes the loopback interface.
@@ -136,6 +184,17 @@ func PrepareNetworkConfigFromInterfaces(interfaces []network.InterfaceInfo) *Pre
 	gatewayAddress := ""
 	namesInOrder := make([]string, 1, len(interfaces)+1)
 	nameToAddress := make(map[string]string)
+	nameToMTU := make(map[string]int)
+	nameToVLAN := make(map[string]PreparedVLAN)
+	bondToSlaves := make(map[string][]string)
+	slaveToBond := make(map[string]string)
+
+	// Parents of bond and VLAN devices are only relevant when they are
+	// devices of the container itself, rather than host bridges.
+	nameToType := make(map[string]network.InterfaceType)
+	for _, info := range interfaces {
+		nameToType[info.InterfaceName] = info.InterfaceType
+	}
 
 	// Always include the loopback.
 	namesInOrder[0] = "lo"
@@ -152,6 +211,25 @@ func PrepareNetworkConfigFromInterfaces(interfaces []network.InterfaceInfo) *Pre
 			nameToAddress[info.InterfaceName] = string(network.ConfigDHCP)
 		}
 
+		if info.MTU > 0 {
+			nameToMTU[info.InterfaceName] = info.MTU
+		}
+
+		parentName := info.ParentInterfaceName
+		parentType, hasParent := nameToType[parentName]
+		switch {
+		case info.InterfaceType == network.VLAN_8021QInterface && hasParent:
+			nameToVLAN[info.InterfaceName] = PreparedVLAN{
+				RawDevice: parentName,
+				Tag:       info.VLANTag,
+			}
+		case info.InterfaceType == network.VLAN_8021QInterface:
+			logger.Warningf("ignoring VLAN device %q with unknown parent %q", info.InterfaceName, parentName)
+		case parentType == network.BondInterface && hasParent:
+			bondToSlaves[parentName] = append(bondToSlaves[parentName], info.InterfaceName)
+			slaveToBond[info.InterfaceName] = parentName
+		}
+
 		for _, dns := range info.DNSServers {
 			dnsServers.Add(dns.Value)
 		}
@@ -169,6 +247,10 @@ func PrepareNetworkConfigFromInterfaces(interfaces []network.InterfaceInfo) *Pre
 	prepared := &PreparedConfig{
 		InterfaceNames:   namesInOrder,
 		NameToAddress:    nameToAddress,
+		NameToMTU:        nameToMTU,
+		NameToVLAN:       nameToVLAN,
+		BondToSlaves:     bondToSlaves,
+		SlaveToBond:      slaveToBond,
 		AutoStarted:      autoStarted.SortedValues(),
 		DNSServers:       dnsServers.SortedValues(),
 		DNSSearchDomains: dnsSearchDomains.SortedValues(),
@@ -181,9 +263,15 @@ func PrepareNetworkConfigFromInterfaces(interfaces []network.InterfaceInfo) *Pre
 
 // newCloudInitConfigWithNetworks creates a cloud-init config which
 // might include per-interface networking config if both networkConfig
-// is not nil and its Interfaces field is not empty.
+// is not nil and its Interfaces field is not empty. The config is
+// rendered as netplan YAML or ENI, depending on the given series.
 func newCloudInitConfigWithNetworks(series string, networkConfig *container.NetworkConfig) (cloudinit.CloudConfig, error) {
-	config, err := GenerateNetworkConfig(networkConfig)
+	generate, configFile := GenerateNetworkConfig, networkInterfacesFile
+	useNetplan := usesNetplan(series)
+	if useNetplan {
+		generate, configFile = GenerateNetplan, netplanConfigFile
+	}
+	config, err := generate(networkConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -192,7 +280,10 @@ func newCloudInitConfigWithNetworks(series string, networkConfig *container.Netw
 		return nil, errors.Trace(err)
 	}
 
-	cloudConfig.AddBootTextFile(networkInterfacesFile, config, 0644)
+	cloudConfig.AddBootTextFile(configFile, config, 0644)
+	if useNetplan {
+		cloudConfig.AddBootCmd("netplan apply")
+	}
 	return cloudConfig, nil
 }
 
@@ -164,6 +164,143 @@ func (s *UserDataSuite) TestGenerateNetworkConfig(c *gc.C) {
 	c.Assert(data, gc.Equals, s.expectedSampleConfig)
 }
 
+func (s *UserDataSuite) bondedVLANInterfaces() []network.InterfaceInfo {
+	return []network.InterfaceInfo{{
+		InterfaceName:       "eth0",
+		InterfaceType:       network.EthernetInterface,
+		ParentInterfaceName: "bond0",
+		ConfigType:          network.ConfigManual,
+		GatewayAddress:      network.NewAddress("10.0.0.1"),
+		MTU:                 9000,
+	}, {
+		InterfaceName:       "eth1",
+		InterfaceType:       network.EthernetInterface,
+		ParentInterfaceName: "bond0",
+		ConfigType:          network.ConfigManual,
+	}, {
+		InterfaceName: "bond0",
+		InterfaceType: network.BondInterface,
+		CIDR:          "10.0.0.0/24",
+		ConfigType:    network.ConfigStatic,
+		Address:       network.NewAddress("10.0.0.5"),
+		DNSServers:    network.NewAddresses("ns1.invalid"),
+		MTU:           9000,
+	}, {
+		InterfaceName:       "bond0.42",
+		InterfaceType:       network.VLAN_8021QInterface,
+		ParentInterfaceName: "bond0",
+		VLANTag:             42,
+		ConfigType:          network.ConfigDHCP,
+		MTU:                 1500,
+	}}
+}
+
+func (s *UserDataSuite) TestGenerateNetworkConfigWithBondsVLANsAndMTU(c *gc.C) {
+	netConfig := container.BridgeNetworkConfig("foo", 0, s.bondedVLANInterfaces())
+	data, err := containerinit.GenerateNetworkConfig(netConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, `
+auto bond0 bond0.42 eth0 eth1 lo
+
+iface lo inet loopback
+  dns-nameservers ns1.invalid
+
+iface eth0 inet manual
+  bond-master bond0
+  mtu 9000
+
+iface eth1 inet manual
+  bond-master bond0
+
+iface bond0 inet static
+  address 10.0.0.5/24
+  gateway 10.0.0.1
+  bond-slaves none
+  bond-mode active-backup
+  bond-miimon 100
+  mtu 9000
+
+iface bond0.42 inet dhcp
+  vlan-raw-device bond0
+  vlan-id 42
+  post-up ip link set dev bond0.42 mtu 1500
+`)
+}
+
+func (s *UserDataSuite) TestGenerateNetworkConfigIgnoresHostParents(c *gc.C) {
+	// Container NICs have host bridges as parents, which must not be
+	// treated as bonds or VLAN raw devices.
+	netConfig := container.BridgeNetworkConfig("foo", 0, []network.InterfaceInfo{{
+		InterfaceName:       "eth0",
+		InterfaceType:       network.EthernetInterface,
+		ParentInterfaceName: "br-bond0",
+		VLANTag:             42,
+		ConfigType:          network.ConfigDHCP,
+	}})
+	data, err := containerinit.GenerateNetworkConfig(netConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, s.expectedFallbackConfig)
+}
+
+func (s *UserDataSuite) TestGenerateNetplan(c *gc.C) {
+	data, err := containerinit.GenerateNetplan(nil)
+	c.Assert(err, gc.ErrorMatches, "missing container network config")
+	c.Assert(data, gc.Equals, "")
+
+	netConfig := container.BridgeNetworkConfig("foo", 0, nil)
+	data, err = containerinit.GenerateNetplan(netConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      dhcp4: true
+`[1:])
+
+	netConfig = container.BridgeNetworkConfig("foo", 0, s.bondedVLANInterfaces())
+	data, err = containerinit.GenerateNetplan(netConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, gc.Equals, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      mtu: 9000
+    eth1: {}
+  bonds:
+    bond0:
+      addresses:
+      - 10.0.0.5/24
+      gateway4: 10.0.0.1
+      nameservers:
+        addresses:
+        - ns1.invalid
+      mtu: 9000
+      interfaces:
+      - eth0
+      - eth1
+      parameters:
+        mode: active-backup
+        mii-monitor-interval: 100
+  vlans:
+    bond0.42:
+      dhcp4: true
+      mtu: 1500
+      id: 42
+      link: bond0
+`[1:])
+}
+
+func (s *UserDataSuite) TestUsesNetplan(c *gc.C) {
+	for _, series := range []string{"trusty", "xenial", "yakkety", "zesty", "win2012r2", "centos7", "bogus"} {
+		c.Check(containerinit.UsesNetplan(series), jc.IsFalse, gc.Commentf("series %q", series))
+	}
+	for _, series := range []string{"artful", "bionic"} {
+		c.Check(containerinit.UsesNetplan(series), jc.IsTrue, gc.Commentf("series %q", series))
+	}
+}
+
 func (s *UserDataSuite) TestNewCloudInitConfigWithNetworksSampleConfig(c *gc.C) {
 	netConfig := container.BridgeNetworkConfig("foo", 0, s.fakeInterfaces)
 	cloudConf, err := containerinit.NewCloudInitConfigWithNetworks("quantal", netConfig)
@@ -184,6 +321,31 @@ func (s *UserDataSuite) TestNewCloudInitConfigWithNetworksFallbackConfig(c *gc.C
 	assertUserData(c, cloudConf, expected)
 }
 
+func (s *UserDataSuite) TestNewCloudInitConfigWithNetworksNetplan(c *gc.C) {
+	netplanConfigFile := filepath.Join(c.MkDir(), "99-juju.yaml")
+	s.PatchValue(containerinit.NetplanConfigFile, netplanConfigFile)
+
+	netConfig := container.BridgeNetworkConfig("foo", 0, nil)
+	cloudConf, err := containerinit.NewCloudInitConfigWithNetworks("bionic", netConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudConf, gc.NotNil)
+
+	expected := fmt.Sprintf(`
+#cloud-config
+bootcmd:
+- install -D -m 644 /dev/null '%[1]s'
+- |-
+  printf '%%s\n' 'network:
+    version: 2
+    ethernets:
+      eth0:
+        dhcp4: true
+  ' > '%[1]s'
+- netplan apply
+`[1:], netplanConfigFile)
+	assertUserData(c, cloudConf, expected)
+}
+
 func (s *UserDataSuite) TestCloudInitUserDataFallbackConfig(c *gc.C) {
 	instanceConfig, err := containertesting.MockMachineConfig("1/lxd/0")
 	c.Assert(err, jc.ErrorIsNil)
@@ -6,5 +6,7 @@ package containerinit
 
 var (
 	NetworkInterfacesFile          = &networkInterfacesFile
+	NetplanConfigFile              = &netplanConfigFile
 	NewCloudInitConfigWithNetworks = newCloudInitConfigWithNetworks
+	UsesNetplan                    = usesNetplan
 )
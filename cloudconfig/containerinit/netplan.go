@@ -0,0 +1,171 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package containerinit
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/network"
+)
+
+var netplanConfigFile = "/etc/netplan/99-juju.yaml"
+
+// netplanSeries holds the Ubuntu series whose images configure networking
+// with netplan instead of ifupdown (ENI): every release from 17.10
+// (artful) on. Later releases need adding here as they are supported.
+var netplanSeries = set.NewStrings(
+	"artful",
+	"bionic",
+	"cosmic",
+	"disco",
+	"eoan",
+	"focal",
+)
+
+// usesNetplan reports whether containers running the given series are
+// configured with netplan rather than /etc/network/interfaces.
+func usesNetplan(forSeries string) bool {
+	return netplanSeries.Contains(forSeries)
+}
+
+// netplanConfig is the root of a netplan YAML document (version 2).
+type netplanConfig struct {
+	Network netplanNetwork `yaml:"network"`
+}
+
+type netplanNetwork struct {
+	Version   int                      `yaml:"version"`
+	Ethernets map[string]netplanDevice `yaml:"ethernets,omitempty"`
+	Bonds     map[string]netplanBond   `yaml:"bonds,omitempty"`
+	VLANs     map[string]netplanVLAN   `yaml:"vlans,omitempty"`
+}
+
+// netplanDevice holds the settings common to all netplan device types.
+type netplanDevice struct {
+	DHCP4       bool                `yaml:"dhcp4,omitempty"`
+	Addresses   []string            `yaml:"addresses,omitempty"`
+	Gateway4    string              `yaml:"gateway4,omitempty"`
+	Gateway6    string              `yaml:"gateway6,omitempty"`
+	Nameservers *netplanNameservers `yaml:"nameservers,omitempty"`
+	MTU         int                 `yaml:"mtu,omitempty"`
+	Optional    bool                `yaml:"optional,omitempty"`
+}
+
+type netplanNameservers struct {
+	Search    []string `yaml:"search,omitempty"`
+	Addresses []string `yaml:"addresses,omitempty"`
+}
+
+type netplanBond struct {
+	netplanDevice `yaml:",inline"`
+	Interfaces    []string              `yaml:"interfaces"`
+	Parameters    netplanBondParameters `yaml:"parameters"`
+}
+
+type netplanBondParameters struct {
+	Mode               string `yaml:"mode"`
+	MIIMonitorInterval int    `yaml:"mii-monitor-interval"`
+}
+
+type netplanVLAN struct {
+	netplanDevice `yaml:",inline"`
+	ID            int    `yaml:"id"`
+	Link          string `yaml:"link"`
+}
+
+// GenerateNetplan renders a netplan config for one or more network
+// interfaces, using the given non-nil networkConfig containing a non-empty
+// Interfaces field. Like GenerateNetworkConfig, the gateway and DNS settings
+// are only written once, for the first statically configured interface.
+func GenerateNetplan(networkConfig *container.NetworkConfig) (string, error) {
+	if networkConfig == nil || len(networkConfig.Interfaces) == 0 {
+		return "", errors.Errorf("missing container network config")
+	}
+	logger.Debugf("generating netplan config from %#v", *networkConfig)
+
+	prepared := PrepareNetworkConfigFromInterfaces(networkConfig.Interfaces)
+	autoStarted := make(map[string]bool)
+	for _, name := range prepared.AutoStarted {
+		autoStarted[name] = true
+	}
+
+	var config netplanConfig
+	config.Network.Version = 2
+	gatewayWritten := false
+	for _, name := range prepared.InterfaceNames {
+		if name == "lo" {
+			// netplan always configures the loopback.
+			continue
+		}
+
+		device := netplanDevice{
+			MTU:      prepared.NameToMTU[name],
+			Optional: !autoStarted[name],
+		}
+		address, hasAddress := prepared.NameToAddress[name]
+		switch {
+		case !hasAddress:
+		case address == string(network.ConfigDHCP):
+			device.DHCP4 = true
+		default:
+			device.Addresses = []string{address}
+			if !gatewayWritten {
+				if strings.Contains(prepared.GatewayAddress, ":") {
+					device.Gateway6 = prepared.GatewayAddress
+				} else {
+					device.Gateway4 = prepared.GatewayAddress
+				}
+				if len(prepared.DNSServers)+len(prepared.DNSSearchDomains) > 0 {
+					device.Nameservers = &netplanNameservers{
+						Search:    prepared.DNSSearchDomains,
+						Addresses: prepared.DNSServers,
+					}
+				}
+				gatewayWritten = true // write it only once
+			}
+		}
+
+		if vlan, isVLAN := prepared.NameToVLAN[name]; isVLAN {
+			if config.Network.VLANs == nil {
+				config.Network.VLANs = make(map[string]netplanVLAN)
+			}
+			config.Network.VLANs[name] = netplanVLAN{
+				netplanDevice: device,
+				ID:            vlan.Tag,
+				Link:          vlan.RawDevice,
+			}
+		} else if slaves, isBond := prepared.BondToSlaves[name]; isBond {
+			if config.Network.Bonds == nil {
+				config.Network.Bonds = make(map[string]netplanBond)
+			}
+			config.Network.Bonds[name] = netplanBond{
+				netplanDevice: device,
+				Interfaces:    slaves,
+				Parameters: netplanBondParameters{
+					Mode:               defaultBondMode,
+					MIIMonitorInterval: defaultBondMIIMonitorInterval,
+				},
+			}
+		} else {
+			if config.Network.Ethernets == nil {
+				config.Network.Ethernets = make(map[string]netplanDevice)
+			}
+			config.Network.Ethernets[name] = device
+		}
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot render netplan config")
+	}
+	generatedConfig := string(data)
+	logger.Debugf("generated netplan config:\n%s", generatedConfig)
+
+	return generatedConfig, nil
+}
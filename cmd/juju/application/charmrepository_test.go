@@ -0,0 +1,89 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csclientparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+)
+
+type RepositoryCharmSuite struct {
+	testing.RepoSuite
+}
+
+var _ = gc.Suite(&RepositoryCharmSuite{})
+
+func (s *RepositoryCharmSuite) publish(c *gc.C, name string, channel csclientparams.Channel) *state.RepositoryCharm {
+	path := testcharms.Repo.CharmArchivePath(c.MkDir(), name)
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmArchiveBytes(data)
+	c.Assert(err, jc.ErrorIsNil)
+	published, err := s.State.PublishRepositoryCharm(state.PublishRepositoryCharmArgs{
+		Charm:     ch,
+		Archive:   bytes.NewReader(data),
+		Size:      int64(len(data)),
+		SHA256:    fmt.Sprintf("%x", sha256.Sum256(data)),
+		Publisher: "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.PromoteRepositoryCharm(name, published.Revision(), channel)
+	c.Assert(err, jc.ErrorIsNil)
+	return published
+}
+
+func (s *RepositoryCharmSuite) TestDeploy(c *gc.C) {
+	s.publish(c, "dummy", csclientparams.StableChannel)
+	err := runDeploy(c, "repo:dummy", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	curl := charm.MustParseURL("local:quantal/dummy-0")
+	s.AssertService(c, "dummy", curl, 1, 0)
+
+	ch, err := s.State.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.RepositoryURL(), gc.Equals, "repo:dummy-0")
+}
+
+func (s *RepositoryCharmSuite) TestDeployNotInChannel(c *gc.C) {
+	s.publish(c, "dummy", csclientparams.EdgeChannel)
+	err := runDeploy(c, "repo:dummy", "--series", "quantal")
+	c.Assert(err, gc.ErrorMatches, `charm "dummy" in channel "stable" not found`)
+	err = runDeploy(c, "repo:dummy", "--series", "quantal", "--channel", "edge")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RepositoryCharmSuite) TestUpgrade(c *gc.C) {
+	s.publish(c, "dummy", csclientparams.StableChannel)
+	err := runDeploy(c, "repo:dummy", "--series", "quantal")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUpgradeCharm(c, "dummy")
+	c.Assert(err, gc.ErrorMatches, `already running latest charm "repo:dummy-0"`)
+
+	s.publish(c, "dummy", csclientparams.StableChannel)
+	err = runUpgradeCharm(c, "dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "local:quantal/dummy-1")
+
+	err = runUpgradeCharm(c, "dummy", "--revision", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	app, err = s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ = app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "local:quantal/dummy-0")
+}
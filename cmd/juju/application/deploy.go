@@ -24,7 +24,9 @@ import (
 	"github.com/juju/juju/api"
 	apiannotations "github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
+	apicharmrepository "github.com/juju/juju/api/charmrepository"
 	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/charmrepository"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -299,9 +301,47 @@ func (c *DeployCommand) maybeReadLocalBundleData(ctx *cmd.Context) (
 	return bundleData, bundleFile, bundleFilePath, err
 }
 
+// deployRepositoryCharm adds the charm referenced by a repo: URL to the
+// model and deploys it.
+func (c *DeployCommand) deployRepositoryCharm(ctx *cmd.Context, client *api.Client, deployer *applicationDeployer) error {
+	rurl, err := charmrepository.ParseURL(c.CharmOrBundle)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.Series != "" {
+		rurl = rurl.WithSeries(c.Series)
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result, err := apicharmrepository.NewClient(root).AddCharm(rurl.String(), c.Channel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl, err := charm.ParseURL(result.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Located charm %q in the controller charm repository.", result.RepositoryURL)
+	return c.deployCharm(deployCharmArgs{
+		id:       charmstore.CharmID{URL: curl},
+		series:   curl.Series,
+		ctx:      ctx,
+		client:   client,
+		deployer: deployer,
+	})
+}
+
 func (c *DeployCommand) deployCharmOrBundle(ctx *cmd.Context, client *api.Client) error {
 	deployer := applicationDeployer{ctx, c}
 
+	// Charms in the controller's charm repository are copied into the
+	// model as local charms, so they need no charm store resolution.
+	if charmrepository.IsURL(c.CharmOrBundle) {
+		return c.deployRepositoryCharm(ctx, client, &deployer)
+	}
+
 	// We may have been given a local bundle file.
 	bundleData, bundleIdent, bundleFilePath, err := c.maybeReadLocalBundleData(ctx)
 	// If the bundle files existed but we couldn't read them, then
@@ -32,6 +32,22 @@ func NewAddUnitCommandForTest(api serviceAddUnitAPI) cmd.Command {
 	})
 }
 
+// NewSetUpgradePolicyCommandForTest returns a SetUpgradePolicyCommand with
+// the api provided as specified.
+func NewSetUpgradePolicyCommandForTest(api charmUpgradesAPI) cmd.Command {
+	return modelcmd.Wrap(&setUpgradePolicyCommand{
+		charmUpgradesCommandBase: charmUpgradesCommandBase{api: api},
+	})
+}
+
+// NewUpgradesAvailableCommandForTest returns an UpgradesAvailableCommand
+// with the api provided as specified.
+func NewUpgradesAvailableCommandForTest(api charmUpgradesAPI) cmd.Command {
+	return modelcmd.Wrap(&upgradesAvailableCommand{
+		charmUpgradesCommandBase: charmUpgradesCommandBase{api: api},
+	})
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
@@ -20,7 +20,9 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
+	apicharmrepository "github.com/juju/juju/api/charmrepository"
 	"github.com/juju/juju/api/charms"
+	"github.com/juju/juju/charmrepository"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -97,6 +99,10 @@ have the same types.
 
 The new charm may add new relations and configuration settings.
 
+Charms deployed from the controller charm repository (repo: URLs) are upgraded
+to the revision in the --channel channel (stable by default), or to the revision
+given with --revision. A repo: URL may also be used with --switch.
+
 --switch and --path are mutually exclusive.
 
 --path and --revision are mutually exclusive. The revision of the updated charm
@@ -186,13 +192,31 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		newRef = c.CharmPath
 	}
 	if c.SwitchURL == "" && c.CharmPath == "" {
-		// If the charm we are upgrading is local, then we must
-		// specify a path or switch url to upgrade with.
 		if oldURL.Schema == "local" {
-			return errors.New("upgrading a local charm requires either --path or --switch")
+			// Local charms copied from the controller charm repository
+			// are upgraded from there; any other local charm needs a
+			// path or switch url to upgrade with.
+			origin, err := c.repositoryOrigin(oldURL)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if origin == nil {
+				return errors.New("upgrading a local charm requires either --path or --switch")
+			}
+			newRef = origin.WithRevision(c.Revision).String()
+		} else {
+			// No new URL specified, but revision might have been.
+			newRef = oldURL.WithRevision(c.Revision).String()
 		}
-		// No new URL specified, but revision might have been.
-		newRef = oldURL.WithRevision(c.Revision).String()
+	}
+
+	if charmrepository.IsURL(newRef) {
+		chID, err := c.addRepositoryCharm(oldURL, newRef)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("Added charm %q to the model.", chID.URL)
+		return c.setCharm(client, serviceClient, chID, nil)
 	}
 
 	bakeryClient, err := c.BakeryClient()
@@ -211,7 +235,17 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
 	ctx.Infof("Added charm %q to the model.", chID.URL)
+	return c.setCharm(client, serviceClient, chID, csMac)
+}
 
+// setCharm uploads any resources for the new charm and switches the
+// application over to it.
+func (c *upgradeCharmCommand) setCharm(
+	client *api.Client,
+	serviceClient *application.Client,
+	chID charmstore.CharmID,
+	csMac *macaroon.Macaroon,
+) error {
 	ids, err := c.upgradeResources(client, chID, csMac)
 	if err != nil {
 		return errors.Trace(err)
@@ -228,6 +262,62 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	return block.ProcessBlockedError(serviceClient.SetCharm(cfg), block.BlockChange)
 }
 
+// repositoryOrigin returns the charm repository URL, without a revision,
+// from which the given local charm was copied, or nil if the charm did
+// not come from the controller charm repository.
+func (c *upgradeCharmCommand) repositoryOrigin(curl *charm.URL) (*charmrepository.URL, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	origin, err := apicharmrepository.NewClient(root).CharmOrigin(curl.String())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if origin == "" {
+		return nil, nil
+	}
+	rurl, err := charmrepository.ParseURL(origin)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rurl.WithRevision(-1), nil
+}
+
+// addRepositoryCharm copies the charm referenced by the given repo: URL
+// into the model, keeping the series of the application's current charm
+// unless the URL names one.
+func (c *upgradeCharmCommand) addRepositoryCharm(oldURL *charm.URL, charmRef string) (charmstore.CharmID, error) {
+	var id charmstore.CharmID
+	rurl, err := charmrepository.ParseURL(charmRef)
+	if err != nil {
+		return id, errors.Trace(err)
+	}
+	if rurl.Series == "" {
+		rurl = rurl.WithSeries(oldURL.Series)
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return id, errors.Trace(err)
+	}
+	result, err := apicharmrepository.NewClient(root).AddCharm(rurl.String(), c.Channel)
+	if err != nil {
+		return id, errors.Trace(err)
+	}
+	newURL, err := charm.ParseURL(result.CharmURL)
+	if err != nil {
+		return id, errors.Trace(err)
+	}
+	if *newURL == *oldURL {
+		if rurl.Revision != -1 {
+			return id, errors.Errorf("already running specified charm %q", result.RepositoryURL)
+		}
+		return id, errors.Errorf("already running latest charm %q", result.RepositoryURL)
+	}
+	id.URL = newURL
+	return id, nil
+}
+
 // upgradeResources pushes metadata up to the server for each resource defined
 // in the new charm's metadata and returns a map of resource names to pending
 // IDs to include in the upgrage-charm call.
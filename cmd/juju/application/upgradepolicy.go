@@ -0,0 +1,277 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	csclientparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/charmupgrades"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/charmupgrade"
+)
+
+var usageSetUpgradePolicySummary = `
+Sets how an application's charm is upgraded when new revisions are published.`[1:]
+
+var usageSetUpgradePolicyDetails = `
+The policy is one of:
+
+    none            new revisions are ignored
+    notify          new revisions are listed by upgrades-available (the default)
+    auto-in-window  new revisions are applied automatically, but only during
+                    the daily maintenance window given with --window
+
+The window is given in UTC as HH:MM-HH:MM, and may span midnight. New
+revisions are looked for in the channel given with --channel, or in the
+channel the charm was deployed from if none is given.
+
+Applications deployed from the controller charm repository (repo: URLs)
+follow the same policies.
+
+Examples:
+    juju set-upgrade-policy mysql none
+    juju set-upgrade-policy mysql notify --channel candidate
+    juju set-upgrade-policy wordpress auto-in-window --window 02:00-04:00
+
+See also:
+    upgrades-available
+    upgrade-charm`[1:]
+
+var usageUpgradesAvailableSummary = `
+Lists the charm upgrades available to the applications in a model.`[1:]
+
+var usageUpgradesAvailableDetails = `
+Newer charm revisions are looked for periodically by the controller. Only
+applications with a newer revision available are listed, unless --all is
+given; applications whose upgrade policy is "none" never have one listed.
+By default, the tabular format is used.
+
+Examples:
+    juju upgrades-available
+    juju upgrades-available --all --format yaml
+
+See also:
+    set-upgrade-policy
+    upgrade-charm`[1:]
+
+type charmUpgradesAPI interface {
+	Close() error
+	UpgradesAvailable() ([]params.CharmUpgradeInfo, error)
+	SetUpgradePolicy(application string, policy params.CharmUpgradePolicy) error
+}
+
+// charmUpgradesCommandBase is the base for commands working with the
+// charm upgrades of applications.
+type charmUpgradesCommandBase struct {
+	modelcmd.ModelCommandBase
+	api charmUpgradesAPI
+}
+
+func (c *charmUpgradesCommandBase) getAPI() (charmUpgradesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charmupgrades.NewClient(root), nil
+}
+
+// NewSetUpgradePolicyCommand returns a command which sets the charm
+// upgrade policy of an application.
+func NewSetUpgradePolicyCommand() cmd.Command {
+	return modelcmd.Wrap(&setUpgradePolicyCommand{})
+}
+
+type setUpgradePolicyCommand struct {
+	charmUpgradesCommandBase
+	ApplicationName string
+	Mode            string
+	Channel         string
+	Window          string
+}
+
+func (c *setUpgradePolicyCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-upgrade-policy",
+		Args:    "<application> none|notify|auto-in-window",
+		Purpose: usageSetUpgradePolicySummary,
+		Doc:     usageSetUpgradePolicyDetails,
+	}
+}
+
+func (c *setUpgradePolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.Channel, "channel", "", "Channel in which to look for new revisions")
+	f.StringVar(&c.Window, "window", "", "Daily maintenance window, in UTC, for automatic upgrades")
+}
+
+func (c *setUpgradePolicyCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no application name specified")
+	case 1:
+		return errors.New("no upgrade policy specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.ApplicationName, c.Mode = args[0], args[1]
+
+	window, err := charmupgrade.ParseWindow(c.Window)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := charmupgrade.Policy{
+		Mode:    charmupgrade.Mode(c.Mode),
+		Channel: csclientparams.Channel(c.Channel),
+		Window:  window,
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if policy.Mode != charmupgrade.AutoInWindow && c.Window != "" {
+		return errors.Errorf("--window only applies to the %q policy", charmupgrade.AutoInWindow)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *setUpgradePolicyCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	err = api.SetUpgradePolicy(c.ApplicationName, params.CharmUpgradePolicy{
+		Mode:    c.Mode,
+		Channel: c.Channel,
+		Window:  c.Window,
+	})
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// NewUpgradesAvailableCommand returns a command which lists the charm
+// upgrades available to the applications in a model.
+func NewUpgradesAvailableCommand() cmd.Command {
+	return modelcmd.Wrap(&upgradesAvailableCommand{})
+}
+
+type upgradesAvailableCommand struct {
+	charmUpgradesCommandBase
+	out cmd.Output
+	All bool
+}
+
+func (c *upgradesAvailableCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "upgrades-available",
+		Purpose: usageUpgradesAvailableSummary,
+		Doc:     usageUpgradesAvailableDetails,
+	}
+}
+
+func (c *upgradesAvailableCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.All, "all", false, "List all applications, including those with no upgrade available")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatUpgradesAvailableTabular,
+	})
+}
+
+func (c *upgradesAvailableCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// CharmUpgrade is the output format of the upgrades-available command.
+type CharmUpgrade struct {
+	Charm     string `yaml:"charm" json:"charm"`
+	Available string `yaml:"available,omitempty" json:"available,omitempty"`
+	Policy    string `yaml:"policy" json:"policy"`
+	Channel   string `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Window    string `yaml:"window,omitempty" json:"window,omitempty"`
+}
+
+func (c *upgradesAvailableCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	infos, err := api.UpgradesAvailable()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output := make(map[string]CharmUpgrade)
+	for _, info := range infos {
+		if info.Available == "" && !c.All {
+			continue
+		}
+		tag, err := names.ParseApplicationTag(info.ApplicationTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output[tag.Id()] = CharmUpgrade{
+			Charm:     info.CharmURL,
+			Available: info.Available,
+			Policy:    info.Policy.Mode,
+			Channel:   info.Policy.Channel,
+			Window:    info.Policy.Window,
+		}
+	}
+	if len(output) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No charm upgrades available.")
+		return nil
+	}
+	return c.out.Write(ctx, output)
+}
+
+func formatUpgradesAvailableTabular(value interface{}) ([]byte, error) {
+	upgrades, ok := value.(map[string]CharmUpgrade)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", upgrades, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "APPLICATION\tCHARM\tAVAILABLE\tPOLICY\tWINDOW\n")
+	names := make([]string, 0, len(upgrades))
+	for name := range upgrades {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		u := upgrades[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, u.Charm, dashIfEmpty(u.Available), u.Policy, dashIfEmpty(u.Window))
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
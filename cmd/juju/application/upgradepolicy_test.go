@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/errors"
+	jutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type UpgradePolicySuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeCharmUpgradesAPI
+}
+
+var _ = gc.Suite(&UpgradePolicySuite{})
+
+func (s *UpgradePolicySuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeCharmUpgradesAPI{
+		infos: []params.CharmUpgradeInfo{{
+			ApplicationTag: "application-wordpress",
+			CharmURL:       "cs:trusty/wordpress-2",
+			Available:      "cs:trusty/wordpress-4",
+			Policy: params.CharmUpgradePolicy{
+				Mode:   "auto-in-window",
+				Window: "02:00-04:00",
+			},
+		}, {
+			ApplicationTag: "application-mysql",
+			CharmURL:       "cs:trusty/mysql-7",
+			Policy:         params.CharmUpgradePolicy{Mode: "notify"},
+		}},
+	}
+}
+
+func (s *UpgradePolicySuite) TestSetUpgradePolicyInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no upgrade policy specified",
+	}, {
+		args: []string{"mysql/0", "none"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "sometimes"},
+		err:  `upgrade policy "sometimes" not valid`,
+	}, {
+		args: []string{"mysql", "auto-in-window"},
+		err:  `"auto-in-window" policy without maintenance window not valid`,
+	}, {
+		args: []string{"mysql", "auto-in-window", "--window", "25:00-01:00"},
+		err:  `invalid maintenance window "25:00-01:00": time of day "25:00" not valid`,
+	}, {
+		args: []string{"mysql", "notify", "--window", "02:00-04:00"},
+		err:  `--window only applies to the "auto-in-window" policy`,
+	}, {
+		args: []string{"mysql", "none", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(application.NewSetUpgradePolicyCommandForTest(s.api), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradePolicySuite) TestSetUpgradePolicy(c *gc.C) {
+	_, err := coretesting.RunCommand(c, application.NewSetUpgradePolicyCommandForTest(s.api),
+		"wordpress", "auto-in-window", "--window", "22:00-02:00", "--channel", "candidate")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []jutesting.StubCall{{
+		"SetUpgradePolicy", []interface{}{"wordpress", params.CharmUpgradePolicy{
+			Mode:    "auto-in-window",
+			Channel: "candidate",
+			Window:  "22:00-02:00",
+		}},
+	}, {
+		"Close", nil,
+	}})
+}
+
+func (s *UpgradePolicySuite) TestSetUpgradePolicyError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := coretesting.RunCommand(c, application.NewSetUpgradePolicyCommandForTest(s.api), "wordpress", "none")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *UpgradePolicySuite) TestUpgradesAvailableTabular(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, application.NewUpgradesAvailableCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"APPLICATION  CHARM                  AVAILABLE              POLICY          WINDOW\n"+
+		"wordpress    cs:trusty/wordpress-2  cs:trusty/wordpress-4  auto-in-window  02:00-04:00\n")
+}
+
+func (s *UpgradePolicySuite) TestUpgradesAvailableAll(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, application.NewUpgradesAvailableCommandForTest(s.api), "--all")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"APPLICATION  CHARM                  AVAILABLE              POLICY          WINDOW\n"+
+		"mysql        cs:trusty/mysql-7      -                      notify          -\n"+
+		"wordpress    cs:trusty/wordpress-2  cs:trusty/wordpress-4  auto-in-window  02:00-04:00\n")
+}
+
+func (s *UpgradePolicySuite) TestUpgradesAvailableYAML(c *gc.C) {
+	ctx, err := coretesting.RunCommand(c, application.NewUpgradesAvailableCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, `
+wordpress:
+  charm: cs:trusty/wordpress-2
+  available: cs:trusty/wordpress-4
+  policy: auto-in-window
+  window: 02:00-04:00
+`[1:])
+}
+
+func (s *UpgradePolicySuite) TestUpgradesAvailableNone(c *gc.C) {
+	s.api.infos = s.api.infos[1:]
+	ctx, err := coretesting.RunCommand(c, application.NewUpgradesAvailableCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(coretesting.Stderr(ctx), gc.Equals, "No charm upgrades available.\n")
+}
+
+type fakeCharmUpgradesAPI struct {
+	jutesting.Stub
+	infos []params.CharmUpgradeInfo
+}
+
+func (f *fakeCharmUpgradesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeCharmUpgradesAPI) UpgradesAvailable() ([]params.CharmUpgradeInfo, error) {
+	f.MethodCall(f, "UpgradesAvailable")
+	return f.infos, f.NextErr()
+}
+
+func (f *fakeCharmUpgradesAPI) SetUpgradePolicy(application string, policy params.CharmUpgradePolicy) error {
+	f.MethodCall(f, "SetUpgradePolicy", application, policy)
+	return f.NextErr()
+}
@@ -6,7 +6,9 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -24,7 +26,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	Create(notes string, key *params.BackupsArchiveKey) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -36,7 +38,7 @@ type APIClient interface {
 	// Remove removes the stored backup.
 	Remove(id string) error
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(string, *params.BackupsArchiveKey, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
 	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
 }
@@ -84,6 +86,43 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
 	fmt.Fprintf(ctx.Stdout, "created on host: %q\n", result.Hostname)
 	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
+	if result.Storage != "" {
+		fmt.Fprintf(ctx.Stdout, "stored in:       %s\n", result.Storage)
+	}
+	if result.Encryption != "" {
+		fmt.Fprintf(ctx.Stdout, "encryption:      %s\n", result.Encryption)
+	}
+}
+
+// readArchiveKey builds an archive key from the passphrase file or the
+// PEM-encoded RSA key file given on the command line. The key file
+// holds a private key if private is true, and a public key otherwise.
+// If neither file is given, nil is returned.
+func readArchiveKey(passphraseFile, keyFile string, private bool) (*params.BackupsArchiveKey, error) {
+	switch {
+	case passphraseFile != "" && keyFile != "":
+		return nil, errors.New("cannot use both a passphrase and a key")
+	case passphraseFile != "":
+		data, err := ioutil.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read passphrase")
+		}
+		passphrase := strings.TrimRight(string(data), "\r\n")
+		if passphrase == "" {
+			return nil, errors.Errorf("passphrase file %q is empty", passphraseFile)
+		}
+		return &params.BackupsArchiveKey{Passphrase: passphrase}, nil
+	case keyFile != "":
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read key")
+		}
+		if private {
+			return &params.BackupsArchiveKey{PrivateKey: string(data)}, nil
+		}
+		return &params.BackupsArchiveKey{PublicKey: string(data)}, nil
+	}
+	return nil, nil
 }
 
 // ArchiveReader can read a backup archive.
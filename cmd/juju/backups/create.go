@@ -44,6 +44,12 @@ Therefore, you should use the --download or --filename options, or use:
 to get a local copy of the backup archive.
 This local copy can then be used to restore an model even if that
 model was already destroyed or is otherwise unavailable.
+
+The archive holds the controller's CA private key and credentials, so
+it may be encrypted.  Use --passphrase-file to encrypt it with the
+passphrase held in a file, or --public-key to encrypt it for the RSA
+public key (or certificate) in a PEM file.  The same passphrase, or
+the matching private key, is needed to restore the backup.
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -60,6 +66,10 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// PassphraseFile holds the passphrase to encrypt the archive with.
+	PassphraseFile string
+	// PublicKeyFile holds the RSA public key to encrypt the archive for.
+	PublicKeyFile string
 }
 
 // Info implements Command.Info.
@@ -77,6 +87,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.StringVar(&c.PassphraseFile, "passphrase-file", "", "Encrypt the archive with the passphrase in this file")
+	f.StringVar(&c.PublicKeyFile, "public-key", "", "Encrypt the archive for the RSA public key in this PEM file")
 }
 
 // Init implements Command.Init.
@@ -93,6 +105,9 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+	if c.PassphraseFile != "" && c.PublicKeyFile != "" {
+		return errors.Errorf("cannot mix --passphrase-file and --public-key")
+	}
 
 	return nil
 }
@@ -104,13 +119,18 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	key, err := readArchiveKey(c.PassphraseFile, c.PublicKeyFile, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	result, err := client.Create(c.Notes, key)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -5,6 +5,8 @@ package backups_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -12,6 +14,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/testing"
 )
@@ -139,3 +142,35 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) TestPassphraseFile(c *gc.C) {
+	client := s.setSuccess()
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.keyArg, jc.DeepEquals, &params.BackupsArchiveKey{Passphrase: "sekrit"})
+}
+
+func (s *createSuite) TestPublicKey(c *gc.C) {
+	client := s.setSuccess()
+	keyFile := filepath.Join(c.MkDir(), "key.pem")
+	err := ioutil.WriteFile(keyFile, []byte(testing.CACert), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.wrappedCommand, "--no-download", "--public-key", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(client.keyArg, jc.DeepEquals, &params.BackupsArchiveKey{PublicKey: testing.CACert})
+}
+
+func (s *createSuite) TestPassphraseAndPublicKey(c *gc.C) {
+	s.setSuccess()
+	_, err := testing.RunCommand(c, s.wrappedCommand, "--passphrase-file", "a", "--public-key", "b")
+
+	c.Check(err, gc.ErrorMatches, "cannot mix --passphrase-file and --public-key")
+}
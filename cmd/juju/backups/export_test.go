@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -19,7 +20,8 @@ const (
 )
 
 var (
-	NewAPIClient = &newAPIClient
+	NewAPIClient      = &newAPIClient
+	VerifyArchiveFile = verifyArchiveFile
 )
 
 type CreateCommand struct {
@@ -75,6 +77,9 @@ func NewRestoreCommandForTest(
 	c := &restoreCommand{
 		getArchiveFunc: getArchive,
 		getEnvironFunc: getEnviron,
+		verifyArchiveFunc: func(filename string, _ *statebackups.ArchiveKey) (string, func(), error) {
+			return filename, func() {}, nil
+		},
 		newAPIClientFunc: func() (RestoreAPI, error) {
 			return api, nil
 		},
@@ -9,11 +9,16 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+When the controller takes scheduled backups (see the backup-interval
+controller setting), the schedule, retention policy and outcome of the
+last scheduled backup are displayed after the list.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -65,16 +70,24 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 
 	if len(result.List) == 0 {
 		fmt.Fprintln(ctx.Stdout, "(no backups found)")
-		return nil
+	} else {
+		c.dumpList(ctx, result.List)
 	}
+	if result.Schedule != nil {
+		fmt.Fprintln(ctx.Stdout)
+		dumpSchedule(ctx, result.Schedule)
+	}
+	return nil
+}
 
+func (c *listCommand) dumpList(ctx *cmd.Context, list []params.BackupsMetadataResult) {
 	verbose := c.Log != nil && c.Log.Verbose
 	if verbose {
-		c.dumpMetadata(ctx, &result.List[0])
+		c.dumpMetadata(ctx, &list[0])
 	} else {
-		fmt.Fprintln(ctx.Stdout, result.List[0].ID)
+		fmt.Fprintln(ctx.Stdout, list[0].ID)
 	}
-	for _, resultItem := range result.List[1:] {
+	for _, resultItem := range list[1:] {
 		if verbose {
 			fmt.Fprintln(ctx.Stdout)
 			c.dumpMetadata(ctx, &resultItem)
@@ -82,5 +95,30 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 			fmt.Fprintln(ctx.Stdout, resultItem.ID)
 		}
 	}
-	return nil
+}
+
+// dumpSchedule writes the formatted backup schedule to stdout.
+func dumpSchedule(ctx *cmd.Context, schedule *params.BackupsScheduleResult) {
+	if schedule.Interval == "" {
+		fmt.Fprintln(ctx.Stdout, "schedule:        (disabled)")
+	} else {
+		fmt.Fprintf(ctx.Stdout, "schedule:        every %s\n", schedule.Interval)
+		fmt.Fprintf(ctx.Stdout, "retention:       last %d, daily %d, weekly %d\n",
+			schedule.KeepLast, schedule.KeepDaily, schedule.KeepWeekly)
+	}
+	lastRun := schedule.LastRun
+	if lastRun == nil {
+		fmt.Fprintln(ctx.Stdout, "last run:        (never)")
+		return
+	}
+	fmt.Fprintf(ctx.Stdout, "last run:        %v\n", lastRun.Started)
+	if lastRun.Error != "" {
+		fmt.Fprintf(ctx.Stdout, "last run error:  %q\n", lastRun.Error)
+	}
+	if lastRun.BackupID != "" {
+		fmt.Fprintf(ctx.Stdout, "last backup ID:  %q\n", lastRun.BackupID)
+	}
+	if len(lastRun.Removed) > 0 {
+		fmt.Fprintf(ctx.Stdout, "expired removed: %q\n", lastRun.Removed)
+	}
 }
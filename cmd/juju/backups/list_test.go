@@ -4,12 +4,15 @@
 package backups_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/testing"
 )
@@ -44,6 +47,31 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *listSuite) TestSchedule(c *gc.C) {
+	client := s.setSuccess()
+	started := time.Date(2016, time.August, 8, 1, 0, 0, 0, time.UTC)
+	client.schedule = &params.BackupsScheduleResult{
+		Interval: "24h0m0s",
+		KeepLast: 3,
+		LastRun: &params.BackupsScheduledRunResult{
+			Started:  started,
+			Finished: started.Add(time.Minute),
+			BackupID: "new-backup",
+			Removed:  []string{"old-backup"},
+		},
+	}
+	ctx, err := testing.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	out := s.metaresult.ID + "\n" + `
+schedule:        every 24h0m0s
+retention:       last 3, daily 0, weekly 0
+last run:        2016-08-08 01:00:00 +0000 UTC
+last backup ID:  "new-backup"
+expired removed: ["old-backup"]
+`
+	s.checkStd(c, ctx, out, "")
+}
+
 func (s *listSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := testing.RunCommand(c, s.subcommand)
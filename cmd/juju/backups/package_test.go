@@ -116,13 +116,15 @@ func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string)
 
 type fakeAPIClient struct {
 	metaresult *params.BackupsMetadataResult
+	schedule   *params.BackupsScheduleResult
 	archive    io.ReadCloser
 	err        error
 
-	calls []string
-	args  []string
-	idArg string
-	notes string
+	calls  []string
+	args   []string
+	idArg  string
+	notes  string
+	keyArg *params.BackupsArchiveKey
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -131,10 +133,11 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(notes string, key *params.BackupsArchiveKey) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
-	c.args = append(c.args, "notes")
+	c.args = append(c.args, "notes", "key")
 	c.notes = notes
+	c.keyArg = key
 	if c.err != nil {
 		return nil, c.err
 	}
@@ -158,6 +161,7 @@ func (c *fakeAPIClient) List() (*params.BackupsListResult, error) {
 	}
 	var result params.BackupsListResult
 	result.List = []params.BackupsMetadataResult{*c.metaresult}
+	result.Schedule = c.schedule
 	return &result, nil
 }
 
@@ -197,6 +201,6 @@ func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResu
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(string, *params.BackupsArchiveKey, apibackups.ClientConnection) error {
 	return nil
 }
@@ -7,15 +7,19 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/api/backups"
+	apiserverbackups "github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
@@ -27,6 +31,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
 	"github.com/juju/juju/jujuclient"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/version"
 )
 
@@ -38,6 +43,7 @@ func NewRestoreCommand() cmd.Command {
 		return restoreCmd.newClient()
 	}
 	restoreCmd.getArchiveFunc = getArchive
+	restoreCmd.verifyArchiveFunc = restoreCmd.verifyArchive
 	restoreCmd.waitForAgentFunc = common.WaitForAgentInitialisation
 	return modelcmd.Wrap(restoreCmd)
 }
@@ -52,10 +58,22 @@ type restoreCommand struct {
 	bootstrap   bool
 	uploadTools bool
 
+	passphraseFile string
+	privateKeyFile string
+
+	// modelUUID, if set, selects a single model to restore from the
+	// backup, as a new model on the running controller.
+	modelUUID string
+	newName   string
+
 	newAPIClientFunc func() (RestoreAPI, error)
 	getEnvironFunc   func(string, *params.BackupsMetadataResult) (environs.Environ, *restoreBootstrapParams, error)
 	getArchiveFunc   func(string) (ArchiveReader, *params.BackupsMetadataResult, error)
-	waitForAgentFunc func(ctx *cmd.Context, c *modelcmd.ModelCommandBase, controllerName string) error
+	// verifyArchiveFunc decrypts the archive file if necessary and
+	// checks its manifest, returning the name of the plain archive
+	// file and a function to clean it up.
+	verifyArchiveFunc func(string, *statebackups.ArchiveKey) (string, func(), error)
+	waitForAgentFunc  func(ctx *cmd.Context, c *modelcmd.ModelCommandBase, controllerName string) error
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -64,10 +82,19 @@ type RestoreAPI interface {
 	Close() error
 
 	// Restore is taken from backups.Client.
-	Restore(backupId string, newClient backups.ClientConnection) error
+	Restore(backupId string, key *params.BackupsArchiveKey, newClient backups.ClientConnection) error
 
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+
+	// RestoreModel is taken from backups.Client.
+	RestoreModel(backupId, modelUUID, newName string, key *params.BackupsArchiveKey) (*params.RestoreModelResult, error)
+
+	// Upload is taken from backups.Client.
+	Upload(archive io.ReadSeeker, meta params.BackupsMetadataResult) (string, error)
+
+	// Download is taken from backups.Client.
+	Download(id string) (io.ReadCloser, error)
 }
 
 var restoreDoc = `
@@ -85,6 +112,25 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+Before anything is restored, the archive's signed manifest is checked
+against the controller's CA certificate, and the archive is checked
+against its recorded checksum.  An encrypted archive needs either
+--passphrase-file, naming a file holding the passphrase it was
+encrypted with, or --private-key, naming a PEM file holding the private
+key matching the public key it was encrypted for.  The private key never
+leaves the client: a backup given with --id is downloaded and decrypted
+locally.  Backups made before manifests and checksums were recorded are
+restored without these checks, with a warning.
+
+With --model-uuid, only the model with the given UUID is restored
+from the backup, as a new model on the current controller; the
+controller and its other models are left untouched.  The model keeps
+its UUID, so the original model must have been destroyed first.  Use
+--model-name to give the restored model a different name.  A backup
+given with --file is uploaded to the controller first.  Charm and tools
+archives are not part of the model's documents, and are not restored;
+the charms must still be available to the controller.
 `
 
 var BootstrapFunc = bootstrap.Bootstrap
@@ -109,6 +155,10 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
 	f.BoolVar(&c.uploadTools, "upload-tools", false, "Upload tools if bootstraping a new machine")
+	f.StringVar(&c.passphraseFile, "passphrase-file", "", "Decrypt the backup with the passphrase in this file")
+	f.StringVar(&c.privateKeyFile, "private-key", "", "Decrypt the backup with the RSA private key in this PEM file")
+	f.StringVar(&c.modelUUID, "model-uuid", "", "Restore only the model with this UUID, as a new model")
+	f.StringVar(&c.newName, "model-name", "", "Name for the model restored with --model-uuid")
 }
 
 // Init is where the preconditions for this commands can be checked.
@@ -122,6 +172,22 @@ func (c *restoreCommand) Init(args []string) error {
 	if c.backupId != "" && c.bootstrap {
 		return errors.Errorf("it is not possible to rebootstrap and restore from an id.")
 	}
+	if c.passphraseFile != "" && c.privateKeyFile != "" {
+		return errors.Errorf("cannot mix --passphrase-file and --private-key")
+	}
+	if c.modelUUID != "" {
+		if c.bootstrap {
+			return errors.Errorf("it is not possible to rebootstrap and restore a single model.")
+		}
+		if !names.IsValidModel(c.modelUUID) {
+			return errors.Errorf("invalid model UUID %q", c.modelUUID)
+		}
+		if c.newName != "" && !names.IsValidModelName(c.newName) {
+			return errors.Errorf("invalid model name %q", c.newName)
+		}
+	} else if c.newName != "" {
+		return errors.Errorf("--model-name can only be used with --model-uuid")
+	}
 	var err error
 	if c.filename != "" {
 		c.filename, err = filepath.Abs(c.filename)
@@ -297,6 +363,103 @@ func (c *restoreCommand) rebootstrap(ctx *cmd.Context, meta *params.BackupsMetad
 	return c.waitForAgentFunc(ctx, &c.ModelCommandBase, c.ControllerName())
 }
 
+// verifyArchive decrypts the archive file if it is encrypted, and
+// checks that its manifest was signed by the controller's CA.
+func (c *restoreCommand) verifyArchive(filename string, key *statebackups.ArchiveKey) (string, func(), error) {
+	details, err := c.ClientStore().ControllerByName(c.ControllerName())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	return verifyArchiveFile(filename, key, details.CACert)
+}
+
+// verifyArchiveFile decrypts the archive file into a temporary file if
+// it is encrypted, and checks that its manifest was signed with the
+// given CA certificate. It returns the name of the plain archive file,
+// and a function to remove any temporary file.
+func verifyArchiveFile(filename string, key *statebackups.ArchiveKey, caCert string) (_ string, _ func(), err error) {
+	plainFilename := filename
+	cleanup := func() {}
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+
+	archive, err := os.Open(filename)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer archive.Close()
+	encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if encrypted {
+		if key == nil {
+			return "", nil, errors.Errorf("backup %q is encrypted; use --passphrase-file or --private-key", filename)
+		}
+		plain, err := ioutil.TempFile("", "juju-restore-")
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		plainFilename = plain.Name()
+		cleanup = func() { os.Remove(plainFilename) }
+		err = statebackups.DecryptArchive(plain, archive, *key)
+		plain.Close()
+		if err != nil {
+			return "", nil, errors.Annotatef(err, "cannot decrypt backup %q", filename)
+		}
+	}
+
+	plain, err := os.Open(plainFilename)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer plain.Close()
+	workspace, err := statebackups.NewArchiveWorkspaceReader(plain)
+	if workspace != nil {
+		defer workspace.Close()
+	}
+	if err != nil {
+		return "", nil, errors.Annotatef(err, "cannot unpack backup %q", filename)
+	}
+	if err := workspace.VerifyManifest(caCert); err != nil {
+		return "", nil, errors.Annotatef(err, "cannot verify backup %q", filename)
+	}
+	return plainFilename, cleanup, nil
+}
+
+// downloadArchive downloads the backup with the given ID to a
+// temporary file, and returns its name.
+func (c *restoreCommand) downloadArchive(backupId string) (_ string, err error) {
+	client, err := c.newAPIClientFunc()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer client.Close()
+	archive, err := client.Download(backupId)
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot download backup %q", backupId)
+	}
+	defer archive.Close()
+
+	f, err := ioutil.TempFile("", "juju-restore-")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err := io.Copy(f, archive); err != nil {
+		return "", errors.Annotatef(err, "cannot download backup %q", backupId)
+	}
+	return f.Name(), nil
+}
+
 func (c *restoreCommand) newClient() (*backups.Client, error) {
 	client, err := c.NewAPIClient()
 	if err != nil {
@@ -317,16 +480,39 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	key, err := readArchiveKey(c.passphraseFile, c.privateKeyFile, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	filename := c.filename
+	target := c.filename
+	if c.backupId != "" {
+		target = c.backupId
+	}
+	if key != nil && key.PrivateKey != "" && c.backupId != "" {
+		// The private key must not be sent to the controller, so the
+		// archive is downloaded and decrypted here instead.
+		downloaded, err := c.downloadArchive(c.backupId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer os.Remove(downloaded)
+		filename = downloaded
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
-	target := c.backupId
-	if c.filename != "" {
-		// Read archive specified by the filename;
-		// we'll need the info later regardless if
-		// we need it now to rebootstrap.
-		target = c.filename
-		var err error
-		archive, meta, err = c.getArchiveFunc(c.filename)
+	if filename != "" {
+		// Verify, and read, the archive specified by the
+		// filename; we'll need the info later regardless
+		// if we need it now to rebootstrap.
+		filename, cleanup, err := c.verifyArchiveFunc(filename, apiserverbackups.ArchiveKeyFromParams(key))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer cleanup()
+		archive, meta, err = c.getArchiveFunc(filename)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -345,12 +531,16 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.modelUUID != "" {
+		return c.restoreModel(ctx, client, key, archive, meta, target)
+	}
+
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.filename != "" {
+	if archive != nil {
 		err = client.RestoreReader(archive, meta, c.newClient)
 	} else {
-		err = client.Restore(c.backupId, c.newClient)
+		err = client.Restore(c.backupId, key, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -358,3 +548,27 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// restoreModel restores the single model selected with --model-uuid. A
+// backup read locally has already been verified and decrypted, and is
+// uploaded to the controller first.
+func (c *restoreCommand) restoreModel(
+	ctx *cmd.Context, client RestoreAPI, key *params.BackupsArchiveKey,
+	archive ArchiveReader, meta *params.BackupsMetadataResult, target string,
+) error {
+	backupId := c.backupId
+	if archive != nil {
+		id, err := client.Upload(archive, *meta)
+		if err != nil {
+			return errors.Annotatef(err, "cannot upload %q", target)
+		}
+		backupId = id
+		key = nil
+	}
+	result, err := client.RestoreModel(backupId, c.modelUUID, c.newName, key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprintf(ctx.Stdout, "model %q (%s) restored from %q\n", result.Name, result.ModelUUID, target)
+	return nil
+}
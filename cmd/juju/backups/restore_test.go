@@ -4,7 +4,13 @@
 package backups_test
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -102,21 +108,183 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 
 	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "-b")
 	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore from an id.")
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--passphrase-file", "a", "--private-key", "b")
+	c.Assert(err, gc.ErrorMatches, "cannot mix --passphrase-file and --private-key")
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--file", "afile", "-b", "--model-uuid", modelUUID)
+	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore a single model.")
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--model-uuid", "foo")
+	c.Assert(err, gc.ErrorMatches, `invalid model UUID "foo"`)
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--model-uuid", modelUUID, "--model-name", "Not Valid")
+	c.Assert(err, gc.ErrorMatches, `invalid model name "Not Valid"`)
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--model-name", "foo")
+	c.Assert(err, gc.ErrorMatches, "--model-name can only be used with --model-uuid")
+}
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *restoreSuite) TestRestoreModelFromID(c *gc.C) {
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil)
+
+	ctx, err := testing.RunCommand(c, s.command, "restore", "--id", "anid", "--model-uuid", modelUUID, "--model-name", "restored")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.calls, jc.DeepEquals, []string{"RestoreModel"})
+	c.Check(api.backupId, gc.Equals, "anid")
+	c.Check(api.modelUUID, gc.Equals, modelUUID)
+	c.Check(api.newName, gc.Equals, "restored")
+	c.Check(testing.Stdout(ctx), gc.Equals, `model "restored" (`+modelUUID+`) restored from "anid"`+"\n")
+}
+
+func (s *restoreSuite) TestRestoreModelFromFile(c *gc.C) {
+	api := &mockRestoreAPI{}
+	meta := &params.BackupsMetadataResult{Notes: "a backup"}
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, api,
+		func(string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			return &mockArchiveReader{}, meta, nil
+		},
+		nil)
+
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--file", "afile", "--model-uuid", modelUUID, "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.calls, jc.DeepEquals, []string{"Upload", "RestoreModel"})
+	c.Check(api.uploaded, jc.DeepEquals, meta)
+	c.Check(api.backupId, gc.Equals, "uploaded-id")
+	c.Check(api.modelUUID, gc.Equals, modelUUID)
+	// The archive was decrypted before it was uploaded.
+	c.Check(api.key, gc.IsNil)
+}
+
+func (s *restoreSuite) TestRestoreIDWithPassphrase(c *gc.C) {
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil)
+
+	_, err = testing.RunCommand(c, s.command, "restore", "--id", "anid", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.backupId, gc.Equals, "anid")
+	c.Check(api.key, jc.DeepEquals, &params.BackupsArchiveKey{Passphrase: "sekrit"})
+}
+
+func (s *restoreSuite) TestRestoreIDWithPrivateKeyDecryptsLocally(c *gc.C) {
+	privateKeyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(privateKeyFile, []byte("private key"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	api := &mockRestoreAPI{}
+	var archiveFile string
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, api,
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			archiveFile = filename
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{}, nil
+		},
+		nil)
+
+	ctx, err := testing.RunCommand(c, s.command, "restore", "--id", "anid", "--private-key", privateKeyFile)
+	c.Assert(err, jc.ErrorIsNil)
+	// The archive was downloaded and restored from the client; the
+	// private key was never sent to the controller.
+	c.Check(api.calls, jc.DeepEquals, []string{"Download", "RestoreReader"})
+	c.Check(api.backupId, gc.Equals, "anid")
+	c.Check(api.key, gc.IsNil)
+	c.Check(archiveFile, gc.Not(gc.Equals), "")
+	_, err = os.Stat(archiveFile)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+	c.Check(testing.Stdout(ctx), gc.Equals, `restore from "anid" completed`+"\n")
+}
+
+func (s *restoreSuite) TestVerifyArchiveFileEncryptedNoKey(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("JUJU-BACKUP-ENCRYPTED-1\n..."), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, _, err = backups.VerifyArchiveFile(filename, nil, testing.CACert)
+	c.Assert(err, gc.ErrorMatches, `backup ".*" is encrypted; use --passphrase-file or --private-key`)
+}
+
+func (s *restoreSuite) TestVerifyArchiveFileLegacyNoManifest(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	f, err := os.Create(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	c.Assert(tw.WriteHeader(&tar.Header{Name: "juju-backup/metadata.json", Mode: 0600, Size: 2}), jc.ErrorIsNil)
+	_, err = tw.Write([]byte("{}"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+
+	// Archives made before manifests were recorded are accepted.
+	plainFilename, cleanup, err := backups.VerifyArchiveFile(filename, nil, testing.CACert)
+	c.Assert(err, jc.ErrorIsNil)
+	defer cleanup()
+	c.Check(plainFilename, gc.Equals, filename)
 }
 
 // TODO(wallyworld) - add more api related unit tests
 type mockRestoreAPI struct {
 	backups.RestoreAPI
+	calls     []string
+	backupId  string
+	key       *params.BackupsArchiveKey
+	modelUUID string
+	newName   string
+	uploaded  *params.BackupsMetadataResult
+}
+
+func (m *mockRestoreAPI) Restore(backupId string, key *params.BackupsArchiveKey, _ apibackups.ClientConnection) error {
+	m.calls = append(m.calls, "Restore")
+	m.backupId = backupId
+	m.key = key
+	return nil
+}
+
+func (m *mockRestoreAPI) RestoreModel(backupId, modelUUID, newName string, key *params.BackupsArchiveKey) (*params.RestoreModelResult, error) {
+	m.calls = append(m.calls, "RestoreModel")
+	m.backupId = backupId
+	m.modelUUID = modelUUID
+	m.newName = newName
+	m.key = key
+	return &params.RestoreModelResult{
+		ModelUUID: modelUUID,
+		Name:      newName,
+		Owner:     "admin@local",
+	}, nil
+}
+
+func (m *mockRestoreAPI) Upload(archive io.ReadSeeker, meta params.BackupsMetadataResult) (string, error) {
+	m.calls = append(m.calls, "Upload")
+	m.uploaded = &meta
+	return "uploaded-id", nil
 }
 
 func (*mockRestoreAPI) Close() error {
 	return nil
 }
 
-func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (m *mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+	m.calls = append(m.calls, "RestoreReader")
 	return nil
 }
 
+func (m *mockRestoreAPI) Download(backupId string) (io.ReadCloser, error) {
+	m.calls = append(m.calls, "Download")
+	m.backupId = backupId
+	return ioutil.NopCloser(strings.NewReader("archive")), nil
+}
+
 type mockArchiveReader struct {
 	backups.ArchiveReader
 }
@@ -0,0 +1,87 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmrepository holds the commands for working with the charm
+// repository hosted by the controller.
+package charmrepository
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/api/charmrepository"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// RepositoryAPI defines the API methods that the charm repository
+// commands use.
+type RepositoryAPI interface {
+	ListCharms(name string) ([]params.RepositoryCharm, error)
+	ResolveCharm(url string, channel csparams.Channel) (params.RepositoryCharm, error)
+	PromoteCharm(url string, channel csparams.Channel) error
+	Close() error
+}
+
+// PublishAPI defines the API methods that the publish-charm command
+// uses.
+type PublishAPI interface {
+	PublishCharm(r io.ReadSeeker, hash string, size int64) (params.RepositoryCharm, error)
+	Close() error
+}
+
+// repositoryCommandBase holds the fields common to the charm repository
+// commands.
+type repositoryCommandBase struct {
+	modelcmd.ModelCommandBase
+	api RepositoryAPI
+}
+
+func (c *repositoryCommandBase) getAPI() (RepositoryAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return charmrepository.NewClient(root), nil
+}
+
+// RepositoryCharm defines the serialization behaviour of a revision of
+// a charm in the charm repository.
+type RepositoryCharm struct {
+	URL       string   `yaml:"url" json:"url"`
+	Summary   string   `yaml:"summary,omitempty" json:"summary,omitempty"`
+	Series    []string `yaml:"series,omitempty" json:"series,omitempty"`
+	Channels  []string `yaml:"channels,omitempty" json:"channels,omitempty"`
+	Publisher string   `yaml:"publisher" json:"publisher"`
+	Published string   `yaml:"published" json:"published"`
+	Size      int64    `yaml:"size" json:"size"`
+	SHA256    string   `yaml:"sha256" json:"sha256"`
+}
+
+func formatRepositoryCharm(ch params.RepositoryCharm) RepositoryCharm {
+	return RepositoryCharm{
+		URL:       ch.URL,
+		Summary:   ch.Summary,
+		Series:    ch.Series,
+		Channels:  ch.Channels,
+		Publisher: ch.Publisher,
+		Published: ch.Published.Format("2006-01-02"),
+		Size:      ch.Size,
+		SHA256:    ch.SHA256,
+	}
+}
+
+// formatChannels returns the channels of a charm revision for tabular
+// output.
+func formatChannels(channels []string) string {
+	if len(channels) == 0 {
+		return "-"
+	}
+	return strings.Join(channels, ",")
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"io"
+	"io/ioutil"
+	"time"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type BaseSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclienttesting.MemStore
+	api   *mockRepositoryAPI
+}
+
+func (s *BaseSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &mockRepositoryAPI{
+		charms: []params.RepositoryCharm{{
+			URL:       "repo:mysql-0",
+			Name:      "mysql",
+			Revision:  0,
+			Summary:   "A database.",
+			Series:    []string{"trusty"},
+			Publisher: "admin@local",
+			Published: time.Date(2016, 8, 1, 0, 0, 0, 0, time.UTC),
+			Size:      42,
+			SHA256:    "abc",
+		}, {
+			URL:       "repo:mysql-1",
+			Name:      "mysql",
+			Revision:  1,
+			Summary:   "A database.",
+			Series:    []string{"trusty"},
+			Channels:  []string{"stable", "edge"},
+			Publisher: "admin@local",
+			Published: time.Date(2016, 8, 2, 0, 0, 0, 0, time.UTC),
+			Size:      43,
+			SHA256:    "def",
+		}},
+	}
+
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = &jujuclient.ControllerAccounts{
+		CurrentAccount: "admin@local",
+	}
+	err := s.store.UpdateModel("testing", "admin@local", "mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].AccountModels["admin@local"].CurrentModel = "mymodel"
+}
+
+type mockRepositoryAPI struct {
+	gitjujutesting.Stub
+	charms    []params.RepositoryCharm
+	published []byte
+}
+
+func (m *mockRepositoryAPI) ListCharms(name string) ([]params.RepositoryCharm, error) {
+	m.MethodCall(m, "ListCharms", name)
+	return m.charms, m.NextErr()
+}
+
+func (m *mockRepositoryAPI) ResolveCharm(url string, channel csparams.Channel) (params.RepositoryCharm, error) {
+	m.MethodCall(m, "ResolveCharm", url, channel)
+	return m.charms[len(m.charms)-1], m.NextErr()
+}
+
+func (m *mockRepositoryAPI) PromoteCharm(url string, channel csparams.Channel) error {
+	m.MethodCall(m, "PromoteCharm", url, channel)
+	return m.NextErr()
+}
+
+func (m *mockRepositoryAPI) PublishCharm(r io.ReadSeeker, hash string, size int64) (params.RepositoryCharm, error) {
+	m.MethodCall(m, "PublishCharm", hash, size)
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return params.RepositoryCharm{}, err
+	}
+	m.published = data
+	return params.RepositoryCharm{URL: "repo:dummy-0"}, m.NextErr()
+}
+
+func (m *mockRepositoryAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -0,0 +1,46 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewPublishCommandForTest returns a publish-charm command with the apis
+// provided as specified.
+func NewPublishCommandForTest(publishAPI PublishAPI, api RepositoryAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &publishCommand{
+		repositoryCommandBase: repositoryCommandBase{api: api},
+		publishAPI:            publishAPI,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// NewListCommandForTest returns a repository-charms command with the api
+// provided as specified.
+func NewListCommandForTest(api RepositoryAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listCommand{repositoryCommandBase: repositoryCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// NewShowCommandForTest returns a show-repository-charm command with the
+// api provided as specified.
+func NewShowCommandForTest(api RepositoryAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &showCommand{repositoryCommandBase: repositoryCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+// NewPromoteCommandForTest returns a promote-charm command with the api
+// provided as specified.
+func NewPromoteCommandForTest(api RepositoryAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &promoteCommand{repositoryCommandBase: repositoryCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageListSummary = `
+Lists the charms in the controller's charm repository.`[1:]
+
+var usageListDetails = `
+Every published revision is listed, along with the channels it is in.
+Give a charm name to list only the revisions of that charm.
+By default, the tabular format is used.
+
+Examples:
+    juju repository-charms
+    juju repository-charms mysql
+
+See also: 
+    publish-charm
+    show-repository-charm`[1:]
+
+// NewListCommand returns a command to list the charms in the charm
+// repository.
+func NewListCommand() cmd.Command {
+	return modelcmd.Wrap(&listCommand{})
+}
+
+// listCommand lists the charms in the charm repository.
+type listCommand struct {
+	repositoryCommandBase
+	out cmd.Output
+
+	Name string
+}
+
+// Info implements Command.Info.
+func (c *listCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "repository-charms",
+		Args:    "[<charm name>]",
+		Purpose: usageListSummary,
+		Doc:     usageListDetails,
+		Aliases: []string{"list-repository-charms"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *listCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.Name = args[0]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *listCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	charms, err := api.ListCharms(c.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output := make([]RepositoryCharm, len(charms))
+	for i, ch := range charms {
+		output[i] = formatRepositoryCharm(ch)
+	}
+	return c.out.Write(ctx, output)
+}
+
+func formatListTabular(value interface{}) ([]byte, error) {
+	charms, ok := value.([]RepositoryCharm)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", charms, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "CHARM\tCHANNELS\tPUBLISHER\tPUBLISHED\n")
+	for _, ch := range charms {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", ch.URL, formatChannels(ch.Channels), ch.Publisher, ch.Published)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/charmrepository"
+	"github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) TestList(c *gc.C) {
+	ctx, err := testing.RunCommand(c, charmrepository.NewListCommandForTest(s.api, s.store), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListCharms", "mysql")
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"CHARM         CHANNELS     PUBLISHER    PUBLISHED\n"+
+		"repo:mysql-0  -            admin@local  2016-08-01\n"+
+		"repo:mysql-1  stable,edge  admin@local  2016-08-02\n")
+}
+
+func (s *ListSuite) TestListYAML(c *gc.C) {
+	s.api.charms = s.api.charms[1:]
+	ctx, err := testing.RunCommand(c, charmrepository.NewListCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListCharms", "")
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+- url: repo:mysql-1
+  summary: A database.
+  series:
+  - trusty
+  channels:
+  - stable
+  - edge
+  publisher: admin@local
+  published: 2016-08-02
+  size: 43
+  sha256: def
+`[1:])
+}
+
+func (s *ListSuite) TestListTooManyArgs(c *gc.C) {
+	_, err := testing.RunCommand(c, charmrepository.NewListCommandForTest(s.api, s.store), "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+}
@@ -0,0 +1,93 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usagePromoteSummary = `
+Puts a revision of a charm in the controller's charm repository in a channel.`[1:]
+
+var usagePromoteDetails = `
+The revision replaces the one that was in the channel. Charm URLs
+without a revision, as used by deploy and upgrade-charm, resolve to the
+revision in the requested channel, which is stable by default.
+Valid channels are stable, candidate, beta and edge. Only controller
+administrators may promote charms.
+
+Examples:
+    juju promote-charm repo:mysql-3 stable
+
+See also: 
+    publish-charm
+    repository-charms`[1:]
+
+// NewPromoteCommand returns a command to put a revision of a charm in
+// the charm repository in a channel.
+func NewPromoteCommand() cmd.Command {
+	return modelcmd.Wrap(&promoteCommand{})
+}
+
+// promoteCommand puts a revision of a charm in the charm repository in
+// a channel.
+type promoteCommand struct {
+	repositoryCommandBase
+
+	URL     string
+	Channel csparams.Channel
+}
+
+// Info implements Command.Info.
+func (c *promoteCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "promote-charm",
+		Args:    "<charm URL> <channel>",
+		Purpose: usagePromoteSummary,
+		Doc:     usagePromoteDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *promoteCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no charm URL specified")
+	case 1:
+		return errors.New("no channel specified")
+	}
+	curl, err := charmrepository.ParseURL(args[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return errors.Errorf("charm URL %q has no revision", args[0])
+	}
+	c.URL = args[0]
+	c.Channel = csparams.Channel(args[1])
+	if err := charmrepository.ValidateChannel(c.Channel); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *promoteCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.PromoteCharm(c.URL, c.Channel); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Promoted %s to %s", c.URL, c.Channel)
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/cmd/juju/charmrepository"
+	"github.com/juju/juju/testing"
+)
+
+type PromoteSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&PromoteSuite{})
+
+func (s *PromoteSuite) TestPromote(c *gc.C) {
+	ctx, err := testing.RunCommand(c, charmrepository.NewPromoteCommandForTest(s.api, s.store), "repo:mysql-1", "stable")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "PromoteCharm", "repo:mysql-1", csparams.StableChannel)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Promoted repo:mysql-1 to stable\n")
+}
+
+var promoteInitErrorTests = []struct {
+	args []string
+	err  string
+}{{
+	err: "no charm URL specified",
+}, {
+	args: []string{"repo:mysql-1"},
+	err:  "no channel specified",
+}, {
+	args: []string{"repo:mysql", "stable"},
+	err:  `charm URL "repo:mysql" has no revision`,
+}, {
+	args: []string{"repo:mysql-1", "bleeding"},
+	err:  `channel "bleeding" not valid`,
+}}
+
+func (s *PromoteSuite) TestPromoteInitErrors(c *gc.C) {
+	for i, test := range promoteInitErrorTests {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := testing.RunCommand(c, charmrepository.NewPromoteCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usagePublishSummary = `
+Publishes a charm to the controller's charm repository.`[1:]
+
+var usagePublishDetails = `
+The charm, given as the path to a charm directory or archive, is added to
+the repository hosted by the controller as the next revision of the
+charm with its name. The charms in the repository can be deployed to
+any model of the controller with "repo:" charm URLs, e.g.
+
+    juju deploy repo:mysql
+
+A new revision is not in any channel, so only charm URLs naming its
+revision resolve to it, until it is promoted to a channel with
+promote-charm or published with --channel. Only controller
+administrators may publish charms.
+
+Examples:
+    juju publish-charm ./mysql
+    juju publish-charm ./mysql --channel edge
+
+See also: 
+    promote-charm
+    repository-charms
+    show-repository-charm`[1:]
+
+// NewPublishCommand returns a command to publish a charm to the charm
+// repository.
+func NewPublishCommand() cmd.Command {
+	return modelcmd.Wrap(&publishCommand{})
+}
+
+// publishCommand publishes a charm to the charm repository.
+type publishCommand struct {
+	repositoryCommandBase
+	publishAPI PublishAPI
+
+	Path    string
+	Channel csparams.Channel
+}
+
+// Info implements Command.Info.
+func (c *publishCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "publish-charm",
+		Args:    "<charm path>",
+		Purpose: usagePublishSummary,
+		Doc:     usagePublishDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *publishCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to promote the published charm to")
+}
+
+// Init implements Command.Init.
+func (c *publishCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no charm path specified")
+	}
+	c.Path = args[0]
+	if c.Channel != csparams.NoChannel {
+		if err := charmrepository.ValidateChannel(c.Channel); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *publishCommand) getPublishAPI() (PublishAPI, error) {
+	if c.publishAPI != nil {
+		return c.publishAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *publishCommand) Run(ctx *cmd.Context) error {
+	data, err := readCharmArchive(ctx.AbsPath(c.Path))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	publishAPI, err := c.getPublishAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer publishAPI.Close()
+	hash := fmt.Sprintf("%x", sha256.Sum256(data))
+	ch, err := publishAPI.PublishCharm(bytes.NewReader(data), hash, int64(len(data)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Published %s", ch.URL)
+
+	if c.Channel == csparams.NoChannel {
+		return nil
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+	if err := api.PromoteCharm(ch.URL, c.Channel); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Promoted %s to %s", ch.URL, c.Channel)
+	return nil
+}
+
+// readCharmArchive returns the contents of the charm archive at path,
+// or of an archive of the charm directory at path.
+func readCharmArchive(path string) ([]byte, error) {
+	ch, err := charm.ReadCharm(path)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm %q", path)
+	}
+	switch ch := ch.(type) {
+	case *charm.CharmDir:
+		var buf bytes.Buffer
+		if err := ch.ArchiveTo(&buf); err != nil {
+			return nil, errors.Annotate(err, "cannot package charm")
+		}
+		return buf.Bytes(), nil
+	case *charm.CharmArchive:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read charm archive")
+		}
+		return data, nil
+	}
+	return nil, errors.Errorf("unknown charm type %T", ch)
+}
@@ -0,0 +1,57 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/cmd/juju/charmrepository"
+	"github.com/juju/juju/testcharms"
+	"github.com/juju/juju/testing"
+)
+
+type PublishSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&PublishSuite{})
+
+func (s *PublishSuite) TestPublishArchive(c *gc.C) {
+	path := testcharms.Repo.CharmArchivePath(c.MkDir(), "dummy")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := testing.RunCommand(c, charmrepository.NewPublishCommandForTest(s.api, s.api, s.store), path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "PublishCharm", "Close")
+	s.api.CheckCall(c, 0, "PublishCharm", fmt.Sprintf("%x", sha256.Sum256(data)), int64(len(data)))
+	c.Assert(s.api.published, gc.DeepEquals, data)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Published repo:dummy-0\n")
+}
+
+func (s *PublishSuite) TestPublishDirWithChannel(c *gc.C) {
+	path := testcharms.Repo.CharmDirPath("dummy")
+	ctx, err := testing.RunCommand(c, charmrepository.NewPublishCommandForTest(s.api, s.api, s.store), path, "--channel", "edge")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "PublishCharm", "PromoteCharm", "Close", "Close")
+	s.api.CheckCall(c, 1, "PromoteCharm", "repo:dummy-0", csparams.EdgeChannel)
+	c.Assert(s.api.published, gc.Not(gc.HasLen), 0)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Published repo:dummy-0\nPromoted repo:dummy-0 to edge\n")
+}
+
+func (s *PublishSuite) TestPublishInvalidChannel(c *gc.C) {
+	_, err := testing.RunCommand(c, charmrepository.NewPublishCommandForTest(s.api, s.api, s.store), "./dummy", "--channel", "bleeding")
+	c.Assert(err, gc.ErrorMatches, `channel "bleeding" not valid`)
+}
+
+func (s *PublishSuite) TestPublishNoPath(c *gc.C) {
+	_, err := testing.RunCommand(c, charmrepository.NewPublishCommandForTest(s.api, s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "no charm path specified")
+}
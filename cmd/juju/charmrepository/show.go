@@ -0,0 +1,91 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageShowSummary = `
+Shows a charm in the controller's charm repository.`[1:]
+
+var usageShowDetails = `
+A charm URL without a revision shows the revision in the channel given
+with --channel, or in the stable channel by default.
+By default, the YAML format is used.
+
+Examples:
+    juju show-repository-charm repo:mysql
+    juju show-repository-charm repo:mysql --channel edge
+    juju show-repository-charm repo:mysql-3
+
+See also: 
+    repository-charms`[1:]
+
+// NewShowCommand returns a command to show a charm in the charm
+// repository.
+func NewShowCommand() cmd.Command {
+	return modelcmd.Wrap(&showCommand{})
+}
+
+// showCommand shows a charm in the charm repository.
+type showCommand struct {
+	repositoryCommandBase
+	out cmd.Output
+
+	URL     string
+	Channel csparams.Channel
+}
+
+// Info implements Command.Info.
+func (c *showCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-repository-charm",
+		Args:    "<charm URL>",
+		Purpose: usageShowSummary,
+		Doc:     usageShowDetails,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to resolve a charm URL without a revision against")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no charm URL specified")
+	}
+	if _, err := charmrepository.ParseURL(args[0]); err != nil {
+		return errors.Trace(err)
+	}
+	c.URL = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *showCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	ch, err := api.ResolveCharm(c.URL, c.Channel)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, formatRepositoryCharm(ch))
+}
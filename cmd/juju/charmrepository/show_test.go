@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmrepository_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/cmd/juju/charmrepository"
+	"github.com/juju/juju/testing"
+)
+
+type ShowSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&ShowSuite{})
+
+func (s *ShowSuite) TestShow(c *gc.C) {
+	ctx, err := testing.RunCommand(c, charmrepository.NewShowCommandForTest(s.api, s.store), "repo:mysql", "--channel", "edge")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ResolveCharm", "repo:mysql", csparams.EdgeChannel)
+	c.Assert(testing.Stdout(ctx), gc.Equals, `
+url: repo:mysql-1
+summary: A database.
+series:
+- trusty
+channels:
+- stable
+- edge
+publisher: admin@local
+published: 2016-08-02
+size: 43
+sha256: def
+`[1:])
+}
+
+func (s *ShowSuite) TestShowInvalidURL(c *gc.C) {
+	_, err := testing.RunCommand(c, charmrepository.NewShowCommandForTest(s.api, s.store), "cs:mysql")
+	c.Assert(err, gc.ErrorMatches, `charm repository URL "cs:mysql" not valid`)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/juju/cmd"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -39,6 +40,13 @@ to the command:
 
 juju add-ssh-key "$(cat ~/mykey.pub)"
 
+Keys may instead be added to a user account with the '--user' option.
+A user's keys are copied to the units of every model that user has write
+or admin access to, and are removed again when that access is revoked or
+the user is disabled.
+
+    juju add-ssh-key --user bob "$(cat ~/mykey.pub)"
+
 See also: 
     ssh-keys
     remove-ssh-key
@@ -67,6 +75,11 @@ func (c *addKeysCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *addKeysCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "Add the keys to this user account instead of the model")
+}
+
 // Init implements Command.Init.
 func (c *addKeysCommand) Init(args []string) error {
 	switch len(args) {
@@ -80,15 +93,16 @@ func (c *addKeysCommand) Init(args []string) error {
 
 // Run implements Command.Run.
 func (c *addKeysCommand) Run(context *cmd.Context) error {
+	if c.user != "" {
+		return c.addUserKeys()
+	}
 	client, err := c.NewKeyManagerClient()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
-	// TODO(alexisb) - currently keys are global which is not ideal.
-	// keymanager needs to be updated to allow keys per user
-	c.user = "admin"
-	results, err := client.AddKeys(c.user, c.sshKeys...)
+	// Model keys are not owned by any user.
+	results, err := client.AddKeys("admin", c.sshKeys...)
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
@@ -99,3 +113,14 @@ func (c *addKeysCommand) Run(context *cmd.Context) error {
 	}
 	return nil
 }
+
+// addUserKeys adds the keys to the user account.
+func (c *addKeysCommand) addUserKeys() error {
+	client, err := c.NewUserManagerClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.AddSSHKeys(c.user, c.sshKeys...)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -24,6 +25,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/mirror"
 	"github.com/juju/juju/environs/sync"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
@@ -85,6 +87,9 @@ address.
     
 Private clouds may need to specify their own custom image metadata and
 tools/agent. Use '--metadata-source' whose value is a local directory.
+Air-gapped sites can instead use '--mirror' with a directory created by
+` + "`juju create-mirror`" + `; it supplies the tools, image metadata and
+Juju GUI, and holds the charms of any mirrored bundle.
 The value of '--agent-version' will become the default tools version to
 use in all models for this controller. The full binary version is accepted
 (e.g.: 2.0.1-xenial-amd64) but only the numeric version (e.g.: 2.0.1) is
@@ -98,6 +103,7 @@ Examples:
     juju bootstrap --config=~/config-rs.yaml joe-syd rackspace
     juju bootstrap --config agent-version=1.25.3 joe-us-east-1 aws
     juju bootstrap --config bootstrap-timeout=1200 joe-eastus azure
+    juju bootstrap --mirror /srv/juju-mirror joe-dc maas
 
 See also: 
     add-credentials
@@ -126,6 +132,7 @@ type bootstrapCommand struct {
 	BootstrapImage        string
 	UploadTools           bool
 	MetadataSource        string
+	Mirror                string
 	Placement             string
 	KeepBrokenEnvironment bool
 	AutoUpgrade           bool
@@ -161,6 +168,7 @@ func (c *bootstrapCommand) SetFlags(f *gnuflag.FlagSet) {
 	}
 	f.BoolVar(&c.UploadTools, "upload-tools", false, "Upload local version of tools before bootstrapping")
 	f.StringVar(&c.MetadataSource, "metadata-source", "", "Local path to use as tools and/or metadata source")
+	f.StringVar(&c.Mirror, "mirror", "", "Local path of a mirror created with create-mirror")
 	f.StringVar(&c.Placement, "to", "", "Placement directive indicating an instance to bootstrap")
 	f.BoolVar(&c.KeepBrokenEnvironment, "keep-broken", false, "Do not destroy the model if bootstrap fails")
 	f.BoolVar(&c.AutoUpgrade, "auto-upgrade", false, "Upgrade to the latest patch release tools on first bootstrap")
@@ -187,6 +195,9 @@ func (c *bootstrapCommand) Init(args []string) (err error) {
 	if c.AgentVersionParam != "" && c.UploadTools {
 		return fmt.Errorf("--agent-version and --upload-tools can't be used together")
 	}
+	if c.Mirror != "" && c.MetadataSource != "" {
+		return fmt.Errorf("--mirror and --metadata-source can't be used together")
+	}
 	if c.BootstrapSeries != "" && !charm.IsValidSeries(c.BootstrapSeries) {
 		return errors.NotValidf("series %q", c.BootstrapSeries)
 	}
@@ -569,6 +580,11 @@ to clean up the model.`[1:])
 	if c.MetadataSource != "" {
 		metadataDir = ctx.AbsPath(c.MetadataSource)
 	}
+	// A mirror holds tools and image metadata in the same layout as
+	// --metadata-source.
+	if c.Mirror != "" {
+		metadataDir = ctx.AbsPath(c.Mirror)
+	}
 
 	// Merge environ and bootstrap-specific constraints.
 	constraintsValidator, err := environ.ConstraintsValidator()
@@ -615,8 +631,19 @@ to clean up the model.`[1:])
 
 	// Check whether the Juju GUI must be installed in the controller.
 	// Leaving this value empty means no GUI will be installed.
-	var guiDataSourceBaseURL string
-	if !c.noGUI {
+	var guiDataSourceBaseURL, guiArchivePath string
+	switch {
+	case c.noGUI:
+	case c.Mirror != "":
+		// The mirror is self-contained: the GUI is installed from it
+		// or not at all.
+		guiArchivePath, err = mirror.GUIArchive(metadataDir)
+		if errors.IsNotFound(err) {
+			ctx.Infof("No Juju GUI found in mirror %q", metadataDir)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	default:
 		guiDataSourceBaseURL = common.GUIDataSourceBaseURL()
 	}
 
@@ -646,6 +673,7 @@ to clean up the model.`[1:])
 		ControllerInheritedConfig: inheritedControllerAttrs,
 		HostedModelConfig:         hostedModelConfig,
 		GUIDataSourceBaseURL:      guiDataSourceBaseURL,
+		GUIArchivePath:            guiArchivePath,
 	})
 	if err != nil {
 		return errors.Annotate(err, "failed to bootstrap model")
@@ -663,7 +691,16 @@ to clean up the model.`[1:])
 	// To avoid race conditions when running scripted bootstraps, wait
 	// for the controller's machine agent to be ready to accept commands
 	// before exiting this bootstrap command.
-	return waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName)
+	if err := waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName); err != nil {
+		return err
+	}
+	if c.Mirror != "" {
+		bundlePath := filepath.Join(metadataDir, mirror.BundleFile)
+		if _, err := os.Stat(bundlePath); err == nil {
+			ctx.Infof("To deploy the mirrored bundle, run:\n    juju deploy %s", bundlePath)
+		}
+	}
+	return nil
 }
 
 // getRegion returns the cloud.Region to use, based on the specified
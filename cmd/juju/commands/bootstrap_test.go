@@ -31,6 +31,7 @@ import (
 	"github.com/juju/juju/environs/filestorage"
 	"github.com/juju/juju/environs/gui"
 	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/mirror"
 	"github.com/juju/juju/environs/simplestreams"
 	sstesting "github.com/juju/juju/environs/simplestreams/testing"
 	"github.com/juju/juju/environs/sync"
@@ -318,6 +319,10 @@ var bootstrapTests = []bootstrapTest{{
 	info: "--agent-version with --upload-tools",
 	args: []string{"--agent-version", "1.1.0", "--upload-tools"},
 	err:  `--agent-version and --upload-tools can't be used together`,
+}, {
+	info: "--mirror with --metadata-source",
+	args: []string{"--mirror", "/srv/mirror", "--metadata-source", "/srv/metadata"},
+	err:  `--mirror and --metadata-source can't be used together`,
 }, {
 	info: "invalid --agent-version value",
 	args: []string{"--agent-version", "foo"},
@@ -496,6 +501,39 @@ func (s *BootstrapSuite) TestBootstrapWithoutGUI(c *gc.C) {
 	c.Assert(bootstrap.args.GUIDataSourceBaseURL, gc.Equals, "")
 }
 
+func (s *BootstrapSuite) TestBootstrapWithMirror(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	mirrorDir := c.MkDir()
+	guiPath := filepath.Join(mirrorDir, mirror.GUIDir, "jujugui-2.1.0.tar.bz2")
+	err := os.MkdirAll(filepath.Dir(guiPath), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(guiPath, []byte("gui"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	coretesting.RunCommand(c, s.newBootstrapCommand(), "devcontroller", "dummy", "--mirror", mirrorDir)
+	c.Assert(bootstrap.args.MetadataDir, gc.Equals, mirrorDir)
+	c.Assert(bootstrap.args.GUIArchivePath, gc.Equals, guiPath)
+	c.Assert(bootstrap.args.GUIDataSourceBaseURL, gc.Equals, "")
+}
+
+func (s *BootstrapSuite) TestBootstrapWithMirrorWithoutGUI(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	mirrorDir := c.MkDir()
+
+	coretesting.RunCommand(c, s.newBootstrapCommand(), "devcontroller", "dummy", "--mirror", mirrorDir)
+	c.Assert(bootstrap.args.MetadataDir, gc.Equals, mirrorDir)
+	c.Assert(bootstrap.args.GUIArchivePath, gc.Equals, "")
+	c.Assert(bootstrap.args.GUIDataSourceBaseURL, gc.Equals, "")
+}
+
 type mockBootstrapInstance struct {
 	instance.Instance
 }
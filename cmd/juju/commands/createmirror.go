@@ -0,0 +1,227 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable/csclient"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/gui"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/mirror"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/sync"
+)
+
+var (
+	mirrorTools  = mirror.Tools
+	mirrorImages = mirror.Images
+	mirrorCharms = mirror.Charms
+	mirrorGUI    = mirror.GUI
+)
+
+// mirrorCharmStore is the part of the charm store used by create-mirror.
+type mirrorCharmStore interface {
+	mirror.CharmStore
+	GetBundle(curl *charm.URL) (charm.Bundle, error)
+}
+
+var newMirrorCharmStore = func(client *httpbakery.Client) mirrorCharmStore {
+	return charmrepo.NewCharmStoreFromClient(csclient.New(csclient.Params{
+		BakeryClient: client,
+	}))
+}
+
+func newCreateMirrorCommand() cmd.Command {
+	return modelcmd.WrapBase(&createMirrorCommand{})
+}
+
+// createMirrorCommand assembles a directory holding everything needed
+// to bootstrap and deploy without Internet access.
+type createMirrorCommand struct {
+	modelcmd.JujuCommandBase
+
+	dir        string
+	versionStr string
+	stream     string
+	source     string
+	region     string
+	endpoint   string
+	series     []string
+	arches     []string
+	bundle     string
+	noGUI      bool
+
+	majorVersion int
+	minorVersion int
+}
+
+const createMirrorDoc = `
+Creates a directory holding everything needed to bootstrap a controller
+and deploy a bundle where there is no Internet access: agent binaries
+and image metadata from the official streams, the charms of a bundle
+from the charm store, and the Juju GUI.
+
+Image metadata is specific to a cloud region, so it is only mirrored
+when --region is given; the region and endpoint must be those of the
+private cloud the images have been imported into.
+
+The directory is then carried into the air-gapped site and used with
+` + "`juju bootstrap --mirror`" + `. A mirrored bundle refers to its charms
+by local path, and can be deployed with ` + "`juju deploy <dir>/bundle.yaml`" + `.
+
+Examples:
+    juju create-mirror /srv/juju-mirror
+    juju create-mirror --bundle cs:bundle/wiki-simple /srv/juju-mirror
+    juju create-mirror --region dc1 --endpoint https://openstack.example.com:5000/v3 \
+        --series xenial --arch amd64 /srv/juju-mirror
+
+See also:
+    bootstrap
+    sync-tools
+`
+
+func (c *createMirrorCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-mirror",
+		Args:    "<directory>",
+		Purpose: "Creates a mirror of tools, images, charms and GUI for air-gapped deployments.",
+		Doc:     createMirrorDoc,
+	}
+}
+
+func (c *createMirrorCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.versionStr, "version", "", "Mirror a specific major[.minor] version of the agent binaries")
+	f.StringVar(&c.stream, "stream", "", "Simplestreams stream of the agent binaries to mirror")
+	f.StringVar(&c.source, "source", "", "Local source directory for agent binaries")
+	f.StringVar(&c.region, "region", "", "Cloud region to mirror image metadata for")
+	f.StringVar(&c.endpoint, "endpoint", "", "Cloud endpoint to mirror image metadata for")
+	f.Var(cmd.NewStringsValue(nil, &c.series), "series", "Series to mirror image metadata for")
+	f.Var(cmd.NewStringsValue(nil, &c.arches), "arch", "Architectures to mirror image metadata for")
+	f.StringVar(&c.bundle, "bundle", "", "Bundle whose charms to mirror, as a charm store URL or local file")
+	f.BoolVar(&c.noGUI, "no-gui", false, "Do not mirror the Juju GUI")
+}
+
+func (c *createMirrorCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no mirror directory specified")
+	}
+	c.dir = args[0]
+	if c.versionStr != "" {
+		var err error
+		if c.majorVersion, c.minorVersion, err = version.ParseMajorMinor(c.versionStr); err != nil {
+			return err
+		}
+	}
+	if c.region == "" && (c.endpoint != "" || len(c.series) > 0 || len(c.arches) > 0) {
+		return errors.New("--endpoint, --series and --arch require --region")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *createMirrorCommand) Run(ctx *cmd.Context) error {
+	loggo.RegisterWriter("createmirror", cmd.NewCommandLogWriter("juju.environs", ctx.Stdout, ctx.Stderr), loggo.INFO)
+	defer loggo.RemoveWriter("createmirror")
+
+	dir := ctx.AbsPath(c.dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+
+	ctx.Infof("Mirroring agent binaries")
+	err := mirrorTools(dir, &sync.SyncContext{
+		MajorVersion: c.majorVersion,
+		MinorVersion: c.minorVersion,
+		Stream:       c.stream,
+		Source:       c.source,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if c.region != "" {
+		ctx.Infof("Mirroring image metadata for region %q", c.region)
+		sources, err := imagemetadata.OfficialDataSources(imagemetadata.ReleasedStream)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+			CloudSpec: simplestreams.CloudSpec{
+				Region:   c.region,
+				Endpoint: c.endpoint,
+			},
+			Series: c.series,
+			Arches: c.arches,
+		})
+		count, err := mirrorImages(dir, sources, cons)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Mirrored metadata for %d images", count)
+	}
+
+	if c.bundle != "" {
+		ctx.Infof("Mirroring charms for bundle %q", c.bundle)
+		if err := c.mirrorBundle(ctx, dir); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if !c.noGUI {
+		source := gui.NewDataSource(common.GUIDataSourceBaseURL())
+		vers, err := mirrorGUI(dir, gui.ReleasedStream, source)
+		if err != nil {
+			return errors.Annotate(err, "cannot mirror Juju GUI")
+		}
+		ctx.Infof("Mirrored Juju GUI %s", vers)
+	}
+
+	ctx.Infof("Mirror created in %s\nBootstrap from it with:\n    juju bootstrap --mirror %s <controller name> <cloud name>", dir, dir)
+	return nil
+}
+
+func (c *createMirrorCommand) mirrorBundle(ctx *cmd.Context, dir string) error {
+	bakeryClient, err := c.BakeryClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	store := newMirrorCharmStore(bakeryClient)
+
+	var data *charm.BundleData
+	if _, err := os.Stat(ctx.AbsPath(c.bundle)); err == nil {
+		data, err = charmrepo.ReadBundleFile(ctx.AbsPath(c.bundle))
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		ref, err := charm.ParseURL(c.bundle)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		curl, _, err := store.Resolve(ref)
+		if err != nil {
+			return errors.Annotatef(err, "cannot resolve bundle %q", c.bundle)
+		}
+		if curl.Series != "bundle" {
+			return errors.Errorf("expected bundle URL, got charm URL %q", curl)
+		}
+		bundle, err := store.GetBundle(curl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data = bundle.Data()
+	}
+	return mirrorCharms(dir, data, store)
+}
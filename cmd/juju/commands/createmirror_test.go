@@ -0,0 +1,159 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/macaroon-bakery.v1/httpbakery"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/mirror"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/sync"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type createMirrorSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	dir   string
+	calls []string
+	store *fakeMirrorCharmStore
+}
+
+var _ = gc.Suite(&createMirrorSuite{})
+
+func (s *createMirrorSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.calls = nil
+	s.store = &fakeMirrorCharmStore{}
+	s.PatchValue(&mirrorTools, func(dir string, sctx *sync.SyncContext) error {
+		s.calls = append(s.calls, "tools")
+		c.Check(dir, gc.Equals, s.dir)
+		return nil
+	})
+	s.PatchValue(&mirrorImages, func(dir string, sources []simplestreams.DataSource, cons *imagemetadata.ImageConstraint) (int, error) {
+		s.calls = append(s.calls, "images")
+		return 3, nil
+	})
+	s.PatchValue(&mirrorCharms, func(dir string, data *charm.BundleData, store mirror.CharmStore) error {
+		s.calls = append(s.calls, "charms")
+		c.Check(data.Applications, gc.HasLen, 1)
+		return nil
+	})
+	s.PatchValue(&mirrorGUI, func(dir, stream string, sources ...simplestreams.DataSource) (version.Number, error) {
+		s.calls = append(s.calls, "gui")
+		return version.MustParse("2.1.0"), nil
+	})
+	s.PatchValue(&newMirrorCharmStore, func(*httpbakery.Client) mirrorCharmStore {
+		return s.store
+	})
+}
+
+func (s *createMirrorSuite) runCreateMirror(c *gc.C, args ...string) (*cmd.Context, error) {
+	return coretesting.RunCommand(c, modelcmd.WrapBase(&createMirrorCommand{}), args...)
+}
+
+func (s *createMirrorSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no mirror directory specified",
+	}, {
+		args: []string{"dir", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--series", "xenial", "dir"},
+		err:  "--endpoint, --series and --arch require --region",
+	}, {
+		args: []string{"--version", "foo", "dir"},
+		err:  `invalid major version number foo: .*`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runCreateMirror(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *createMirrorSuite) TestCreateMirror(c *gc.C) {
+	ctx, err := s.runCreateMirror(c, s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"tools", "gui"})
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "juju bootstrap --mirror "+s.dir)
+}
+
+func (s *createMirrorSuite) TestCreateMirrorAll(c *gc.C) {
+	bundlePath := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(bundlePath, []byte("applications:\n  wiki:\n    charm: cs:trusty/mediawiki-5\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := s.runCreateMirror(c,
+		"--region", "dc1", "--series", "xenial", "--bundle", bundlePath, s.dir,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"tools", "images", "charms", "gui"})
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "Mirrored metadata for 3 images")
+}
+
+func (s *createMirrorSuite) TestCreateMirrorStoreBundle(c *gc.C) {
+	_, err := s.runCreateMirror(c, "--bundle", "cs:bundle/wiki", "--no-gui", s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.calls, jc.DeepEquals, []string{"tools", "charms"})
+	c.Assert(s.store.gotBundle, gc.Equals, "cs:bundle/wiki-3")
+}
+
+func (s *createMirrorSuite) TestCreateMirrorNotBundle(c *gc.C) {
+	_, err := s.runCreateMirror(c, "--bundle", "cs:trusty/mysql", s.dir)
+	c.Assert(err, gc.ErrorMatches, `expected bundle URL, got charm URL "cs:trusty/mysql-3"`)
+}
+
+func (s *createMirrorSuite) TestCreateMirrorToolsError(c *gc.C) {
+	s.PatchValue(&mirrorTools, func(string, *sync.SyncContext) error {
+		return errors.New("cannot mirror agent binaries: no tools")
+	})
+	_, err := s.runCreateMirror(c, s.dir)
+	c.Assert(err, gc.ErrorMatches, "cannot mirror agent binaries: no tools")
+}
+
+type fakeMirrorCharmStore struct {
+	gotBundle string
+}
+
+func (f *fakeMirrorCharmStore) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	curl := *ref
+	curl.Revision = 3
+	return &curl, nil, nil
+}
+
+func (f *fakeMirrorCharmStore) Get(curl *charm.URL) (charm.Charm, error) {
+	return nil, errors.NotImplementedf("Get")
+}
+
+func (f *fakeMirrorCharmStore) GetBundle(curl *charm.URL) (charm.Bundle, error) {
+	f.gotBundle = curl.String()
+	return fakeBundle{}, nil
+}
+
+type fakeBundle struct {
+	charm.Bundle
+}
+
+func (fakeBundle) Data() *charm.BundleData {
+	return &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"wiki": {Charm: "cs:trusty/mediawiki-5"},
+		},
+	}
+}
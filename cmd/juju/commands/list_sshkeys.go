@@ -31,7 +31,12 @@ Examples:
 
 To examine the full key, use the '--full' option:
 
-    juju ssh-keys -m jujutest --full`[1:]
+    juju ssh-keys -m jujutest --full
+
+To list the keys of a user account, rather than those of the model, use
+the '--user' option:
+
+    juju ssh-keys --user bob`[1:]
 
 // NewListKeysCommand returns a command used to list the authorized ssh keys.
 func NewListKeysCommand() cmd.Command {
@@ -58,24 +63,26 @@ func (c *listKeysCommand) Info() *cmd.Info {
 // SetFlags implements Command.SetFlags.
 func (c *listKeysCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.showFullKey, "full", false, "Show full key instead of just the fingerprint")
+	f.StringVar(&c.user, "user", "", "List the keys of this user account instead of the model")
 }
 
 // Run implements Command.Run.
 func (c *listKeysCommand) Run(context *cmd.Context) error {
+	mode := ssh.Fingerprints
+	if c.showFullKey {
+		mode = ssh.FullKeys
+	}
+	if c.user != "" {
+		return c.listUserKeys(context, mode)
+	}
 	client, err := c.NewKeyManagerClient()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	mode := ssh.Fingerprints
-	if c.showFullKey {
-		mode = ssh.FullKeys
-	}
-	// TODO(alexisb) - currently keys are global which is not ideal.
-	// keymanager needs to be updated to allow keys per user
-	c.user = "admin"
-	results, err := client.ListKeys(mode, c.user)
+	// Model keys are not owned by any user.
+	results, err := client.ListKeys(mode, "admin")
 	if err != nil {
 		return err
 	}
@@ -87,3 +94,20 @@ func (c *listKeysCommand) Run(context *cmd.Context) error {
 	fmt.Fprintln(context.Stdout, strings.Join(result.Result, "\n"))
 	return nil
 }
+
+// listUserKeys lists the keys of the user account.
+func (c *listKeysCommand) listUserKeys(context *cmd.Context, mode ssh.ListMode) error {
+	client, err := c.NewUserManagerClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	keys, err := client.SSHKeys(c.user, mode)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(context.Stdout, "Keys of user: %s\n", c.user)
+	fmt.Fprintln(context.Stdout, strings.Join(keys, "\n"))
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/cachedimages"
 	"github.com/juju/juju/cmd/juju/charmcmd"
+	"github.com/juju/juju/cmd/juju/charmrepository"
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/juju/gui"
@@ -245,12 +246,21 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewModelGetConstraintsCommand())
 	r.Register(model.NewModelSetConstraintsCommand())
 	r.Register(newSyncToolsCommand())
+	r.Register(newCreateMirrorCommand())
 	r.Register(newUpgradeJujuCommand(nil))
 	r.Register(application.NewUpgradeCharmCommand())
+	r.Register(application.NewSetUpgradePolicyCommand())
+	r.Register(application.NewUpgradesAvailableCommand())
 
 	// Charm publishing commands.
 	r.Register(newPublishCommand())
 
+	// Manage the controller's charm repository.
+	r.Register(charmrepository.NewPublishCommand())
+	r.Register(charmrepository.NewListCommand())
+	r.Register(charmrepository.NewShowCommand())
+	r.Register(charmrepository.NewPromoteCommand())
+
 	// Charm tool commands.
 	r.Register(newHelpToolCommand())
 	r.Register(charmcmd.NewSuperCommand())
@@ -277,8 +287,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(user.NewListCommand())
 	r.Register(user.NewEnableCommand())
 	r.Register(user.NewDisableCommand())
+	r.Register(user.NewUnlockCommand())
 	r.Register(user.NewLoginCommand())
 	r.Register(user.NewLogoutCommand())
+	r.Register(user.NewAddGroupCommand())
+	r.Register(user.NewRemoveGroupCommand())
+	r.Register(user.NewAddToGroupCommand())
+	r.Register(user.NewRemoveFromGroupCommand())
+	r.Register(user.NewListGroupsCommand())
 
 	// Manage cached images
 	r.Register(cachedimages.NewRemoveCommand())
@@ -313,6 +329,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
+	r.Register(newRemoveControllerMemberCommand())
 
 	// Manage and control services
 	r.Register(application.NewAddUnitCommand())
@@ -347,6 +364,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage subnets
 	r.Register(subnet.NewAddCommand())
 	r.Register(subnet.NewListCommand())
+	r.Register(subnet.NewReserveAddressCommand())
+	r.Register(subnet.NewReleaseAddressCommand())
 	if featureflag.Enabled(feature.PostNetCLIMVP) {
 		r.Register(subnet.NewCreateCommand())
 		r.Register(subnet.NewRemoveCommand())
@@ -363,11 +382,14 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewRemoveBlocksCommand())
 	r.Register(controller.NewShowControllerCommand())
+	r.Register(controller.NewControllerHealthCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewSetSSHJumpHostsCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
 	r.Register(metricsdebug.NewCollectMetricsCommand())
+	r.Register(metricsdebug.NewMetricsCommand())
 	r.Register(setmeterstatus.New())
 
 	// Manage clouds and credentials
@@ -355,6 +355,7 @@ var commandNames = []string{
 	"actions",
 	"add-cloud",
 	"add-credential",
+	"add-group",
 	"add-machine",
 	"add-machines",
 	"add-model",
@@ -364,6 +365,7 @@ var commandNames = []string{
 	"add-ssh-keys",
 	"add-storage",
 	"add-subnet",
+	"add-to-group",
 	"add-unit",
 	"add-units",
 	"add-user",
@@ -381,9 +383,11 @@ var commandNames = []string{
 	"charm",
 	"clouds",
 	"collect-metrics",
+	"controller-health",
 	"controllers",
 	"create-backup",
 	"create-budget",
+	"create-mirror",
 	"create-storage-pool",
 	"credentials",
 	"debug-hooks",
@@ -407,6 +411,7 @@ var commandNames = []string{
 	"get-model-config",
 	"get-model-constraints",
 	"grant",
+	"groups",
 	"gui",
 	"help",
 	"help-tool",
@@ -423,10 +428,12 @@ var commandNames = []string{
 	"list-clouds",
 	"list-controllers",
 	"list-credentials",
+	"list-groups",
 	"list-machine",
 	"list-machines",
 	"list-models",
 	"list-plans",
+	"list-repository-charms",
 	"list-shares",
 	"list-ssh-key",
 	"list-ssh-keys",
@@ -439,23 +446,32 @@ var commandNames = []string{
 	"logout",
 	"machine",
 	"machines",
+	"metrics",
 	"models",
 	"plans",
+	"promote-charm",
 	"publish",
+	"publish-charm",
 	"register",
 	"relate", //alias for add-relation
+	"release-address",
 	"remove-all-blocks",
 	"remove-application", // alias for destroy-application
 	"remove-backup",
 	"remove-cached-images",
 	"remove-cloud",
+	"remove-controller-member",
 	"remove-credential",
+	"remove-from-group",
+	"remove-group",
 	"remove-machine",
 	"remove-machines",
 	"remove-relation", // alias for destroy-relation
 	"remove-ssh-key",
 	"remove-ssh-keys",
 	"remove-unit", // alias for destroy-unit
+	"repository-charms",
+	"reserve-address",
 	"resolved",
 	"restore-backup",
 	"retry-provisioning",
@@ -473,6 +489,8 @@ var commandNames = []string{
 	"set-model-config",
 	"set-model-constraints",
 	"set-plan",
+	"set-ssh-jump-hosts",
+	"set-upgrade-policy",
 	"ssh-key",
 	"ssh-keys",
 	"shares",
@@ -486,6 +504,7 @@ var commandNames = []string{
 	"show-machine",
 	"show-machines",
 	"show-model",
+	"show-repository-charm",
 	"show-status",
 	"show-storage",
 	"show-user",
@@ -500,6 +519,7 @@ var commandNames = []string{
 	"sync-tools",
 	"unblock",
 	"unexpose",
+	"unlock-user",
 	"update-allocation",
 	"upload-backup",
 	"unregister",
@@ -508,6 +528,7 @@ var commandNames = []string{
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
+	"upgrades-available",
 	"users",
 	"version",
 }
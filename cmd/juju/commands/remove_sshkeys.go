@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/juju/cmd"
+	"launchpad.net/gnuflag"
 
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -28,6 +29,11 @@ Examples:
     juju remove-ssh-key 45:7f:33:2c:10:4e:6c:14:e3:a1:a4:c8:b2:e1:34:b4
     juju remove-ssh-key bob@ubuntu carol@ubuntu
 
+Keys may instead be removed from a user account with the '--user' option,
+which removes them from the units of every model the user has access to.
+
+    juju remove-ssh-key --user bob bob@ubuntu
+
 See also: 
     ssh-keys
     add-ssh-key
@@ -56,6 +62,11 @@ func (c *removeKeysCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *removeKeysCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.user, "user", "", "Remove the keys from this user account instead of the model")
+}
+
 // Init implements Command.Init.
 func (c *removeKeysCommand) Init(args []string) error {
 	switch len(args) {
@@ -69,16 +80,17 @@ func (c *removeKeysCommand) Init(args []string) error {
 
 // Run implements Command.Run.
 func (c *removeKeysCommand) Run(context *cmd.Context) error {
+	if c.user != "" {
+		return c.removeUserKeys()
+	}
 	client, err := c.NewKeyManagerClient()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	// TODO(alexisb) - currently keys are global which is not ideal.
-	// keymanager needs to be updated to allow keys per user
-	c.user = "admin"
-	results, err := client.DeleteKeys(c.user, c.keyIds...)
+	// Model keys are not owned by any user.
+	results, err := client.DeleteKeys("admin", c.keyIds...)
 	if err != nil {
 		return block.ProcessBlockedError(err, block.BlockChange)
 	}
@@ -89,3 +101,14 @@ func (c *removeKeysCommand) Run(context *cmd.Context) error {
 	}
 	return nil
 }
+
+// removeUserKeys removes the keys from the user account.
+func (c *removeKeysCommand) removeUserKeys() error {
+	client, err := c.NewUserManagerClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	err = client.RemoveSSHKeys(c.user, c.keyIds...)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,175 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+func newRemoveControllerMemberCommand() cmd.Command {
+	command := &removeControllerMemberCommand{}
+	command.newClientFunc = func() (RemoveControllerMemberClient, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get API connection")
+		}
+		return highavailability.NewClient(root), nil
+	}
+	return modelcmd.Wrap(command)
+}
+
+// removeControllerMemberCommand removes a lost controller machine
+// from the controller.
+type removeControllerMemberCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	// newClientFunc returns the client to be used by the command.
+	newClientFunc func() (RemoveControllerMemberClient, error)
+
+	// MachineId identifies the controller machine to remove.
+	MachineId string
+	// Force allows a controller machine that is still running
+	// to be removed.
+	Force bool
+	// Replace asks for a replacement controller machine.
+	Replace bool
+	// Placement, if set, is where the replacement is placed: an
+	// existing machine, or a provider placement directive such as
+	// a zone.
+	Placement string
+	// Series and Constraints are used for a new replacement machine.
+	// They default to those of the removed machine.
+	Series      string
+	Constraints constraints.Value
+}
+
+const removeControllerMemberDoc = `
+Removes a controller machine that has been lost for good from the
+controller.
+
+The machine is removed from the mongo replica set, and its API
+addresses are no longer handed out to agents and clients. If the
+machine has a vote in the replica set, the vote is taken away first,
+so the removal completes shortly after the command returns.
+
+A controller machine whose agent is still running is not removed
+unless --force is used. The last voting controller machine cannot be
+removed.
+
+With --replace, or --to, a replacement controller machine is
+provided. --to names either an existing machine, which becomes a
+controller, or a placement directive such as a zone for a new
+machine. New machines use the series and constraints of the removed
+machine, unless --series or --constraints are given.
+
+Examples:
+    # Remove the lost controller machine 2.
+    juju remove-controller-member 2
+
+    # Remove machine 2, and replace it with a new machine in zone us-east-1c.
+    juju remove-controller-member 2 --to zone=us-east-1c
+
+    # Remove machine 2, and make existing machine 5 a controller instead.
+    juju remove-controller-member 2 --to 5
+
+See also:
+    enable-ha
+`
+
+func (c *removeControllerMemberCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-controller-member",
+		Args:    "<machine>",
+		Purpose: "Remove a lost controller machine, optionally replacing it.",
+		Doc:     removeControllerMemberDoc,
+	}
+}
+
+func (c *removeControllerMemberCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.BoolVar(&c.Force, "force", false, "Remove the controller machine even if its agent is running")
+	f.BoolVar(&c.Replace, "replace", false, "Provide a replacement controller machine")
+	f.StringVar(&c.Placement, "to", "", "The machine or placement directive for the replacement")
+	f.StringVar(&c.Series, "series", "", "The series of a new replacement machine")
+	f.Var(constraints.ConstraintsValue{&c.Constraints}, "constraints", "Machine constraints for a new replacement machine")
+	c.out.AddFlags(f, "simple", map[string]cmd.Formatter{
+		"yaml":   cmd.FormatYaml,
+		"json":   cmd.FormatJson,
+		"simple": formatSimple,
+	})
+}
+
+func (c *removeControllerMemberCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine specified")
+	}
+	c.MachineId = args[0]
+	if !names.IsValidMachine(c.MachineId) {
+		return errors.Errorf("invalid machine %q", c.MachineId)
+	}
+	if names.IsContainerMachine(c.MachineId) {
+		return errors.Errorf("machine %q is a container, not a controller", c.MachineId)
+	}
+	if c.Placement != "" {
+		p, err := instance.ParsePlacement(c.Placement)
+		if err == nil && p.Scope == instance.MachineScope {
+			if names.IsContainerMachine(p.Directive) {
+				return errors.New("remove-controller-member cannot be used with container placement directives")
+			}
+			c.Placement = p.String()
+		} else if err != instance.ErrPlacementScopeMissing {
+			return errors.Errorf("unsupported remove-controller-member placement directive %q", c.Placement)
+		}
+		c.Replace = true
+	}
+	if !c.Replace && (c.Series != "" || !constraints.IsEmpty(&c.Constraints)) {
+		return errors.New("--series and --constraints can only be used with a replacement")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// RemoveControllerMemberClient defines the methods on the client API
+// that the remove-controller-member command calls.
+type RemoveControllerMemberClient interface {
+	Close() error
+	RemoveControllerMember(params.RemoveControllerMember) (params.ControllersChanges, error)
+}
+
+// Run connects to the controller and removes the controller machine.
+func (c *removeControllerMemberCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newClientFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	changes, err := client.RemoveControllerMember(params.RemoveControllerMember{
+		MachineTag:  names.NewMachineTag(c.MachineId).String(),
+		Force:       c.Force,
+		Replace:     c.Replace,
+		Placement:   c.Placement,
+		Constraints: c.Constraints,
+		Series:      c.Series,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	result := availabilityInfo{
+		Added:     machineTagsToIds(changes.Added...),
+		Removed:   machineTagsToIds(changes.Removed...),
+		Demoted:   machineTagsToIds(changes.Demoted...),
+		Converted: machineTagsToIds(changes.Converted...),
+	}
+	return c.out.Write(ctx, result)
+}
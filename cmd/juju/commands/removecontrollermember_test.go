@@ -0,0 +1,152 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/juju/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RemoveControllerMemberSuite struct {
+	testing.JujuConnSuite
+	fake *fakeRemoveControllerMemberClient
+}
+
+var _ = gc.Suite(&RemoveControllerMemberSuite{})
+
+func (s *RemoveControllerMemberSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.fake = &fakeRemoveControllerMemberClient{}
+}
+
+type fakeRemoveControllerMemberClient struct {
+	member *params.RemoveControllerMember
+	result params.ControllersChanges
+	err    error
+}
+
+func (f *fakeRemoveControllerMemberClient) Close() error {
+	return nil
+}
+
+func (f *fakeRemoveControllerMemberClient) RemoveControllerMember(member params.RemoveControllerMember) (params.ControllersChanges, error) {
+	f.member = &member
+	return f.result, f.err
+}
+
+func (s *RemoveControllerMemberSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &removeControllerMemberCommand{
+		newClientFunc: func() (RemoveControllerMemberClient, error) { return s.fake, nil },
+	}
+	return coretesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *RemoveControllerMemberSuite) TestRemove(c *gc.C) {
+	s.fake.result = params.ControllersChanges{Demoted: []string{"machine-2"}}
+	ctx, err := s.run(c, "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "demoting machines: 2\n\n")
+	c.Assert(s.fake.member, jc.DeepEquals, &params.RemoveControllerMember{
+		MachineTag: "machine-2",
+	})
+}
+
+func (s *RemoveControllerMemberSuite) TestRemoveForce(c *gc.C) {
+	_, err := s.run(c, "2", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.member.Force, jc.IsTrue)
+	c.Assert(s.fake.member.Replace, jc.IsFalse)
+}
+
+func (s *RemoveControllerMemberSuite) TestReplace(c *gc.C) {
+	s.fake.result = params.ControllersChanges{
+		Demoted: []string{"machine-2"},
+		Added:   []string{"machine-3"},
+	}
+	ctx, err := s.run(c, "2", "--replace", "--series", "xenial", "--constraints", "mem=4G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals,
+		"adding machines: 3\n"+
+			"demoting machines: 2\n\n")
+	c.Assert(s.fake.member, jc.DeepEquals, &params.RemoveControllerMember{
+		MachineTag:  "machine-2",
+		Replace:     true,
+		Series:      "xenial",
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+}
+
+func (s *RemoveControllerMemberSuite) TestReplaceInZone(c *gc.C) {
+	_, err := s.run(c, "2", "--to", "zone=us-east-1c")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.member, jc.DeepEquals, &params.RemoveControllerMember{
+		MachineTag: "machine-2",
+		Replace:    true,
+		Placement:  "zone=us-east-1c",
+	})
+}
+
+func (s *RemoveControllerMemberSuite) TestReplaceOnMachine(c *gc.C) {
+	s.fake.result = params.ControllersChanges{
+		Demoted:   []string{"machine-2"},
+		Converted: []string{"machine-5"},
+	}
+	ctx, err := s.run(c, "2", "--to", "5", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, "demoted: [\"2\"]\nconverted: [\"5\"]\n")
+	c.Assert(s.fake.member.Placement, gc.Equals, "#:5")
+	c.Assert(s.fake.member.Replace, jc.IsTrue)
+}
+
+func (s *RemoveControllerMemberSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args   []string
+		expect string
+	}{{
+		args:   nil,
+		expect: "no machine specified",
+	}, {
+		args:   []string{"foo"},
+		expect: `invalid machine "foo"`,
+	}, {
+		args:   []string{"0/lxd/1"},
+		expect: `machine "0/lxd/1" is a container, not a controller`,
+	}, {
+		args:   []string{"2", "3"},
+		expect: `unrecognized args: \["3"\]`,
+	}, {
+		args:   []string{"2", "--to", "0/lxd/1"},
+		expect: "remove-controller-member cannot be used with container placement directives",
+	}, {
+		args:   []string{"2", "--to", "lxd:1"},
+		expect: `unsupported remove-controller-member placement directive "lxd:1"`,
+	}, {
+		args:   []string{"2", "--series", "xenial"},
+		expect: "--series and --constraints can only be used with a replacement",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(s.fake.member, gc.IsNil)
+	}
+}
+
+func (s *RemoveControllerMemberSuite) TestBlocked(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockRemoveControllerMember")
+	_, err := s.run(c, "2")
+	c.Assert(err, gc.ErrorMatches, cmd.ErrSilent.Error())
+
+	stripped := strings.Replace(c.GetTestLog(), "\n", "", -1)
+	c.Check(stripped, gc.Matches, ".*TestBlockRemoveControllerMember.*")
+}
@@ -64,6 +64,10 @@ the unit.
 in the model.  If you specify --all you cannot provide additional
 targets.
 
+Commands are sent to the targets by the controller and run by their
+agents, not over SSH, so any SSH jump hosts configured for the controller
+(see "juju set-ssh-jump-hosts") are not used.
+
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 `
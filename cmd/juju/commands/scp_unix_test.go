@@ -12,6 +12,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -195,3 +196,21 @@ func (s *SCPSuite) TestSCPCommand(c *gc.C) {
 		}
 	}
 }
+
+func (s *SCPSuite) TestSCPCommandJumpHosts(c *gc.C) {
+	s.setupModel(c)
+	s.setJumpHosts(c, jujuclient.SSHJumpHost{Host: "bastion.example.com", User: "admin"})
+
+	ctx, err := coretesting.RunCommand(c, newSCPCommand(), "0:foo", ".")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	actual, err := ioutil.ReadFile(filepath.Join(s.binDir, "scp.args"))
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		jumpProxy:       `ssh -W %h:%p admin@bastion\.example\.com`,
+		args:            "ubuntu@0.private:foo .",
+	}
+	expectedArgs.check(c, string(actual))
+}
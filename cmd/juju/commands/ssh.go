@@ -29,6 +29,13 @@ The SSH host keys of the target are verified. The --no-host-key-checks option
 can be used to disable these checks. Use of this option is not recommended as
 it opens up the possibility of a man-in-the-middle attack.
 
+When SSH jump hosts are configured for the controller (see
+"juju set-ssh-jump-hosts"), the connection is made through each of them
+in turn. The host keys of jump hosts which are Juju machines are verified
+like those of the target; external jump hosts are verified using the host
+keys stored with them or, if there are none, using your own known_hosts
+file.
+
 Examples:
 Connect to machine 0:
 
@@ -47,7 +54,8 @@ Connect to a jenkins unit as user jenkins:
     juju ssh jenkins@jenkins/0
 
 See also: 
-    scp`
+    scp
+    set-ssh-jump-hosts`
 
 func newSSHCommand() cmd.Command {
 	return modelcmd.Wrap(&sshCommand{})
@@ -37,6 +37,7 @@ type SSHCommon struct {
 	apiClient       sshAPIClient
 	apiAddr         string
 	knownHostsPath  string
+	jumpHosts       []*resolvedTarget
 }
 
 type sshAPIClient interface {
@@ -51,6 +52,11 @@ type resolvedTarget struct {
 	user   string
 	entity string
 	host   string
+
+	// hostKeys holds the known SSH host keys of a jump host, if any:
+	// either those stored with the controller details for an external
+	// host, or those of the Juju machine or unit.
+	hostKeys []string
 }
 
 func (t *resolvedTarget) userHost() string {
@@ -94,8 +100,8 @@ func (c *SSHCommon) SetFlags(f *gnuflag.FlagSet) {
 // if SSH proxying is required. It must be called at the top of the
 // command's Run method.
 //
-// The apiClient, apiAddr and proxy fields are initialized after this
-// call.
+// The apiClient, apiAddr, proxy and jumpHosts fields are initialized
+// after this call.
 func (c *SSHCommon) initRun() error {
 	if err := c.ensureAPIClient(); err != nil {
 		return errors.Trace(err)
@@ -105,6 +111,40 @@ func (c *SSHCommon) initRun() error {
 	} else {
 		c.proxy = proxy
 	}
+	if err := c.resolveJumpHosts(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// resolveJumpHosts resolves the SSH jump hosts configured for the current
+// controller, if any. Jump hosts which are Juju machines or units are
+// resolved like any other target: the first one by its public address,
+// and any later ones by their private address, as they are reached
+// through the previous hop.
+func (c *SSHCommon) resolveJumpHosts() error {
+	controllerName := c.ControllerName()
+	details, err := c.ClientStore().ControllerByName(controllerName)
+	if err != nil {
+		return errors.Annotatef(err, "getting details of controller %q", controllerName)
+	}
+	c.jumpHosts = nil
+	if len(details.SSHJumpHosts) > 0 && c.proxy {
+		logger.Warningf("proxying through the controller is ignored when SSH jump hosts are configured")
+		c.proxy = false
+	}
+	for _, jumpHost := range details.SSHJumpHosts {
+		userHost := jumpHost.Host
+		if jumpHost.User != "" {
+			userHost = jumpHost.User + "@" + userHost
+		}
+		hop, err := c.resolveTarget(userHost)
+		if err != nil {
+			return errors.Annotatef(err, "resolving SSH jump host %q", jumpHost.Host)
+		}
+		hop.hostKeys = jumpHost.HostKeys
+		c.jumpHosts = append(c.jumpHosts, hop)
+	}
 	return nil
 }
 
@@ -157,7 +197,9 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 		options.EnablePTY()
 	}
 
-	if c.proxy {
+	if len(c.jumpHosts) > 0 {
+		options.SetProxyCommand(jumpHostsProxyCommand(c.jumpHosts, c.hopSSHOptions)...)
+	} else if c.proxy {
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
@@ -166,9 +208,51 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 	return &options, nil
 }
 
+// hopSSHOptions returns the host key checking options used when
+// connecting to the given SSH jump host. Jump hosts with known host
+// keys are checked against the generated known_hosts file; others are
+// checked using the user's personal known_hosts file and preferences.
+func (c *SSHCommon) hopSSHOptions(hop *resolvedTarget) []string {
+	switch {
+	case c.noHostKeyChecks:
+		return []string{
+			"-o", "StrictHostKeyChecking no",
+			"-o", "UserKnownHostsFile /dev/null",
+		}
+	case c.knownHostsPath != "" && len(hop.hostKeys) > 0:
+		return []string{
+			"-o", "StrictHostKeyChecking yes",
+			"-o", "UserKnownHostsFile " + c.knownHostsPath,
+		}
+	}
+	return nil
+}
+
+// jumpHostsProxyCommand returns the ProxyCommand used to reach the final
+// SSH target (%h:%p) through the given chain of jump hosts. Each hop is
+// reached through the ProxyCommand of the previous one, using "ssh -W"
+// with the options returned by hopOptions for that hop.
+func jumpHostsProxyCommand(hops []*resolvedTarget, hopOptions func(*resolvedTarget) []string) []string {
+	var proxyCommand []string
+	for i, hop := range hops {
+		forward := "%h:%p"
+		if i < len(hops)-1 {
+			forward = net.JoinHostPort(hops[i+1].host, "22")
+		}
+		args := append([]string{"ssh"}, hopOptions(hop)...)
+		if proxyCommand != nil {
+			args = append(args, "-o", "ProxyCommand "+utils.CommandString(proxyCommand...))
+		}
+		proxyCommand = append(args, "-W", forward, hop.userHost())
+	}
+	return proxyCommand
+}
+
 // generateKnownHosts takes the provided targets, retrieves the SSH
-// public host keys for them and generates a temporary known_hosts
-// file for them.
+// public host keys for them and for any jump hosts, and generates a
+// temporary known_hosts file for them. The path of the file is only
+// returned if it holds the keys of the targets; it is recorded in
+// c.knownHostsPath regardless, for use by the jump hosts.
 func (c *SSHCommon) generateKnownHosts(targets []*resolvedTarget) (string, error) {
 	knownHosts := newKnownHostsBuilder()
 	agentCount := 0
@@ -189,6 +273,21 @@ func (c *SSHCommon) generateKnownHosts(targets []*resolvedTarget) (string, error
 	if agentCount > 0 && nonAgentCount > 0 {
 		return "", errors.New("can't determine host keys for all targets: consider --no-host-key-checks")
 	}
+	haveTargetKeys := knownHosts.size() > 0
+
+	// Jump hosts are checked separately from the targets, so an
+	// external jump host without stored keys doesn't prevent the keys
+	// of the targets from being checked.
+	for _, hop := range c.jumpHosts {
+		if hop.isAgent() {
+			keys, err := c.apiClient.PublicKeys(hop.entity)
+			if err != nil {
+				return "", errors.Annotatef(err, "retrieving SSH host keys for %q", hop.entity)
+			}
+			hop.hostKeys = keys
+		}
+		knownHosts.add(hop.host, hop.hostKeys)
+	}
 
 	if knownHosts.size() == 0 {
 		// No public keys to write so exit early.
@@ -204,6 +303,9 @@ func (c *SSHCommon) generateKnownHosts(targets []*resolvedTarget) (string, error
 	if knownHosts.write(f); err != nil {
 		return "", errors.Trace(err)
 	}
+	if !haveTargetKeys {
+		return "", nil
+	}
 	return c.knownHostsPath, nil
 }
 
@@ -290,7 +392,7 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	// a loop.
 	var err error
 	for a := sshHostFromTargetAttemptStrategy.Start(); a.Next(); {
-		if c.proxy {
+		if c.proxy || len(c.jumpHosts) > 0 {
 			out.host, err = c.apiClient.PrivateAddress(out.entity)
 		} else {
 			out.host, err = c.apiClient.PublicAddress(out.entity)
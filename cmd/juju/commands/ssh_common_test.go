@@ -15,6 +15,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -30,13 +31,17 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// jumpProxy is a pattern matching the expected ProxyCommand
+	// option used to connect through SSH jump hosts, if any.
+	jumpProxy string
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
 
 	// knownHosts may either be:
 	// a comma separated list of machine ids - the host keys for these
-	//    machines are expected in the UserKnownHostsFile
+	//    machines (or jump hosts) are expected in the UserKnownHostsFile
 	// "null" - the UserKnownHostsFile must be "/dev/null"
 	// empty - no UserKnownHostsFile option expected
 	knownHosts string
@@ -69,6 +74,9 @@ func (s *argsSpec) check(c *gc.C, output string) {
 		expect("-o ProxyCommand juju ssh --proxy=false --no-host-key-checks " +
 			"--pty=false ubuntu@localhost -q \"nc %h %p\"")
 	}
+	if s.jumpProxy != "" {
+		expect("-o ProxyCommand " + s.jumpProxy)
+	}
 	expect("-o PasswordAuthentication no -o ServerAliveInterval 30")
 	if s.enablePty {
 		expect("-t -t")
@@ -193,9 +201,55 @@ func (s *SSHCommonSuite) setAddresses6(c *gc.C, m *state.Machine) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SSHCommonSuite) setJumpHosts(c *gc.C, jumpHosts ...jujuclient.SSHJumpHost) {
+	details, err := s.ControllerStore.ControllerByName(testing.ControllerName)
+	c.Assert(err, jc.ErrorIsNil)
+	details.SSHJumpHosts = jumpHosts
+	err = s.ControllerStore.UpdateController(testing.ControllerName, *details)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *SSHCommonSuite) setKeys(c *gc.C, m *state.Machine) {
 	id := m.Id()
 	keys := state.SSHHostKeys{"dsa-" + id, "rsa-" + id}
 	err := s.State.SetSSHHostKeys(m.MachineTag(), keys)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+type jumpHostsSuite struct{}
+
+var _ = gc.Suite(&jumpHostsSuite{})
+
+func (s *jumpHostsSuite) TestJumpHostsProxyCommandSingleHop(c *gc.C) {
+	hops := []*resolvedTarget{{user: "admin", host: "bastion.example.com"}}
+	options := func(*resolvedTarget) []string {
+		return []string{"-o", "StrictHostKeyChecking yes"}
+	}
+
+	c.Assert(jumpHostsProxyCommand(hops, options), jc.DeepEquals, []string{
+		"ssh", "-o", "StrictHostKeyChecking yes",
+		"-W", "%h:%p", "admin@bastion.example.com",
+	})
+}
+
+func (s *jumpHostsSuite) TestJumpHostsProxyCommandChain(c *gc.C) {
+	hops := []*resolvedTarget{
+		{user: "admin", host: "bastion.example.com"},
+		{user: "ubuntu", entity: "0", host: "2001:db8::1"},
+	}
+
+	// Only the Juju machine has known host keys here.
+	options := func(hop *resolvedTarget) []string {
+		if hop.isAgent() {
+			return []string{"-o", "StrictHostKeyChecking yes"}
+		}
+		return nil
+	}
+
+	command := jumpHostsProxyCommand(hops, options)
+	c.Assert(command, gc.HasLen, 8)
+	c.Check(command[:3], jc.DeepEquals, []string{"ssh", "-o", "StrictHostKeyChecking yes"})
+	c.Check(command[3], gc.Equals, "-o")
+	c.Check(command[4], gc.Matches, `ProxyCommand ssh -W .*\[2001:db8::1\]:22.* admin@bastion.example.com`)
+	c.Check(command[5:], jc.DeepEquals, []string{"-W", "%h:%p", "ubuntu@2001:db8::1"})
+}
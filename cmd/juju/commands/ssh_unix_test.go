@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/jujuclient"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -167,6 +168,98 @@ func (s *SSHSuite) TestSSHCommandModelConfigProxySSH(c *gc.C) {
 	expectedArgs.check(c, coretesting.Stdout(ctx))
 }
 
+func (s *SSHSuite) TestSSHCommandJumpHosts(c *gc.C) {
+	s.setupModel(c)
+	s.setJumpHosts(c, jujuclient.SSHJumpHost{
+		Host:     "bastion.example.com",
+		User:     "admin",
+		HostKeys: []string{"dsa-bastion", "rsa-bastion"},
+	}, jujuclient.SSHJumpHost{
+		Host: "2",
+	})
+
+	// Machine 2 is reached through the bastion, and the target through
+	// machine 2, so both are resolved by their private addresses.
+	ctx, err := coretesting.RunCommand(c, newSSHCommand(), "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0,bastion,2",
+		enablePty:       true,
+		jumpProxy: `ssh -o "StrictHostKeyChecking yes" -o "UserKnownHostsFile \S+" ` +
+			`-o "ProxyCommand ssh .*-W \[fc00:bbb::1\]:22 admin@bastion\.example\.com" ` +
+			`-W %h:%p ubuntu@fc00:bbb::1`,
+		args: "ubuntu@0.private",
+	}
+	expectedArgs.check(c, coretesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestSSHCommandJumpHostWithoutKeys(c *gc.C) {
+	s.setupModel(c)
+	s.setJumpHosts(c, jujuclient.SSHJumpHost{Host: "bastion.example.com"})
+
+	// The keys of the target are still checked, while the jump host is
+	// checked against the user's own known_hosts file.
+	ctx, err := coretesting.RunCommand(c, newSSHCommand(), "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		enablePty:       true,
+		jumpProxy:       `ssh -W %h:%p bastion\.example\.com`,
+		args:            "ubuntu@0.private",
+	}
+	expectedArgs.check(c, coretesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestSSHCommandJumpHostsNoHostKeyChecks(c *gc.C) {
+	s.setupModel(c)
+	s.setJumpHosts(c, jujuclient.SSHJumpHost{Host: "bastion.example.com"})
+
+	ctx, err := coretesting.RunCommand(c, newSSHCommand(), "--no-host-key-checks", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "no",
+		knownHosts:      "null",
+		enablePty:       true,
+		jumpProxy: `ssh -o "StrictHostKeyChecking no" -o "UserKnownHostsFile /dev/null" ` +
+			`-W %h:%p bastion\.example\.com`,
+		args: "ubuntu@0.private",
+	}
+	expectedArgs.check(c, coretesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestSSHCommandJumpHostsIgnoreProxy(c *gc.C) {
+	s.setupModel(c)
+	s.setJumpHosts(c, jujuclient.SSHJumpHost{Host: "2"})
+
+	// The first jump host is reached directly, by its public address.
+	ctx, err := coretesting.RunCommand(c, newSSHCommand(), "--proxy=true", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(coretesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0,2",
+		enablePty:       true,
+		jumpProxy: `ssh -o "StrictHostKeyChecking yes" -o "UserKnownHostsFile \S+" ` +
+			`-W %h:%p ubuntu@2001:db8::1`,
+		args: "ubuntu@0.private",
+	}
+	expectedArgs.check(c, coretesting.Stdout(ctx))
+}
+
+func (s *SSHSuite) TestSSHCommandJumpHostNotFound(c *gc.C) {
+	s.setupModel(c)
+	s.setJumpHosts(c, jujuclient.SSHJumpHost{Host: "99"})
+	s.PatchValue(&sshHostFromTargetAttemptStrategy, attemptStarter(attemptStrategy{}))
+
+	_, err := coretesting.RunCommand(c, newSSHCommand(), "0")
+	c.Assert(err, gc.ErrorMatches, `resolving SSH jump host "99": .*`)
+}
+
 func (s *SSHSuite) TestSSHWillWorkInUpgrade(c *gc.C) {
 	// Check the API client interface used by "juju ssh" against what
 	// the API server will allow during upgrades. Ensure that the API
@@ -5,6 +5,7 @@ package commands
 
 import (
 	"github.com/juju/juju/api/keymanager"
+	"github.com/juju/juju/api/usermanager"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -21,3 +22,14 @@ func (c *SSHKeysBase) NewKeyManagerClient() (*keymanager.Client, error) {
 	}
 	return keymanager.NewClient(root), nil
 }
+
+// NewUserManagerClient returns a usermanager client for the root api
+// endpoint that the environment command returns. It is used to manage
+// the keys of user accounts rather than those of the model.
+func (c *SSHKeysBase) NewUserManagerClient() (*usermanager.Client, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return usermanager.NewClient(root), nil
+}
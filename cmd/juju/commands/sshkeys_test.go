@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/juju/osenv"
 	jujutesting "github.com/juju/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type SSHKeysSuite struct {
@@ -114,6 +115,17 @@ func (s *ListKeysSuite) TestListFullKeys(c *gc.C) {
 	c.Assert(output, gc.Matches, "Keys used in model: controller\n.*user@host\n.*another@host")
 }
 
+func (s *ListKeysSuite) TestListUserKeys(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := user.AddSSHKeys([]string{sshtesting.ValidKeyOne.Key + " bob@host"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	context, err := coretesting.RunCommand(c, NewListKeysCommand(), "--user", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	output := strings.TrimSpace(coretesting.Stdout(context))
+	c.Assert(output, gc.Matches, "Keys of user: bob\n.*\\(bob@host\\)")
+}
+
 func (s *ListKeysSuite) TestTooManyArgs(c *gc.C) {
 	_, err := coretesting.RunCommand(c, NewListKeysCommand(), "foo")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
@@ -136,6 +148,20 @@ func (s *AddKeySuite) TestAddKey(c *gc.C) {
 	s.assertEnvironKeys(c, key1, key2)
 }
 
+func (s *AddKeySuite) TestAddUserKey(c *gc.C) {
+	key1 := sshtesting.ValidKeyOne.Key + " user@host"
+	s.setAuthorizedKeys(c, key1)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+
+	key2 := sshtesting.ValidKeyTwo.Key + " bob@host"
+	_, err := coretesting.RunCommand(c, NewAddKeysCommand(), "--user", "bob", key2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironKeys(c, key1)
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SSHKeys(), jc.DeepEquals, []string{key2})
+}
+
 func (s *AddKeySuite) TestBlockAddKey(c *gc.C) {
 	key1 := sshtesting.ValidKeyOne.Key + " user@host"
 	s.setAuthorizedKeys(c, key1)
@@ -165,6 +191,18 @@ func (s *RemoveKeySuite) TestRemoveKeys(c *gc.C) {
 	s.assertEnvironKeys(c, key1)
 }
 
+func (s *RemoveKeySuite) TestRemoveUserKeys(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := user.AddSSHKeys([]string{sshtesting.ValidKeyOne.Key + " bob@host"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = coretesting.RunCommand(c, NewRemoveKeysCommand(), "--user", "bob", "bob@host")
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SSHKeys(), gc.HasLen, 0)
+}
+
 func (s *RemoveKeySuite) TestBlockRemoveKeys(c *gc.C) {
 	key1 := sshtesting.ValidKeyOne.Key + " user@host"
 	key2 := sshtesting.ValidKeyTwo.Key + " another@host"
@@ -11,18 +11,24 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/sync"
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/network"
 	coretools "github.com/juju/juju/tools"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -51,10 +57,29 @@ controllers in a high availability model failed to upgrade).
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
 Backups are recommended prior to upgrading.
+With '--dry-run', the controller also checks that the upgrade can be
+expected to complete: that mongo can be upgraded, that there is enough
+free disk space on every controller machine, and that every agent in
+the model is running. The upgrade steps that would run are listed when
+the controller is already running the chosen version. Nothing is
+changed.
+The controller takes a snapshot of its database before running its
+upgrade steps. If those steps fail, '--abort' rolls the controller back
+to the snapshot and returns the model to its previous agent version.
+This is only possible when there is a single controller.
+Once the controllers are upgraded, the other machines in the model are
+upgraded together, unless '--canary' or '--batch-size' is given. The
+canary machines are then upgraded first, followed by the remaining
+machines in batches of '--batch-size' machines. Each batch starts once
+every machine in the previous batches is running the new version and
+the time given by '--pause' has passed. Containers are upgraded with
+the machine that hosts them.
 
 Examples:
     juju upgrade-juju --dry-run
     juju upgrade-juju --version 2.0.1
+    juju upgrade-juju --canary 3,4 --batch-size 10 --pause 30m
+    juju upgrade-juju --abort
     
 See also: 
     sync-tools`
@@ -75,6 +100,10 @@ type upgradeJujuCommand struct {
 	DryRun        bool
 	ResetPrevious bool
 	AssumeYes     bool
+	Abort         bool
+	Canaries      []string
+	BatchSize     int
+	Pause         time.Duration
 
 	// minMajorUpgradeVersion maps known major numbers to
 	// the minimum version that can be upgraded to that
@@ -98,9 +127,30 @@ func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.ResetPrevious, "reset-previous-upgrade", false, "Clear the previous (incomplete) upgrade status (use with care)")
 	f.BoolVar(&c.AssumeYes, "y", false, "Answer 'yes' to confirmation prompts")
 	f.BoolVar(&c.AssumeYes, "yes", false, "")
+	f.BoolVar(&c.Abort, "abort", false, "Roll back an upgrade whose controller upgrade steps failed")
+	f.Var(cmd.NewStringsValue(nil, &c.Canaries), "canary", "Machines to upgrade before the others, as a comma-separated list")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Number of machines to upgrade together after the canaries")
+	f.DurationVar(&c.Pause, "pause", 0, "How long to wait after a batch has upgraded before upgrading the next")
 }
 
 func (c *upgradeJujuCommand) Init(args []string) error {
+	if c.Abort && (c.vers != "" || c.UploadTools || c.DryRun || c.ResetPrevious || c.planned()) {
+		return errors.New("--abort cannot be used with other upgrade options")
+	}
+	for _, id := range c.Canaries {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid canary machine id %q", id)
+		}
+	}
+	if c.BatchSize < 0 {
+		return errors.New("--batch-size must not be negative")
+	}
+	if c.Pause < 0 {
+		return errors.New("--pause must not be negative")
+	}
+	if c.Pause > 0 && !c.planned() {
+		return errors.New("--pause requires --canary or --batch-size")
+	}
 	if c.vers != "" {
 		vers, err := version.Parse(c.vers)
 		if err != nil {
@@ -120,6 +170,11 @@ func (c *upgradeJujuCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// planned returns whether the upgrade is rolled out in batches.
+func (c *upgradeJujuCommand) planned() bool {
+	return len(c.Canaries) > 0 || c.BatchSize > 0
+}
+
 var (
 	errUpToDate            = stderrors.New("no upgrades available")
 	downgradeErrMsg        = "cannot change version from %s to %s"
@@ -168,6 +223,10 @@ type upgradeJujuAPI interface {
 	FindTools(majorVersion, minorVersion int, series, arch string) (result params.FindToolsResult, err error)
 	UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error)
 	AbortCurrentUpgrade() error
+	RollbackCurrentUpgrade() error
+	SetUpgradePlan(version version.Number, canaries []string, batchSize int, pause time.Duration) error
+	RemoveUpgradePlan() error
+	CheckUpgrade(version version.Number) (params.UpgradeCheckResult, error)
 	SetModelAgentVersion(version version.Number) error
 	Close() error
 }
@@ -184,6 +243,13 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 		return err
 	}
 	defer client.Close()
+	if c.Abort {
+		if err := client.RollbackCurrentUpgrade(); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("rolling back upgrade; the controller will return to the previous agent version")
+		return nil
+	}
 	defer func() {
 		if err == errUpToDate {
 			ctx.Infof(err.Error())
@@ -295,6 +361,9 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 		logger.Infof("version %s incompatible with this client (%s)", context.chosen, jujuversion.Current)
 	}
 	if c.DryRun {
+		if err := c.checkUpgrade(ctx, client, context.chosen); err != nil {
+			return err
+		}
 		ctx.Infof("upgrade to this version by running\n    juju upgrade-juju --version=\"%s\"\n", context.chosen)
 	} else {
 		if c.ResetPrevious {
@@ -309,7 +378,18 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 				return block.ProcessBlockedError(err, block.BlockChange)
 			}
 		}
+		if c.planned() {
+			err := client.SetUpgradePlan(context.chosen, c.Canaries, c.BatchSize, c.Pause)
+			if err != nil {
+				return block.ProcessBlockedError(err, block.BlockChange)
+			}
+		}
 		if err := client.SetModelAgentVersion(context.chosen); err != nil {
+			if c.planned() {
+				if err := client.RemoveUpgradePlan(); err != nil {
+					logger.Errorf("cannot remove upgrade plan: %v", err)
+				}
+			}
 			if params.IsCodeUpgradeInProgress(err) {
 				return errors.Errorf("%s\n\n"+
 					"Please wait for the upgrade to complete or if there was a problem with\n"+
@@ -325,6 +405,128 @@ func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// upgradeChecker is the API used to check an upgrade against one
+// controller.
+type upgradeChecker interface {
+	CheckUpgrade(version version.Number) (params.UpgradeCheckResult, error)
+	Close() error
+}
+
+// getOtherControllerCheckers returns an upgradeChecker for each of the
+// controller's API servers other than the one the command connects to,
+// so that the checks local to a controller machine are made on every
+// machine of an HA controller. API servers that cannot be reached are
+// reported as problems rather than errors, so that the other checks
+// are still made.
+var getOtherControllerCheckers = func(c *upgradeJujuCommand) ([]upgradeChecker, []string, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer root.Close()
+
+	var checkers []upgradeChecker
+	var problems []string
+	for _, hostPorts := range root.APIHostPorts() {
+		addrs := network.HostPortsToStrings(hostPorts)
+		if set.NewStrings(addrs...).Contains(root.Addr()) {
+			continue
+		}
+		conn, err := c.newAPIRootAt(addrs)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("cannot connect to controller at %s: %v", strings.Join(addrs, ", "), err))
+			continue
+		}
+		checkers = append(checkers, conn.Client())
+	}
+	return checkers, problems, nil
+}
+
+// newAPIRootAt returns a connection to the command's model through the
+// API server at the given addresses.
+func (c *upgradeJujuCommand) newAPIRootAt(addrs []string) (api.Connection, error) {
+	args, err := c.NewAPIConnectionParams(c.ClientStore(), c.ControllerName(), c.AccountName(), c.ModelName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	openAPI := args.OpenAPI
+	args.OpenAPI = func(info *api.Info, opts api.DialOpts) (api.Connection, error) {
+		info.Addrs = addrs
+		return openAPI(info, opts)
+	}
+	return juju.NewAPIConnection(args)
+}
+
+// checkUpgrade reports the upgrade steps that an upgrade to the given
+// version would run, and the result of the pre-flight checks for it
+// made by every controller machine. An error is returned if any check
+// fails.
+func (c *upgradeJujuCommand) checkUpgrade(ctx *cmd.Context, client upgradeChecker, vers version.Number) error {
+	result, err := client.CheckUpgrade(vers)
+	if params.IsCodeNotImplemented(err) {
+		ctx.Infof("controller does not support pre-flight checks")
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot check upgrade")
+	}
+	problems := controllerProblems(result)
+	others, otherProblems, err := getOtherControllerCheckers(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	problems = append(problems, otherProblems...)
+	defer func() {
+		for _, other := range others {
+			other.Close()
+		}
+	}()
+	for _, other := range others {
+		otherResult, err := other.CheckUpgrade(vers)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("cannot check upgrade: %v", err))
+			continue
+		}
+		problems = append(problems, controllerProblems(otherResult)...)
+	}
+
+	if len(result.Steps) == 0 {
+		ctx.Infof("no upgrade steps to run")
+	} else {
+		steps := make([]string, len(result.Steps))
+		for i, step := range result.Steps {
+			steps[i] = fmt.Sprintf("    %s: %s (%s)", step.Version, step.Description, strings.Join(step.Targets, ", "))
+		}
+		ctx.Infof("upgrade steps to run:\n%s", strings.Join(steps, "\n"))
+	}
+	if result.StepsVersion != version.Zero && result.StepsVersion.Compare(vers) < 0 {
+		ctx.Infof("upgrade steps added after %s, the version the controller is running, are not listed", result.StepsVersion)
+	}
+	if len(result.DownAgents) > 0 {
+		ctx.Infof("agents not running:\n    %s", strings.Join(result.DownAgents, "\n    "))
+	}
+	if len(problems) > 0 {
+		ctx.Infof("problems:\n    %s", strings.Join(problems, "\n    "))
+	}
+	if len(result.DownAgents) > 0 || len(problems) > 0 {
+		return errors.New("pre-flight checks failed")
+	}
+	ctx.Infof("pre-flight checks passed")
+	return nil
+}
+
+// controllerProblems returns the problems found by the controller
+// machine that made the checks, prefixed with the machine when known.
+func controllerProblems(result params.UpgradeCheckResult) []string {
+	if result.Controller == "" {
+		return result.Problems
+	}
+	problems := make([]string, len(result.Problems))
+	for i, problem := range result.Problems {
+		problems[i] = fmt.Sprintf("machine %s: %s", result.Controller, problem)
+	}
+	return problems
+}
+
 const resetPreviousUpgradeMessage = `
 WARNING! using --reset-previous-upgrade when an upgrade is in progress
 will cause the upgrade to fail. Only use this option to clear an
@@ -7,9 +7,11 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"strings"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
@@ -77,6 +79,26 @@ var upgradeJujuTests = []struct {
 	currentVersion: "1.0.0-quantal-amd64",
 	args:           []string{"foo"},
 	expectInitErr:  "unrecognized args:.*",
+}, {
+	about:          "abort with version",
+	currentVersion: "1.0.0-quantal-amd64",
+	args:           []string{"--abort", "--version", "1.0.1"},
+	expectInitErr:  "--abort cannot be used with other upgrade options",
+}, {
+	about:          "invalid canary",
+	currentVersion: "1.0.0-quantal-amd64",
+	args:           []string{"--canary", "3,foo"},
+	expectInitErr:  `invalid canary machine id "foo"`,
+}, {
+	about:          "negative batch size",
+	currentVersion: "1.0.0-quantal-amd64",
+	args:           []string{"--batch-size", "-1"},
+	expectInitErr:  "--batch-size must not be negative",
+}, {
+	about:          "pause without batches",
+	currentVersion: "1.0.0-quantal-amd64",
+	args:           []string{"--pause", "10m"},
+	expectInitErr:  "--pause requires --canary or --batch-size",
 }, {
 	about:          "removed arg --dev specified",
 	currentVersion: "1.0.0-quantal-amd64",
@@ -499,6 +521,8 @@ func (s *UpgradeJujuSuite) TestUpgradeDryRun(c *gc.C) {
     2.1.3-quantal-amd64
 best version:
     2.1.3
+no upgrade steps to run
+pre-flight checks passed
 upgrade to this version by running
     juju upgrade-juju --version="2.1.3"
 `,
@@ -517,6 +541,8 @@ upgrade to this version by running
     2.2.3-quantal-amd64
 best version:
     2.1.3
+upgrade steps to run are only known to controllers running 2.1.3
+pre-flight checks passed
 upgrade to this version by running
     juju upgrade-juju --version="2.1.3"
 `,
@@ -533,6 +559,8 @@ upgrade to this version by running
     2.1.3-quantal-amd64
 best version:
     2.1.3
+upgrade steps to run are only known to controllers running 2.1.3
+pre-flight checks passed
 upgrade to this version by running
     juju upgrade-juju --version="2.1.3"
 `,
@@ -749,6 +777,159 @@ func (s *UpgradeJujuSuite) TestUpgradeUnknownSeriesInStreams(c *gc.C) {
 	c.Assert(fakeAPI.tools, gc.DeepEquals, []string{"2.1.0-weird-amd64", fakeAPI.nextVersion.String()})
 }
 
+func (s *UpgradeJujuSuite) TestUpgradeDryRunChecksFail(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.checkResult = params.UpgradeCheckResult{
+		Problems:   []string{"not enough free disk space for upgrade: 10MiB available, require 250MiB"},
+		DownAgents: []string{"machine-1", "unit-mysql-0"},
+		Steps: []params.UpgradeStep{{
+			Version:     version.MustParse("2.1.0"),
+			Description: "add the foo collection",
+			Targets:     []string{"databaseMaster"},
+		}},
+	}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--dry-run"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "pre-flight checks failed")
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, `
+upgrade steps to run:
+    2.1.0: add the foo collection (databaseMaster)
+agents not running:
+    machine-1
+    unit-mysql-0
+problems:
+    not enough free disk space for upgrade: 10MiB available, require 250MiB
+`[1:])
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeDryRunChecksOtherControllers(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.checkResult = params.UpgradeCheckResult{
+		Controller:   "0",
+		Steps:        []params.UpgradeStep{{Version: version.MustParse("2.0.0"), Description: "add the foo collection", Targets: []string{"databaseMaster"}}},
+		StepsVersion: version.MustParse("2.0.0"),
+	}
+	other := &fakeUpgradeChecker{result: params.UpgradeCheckResult{
+		Controller: "1",
+		Problems:   []string{"not enough free disk space for upgrade: 10MiB available, require 250MiB"},
+	}}
+	fakeAPI.otherControllers = []upgradeChecker{other}
+	fakeAPI.otherProblems = []string{"cannot connect to controller at 10.0.0.3:17070: connection refused"}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--dry-run"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, gc.ErrorMatches, "pre-flight checks failed")
+	stderr := coretesting.Stderr(ctx)
+	c.Assert(stderr, jc.Contains, `
+upgrade steps to run:
+    2.0.0: add the foo collection (databaseMaster)
+upgrade steps added after 2.0.0, the version the controller is running, are not listed
+`[1:])
+	c.Assert(stderr, jc.Contains, `
+problems:
+    cannot connect to controller at 10.0.0.3:17070: connection refused
+    machine 1: not enough free disk space for upgrade: 10MiB available, require 250MiB
+`[1:])
+	c.Assert(other.closed, jc.IsTrue)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeDryRunChecksNotSupported(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.checkErr = &params.Error{
+		Message: "no such request",
+		Code:    params.CodeNotImplemented,
+	}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--dry-run"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "controller does not support pre-flight checks\n")
+}
+
+func (s *UpgradeJujuSuite) TestAbortUpgrade(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--abort"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := coretesting.Context(c)
+	err = modelcmd.Wrap(cmd).Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.rollbackCalled, jc.IsTrue)
+	c.Assert(fakeAPI.findToolsCalled, jc.IsFalse)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Zero)
+	c.Assert(coretesting.Stderr(ctx), gc.Equals,
+		"rolling back upgrade; the controller will return to the previous agent version\n")
+}
+
+func (s *UpgradeJujuSuite) TestAbortUpgradeFails(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.rollbackErr = &params.Error{
+		Message: `cannot roll back upgrade with status "running"`,
+	}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--abort"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelcmd.Wrap(cmd).Run(coretesting.Context(c))
+	c.Assert(err, gc.ErrorMatches, `cannot roll back upgrade with status "running"`)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeInBatches(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{
+		"--canary", "3,4", "--batch-size", "10", "--pause", "30m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelcmd.Wrap(cmd).Run(coretesting.Context(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.plan, jc.DeepEquals, &fakeUpgradePlan{
+		version:   fakeAPI.nextVersion.Number,
+		canaries:  []string{"3", "4"},
+		batchSize: 10,
+		pause:     30 * time.Minute,
+	})
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.planRemoved, jc.IsFalse)
+}
+
+func (s *UpgradeJujuSuite) TestUpgradeInBatchesRemovesPlanOnFailure(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.setVersionErr = &params.Error{
+		Message: "a message from the server about the problem",
+		Code:    params.CodeUpgradeInProgress,
+	}
+	fakeAPI.patch(s)
+	cmd := &upgradeJujuCommand{}
+	err := coretesting.InitCommand(modelcmd.Wrap(cmd), []string{"--batch-size", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = modelcmd.Wrap(cmd).Run(coretesting.Context(c))
+	c.Assert(err, gc.ErrorMatches, "a message from the server about the problem(.|\n)*")
+	c.Assert(fakeAPI.plan, gc.NotNil)
+	c.Assert(fakeAPI.planRemoved, jc.IsTrue)
+}
+
 func (s *UpgradeJujuSuite) TestUpgradeInProgress(c *gc.C) {
 	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
 	fakeAPI.setVersionErr = &params.Error{
@@ -864,6 +1045,21 @@ type fakeUpgradeJujuAPI struct {
 	setVersionCalledWith      version.Number
 	tools                     []string
 	findToolsCalled           bool
+	checkResult               params.UpgradeCheckResult
+	checkErr                  error
+	otherControllers          []upgradeChecker
+	otherProblems             []string
+	rollbackCalled            bool
+	rollbackErr               error
+	plan                      *fakeUpgradePlan
+	planRemoved               bool
+}
+
+type fakeUpgradePlan struct {
+	version   version.Number
+	canaries  []string
+	batchSize int
+	pause     time.Duration
 }
 
 func (a *fakeUpgradeJujuAPI) reset() {
@@ -878,6 +1074,9 @@ func (a *fakeUpgradeJujuAPI) patch(s *UpgradeJujuSuite) {
 	s.PatchValue(&getUpgradeJujuAPI, func(*upgradeJujuCommand) (upgradeJujuAPI, error) {
 		return a, nil
 	})
+	s.PatchValue(&getOtherControllerCheckers, func(*upgradeJujuCommand) ([]upgradeChecker, []string, error) {
+		return a.otherControllers, a.otherProblems, nil
+	})
 }
 
 func (a *fakeUpgradeJujuAPI) addTools(tools ...string) {
@@ -915,6 +1114,41 @@ func (a *fakeUpgradeJujuAPI) AbortCurrentUpgrade() error {
 	return nil
 }
 
+func (a *fakeUpgradeJujuAPI) RollbackCurrentUpgrade() error {
+	a.rollbackCalled = true
+	return a.rollbackErr
+}
+
+func (a *fakeUpgradeJujuAPI) SetUpgradePlan(v version.Number, canaries []string, batchSize int, pause time.Duration) error {
+	a.plan = &fakeUpgradePlan{v, canaries, batchSize, pause}
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) RemoveUpgradePlan() error {
+	a.planRemoved = true
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) CheckUpgrade(v version.Number) (params.UpgradeCheckResult, error) {
+	return a.checkResult, a.checkErr
+}
+
+// fakeUpgradeChecker stands in for the connection to another
+// controller of an HA controller.
+type fakeUpgradeChecker struct {
+	result params.UpgradeCheckResult
+	closed bool
+}
+
+func (f *fakeUpgradeChecker) CheckUpgrade(v version.Number) (params.UpgradeCheckResult, error) {
+	return f.result, nil
+}
+
+func (f *fakeUpgradeChecker) Close() error {
+	f.closed = true
+	return nil
+}
+
 func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number) error {
 	a.setVersionCalledWith = v
 	return a.setVersionErr
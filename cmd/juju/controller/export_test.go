@@ -29,6 +29,17 @@ func NewShowControllerCommandForTest(testStore jujuclient.ClientStore) *showCont
 	}
 }
 
+// NewShowControllerCommandWithBackupsForTest returns a showControllerCommand
+// with the clientstore and backups API provided as specified.
+func NewShowControllerCommandWithBackupsForTest(testStore jujuclient.ClientStore, backupsAPI BackupsAPI) *showControllerCommand {
+	return &showControllerCommand{
+		store: testStore,
+		newBackupsAPI: func(controllerName, accountName string) (BackupsAPI, error) {
+			return backupsAPI, nil
+		},
+	}
+}
+
 type AddModelCommand struct {
 	*addModelCommand
 }
@@ -136,6 +147,17 @@ func NewListBlocksCommandForTest(api listBlocksAPI, apierr error, store jujuclie
 	return modelcmd.WrapController(c)
 }
 
+// NewControllerHealthCommandForTest returns a controllerHealthCommand
+// with the controller endpoint mocked out.
+func NewControllerHealthCommandForTest(api controllerHealthAPI, apierr error, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerHealthCommand{
+		api:    api,
+		apierr: apierr,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewGetConfigCommandCommandForTest returns a GetConfigCommandCommand with
 // the api provided as specified.
 func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore) cmd.Command {
@@ -158,3 +180,11 @@ func FmtModelStatus(data ModelData) string {
 func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, error) {
 	return newData(api, ctrUUID)
 }
+
+// NewSetSSHJumpHostsCommandForTest returns a set-ssh-jump-hosts command
+// using the given client store.
+func NewSetSSHJumpHostsCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &setSSHJumpHostsCommand{}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
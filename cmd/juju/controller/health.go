@@ -0,0 +1,241 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewControllerHealthCommand returns a command that reports the health
+// of the controller's mongo replica set.
+func NewControllerHealthCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerHealthCommand{})
+}
+
+// controllerHealthCommand reports replica set member state, replication
+// lag, oplog coverage and database sizes for a controller.
+type controllerHealthCommand struct {
+	modelcmd.ControllerCommandBase
+	out    cmd.Output
+	api    controllerHealthAPI
+	apierr error
+}
+
+var controllerHealthDoc = `
+Reports the health of the database backing the controller: the state
+of each replica set member and how far it lags behind the primary, the
+span of time covered by the replication oplog, and the disk usage of
+each database and collection.
+
+A secondary that lags the primary by more than the oplog window can no
+longer catch up by replication and must be resynced.
+
+Examples:
+
+    juju controller-health
+    juju controller-health --format yaml
+
+See also: show-controller
+          enable-ha
+`
+
+// controllerHealthAPI defines the methods on the controller API endpoint
+// that the controller-health command calls.
+type controllerHealthAPI interface {
+	Close() error
+	MongoHealth() (params.MongoHealthResult, error)
+}
+
+// Info implements Command.Info.
+func (c *controllerHealthCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "controller-health",
+		Purpose: "Reports replica set and database health for a controller.",
+		Doc:     controllerHealthDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerHealthCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerHealthTabular,
+	})
+}
+
+func (c *controllerHealthCommand) getAPI() (controllerHealthAPI, error) {
+	if c.api != nil {
+		return c.api, c.apierr
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *controllerHealthCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to the API")
+	}
+	defer api.Close()
+
+	result, err := api.MongoHealth()
+	if err != nil {
+		return errors.Annotate(err, "cannot get controller health")
+	}
+	return c.out.Write(ctx, convertControllerHealth(result))
+}
+
+type controllerHealth struct {
+	ReplicaSet string              `yaml:"replica-set" json:"replica-set"`
+	Primary    string              `yaml:"primary" json:"primary"`
+	Members    []replicaSetMember  `yaml:"members" json:"members"`
+	Oplog      oplogCoverage       `yaml:"oplog" json:"oplog"`
+	Databases  []databaseDiskUsage `yaml:"databases" json:"databases"`
+}
+
+type replicaSetMember struct {
+	Id      int    `yaml:"id" json:"id"`
+	Address string `yaml:"address" json:"address"`
+	State   string `yaml:"state" json:"state"`
+	Healthy bool   `yaml:"healthy" json:"healthy"`
+	Lag     string `yaml:"lag" json:"lag"`
+	Uptime  string `yaml:"uptime" json:"uptime"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+type oplogCoverage struct {
+	First   string `yaml:"first" json:"first"`
+	Last    string `yaml:"last" json:"last"`
+	Window  string `yaml:"window" json:"window"`
+	Size    int64  `yaml:"size" json:"size"`
+	MaxSize int64  `yaml:"max-size" json:"max-size"`
+}
+
+type databaseDiskUsage struct {
+	Name        string                `yaml:"name" json:"name"`
+	DataSize    int64                 `yaml:"data-size" json:"data-size"`
+	StorageSize int64                 `yaml:"storage-size" json:"storage-size"`
+	IndexSize   int64                 `yaml:"index-size" json:"index-size"`
+	Collections []collectionDiskUsage `yaml:"collections,omitempty" json:"collections,omitempty"`
+}
+
+type collectionDiskUsage struct {
+	Name        string `yaml:"name" json:"name"`
+	Count       int64  `yaml:"count" json:"count"`
+	DataSize    int64  `yaml:"data-size" json:"data-size"`
+	StorageSize int64  `yaml:"storage-size" json:"storage-size"`
+	IndexSize   int64  `yaml:"index-size" json:"index-size"`
+}
+
+func convertControllerHealth(result params.MongoHealthResult) controllerHealth {
+	health := controllerHealth{
+		ReplicaSet: result.ReplicaSet,
+		Primary:    result.Primary,
+		Oplog: oplogCoverage{
+			First:   result.Oplog.First.UTC().Format(time.RFC3339),
+			Last:    result.Oplog.Last.UTC().Format(time.RFC3339),
+			Window:  result.Oplog.Last.Sub(result.Oplog.First).String(),
+			Size:    result.Oplog.Size,
+			MaxSize: result.Oplog.MaxSize,
+		},
+	}
+	for _, m := range result.Members {
+		health.Members = append(health.Members, replicaSetMember{
+			Id:      m.Id,
+			Address: m.Address,
+			State:   m.State,
+			Healthy: m.Healthy,
+			Lag:     m.Lag.String(),
+			Uptime:  m.Uptime.String(),
+			Message: m.Message,
+		})
+	}
+	for _, db := range result.Databases {
+		usage := databaseDiskUsage{
+			Name:        db.Name,
+			DataSize:    db.DataSize,
+			StorageSize: db.StorageSize,
+			IndexSize:   db.IndexSize,
+		}
+		for _, coll := range db.Collections {
+			usage.Collections = append(usage.Collections, collectionDiskUsage{
+				Name:        coll.Name,
+				Count:       coll.Count,
+				DataSize:    coll.DataSize,
+				StorageSize: coll.StorageSize,
+				IndexSize:   coll.IndexSize,
+			})
+		}
+		health.Databases = append(health.Databases, usage)
+	}
+	return health
+}
+
+func formatControllerHealthTabular(value interface{}) ([]byte, error) {
+	health, ok := value.(controllerHealth)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", health, value)
+	}
+
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "REPLICA SET\tPRIMARY\tOPLOG WINDOW\tOPLOG SIZE\n")
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s/%s\n",
+		health.ReplicaSet, health.Primary, health.Oplog.Window,
+		byteSize(health.Oplog.Size), byteSize(health.Oplog.MaxSize),
+	)
+	fmt.Fprintln(tw)
+	fmt.Fprintf(tw, "MEMBER\tADDRESS\tSTATE\tHEALTHY\tLAG\tUPTIME\tMESSAGE\n")
+	for _, m := range health.Members {
+		healthy := "no"
+		if m.Healthy {
+			healthy = "yes"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.Id, m.Address, m.State, healthy, m.Lag, m.Uptime, m.Message,
+		)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintf(tw, "DATABASE\tCOLLECTION\tCOUNT\tDATA\tSTORAGE\tINDEXES\n")
+	for _, db := range health.Databases {
+		fmt.Fprintf(tw, "%s\t\t\t%s\t%s\t%s\n",
+			db.Name, byteSize(db.DataSize), byteSize(db.StorageSize), byteSize(db.IndexSize),
+		)
+		for _, coll := range db.Collections {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
+				db.Name, coll.Name, coll.Count,
+				byteSize(coll.DataSize), byteSize(coll.StorageSize), byteSize(coll.IndexSize),
+			)
+		}
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
+
+func byteSize(n int64) string {
+	if n < 0 {
+		n = 0
+	}
+	return humanize.IBytes(uint64(n))
+}
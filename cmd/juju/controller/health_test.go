@@ -0,0 +1,164 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type ControllerHealthSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeControllerHealthAPI
+	apierror error
+	store    *jujuclienttesting.MemStore
+}
+
+var _ = gc.Suite(&ControllerHealthSuite{})
+
+type fakeControllerHealthAPI struct {
+	err    error
+	result params.MongoHealthResult
+}
+
+func (f *fakeControllerHealthAPI) Close() error { return nil }
+
+func (f *fakeControllerHealthAPI) MongoHealth() (params.MongoHealthResult, error) {
+	return f.result, f.err
+}
+
+func (s *ControllerHealthSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.apierror = nil
+	now := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeControllerHealthAPI{
+		result: params.MongoHealthResult{
+			ReplicaSet: "juju",
+			Primary:    "10.0.0.1:37017",
+			Members: []params.MongoMemberHealth{{
+				Id:      1,
+				Address: "10.0.0.1:37017",
+				State:   "PRIMARY",
+				Healthy: true,
+				Self:    true,
+				Optime:  now,
+				Uptime:  time.Hour,
+			}, {
+				Id:      2,
+				Address: "10.0.0.2:37017",
+				State:   "SECONDARY",
+				Healthy: true,
+				Optime:  now.Add(-3 * time.Second),
+				Uptime:  time.Hour,
+				Lag:     3 * time.Second,
+				Message: "syncing from: 10.0.0.1:37017",
+			}},
+			Oplog: params.MongoOplogInfo{
+				First:   now.Add(-36 * time.Hour),
+				Last:    now,
+				Size:    512 * 1024 * 1024,
+				MaxSize: 1024 * 1024 * 1024,
+			},
+			Databases: []params.MongoDatabaseSize{{
+				Name:        "juju",
+				DataSize:    2048,
+				StorageSize: 8192,
+				IndexSize:   4096,
+				Collections: []params.MongoCollectionSize{{
+					Name:        "machines",
+					Count:       3,
+					DataSize:    1024,
+					StorageSize: 4096,
+					IndexSize:   2048,
+				}},
+			}},
+		},
+	}
+	s.store = jujuclienttesting.NewMemStore()
+	s.store.Controllers["dummysys"] = jujuclient.ControllerDetails{}
+}
+
+func (s *ControllerHealthSuite) runControllerHealth(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewControllerHealthCommandForTest(s.api, s.apierror, s.store)
+	args = append(args, "-c", "dummysys")
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *ControllerHealthSuite) TestCannotConnectToAPI(c *gc.C) {
+	s.apierror = errors.New("connection refused")
+	_, err := s.runControllerHealth(c)
+	c.Assert(err, gc.ErrorMatches, "cannot connect to the API: connection refused")
+}
+
+func (s *ControllerHealthSuite) TestAPIError(c *gc.C) {
+	s.api.err = errors.New("not running with --replSet")
+	_, err := s.runControllerHealth(c)
+	c.Assert(err, gc.ErrorMatches, "cannot get controller health: not running with --replSet")
+}
+
+func (s *ControllerHealthSuite) TestTabular(c *gc.C) {
+	ctx, err := s.runControllerHealth(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"REPLICA SET  PRIMARY         OPLOG WINDOW  OPLOG SIZE\n"+
+		"juju         10.0.0.1:37017  36h0m0s       512 MiB/1.0 GiB\n"+
+		"\n"+
+		"MEMBER  ADDRESS         STATE      HEALTHY  LAG  UPTIME  MESSAGE\n"+
+		"1       10.0.0.1:37017  PRIMARY    yes      0s   1h0m0s  \n"+
+		"2       10.0.0.2:37017  SECONDARY  yes      3s   1h0m0s  syncing from: 10.0.0.1:37017\n"+
+		"\n"+
+		"DATABASE  COLLECTION  COUNT  DATA     STORAGE  INDEXES\n"+
+		"juju                         2.0 KiB  8.0 KiB  4.0 KiB\n"+
+		"juju      machines    3      1.0 KiB  4.0 KiB  2.0 KiB\n"+
+		"\n")
+}
+
+func (s *ControllerHealthSuite) TestYAML(c *gc.C) {
+	ctx, err := s.runControllerHealth(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"replica-set: juju\n"+
+		"primary: 10.0.0.1:37017\n"+
+		"members:\n"+
+		"- id: 1\n"+
+		"  address: 10.0.0.1:37017\n"+
+		"  state: PRIMARY\n"+
+		"  healthy: true\n"+
+		"  lag: 0s\n"+
+		"  uptime: 1h0m0s\n"+
+		"- id: 2\n"+
+		"  address: 10.0.0.2:37017\n"+
+		"  state: SECONDARY\n"+
+		"  healthy: true\n"+
+		"  lag: 3s\n"+
+		"  uptime: 1h0m0s\n"+
+		"  message: 'syncing from: 10.0.0.1:37017'\n"+
+		"oplog:\n"+
+		"  first: 2016-04-30T00:00:00Z\n"+
+		"  last: 2016-05-01T12:00:00Z\n"+
+		"  window: 36h0m0s\n"+
+		"  size: 536870912\n"+
+		"  max-size: 1073741824\n"+
+		"databases:\n"+
+		"- name: juju\n"+
+		"  data-size: 2048\n"+
+		"  storage-size: 8192\n"+
+		"  index-size: 4096\n"+
+		"  collections:\n"+
+		"  - name: machines\n"+
+		"    count: 3\n"+
+		"    data-size: 1024\n"+
+		"    storage-size: 4096\n"+
+		"    index-size: 2048\n")
+}
@@ -0,0 +1,171 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewSetSSHJumpHostsCommand returns a command to set the SSH jump hosts
+// of a controller.
+func NewSetSSHJumpHostsCommand() cmd.Command {
+	return modelcmd.WrapController(&setSSHJumpHostsCommand{})
+}
+
+// setSSHJumpHostsCommand records the chain of hosts that SSH connections
+// to the machines of a controller go through, in the local store.
+type setSSHJumpHostsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	jumpHosts []jujuclient.SSHJumpHost
+	hostKeys  []string
+}
+
+const setSSHJumpHostsDoc = `
+Sets the chain of hosts (e.g. bastion hosts) that juju ssh, scp and
+debug-hooks connect through to reach the machines of a controller, in
+the order they are connected to. Each host is either a hostname or
+address, or the ID of a machine or unit in the model being connected
+to, optionally preceded by the user to log in as. Without any hosts,
+machines are connected to directly again.
+
+The host keys of external jump hosts are verified with the keys given
+by --host-keys, as <host>=<file> where the file holds the public keys
+of the host in authorized_keys format (e.g. /etc/ssh/ssh_host_*.pub),
+or else with your own known_hosts file. The host keys of Juju machines
+and units are taken from the controller.
+
+The jump hosts are only stored on this client. They are not used by
+juju run, whose commands are run by the agents of the target machines.
+
+Examples:
+
+    juju set-ssh-jump-hosts bastion.example.com
+    juju set-ssh-jump-hosts admin@bastion.example.com ubuntu@0 \
+        --host-keys bastion.example.com=bastion-keys.pub
+    juju set-ssh-jump-hosts -c mycontroller
+
+See also:
+    ssh
+    scp
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *setSSHJumpHostsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-ssh-jump-hosts",
+		Args:    "[[<user>@]<host> ...]",
+		Purpose: "Sets the hosts SSH connections to a controller's machines go through.",
+		Doc:     strings.TrimSpace(setSSHJumpHostsDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setSSHJumpHostsCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(cmd.NewAppendStringsValue(&c.hostKeys), "host-keys", "The file holding the public keys of an external jump host, as <host>=<file>")
+}
+
+// Init implements Command.Init.
+func (c *setSSHJumpHostsCommand) Init(args []string) error {
+	for _, arg := range args {
+		var jumpHost jujuclient.SSHJumpHost
+		if at := strings.LastIndex(arg, "@"); at >= 0 {
+			jumpHost.User, jumpHost.Host = arg[:at], arg[at+1:]
+		} else {
+			jumpHost.Host = arg
+		}
+		if jumpHost.Host == "" {
+			return errors.Errorf("missing host in %q", arg)
+		}
+		c.jumpHosts = append(c.jumpHosts, jumpHost)
+	}
+	for _, hostKeys := range c.hostKeys {
+		if !strings.Contains(hostKeys, "=") {
+			return errors.Errorf("expected --host-keys <host>=<file>, got %q", hostKeys)
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setSSHJumpHostsCommand) Run(ctx *cmd.Context) error {
+	for _, hostKeys := range c.hostKeys {
+		parts := strings.SplitN(hostKeys, "=", 2)
+		host, path := parts[0], parts[1]
+		if names.IsValidMachine(host) || names.IsValidUnit(host) {
+			return errors.Errorf("host keys of Juju machine or unit %q are taken from the controller", host)
+		}
+		keys, err := readHostKeys(ctx.AbsPath(path))
+		if err != nil {
+			return errors.Annotatef(err, "reading host keys of %q", host)
+		}
+		found := false
+		for i, jumpHost := range c.jumpHosts {
+			if jumpHost.Host == host {
+				c.jumpHosts[i].HostKeys = append(c.jumpHosts[i].HostKeys, keys...)
+				found = true
+			}
+		}
+		if !found {
+			return errors.Errorf("host keys given for %q, which is not a jump host", host)
+		}
+	}
+
+	controllerName := c.ControllerName()
+	store := c.ClientStore()
+	details, err := store.ControllerByName(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details.SSHJumpHosts = c.jumpHosts
+	if err := store.UpdateController(controllerName, *details); err != nil {
+		return errors.Trace(err)
+	}
+	if len(c.jumpHosts) == 0 {
+		ctx.Infof("SSH jump hosts of controller %q removed", controllerName)
+	}
+	return nil
+}
+
+// readHostKeys returns the public keys held by the file at path, in
+// authorized_keys format. Blank lines and comments are skipped.
+func readHostKeys(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+
+	var keys []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := ssh.ParseAuthorisedKey(line); err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys = append(keys, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no keys found")
+	}
+	return keys, nil
+}
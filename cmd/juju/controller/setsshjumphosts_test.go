@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	jc "github.com/juju/testing/checkers"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type SetSSHJumpHostsSuite struct {
+	baseControllerSuite
+}
+
+var _ = gc.Suite(&SetSSHJumpHostsSuite{})
+
+func (s *SetSSHJumpHostsSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+}
+
+func (s *SetSSHJumpHostsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewSetSSHJumpHostsCommandForTest(s.store)
+	return testing.RunCommand(c, command, args...)
+}
+
+func (s *SetSSHJumpHostsSuite) jumpHosts(c *gc.C, controllerName string) []jujuclient.SSHJumpHost {
+	details, err := s.store.ControllerByName(controllerName)
+	c.Assert(err, jc.ErrorIsNil)
+	return details.SSHJumpHosts
+}
+
+func (s *SetSSHJumpHostsSuite) writeKeys(c *gc.C, content string) string {
+	path := filepath.Join(c.MkDir(), "keys.pub")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *SetSSHJumpHostsSuite) TestSetJumpHosts(c *gc.C) {
+	_, err := s.run(c, "admin@bastion.example.com", "ubuntu@0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.jumpHosts(c, "mallards"), jc.DeepEquals, []jujuclient.SSHJumpHost{
+		{Host: "bastion.example.com", User: "admin"},
+		{Host: "0", User: "ubuntu"},
+	})
+	c.Assert(s.jumpHosts(c, "aws-test"), gc.HasLen, 0)
+}
+
+func (s *SetSSHJumpHostsSuite) TestSetJumpHostsOtherController(c *gc.C) {
+	_, err := s.run(c, "-c", "aws-test", "bastion.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.jumpHosts(c, "aws-test"), jc.DeepEquals, []jujuclient.SSHJumpHost{
+		{Host: "bastion.example.com"},
+	})
+	c.Assert(s.jumpHosts(c, "mallards"), gc.HasLen, 0)
+}
+
+func (s *SetSSHJumpHostsSuite) TestRemoveJumpHosts(c *gc.C) {
+	_, err := s.run(c, "bastion.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stderr(ctx), gc.Equals, "SSH jump hosts of controller \"mallards\" removed\n")
+	c.Assert(s.jumpHosts(c, "mallards"), gc.HasLen, 0)
+}
+
+func (s *SetSSHJumpHostsSuite) TestHostKeys(c *gc.C) {
+	path := s.writeKeys(c, "# bastion\n\n"+sshtesting.ValidKeyOne.Key+" root@bastion\n")
+	_, err := s.run(c, "bastion.example.com", "--host-keys", "bastion.example.com="+path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.jumpHosts(c, "mallards"), jc.DeepEquals, []jujuclient.SSHJumpHost{{
+		Host:     "bastion.example.com",
+		HostKeys: []string{sshtesting.ValidKeyOne.Key + " root@bastion"},
+	}})
+}
+
+func (s *SetSSHJumpHostsSuite) TestHostKeysInvalid(c *gc.C) {
+	path := s.writeKeys(c, "not a key\n")
+	_, err := s.run(c, "bastion.example.com", "--host-keys", "bastion.example.com="+path)
+	c.Assert(err, gc.ErrorMatches, `reading host keys of "bastion.example.com": .*`)
+	c.Assert(s.jumpHosts(c, "mallards"), gc.HasLen, 0)
+}
+
+func (s *SetSSHJumpHostsSuite) TestHostKeysUnknownHost(c *gc.C) {
+	path := s.writeKeys(c, sshtesting.ValidKeyOne.Key+"\n")
+	_, err := s.run(c, "bastion.example.com", "--host-keys", "other.example.com="+path)
+	c.Assert(err, gc.ErrorMatches, `host keys given for "other.example.com", which is not a jump host`)
+}
+
+func (s *SetSSHJumpHostsSuite) TestHostKeysJujuMachine(c *gc.C) {
+	path := s.writeKeys(c, sshtesting.ValidKeyOne.Key+"\n")
+	_, err := s.run(c, "0", "--host-keys", "0="+path)
+	c.Assert(err, gc.ErrorMatches, `host keys of Juju machine or unit "0" are taken from the controller`)
+}
+
+func (s *SetSSHJumpHostsSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "admin@")
+	c.Assert(err, gc.ErrorMatches, `missing host in "admin@"`)
+	_, err = s.run(c, "bastion.example.com", "--host-keys", "bastion.example.com")
+	c.Assert(err, gc.ErrorMatches, `expected --host-keys <host>=<file>, got "bastion.example.com"`)
+}
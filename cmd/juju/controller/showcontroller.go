@@ -5,12 +5,16 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/jujuclient"
 )
@@ -21,6 +25,8 @@ Shows detailed information of a controller.`[1:]
 var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
 and accounts. The active model and user accounts are also displayed.
+When the controller takes scheduled backups, the backup schedule and the
+outcome of the last scheduled backup are retrieved from the controller.
 
 Examples:
     juju show-controller
@@ -34,6 +40,7 @@ func NewShowControllerCommand() cmd.Command {
 	cmd := &showControllerCommand{
 		store: jujuclient.NewFileClientStore(),
 	}
+	cmd.newBackupsAPI = cmd.backupsAPI
 	return modelcmd.WrapBase(cmd)
 }
 
@@ -101,6 +108,10 @@ type ShowControllerDetails struct {
 	// This is only available on the client that bootstrapped the controller.
 	BootstrapConfig *BootstrapConfig `yaml:"bootstrap-config,omitempty" json:"bootstrap-config,omitempty"`
 
+	// BackupSchedule holds the controller's backup schedule, if backups
+	// are scheduled or have been in the past.
+	BackupSchedule *BackupSchedule `yaml:"backup-schedule,omitempty" json:"backup-schedule,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -155,6 +166,26 @@ type BootstrapConfig struct {
 	Credential           string                 `yaml:"credential,omitempty" json:"credential,omitempty"`
 }
 
+// BackupSchedule holds the schedule and retention policy of a controller's
+// scheduled backups, and the outcome of the last one.
+type BackupSchedule struct {
+	Interval   string `yaml:"interval,omitempty" json:"interval,omitempty"`
+	KeepLast   int    `yaml:"keep-last,omitempty" json:"keep-last,omitempty"`
+	KeepDaily  int    `yaml:"keep-daily,omitempty" json:"keep-daily,omitempty"`
+	KeepWeekly int    `yaml:"keep-weekly,omitempty" json:"keep-weekly,omitempty"`
+
+	LastRun *ScheduledBackupRun `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+}
+
+// ScheduledBackupRun holds the outcome of a scheduled backup.
+type ScheduledBackupRun struct {
+	Started  time.Time `yaml:"started" json:"started"`
+	Finished time.Time `yaml:"finished" json:"finished"`
+	BackupID string    `yaml:"backup-id,omitempty" json:"backup-id,omitempty"`
+	Removed  []string  `yaml:"removed,omitempty" json:"removed,omitempty"`
+	Error    string    `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
 func (c *showControllerCommand) convertControllerForShow(controllerName string, details *jujuclient.ControllerDetails) ShowControllerDetails {
 	controller := ShowControllerDetails{
 		Details: ControllerDetails{
@@ -167,6 +198,7 @@ func (c *showControllerCommand) convertControllerForShow(controllerName string,
 	}
 	c.convertAccountsForShow(controllerName, &controller)
 	c.convertBootstrapConfigForShow(controllerName, &controller)
+	c.convertBackupScheduleForShow(controllerName, &controller)
 	return controller
 }
 
@@ -249,11 +281,64 @@ func (c *showControllerCommand) convertBootstrapConfigForShow(controllerName str
 	}
 }
 
+// BackupsAPI defines the backups API methods used by show-controller.
+type BackupsAPI interface {
+	List() (*params.BackupsListResult, error)
+	Close() error
+}
+
+// backupsAPI returns a backups API client connected to the controller
+// model of the named controller.
+func (c *showControllerCommand) backupsAPI(controllerName, accountName string) (BackupsAPI, error) {
+	root, err := c.NewAPIRoot(c.store, controllerName, accountName, environs.ControllerModelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return backups.NewClient(root)
+}
+
+func (c *showControllerCommand) convertBackupScheduleForShow(controllerName string, controller *ShowControllerDetails) {
+	if c.newBackupsAPI == nil || controller.CurrentAccount == "" {
+		return
+	}
+	client, err := c.newBackupsAPI(controllerName, controller.CurrentAccount)
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	defer client.Close()
+	result, err := client.List()
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	schedule := result.Schedule
+	if schedule == nil {
+		return
+	}
+	controller.BackupSchedule = &BackupSchedule{
+		Interval:   schedule.Interval,
+		KeepLast:   schedule.KeepLast,
+		KeepDaily:  schedule.KeepDaily,
+		KeepWeekly: schedule.KeepWeekly,
+	}
+	if lastRun := schedule.LastRun; lastRun != nil {
+		controller.BackupSchedule.LastRun = &ScheduledBackupRun{
+			Started:  lastRun.Started,
+			Finished: lastRun.Finished,
+			BackupID: lastRun.BackupID,
+			Removed:  lastRun.Removed,
+			Error:    lastRun.Error,
+		}
+	}
+}
+
 type showControllerCommand struct {
 	modelcmd.JujuCommandBase
 
-	out   cmd.Output
-	store jujuclient.ClientStore
+	out           cmd.Output
+	store         jujuclient.ClientStore
+	newBackupsAPI func(controllerName, accountName string) (BackupsAPI, error)
 
 	controllerNames []string
 	showPasswords   bool
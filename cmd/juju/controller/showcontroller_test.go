@@ -4,13 +4,16 @@
 package controller_test
 
 import (
+	"encoding/json"
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -154,6 +157,64 @@ mallards:
 	s.assertShowController(c, "mallards")
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithBackupSchedule(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	store := s.createTestClientStore(c)
+	started := time.Date(2016, time.August, 8, 1, 0, 0, 0, time.UTC)
+	api := &fakeBackupsAPI{
+		result: &params.BackupsListResult{
+			Schedule: &params.BackupsScheduleResult{
+				Interval: "24h0m0s",
+				KeepLast: 3,
+				LastRun: &params.BackupsScheduledRunResult{
+					Started:  started,
+					Finished: started.Add(time.Minute),
+					BackupID: "new-backup",
+					Removed:  []string{"old-backup"},
+				},
+			},
+		},
+	}
+	command := controller.NewShowControllerCommandWithBackupsForTest(store, api)
+	ctx, err := testing.RunCommand(c, command, "mallards", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var output map[string]controller.ShowControllerDetails
+	err = json.Unmarshal([]byte(testing.Stdout(ctx)), &output)
+	c.Assert(err, jc.ErrorIsNil)
+	schedule := output["mallards"].BackupSchedule
+	c.Assert(schedule, gc.NotNil)
+	c.Check(schedule.Interval, gc.Equals, "24h0m0s")
+	c.Check(schedule.KeepLast, gc.Equals, 3)
+	c.Assert(schedule.LastRun, gc.NotNil)
+	c.Check(schedule.LastRun.Started.Equal(started), jc.IsTrue)
+	c.Check(schedule.LastRun.BackupID, gc.Equals, "new-backup")
+	c.Check(schedule.LastRun.Removed, jc.DeepEquals, []string{"old-backup"})
+	c.Check(api.closed, jc.IsTrue)
+}
+
+func (s *ShowControllerSuite) TestShowControllerBackupScheduleError(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	store := s.createTestClientStore(c)
+	api := &fakeBackupsAPI{err: errors.New("connection refused")}
+	command := controller.NewShowControllerCommandWithBackupsForTest(store, api)
+	ctx, err := testing.RunCommand(c, command, "mallards")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), jc.Contains, "errors:\n  - connection refused\n")
+}
+
 func (s *ShowControllerSuite) TestShowOneControllerManyInStore(c *gc.C) {
 	s.createTestClientStore(c)
 
@@ -286,3 +347,18 @@ func (s *ShowControllerSuite) assertShowController(c *gc.C, args ...string) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stdout(context), gc.Equals, s.expectedOutput)
 }
+
+type fakeBackupsAPI struct {
+	result *params.BackupsListResult
+	err    error
+	closed bool
+}
+
+func (f *fakeBackupsAPI) List() (*params.BackupsListResult, error) {
+	return f.result, f.err
+}
+
+func (f *fakeBackupsAPI) Close() error {
+	f.closed = true
+	return nil
+}
@@ -7,8 +7,12 @@ import (
 	"errors"
 	"time"
 
+	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
+
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
 )
 
 var (
@@ -44,3 +48,9 @@ func PatchGetActionResult(patchValue func(interface{}, interface{}), actions map
 		return params.ActionResult{}, errors.New("plm")
 	})
 }
+
+// NewMetricsCommandForTest returns a MetricsCommand that waits for new
+// metrics using the given clock.
+func NewMetricsCommandForTest(clock clock.Clock) cmd.Command {
+	return modelcmd.Wrap(&MetricsCommand{clock: clock})
+}
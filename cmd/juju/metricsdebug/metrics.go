@@ -0,0 +1,213 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsdebug
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const metricsDoc = `
+Displays the metrics collected by the given units or applications.
+
+With --follow, the command keeps running, and displays new metrics as
+they are collected until it is interrupted. With --json, each metric is
+displayed as a JSON object on its own line, which is convenient for
+feeding the metrics to other tools.
+
+Metrics are collected by units every five minutes; use collect-metrics
+to collect them sooner.
+
+Examples:
+    juju metrics metered
+    juju metrics metered/0 other-metered --follow
+    juju metrics metered --follow --json
+
+See also:
+    collect-metrics
+    debug-metrics
+`
+
+const defaultMetricsInterval = 10 * time.Second
+
+// MetricsCommand displays the metrics collected by units, and can
+// follow them as new ones are collected.
+type MetricsCommand struct {
+	modelcmd.ModelCommandBase
+	Tags     []names.Tag
+	Follow   bool
+	Interval time.Duration
+	Json     bool
+
+	clock clock.Clock
+}
+
+// NewMetricsCommand creates a new MetricsCommand.
+func NewMetricsCommand() cmd.Command {
+	return modelcmd.Wrap(&MetricsCommand{clock: clock.WallClock})
+}
+
+// Info implements Command.Info.
+func (c *MetricsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "metrics",
+		Args:    "<application or unit> ...",
+		Purpose: "Displays the metrics collected by units, optionally following new ones.",
+		Doc:     metricsDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *MetricsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Follow, "follow", false, "Keep displaying metrics as they are collected")
+	f.DurationVar(&c.Interval, "interval", defaultMetricsInterval, "How often to look for new metrics when following")
+	f.BoolVar(&c.Json, "json", false, "Display each metric as a JSON object on its own line")
+}
+
+// Init reads and verifies the cli arguments for the MetricsCommand.
+func (c *MetricsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("you need to specify at least one unit or application")
+	}
+	for _, arg := range args {
+		switch {
+		case names.IsValidUnit(arg):
+			c.Tags = append(c.Tags, names.NewUnitTag(arg))
+		case names.IsValidApplication(arg):
+			c.Tags = append(c.Tags, names.NewApplicationTag(arg))
+		default:
+			return errors.Errorf("%q is not a valid unit or application", arg)
+		}
+	}
+	if c.Interval <= 0 {
+		return errors.Errorf("interval must be positive, got %v", c.Interval)
+	}
+	return nil
+}
+
+// metric is a metric collected by a unit, as displayed by the
+// metrics command.
+type metric struct {
+	Time  time.Time `json:"time"`
+	Unit  string    `json:"unit"`
+	Key   string    `json:"key"`
+	Value string    `json:"value"`
+}
+
+// Run implements Command.Run.
+func (c *MetricsCommand) Run(ctx *cmd.Context) error {
+	client, err := newClient(c.ModelCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	seen := make(map[metric]bool)
+	printHeader := !c.Json
+	for {
+		metrics, err := c.getMetrics(client)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Only the metrics still stored are remembered, so that
+		// the metrics the controller has cleaned up are forgotten.
+		var unseen []metric
+		stored := make(map[metric]bool)
+		for _, m := range metrics {
+			if !seen[m] {
+				unseen = append(unseen, m)
+			}
+			stored[m] = true
+		}
+		seen = stored
+		if err := c.write(ctx, unseen, printHeader); err != nil {
+			return errors.Trace(err)
+		}
+		printHeader = false
+		if !c.Follow {
+			return nil
+		}
+		select {
+		case <-interrupted:
+			return nil
+		case <-c.clock.After(c.Interval):
+		}
+	}
+}
+
+func (c *MetricsCommand) getMetrics(client GetMetricsClient) ([]metric, error) {
+	var metrics []metric
+	for _, tag := range c.Tags {
+		results, err := client.GetMetrics(tag.String())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, r := range results {
+			unit := r.Unit
+			if unit == "" && tag.Kind() == names.UnitTagKind {
+				// Older controllers don't report units.
+				unit = tag.Id()
+			}
+			metrics = append(metrics, metric{
+				Time:  r.Time.UTC(),
+				Unit:  unit,
+				Key:   r.Key,
+				Value: r.Value,
+			})
+		}
+	}
+	sort.Sort(metricsByTime(metrics))
+	return metrics, nil
+}
+
+func (c *MetricsCommand) write(ctx *cmd.Context, metrics []metric, printHeader bool) error {
+	if c.Json {
+		encoder := json.NewEncoder(ctx.Stdout)
+		for _, m := range metrics {
+			if err := encoder.Encode(m); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
+	tw := tabwriter.NewWriter(ctx.Stdout, 0, 1, 1, ' ', 0)
+	if printHeader {
+		fmt.Fprintf(tw, "TIME\tUNIT\tMETRIC\tVALUE\n")
+	}
+	for _, m := range metrics {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n", m.Time.Format(time.RFC3339), m.Unit, m.Key, m.Value)
+	}
+	return tw.Flush()
+}
+
+type metricsByTime []metric
+
+func (m metricsByTime) Len() int      { return len(m) }
+func (m metricsByTime) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+func (m metricsByTime) Less(i, j int) bool {
+	if !m[i].Time.Equal(m[j].Time) {
+		return m[i].Time.Before(m[j].Time)
+	}
+	if m[i].Unit != m[j].Unit {
+		return m[i].Unit < m[j].Unit
+	}
+	return m[i].Key < m[j].Key
+}
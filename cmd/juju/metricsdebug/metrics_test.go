@@ -0,0 +1,116 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package metricsdebug_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/metricsdebug"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// sequenceMetricsClient returns the next of its results on each call
+// to GetMetrics, and an error once they run out.
+type sequenceMetricsClient struct {
+	testing.Stub
+	results [][]params.MetricResult
+}
+
+func (m *sequenceMetricsClient) GetMetrics(tag string) ([]params.MetricResult, error) {
+	m.MethodCall(m, "GetMetrics", tag)
+	if len(m.results) == 0 {
+		return nil, errors.New("no more metrics")
+	}
+	result := m.results[0]
+	m.results = m.results[1:]
+	return result, nil
+}
+
+func (m *sequenceMetricsClient) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+type MetricsSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	client *sequenceMetricsClient
+	clock  *coretesting.AutoAdvancingClock
+}
+
+var _ = gc.Suite(&MetricsSuite{})
+
+var metricsTime = time.Date(2016, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func (s *MetricsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.client = &sequenceMetricsClient{}
+	s.PatchValue(metricsdebug.NewClient, func(_ modelcmd.ModelCommandBase) (metricsdebug.GetMetricsClient, error) {
+		return s.client, nil
+	})
+	clock := coretesting.NewClock(metricsTime)
+	s.clock = &coretesting.AutoAdvancingClock{Clock: clock, Advance: clock.Advance}
+}
+
+func (s *MetricsSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "you need to specify at least one unit or application",
+	}, {
+		args: []string{"metered", "!!!"},
+		err:  `"!!!" is not a valid unit or application`,
+	}, {
+		args: []string{"metered", "--interval", "0s"},
+		err:  "interval must be positive, got 0s",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := coretesting.InitCommand(metricsdebug.NewMetricsCommandForTest(s.clock), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MetricsSuite) TestMetrics(c *gc.C) {
+	s.client.results = [][]params.MetricResult{{
+		{Time: metricsTime.Add(time.Minute), Key: "pings", Value: "7", Unit: "metered/1"},
+		{Time: metricsTime, Key: "pings", Value: "5", Unit: "metered/0"},
+	}, {
+		{Time: metricsTime, Key: "juju-units", Value: "1"},
+	}}
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommandForTest(s.clock), "metered", "other/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		"TIME                 UNIT      METRIC     VALUE\n"+
+		"2016-10-18T12:00:00Z metered/0 pings      5\n"+
+		"2016-10-18T12:00:00Z other/0   juju-units 1\n"+
+		"2016-10-18T12:01:00Z metered/1 pings      7\n")
+	s.client.CheckCalls(c, []testing.StubCall{
+		{"GetMetrics", []interface{}{"application-metered"}},
+		{"GetMetrics", []interface{}{"unit-other-0"}},
+		{"Close", nil},
+	})
+}
+
+func (s *MetricsSuite) TestFollow(c *gc.C) {
+	first := params.MetricResult{Time: metricsTime, Key: "pings", Value: "5", Unit: "metered/0"}
+	second := params.MetricResult{Time: metricsTime.Add(5 * time.Minute), Key: "pings", Value: "8", Unit: "metered/0"}
+	s.client.results = [][]params.MetricResult{
+		{first},
+		{first},
+		{first, second},
+	}
+	ctx, err := coretesting.RunCommand(c, metricsdebug.NewMetricsCommandForTest(s.clock), "metered", "--follow", "--json")
+	c.Assert(err, gc.ErrorMatches, "no more metrics")
+	c.Assert(coretesting.Stdout(ctx), gc.Equals, ""+
+		`{"time":"2016-10-18T12:00:00Z","unit":"metered/0","key":"pings","value":"5"}`+"\n"+
+		`{"time":"2016-10-18T12:05:00Z","unit":"metered/0","key":"pings","value":"8"}`+"\n")
+	c.Assert(s.clock.Now(), gc.Equals, metricsTime.Add(30*time.Second))
+}
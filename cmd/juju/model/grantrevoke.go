@@ -8,13 +8,14 @@ import (
 	"github.com/juju/errors"
 	"launchpad.net/gnuflag"
 
+	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/permission"
 )
 
 var usageGrantSummary = `
-Grants access to a Juju user for a model.`[1:]
+Grants access to a Juju user for a model or the controller.`[1:]
 
 var usageGrantDetails = `
 By default, the controller is the current controller.
@@ -25,6 +26,21 @@ Model access can also be granted at user-addition time with the
 Users with read access are limited in what they can do with models:
 ` + "`juju models`, `juju machines`, and `juju status`" + `.
 
+Granting login, add-model or superuser access applies to the controller
+rather than to models, so no model is given. Users with add-model access
+can create models of their own, and superusers can administer the
+controller and all of its models.
+
+With --group, access is granted to a group of users rather than to a
+single user; see ` + "`juju add-group`" + `. Members of a group have the greater
+of their own access and that of their groups.
+
+Granting operate or manage access applies to applications in a model,
+which is the current model unless --model is given. Users with read
+access to the model and operate access to an application can configure
+it and run actions on its units; manage access also allows upgrading,
+scaling and removing it.
+
 Examples:
 Grant user 'joe' default (read) access to model 'mymodel':
 
@@ -38,19 +54,36 @@ Grant user 'sam' default (read) access to models 'model1' and 'model2':
 
     juju grant sam model1 model2
 
+Grant user 'ann' the ability to create models on the controller:
+
+    juju grant --acl=add-model ann
+
+Grant user 'joe' operate access to application 'mysql' in the current
+model:
+
+    juju grant --acl=operate joe mysql
+
+Grant the members of group 'devs' write access to model 'mymodel':
+
+    juju grant --group --acl=write devs mymodel
+
 See also: 
     revoke
     add-user`
 
 var usageRevokeSummary = `
-Revokes access from a Juju user for a model.`[1:]
+Revokes access from a Juju user for a model or the controller.`[1:]
 
 var usageRevokeDetails = `
 By default, the controller is the current controller.
 
 Revoking write access, from a user who has that permission, will leave
 that user with read access. Revoking read access, however, also revokes
-write access.
+write access. Controller access is revoked in the same way: revoking
+superuser access leaves add-model access, revoking add-model access
+leaves login access, and revoking login access removes all access to
+the controller. Revoking manage access on an application leaves operate
+access, and revoking operate access removes all access to it.
 
 Examples:
 Revoke read (and write) access from user 'joe' for model 'mymodel':
@@ -61,20 +94,48 @@ Revoke write access from user 'sam' for models 'model1' and 'model2':
 
     juju revoke --acl=write sam model1 model2
 
+Revoke superuser access from user 'ann':
+
+    juju revoke --acl=superuser ann
+
+Revoke all access from user 'joe' to application 'mysql' in model
+'mymodel':
+
+    juju revoke --acl=operate -m mymodel joe mysql
+
+Revoke superuser access from the members of group 'admins':
+
+    juju revoke --group --acl=superuser admins
+
 See also: 
     grant`[1:]
 
 type accessCommand struct {
 	modelcmd.ControllerCommandBase
 
-	User        string
-	ModelNames  []string
-	ModelAccess string
+	User       string
+	ModelNames []string
+	Access     string
+
+	// Group is set when User names a user group.
+	Group bool
+
+	// Controller is set when Access applies to the
+	// controller rather than to models.
+	Controller bool
+
+	// ApplicationNames holds the applications that Access
+	// applies to, within the model named by ModelName.
+	ApplicationNames []string
+	ModelName        string
 }
 
 // SetFlags implements cmd.Command.
 func (c *accessCommand) SetFlags(f *gnuflag.FlagSet) {
-	f.StringVar(&c.ModelAccess, "acl", "read", "Access control ('read' or 'write')")
+	f.StringVar(&c.Access, "acl", "read", "Access control ('read', 'write', 'admin', 'login', 'add-model', 'superuser', 'operate' or 'manage')")
+	f.StringVar(&c.ModelName, "m", "", "Model containing the applications, for 'operate' and 'manage' access")
+	f.StringVar(&c.ModelName, "model", "", "")
+	f.BoolVar(&c.Group, "group", false, "Change the access of the named user group rather than a user")
 }
 
 // Init implements cmd.Command.
@@ -82,19 +143,63 @@ func (c *accessCommand) Init(args []string) error {
 	if len(args) < 1 {
 		return errors.New("no user specified")
 	}
+	c.User = args[0]
 
-	if len(args) < 2 {
+	if _, err := permission.ParseApplicationAccess(c.Access); err == nil {
+		if c.Group {
+			return errors.Errorf("%q access cannot be granted to groups", c.Access)
+		}
+		c.ApplicationNames = args[1:]
+		if len(c.ApplicationNames) == 0 {
+			return errors.New("no application specified")
+		}
+		return nil
+	}
+	if c.ModelName != "" {
+		return errors.Errorf("--model applies only to application access")
+	}
+	c.ModelNames = args[1:]
+
+	if _, err := permission.ParseControllerAccess(c.Access); err == nil {
+		if len(c.ModelNames) > 0 {
+			return errors.Errorf("%q access applies to the controller, not to models", c.Access)
+		}
+		c.Controller = true
+		return nil
+	}
+
+	if len(c.ModelNames) == 0 {
 		return errors.New("no model specified")
 	}
+	_, err := permission.ParseModelAccess(c.Access)
+	return err
+}
 
-	_, err := permission.ParseModelAccess(c.ModelAccess)
+// applicationModelName returns the name of the model containing the
+// applications that access is being changed on.
+func (c *accessCommand) applicationModelName() (string, error) {
+	if c.ModelName != "" {
+		return c.ModelName, nil
+	}
+	modelName, err := c.ClientStore().CurrentModel(c.ControllerName(), c.AccountName())
 	if err != nil {
-		return err
+		return "", errors.Annotate(err, "getting current model")
 	}
+	return modelName, nil
+}
 
-	c.User = args[0]
-	c.ModelNames = args[1:]
-	return nil
+// newApplicationAPIClient returns an Application API client for the
+// model containing the applications that access is being changed on.
+func (c *accessCommand) newApplicationAPIClient() (*application.Client, error) {
+	modelName, err := c.applicationModelName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	root, err := c.NewModelAPIRoot(modelName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return application.NewClient(root), nil
 }
 
 // NewGrantCommand returns a new grant command.
@@ -102,17 +207,20 @@ func NewGrantCommand() cmd.Command {
 	return modelcmd.WrapController(&grantCommand{})
 }
 
-// grantCommand represents the command to grant a user access to one or more models.
+// grantCommand represents the command to grant a user access to one or
+// more models, or to the controller.
 type grantCommand struct {
 	accessCommand
-	api GrantModelAPI
+	api            GrantModelAPI
+	controllerAPI  GrantControllerAPI
+	applicationAPI GrantApplicationAPI
 }
 
 // Info implements Command.Info.
 func (c *grantCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "grant",
-		Args:    "<user name> <model name> ...",
+		Args:    "<user or group name> [<model name> ...|<application name> ...]",
 		Purpose: usageGrantSummary,
 		Doc:     usageGrantDetails,
 	}
@@ -125,14 +233,64 @@ func (c *grantCommand) getAPI() (GrantModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *grantCommand) getControllerAPI() (GrantControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+func (c *grantCommand) getApplicationAPI() (GrantApplicationAPI, error) {
+	if c.applicationAPI != nil {
+		return c.applicationAPI, nil
+	}
+	return c.newApplicationAPIClient()
+}
+
 // GrantModelAPI defines the API functions used by the grant command.
 type GrantModelAPI interface {
 	Close() error
 	GrantModel(user, access string, modelUUIDs ...string) error
+	GrantModelGroup(group, access string, modelUUIDs ...string) error
+}
+
+// GrantControllerAPI defines the API functions used by the grant command
+// to grant controller access.
+type GrantControllerAPI interface {
+	Close() error
+	GrantController(user, access string) error
+	GrantControllerGroup(group, access string) error
+}
+
+// GrantApplicationAPI defines the API functions used by the grant
+// command to grant application access.
+type GrantApplicationAPI interface {
+	Close() error
+	GrantApplication(user, access string, applications ...string) error
 }
 
 // Run implements cmd.Command.
 func (c *grantCommand) Run(ctx *cmd.Context) error {
+	if c.Controller {
+		client, err := c.getControllerAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		if c.Group {
+			return block.ProcessBlockedError(client.GrantControllerGroup(c.User, c.Access), block.BlockChange)
+		}
+		return block.ProcessBlockedError(client.GrantController(c.User, c.Access), block.BlockChange)
+	}
+	if len(c.ApplicationNames) > 0 {
+		client, err := c.getApplicationAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return block.ProcessBlockedError(client.GrantApplication(c.User, c.Access, c.ApplicationNames...), block.BlockChange)
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -143,7 +301,10 @@ func (c *grantCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.GrantModel(c.User, c.ModelAccess, models...), block.BlockChange)
+	if c.Group {
+		return block.ProcessBlockedError(client.GrantModelGroup(c.User, c.Access, models...), block.BlockChange)
+	}
+	return block.ProcessBlockedError(client.GrantModel(c.User, c.Access, models...), block.BlockChange)
 }
 
 // NewRevokeCommand returns a new revoke command.
@@ -151,17 +312,19 @@ func NewRevokeCommand() cmd.Command {
 	return modelcmd.WrapController(&revokeCommand{})
 }
 
-// revokeCommand revokes a user's access to models.
+// revokeCommand revokes a user's access to models or to the controller.
 type revokeCommand struct {
 	accessCommand
-	api RevokeModelAPI
+	api            RevokeModelAPI
+	controllerAPI  RevokeControllerAPI
+	applicationAPI RevokeApplicationAPI
 }
 
 // Info implements cmd.Command.
 func (c *revokeCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "revoke",
-		Args:    "<user> <model name> ...",
+		Args:    "<user or group name> [<model name> ...|<application name> ...]",
 		Purpose: usageRevokeSummary,
 		Doc:     usageRevokeDetails,
 	}
@@ -174,14 +337,64 @@ func (c *revokeCommand) getAPI() (RevokeModelAPI, error) {
 	return c.NewModelManagerAPIClient()
 }
 
+func (c *revokeCommand) getControllerAPI() (RevokeControllerAPI, error) {
+	if c.controllerAPI != nil {
+		return c.controllerAPI, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+func (c *revokeCommand) getApplicationAPI() (RevokeApplicationAPI, error) {
+	if c.applicationAPI != nil {
+		return c.applicationAPI, nil
+	}
+	return c.newApplicationAPIClient()
+}
+
 // RevokeModelAPI defines the API functions used by the revoke command.
 type RevokeModelAPI interface {
 	Close() error
 	RevokeModel(user, access string, modelUUIDs ...string) error
+	RevokeModelGroup(group, access string, modelUUIDs ...string) error
+}
+
+// RevokeControllerAPI defines the API functions used by the revoke
+// command to revoke controller access.
+type RevokeControllerAPI interface {
+	Close() error
+	RevokeController(user, access string) error
+	RevokeControllerGroup(group, access string) error
+}
+
+// RevokeApplicationAPI defines the API functions used by the revoke
+// command to revoke application access.
+type RevokeApplicationAPI interface {
+	Close() error
+	RevokeApplication(user, access string, applications ...string) error
 }
 
 // Run implements cmd.Command.
 func (c *revokeCommand) Run(ctx *cmd.Context) error {
+	if c.Controller {
+		client, err := c.getControllerAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		if c.Group {
+			return block.ProcessBlockedError(client.RevokeControllerGroup(c.User, c.Access), block.BlockChange)
+		}
+		return block.ProcessBlockedError(client.RevokeController(c.User, c.Access), block.BlockChange)
+	}
+	if len(c.ApplicationNames) > 0 {
+		client, err := c.getApplicationAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return block.ProcessBlockedError(client.RevokeApplication(c.User, c.Access, c.ApplicationNames...), block.BlockChange)
+	}
+
 	client, err := c.getAPI()
 	if err != nil {
 		return err
@@ -192,5 +405,8 @@ func (c *revokeCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
-	return block.ProcessBlockedError(client.RevokeModel(c.User, c.ModelAccess, modelUUIDs...), block.BlockChange)
+	if c.Group {
+		return block.ProcessBlockedError(client.RevokeModelGroup(c.User, c.Access, modelUUIDs...), block.BlockChange)
+	}
+	return block.ProcessBlockedError(client.RevokeModel(c.User, c.Access, modelUUIDs...), block.BlockChange)
 }
@@ -88,6 +88,67 @@ func (s *grantRevokeSuite) TestAccess(c *gc.C) {
 	c.Assert(s.fake.access, gc.Equals, "write")
 }
 
+func (s *grantRevokeSuite) TestControllerAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "add-model", "sam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.controller, jc.IsTrue)
+	c.Assert(s.fake.modelUUIDs, gc.HasLen, 0)
+	c.Assert(s.fake.access, gc.Equals, "add-model")
+}
+
+func (s *grantRevokeSuite) TestControllerAccessWithModels(c *gc.C) {
+	_, err := s.run(c, "--acl", "superuser", "sam", "model1")
+	c.Assert(err, gc.ErrorMatches, `"superuser" access applies to the controller, not to models`)
+}
+
+func (s *grantRevokeSuite) TestApplicationAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "operate", "sam", "mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.user, gc.Equals, "sam")
+	c.Assert(s.fake.applications, jc.DeepEquals, []string{"mysql", "wordpress"})
+	c.Assert(s.fake.modelUUIDs, gc.HasLen, 0)
+	c.Assert(s.fake.access, gc.Equals, "operate")
+}
+
+func (s *grantRevokeSuite) TestApplicationAccessNoApplication(c *gc.C) {
+	_, err := s.run(c, "--acl", "manage", "sam")
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+}
+
+func (s *grantRevokeSuite) TestModelFlagWithModelAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "write", "-m", "foo", "sam", "model1")
+	c.Assert(err, gc.ErrorMatches, "--model applies only to application access")
+}
+
+func (s *grantRevokeSuite) TestGroupModelAccess(c *gc.C) {
+	_, err := s.run(c, "--group", "--acl", "write", "devs", "model1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.group, jc.IsTrue)
+	c.Assert(s.fake.user, gc.Equals, "devs")
+	c.Assert(s.fake.modelUUIDs, jc.DeepEquals, []string{model1ModelUUID})
+	c.Assert(s.fake.access, gc.Equals, "write")
+}
+
+func (s *grantRevokeSuite) TestGroupControllerAccess(c *gc.C) {
+	_, err := s.run(c, "--group", "--acl", "superuser", "admins")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.group, jc.IsTrue)
+	c.Assert(s.fake.controller, jc.IsTrue)
+	c.Assert(s.fake.user, gc.Equals, "admins")
+	c.Assert(s.fake.access, gc.Equals, "superuser")
+}
+
+func (s *grantRevokeSuite) TestGroupApplicationAccess(c *gc.C) {
+	_, err := s.run(c, "--group", "--acl", "operate", "devs", "mysql")
+	c.Assert(err, gc.ErrorMatches, `"operate" access cannot be granted to groups`)
+}
+
+func (s *grantRevokeSuite) TestInvalidAccess(c *gc.C) {
+	_, err := s.run(c, "--acl", "owner", "sam", "model1")
+	c.Assert(err, gc.ErrorMatches, `invalid model access permission "owner"`)
+}
+
 func (s *grantRevokeSuite) TestBlockGrant(c *gc.C) {
 	s.fake.err = &params.Error{Code: params.CodeOperationBlocked}
 	_, err := s.run(c, "sam", "foo")
@@ -104,13 +165,13 @@ var _ = gc.Suite(&grantSuite{})
 func (s *grantSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewGrantCommandForTest(fake, s.store)
+		c, _ := model.NewGrantCommandForTest(fake, fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *grantSuite) TestInit(c *gc.C) {
-	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.store)
+	wrappedCmd, grantCmd := model.NewGrantCommandForTest(s.fake, s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -136,13 +197,13 @@ var _ = gc.Suite(&revokeSuite{})
 func (s *revokeSuite) SetUpTest(c *gc.C) {
 	s.grantRevokeSuite.SetUpTest(c)
 	s.cmdFactory = func(fake *fakeGrantRevokeAPI) cmd.Command {
-		c, _ := model.NewRevokeCommandForTest(fake, s.store)
+		c, _ := model.NewRevokeCommandForTest(fake, fake, fake, s.store)
 		return c
 	}
 }
 
 func (s *revokeSuite) TestInit(c *gc.C) {
-	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.store)
+	wrappedCmd, revokeCmd := model.NewRevokeCommandForTest(s.fake, s.fake, s.fake, s.store)
 	err := testing.InitCommand(wrappedCmd, []string{})
 	c.Assert(err, gc.ErrorMatches, "no user specified")
 
@@ -160,10 +221,13 @@ func (s *revokeSuite) TestInit(c *gc.C) {
 }
 
 type fakeGrantRevokeAPI struct {
-	err        error
-	user       string
-	access     string
-	modelUUIDs []string
+	err          error
+	user         string
+	access       string
+	modelUUIDs   []string
+	controller   bool
+	group        bool
+	applications []string
 }
 
 func (f *fakeGrantRevokeAPI) Close() error { return nil }
@@ -176,6 +240,48 @@ func (f *fakeGrantRevokeAPI) RevokeModel(user, access string, modelUUIDs ...stri
 	return f.fake(user, access, modelUUIDs...)
 }
 
+func (f *fakeGrantRevokeAPI) GrantController(user, access string) error {
+	f.controller = true
+	return f.fake(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeController(user, access string) error {
+	f.controller = true
+	return f.fake(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) GrantModelGroup(group, access string, modelUUIDs ...string) error {
+	f.group = true
+	return f.fake(group, access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeModelGroup(group, access string, modelUUIDs ...string) error {
+	f.group = true
+	return f.fake(group, access, modelUUIDs...)
+}
+
+func (f *fakeGrantRevokeAPI) GrantControllerGroup(group, access string) error {
+	f.controller = true
+	f.group = true
+	return f.fake(group, access)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeControllerGroup(group, access string) error {
+	f.controller = true
+	f.group = true
+	return f.fake(group, access)
+}
+
+func (f *fakeGrantRevokeAPI) GrantApplication(user, access string, applications ...string) error {
+	f.applications = applications
+	return f.fake(user, access)
+}
+
+func (f *fakeGrantRevokeAPI) RevokeApplication(user, access string, applications ...string) error {
+	f.applications = applications
+	return f.fake(user, access)
+}
+
 func (f *fakeGrantRevokeAPI) fake(user, access string, modelUUIDs ...string) error {
 	f.user = user
 	f.access = access
@@ -52,3 +52,25 @@ func NewListCommandForTest(api SubnetAPI) (cmd.Command, *ListCommand) {
 	}
 	return modelcmd.Wrap(cmd), &ListCommand{cmd}
 }
+
+type ReserveAddressCommand struct {
+	*reserveAddressCommand
+}
+
+func NewReserveAddressCommandForTest(api SubnetAPI) (cmd.Command, *ReserveAddressCommand) {
+	cmd := &reserveAddressCommand{
+		SubnetCommandBase: SubnetCommandBase{api: api},
+	}
+	return modelcmd.Wrap(cmd), &ReserveAddressCommand{cmd}
+}
+
+type ReleaseAddressCommand struct {
+	*releaseAddressCommand
+}
+
+func NewReleaseAddressCommandForTest(api SubnetAPI) (cmd.Command, *ReleaseAddressCommand) {
+	cmd := &releaseAddressCommand{
+		SubnetCommandBase: SubnetCommandBase{api: api},
+	}
+	return modelcmd.Wrap(cmd), &ReleaseAddressCommand{cmd}
+}
@@ -195,6 +195,21 @@ func (sa *StubAPI) RemoveSubnet(subnetCIDR names.SubnetTag) error {
 	return sa.NextErr()
 }
 
+func (sa *StubAPI) ReserveAddress(address string, subnetCIDR names.SubnetTag, machineTag *names.MachineTag) error {
+	if machineTag == nil {
+		// See the comment in ListSubnets below.
+		sa.MethodCall(sa, "ReserveAddress", address, subnetCIDR, nil)
+	} else {
+		sa.MethodCall(sa, "ReserveAddress", address, subnetCIDR, machineTag)
+	}
+	return sa.NextErr()
+}
+
+func (sa *StubAPI) ReleaseAddress(address string) error {
+	sa.MethodCall(sa, "ReleaseAddress", address)
+	return sa.NextErr()
+}
+
 func (sa *StubAPI) ListSubnets(withSpace *names.SpaceTag, withZone string) ([]params.Subnet, error) {
 	if withSpace == nil {
 		// Due to the way CheckCall works (using jc.DeepEquals
@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewReleaseAddressCommand returns a command used to release a
+// reserved IP address.
+func NewReleaseAddressCommand() cmd.Command {
+	return modelcmd.Wrap(&releaseAddressCommand{})
+}
+
+// releaseAddressCommand calls the API to release a previously
+// reserved IP address.
+type releaseAddressCommand struct {
+	SubnetCommandBase
+
+	Address string
+}
+
+const releaseAddressCommandDoc = `
+Releases an IP address reserved with "juju reserve-address". Machines
+already using the address keep it, but it can be allocated to other
+machines once it is no longer in use.
+
+See also:
+    reserve-address
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *releaseAddressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "release-address",
+		Args:    "<address>",
+		Purpose: "Release a reserved IP address.",
+		Doc:     strings.TrimSpace(releaseAddressCommandDoc),
+	}
+}
+
+// Init is defined on the cmd.Command interface. It checks the
+// arguments for sanity and sets up the command to run.
+func (c *releaseAddressCommand) Init(args []string) error {
+	// Ensure we have exactly 1 argument.
+	err := c.CheckNumArgs(args, []error{errNoAddress})
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(args[0])
+	if ip == nil {
+		return errors.Errorf("%q is not a valid IP address", args[0])
+	}
+	c.Address = ip.String()
+
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *releaseAddressCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithAPI(ctx, func(api SubnetAPI, ctx *cmd.Context) error {
+		if err := api.ReleaseAddress(c.Address); err != nil {
+			return errors.Annotatef(err, "cannot release address %q", c.Address)
+		}
+
+		ctx.Infof("released address %q", c.Address)
+		return nil
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/subnet"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ReleaseAddressSuite struct {
+	BaseSubnetSuite
+}
+
+var _ = gc.Suite(&ReleaseAddressSuite{})
+
+func (s *ReleaseAddressSuite) SetUpTest(c *gc.C) {
+	s.BaseSubnetSuite.SetUpTest(c)
+	s.command, _ = subnet.NewReleaseAddressCommandForTest(s.api)
+	c.Assert(s.command, gc.NotNil)
+}
+
+func (s *ReleaseAddressSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		about         string
+		args          []string
+		expectAddress string
+		expectErr     string
+	}{{
+		about:     "no arguments",
+		expectErr: "address is required",
+	}, {
+		about:     "an invalid address",
+		args:      s.Strings("foo"),
+		expectErr: `"foo" is not a valid IP address`,
+	}, {
+		about:     "too many arguments",
+		args:      s.Strings("10.20.0.1", "bar"),
+		expectErr: `unrecognized args: \["bar"\]`,
+	}, {
+		about:         "valid address",
+		args:          s.Strings("10.20.0.1"),
+		expectAddress: "10.20.0.1",
+	}} {
+		c.Logf("test #%d: %s", i, test.about)
+		wrappedCommand, command := subnet.NewReleaseAddressCommandForTest(s.api)
+		err := coretesting.InitCommand(wrappedCommand, test.args)
+		if test.expectErr != "" {
+			c.Check(err, gc.ErrorMatches, test.expectErr)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(command.Address, gc.Equals, test.expectAddress)
+		}
+
+		// No API calls should be recorded at this stage.
+		s.api.CheckCallNames(c)
+	}
+}
+
+func (s *ReleaseAddressSuite) TestRunSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`released address "10.20.0.42"\n`,
+		"", // empty stdout.
+		"10.20.0.42",
+	)
+
+	s.api.CheckCallNames(c, "ReleaseAddress", "Close")
+	s.api.CheckCall(c, 0, "ReleaseAddress", "10.20.0.42")
+}
+
+func (s *ReleaseAddressSuite) TestRunWithUnknownAddressFails(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf("reservation for address %q", "10.20.0.42"))
+
+	err := s.AssertRunFails(c,
+		`cannot release address "10.20.0.42": reservation for address "10.20.0.42" not found`,
+		"10.20.0.42",
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	s.api.CheckCallNames(c, "ReleaseAddress", "Close")
+}
@@ -0,0 +1,130 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewReserveAddressCommand returns a command used to reserve an IP
+// address in a subnet.
+func NewReserveAddressCommand() cmd.Command {
+	return modelcmd.Wrap(&reserveAddressCommand{})
+}
+
+// reserveAddressCommand calls the API to reserve an IP address in
+// one of the subnets of a space.
+type reserveAddressCommand struct {
+	SubnetCommandBase
+
+	CIDR    names.SubnetTag
+	Address string
+	Machine string
+}
+
+const reserveAddressCommandDoc = `
+Reserves an IP address in an existing subnet, which must be part of a
+space. A reserved address cannot be reserved again until it is
+released.
+
+When --machine is given, the address is reserved for that machine
+(usually a container). Container addresses are allocated through the
+cloud provider, and the reserved address is requested instead of a
+random one from the subnet. Only MAAS supports this; reserving an
+address for a machine fails on other clouds, including OpenStack.
+Without --machine, the address is only recorded as reserved: Juju does
+not stop the cloud provider from allocating it to a machine.
+
+Reservations are kept when their machine is removed, until they are
+released with release-address.
+
+Examples:
+
+    juju reserve-address 10.20.0.0/24 10.20.0.42 --machine 0/lxd/1
+
+See also:
+    release-address
+    list-subnets
+`
+
+// Info is defined on the cmd.Command interface.
+func (c *reserveAddressCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "reserve-address",
+		Args:    "<CIDR> <address>",
+		Purpose: "Reserve an IP address in a subnet.",
+		Doc:     strings.TrimSpace(reserveAddressCommandDoc),
+	}
+}
+
+// SetFlags is defined on the cmd.Command interface.
+func (c *reserveAddressCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.SubnetCommandBase.SetFlags(f)
+	f.StringVar(&c.Machine, "machine", "", "the machine to reserve the address for")
+}
+
+// Init is defined on the cmd.Command interface. It checks the
+// arguments for sanity and sets up the command to run.
+func (c *reserveAddressCommand) Init(args []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "invalid arguments specified")
+
+	// Ensure we have at least 2 arguments.
+	err = c.CheckNumArgs(args, []error{errNoCIDR, errNoAddress})
+	if err != nil {
+		return err
+	}
+
+	// Validate given CIDR.
+	c.CIDR, err = c.ValidateCIDR(args[0], true)
+	if err != nil {
+		return err
+	}
+
+	// Validate the address is in the subnet.
+	ip := net.ParseIP(args[1])
+	if ip == nil {
+		return errors.Errorf("%q is not a valid IP address", args[1])
+	}
+	if _, ipNet, _ := net.ParseCIDR(c.CIDR.Id()); !ipNet.Contains(ip) {
+		return errors.Errorf("address %q is not in subnet %q", args[1], c.CIDR.Id())
+	}
+	c.Address = ip.String()
+
+	if c.Machine != "" && !names.IsValidMachine(c.Machine) {
+		return errors.Errorf("%q is not a valid machine ID", c.Machine)
+	}
+
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *reserveAddressCommand) Run(ctx *cmd.Context) error {
+	return c.RunWithAPI(ctx, func(api SubnetAPI, ctx *cmd.Context) error {
+		var machineTag *names.MachineTag
+		if c.Machine != "" {
+			tag := names.NewMachineTag(c.Machine)
+			machineTag = &tag
+		}
+
+		// Try reserving the address.
+		if err := api.ReserveAddress(c.Address, c.CIDR, machineTag); err != nil {
+			return errors.Annotatef(err, "cannot reserve address %q", c.Address)
+		}
+
+		if machineTag != nil {
+			ctx.Infof("reserved address %q in subnet %q for machine %q", c.Address, c.CIDR.Id(), c.Machine)
+		} else {
+			ctx.Infof("reserved address %q in subnet %q", c.Address, c.CIDR.Id())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package subnet_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/subnet"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ReserveAddressSuite struct {
+	BaseSubnetSuite
+}
+
+var _ = gc.Suite(&ReserveAddressSuite{})
+
+func (s *ReserveAddressSuite) SetUpTest(c *gc.C) {
+	s.BaseSubnetSuite.SetUpTest(c)
+	s.command, _ = subnet.NewReserveAddressCommandForTest(s.api)
+	c.Assert(s.command, gc.NotNil)
+}
+
+func (s *ReserveAddressSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		about         string
+		args          []string
+		expectCIDR    string
+		expectAddress string
+		expectMachine string
+		expectErr     string
+	}{{
+		about:     "no arguments",
+		expectErr: "invalid arguments specified: CIDR is required",
+	}, {
+		about:     "only a CIDR",
+		args:      s.Strings("10.20.0.0/24"),
+		expectErr: "invalid arguments specified: address is required",
+	}, {
+		about:     "an invalid CIDR",
+		args:      s.Strings("foo", "10.20.0.1"),
+		expectErr: `invalid arguments specified: "foo" is not a valid CIDR`,
+	}, {
+		about:     "an invalid address",
+		args:      s.Strings("10.20.0.0/24", "bar"),
+		expectErr: `invalid arguments specified: "bar" is not a valid IP address`,
+	}, {
+		about:     "address outside of the subnet",
+		args:      s.Strings("10.20.0.0/24", "10.30.0.1"),
+		expectErr: `invalid arguments specified: address "10.30.0.1" is not in subnet "10.20.0.0/24"`,
+	}, {
+		about:     "an invalid machine",
+		args:      s.Strings("10.20.0.0/24", "10.20.0.1", "--machine", "foo"),
+		expectErr: `invalid arguments specified: "foo" is not a valid machine ID`,
+	}, {
+		about:     "too many arguments",
+		args:      s.Strings("10.20.0.0/24", "10.20.0.1", "baz"),
+		expectErr: `invalid arguments specified: unrecognized args: \["baz"\]`,
+	}, {
+		about:         "CIDR and address",
+		args:          s.Strings("10.20.0.0/24", "10.20.0.1"),
+		expectCIDR:    "10.20.0.0/24",
+		expectAddress: "10.20.0.1",
+	}, {
+		about:         "CIDR, address and machine",
+		args:          s.Strings("2001:db8::/32", "2001:db8::42", "--machine", "0/lxd/1"),
+		expectCIDR:    "2001:db8::/32",
+		expectAddress: "2001:db8::42",
+		expectMachine: "0/lxd/1",
+	}} {
+		c.Logf("test #%d: %s", i, test.about)
+		wrappedCommand, command := subnet.NewReserveAddressCommandForTest(s.api)
+		err := coretesting.InitCommand(wrappedCommand, test.args)
+		if test.expectErr != "" {
+			c.Check(err, gc.ErrorMatches, test.expectErr)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(command.CIDR.Id(), gc.Equals, test.expectCIDR)
+			c.Check(command.Address, gc.Equals, test.expectAddress)
+			c.Check(command.Machine, gc.Equals, test.expectMachine)
+		}
+
+		// No API calls should be recorded at this stage.
+		s.api.CheckCallNames(c)
+	}
+}
+
+func (s *ReserveAddressSuite) TestRunSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`reserved address "10.20.0.42" in subnet "10.20.0.0/24"\n`,
+		"", // empty stdout.
+		"10.20.0.0/24", "10.20.0.42",
+	)
+
+	s.api.CheckCallNames(c, "ReserveAddress", "Close")
+	s.api.CheckCall(c, 0, "ReserveAddress", "10.20.0.42", names.NewSubnetTag("10.20.0.0/24"), nil)
+}
+
+func (s *ReserveAddressSuite) TestRunWithMachineSucceeds(c *gc.C) {
+	s.AssertRunSucceeds(c,
+		`reserved address "10.20.0.42" in subnet "10.20.0.0/24" for machine "0/lxd/1"\n`,
+		"", // empty stdout.
+		"10.20.0.0/24", "10.20.0.42", "--machine", "0/lxd/1",
+	)
+
+	machineTag := names.NewMachineTag("0/lxd/1")
+	s.api.CheckCallNames(c, "ReserveAddress", "Close")
+	s.api.CheckCall(c, 0, "ReserveAddress", "10.20.0.42", names.NewSubnetTag("10.20.0.0/24"), &machineTag)
+}
+
+func (s *ReserveAddressSuite) TestRunWhenAlreadyReservedFails(c *gc.C) {
+	s.api.SetErrors(errors.AlreadyExistsf("reservation for address %q", "10.20.0.42"))
+
+	err := s.AssertRunFails(c,
+		`cannot reserve address "10.20.0.42": reservation for address "10.20.0.42" already exists`,
+		"10.20.0.0/24", "10.20.0.42",
+	)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	s.api.CheckCallNames(c, "ReserveAddress", "Close")
+}
@@ -45,6 +45,13 @@ type SubnetAPI interface {
 	// related entites are cleaned up. It will fail if the subnet is
 	// still in use by any machines.
 	RemoveSubnet(subnetCIDR names.SubnetTag) error
+
+	// ReserveAddress reserves an IP address in the given subnet, for
+	// the given machine when not nil.
+	ReserveAddress(address string, subnetCIDR names.SubnetTag, machineTag *names.MachineTag) error
+
+	// ReleaseAddress releases a previously reserved IP address.
+	ReleaseAddress(address string) error
 }
 
 // mvpAPIShim forwards SubnetAPI methods to the real API facade for
@@ -68,6 +75,14 @@ func (m *mvpAPIShim) ListSubnets(withSpace *names.SpaceTag, withZone string) ([]
 	return m.facade.ListSubnets(withSpace, withZone)
 }
 
+func (m *mvpAPIShim) ReserveAddress(address string, subnetCIDR names.SubnetTag, machineTag *names.MachineTag) error {
+	return m.facade.ReserveAddress(address, subnetCIDR, machineTag)
+}
+
+func (m *mvpAPIShim) ReleaseAddress(address string) error {
+	return m.facade.ReleaseAddress(address)
+}
+
 var logger = loggo.GetLogger("juju.cmd.juju.subnet")
 
 // SubnetCommandBase is the base type embedded into all subnet
@@ -114,6 +129,7 @@ var (
 	errNoCIDROrID = errors.New("either CIDR or provider ID is required")
 	errNoSpace    = errors.New("space name is required")
 	errNoZones    = errors.New("at least one zone is required")
+	errNoAddress  = errors.New("address is required")
 )
 
 // CheckNumArgs is a helper used to validate the number of arguments
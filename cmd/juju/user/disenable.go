@@ -41,6 +41,23 @@ See also:
     disable-user
     login`[1:]
 
+var usageUnlockUserSummary = `
+Unlocks a Juju user locked out after too many failed logins.`[1:]
+
+var usageUnlockUserDetails = `
+A local user is locked out once they fail to log in with their password
+as many times in a row as the controller's login-lockout-attempts
+setting allows. A locked out user cannot log in, even with the right
+password, until a controller administrator unlocks them.
+
+Examples:
+    juju unlock-user bob
+
+See also: 
+    show-user
+    change-user-password
+    enable-user`[1:]
+
 // disenableUserBase common code for enable/disable user commands
 type disenableUserBase struct {
 	modelcmd.ControllerCommandBase
@@ -66,6 +83,15 @@ type enableCommand struct {
 	disenableUserBase
 }
 
+func NewUnlockCommand() cmd.Command {
+	return modelcmd.WrapController(&unlockCommand{})
+}
+
+// unlockCommand unlocks users locked out after too many failed logins.
+type unlockCommand struct {
+	disenableUserBase
+}
+
 // Info implements Command.Info.
 func (c *disableCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -86,6 +112,16 @@ func (c *enableCommand) Info() *cmd.Info {
 	}
 }
 
+// Info implements Command.Info.
+func (c *unlockCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "unlock-user",
+		Args:    "<user name>",
+		Purpose: usageUnlockUserSummary,
+		Doc:     usageUnlockUserDetails,
+	}
+}
+
 // Init implements Command.Init.
 func (c *disenableUserBase) Init(args []string) error {
 	if len(args) == 0 {
@@ -104,11 +140,12 @@ func (c *disenableUserBase) Username() string {
 	return c.User
 }
 
-// disenableUserAPI defines the API methods that the disable and enable
-// commands use.
+// disenableUserAPI defines the API methods that the disable, enable
+// and unlock commands use.
 type disenableUserAPI interface {
 	EnableUser(username string) error
 	DisableUser(username string) error
+	UnlockUser(username string) error
 	Close() error
 }
 
@@ -147,3 +184,21 @@ func (c *enableCommand) Run(ctx *cmd.Context) error {
 	ctx.Infof("User %q enabled", c.User)
 	return nil
 }
+
+// Run implements Command.Run.
+func (c *unlockCommand) Run(ctx *cmd.Context) error {
+	if c.api == nil {
+		api, err := c.NewUserManagerAPIClient()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.api = api
+		defer c.api.Close()
+	}
+
+	if err := c.api.UnlockUser(c.User); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("User %q unlocked", c.User)
+	return nil
+}
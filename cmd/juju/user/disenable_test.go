@@ -62,6 +62,8 @@ func (s *DisableUserSuite) TestInit(c *gc.C) {
 	s.testInit(c, wrappedCommand, command)
 	wrappedCommand, command = user.NewDisableCommandForTest(nil, s.store)
 	s.testInit(c, wrappedCommand, command)
+	wrappedCommand, command = user.NewUnlockCommandForTest(nil, s.store)
+	s.testInit(c, wrappedCommand, command)
 }
 
 func (s *DisableUserSuite) TestDisable(c *gc.C) {
@@ -80,9 +82,18 @@ func (s *DisableUserSuite) TestEnable(c *gc.C) {
 	c.Assert(s.mock.enable, gc.Equals, username)
 }
 
+func (s *DisableUserSuite) TestUnlock(c *gc.C) {
+	username := "testing"
+	unlockCommand, _ := user.NewUnlockCommandForTest(s.mock, s.store)
+	_, err := testing.RunCommand(c, unlockCommand, username)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.unlock, gc.Equals, username)
+}
+
 type mockDisenableUserAPI struct {
 	enable  string
 	disable string
+	unlock  string
 }
 
 func (m *mockDisenableUserAPI) Close() error {
@@ -98,3 +109,8 @@ func (m *mockDisenableUserAPI) DisableUser(username string) error {
 	m.disable = username
 	return nil
 }
+
+func (m *mockDisenableUserAPI) UnlockUser(username string) error {
+	m.unlock = username
+	return nil
+}
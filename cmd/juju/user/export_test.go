@@ -87,9 +87,57 @@ func NewEnableCommandForTest(api disenableUserAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c), &DisenableUserBase{&c.disenableUserBase}
 }
 
+// NewUnlockCommandForTest returns an unlock-user command with the api
+// provided as specified.
+func NewUnlockCommandForTest(api disenableUserAPI, store jujuclient.ClientStore) (cmd.Command, *DisenableUserBase) {
+	c := &unlockCommand{disenableUserBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c), &DisenableUserBase{&c.disenableUserBase}
+}
+
 // NewListCommand returns a ListCommand with the api provided as specified.
 func NewListCommandForTest(api UserInfoAPI, store jujuclient.ClientStore) cmd.Command {
 	c := &listCommand{infoCommandBase: infoCommandBase{api: api}}
 	c.SetClientStore(store)
 	return modelcmd.WrapController(c)
 }
+
+// NewAddGroupCommandForTest returns an add-group command with the api
+// provided as specified.
+func NewAddGroupCommandForTest(api UserGroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveGroupCommandForTest returns a remove-group command with the
+// api provided as specified.
+func NewRemoveGroupCommandForTest(api UserGroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeGroupCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddToGroupCommandForTest returns an add-to-group command with the
+// api provided as specified.
+func NewAddToGroupCommandForTest(api UserGroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addToGroupCommand{groupMembersCommandBase{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveFromGroupCommandForTest returns a remove-from-group command
+// with the api provided as specified.
+func NewRemoveFromGroupCommandForTest(api UserGroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeFromGroupCommand{groupMembersCommandBase{groupCommandBase: groupCommandBase{api: api}}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewListGroupsCommandForTest returns a groups command with the api
+// provided as specified.
+func NewListGroupsCommandForTest(api UserGroupAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &listGroupsCommand{groupCommandBase: groupCommandBase{api: api}}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
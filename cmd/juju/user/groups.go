@@ -0,0 +1,389 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageAddGroupSummary = `
+Adds a group of Juju users.`[1:]
+
+var usageAddGroupDetails = `
+Model and controller access can be granted to a group with
+` + "`juju grant --group`" + `; every member of the group then has that
+access, in addition to any granted to them directly.
+
+Groups whose names carry the domain of an external identity provider,
+such as "devs@external", are external groups. Their members are
+recorded from the identity provider each time a user logs in, and
+cannot be changed with ` + "`juju add-to-group`" + `.
+
+Examples:
+    juju add-group devs
+    juju add-group devs@external
+
+See also: 
+    add-to-group
+    groups
+    grant
+    remove-group`[1:]
+
+var usageRemoveGroupSummary = `
+Removes a group of Juju users.`[1:]
+
+var usageRemoveGroupDetails = `
+All access granted to the group is removed along with it. Access
+granted directly to its members is not affected.
+
+Examples:
+    juju remove-group devs
+
+See also: 
+    add-group
+    groups`[1:]
+
+var usageAddToGroupSummary = `
+Adds Juju users to a group.`[1:]
+
+var usageAddToGroupDetails = `
+Examples:
+    juju add-to-group devs bob mary
+
+See also: 
+    add-group
+    remove-from-group
+    groups`[1:]
+
+var usageRemoveFromGroupSummary = `
+Removes Juju users from a group.`[1:]
+
+var usageRemoveFromGroupDetails = `
+Examples:
+    juju remove-from-group devs bob
+
+See also: 
+    add-to-group
+    groups`[1:]
+
+var usageListGroupsSummary = `
+Lists groups of Juju users.`[1:]
+
+var usageListGroupsDetails = `
+By default, the tabular format is used.
+
+Examples:
+    juju groups
+
+See also: 
+    add-group
+    add-to-group`[1:]
+
+// UserGroupAPI defines the API methods that the group commands use.
+type UserGroupAPI interface {
+	AddUserGroup(name string) error
+	RemoveUserGroup(name string) error
+	AddUserGroupMembers(group string, usernames ...string) error
+	RemoveUserGroupMembers(group string, usernames ...string) error
+	UserGroups() ([]params.UserGroupInfo, error)
+	Close() error
+}
+
+// groupCommandBase holds the fields common to the group commands.
+type groupCommandBase struct {
+	modelcmd.ControllerCommandBase
+	api UserGroupAPI
+}
+
+func (c *groupCommandBase) getAPI() (UserGroupAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewUserManagerAPIClient()
+}
+
+// NewAddGroupCommand returns a command to add a user group.
+func NewAddGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addGroupCommand{})
+}
+
+// addGroupCommand adds a user group.
+type addGroupCommand struct {
+	groupCommandBase
+	Group string
+}
+
+// Info implements Command.Info.
+func (c *addGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-group",
+		Args:    "<group name>",
+		Purpose: usageAddGroupSummary,
+		Doc:     usageAddGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *addGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *addGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddUserGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q added", c.Group)
+	return nil
+}
+
+// NewRemoveGroupCommand returns a command to remove a user group.
+func NewRemoveGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeGroupCommand{})
+}
+
+// removeGroupCommand removes a user group.
+type removeGroupCommand struct {
+	groupCommandBase
+	Group string
+}
+
+// Info implements Command.Info.
+func (c *removeGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-group",
+		Args:    "<group name>",
+		Purpose: usageRemoveGroupSummary,
+		Doc:     usageRemoveGroupDetails,
+	}
+}
+
+// Init implements Command.Init.
+func (c *removeGroupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.Run.
+func (c *removeGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveUserGroup(c.Group); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Group %q removed", c.Group)
+	return nil
+}
+
+// groupMembersCommandBase holds the fields common to the commands
+// that change group membership.
+type groupMembersCommandBase struct {
+	groupCommandBase
+	Group string
+	Users []string
+}
+
+// Init implements Command.Init.
+func (c *groupMembersCommandBase) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no group name supplied")
+	}
+	c.Group = args[0]
+	c.Users = args[1:]
+	if len(c.Users) == 0 {
+		return errors.New("no username supplied")
+	}
+	return nil
+}
+
+// NewAddToGroupCommand returns a command to add users to a group.
+func NewAddToGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&addToGroupCommand{})
+}
+
+// addToGroupCommand adds users to a group.
+type addToGroupCommand struct {
+	groupMembersCommandBase
+}
+
+// Info implements Command.Info.
+func (c *addToGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-to-group",
+		Args:    "<group name> <user name> ...",
+		Purpose: usageAddToGroupSummary,
+		Doc:     usageAddToGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *addToGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.AddUserGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Added %s to group %q", strings.Join(c.Users, ", "), c.Group)
+	return nil
+}
+
+// NewRemoveFromGroupCommand returns a command to remove users from a
+// group.
+func NewRemoveFromGroupCommand() cmd.Command {
+	return modelcmd.WrapController(&removeFromGroupCommand{})
+}
+
+// removeFromGroupCommand removes users from a group.
+type removeFromGroupCommand struct {
+	groupMembersCommandBase
+}
+
+// Info implements Command.Info.
+func (c *removeFromGroupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-from-group",
+		Args:    "<group name> <user name> ...",
+		Purpose: usageRemoveFromGroupSummary,
+		Doc:     usageRemoveFromGroupDetails,
+	}
+}
+
+// Run implements Command.Run.
+func (c *removeFromGroupCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	if err := api.RemoveUserGroupMembers(c.Group, c.Users...); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Removed %s from group %q", strings.Join(c.Users, ", "), c.Group)
+	return nil
+}
+
+// NewListGroupsCommand returns a command to list user groups.
+func NewListGroupsCommand() cmd.Command {
+	return modelcmd.WrapController(&listGroupsCommand{})
+}
+
+// listGroupsCommand lists user groups.
+type listGroupsCommand struct {
+	groupCommandBase
+	out cmd.Output
+}
+
+// GroupInfo defines the serialization behaviour of the group
+// information.
+type GroupInfo struct {
+	Name        string   `yaml:"name" json:"name"`
+	CreatedBy   string   `yaml:"created-by" json:"created-by"`
+	DateCreated string   `yaml:"date-created" json:"date-created"`
+	External    bool     `yaml:"external,omitempty" json:"external,omitempty"`
+	Members     []string `yaml:"members,omitempty" json:"members,omitempty"`
+}
+
+// Info implements Command.Info.
+func (c *listGroupsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "groups",
+		Purpose: usageListGroupsSummary,
+		Doc:     usageListGroupsDetails,
+		Aliases: []string{"list-groups"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listGroupsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatGroupsTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listGroupsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	groups, err := api.UserGroups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output := make([]GroupInfo, len(groups))
+	for i, group := range groups {
+		output[i] = GroupInfo{
+			Name:        group.Name,
+			CreatedBy:   group.CreatedBy,
+			DateCreated: group.DateCreated.Format("2006-01-02"),
+			External:    group.External,
+			Members:     group.Members,
+		}
+	}
+	return c.out.Write(ctx, output)
+}
+
+func formatGroupsTabular(value interface{}) ([]byte, error) {
+	groups, ok := value.([]GroupInfo)
+	if !ok {
+		return nil, errors.Errorf("expected value of type %T, got %T", groups, value)
+	}
+	var out bytes.Buffer
+	const (
+		// To format things into columns.
+		minwidth = 0
+		tabwidth = 1
+		padding  = 2
+		padchar  = ' '
+		flags    = 0
+	)
+	tw := tabwriter.NewWriter(&out, minwidth, tabwidth, padding, padchar, flags)
+	fmt.Fprintf(tw, "NAME\tCREATED BY\tMEMBERS\n")
+	for _, group := range groups {
+		members := strings.Join(group.Members, ", ")
+		if group.External {
+			members = "(external)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", group.Name, group.CreatedBy, members)
+	}
+	tw.Flush()
+	return out.Bytes(), nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package user_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/testing"
+)
+
+type GroupsSuite struct {
+	BaseSuite
+	mock *mockUserGroupAPI
+}
+
+var _ = gc.Suite(&GroupsSuite{})
+
+func (s *GroupsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.mock = &mockUserGroupAPI{}
+}
+
+func (s *GroupsSuite) TestAddGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store), "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.added, gc.Equals, "devs")
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Group \"devs\" added\n")
+}
+
+func (s *GroupsSuite) TestAddGroupInit(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store))
+	c.Assert(err, gc.ErrorMatches, "no group name supplied")
+	_, err = testing.RunCommand(c, user.NewAddGroupCommandForTest(s.mock, s.store), "devs", "ops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["ops"\]`)
+}
+
+func (s *GroupsSuite) TestRemoveGroup(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewRemoveGroupCommandForTest(s.mock, s.store), "devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.removed, gc.Equals, "devs")
+}
+
+func (s *GroupsSuite) TestAddToGroup(c *gc.C) {
+	ctx, err := testing.RunCommand(c, user.NewAddToGroupCommandForTest(s.mock, s.store), "devs", "bob", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.group, gc.Equals, "devs")
+	c.Assert(s.mock.addedMembers, jc.DeepEquals, []string{"bob", "mary"})
+	c.Assert(testing.Stderr(ctx), gc.Equals, "Added bob, mary to group \"devs\"\n")
+}
+
+func (s *GroupsSuite) TestAddToGroupNoUser(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewAddToGroupCommandForTest(s.mock, s.store), "devs")
+	c.Assert(err, gc.ErrorMatches, "no username supplied")
+}
+
+func (s *GroupsSuite) TestRemoveFromGroup(c *gc.C) {
+	_, err := testing.RunCommand(c, user.NewRemoveFromGroupCommandForTest(s.mock, s.store), "devs", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mock.group, gc.Equals, "devs")
+	c.Assert(s.mock.removedMembers, jc.DeepEquals, []string{"bob"})
+}
+
+func (s *GroupsSuite) TestRemoveFromGroupError(c *gc.C) {
+	s.mock.err = errors.New("boom")
+	_, err := testing.RunCommand(c, user.NewRemoveFromGroupCommandForTest(s.mock, s.store), "devs", "bob")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *GroupsSuite) TestListGroups(c *gc.C) {
+	created := time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC)
+	s.mock.groups = []params.UserGroupInfo{{
+		Name:        "devs",
+		CreatedBy:   "admin@local",
+		DateCreated: created,
+		Members:     []string{"bob@local", "mary@local"},
+	}, {
+		Name:        "ops@external",
+		CreatedBy:   "admin@local",
+		DateCreated: created,
+		External:    true,
+		Members:     []string{"ann@external"},
+	}}
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mock, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"NAME          CREATED BY   MEMBERS\n"+
+		"devs          admin@local  bob@local, mary@local\n"+
+		"ops@external  admin@local  (external)\n")
+}
+
+func (s *GroupsSuite) TestListGroupsYaml(c *gc.C) {
+	s.mock.groups = []params.UserGroupInfo{{
+		Name:        "devs",
+		CreatedBy:   "admin@local",
+		DateCreated: time.Date(2016, 9, 1, 0, 0, 0, 0, time.UTC),
+		Members:     []string{"bob@local"},
+	}}
+	ctx, err := testing.RunCommand(c, user.NewListGroupsCommandForTest(s.mock, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(ctx), gc.Equals, ""+
+		"- name: devs\n"+
+		"  created-by: admin@local\n"+
+		"  date-created: 2016-09-01\n"+
+		"  members:\n"+
+		"  - bob@local\n")
+}
+
+type mockUserGroupAPI struct {
+	err            error
+	added          string
+	removed        string
+	group          string
+	addedMembers   []string
+	removedMembers []string
+	groups         []params.UserGroupInfo
+}
+
+func (m *mockUserGroupAPI) AddUserGroup(name string) error {
+	m.added = name
+	return m.err
+}
+
+func (m *mockUserGroupAPI) RemoveUserGroup(name string) error {
+	m.removed = name
+	return m.err
+}
+
+func (m *mockUserGroupAPI) AddUserGroupMembers(group string, usernames ...string) error {
+	m.group = group
+	m.addedMembers = usernames
+	return m.err
+}
+
+func (m *mockUserGroupAPI) RemoveUserGroupMembers(group string, usernames ...string) error {
+	m.group = group
+	m.removedMembers = usernames
+	return m.err
+}
+
+func (m *mockUserGroupAPI) UserGroups() ([]params.UserGroupInfo, error) {
+	return m.groups, m.err
+}
+
+func (m *mockUserGroupAPI) Close() error {
+	return nil
+}
@@ -21,7 +21,9 @@ Show information about a user.`[1:]
 
 var helpDetails = `
 By default, the YAML format is used and the user name is the current
-user.
+user. The access the user has on the controller (login, add-model or
+superuser) is shown if they have any, along with the time and source
+address of the user's most recent logins.
 
 
 Examples:
@@ -65,11 +67,21 @@ type infoCommand struct {
 
 // UserInfo defines the serialization behaviour of the user information.
 type UserInfo struct {
-	Username       string `yaml:"user-name" json:"user-name"`
-	DisplayName    string `yaml:"display-name" json:"display-name"`
-	DateCreated    string `yaml:"date-created" json:"date-created"`
-	LastConnection string `yaml:"last-connection" json:"last-connection"`
-	Disabled       bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	Username         string `yaml:"user-name" json:"user-name"`
+	DisplayName      string `yaml:"display-name" json:"display-name"`
+	DateCreated      string `yaml:"date-created" json:"date-created"`
+	LastConnection   string `yaml:"last-connection" json:"last-connection"`
+	ControllerAccess string `yaml:"controller-access,omitempty" json:"controller-access,omitempty"`
+	Disabled         bool   `yaml:"disabled,omitempty" json:"disabled,omitempty"`
+	LockedOut        bool   `yaml:"locked-out,omitempty" json:"locked-out,omitempty"`
+
+	LoginHistory []LoginRecord `yaml:"login-history,omitempty" json:"login-history,omitempty"`
+}
+
+// LoginRecord defines the serialization behaviour of a recorded login.
+type LoginRecord struct {
+	Time    string `yaml:"time" json:"time"`
+	Address string `yaml:"address" json:"address"`
 }
 
 // Info implements Command.Info.
@@ -138,16 +150,27 @@ func (c *infoCommandBase) apiUsersToUserInfoSlice(users []params.UserInfo) []Use
 	var now = time.Now()
 	for _, info := range users {
 		outInfo := UserInfo{
-			Username:       info.Username,
-			DisplayName:    info.DisplayName,
-			Disabled:       info.Disabled,
-			LastConnection: common.LastConnection(info.LastConnection, now, c.exactTime),
+			Username:         info.Username,
+			DisplayName:      info.DisplayName,
+			Disabled:         info.Disabled,
+			LockedOut:        info.LockedOut,
+			LastConnection:   common.LastConnection(info.LastConnection, now, c.exactTime),
+			ControllerAccess: string(info.ControllerAccess),
 		}
 		if c.exactTime {
 			outInfo.DateCreated = info.DateCreated.String()
 		} else {
 			outInfo.DateCreated = common.UserFriendlyDuration(info.DateCreated, now)
 		}
+		for _, login := range info.LoginHistory {
+			record := LoginRecord{Address: login.Address}
+			if c.exactTime {
+				record.Time = login.Time.String()
+			} else {
+				record.Time = common.UserFriendlyDuration(login.Time, now)
+			}
+			outInfo.LoginHistory = append(outInfo.LoginHistory, record)
+		}
 
 		output = append(output, outInfo)
 	}
@@ -56,6 +56,14 @@ func (*fakeUserInfoAPI) UserInfo(usernames []string, all usermanager.IncludeDisa
 	case "foobar":
 		info.Username = "foobar"
 		info.DisplayName = "Foo Bar"
+		info.ControllerAccess = params.ControllerAddModelAccess
+	case "jsmith":
+		info.Username = "jsmith"
+		info.LockedOut = true
+		info.LoginHistory = []params.LoginRecord{
+			{Time: lastConnection, Address: "10.0.0.1:45678"},
+			{Time: dateCreated, Address: "10.0.0.2:45678"},
+		}
 	default:
 		return nil, common.ErrPerm
 	}
@@ -89,9 +97,34 @@ func (s *UserInfoCommandSuite) TestUserInfoWithUsername(c *gc.C) {
 display-name: Foo Bar
 date-created: 1981-02-27
 last-connection: 2014-01-01
+controller-access: add-model
 `)
 }
 
+func (s *UserInfoCommandSuite) TestUserInfoLoginHistory(c *gc.C) {
+	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "jsmith")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `user-name: jsmith
+display-name: ""
+date-created: 1981-02-27
+last-connection: 2014-01-01
+locked-out: true
+login-history:
+- time: 2014-01-01
+  address: 10.0.0.1:45678
+- time: 1981-02-27
+  address: 10.0.0.2:45678
+`)
+}
+
+func (s *UserInfoCommandSuite) TestUserInfoLoginHistoryExactTime(c *gc.C) {
+	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "jsmith", "--exact-time", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testing.Stdout(context), gc.Equals, `
+{"user-name":"jsmith","display-name":"","date-created":"1981-02-27 16:10:05 +0000 UTC","last-connection":"2014-01-01 00:00:00 +0000 UTC","locked-out":true,"login-history":[{"time":"2014-01-01 00:00:00 +0000 UTC","address":"10.0.0.1:45678"},{"time":"1981-02-27 16:10:05 +0000 UTC","address":"10.0.0.2:45678"}]}
+`[1:])
+}
+
 func (s *UserInfoCommandSuite) TestUserInfoUserDoesNotExist(c *gc.C) {
 	_, err := testing.RunCommand(c, s.NewShowUserCommand(), "barfoo")
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -109,7 +142,7 @@ func (s *UserInfoCommandSuite) TestUserInfoFormatJsonWithUsername(c *gc.C) {
 	context, err := testing.RunCommand(c, s.NewShowUserCommand(), "foobar", "--format", "json")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(testing.Stdout(context), gc.Equals, `
-{"user-name":"foobar","display-name":"Foo Bar","date-created":"1981-02-27","last-connection":"2014-01-01"}
+{"user-name":"foobar","display-name":"Foo Bar","date-created":"1981-02-27","last-connection":"2014-01-01","controller-access":"add-model"}
 `[1:])
 }
 
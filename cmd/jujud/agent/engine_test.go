@@ -34,6 +34,7 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"charm-revision-updater",
+		"charm-upgrader",
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
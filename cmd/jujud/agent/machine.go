@@ -58,9 +58,12 @@ import (
 	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/resource/resourceadapters"
+	resourceworkers "github.com/juju/juju/resource/workers"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage/looputil"
 	"github.com/juju/juju/upgrades"
@@ -68,6 +71,7 @@ import (
 	"github.com/juju/juju/watcher"
 	"github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -82,6 +86,7 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/txnpruner"
+	"github.com/juju/juju/worker/upgraderollout"
 	"github.com/juju/juju/worker/upgradesteps"
 )
 
@@ -955,6 +960,33 @@ func (a *MachineAgent) startStateWorkers(st *state.State) (worker.Worker, error)
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour*2), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				paths := statebackups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.New(backupscheduler.Config{
+					Backend: backupscheduler.NewStateBackend(st, a.machineId, paths),
+					Clock:   clock.WallClock,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "upgraderollout", func() (worker.Worker, error) {
+				return upgraderollout.New(upgraderollout.Config{
+					Backend:  st,
+					Clock:    clock.WallClock,
+					Interval: time.Minute,
+				})
+			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "resourceprefetcher", func() (worker.Worker, error) {
+				return resourceworkers.NewPrefetcher(resourceworkers.PrefetchConfig{
+					Backend:  resourceadapters.NewPrefetchBackend(st),
+					Clock:    clock.WallClock,
+					Interval: time.Hour,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -987,6 +1019,7 @@ func (a *MachineAgent) startModelWorkers(uuid string) (worker.Worker, error) {
 		Clock:                       clock.WallClock,
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
+		CharmUpgradeCheckInterval:   10 * time.Minute,
 		InstPollerAggregationDelay:  3 * time.Second,
 		// TODO(perrito666) the status history pruning numbers need
 		// to be adjusting, after collecting user data from large install
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/charmrevision"
 	"github.com/juju/juju/worker/charmrevision/charmrevisionmanifold"
+	"github.com/juju/juju/worker/charmupgrader"
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/discoverspaces"
@@ -74,6 +75,10 @@ type ManifoldsConfig struct {
 	// revision worker will check for new revisions of known charms.
 	CharmRevisionUpdateInterval time.Duration
 
+	// CharmUpgradeCheckInterval determines how often the charm-
+	// upgrader worker will check for charm upgrades to apply.
+	CharmUpgradeCheckInterval time.Duration
+
 	// StatusHistoryPruner* values control status-history pruning
 	// behaviour.
 	StatusHistoryPrunerMaxHistoryTime time.Duration
@@ -232,6 +237,14 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade: charmrevisionmanifold.NewAPIFacade,
 			NewWorker: charmrevision.NewWorker,
 		})),
+		charmUpgraderName: ifNotDead(charmupgrader.Manifold(charmupgrader.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Period:        config.CharmUpgradeCheckInterval,
+
+			NewFacade: charmupgrader.NewFacade,
+			NewWorker: charmupgrader.NewWorker,
+		})),
 		metricWorkerName: ifNotDead(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
 		})),
@@ -311,6 +324,7 @@ const (
 	applicationscalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
 	charmRevisionUpdaterName = "charm-revision-updater"
+	charmUpgraderName        = "charm-upgrader"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
@@ -35,6 +35,7 @@ func (s *ManifoldsSuite) TestNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"charm-revision-updater",
+		"charm-upgrader",
 		"clock",
 		"compute-provisioner",
 		"environ-tracker",
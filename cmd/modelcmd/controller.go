@@ -156,6 +156,13 @@ func (c *ControllerCommandBase) NewUserManagerAPIClient() (*usermanager.Client,
 // credentials.  Only the UserManager and ModelManager may be accessed
 // through this API connection.
 func (c *ControllerCommandBase) NewAPIRoot() (api.Connection, error) {
+	return c.NewModelAPIRoot("")
+}
+
+// NewModelAPIRoot returns an API connection to the named model on the
+// current controller using the current credentials. If modelName is
+// empty, the connection is to the controller only.
+func (c *ControllerCommandBase) NewModelAPIRoot(modelName string) (api.Connection, error) {
 	if c.controllerName == "" {
 		controllers, err := c.store.AllControllers()
 		if err != nil {
@@ -173,7 +180,7 @@ func (c *ControllerCommandBase) NewAPIRoot() (api.Connection, error) {
 	if opener == nil {
 		opener = OpenFunc(c.JujuCommandBase.NewAPIRoot)
 	}
-	return opener.Open(c.store, c.controllerName, c.accountName, "")
+	return opener.Open(c.store, c.controllerName, c.accountName, modelName)
 }
 
 // ModelUUIDs returns the model UUIDs for the given model names.
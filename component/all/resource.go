@@ -143,6 +143,22 @@ func (r resources) registerPublicCommands() {
 			},
 		})
 	})
+
+	commands.RegisterEnvCommand(func() modelcmd.ModelCommand {
+		return cmd.NewHistoryCommand(cmd.HistoryDeps{
+			NewClient: func(c *cmd.HistoryCommand) (cmd.HistoryClient, error) {
+				return resourceadapters.NewAPIClient(c.NewAPIRoot)
+			},
+		})
+	})
+
+	commands.RegisterEnvCommand(func() modelcmd.ModelCommand {
+		return cmd.NewRollbackCommand(cmd.RollbackDeps{
+			NewClient: func(c *cmd.RollbackCommand) (cmd.RollbackClient, error) {
+				return resourceadapters.NewAPIClient(c.NewAPIRoot)
+			},
+		})
+	})
 }
 
 // TODO(katco): This seems to be common across components. Pop up a
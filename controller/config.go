@@ -10,11 +10,15 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils"
 	"gopkg.in/juju/environschema.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
@@ -48,6 +52,86 @@ const (
 	// NumaControlPolicyKey stores the value for this setting
 	SetNumaControlPolicyKey = "set-numa-control-policy"
 
+	// JujuManagementSpace is the network space that agents should use to
+	// communicate with controllers, and controllers with each other.
+	JujuManagementSpace = "juju-mgmt-space"
+
+	// BackupInterval is how often the controller takes a scheduled backup,
+	// e.g. "24h". Scheduled backups are disabled when it is empty or zero.
+	BackupInterval = "backup-interval"
+
+	// BackupKeepLast is the number of most recent scheduled backups kept.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDaily is the number of days for which the most recent
+	// scheduled backup of each day is kept.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the most recent
+	// scheduled backup of each week is kept.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// BackupsStorage is the target backup archives are stored in: one of
+	// "mongo" (the default), "local" or "s3".
+	BackupsStorage = "backups-storage"
+
+	// BackupsStorageDir is the directory on the controller machines backup
+	// archives are stored in, when BackupsStorage is "local".
+	BackupsStorageDir = "backups-storage-dir"
+
+	// BackupsS3Endpoint is the URL of the S3-compatible object store used
+	// when BackupsStorage is "s3". When empty, the endpoint of the AWS
+	// region BackupsS3Region is used.
+	BackupsS3Endpoint = "backups-s3-endpoint"
+
+	// BackupsS3Region is the region of the S3-compatible object store.
+	BackupsS3Region = "backups-s3-region"
+
+	// BackupsS3Bucket is the bucket backup archives are stored in.
+	BackupsS3Bucket = "backups-s3-bucket"
+
+	// BackupsS3AccessKey is the access key used to authenticate with the
+	// S3-compatible object store.
+	BackupsS3AccessKey = "backups-s3-access-key"
+
+	// BackupsS3SecretKey is the secret key used to authenticate with the
+	// S3-compatible object store.
+	BackupsS3SecretKey = "backups-s3-secret-key"
+
+	// PasswordMinLength is the minimum number of characters in a local
+	// user's password. There is no minimum when it is zero.
+	PasswordMinLength = "password-min-length"
+
+	// PasswordCharClasses is the number of distinct character classes
+	// (lower case, upper case, digits and other characters) a local
+	// user's password must contain.
+	PasswordCharClasses = "password-char-classes"
+
+	// PasswordMaxAge is how long a local user's password remains valid
+	// after it was last set, e.g. "2160h". Passwords never expire when it
+	// is empty or zero.
+	PasswordMaxAge = "password-max-age"
+
+	// LoginLockoutAttempts is the number of consecutive failed password
+	// logins after which a local user is locked out. Users are never
+	// locked out when it is zero.
+	LoginLockoutAttempts = "login-lockout-attempts"
+
+	// LoginHistorySize is the number of most recent logins recorded for
+	// each local user.
+	LoginHistorySize = "login-history-size"
+
+	// ResourceCacheSize is the maximum total size, in MiB, of the charm
+	// store resources the controller fetches ahead of time, across all
+	// of its models. Resources are not fetched ahead of time when it is
+	// zero.
+	ResourceCacheSize = "resource-cache-size"
+
+	// ResourceCacheMaxAge is how long a cached charm store resource that
+	// no application might use any more is kept, e.g. "168h". Such
+	// resources are only evicted to make room when it is empty or zero.
+	ResourceCacheMaxAge = "resource-cache-max-age"
+
 	// Attribute Defaults
 
 	// DefaultNumaControlPolicy should not be used by default.
@@ -59,6 +143,21 @@ const (
 
 	// DefaultApiPort is the default port the API server is listening on.
 	DefaultAPIPort int = 17070
+
+	// DefaultLoginHistorySize is the number of logins recorded for each
+	// local user when LoginHistorySize is not set.
+	DefaultLoginHistorySize = 10
+
+	// BackupsStorageMongo stores backup archives in the controller's
+	// mongo blobstore.
+	BackupsStorageMongo = "mongo"
+
+	// BackupsStorageLocal stores backup archives in a local directory.
+	BackupsStorageLocal = "local"
+
+	// BackupsStorageS3 stores backup archives in an S3-compatible object
+	// store.
+	BackupsStorageS3 = "s3"
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -72,6 +171,25 @@ var ControllerOnlyConfigAttributes = []string{
 	IdentityURL,
 	IdentityPublicKey,
 	SetNumaControlPolicyKey,
+	JujuManagementSpace,
+	BackupInterval,
+	BackupKeepLast,
+	BackupKeepDaily,
+	BackupKeepWeekly,
+	BackupsStorage,
+	BackupsStorageDir,
+	BackupsS3Endpoint,
+	BackupsS3Region,
+	BackupsS3Bucket,
+	BackupsS3AccessKey,
+	BackupsS3SecretKey,
+	PasswordMinLength,
+	PasswordCharClasses,
+	PasswordMaxAge,
+	LoginLockoutAttempts,
+	LoginHistorySize,
+	ResourceCacheSize,
+	ResourceCacheMaxAge,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -113,6 +231,20 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// asInt returns the named attribute as an integer, or 0 if it isn't found.
+func (c Config) asInt(name string) int {
+	switch value := c[name].(type) {
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(value)
+	case int64:
+		return int(value)
+	case int:
+		return value
+	}
+	return 0
+}
+
 // asString is a private helper method to keep the ugly string casting
 // in once place. It returns the given named attribute as a string,
 // returning "" if it isn't found.
@@ -193,6 +325,192 @@ func (c Config) NumaCtlPreference() bool {
 	return DefaultNumaControlPolicy
 }
 
+// JujuManagementSpace returns the name of the network space used for
+// agent-to-controller and controller-to-controller traffic, or "" if not set.
+func (c Config) JujuManagementSpace() string {
+	return c.asString(JujuManagementSpace)
+}
+
+// BackupInterval returns how often scheduled backups are taken, or 0 if
+// scheduled backups are disabled.
+func (c Config) BackupInterval() time.Duration {
+	value := c.asString(BackupInterval)
+	if value == "" {
+		return 0
+	}
+	// Validate ensures this is a valid duration.
+	interval, _ := time.ParseDuration(value)
+	return interval
+}
+
+// BackupKeepLast returns the number of most recent scheduled backups kept.
+func (c Config) BackupKeepLast() int {
+	return c.asInt(BackupKeepLast)
+}
+
+// BackupKeepDaily returns the number of days for which a daily scheduled
+// backup is kept.
+func (c Config) BackupKeepDaily() int {
+	return c.asInt(BackupKeepDaily)
+}
+
+// BackupKeepWeekly returns the number of weeks for which a weekly scheduled
+// backup is kept.
+func (c Config) BackupKeepWeekly() int {
+	return c.asInt(BackupKeepWeekly)
+}
+
+// BackupsStorage returns the name of the target new backup archives are
+// stored in.
+func (c Config) BackupsStorage() string {
+	if target := c.asString(BackupsStorage); target != "" {
+		return target
+	}
+	return BackupsStorageMongo
+}
+
+// BackupsStorageDir returns the directory backup archives are stored in
+// when using the "local" backups storage.
+func (c Config) BackupsStorageDir() string {
+	return c.asString(BackupsStorageDir)
+}
+
+// BackupsS3Config returns the settings of the object store used by the
+// "s3" backups storage.
+func (c Config) BackupsS3Config() BackupsS3Config {
+	return BackupsS3Config{
+		Endpoint:  c.asString(BackupsS3Endpoint),
+		Region:    c.asString(BackupsS3Region),
+		Bucket:    c.asString(BackupsS3Bucket),
+		AccessKey: c.asString(BackupsS3AccessKey),
+		SecretKey: c.asString(BackupsS3SecretKey),
+	}
+}
+
+// BackupsS3Config holds the settings of an S3-compatible object store
+// backup archives are stored in.
+type BackupsS3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// Validate returns an error if the settings are incomplete.
+func (c BackupsS3Config) Validate() error {
+	if c.Endpoint == "" && c.Region == "" {
+		return errors.NotValidf("missing %s and %s", BackupsS3Endpoint, BackupsS3Region)
+	}
+	if c.Bucket == "" {
+		return errors.NotValidf("missing %s", BackupsS3Bucket)
+	}
+	if c.AccessKey == "" || c.SecretKey == "" {
+		return errors.NotValidf("missing %s or %s", BackupsS3AccessKey, BackupsS3SecretKey)
+	}
+	return nil
+}
+
+// PasswordPolicy returns the rules local user passwords must follow.
+func (c Config) PasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:   c.asInt(PasswordMinLength),
+		CharClasses: c.asInt(PasswordCharClasses),
+	}
+}
+
+// PasswordMaxAge returns how long a local user's password remains valid
+// after it was set, or 0 if passwords never expire.
+func (c Config) PasswordMaxAge() time.Duration {
+	value := c.asString(PasswordMaxAge)
+	if value == "" {
+		return 0
+	}
+	// Validate ensures this is a valid duration.
+	maxAge, _ := time.ParseDuration(value)
+	return maxAge
+}
+
+// LoginLockoutAttempts returns the number of consecutive failed logins
+// after which a local user is locked out, or 0 if users are never locked
+// out.
+func (c Config) LoginLockoutAttempts() int {
+	return c.asInt(LoginLockoutAttempts)
+}
+
+// LoginHistorySize returns the number of most recent logins recorded for
+// each local user.
+func (c Config) LoginHistorySize() int {
+	if size := c.asInt(LoginHistorySize); size > 0 {
+		return size
+	}
+	return DefaultLoginHistorySize
+}
+
+// ResourceCacheSize returns the maximum total size, in MiB, of the charm
+// store resources fetched ahead of time across all models, or 0 if they
+// are not.
+func (c Config) ResourceCacheSize() int {
+	return c.asInt(ResourceCacheSize)
+}
+
+// ResourceCacheMaxAge returns how long a cached charm store resource no
+// application might use is kept, or 0 if it is kept until its room is
+// needed.
+func (c Config) ResourceCacheMaxAge() time.Duration {
+	value := c.asString(ResourceCacheMaxAge)
+	if value == "" {
+		return 0
+	}
+	// Validate ensures this is a valid duration.
+	maxAge, _ := time.ParseDuration(value)
+	return maxAge
+}
+
+// PasswordPolicy holds the rules local user passwords must follow.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters in a password.
+	MinLength int
+
+	// CharClasses is the number of distinct character classes a
+	// password must contain.
+	CharClasses int
+}
+
+// Check returns an error describing why the password does not follow
+// the policy, or nil if it does.
+func (p PasswordPolicy) Check(password string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return errors.NotValidf("password shorter than %d characters", p.MinLength)
+	}
+	if passwordCharClasses(password) < p.CharClasses {
+		return errors.NotValidf(
+			"password with fewer than %d of lower case, upper case, digit and other characters",
+			p.CharClasses,
+		)
+	}
+	return nil
+}
+
+// passwordCharClasses returns the number of distinct character classes
+// found in the password.
+func passwordCharClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
+
 // maybeReadAttrFromFile sets defined[attr] to:
 //
 // 1) The content of the file defined[attr+"-path"], if that's set
@@ -285,6 +603,70 @@ func Validate(c Config) error {
 		return errors.Errorf("controller-uuid: expected UUID, got string(%q)", uuid)
 	}
 
+	if space, ok := c[JujuManagementSpace].(string); ok && space != "" && !names.IsValidSpace(space) {
+		return errors.NotValidf("%s %q", JujuManagementSpace, space)
+	}
+
+	if v, ok := c[BackupInterval].(string); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupInterval)
+		}
+		if interval < 0 {
+			return errors.NotValidf("negative %s %q", BackupInterval, v)
+		}
+	}
+
+	for _, attr := range []string{BackupKeepLast, BackupKeepDaily, BackupKeepWeekly} {
+		if c.asInt(attr) < 0 {
+			return errors.NotValidf("negative %s", attr)
+		}
+	}
+
+	for _, attr := range []string{PasswordMinLength, LoginLockoutAttempts, LoginHistorySize, ResourceCacheSize} {
+		if c.asInt(attr) < 0 {
+			return errors.NotValidf("negative %s", attr)
+		}
+	}
+
+	if classes := c.asInt(PasswordCharClasses); classes < 0 || classes > 4 {
+		return errors.NotValidf("%s %d (must be between 0 and 4)", PasswordCharClasses, classes)
+	}
+
+	if v, ok := c[PasswordMaxAge].(string); ok && v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", PasswordMaxAge)
+		}
+		if maxAge < 0 {
+			return errors.NotValidf("negative %s %q", PasswordMaxAge, v)
+		}
+	}
+
+	if v, ok := c[ResourceCacheMaxAge].(string); ok && v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", ResourceCacheMaxAge)
+		}
+		if maxAge < 0 {
+			return errors.NotValidf("negative %s %q", ResourceCacheMaxAge, v)
+		}
+	}
+
+	switch target := c.BackupsStorage(); target {
+	case BackupsStorageMongo:
+	case BackupsStorageLocal:
+		if dir := c.BackupsStorageDir(); !filepath.IsAbs(dir) {
+			return errors.NotValidf("%s %q (must be an absolute path)", BackupsStorageDir, dir)
+		}
+	case BackupsStorageS3:
+		if err := c.BackupsS3Config().Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.NotValidf("%s %q", BackupsStorage, target)
+	}
+
 	return nil
 }
 
@@ -360,4 +742,101 @@ var ConfigSchema = environschema.Fields{
 		Group:       environschema.JujuGroup,
 		Immutable:   true,
 	},
+	JujuManagementSpace: {
+		Description: "The network space agents should use to communicate with controllers",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupInterval: {
+		Description: "How often the controller takes a scheduled backup, e.g. 24h (disabled if empty)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupKeepLast: {
+		Description: "The number of most recent scheduled backups to keep",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupKeepDaily: {
+		Description: "The number of days for which the last scheduled backup of the day is kept",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupKeepWeekly: {
+		Description: "The number of weeks for which the last scheduled backup of the week is kept",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsStorage: {
+		Description: "Where backup archives are stored",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{BackupsStorageMongo, BackupsStorageLocal, BackupsStorageS3},
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsStorageDir: {
+		Description: "The directory backup archives are stored in, with the local backups storage",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsS3Endpoint: {
+		Description: "The URL of the S3-compatible object store, with the s3 backups storage",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsS3Region: {
+		Description: "The region of the S3-compatible object store, with the s3 backups storage",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsS3Bucket: {
+		Description: "The bucket backup archives are stored in, with the s3 backups storage",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsS3AccessKey: {
+		Description: "The access key of the S3-compatible object store, with the s3 backups storage",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BackupsS3SecretKey: {
+		Description: "The secret key of the S3-compatible object store, with the s3 backups storage",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Secret:      true,
+	},
+	PasswordMinLength: {
+		Description: "The minimum number of characters in a local user's password",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	PasswordCharClasses: {
+		Description: "The number of character classes (lower case, upper case, digits, other) a local user's password must contain",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	PasswordMaxAge: {
+		Description: "How long a local user's password remains valid after it is set, e.g. 2160h (never expires if empty)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LoginLockoutAttempts: {
+		Description: "The number of consecutive failed logins after which a local user is locked out (disabled if zero)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LoginHistorySize: {
+		Description: "The number of most recent logins recorded for each local user (default 10)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ResourceCacheSize: {
+		Description: "The maximum total size, in MiB, of the charm store resources fetched ahead of time across all models (disabled if zero)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ResourceCacheMaxAge: {
+		Description: "How long a cached charm store resource no application uses is kept, e.g. 168h (until its room is needed if empty)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -72,3 +72,166 @@ func (s *ConfigSuite) TestGenerateControllerCertAndKey(c *gc.C) {
 		c.Assert(sanIPs, jc.SameContents, test.sanValues)
 	}
 }
+
+func (s *ConfigSuite) TestJujuManagementSpace(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.JujuManagementSpace(), gc.Equals, "")
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+
+	cfg[controller.JujuManagementSpace] = "mgmt"
+	c.Assert(cfg.JujuManagementSpace(), gc.Equals, "mgmt")
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+	c.Assert(controller.ControllerOnlyAttribute(controller.JujuManagementSpace), jc.IsTrue)
+
+	cfg[controller.JujuManagementSpace] = "Not A Space"
+	err := controller.Validate(cfg)
+	c.Assert(err, gc.ErrorMatches, `juju-mgmt-space "Not A Space" not valid`)
+}
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.BackupInterval(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, 0)
+
+	cfg[controller.BackupInterval] = "12h"
+	cfg[controller.BackupKeepLast] = 3
+	cfg[controller.BackupKeepDaily] = float64(7)
+	cfg[controller.BackupKeepWeekly] = int64(4)
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+	c.Check(cfg.BackupInterval(), gc.Equals, 12*time.Hour)
+	c.Check(cfg.BackupKeepLast(), gc.Equals, 3)
+	c.Check(cfg.BackupKeepDaily(), gc.Equals, 7)
+	c.Check(cfg.BackupKeepWeekly(), gc.Equals, 4)
+	c.Check(controller.ControllerOnlyAttribute(controller.BackupInterval), jc.IsTrue)
+
+	cfg[controller.BackupInterval] = "daily"
+	err := controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `invalid backup-interval: time: invalid duration daily`)
+
+	cfg[controller.BackupInterval] = "-1h"
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `negative backup-interval "-1h" not valid`)
+
+	cfg[controller.BackupInterval] = "1h"
+	cfg[controller.BackupKeepDaily] = -1
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `negative backup-keep-daily not valid`)
+}
+
+func (s *ConfigSuite) TestBackupsStorage(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.BackupsStorage(), gc.Equals, controller.BackupsStorageMongo)
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+
+	cfg[controller.BackupsStorage] = "local"
+	err := controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `backups-storage-dir "" \(must be an absolute path\) not valid`)
+	cfg[controller.BackupsStorageDir] = "/srv/backups"
+	c.Check(controller.Validate(cfg), jc.ErrorIsNil)
+	c.Check(cfg.BackupsStorageDir(), gc.Equals, "/srv/backups")
+
+	cfg[controller.BackupsStorage] = "s3"
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `missing backups-s3-endpoint and backups-s3-region not valid`)
+	cfg[controller.BackupsS3Endpoint] = "https://objects.example.com"
+	cfg[controller.BackupsS3Bucket] = "juju-backups"
+	cfg[controller.BackupsS3AccessKey] = "access"
+	cfg[controller.BackupsS3SecretKey] = "secret"
+	c.Check(controller.Validate(cfg), jc.ErrorIsNil)
+	c.Check(cfg.BackupsS3Config(), jc.DeepEquals, controller.BackupsS3Config{
+		Endpoint:  "https://objects.example.com",
+		Bucket:    "juju-backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+
+	cfg[controller.BackupsStorage] = "tape"
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `backups-storage "tape" not valid`)
+}
+
+func (s *ConfigSuite) TestPasswordPolicy(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.PasswordPolicy(), jc.DeepEquals, controller.PasswordPolicy{})
+	c.Assert(cfg.PasswordMaxAge(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.LoginLockoutAttempts(), gc.Equals, 0)
+	c.Assert(cfg.LoginHistorySize(), gc.Equals, controller.DefaultLoginHistorySize)
+
+	cfg[controller.PasswordMinLength] = 8
+	cfg[controller.PasswordCharClasses] = float64(3)
+	cfg[controller.PasswordMaxAge] = "720h"
+	cfg[controller.LoginLockoutAttempts] = 5
+	cfg[controller.LoginHistorySize] = int64(20)
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+	c.Check(cfg.PasswordPolicy(), jc.DeepEquals, controller.PasswordPolicy{
+		MinLength:   8,
+		CharClasses: 3,
+	})
+	c.Check(cfg.PasswordMaxAge(), gc.Equals, 720*time.Hour)
+	c.Check(cfg.LoginLockoutAttempts(), gc.Equals, 5)
+	c.Check(cfg.LoginHistorySize(), gc.Equals, 20)
+	c.Check(controller.ControllerOnlyAttribute(controller.PasswordMaxAge), jc.IsTrue)
+
+	cfg[controller.PasswordCharClasses] = 5
+	err := controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `password-char-classes 5 \(must be between 0 and 4\) not valid`)
+
+	cfg[controller.PasswordCharClasses] = 3
+	cfg[controller.PasswordMaxAge] = "-1h"
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `negative password-max-age "-1h" not valid`)
+
+	cfg[controller.PasswordMaxAge] = ""
+	cfg[controller.LoginLockoutAttempts] = -1
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `negative login-lockout-attempts not valid`)
+}
+
+func (s *ConfigSuite) TestResourceCache(c *gc.C) {
+	cfg := controller.Config{}
+	c.Assert(cfg.ResourceCacheSize(), gc.Equals, 0)
+	c.Assert(cfg.ResourceCacheMaxAge(), gc.Equals, time.Duration(0))
+
+	cfg[controller.ResourceCacheSize] = 1024
+	cfg[controller.ResourceCacheMaxAge] = "168h"
+	c.Assert(controller.Validate(cfg), jc.ErrorIsNil)
+	c.Check(cfg.ResourceCacheSize(), gc.Equals, 1024)
+	c.Check(cfg.ResourceCacheMaxAge(), gc.Equals, 168*time.Hour)
+	c.Check(controller.ControllerOnlyAttribute(controller.ResourceCacheSize), jc.IsTrue)
+
+	cfg[controller.ResourceCacheMaxAge] = "a week"
+	err := controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `invalid resource-cache-max-age: .*`)
+
+	cfg[controller.ResourceCacheMaxAge] = ""
+	cfg[controller.ResourceCacheSize] = -1
+	err = controller.Validate(cfg)
+	c.Check(err, gc.ErrorMatches, `negative resource-cache-size not valid`)
+}
+
+func (s *ConfigSuite) TestPasswordPolicyCheck(c *gc.C) {
+	policy := controller.PasswordPolicy{MinLength: 8, CharClasses: 3}
+	for i, test := range []struct {
+		password string
+		err      string
+	}{{
+		password: "Passw0rd",
+	}, {
+		password: "pa$$w0rd-with-symbols",
+	}, {
+		password: "Pa55",
+		err:      `password shorter than 8 characters not valid`,
+	}, {
+		password: "password",
+		err:      `password with fewer than 3 of lower case, upper case, digit and other characters not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.password)
+		err := policy.Check(test.password)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+	c.Check(controller.PasswordPolicy{}.Check("x"), jc.ErrorIsNil)
+}
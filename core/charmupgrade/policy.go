@@ -0,0 +1,174 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrade
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+)
+
+// Mode determines what happens when a newer revision of an
+// application's charm becomes available.
+type Mode string
+
+const (
+	// None indicates that new revisions are ignored.
+	None Mode = "none"
+
+	// Notify indicates that new revisions are reported, but never
+	// applied automatically. This is the default.
+	Notify Mode = "notify"
+
+	// AutoInWindow indicates that new revisions are applied
+	// automatically during the policy's maintenance window.
+	AutoInWindow Mode = "auto-in-window"
+)
+
+// Validate returns an error if the mode is not known.
+func (m Mode) Validate() error {
+	switch m {
+	case None, Notify, AutoInWindow:
+		return nil
+	}
+	return errors.NotValidf("upgrade policy %q", m)
+}
+
+const day = 24 * time.Hour
+
+// Window is a daily period, in UTC, during which automatic upgrades
+// may be applied. A window may span midnight.
+type Window struct {
+	// Start is the offset from midnight UTC at which the window opens.
+	Start time.Duration
+
+	// Duration is how long the window stays open.
+	Duration time.Duration
+}
+
+// IsZero returns whether no window has been defined.
+func (w Window) IsZero() bool {
+	return w == Window{}
+}
+
+// Validate returns an error if the window is not well formed.
+func (w Window) Validate() error {
+	if w.Start < 0 || w.Start >= day {
+		return errors.NotValidf("window start %v", w.Start)
+	}
+	if w.Duration <= 0 || w.Duration >= day {
+		return errors.NotValidf("window duration %v", w.Duration)
+	}
+	return nil
+}
+
+// Contains returns whether the window is open at the given time.
+func (w Window) Contains(t time.Time) bool {
+	if w.IsZero() {
+		return false
+	}
+	t = t.UTC()
+	offset := t.Sub(t.Truncate(day))
+	since := (offset - w.Start + day) % day
+	return since < w.Duration
+}
+
+// String returns the window in the form accepted by ParseWindow,
+// for example "02:00-04:30". The zero window is rendered as "".
+func (w Window) String() string {
+	if w.IsZero() {
+		return ""
+	}
+	return formatClock(w.Start) + "-" + formatClock((w.Start+w.Duration)%day)
+}
+
+// ParseWindow parses a window of the form "HH:MM-HH:MM", in UTC.
+// The empty string yields the zero window.
+func ParseWindow(s string) (Window, error) {
+	if s == "" {
+		return Window{}, nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return Window{}, errors.NotValidf("maintenance window %q", s)
+	}
+	start, err := parseClock(parts[0])
+	if err != nil {
+		return Window{}, errors.Annotatef(err, "invalid maintenance window %q", s)
+	}
+	end, err := parseClock(parts[1])
+	if err != nil {
+		return Window{}, errors.Annotatef(err, "invalid maintenance window %q", s)
+	}
+	if start == end {
+		return Window{}, errors.NotValidf("empty maintenance window %q", s)
+	}
+	return Window{
+		Start:    start,
+		Duration: (end - start + day) % day,
+	}, nil
+}
+
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.NotValidf("time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Policy describes how an application is upgraded when newer
+// revisions of its charm are published.
+type Policy struct {
+	// Mode determines whether new revisions are ignored, reported
+	// or applied.
+	Mode Mode
+
+	// Channel, if set, is the channel in which new revisions are
+	// looked for, in place of the one the application was deployed
+	// from.
+	Channel csparams.Channel
+
+	// Window is when new revisions may be applied. It must be set
+	// for AutoInWindow, and is ignored otherwise.
+	Window Window
+}
+
+// DefaultPolicy is the policy of applications that have not been
+// given one.
+var DefaultPolicy = Policy{Mode: Notify}
+
+// Validate returns an error if the policy is not consistent.
+func (p Policy) Validate() error {
+	if err := p.Mode.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if p.Mode == AutoInWindow {
+		if p.Window.IsZero() {
+			return errors.NotValidf("%q policy without maintenance window", p.Mode)
+		}
+		if err := p.Window.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Reports returns whether new revisions should be reported.
+func (p Policy) Reports() bool {
+	return p.Mode != None
+}
+
+// Allows returns whether new revisions may be applied automatically
+// at the given time.
+func (p Policy) Allows(t time.Time) bool {
+	return p.Mode == AutoInWindow && p.Window.Contains(t)
+}
@@ -0,0 +1,109 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrade_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/charmupgrade"
+)
+
+type PolicySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PolicySuite{})
+
+func (*PolicySuite) TestModeValidate(c *gc.C) {
+	for i, test := range []charmupgrade.Mode{
+		charmupgrade.None, charmupgrade.Notify, charmupgrade.AutoInWindow,
+	} {
+		c.Logf("test %d: %s", i, test)
+		c.Check(test.Validate(), jc.ErrorIsNil)
+	}
+	err := charmupgrade.Mode("auto").Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `upgrade policy "auto" not valid`)
+}
+
+func (*PolicySuite) TestParseWindow(c *gc.C) {
+	for i, test := range []struct {
+		in     string
+		window charmupgrade.Window
+		err    string
+	}{{
+		in: "",
+	}, {
+		in:     "02:00-04:30",
+		window: charmupgrade.Window{Start: 2 * time.Hour, Duration: 150 * time.Minute},
+	}, {
+		in:     "23:00-01:00",
+		window: charmupgrade.Window{Start: 23 * time.Hour, Duration: 2 * time.Hour},
+	}, {
+		in:  "02:00",
+		err: `maintenance window "02:00" not valid`,
+	}, {
+		in:  "02:00-25:00",
+		err: `invalid maintenance window "02:00-25:00": time of day "25:00" not valid`,
+	}, {
+		in:  "02:00-02:00",
+		err: `empty maintenance window "02:00-02:00" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.in)
+		window, err := charmupgrade.ParseWindow(test.in)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(window, gc.Equals, test.window)
+		c.Check(window.String(), gc.Equals, test.in)
+	}
+}
+
+func (*PolicySuite) TestWindowContains(c *gc.C) {
+	window, err := charmupgrade.ParseWindow("23:00-01:00")
+	c.Assert(err, jc.ErrorIsNil)
+	at := func(hour, min int) time.Time {
+		return time.Date(2016, 8, 1, hour, min, 0, 0, time.UTC)
+	}
+	c.Check(window.Contains(at(22, 59)), jc.IsFalse)
+	c.Check(window.Contains(at(23, 0)), jc.IsTrue)
+	c.Check(window.Contains(at(0, 30)), jc.IsTrue)
+	c.Check(window.Contains(at(1, 0)), jc.IsFalse)
+	c.Check(charmupgrade.Window{}.Contains(at(0, 0)), jc.IsFalse)
+}
+
+func (*PolicySuite) TestPolicyValidate(c *gc.C) {
+	c.Check(charmupgrade.DefaultPolicy.Validate(), jc.ErrorIsNil)
+	err := charmupgrade.Policy{Mode: charmupgrade.AutoInWindow}.Validate()
+	c.Check(err, gc.ErrorMatches, `"auto-in-window" policy without maintenance window not valid`)
+	err = charmupgrade.Policy{
+		Mode:   charmupgrade.AutoInWindow,
+		Window: charmupgrade.Window{Start: 2 * time.Hour, Duration: time.Hour},
+	}.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (*PolicySuite) TestPolicyAllows(c *gc.C) {
+	policy := charmupgrade.Policy{
+		Mode:   charmupgrade.AutoInWindow,
+		Window: charmupgrade.Window{Start: 2 * time.Hour, Duration: time.Hour},
+	}
+	inside := time.Date(2016, 8, 1, 2, 30, 0, 0, time.UTC)
+	outside := time.Date(2016, 8, 1, 4, 0, 0, 0, time.UTC)
+	c.Check(policy.Allows(inside), jc.IsTrue)
+	c.Check(policy.Allows(outside), jc.IsFalse)
+
+	policy.Mode = charmupgrade.Notify
+	c.Check(policy.Allows(inside), jc.IsFalse)
+	c.Check(policy.Reports(), jc.IsTrue)
+	policy.Mode = charmupgrade.None
+	c.Check(policy.Reports(), jc.IsFalse)
+}
@@ -122,6 +122,11 @@ type BootstrapParams struct {
 	// used to retrieve the Juju GUI archive installed in the controller.
 	// If not set, the Juju GUI is not installed from simplestreams.
 	GUIDataSourceBaseURL string
+
+	// GUIArchivePath, if set, is the path to a local Juju GUI archive
+	// to install in the controller. It takes precedence over
+	// GUIDataSourceBaseURL.
+	GUIArchivePath string
 }
 
 // Bootstrap bootstraps the given environment. The supplied constraints are
@@ -302,7 +307,7 @@ func Bootstrap(ctx environs.BootstrapContext, environ environs.Environ, args Boo
 	instanceConfig.Bootstrap.ControllerConfig = args.ControllerConfig
 	instanceConfig.Bootstrap.ControllerInheritedConfig = args.ControllerInheritedConfig
 	instanceConfig.Bootstrap.HostedModelConfig = args.HostedModelConfig
-	instanceConfig.Bootstrap.GUI = guiArchive(args.GUIDataSourceBaseURL, func(msg string) {
+	instanceConfig.Bootstrap.GUI = guiArchive(args.GUIArchivePath, args.GUIDataSourceBaseURL, func(msg string) {
 		ctx.Infof(msg)
 	})
 
@@ -533,13 +538,16 @@ func validateConstraints(env environs.Environ, cons constraints.Value) error {
 
 // guiArchive returns information on the GUI archive that will be uploaded
 // to the controller. Possible errors in retrieving the GUI archive information
-// do not prevent the model to be bootstrapped. If dataSourceBaseURL is
+// do not prevent the model to be bootstrapped. If path is non-empty, the
+// local archive at that path is used. Otherwise, if dataSourceBaseURL is
 // non-empty, remote GUI archive info is retrieved from simplestreams using it
 // as the base URL. The given logProgress function is used to inform users
 // about errors or progress in setting up the Juju GUI.
-func guiArchive(dataSourceBaseURL string, logProgress func(string)) *coretools.GUIArchive {
-	// The environment variable is only used for development purposes.
-	path := os.Getenv("JUJU_GUI")
+func guiArchive(path, dataSourceBaseURL string, logProgress func(string)) *coretools.GUIArchive {
+	if path == "" {
+		// The environment variable is only used for development purposes.
+		path = os.Getenv("JUJU_GUI")
+	}
 	if path != "" {
 		vers, err := guiVersion(path)
 		if err != nil {
@@ -461,6 +461,21 @@ func (s *bootstrapSuite) TestBootstrapGUIStreamsFailure(c *gc.C) {
 	c.Assert(env.instanceConfig.Bootstrap.GUI, gc.IsNil)
 }
 
+func (s *bootstrapSuite) TestBootstrapGUIArchivePath(c *gc.C) {
+	path := makeGUIArchive(c, "jujugui-2.3.0")
+	s.PatchEnvironment("JUJU_GUI", "/no/such/file")
+	env := newEnviron("foo", useDefaultKeys, nil)
+	ctx := coretesting.Context(c)
+	err := bootstrap.Bootstrap(modelcmd.BootstrapContext(ctx), env, bootstrap.BootstrapParams{
+		ControllerConfig: coretesting.FakeControllerBootstrapConfig(),
+		GUIArchivePath:   path,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(coretesting.Stderr(ctx), jc.Contains, "Preparing for Juju GUI 2.3.0 installation from local archive\n")
+	c.Assert(env.instanceConfig.Bootstrap.GUI.URL, gc.Equals, "file://"+path)
+	c.Assert(env.instanceConfig.Bootstrap.GUI.Version.String(), gc.Equals, "2.3.0")
+}
+
 func (s *bootstrapSuite) TestBootstrapGUIErrorNotFound(c *gc.C) {
 	s.PatchEnvironment("JUJU_GUI", "/no/such/file")
 	env := newEnviron("foo", useDefaultKeys, nil)
@@ -145,6 +145,12 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// MetricsExportTargetKey sets where the charm metrics collected in
+	// the model are sent as well as to the charm store metrics
+	// collector: a webhook or Prometheus push gateway given as
+	// "webhook:<url>" or "prometheus:<url>".
+	MetricsExportTargetKey = "metrics-export-target"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -469,6 +475,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MetricsExportTargetKey].(string); ok {
+		if _, err := ParseMetricsExportTarget(v); err != nil {
+			return errors.Annotatef(err, "invalid %s", MetricsExportTargetKey)
+		}
+	}
+
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
 	}
@@ -820,6 +832,14 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// MetricsExportTarget returns where the charm metrics collected in the
+// model are sent as well as to the charm store metrics collector.
+func (c *Config) MetricsExportTarget() MetricsExportTarget {
+	// Validate ensures this is a valid target.
+	target, _ := ParseMetricsExportTarget(c.asString(MetricsExportTargetKey))
+	return target
+}
+
 // ProvisionerHarvestMode reports the harvesting methodology the
 // provisioner should take.
 func (c *Config) ProvisionerHarvestMode() HarvestMode {
@@ -1020,6 +1040,7 @@ var alwaysOptional = schema.Defaults{
 	AgentStreamKey:               schema.Omit,
 	ResourceTagsKey:              schema.Omit,
 	CloudImageBaseURL:            schema.Omit,
+	MetricsExportTargetKey:       schema.Omit,
 
 	// AutomaticallyRetryHooks is assumed to be true if missing
 	AutomaticallyRetryHooks: schema.Omit,
@@ -1471,4 +1492,9 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	MetricsExportTargetKey: {
+		Description: `Where charm metrics are sent as well as to the charm store metrics collector: "webhook:<url>" or "prometheus:<push gateway url>"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -1466,6 +1466,47 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestMetricsExportTargetDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MetricsExportTarget(), jc.DeepEquals, config.MetricsExportTarget{
+		Kind: config.MetricsExportCollector,
+	})
+}
+
+func (s *ConfigSuite) TestMetricsExportTarget(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"metrics-export-target": "prometheus:http://10.0.0.1:9091",
+	})
+	c.Assert(cfg.MetricsExportTarget(), jc.DeepEquals, config.MetricsExportTarget{
+		Kind: config.MetricsExportPrometheus,
+		URL:  "http://10.0.0.1:9091",
+	})
+}
+
+func (s *ConfigSuite) TestMetricsExportTargetInvalid(c *gc.C) {
+	for i, test := range []struct {
+		target string
+		err    string
+	}{{
+		target: "http://10.0.0.1:9091",
+		err:    `invalid metrics-export-target: metrics export target kind "http" not valid`,
+	}, {
+		target: "webhook",
+		err:    `invalid metrics-export-target: metrics export target "webhook" not valid`,
+	}, {
+		target: "webhook:ftp://example.com/metrics",
+		err:    `invalid metrics-export-target: webhook URL "ftp://example.com/metrics" not valid`,
+	}, {
+		target: "prometheus:gateway",
+		err:    `invalid metrics-export-target: prometheus URL "gateway" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.target)
+		attrs := minimalConfigAttrs.Merge(testing.Attrs{"metrics-export-target": test.target})
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestCloudImageBaseURL(c *gc.C) {
 	s.addJujuFiles(c)
 	config := newTestConfig(c, testing.Attrs{})
@@ -0,0 +1,76 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// MetricsExportKind identifies the kind of service charm metrics are
+// exported to.
+type MetricsExportKind string
+
+const (
+	// MetricsExportCollector sends charm metrics to the charm store
+	// metrics collector. This is the default.
+	MetricsExportCollector MetricsExportKind = ""
+
+	// MetricsExportWebhook posts charm metric batches, as JSON, to an
+	// HTTP endpoint.
+	MetricsExportWebhook MetricsExportKind = "webhook"
+
+	// MetricsExportPrometheus pushes charm metrics to a Prometheus
+	// push gateway.
+	MetricsExportPrometheus MetricsExportKind = "prometheus"
+)
+
+// MetricsExportTarget describes where the charm metrics collected in a
+// model are sent.
+type MetricsExportTarget struct {
+	// Kind is the kind of service the metrics are sent to.
+	Kind MetricsExportKind
+
+	// URL is the address of the webhook or push gateway. It is empty
+	// for the charm store metrics collector.
+	URL string
+}
+
+// String returns the target in the form used for the
+// metrics-export-target attribute.
+func (t MetricsExportTarget) String() string {
+	if t.Kind == MetricsExportCollector {
+		return ""
+	}
+	return string(t.Kind) + ":" + t.URL
+}
+
+// ParseMetricsExportTarget parses a metrics export target of the form
+// "webhook:<url>" or "prometheus:<url>". The empty string is the charm
+// store metrics collector.
+func ParseMetricsExportTarget(s string) (MetricsExportTarget, error) {
+	if s == "" {
+		return MetricsExportTarget{}, nil
+	}
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return MetricsExportTarget{}, errors.NotValidf("metrics export target %q", s)
+	}
+	kind := MetricsExportKind(parts[0])
+	switch kind {
+	case MetricsExportWebhook, MetricsExportPrometheus:
+	default:
+		return MetricsExportTarget{}, errors.NotValidf("metrics export target kind %q", parts[0])
+	}
+	u, err := url.Parse(parts[1])
+	if err != nil {
+		return MetricsExportTarget{}, errors.Annotatef(err, "invalid %s URL", kind)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return MetricsExportTarget{}, errors.NotValidf("%s URL %q", kind, parts[1])
+	}
+	return MetricsExportTarget{Kind: kind, URL: parts[1]}, nil
+}
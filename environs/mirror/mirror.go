@@ -0,0 +1,274 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package mirror assembles a self-contained directory holding everything
+// needed to bootstrap and deploy without Internet access: agent binaries
+// and image metadata in the layout expected by bootstrap's metadata
+// source, the charms of a bundle, and a Juju GUI archive.
+//
+// A mirror directory is laid out as follows:
+//
+//   tools/      agent binaries and their simplestreams metadata
+//   images/     image simplestreams metadata
+//   charms/     charm archives referenced by bundle.yaml
+//   bundle.yaml the mirrored bundle, with charms referenced by local path
+//   gui/        the Juju GUI archive
+package mirror
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/series"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/gui"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/sync"
+	envtools "github.com/juju/juju/environs/tools"
+)
+
+var logger = loggo.GetLogger("juju.environs.mirror")
+
+const (
+	// CharmsDir is the directory within a mirror holding charm archives.
+	CharmsDir = "charms"
+
+	// BundleFile is the name of the mirrored bundle within a mirror.
+	BundleFile = "bundle.yaml"
+
+	// GUIDir is the directory within a mirror holding the Juju GUI
+	// archive.
+	GUIDir = "gui"
+)
+
+var (
+	syncTools        = sync.SyncTools
+	fetchImages      = imagemetadata.Fetch
+	guiFetchMetadata = gui.FetchMetadata
+)
+
+// Tools copies agent binaries and their simplestreams metadata into the
+// mirror at dir. The target finder and uploader of syncContext are
+// replaced with ones that write to the mirror.
+func Tools(dir string, syncContext *sync.SyncContext) error {
+	stor, err := filestorage.NewFileStorageWriter(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	syncContext.TargetToolsFinder = sync.StorageToolsFinder{Storage: stor}
+	syncContext.TargetToolsUploader = sync.StorageToolsUploader{
+		Storage:       stor,
+		WriteMetadata: true,
+		WriteMirrors:  envtools.DoNotWriteMirrors,
+	}
+	return errors.Annotate(syncTools(syncContext), "cannot mirror agent binaries")
+}
+
+// Images copies the image metadata matching cons from the given sources
+// into the mirror at dir, and returns the number of images recorded. The
+// metadata is recorded against the region and endpoint of cons.
+func Images(dir string, sources []simplestreams.DataSource, cons *imagemetadata.ImageConstraint) (int, error) {
+	metadata, _, err := fetchImages(sources, cons)
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot fetch image metadata")
+	}
+	bySeries := make(map[string][]*imagemetadata.ImageMetadata)
+	for _, im := range metadata {
+		s, err := series.VersionSeries(im.Version)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		bySeries[s] = append(bySeries[s], im)
+	}
+	stor, err := filestorage.NewFileStorageWriter(dir)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for s, images := range bySeries {
+		logger.Infof("writing %d %s images", len(images), s)
+		if err := imagemetadata.MergeAndWriteMetadata(s, images, &cons.CloudSpec, stor); err != nil {
+			return 0, errors.Annotatef(err, "cannot write %s image metadata", s)
+		}
+	}
+	return len(metadata), nil
+}
+
+// CharmStore is the part of the charm store used to mirror charms.
+type CharmStore interface {
+	// Resolve resolves the series and revision of the given URL.
+	Resolve(ref *charm.URL) (*charm.URL, []string, error)
+
+	// Get downloads the charm with the given URL.
+	Get(curl *charm.URL) (charm.Charm, error)
+}
+
+// Charms downloads the charms used by a bundle into the mirror at dir,
+// and writes a copy of the bundle that refers to them by local path.
+// Bundles that already refer to local charms are rejected, as the
+// mirror could not be moved without them.
+func Charms(dir string, data *charm.BundleData, store CharmStore) error {
+	if err := os.MkdirAll(filepath.Join(dir, CharmsDir), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	mirrored := make(map[string]*charm.URL)
+	paths := make(map[string]string)
+	names := make([]string, 0, len(data.Applications))
+	for name := range data.Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		app := data.Applications[name]
+		if isLocalCharm(app.Charm) {
+			return errors.NotSupportedf("mirroring local charm %q for application %q", app.Charm, name)
+		}
+		curl, ok := mirrored[app.Charm]
+		if !ok {
+			ref, err := charm.ParseURL(app.Charm)
+			if err != nil {
+				return errors.Annotatef(err, "application %q", name)
+			}
+			curl, _, err = store.Resolve(ref)
+			if err != nil {
+				return errors.Annotatef(err, "cannot resolve charm %q", app.Charm)
+			}
+			path, err := mirrorCharm(dir, curl, store)
+			if err != nil {
+				return errors.Annotatef(err, "cannot mirror charm %q", curl)
+			}
+			mirrored[app.Charm] = curl
+			paths[app.Charm] = path
+		}
+		// A local charm path carries no series, so record the
+		// series the store resolved.
+		if app.Series == "" {
+			app.Series = curl.Series
+		}
+		app.Charm = paths[app.Charm]
+	}
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ioutil.WriteFile(filepath.Join(dir, BundleFile), out, 0644))
+}
+
+func isLocalCharm(ref string) bool {
+	return len(ref) > 0 && (ref[0] == '.' || filepath.IsAbs(ref))
+}
+
+// mirrorCharm copies the archive for curl into the mirror, returning
+// its path relative to the mirrored bundle.
+func mirrorCharm(dir string, curl *charm.URL, store CharmStore) (string, error) {
+	ch, err := store.Get(curl)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	archive, ok := ch.(*charm.CharmArchive)
+	if !ok {
+		return "", errors.Errorf("expected a charm archive, got %T", ch)
+	}
+	name := fmt.Sprintf("%s-%d.charm", curl.Name, curl.Revision)
+	if curl.Series != "" {
+		name = fmt.Sprintf("%s-%s-%d.charm", curl.Series, curl.Name, curl.Revision)
+	}
+	logger.Infof("copying charm %s", curl)
+	if err := copyFile(filepath.Join(dir, CharmsDir, name), archive.Path); err != nil {
+		return "", errors.Trace(err)
+	}
+	return "./" + CharmsDir + "/" + name, nil
+}
+
+func copyFile(dest, source string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer in.Close()
+	out, err := os.Create(dest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer out.Close()
+	_, err = io.Copy(out, in)
+	return errors.Trace(err)
+}
+
+// GUI downloads the newest Juju GUI archive in the given stream into
+// the mirror at dir, and returns its version.
+func GUI(dir, stream string, sources ...simplestreams.DataSource) (version.Number, error) {
+	allMeta, err := guiFetchMetadata(stream, sources...)
+	if err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	if len(allMeta) == 0 {
+		return version.Zero, errors.NotFoundf("Juju GUI archive")
+	}
+	// Metadata are returned in descending version order.
+	meta := allMeta[0]
+	r, _, err := meta.Source.Fetch(meta.Path)
+	if err != nil {
+		return version.Zero, errors.Annotate(err, "cannot download Juju GUI archive")
+	}
+	defer r.Close()
+
+	if err := os.MkdirAll(filepath.Join(dir, GUIDir), 0755); err != nil {
+		return version.Zero, errors.Trace(err)
+	}
+	path := filepath.Join(dir, GUIDir, fmt.Sprintf("jujugui-%s.tar.bz2", meta.Version))
+	if err := writeGUIArchive(path, r, meta); err != nil {
+		os.Remove(path)
+		return version.Zero, errors.Trace(err)
+	}
+	return meta.Version, nil
+}
+
+// writeGUIArchive writes the archive read from r to path, verifying
+// its size and hash against meta.
+func writeGUIArchive(path string, r io.Reader, meta *gui.Metadata) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(f, hash), r)
+	if err != nil {
+		return errors.Annotate(err, "cannot download Juju GUI archive")
+	}
+	if size != meta.Size {
+		return errors.Errorf("Juju GUI archive size mismatch: expected %d, got %d", meta.Size, size)
+	}
+	if sum := fmt.Sprintf("%x", hash.Sum(nil)); sum != meta.SHA256 {
+		return errors.Errorf("Juju GUI archive hash mismatch: expected %s, got %s", meta.SHA256, sum)
+	}
+	return nil
+}
+
+// GUIArchive returns the path of the Juju GUI archive in the mirror at
+// dir, or a NotFound error if the mirror does not hold one.
+func GUIArchive(dir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, GUIDir, "jujugui-*.tar.bz2"))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(paths) == 0 {
+		return "", errors.NotFoundf("Juju GUI archive in %q", dir)
+	}
+	if len(paths) > 1 {
+		return "", errors.Errorf("more than one Juju GUI archive in %q", dir)
+	}
+	return paths[0], nil
+}
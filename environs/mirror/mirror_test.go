@@ -0,0 +1,221 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mirror_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/environs/filestorage"
+	"github.com/juju/juju/environs/gui"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/mirror"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/environs/storage"
+	"github.com/juju/juju/environs/sync"
+	"github.com/juju/juju/testcharms"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mirrorSuite struct {
+	coretesting.BaseSuite
+	dir string
+}
+
+var _ = gc.Suite(&mirrorSuite{})
+
+func (s *mirrorSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+func (s *mirrorSuite) TestTools(c *gc.C) {
+	var called bool
+	s.PatchValue(mirror.SyncTools, func(syncContext *sync.SyncContext) error {
+		called = true
+		c.Check(syncContext.Stream, gc.Equals, "proposed")
+		c.Check(syncContext.TargetToolsFinder, gc.FitsTypeOf, sync.StorageToolsFinder{})
+		uploader, ok := syncContext.TargetToolsUploader.(sync.StorageToolsUploader)
+		c.Assert(ok, jc.IsTrue)
+		c.Check(uploader.WriteMetadata, jc.IsTrue)
+		return nil
+	})
+	err := mirror.Tools(s.dir, &sync.SyncContext{Stream: "proposed"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *mirrorSuite) TestToolsError(c *gc.C) {
+	s.PatchValue(mirror.SyncTools, func(*sync.SyncContext) error {
+		return errors.New("no tools")
+	})
+	err := mirror.Tools(s.dir, &sync.SyncContext{})
+	c.Assert(err, gc.ErrorMatches, "cannot mirror agent binaries: no tools")
+}
+
+func (s *mirrorSuite) TestImages(c *gc.C) {
+	s.PatchValue(mirror.FetchImages, func(
+		sources []simplestreams.DataSource, cons *imagemetadata.ImageConstraint,
+	) ([]*imagemetadata.ImageMetadata, *simplestreams.ResolveInfo, error) {
+		c.Check(cons.Series, jc.DeepEquals, []string{"trusty", "xenial"})
+		return []*imagemetadata.ImageMetadata{{
+			Id:      "ami-trusty",
+			Arch:    "amd64",
+			Version: "14.04",
+		}, {
+			Id:      "ami-xenial",
+			Arch:    "amd64",
+			Version: "16.04",
+		}}, nil, nil
+	})
+	cons := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+		CloudSpec: simplestreams.CloudSpec{Region: "region", Endpoint: "endpoint"},
+		Series:    []string{"trusty", "xenial"},
+		Arches:    []string{"amd64"},
+	})
+	count, err := mirror.Images(s.dir, nil, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 2)
+
+	// The mirrored metadata can be read back for the same cloud.
+	stor, err := filestorage.NewFileStorageReader(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	source := storage.NewStorageSimpleStreamsDataSource(
+		"mirror", stor, storage.BaseImagesPath, simplestreams.CUSTOM_CLOUD_DATA, false,
+	)
+	metadata, _, err := imagemetadata.Fetch([]simplestreams.DataSource{source}, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, im := range metadata {
+		ids = append(ids, im.Id)
+	}
+	c.Assert(ids, jc.SameContents, []string{"ami-trusty", "ami-xenial"})
+}
+
+type fakeCharmStore struct {
+	archive *charm.CharmArchive
+	got     []string
+}
+
+func (f *fakeCharmStore) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	curl := *ref
+	if curl.Series == "" {
+		curl.Series = "trusty"
+	}
+	if curl.Revision == -1 {
+		curl.Revision = 7
+	}
+	return &curl, []string{curl.Series}, nil
+}
+
+func (f *fakeCharmStore) Get(curl *charm.URL) (charm.Charm, error) {
+	f.got = append(f.got, curl.String())
+	return f.archive, nil
+}
+
+func (s *mirrorSuite) TestCharms(c *gc.C) {
+	store := &fakeCharmStore{
+		archive: testcharms.Repo.CharmArchive(c.MkDir(), "dummy"),
+	}
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"one":   {Charm: "cs:dummy"},
+			"two":   {Charm: "cs:dummy"},
+			"three": {Charm: "cs:xenial/dummy-3", NumUnits: 1},
+		},
+	}
+	err := mirror.Charms(s.dir, data, store)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Each distinct charm is fetched once.
+	c.Assert(store.got, jc.SameContents, []string{"cs:trusty/dummy-7", "cs:xenial/dummy-3"})
+	for _, name := range []string{"trusty-dummy-7.charm", "xenial-dummy-3.charm"} {
+		_, err := os.Stat(filepath.Join(s.dir, mirror.CharmsDir, name))
+		c.Check(err, jc.ErrorIsNil)
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, mirror.BundleFile))
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	mirrored, err := charm.ReadBundleData(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mirrored.Applications["one"].Charm, gc.Equals, "./charms/trusty-dummy-7.charm")
+	c.Check(mirrored.Applications["one"].Series, gc.Equals, "trusty")
+	c.Check(mirrored.Applications["two"].Charm, gc.Equals, "./charms/trusty-dummy-7.charm")
+	c.Check(mirrored.Applications["two"].Series, gc.Equals, "trusty")
+	c.Check(mirrored.Applications["three"].Charm, gc.Equals, "./charms/xenial-dummy-3.charm")
+	c.Check(mirrored.Applications["three"].NumUnits, gc.Equals, 1)
+}
+
+func (s *mirrorSuite) TestCharmsLocalCharm(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"local": {Charm: "./dummy"},
+		},
+	}
+	err := mirror.Charms(s.dir, data, &fakeCharmStore{})
+	c.Assert(err, gc.ErrorMatches, `mirroring local charm "./dummy" for application "local" not supported`)
+}
+
+func (s *mirrorSuite) patchGUIMetadata(c *gc.C, data []byte, size int64, hash string) {
+	sourceDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(sourceDir, "gui.tar.bz2"), data, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	source := simplestreams.NewURLDataSource(
+		"test", "file://"+filepath.ToSlash(sourceDir), utils.NoVerifySSLHostnames, simplestreams.DEFAULT_CLOUD_DATA, false,
+	)
+	s.PatchValue(mirror.GUIFetchMetadata, func(stream string, sources ...simplestreams.DataSource) ([]*gui.Metadata, error) {
+		c.Check(stream, gc.Equals, gui.ReleasedStream)
+		return []*gui.Metadata{{
+			Version: version.MustParse("2.1.0"),
+			Path:    "gui.tar.bz2",
+			Size:    size,
+			SHA256:  hash,
+			Source:  source,
+		}, {
+			Version: version.MustParse("2.0.0"),
+		}}, nil
+	})
+}
+
+func (s *mirrorSuite) TestGUI(c *gc.C) {
+	data := []byte("gui archive")
+	s.patchGUIMetadata(c, data, int64(len(data)), fmt.Sprintf("%x", sha256.Sum256(data)))
+
+	vers, err := mirror.GUI(s.dir, gui.ReleasedStream)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(vers, gc.Equals, version.MustParse("2.1.0"))
+
+	path, err := mirror.GUIArchive(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, filepath.Join(s.dir, mirror.GUIDir, "jujugui-2.1.0.tar.bz2"))
+	content, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(content, jc.DeepEquals, data)
+}
+
+func (s *mirrorSuite) TestGUIHashMismatch(c *gc.C) {
+	data := []byte("gui archive")
+	s.patchGUIMetadata(c, data, int64(len(data)), "bad")
+
+	_, err := mirror.GUI(s.dir, gui.ReleasedStream)
+	c.Assert(err, gc.ErrorMatches, "Juju GUI archive hash mismatch: expected bad, got .*")
+	_, err = mirror.GUIArchive(s.dir)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *mirrorSuite) TestGUIArchiveNotFound(c *gc.C) {
+	_, err := mirror.GUIArchive(s.dir)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
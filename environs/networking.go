@@ -41,6 +41,12 @@ type Networking interface {
 	// provider that have subnets available.
 	Spaces() ([]network.SpaceInfo, error)
 
+	// SupportsContainerAddresses returns whether the current environment
+	// supports allocating static addresses for containers with
+	// AllocateContainerAddresses. The returned error satisfies
+	// errors.IsNotSupported(), unless a general API failure occurs.
+	SupportsContainerAddresses() (bool, error)
+
 	// AllocateContainerAddresses allocates a static address for each of the
 	// container NICs in preparedInfo, hosted by the hostInstanceID. Returns the
 	// network config including all allocated addresses on success.
@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package featuretests
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/resource/api/client"
+	"github.com/juju/juju/resource/resourceadapters"
+	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type applicationAccessSuite struct {
+	testing.JujuConnSuite
+}
+
+// upgradeCharm makes the API calls "juju upgrade-charm" makes, as the
+// given user, to upgrade the mysql application to the given charm.
+func (s *applicationAccessSuite) upgradeCharm(c *gc.C, user *state.User, ch *state.Charm) error {
+	conn := s.OpenAPIAs(c, user.UserTag(), "secret")
+
+	if err := conn.Client().AddCharm(ch.URL(), csparams.NoChannel); err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := resourceadapters.NewAPIClient(func() (api.Connection, error) {
+		return conn, nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = resources.AddPendingResources(client.AddPendingResourcesArgs{
+		ApplicationID: "mysql",
+		Resources:     []charmresource.Resource{resourcetesting.NewCharmResource(c, "data", "content")},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.NewClient(conn).SetCharm(application.SetCharmConfig{
+		ApplicationName: "mysql",
+		CharmID:         charmstore.CharmID{URL: ch.URL()},
+	})
+}
+
+func (s *applicationAccessSuite) TestManageAccessUpgradesCharm(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Revision: "42"})
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "secret", Access: state.ReadAccess})
+	err := s.State.SetApplicationAccess(app.Name(), user.UserTag(), state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.upgradeCharm(c, user, ch)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl, jc.DeepEquals, ch.URL())
+}
+
+func (s *applicationAccessSuite) TestOperateAccessCannotUpgradeCharm(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Revision: "42"})
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "secret", Access: state.ReadAccess})
+	err := s.State.SetApplicationAccess(app.Name(), user.UserTag(), state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.upgradeCharm(c, user, ch)
+	c.Assert(params.IsCodeUnauthorized(errors.Cause(err)), jc.IsTrue)
+}
+
+func (s *applicationAccessSuite) TestWriteUserCannotUpgradeOtherTeamsCharm(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Revision: "42"})
+	owner := s.Factory.MakeUser(c, &factory.UserParams{Access: state.ReadAccess})
+	err := s.State.SetApplicationAccess(app.Name(), owner.UserTag(), state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: "secret", Access: state.WriteAccess})
+
+	err = s.upgradeCharm(c, user, ch)
+	c.Assert(params.IsCodeUnauthorized(errors.Cause(err)), jc.IsTrue)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl, gc.Not(jc.DeepEquals), ch.URL())
+}
@@ -39,6 +39,7 @@ func init() {
 	gc.Suite(&cmdRegistrationSuite{})
 	gc.Suite(&cmdLoginSuite{})
 	gc.Suite(&BakeryStorageSuite{})
+	gc.Suite(&applicationAccessSuite{})
 }
 
 func TestPackage(t *stdtesting.T) {
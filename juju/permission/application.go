@@ -0,0 +1,38 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package permission
+
+import (
+	"github.com/juju/errors"
+)
+
+// ApplicationAccess defines the permission that a model user has on an
+// application.
+type ApplicationAccess int
+
+const (
+	_ = iota
+
+	// ApplicationOperateAccess allows a user to configure an
+	// application and run actions on its units.
+	ApplicationOperateAccess ApplicationAccess = iota
+
+	// ApplicationManageAccess allows a user to upgrade, scale and
+	// remove an application.
+	ApplicationManageAccess ApplicationAccess = iota
+)
+
+// ParseApplicationAccess parses a user-facing string representation of
+// an application access permission into a logical representation.
+func ParseApplicationAccess(access string) (ApplicationAccess, error) {
+	var fail = ApplicationAccess(0)
+	switch access {
+	case "operate":
+		return ApplicationOperateAccess, nil
+	case "manage":
+		return ApplicationManageAccess, nil
+	default:
+		return fail, errors.Errorf("invalid application access permission %q", access)
+	}
+}
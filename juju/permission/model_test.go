@@ -39,3 +39,33 @@ func (s *permissionSuite) TestParseModelAccessInvalid(c *gc.C) {
 	_, err := permission.ParseModelAccess("preposterous")
 	c.Check(err, gc.ErrorMatches, "invalid model access permission.*")
 }
+
+func (s *permissionSuite) TestParseControllerAccess(c *gc.C) {
+	access, err := permission.ParseControllerAccess("login")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ControllerLoginAccess)
+
+	access, err = permission.ParseControllerAccess("add-model")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ControllerAddModelAccess)
+
+	access, err = permission.ParseControllerAccess("superuser")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ControllerSuperuserAccess)
+
+	_, err = permission.ParseControllerAccess("admin")
+	c.Check(err, gc.ErrorMatches, `invalid controller access permission "admin"`)
+}
+
+func (s *permissionSuite) TestParseApplicationAccess(c *gc.C) {
+	access, err := permission.ParseApplicationAccess("operate")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ApplicationOperateAccess)
+
+	access, err = permission.ParseApplicationAccess("manage")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(access, gc.Equals, permission.ApplicationManageAccess)
+
+	_, err = permission.ParseApplicationAccess("write")
+	c.Check(err, gc.ErrorMatches, `invalid application access permission "write"`)
+}
@@ -41,6 +41,12 @@ controllers:
     api-endpoints: [this-is-one-of-many-api-endpoints]
     ca-cert: this-is-a-ca-cert
     cloud: prodstack
+    ssh-jump-hosts:
+    - host: bastion.prodstack.canonical.com
+      user: admin
+      host-keys:
+      - ssh-rsa bastion-key
+    - host: "0"
 current-controller: mallards
 `
 
@@ -76,6 +82,13 @@ func parseControllers(c *gc.C) *jujuclient.Controllers {
 	// ensure that multiple server hostnames and eapi endpoints are parsed correctly
 	c.Assert(controllers.Controllers["mark-test-prodstack"].UnresolvedAPIEndpoints, gc.HasLen, 2)
 	c.Assert(controllers.Controllers["mallards"].APIEndpoints, gc.HasLen, 2)
+	c.Assert(controllers.Controllers["mark-test-prodstack"].SSHJumpHosts, jc.DeepEquals, []jujuclient.SSHJumpHost{{
+		Host:     "bastion.prodstack.canonical.com",
+		User:     "admin",
+		HostKeys: []string{"ssh-rsa bastion-key"},
+	}, {
+		Host: "0",
+	}})
 	return controllers
 }
 
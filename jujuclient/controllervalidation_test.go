@@ -24,6 +24,7 @@ func (s *ControllerValidationSuite) SetUpTest(c *gc.C) {
 		"test.ca.cert",
 		"aws",
 		"southeastasia",
+		nil,
 	}
 }
 
@@ -43,6 +44,11 @@ func (s *ControllerValidationSuite) TestValidateControllerDetailsNoCACert(c *gc.
 	s.assertValidateControllerDetailsFails(c, "missing ca-cert, controller details not valid")
 }
 
+func (s *ControllerValidationSuite) TestValidateControllerDetailsSSHJumpHostNoHost(c *gc.C) {
+	s.controller.SSHJumpHosts = []jujuclient.SSHJumpHost{{Host: "bastion.example.com"}, {User: "admin"}}
+	s.assertValidateControllerDetailsFails(c, "missing host of ssh jump host 1, controller details not valid")
+}
+
 func (s *ControllerValidationSuite) assertValidateControllerDetailsFails(c *gc.C, failureMessage string) {
 	err := jujuclient.ValidateControllerDetails(s.controller)
 	c.Assert(err, gc.ErrorMatches, failureMessage)
@@ -31,6 +31,28 @@ type ControllerDetails struct {
 	// CloudRegion is the name of the cloud region that this controller
 	// runs in. This will be empty for clouds without regions.
 	CloudRegion string `yaml:"region,omitempty"`
+
+	// SSHJumpHosts holds the chain of hosts that SSH connections to
+	// machines of this controller go through, in the order they are
+	// connected to. It is empty when machines are reached directly.
+	SSHJumpHosts []SSHJumpHost `yaml:"ssh-jump-hosts,omitempty"`
+}
+
+// SSHJumpHost holds the details of a host that SSH connections are
+// proxied through (e.g. a bastion host).
+type SSHJumpHost struct {
+	// Host is the hostname or address of the jump host, or the ID of a
+	// Juju machine or unit in the model being connected to.
+	Host string `yaml:"host"`
+
+	// User is the user to log into the jump host as. Defaults to the
+	// current user for external hosts, and "ubuntu" for Juju machines.
+	User string `yaml:"user,omitempty"`
+
+	// HostKeys holds the public SSH host keys of an external jump host,
+	// in authorized_keys format. Keys of Juju machines and units are
+	// taken from the controller instead.
+	HostKeys []string `yaml:"host-keys,omitempty"`
 }
 
 // ModelDetails holds details of a model.
@@ -16,6 +16,11 @@ func ValidateControllerDetails(details ControllerDetails) error {
 	if details.CACert == "" {
 		return errors.NotValidf("missing ca-cert, controller details")
 	}
+	for i, jumpHost := range details.SSHJumpHosts {
+		if jumpHost.Host == "" {
+			return errors.NotValidf("missing host of ssh jump host %d, controller details", i)
+		}
+	}
 	return nil
 }
 
@@ -89,3 +89,30 @@ func (s *adminSuite) TestSetAdminMongoPassword(c *gc.C) {
 			string(mgo.RoleUserAdminAny),
 			string(mgo.RoleClusterAdmin)})
 }
+
+func (s *adminSuite) TestDatabaseSizes(c *gc.C) {
+	dialInfo := s.setUpMongo(c)
+	session, err := mgo.DialWithInfo(dialInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	defer session.Close()
+
+	err = session.DB("juju").C("machines").Insert(bson.M{"_id": "0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sizes, err := mongo.DatabaseSizes(session)
+	c.Assert(err, jc.ErrorIsNil)
+	var found bool
+	for _, db := range sizes {
+		if db.Name != "juju" {
+			continue
+		}
+		found = true
+		c.Check(db.DataSize > 0, jc.IsTrue)
+		collections := make(map[string]mongo.CollectionSize)
+		for _, coll := range db.Collections {
+			collections[coll.Name] = coll
+		}
+		c.Check(collections["machines"].Count, gc.Equals, int64(1))
+	}
+	c.Assert(found, jc.IsTrue)
+}
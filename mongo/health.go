@@ -0,0 +1,235 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MemberHealth holds the replication state of a single replica set
+// member, as reported by replSetGetStatus.
+type MemberHealth struct {
+	Id      int
+	Address string
+	State   string
+	Healthy bool
+	Self    bool
+	Optime  time.Time
+	Uptime  time.Duration
+	Message string
+
+	// Lag is how far the member's last applied operation trails the
+	// primary's. It is zero for the primary itself, and for every
+	// member when there is no primary.
+	Lag time.Duration
+}
+
+// ReplicaSetHealth holds the replication state of the replica set.
+type ReplicaSetHealth struct {
+	Name    string
+	Members []MemberHealth
+}
+
+// Primary returns the address of the primary member, or the empty
+// string if the replica set has no primary.
+func (h *ReplicaSetHealth) Primary() string {
+	for _, m := range h.Members {
+		if m.State == "PRIMARY" {
+			return m.Address
+		}
+	}
+	return ""
+}
+
+type replicaSetStatusDoc struct {
+	Name    string                   `bson:"set"`
+	Members []replicaSetMemberStatus `bson:"members"`
+}
+
+type replicaSetMemberStatus struct {
+	Id         int       `bson:"_id"`
+	Name       string    `bson:"name"`
+	Health     float64   `bson:"health"`
+	StateStr   string    `bson:"stateStr"`
+	Self       bool      `bson:"self"`
+	OptimeDate time.Time `bson:"optimeDate"`
+	Uptime     int64     `bson:"uptime"`
+	ErrMsg     string    `bson:"errmsg"`
+	// LastHeartbeatMessage is reported instead of errmsg for members
+	// other than the one we are connected to.
+	LastHeartbeatMessage string `bson:"lastHeartbeatMessage"`
+}
+
+// CurrentReplicaSetHealth returns the replication state of every
+// member of the replica set the session is connected to.
+func CurrentReplicaSetHealth(session *mgo.Session) (*ReplicaSetHealth, error) {
+	var doc replicaSetStatusDoc
+	if err := session.Run("replSetGetStatus", &doc); err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	return replicaSetHealth(doc), nil
+}
+
+func replicaSetHealth(doc replicaSetStatusDoc) *ReplicaSetHealth {
+	var primaryOptime time.Time
+	for _, m := range doc.Members {
+		if m.StateStr == "PRIMARY" {
+			primaryOptime = m.OptimeDate
+			break
+		}
+	}
+	health := &ReplicaSetHealth{Name: doc.Name}
+	for _, m := range doc.Members {
+		message := m.ErrMsg
+		if message == "" {
+			message = m.LastHeartbeatMessage
+		}
+		member := MemberHealth{
+			Id:      m.Id,
+			Address: m.Name,
+			State:   m.StateStr,
+			Healthy: m.Health == 1,
+			Self:    m.Self,
+			Optime:  m.OptimeDate,
+			Uptime:  time.Duration(m.Uptime) * time.Second,
+			Message: message,
+		}
+		if !primaryOptime.IsZero() && primaryOptime.After(m.OptimeDate) {
+			member.Lag = primaryOptime.Sub(m.OptimeDate)
+		}
+		health.Members = append(health.Members, member)
+	}
+	return health
+}
+
+// OplogInfo describes the operations held in the replication oplog.
+type OplogInfo struct {
+	// First and Last are the times of the oldest and newest
+	// operations in the oplog.
+	First time.Time
+	Last  time.Time
+
+	// Size is the number of bytes currently used by the oplog,
+	// and MaxSize is its capped size.
+	Size    int64
+	MaxSize int64
+}
+
+// Window returns the span of time covered by the oplog. A secondary
+// that falls further behind than this must be resynced from scratch.
+func (info *OplogInfo) Window() time.Duration {
+	return info.Last.Sub(info.First)
+}
+
+// CurrentOplogInfo returns the coverage and size of the oplog
+// in the local database.
+func CurrentOplogInfo(session *mgo.Session) (*OplogInfo, error) {
+	oplog := GetOplog(session)
+	var first, last OplogDoc
+	if err := oplog.Find(nil).Sort("$natural").One(&first); err != nil {
+		return nil, errors.Annotate(err, "cannot read oldest oplog entry")
+	}
+	if err := oplog.Find(nil).Sort("-$natural").One(&last); err != nil {
+		return nil, errors.Annotate(err, "cannot read newest oplog entry")
+	}
+	var stats struct {
+		Size    int64 `bson:"size"`
+		MaxSize int64 `bson:"maxSize"`
+	}
+	err := oplog.Database.Run(bson.D{{"collStats", oplog.Name}}, &stats)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get oplog stats")
+	}
+	return &OplogInfo{
+		First:   mongoTimestampTime(first.Timestamp),
+		Last:    mongoTimestampTime(last.Timestamp),
+		Size:    stats.Size,
+		MaxSize: stats.MaxSize,
+	}, nil
+}
+
+// mongoTimestampTime is the inverse of NewMongoTimestamp.
+func mongoTimestampTime(ts bson.MongoTimestamp) time.Time {
+	return time.Unix(int64(ts>>32), 0).UTC()
+}
+
+// DatabaseSize holds the disk usage of a database and its collections.
+type DatabaseSize struct {
+	Name        string
+	DataSize    int64
+	StorageSize int64
+	IndexSize   int64
+	Collections []CollectionSize
+}
+
+// CollectionSize holds the disk usage of a single collection.
+type CollectionSize struct {
+	Name        string
+	Count       int64
+	DataSize    int64
+	StorageSize int64
+	IndexSize   int64
+}
+
+// DatabaseSizes returns the disk usage of every database on the
+// server, and of every collection within them.
+func DatabaseSizes(session *mgo.Session) ([]DatabaseSize, error) {
+	names, err := session.DatabaseNames()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list databases")
+	}
+	var sizes []DatabaseSize
+	for _, name := range names {
+		size, err := databaseSize(session.DB(name))
+		if err != nil {
+			return nil, errors.Annotatef(err, "database %q", name)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+func databaseSize(db *mgo.Database) (DatabaseSize, error) {
+	var stats struct {
+		DataSize    int64 `bson:"dataSize"`
+		StorageSize int64 `bson:"storageSize"`
+		IndexSize   int64 `bson:"indexSize"`
+	}
+	if err := db.Run("dbStats", &stats); err != nil {
+		return DatabaseSize{}, errors.Annotate(err, "cannot get database stats")
+	}
+	size := DatabaseSize{
+		Name:        db.Name,
+		DataSize:    stats.DataSize,
+		StorageSize: stats.StorageSize,
+		IndexSize:   stats.IndexSize,
+	}
+	names, err := db.CollectionNames()
+	if err != nil {
+		return DatabaseSize{}, errors.Annotate(err, "cannot list collections")
+	}
+	for _, name := range names {
+		var stats struct {
+			Count          int64 `bson:"count"`
+			Size           int64 `bson:"size"`
+			StorageSize    int64 `bson:"storageSize"`
+			TotalIndexSize int64 `bson:"totalIndexSize"`
+		}
+		if err := db.Run(bson.D{{"collStats", name}}, &stats); err != nil {
+			return DatabaseSize{}, errors.Annotatef(err, "cannot get stats for collection %q", name)
+		}
+		size.Collections = append(size.Collections, CollectionSize{
+			Name:        name,
+			Count:       stats.Count,
+			DataSize:    stats.Size,
+			StorageSize: stats.StorageSize,
+			IndexSize:   stats.TotalIndexSize,
+		})
+	}
+	return size, nil
+}
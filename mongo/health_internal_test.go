@@ -0,0 +1,99 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type healthSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&healthSuite{})
+
+func (s *healthSuite) TestReplicaSetHealth(c *gc.C) {
+	now := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	health := replicaSetHealth(replicaSetStatusDoc{
+		Name: "juju",
+		Members: []replicaSetMemberStatus{{
+			Id:                   1,
+			Name:                 "10.0.0.1:37017",
+			Health:               1,
+			StateStr:             "SECONDARY",
+			OptimeDate:           now.Add(-5 * time.Second),
+			Uptime:               60,
+			LastHeartbeatMessage: "syncing from: 10.0.0.2:37017",
+		}, {
+			Id:         2,
+			Name:       "10.0.0.2:37017",
+			Health:     1,
+			StateStr:   "PRIMARY",
+			Self:       true,
+			OptimeDate: now,
+			Uptime:     120,
+		}, {
+			Id:       3,
+			Name:     "10.0.0.3:37017",
+			StateStr: "(not reachable/healthy)",
+			ErrMsg:   "no response",
+		}},
+	})
+	c.Assert(health.Primary(), gc.Equals, "10.0.0.2:37017")
+	c.Assert(health, jc.DeepEquals, &ReplicaSetHealth{
+		Name: "juju",
+		Members: []MemberHealth{{
+			Id:      1,
+			Address: "10.0.0.1:37017",
+			State:   "SECONDARY",
+			Healthy: true,
+			Optime:  now.Add(-5 * time.Second),
+			Uptime:  time.Minute,
+			Message: "syncing from: 10.0.0.2:37017",
+			Lag:     5 * time.Second,
+		}, {
+			Id:      2,
+			Address: "10.0.0.2:37017",
+			State:   "PRIMARY",
+			Healthy: true,
+			Self:    true,
+			Optime:  now,
+			Uptime:  2 * time.Minute,
+		}, {
+			Id:      3,
+			Address: "10.0.0.3:37017",
+			State:   "(not reachable/healthy)",
+			Message: "no response",
+		}},
+	})
+}
+
+func (s *healthSuite) TestReplicaSetHealthNoPrimary(c *gc.C) {
+	health := replicaSetHealth(replicaSetStatusDoc{
+		Members: []replicaSetMemberStatus{{
+			Name:       "10.0.0.1:37017",
+			Health:     1,
+			StateStr:   "SECONDARY",
+			OptimeDate: time.Now(),
+		}},
+	})
+	c.Assert(health.Primary(), gc.Equals, "")
+	c.Assert(health.Members[0].Lag, gc.Equals, time.Duration(0))
+}
+
+func (s *healthSuite) TestMongoTimestampTime(c *gc.C) {
+	t := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(mongoTimestampTime(NewMongoTimestamp(t)), gc.Equals, t)
+}
+
+func (s *healthSuite) TestOplogWindow(c *gc.C) {
+	t := time.Date(2016, 5, 1, 12, 0, 0, 0, time.UTC)
+	info := OplogInfo{First: t, Last: t.Add(36 * time.Hour)}
+	c.Assert(info.Window(), gc.Equals, 36*time.Hour)
+}
@@ -151,6 +151,13 @@ type OpSubnets struct {
 	Info       []network.SubnetInfo
 }
 
+type OpAllocateContainerAddresses struct {
+	Env            string
+	HostInstanceId instance.Id
+	ContainerTag   names.MachineTag
+	Info           []network.InterfaceInfo
+}
+
 type OpStartInstance struct {
 	Env              string
 	MachineId        string
@@ -1068,6 +1075,14 @@ func (env *environ) SupportsSpaces() (bool, error) {
 	return true, nil
 }
 
+// SupportsContainerAddresses is specified on environs.Networking.
+func (env *environ) SupportsContainerAddresses() (bool, error) {
+	if err := env.checkBroken("SupportsContainerAddresses"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // SupportsSpaceDiscovery is specified on environs.Networking.
 func (env *environ) SupportsSpaceDiscovery() (bool, error) {
 	if err := env.checkBroken("SupportsSpaceDiscovery"); err != nil {
@@ -1529,8 +1544,66 @@ func delay() {
 	}
 }
 
-func (e *environ) AllocateContainerAddresses(hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
-	return nil, errors.NotSupportedf("container address allocation")
+// AllocateContainerAddresses is specified on environs.Networking. Addresses
+// already set in preparedInfo (e.g. from reservations) are kept, while the
+// next free address in the subnet is picked for all other NICs.
+func (env *environ) AllocateContainerAddresses(hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
+	if err := env.checkBroken("AllocateContainerAddresses"); err != nil {
+		return nil, err
+	}
+	if len(preparedInfo) == 0 {
+		return nil, errors.Errorf("no prepared info to allocate")
+	}
+
+	estate, err := env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	allocatedInfo := make([]network.InterfaceInfo, len(preparedInfo))
+	for i, info := range preparedInfo {
+		_, ipNet, err := net.ParseCIDR(info.CIDR)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid CIDR of interface %q", info.InterfaceName)
+		}
+		if info.Address.Value == "" {
+			estate.maxAddr++
+			info.Address = network.NewAddress(nthAddress(ipNet, estate.maxAddr+1))
+		} else if !ipNet.Contains(net.ParseIP(info.Address.Value)) {
+			return nil, errors.Errorf(
+				"requested address %q of interface %q not in subnet %q",
+				info.Address.Value, info.InterfaceName, info.CIDR,
+			)
+		}
+		if info.GatewayAddress.Value == "" {
+			info.GatewayAddress = network.NewAddress(nthAddress(ipNet, 1))
+		}
+		info.ConfigType = network.ConfigStatic
+		allocatedInfo[i] = info
+	}
+
+	estate.ops <- OpAllocateContainerAddresses{
+		Env:            env.name,
+		HostInstanceId: hostInstanceID,
+		ContainerTag:   containerTag,
+		Info:           allocatedInfo,
+	}
+	return allocatedInfo, nil
+}
+
+// nthAddress returns the address at the given offset from the start of
+// ipNet.
+func nthAddress(ipNet *net.IPNet, offset int) string {
+	ip := make(net.IP, len(ipNet.IP))
+	copy(ip, ipNet.IP)
+	for i := len(ip) - 1; i >= 0 && offset > 0; i-- {
+		sum := int(ip[i]) + offset
+		ip[i] = byte(sum % 256)
+		offset = sum / 256
+	}
+	return ip.String()
 }
 
 // MigrationConfigUpdate implements MigrationConfigUpdater.
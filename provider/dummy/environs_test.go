@@ -12,6 +12,7 @@ import (
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
@@ -329,6 +330,71 @@ func (s *suite) TestSubnets(c *gc.C) {
 	c.Assert(netInfo, gc.HasLen, 0)
 }
 
+func (s *suite) TestAllocateContainerAddresses(c *gc.C) {
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	opc := make(chan dummy.Operation, 200)
+	dummy.Listen(opc)
+
+	preparedInfo := []network.InterfaceInfo{{
+		InterfaceName: "eth0",
+		CIDR:          "0.10.0.0/24",
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+	}, {
+		InterfaceName: "eth1",
+		CIDR:          "0.20.0.0/24",
+		MACAddress:    "aa:bb:cc:dd:ee:f1",
+		Address:       network.NewAddress("0.20.0.42"),
+	}}
+	expectInfo := []network.InterfaceInfo{{
+		InterfaceName:  "eth0",
+		CIDR:           "0.10.0.0/24",
+		MACAddress:     "aa:bb:cc:dd:ee:f0",
+		ConfigType:     network.ConfigStatic,
+		Address:        network.NewAddress("0.10.0.2"),
+		GatewayAddress: network.NewAddress("0.10.0.1"),
+	}, {
+		InterfaceName:  "eth1",
+		CIDR:           "0.20.0.0/24",
+		MACAddress:     "aa:bb:cc:dd:ee:f1",
+		ConfigType:     network.ConfigStatic,
+		Address:        network.NewAddress("0.20.0.42"),
+		GatewayAddress: network.NewAddress("0.20.0.1"),
+	}}
+	containerTag := names.NewMachineTag("0/lxd/0")
+	info, err := e.AllocateContainerAddresses("i-42", containerTag, preparedInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, expectInfo)
+
+	select {
+	case op := <-opc:
+		allocOp, ok := op.(dummy.OpAllocateContainerAddresses)
+		if !ok {
+			c.Fatalf("unexpected op: %#v", op)
+		}
+		c.Check(allocOp.HostInstanceId, gc.Equals, instance.Id("i-42"))
+		c.Check(allocOp.ContainerTag, gc.Equals, containerTag)
+		c.Check(allocOp.Info, jc.DeepEquals, expectInfo)
+	case <-time.After(testing.ShortWait):
+		c.Fatalf("time out wating for operation")
+	}
+
+	// Requested addresses outside of the subnet are rejected.
+	preparedInfo[1].Address = network.NewAddress("0.30.0.42")
+	_, err = e.AllocateContainerAddresses("i-42", containerTag, preparedInfo)
+	c.Assert(err, gc.ErrorMatches, `requested address "0.30.0.42" of interface "eth1" not in subnet "0.20.0.0/24"`)
+
+	// Test we can induce errors.
+	s.breakMethods(c, e, "AllocateContainerAddresses")
+	info, err = e.AllocateContainerAddresses("i-42", containerTag, preparedInfo)
+	c.Assert(err, gc.ErrorMatches, `dummy\.AllocateContainerAddresses is broken`)
+	c.Assert(info, gc.HasLen, 0)
+}
+
 func assertInterfaces(c *gc.C, e environs.Environ, opc chan dummy.Operation, expectInstId instance.Id, expectInfo []network.InterfaceInfo) {
 	select {
 	case op := <-opc:
@@ -163,6 +163,11 @@ func (e *environ) SupportsSpaceDiscovery() (bool, error) {
 	return false, nil
 }
 
+// SupportsContainerAddresses is specified on environs.Networking.
+func (e *environ) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	// TODO(anastasiamac 2016-03-16) LP#1557874
@@ -138,7 +138,7 @@ func (env *maasEnviron) updateDeviceInterface(deviceID instance.Id, interfaceID,
 	return iface, nil
 }
 
-func (env *maasEnviron) linkDeviceInterfaceToSubnet(deviceID instance.Id, interfaceID, subnetID string, mode maasLinkMode) (*maasInterface, error) {
+func (env *maasEnviron) linkDeviceInterfaceToSubnet(deviceID instance.Id, interfaceID, subnetID, ipAddress string, mode maasLinkMode) (*maasInterface, error) {
 	deviceSystemID := extractSystemId(deviceID)
 	uri := path.Join("nodes", deviceSystemID, "interfaces", interfaceID)
 	interfacesAPI := env.getMAASClient().GetSubObject(uri)
@@ -146,6 +146,11 @@ func (env *maasEnviron) linkDeviceInterfaceToSubnet(deviceID instance.Id, interf
 	params := make(url.Values)
 	params.Add("mode", string(mode))
 	params.Add("subnet", subnetID)
+	if ipAddress != "" {
+		// Only valid with static mode, allocating the requested address
+		// instead of picking one from the subnet.
+		params.Add("ip_address", ipAddress)
+	}
 
 	result, err := interfacesAPI.CallPost("link_subnet", params)
 	if err != nil {
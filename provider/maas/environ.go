@@ -255,6 +255,11 @@ func (env *maasEnviron) SupportsSpaceDiscovery() (bool, error) {
 	return true, nil
 }
 
+// SupportsContainerAddresses is specified on environs.Networking.
+func (env *maasEnviron) SupportsContainerAddresses() (bool, error) {
+	return true, nil
+}
+
 // allArchitectures2 uses the MAAS2 controller to get architectures from boot
 // resources.
 func (env *maasEnviron) allArchitectures2() ([]string, error) {
@@ -2075,7 +2080,7 @@ func (env *maasEnviron) allocateContainerAddresses1(hostInstanceID instance.Id,
 		deviceNICIDs[i] = maasNICID
 		subnetID := string(nic.ProviderSubnetId)
 
-		linkedInterface, err := env.linkDeviceInterfaceToSubnet(deviceID, maasNICID, subnetID, modeStatic)
+		linkedInterface, err := env.linkDeviceInterfaceToSubnet(deviceID, maasNICID, subnetID, nic.Address.Value, modeStatic)
 		if err != nil {
 			return nil, errors.Annotate(err, "cannot link device interface to subnet")
 		}
@@ -2152,6 +2157,23 @@ func (env *maasEnviron) allocateContainerAddresses2(hostInstanceID instance.Id,
 		// one interface.
 		return nil, errors.Errorf("unexpected number of interfaces inresponse from creating device: %v", interface_set)
 	}
+	if requestedAddress := primaryNICInfo.Address.Value; requestedAddress != "" {
+		// The primary NIC was linked to the subnet with an automatically
+		// picked static address, so relink it using the requested one.
+		primaryNIC := interface_set[0]
+		if err := primaryNIC.UnlinkSubnet(subnet); err != nil {
+			return nil, errors.Annotate(err, "cannot unlink primary device interface from subnet")
+		}
+		linkArgs := gomaasapi.LinkSubnetArgs{
+			Mode:      gomaasapi.LinkModeStatic,
+			Subnet:    subnet,
+			IPAddress: requestedAddress,
+		}
+		if err := primaryNIC.LinkSubnet(linkArgs); err != nil {
+			return nil, errors.Annotatef(err, "cannot link primary device interface to subnet with address %q", requestedAddress)
+		}
+		logger.Debugf("linked primary device interface to subnet with requested address %q", requestedAddress)
+	}
 
 	nameToParentName := make(map[string]string)
 	for _, nic := range preparedInfo {
@@ -2173,8 +2195,9 @@ func (env *maasEnviron) allocateContainerAddresses2(hostInstanceID instance.Id,
 			logger.Debugf("created device interface: %+v", createdNIC)
 
 			linkArgs := gomaasapi.LinkSubnetArgs{
-				Mode:   gomaasapi.LinkModeStatic,
-				Subnet: subnet,
+				Mode:      gomaasapi.LinkModeStatic,
+				Subnet:    subnet,
+				IPAddress: nic.Address.Value,
 			}
 			err = createdNIC.LinkSubnet(linkArgs)
 			if err != nil {
@@ -1478,6 +1478,106 @@ func (suite *maas2EnvironSuite) TestAllocateContainerAddressesLinkSubnetError(c
 	}
 	c.Assert(maasArgs, jc.DeepEquals, expected)
 }
+func (suite *maas2EnvironSuite) TestAllocateContainerAddressesRequestedAddress(c *gc.C) {
+	subnet := makeFakeSubnet(3)
+	subnet.vlan = fakeVLAN{id: 5001, mtu: 1500}
+	primary := &fakeInterface{
+		Stub:       &testing.Stub{},
+		id:         93,
+		name:       "eth0",
+		type_:      "physical",
+		enabled:    true,
+		macAddress: "DEADBEEF",
+		vlan:       subnet.vlan,
+		links: []gomaasapi.Link{
+			&fakeLink{
+				id:        480,
+				subnet:    &subnet,
+				ipAddress: "10.20.19.50",
+				mode:      "static",
+			},
+		},
+	}
+	device := &fakeDevice{
+		interfaceSet: []gomaasapi.Interface{primary},
+		systemID:     "foo",
+	}
+	controller := &fakeController{
+		machines: []gomaasapi.Machine{&fakeMachine{
+			Stub:         &testing.Stub{},
+			systemID:     "1",
+			createDevice: device,
+		}},
+		spaces: []gomaasapi.Space{
+			fakeSpace{
+				name:    "freckles",
+				id:      4567,
+				subnets: []gomaasapi.Subnet{subnet},
+			},
+		},
+		devices: []gomaasapi.Device{device},
+	}
+	suite.injectController(controller)
+	env := suite.makeEnviron(c, nil)
+	prepared := []network.InterfaceInfo{{
+		InterfaceName: "eth0",
+		CIDR:          "10.20.19.0/24",
+		MACAddress:    "DEADBEEF",
+		Address:       network.NewAddress("10.20.19.50"),
+	}}
+	ignored := names.NewMachineTag("1/lxd/0")
+	result, err := env.AllocateContainerAddresses(instance.Id("1"), ignored, prepared)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Check(result[0].Address.Value, gc.Equals, "10.20.19.50")
+
+	// The primary NIC, linked with an address picked by MAAS when the
+	// device was created, is relinked with the requested address.
+	primary.CheckCalls(c, []testing.StubCall{{
+		FuncName: "UnlinkSubnet",
+		Args:     []interface{}{subnet},
+	}, {
+		FuncName: "LinkSubnet",
+		Args: []interface{}{gomaasapi.LinkSubnetArgs{
+			Mode:      gomaasapi.LinkModeStatic,
+			Subnet:    subnet,
+			IPAddress: "10.20.19.50",
+		}},
+	}})
+}
+
+func (suite *maas2EnvironSuite) TestAllocateContainerAddressesUnlinkSubnetError(c *gc.C) {
+	subnet := makeFakeSubnet(3)
+	primary := &fakeInterface{Stub: &testing.Stub{}}
+	primary.SetErrors(errors.New("boom"))
+	device := &fakeDevice{
+		interfaceSet: []gomaasapi.Interface{primary},
+		systemID:     "foo",
+	}
+	controller := &fakeController{
+		machines: []gomaasapi.Machine{&fakeMachine{
+			Stub:         &testing.Stub{},
+			systemID:     "1",
+			createDevice: device,
+		}},
+		spaces: []gomaasapi.Space{
+			fakeSpace{
+				name:    "freckles",
+				id:      4567,
+				subnets: []gomaasapi.Subnet{subnet},
+			},
+		},
+	}
+	prepared := []network.InterfaceInfo{{
+		InterfaceName: "eth0",
+		CIDR:          "10.20.19.0/24",
+		MACAddress:    "DEADBEEF",
+		Address:       network.NewAddress("10.20.19.50"),
+	}}
+	suite.assertAllocateContainerAddressesFails(c, controller, prepared, "cannot unlink primary device interface from subnet: boom")
+	primary.CheckCallNames(c, "UnlinkSubnet")
+}
+
 func (suite *maas2EnvironSuite) TestStorageReturnsStorage(c *gc.C) {
 	controller := newFakeController()
 	env := suite.makeEnviron(c, controller)
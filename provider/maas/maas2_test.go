@@ -409,6 +409,11 @@ func (v *fakeInterface) LinkSubnet(args gomaasapi.LinkSubnetArgs) error {
 	return v.NextErr()
 }
 
+func (v *fakeInterface) UnlinkSubnet(subnet gomaasapi.Subnet) error {
+	v.MethodCall(v, "UnlinkSubnet", subnet)
+	return v.NextErr()
+}
+
 type fakeLink struct {
 	gomaasapi.Link
 	id        int
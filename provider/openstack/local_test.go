@@ -110,11 +110,12 @@ type localServer struct {
 	Mux             *http.ServeMux
 	oldHandler      http.Handler
 	Nova            *novaservice.Nova
+	Neutron         *fakeNeutron
 	restoreTimeouts func()
 	UseTLS          bool
 }
 
-type newOpenstackFunc func(*http.ServeMux, *identity.Credentials, identity.AuthMode) *novaservice.Nova
+type newOpenstackFunc func(*http.ServeMux, *identity.Credentials, identity.AuthMode) (*novaservice.Nova, identityservice.IdentityService)
 
 func (s *localServer) start(
 	c *gc.C, cred *identity.Credentials, newOpenstackFunc newOpenstackFunc,
@@ -131,7 +132,11 @@ func (s *localServer) start(
 	s.Server.Config.Handler = s.Mux
 	cred.URL = s.Server.URL
 	c.Logf("Started service at: %v", s.Server.URL)
-	s.Nova = newOpenstackFunc(s.Mux, cred, identity.AuthUserPass)
+	var identityService identityservice.IdentityService
+	s.Nova, identityService = newOpenstackFunc(s.Mux, cred, identity.AuthUserPass)
+	s.Neutron = newFakeNeutron(cred.URL, cred.Region)
+	identityService.RegisterServiceProvider("neutron", "network", s.Neutron)
+	s.Neutron.SetupHTTP(s.Mux)
 	s.restoreTimeouts = envtesting.PatchAttemptStrategies(openstack.ShortAttempt, openstack.StorageAttempt)
 	s.Nova.SetAvailabilityZones(
 		nova.AvailabilityZone{Name: "test-unavailable"},
@@ -1042,8 +1047,87 @@ func (s *localServerSuite) TestSupportedArchitectures(c *gc.C) {
 
 func (s *localServerSuite) TestSupportsNetworking(c *gc.C) {
 	env := s.Open(c, s.env.Config())
-	_, ok := environs.SupportsNetworking(env)
-	c.Assert(ok, jc.IsFalse)
+	netEnv, ok := environs.SupportsNetworking(env)
+	c.Assert(ok, jc.IsTrue)
+
+	supported, err := netEnv.SupportsSpaces()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsFalse)
+
+	supported, err = netEnv.SupportsSpaceDiscovery()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+}
+
+// setUpNeutronNetworks serves two tenant networks with subnets, an
+// external network and a subnet of an unknown network from the fake
+// Neutron API.
+func (s *localServerSuite) setUpNeutronNetworks() {
+	s.srv.Neutron.Networks = []neutronNetwork{
+		{Id: "net-1", Name: "dmz"},
+		{Id: "net-2", Name: "public", External: true},
+		{Id: "net-3", Name: "internal"},
+	}
+	s.srv.Neutron.Subnets = []neutronSubnet{
+		{Id: "sub-1", NetworkId: "net-1", Cidr: "10.1.0.0/24"},
+		{Id: "sub-2", NetworkId: "net-2", Cidr: "203.0.113.0/24"},
+		{Id: "sub-3", NetworkId: "net-3", Cidr: "10.3.0.0/24"},
+		{Id: "sub-4", NetworkId: "net-3", Cidr: "10.4.0.0/24"},
+		{Id: "sub-5", NetworkId: "net-5", Cidr: "10.5.0.0/24"},
+	}
+}
+
+func (s *localServerSuite) TestSpaces(c *gc.C) {
+	s.setUpNeutronNetworks()
+	env := s.Open(c, s.env.Config()).(environs.NetworkingEnviron)
+
+	spaces, err := env.Spaces()
+	c.Assert(err, jc.ErrorIsNil)
+	zones := []string{"test-available"}
+	c.Assert(spaces, jc.DeepEquals, []network.SpaceInfo{{
+		Name:       "dmz",
+		ProviderId: "net-1",
+		Subnets: []network.SubnetInfo{{
+			CIDR:              "10.1.0.0/24",
+			ProviderId:        "sub-1",
+			AvailabilityZones: zones,
+			SpaceProviderId:   "net-1",
+		}},
+	}, {
+		Name:       "internal",
+		ProviderId: "net-3",
+		Subnets: []network.SubnetInfo{{
+			CIDR:              "10.3.0.0/24",
+			ProviderId:        "sub-3",
+			AvailabilityZones: zones,
+			SpaceProviderId:   "net-3",
+		}, {
+			CIDR:              "10.4.0.0/24",
+			ProviderId:        "sub-4",
+			AvailabilityZones: zones,
+			SpaceProviderId:   "net-3",
+		}},
+	}})
+}
+
+func (s *localServerSuite) TestSubnets(c *gc.C) {
+	s.setUpNeutronNetworks()
+	env := s.Open(c, s.env.Config()).(environs.NetworkingEnviron)
+
+	subnets, err := env.Subnets(instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 3)
+
+	subnets, err = env.Subnets(instance.UnknownId, []network.Id{"sub-4"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Assert(subnets[0].CIDR, gc.Equals, "10.4.0.0/24")
+
+	_, err = env.Subnets(instance.UnknownId, []network.Id{"sub-3", "sub-2", "missing"})
+	c.Assert(err, gc.ErrorMatches, `failed to find the following subnet ids: \[sub-2 missing\]`)
+
+	_, err = env.Subnets("inst-0", nil)
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
 }
 
 func (s *localServerSuite) TestFindImageBadDefaultImage(c *gc.C) {
@@ -2010,13 +2094,13 @@ func (s *noSwiftSuite) TestBootstrap(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func newFullOpenstackService(mux *http.ServeMux, cred *identity.Credentials, auth identity.AuthMode) *novaservice.Nova {
+func newFullOpenstackService(mux *http.ServeMux, cred *identity.Credentials, auth identity.AuthMode) (*novaservice.Nova, identityservice.IdentityService) {
 	service := openstackservice.New(cred, auth)
 	service.SetupHTTP(mux)
-	return service.Nova
+	return service.Nova, service.Identity
 }
 
-func newNovaOnlyOpenstackService(mux *http.ServeMux, cred *identity.Credentials, auth identity.AuthMode) *novaservice.Nova {
+func newNovaOnlyOpenstackService(mux *http.ServeMux, cred *identity.Credentials, auth identity.AuthMode) (*novaservice.Nova, identityservice.IdentityService) {
 	var identityService, fallbackService identityservice.IdentityService
 	if auth == identity.AuthKeyPair {
 		identityService = identityservice.NewKeyPair()
@@ -2031,5 +2115,5 @@ func newNovaOnlyOpenstackService(mux *http.ServeMux, cred *identity.Credentials,
 	novaService := novaservice.New(cred.URL, "v2", userInfo.TenantId, cred.Region, identityService, fallbackService)
 	identityService.SetupHTTP(mux)
 	novaService.SetupHTTP(mux)
-	return novaService
+	return novaService, identityService
 }
@@ -0,0 +1,208 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/goose.v1/client"
+	gooseerrors "gopkg.in/goose.v1/errors"
+	goosehttp "gopkg.in/goose.v1/http"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+)
+
+var _ environs.Networking = (*Environ)(nil)
+
+// SupportsSpaces is specified on environs.Networking. Spaces are
+// discovered from Neutron, but instances are not yet started in the
+// networks of the spaces named in their constraints, so space
+// constraints are not supported.
+func (e *Environ) SupportsSpaces() (bool, error) {
+	return false, nil
+}
+
+// SupportsSpaceDiscovery is specified on environs.Networking. Spaces
+// can be discovered when the cloud has a Neutron endpoint.
+func (e *Environ) SupportsSpaceDiscovery() (bool, error) {
+	supported, err := e.supportsNeutron()
+	return supported, errors.Trace(err)
+}
+
+// supportsNeutron reports whether the cloud has a Neutron endpoint in
+// the environment's region.
+func (e *Environ) supportsNeutron() (bool, error) {
+	if !e.client.IsAuthenticated() {
+		if err := authenticateClient(e); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+	_, ok := e.client.EndpointsForRegion(e.ecfg().region())["network"]
+	return ok, nil
+}
+
+// Spaces is specified on environs.Networking. Each Neutron network
+// with subnets is returned as a space containing those subnets.
+// External networks are not included.
+func (e *Environ) Spaces() ([]network.SpaceInfo, error) {
+	networks, subnets, err := e.neutronNetworks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var spaces []network.SpaceInfo
+	spaceIndex := make(map[network.Id]int)
+	for _, subnet := range subnets {
+		i, ok := spaceIndex[subnet.SpaceProviderId]
+		if !ok {
+			i = len(spaces)
+			spaceIndex[subnet.SpaceProviderId] = i
+			spaces = append(spaces, network.SpaceInfo{
+				Name:       networks[string(subnet.SpaceProviderId)].Name,
+				ProviderId: subnet.SpaceProviderId,
+			})
+		}
+		spaces[i].Subnets = append(spaces[i].Subnets, subnet)
+	}
+	return spaces, nil
+}
+
+// Subnets is specified on environs.Networking. Only subnets known to the
+// whole environment are supported, i.e. instId must be instance.UnknownId.
+// subnetIds can be empty, in which case all known subnets are returned.
+func (e *Environ) Subnets(instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	if instId != instance.UnknownId {
+		return nil, errors.NotSupportedf("subnets for instance")
+	}
+	_, subnets, err := e.neutronNetworks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(subnetIds) == 0 {
+		return subnets, nil
+	}
+
+	byId := make(map[network.Id]network.SubnetInfo)
+	for _, subnet := range subnets {
+		byId[subnet.ProviderId] = subnet
+	}
+	var results []network.SubnetInfo
+	var notFound []string
+	for _, id := range subnetIds {
+		subnet, ok := byId[id]
+		if !ok {
+			notFound = append(notFound, string(id))
+			continue
+		}
+		results = append(results, subnet)
+	}
+	if len(notFound) != 0 {
+		return nil, errors.Errorf("failed to find the following subnet ids: %v", notFound)
+	}
+	return results, nil
+}
+
+// neutronNetwork holds the attributes of a Neutron network used by
+// juju.
+type neutronNetwork struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	External bool   `json:"router:external"`
+}
+
+// neutronSubnet holds the attributes of a Neutron subnet used by juju.
+type neutronSubnet struct {
+	Id        string `json:"id"`
+	NetworkId string `json:"network_id"`
+	Cidr      string `json:"cidr"`
+}
+
+// neutronNetworks returns the tenant's Neutron networks that are not
+// external, keyed by id, along with their subnets. All subnets span
+// every availability zone of the environment.
+func (e *Environ) neutronNetworks() (map[string]neutronNetwork, []network.SubnetInfo, error) {
+	if supported, err := e.supportsNeutron(); err != nil {
+		return nil, nil, errors.Trace(err)
+	} else if !supported {
+		return nil, nil, errors.NotSupportedf("networking without a Neutron endpoint")
+	}
+	var networksResp struct {
+		Networks []neutronNetwork `json:"networks"`
+	}
+	requestData := goosehttp.RequestData{RespValue: &networksResp}
+	if err := e.client.SendRequest(client.GET, "network", "v2.0/networks", &requestData); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot list networks")
+	}
+	var subnetsResp struct {
+		Subnets []neutronSubnet `json:"subnets"`
+	}
+	requestData = goosehttp.RequestData{RespValue: &subnetsResp}
+	if err := e.client.SendRequest(client.GET, "network", "v2.0/subnets", &requestData); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot list subnets")
+	}
+	zoneNames, err := e.availabilityZoneNames()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	networks := make(map[string]neutronNetwork)
+	for _, net := range networksResp.Networks {
+		if net.External {
+			logger.Debugf("skipping external network %q (%s)", net.Name, net.Id)
+			continue
+		}
+		networks[net.Id] = net
+	}
+	var subnets []network.SubnetInfo
+	for _, subnet := range subnetsResp.Subnets {
+		if _, ok := networks[subnet.NetworkId]; !ok {
+			continue
+		}
+		subnets = append(subnets, network.SubnetInfo{
+			CIDR:              subnet.Cidr,
+			ProviderId:        network.Id(subnet.Id),
+			AvailabilityZones: zoneNames,
+			SpaceProviderId:   network.Id(subnet.NetworkId),
+		})
+	}
+	return networks, subnets, nil
+}
+
+// availabilityZoneNames returns the names of all available zones, or nil
+// if availability zones are not supported by the cloud.
+func (e *Environ) availabilityZoneNames() ([]string, error) {
+	zones, err := e.AvailabilityZones()
+	if errors.IsNotImplemented(err) || gooseerrors.IsNotImplemented(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get availability zones")
+	}
+	var zoneNames []string
+	for _, zone := range zones {
+		if zone.Available() {
+			zoneNames = append(zoneNames, zone.Name())
+		}
+	}
+	return zoneNames, nil
+}
+
+// NetworkInterfaces is specified on environs.Networking.
+func (e *Environ) NetworkInterfaces(instId instance.Id) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("network interfaces")
+}
+
+// SupportsContainerAddresses is specified on environs.Networking.
+// Container addresses would need Neutron ports on the host's network,
+// allowed as address pairs of the host's port, which are not created.
+func (e *Environ) SupportsContainerAddresses() (bool, error) {
+	return false, errors.NotSupportedf("container address allocation")
+}
+
+// AllocateContainerAddresses is specified on environs.Networking. It is
+// not supported, see SupportsContainerAddresses.
+func (e *Environ) AllocateContainerAddresses(hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
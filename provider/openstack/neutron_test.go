@@ -0,0 +1,77 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gopkg.in/goose.v1/testservices/identityservice"
+)
+
+// neutronNetwork is a network served by fakeNeutron.
+type neutronNetwork struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	External bool   `json:"router:external"`
+}
+
+// neutronSubnet is a subnet served by fakeNeutron.
+type neutronSubnet struct {
+	Id        string `json:"id"`
+	NetworkId string `json:"network_id"`
+	Cidr      string `json:"cidr"`
+}
+
+// fakeNeutron is a test double for the network and subnet listing
+// parts of the Neutron API, which the goose test services don't
+// provide. It is registered in the identity service's catalog as the
+// "network" endpoint.
+type fakeNeutron struct {
+	url    string
+	region string
+
+	Networks []neutronNetwork
+	Subnets  []neutronSubnet
+}
+
+func newFakeNeutron(hostURL, region string) *fakeNeutron {
+	return &fakeNeutron{
+		url:    hostURL + "/neutron",
+		region: region,
+	}
+}
+
+// Endpoints is part of identityservice.ServiceProvider.
+func (n *fakeNeutron) Endpoints() []identityservice.Endpoint {
+	return []identityservice.Endpoint{{
+		AdminURL:    n.url,
+		InternalURL: n.url,
+		PublicURL:   n.url,
+		Region:      n.region,
+	}}
+}
+
+// V3Endpoints is part of identityservice.ServiceProvider. Only the
+// Keystone V2 catalog is used by the tests.
+func (n *fakeNeutron) V3Endpoints() []identityservice.V3Endpoint {
+	return nil
+}
+
+// SetupHTTP serves the fake Neutron API on the given mux.
+func (n *fakeNeutron) SetupHTTP(mux *http.ServeMux) {
+	mux.HandleFunc("/neutron/v2.0/networks", func(w http.ResponseWriter, r *http.Request) {
+		n.respond(w, map[string]interface{}{"networks": n.Networks})
+	})
+	mux.HandleFunc("/neutron/v2.0/subnets", func(w http.ResponseWriter, r *http.Request) {
+		n.respond(w, map[string]interface{}{"subnets": n.Subnets})
+	})
+}
+
+func (n *fakeNeutron) respond(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -100,8 +100,10 @@ func newResource(c *gc.C, name, username, data string) (resource.Resource, api.R
 type stubFacade struct {
 	basetesting.StubFacadeCaller
 
-	apiResults map[string]api.ResourcesResult
-	pendingIDs []string
+	apiResults    map[string]api.ResourcesResult
+	pendingIDs    []string
+	historyResult api.ResourceHistoryResult
+	errorResult   params.ErrorResult
 }
 
 func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
@@ -134,6 +136,10 @@ func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
 			}
 		case *api.AddPendingResourcesResult:
 			typedResponse.PendingIDs = s.pendingIDs
+		case *api.ResourceHistoryResults:
+			typedResponse.Results = []api.ResourceHistoryResult{s.historyResult}
+		case *params.ErrorResults:
+			typedResponse.Results = []params.ErrorResult{s.errorResult}
 		default:
 			c.Errorf("bad type %T", response)
 		}
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/macaroon.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
@@ -102,6 +103,75 @@ func (c Client) Upload(service, name, filename string, reader io.ReadSeeker) err
 	return nil
 }
 
+// UploadFromURL has the controller fetch the content of the resource
+// from the given HTTP(S) URL and store it as the application's new
+// revision of the resource.
+func (c Client) UploadFromURL(service, name, url string) error {
+	args, err := api.NewSetResourcesFromURLArgs(service, name, url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var results params.ErrorResults
+	if err := c.FacadeCall("SetResourcesFromURL", &args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if err := results.OneError(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ResourceHistory returns the previous revisions of the identified
+// resource, most recent first.
+func (c Client) ResourceHistory(service, name string) ([]resource.HistoryEntry, error) {
+	args, err := api.NewResourceHistoryArgs(service, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var apiResults api.ResourceHistoryResults
+	if err := c.FacadeCall("ResourceHistory", &args, &apiResults); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(apiResults.Results) != 1 {
+		return nil, errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+	apiResult := apiResults.Results[0]
+	if apiResult.Error != nil {
+		err := common.RestoreError(apiResult.Error)
+		return nil, errors.Trace(err)
+	}
+
+	var history []resource.HistoryEntry
+	for _, apiEntry := range apiResult.History {
+		entry, err := api.API2HistoryEntry(apiEntry)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// RollbackResource makes the previous revision of the resource with
+// the given history index the application's active revision again.
+func (c Client) RollbackResource(service, name string, index int) error {
+	args, err := api.NewRollbackResourcesArgs(service, name, index)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var results params.ErrorResults
+	if err := c.FacadeCall("RollbackResources", &args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if err := results.OneError(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // AddPendingResourcesArgs holds the arguments to AddPendingResources().
 type AddPendingResourcesArgs struct {
 	// ApplicationID identifies the application being deployed.
@@ -0,0 +1,123 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&HistorySuite{})
+
+type HistorySuite struct {
+	BaseSuite
+}
+
+func (s *HistorySuite) TestUploadFromURL(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadFromURL("a-application", "spam", "https://example.com/spam.tgz")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall", "SetResourcesFromURL", &api.SetResourcesFromURLArgs{
+		Resources: []api.ResourceURL{{
+			ResourceArg: api.ResourceArg{
+				Entity: params.Entity{Tag: "application-a-application"},
+				Name:   "spam",
+			},
+			URL: "https://example.com/spam.tgz",
+		}},
+	}, &params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+}
+
+func (s *HistorySuite) TestUploadFromURLError(c *gc.C) {
+	s.facade.errorResult.Error = &params.Error{Message: "<failure>"}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadFromURL("a-application", "spam", "https://example.com/spam.tgz")
+
+	c.Check(err, gc.ErrorMatches, "<failure>")
+}
+
+func (s *HistorySuite) TestUploadFromURLBadService(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.UploadFromURL("???", "spam", "https://example.com/spam.tgz")
+
+	c.Check(err, gc.ErrorMatches, `invalid application "\?\?\?"`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *HistorySuite) TestResourceHistory(c *gc.C) {
+	res, apiRes := newResource(c, "spam", "a-user", "spamspamspam")
+	superseded := time.Now()
+	s.facade.historyResult.History = []api.HistoryEntry{{
+		Resource:   apiRes,
+		Index:      1,
+		Superseded: superseded,
+	}}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	history, err := cl.ResourceHistory("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(history, jc.DeepEquals, []resource.HistoryEntry{{
+		Resource:   res,
+		Index:      1,
+		Superseded: superseded,
+	}})
+	s.stub.CheckCallNames(c, "FacadeCall")
+}
+
+func (s *HistorySuite) TestResourceHistoryNotFound(c *gc.C) {
+	s.facade.historyResult.Error = &params.Error{
+		Message: `application "a-application" not found`,
+		Code:    params.CodeNotFound,
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ResourceHistory("a-application", "spam")
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *HistorySuite) TestRollbackResource(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall", "RollbackResources", &api.RollbackResourcesArgs{
+		Resources: []api.RollbackResourceArg{{
+			ResourceArg: api.ResourceArg{
+				Entity: params.Entity{Tag: "application-a-application"},
+				Name:   "spam",
+			},
+			Index: 2,
+		}},
+	}, &params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+}
+
+func (s *HistorySuite) TestRollbackResourceBadIndex(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 0)
+
+	c.Check(err, gc.ErrorMatches, `invalid history index 0`)
+	s.stub.CheckNoCalls(c)
+}
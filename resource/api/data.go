@@ -111,6 +111,10 @@ type ResourcesResult struct {
 	// UnitResources contains a list of the resources for each unit in the
 	// application.
 	UnitResources []UnitResources `json:"unit-resources"`
+
+	// Cache is the list of charm store resources the controller
+	// fetched ahead of time for the application.
+	Cache []CachedResource `json:"cache,omitempty"`
 }
 
 // A UnitResources contains a list of the resources the unit defined by Entity.
@@ -186,6 +190,157 @@ type CharmResource struct {
 	Size int64 `json:"size"`
 }
 
+// SetResourcesFromURLArgs holds the arguments to the
+// SetResourcesFromURL API endpoint.
+type SetResourcesFromURLArgs struct {
+	// Resources is the list of resources to fetch and store.
+	Resources []ResourceURL `json:"resources"`
+}
+
+// ResourceURL identifies an application resource along with the
+// HTTP(S) URL from which the controller fetches its content.
+type ResourceURL struct {
+	ResourceArg
+
+	// URL is where the content of the resource is fetched from.
+	URL string `json:"url"`
+}
+
+// NewSetResourcesFromURLArgs returns the arguments for the
+// SetResourcesFromURL API endpoint.
+func NewSetResourcesFromURLArgs(applicationID, name, url string) (SetResourcesFromURLArgs, error) {
+	var args SetResourcesFromURLArgs
+	resArg, err := newResourceArg(applicationID, name)
+	if err != nil {
+		return args, errors.Trace(err)
+	}
+	if url == "" {
+		return args, errors.New("missing URL")
+	}
+	args.Resources = []ResourceURL{{
+		ResourceArg: resArg,
+		URL:         url,
+	}}
+	return args, nil
+}
+
+// ResourceArg identifies a resource of an application.
+type ResourceArg struct {
+	params.Entity
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+}
+
+func newResourceArg(applicationID, name string) (ResourceArg, error) {
+	var arg ResourceArg
+	if !names.IsValidApplication(applicationID) {
+		return arg, errors.Errorf("invalid application %q", applicationID)
+	}
+	if name == "" {
+		return arg, errors.New("missing resource name")
+	}
+	arg.Tag = names.NewApplicationTag(applicationID).String()
+	arg.Name = name
+	return arg, nil
+}
+
+// ResourceHistoryArgs holds the arguments to the ResourceHistory API
+// endpoint.
+type ResourceHistoryArgs struct {
+	// Resources identifies the resources of which to list the history.
+	Resources []ResourceArg `json:"resources"`
+}
+
+// NewResourceHistoryArgs returns the arguments for the ResourceHistory
+// API endpoint.
+func NewResourceHistoryArgs(applicationID, name string) (ResourceHistoryArgs, error) {
+	var args ResourceHistoryArgs
+	resArg, err := newResourceArg(applicationID, name)
+	if err != nil {
+		return args, errors.Trace(err)
+	}
+	args.Resources = []ResourceArg{resArg}
+	return args, nil
+}
+
+// ResourceHistoryResults holds the resource histories that result
+// from a bulk API call.
+type ResourceHistoryResults struct {
+	// Results is the list of resource history results.
+	Results []ResourceHistoryResult `json:"results"`
+}
+
+// ResourceHistoryResult holds the history of a single resource.
+type ResourceHistoryResult struct {
+	params.ErrorResult
+
+	// History is the list of previous revisions of the resource,
+	// most recent first.
+	History []HistoryEntry `json:"history"`
+}
+
+// HistoryEntry contains info about a previous revision of a resource.
+type HistoryEntry struct {
+	Resource
+
+	// Index identifies the entry in the resource's history.
+	Index int `json:"index"`
+
+	// Superseded indicates when the revision was replaced.
+	Superseded time.Time `json:"superseded"`
+}
+
+// CachedResource contains info about a charm store resource the
+// controller fetched ahead of time.
+type CachedResource struct {
+	CharmResource
+
+	// ApplicationID identifies the application for which the resource
+	// was fetched.
+	ApplicationID string `json:"application"`
+
+	// Fetched indicates when the resource was fetched.
+	Fetched time.Time `json:"fetched"`
+
+	// LastUsed indicates when the cached resource was last needed.
+	LastUsed time.Time `json:"last-used"`
+}
+
+// RollbackResourcesArgs holds the arguments to the RollbackResources
+// API endpoint.
+type RollbackResourcesArgs struct {
+	// Resources identifies the resources to roll back.
+	Resources []RollbackResourceArg `json:"resources"`
+}
+
+// RollbackResourceArg identifies the previous revision of a resource
+// to roll back to.
+type RollbackResourceArg struct {
+	ResourceArg
+
+	// Index identifies the entry in the resource's history.
+	Index int `json:"index"`
+}
+
+// NewRollbackResourcesArgs returns the arguments for the
+// RollbackResources API endpoint.
+func NewRollbackResourcesArgs(applicationID, name string, index int) (RollbackResourcesArgs, error) {
+	var args RollbackResourcesArgs
+	resArg, err := newResourceArg(applicationID, name)
+	if err != nil {
+		return args, errors.Trace(err)
+	}
+	if index <= 0 {
+		return args, errors.Errorf("invalid history index %d", index)
+	}
+	args.Resources = []RollbackResourceArg{{
+		ResourceArg: resArg,
+		Index:       index,
+	}}
+	return args, nil
+}
+
 func resolveErrors(errs []error) error {
 	switch len(errs) {
 	case 0:
@@ -85,6 +85,14 @@ func APIResult2ServiceResources(apiResult ResourcesResult) (resource.ServiceReso
 		result.CharmStoreResources = append(result.CharmStoreResources, res)
 	}
 
+	for _, apiCached := range apiResult.Cache {
+		cached, err := API2CachedResource(apiCached)
+		if err != nil {
+			return resource.ServiceResources{}, errors.Annotate(err, "got bad data from server")
+		}
+		result.Cache = append(result.Cache, cached)
+	}
+
 	return result, nil
 }
 
@@ -115,6 +123,10 @@ func ServiceResources2APIResult(svcRes resource.ServiceResources) ResourcesResul
 	for i, chRes := range svcRes.CharmStoreResources {
 		result.CharmStoreResources[i] = CharmResource2API(chRes)
 	}
+
+	for _, cached := range svcRes.Cache {
+		result.Cache = append(result.Cache, CachedResource2API(cached))
+	}
 	return result
 }
 
@@ -144,6 +156,67 @@ func API2Resource(apiRes Resource) (resource.Resource, error) {
 	return res, nil
 }
 
+// HistoryEntry2API converts a resource.HistoryEntry into
+// a HistoryEntry struct.
+func HistoryEntry2API(entry resource.HistoryEntry) HistoryEntry {
+	return HistoryEntry{
+		Resource:   Resource2API(entry.Resource),
+		Index:      entry.Index,
+		Superseded: entry.Superseded,
+	}
+}
+
+// API2HistoryEntry converts an API HistoryEntry struct into
+// a resource.HistoryEntry.
+func API2HistoryEntry(apiEntry HistoryEntry) (resource.HistoryEntry, error) {
+	var entry resource.HistoryEntry
+
+	res, err := API2Resource(apiEntry.Resource)
+	if err != nil {
+		return entry, errors.Trace(err)
+	}
+
+	entry = resource.HistoryEntry{
+		Resource:   res,
+		Index:      apiEntry.Index,
+		Superseded: apiEntry.Superseded,
+	}
+	return entry, nil
+}
+
+// CachedResource2API converts a resource.CachedResource into
+// a CachedResource struct.
+func CachedResource2API(res resource.CachedResource) CachedResource {
+	return CachedResource{
+		CharmResource: CharmResource2API(res.Resource),
+		ApplicationID: res.ApplicationID,
+		Fetched:       res.Fetched,
+		LastUsed:      res.LastUsed,
+	}
+}
+
+// API2CachedResource converts an API CachedResource struct into
+// a resource.CachedResource.
+func API2CachedResource(apiRes CachedResource) (resource.CachedResource, error) {
+	var res resource.CachedResource
+
+	charmRes, err := API2CharmResource(apiRes.CharmResource)
+	if err != nil {
+		return res, errors.Trace(err)
+	}
+
+	res = resource.CachedResource{
+		Resource:      charmRes,
+		ApplicationID: apiRes.ApplicationID,
+		Fetched:       apiRes.Fetched,
+		LastUsed:      apiRes.LastUsed,
+	}
+	if err := res.Validate(); err != nil {
+		return res, errors.Trace(err)
+	}
+	return res, nil
+}
+
 // CharmResource2API converts a charm resource into
 // a CharmResource struct.
 func CharmResource2API(res charmresource.Resource) CharmResource {
@@ -448,6 +448,45 @@ func (HelpersSuite) TestAPI2Resource(c *gc.C) {
 	c.Check(res, jc.DeepEquals, expected)
 }
 
+func (HelpersSuite) TestHistoryEntryRoundTrip(c *gc.C) {
+	now := time.Now()
+	opened := resourcetesting.NewResource(c, nil, "spam", "a-application", "spamspamspam")
+	entry := resource.HistoryEntry{
+		Resource:   opened.Resource,
+		Index:      3,
+		Superseded: now,
+	}
+
+	apiEntry := api.HistoryEntry2API(entry)
+	c.Check(apiEntry.Resource, jc.DeepEquals, api.Resource2API(opened.Resource))
+	c.Check(apiEntry.Index, gc.Equals, 3)
+	c.Check(apiEntry.Superseded, gc.Equals, now)
+
+	converted, err := api.API2HistoryEntry(apiEntry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(converted, jc.DeepEquals, entry)
+}
+
+func (HelpersSuite) TestCachedResourceRoundTrip(c *gc.C) {
+	now := time.Now()
+	chRes := resourcetesting.NewCharmResource(c, "spam", "spamspamspam")
+	chRes.Origin = charmresource.OriginStore
+	cached := resource.CachedResource{
+		Resource:      chRes,
+		ApplicationID: "a-application",
+		Fetched:       now.Add(-time.Hour),
+		LastUsed:      now,
+	}
+
+	apiRes := api.CachedResource2API(cached)
+	c.Check(apiRes.CharmResource, jc.DeepEquals, api.CharmResource2API(chRes))
+	c.Check(apiRes.ApplicationID, gc.Equals, "a-application")
+
+	converted, err := api.API2CachedResource(apiRes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(converted, jc.DeepEquals, cached)
+}
+
 func (HelpersSuite) TestCharmResource2API(c *gc.C) {
 	fp, err := charmresource.NewFingerprint([]byte(fingerprint))
 	c.Assert(err, jc.ErrorIsNil)
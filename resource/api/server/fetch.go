@@ -0,0 +1,148 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// parseResourceURL parses the URL from which the content of a resource
+// is fetched. Only HTTP(S) URLs are supported.
+func parseResourceURL(rawURL string) (*url.URL, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "invalid resource URL")
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return nil, errors.NotValidf("resource URL %q (only http and https are supported)", rawURL)
+	}
+	if u.Host == "" {
+		return nil, errors.NotValidf("resource URL %q (missing host)", rawURL)
+	}
+	return u, nil
+}
+
+const (
+	// fetchTimeout bounds the time taken to download the content of
+	// a resource, including connecting and following redirects.
+	fetchTimeout = 10 * time.Minute
+
+	// fetchDialTimeout bounds the time taken to connect to the host
+	// serving the content of a resource.
+	fetchDialTimeout = 30 * time.Second
+
+	// maxFetchSize is the most content fetched for a resource whose
+	// size is not known.
+	maxFetchSize = 1 << 30
+)
+
+// checkFetchIP returns an error if the content of a resource may not
+// be fetched from the given IP address. Clients choose the URLs, so
+// the controller refuses to connect to its own addresses, and to
+// loopback and link-local addresses, which would give clients access
+// to services that are only meant to be reachable from the controller
+// host, such as cloud metadata services.
+var checkFetchIP = func(ip net.IP, controllerIPs []net.IP) error {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return errors.Errorf("cannot fetch resources from address %s", ip)
+	}
+	for _, controllerIP := range controllerIPs {
+		if ip.Equal(controllerIP) {
+			return errors.Errorf("cannot fetch resources from controller address %s", ip)
+		}
+	}
+	return nil
+}
+
+// newFetchClient returns an HTTP client that only connects to
+// addresses allowed by checkFetchIP. Each address is checked when
+// connecting, so redirects, and names that resolve to different
+// addresses over time, are checked too.
+func newFetchClient(controllerIPs []net.IP) *http.Client {
+	dialer := &net.Dialer{Timeout: fetchDialTimeout}
+	dial := func(network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ips, err := net.LookupIP(host)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ip := range ips {
+			if err := checkFetchIP(ip, controllerIPs); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Connect to the address just checked, rather than letting
+		// the dialer resolve the name again.
+		return dialer.Dial(network, net.JoinHostPort(ips[0].String(), port))
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Dial:                dial,
+			TLSHandshakeTimeout: fetchDialTimeout,
+		},
+		Timeout: fetchTimeout,
+	}
+}
+
+// fetchResource downloads the content at the given URL into a
+// temporary file. The file is positioned at its start, ready to be
+// read. The caller is responsible for removing it.
+//
+// No more than maxSize bytes are read; if maxSize is not positive,
+// no more than maxFetchSize bytes are. The content may not be served
+// from any of the given controller addresses.
+func fetchResource(u *url.URL, maxSize int64, controllerIPs []net.IP) (*os.File, error) {
+	if maxSize <= 0 {
+		maxSize = maxFetchSize
+	}
+	resp, err := newFetchClient(controllerIPs).Get(u.String())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad HTTP response: %s", resp.Status)
+	}
+	if resp.ContentLength > maxSize {
+		return nil, errors.Errorf("resource too large: %d bytes, expected at most %d", resp.ContentLength, maxSize)
+	}
+
+	file, err := ioutil.TempFile("", "juju-resource-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cleanup := func() {
+		file.Close()
+		os.Remove(file.Name())
+	}
+	// Read one byte more than allowed, to tell content of exactly
+	// the maximum size from content that is too large.
+	n, err := io.Copy(file, io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		cleanup()
+		return nil, errors.Trace(err)
+	}
+	if n > maxSize {
+		cleanup()
+		return nil, errors.Errorf("resource too large: expected at most %d bytes", maxSize)
+	}
+	if _, err := file.Seek(0, os.SEEK_SET); err != nil {
+		cleanup()
+		return nil, errors.Trace(err)
+	}
+	return file, nil
+}
@@ -5,6 +5,9 @@ package server
 
 import (
 	"io"
+	"net"
+	"os"
+	"path"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -49,13 +52,23 @@ type CharmStore interface {
 // Facade is the public API facade for resources.
 type Facade struct {
 	// store is the data source for the facade.
-	store resourceInfoStore
+	store DataStore
 
 	newCharmstoreClient func() (CharmStore, error)
+
+	// username identifies the user making the API calls.
+	username string
+
+	// controllerIPs holds the controller's addresses, from which
+	// resources are never fetched.
+	controllerIPs []net.IP
 }
 
 // NewFacade returns a new resoures facade for the given Juju state.
-func NewFacade(store DataStore, newClient func() (CharmStore, error)) (*Facade, error) {
+// Resources that the controller fetches on behalf of the client are
+// recorded as added by the named user, and are never fetched from
+// any of the given controller addresses.
+func NewFacade(store DataStore, newClient func() (CharmStore, error), username string, controllerAddrs []string) (*Facade, error) {
 	if store == nil {
 		return nil, errors.Errorf("missing data store")
 	}
@@ -67,9 +80,18 @@ func NewFacade(store DataStore, newClient func() (CharmStore, error)) (*Facade,
 		return nil, errors.Errorf("missing factory for new charm store clients")
 	}
 
+	var controllerIPs []net.IP
+	for _, addr := range controllerAddrs {
+		if ip := net.ParseIP(addr); ip != nil {
+			controllerIPs = append(controllerIPs, ip)
+		}
+	}
+
 	f := &Facade{
 		store:               store,
 		newCharmstoreClient: newClient,
+		username:            username,
+		controllerIPs:       controllerIPs,
 	}
 	return f, nil
 }
@@ -85,6 +107,14 @@ type resourceInfoStore interface {
 	// it is resolved. The returned ID is used to identify the pending
 	// resources when resolving it.
 	AddPendingResource(applicationID, userID string, chRes charmresource.Resource, r io.Reader) (string, error)
+
+	// ResourceHistory returns the previous revisions of the identified
+	// resource, most recent first.
+	ResourceHistory(applicationID, name string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the previous revision of the identified
+	// resource with the given history index the active revision again.
+	RollbackResource(applicationID, name string, index int) error
 }
 
 // ListResources returns the list of resources for the given application.
@@ -315,6 +345,117 @@ func (f Facade) addPendingResource(applicationID string, chRes charmresource.Res
 	return pendingID, nil
 }
 
+// SetResourcesFromURL fetches the content of each of the identified
+// resources from its HTTP(S) URL and stores it as the application's
+// new revision of the resource. The content is fingerprinted by the
+// controller, so the client never has to download it.
+func (f Facade) SetResourcesFromURL(args api.SetResourcesFromURLArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Resources)),
+	}
+	for i, arg := range args.Resources {
+		tag, apiErr := parseApplicationTag(arg.Tag)
+		if apiErr != nil {
+			results.Results[i].Error = apiErr
+			continue
+		}
+		if err := f.setResourceFromURL(tag.Id(), arg.Name, arg.URL); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (f Facade) setResourceFromURL(applicationID, name, rawURL string) error {
+	u, err := parseResourceURL(rawURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := f.store.GetResource(applicationID, name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if res.Type != charmresource.TypeFile {
+		return errors.NotSupportedf("fetching %s resource %q from a URL", res.Type, name)
+	}
+	ext := path.Ext(res.Path)
+	if path.Ext(u.Path) != ext {
+		return errors.Errorf("incorrect extension on resource URL %q, expected %q", rawURL, ext)
+	}
+
+	file, err := fetchResource(u, res.Size, f.controllerIPs)
+	if err != nil {
+		return errors.Annotatef(err, "cannot fetch resource %q", name)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	content, err := resource.GenerateContent(file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	chRes := res.Resource
+	chRes.Origin = charmresource.OriginUpload
+	chRes.Revision = 0
+	chRes.Fingerprint = content.Fingerprint
+	chRes.Size = content.Size
+	if err := chRes.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	logger.Debugf("storing resource %q for application %q from %q", name, applicationID, rawURL)
+	if _, err := f.store.SetResource(applicationID, f.username, chRes, content.Data); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ResourceHistory returns the previous revisions of each of the
+// identified resources.
+func (f Facade) ResourceHistory(args api.ResourceHistoryArgs) (api.ResourceHistoryResults, error) {
+	results := api.ResourceHistoryResults{
+		Results: make([]api.ResourceHistoryResult, len(args.Resources)),
+	}
+	for i, arg := range args.Resources {
+		tag, apiErr := parseApplicationTag(arg.Tag)
+		if apiErr != nil {
+			results.Results[i].Error = apiErr
+			continue
+		}
+		history, err := f.store.ResourceHistory(tag.Id(), arg.Name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, entry := range history {
+			results.Results[i].History = append(results.Results[i].History, api.HistoryEntry2API(entry))
+		}
+	}
+	return results, nil
+}
+
+// RollbackResources makes a previous revision of each of the
+// identified resources the active revision again. The units of each
+// application then run their upgrade-charm hook.
+func (f Facade) RollbackResources(args api.RollbackResourcesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Resources)),
+	}
+	for i, arg := range args.Resources {
+		tag, apiErr := parseApplicationTag(arg.Tag)
+		if apiErr != nil {
+			results.Results[i].Error = apiErr
+			continue
+		}
+		if err := f.store.RollbackResource(tag.Id(), arg.Name, arg.Index); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
 func parseApplicationTag(tagStr string) (names.ApplicationTag, *params.Error) { // note the concrete error type
 	ApplicationTag, err := names.ParseApplicationTag(tagStr)
 	if err != nil {
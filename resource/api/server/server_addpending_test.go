@@ -24,7 +24,7 @@ func (s *AddPendingResourcesSuite) TestNoURL(c *gc.C) {
 	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
 	id1 := "some-unique-ID"
 	s.data.ReturnAddPendingResource = id1
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -57,7 +57,7 @@ func (s *AddPendingResourcesSuite) TestWithURLUpToDate(c *gc.C) {
 	s.csClient.ReturnListResources = [][]charmresource.Resource{{
 		res1.Resource,
 	}}
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -96,7 +96,7 @@ func (s *AddPendingResourcesSuite) TestWithURLMismatchComplete(c *gc.C) {
 	s.csClient.ReturnListResources = [][]charmresource.Resource{{
 		csRes.Resource,
 	}}
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -145,7 +145,7 @@ func (s *AddPendingResourcesSuite) TestWithURLMismatchIncomplete(c *gc.C) {
 		Size:        res1.Size,
 	}
 	s.csClient.ReturnResourceInfo = &expected
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -187,7 +187,7 @@ func (s *AddPendingResourcesSuite) TestWithURLNoRevision(c *gc.C) {
 	s.csClient.ReturnListResources = [][]charmresource.Resource{{
 		csRes.Resource,
 	}}
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -223,7 +223,7 @@ func (s *AddPendingResourcesSuite) TestLocalCharm(c *gc.C) {
 	apiRes1.Revision = 3
 	id1 := "some-unique-ID"
 	s.data.ReturnAddPendingResource = id1
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -263,7 +263,7 @@ func (s *AddPendingResourcesSuite) TestWithURLUpload(c *gc.C) {
 	s.csClient.ReturnListResources = [][]charmresource.Resource{{
 		csRes.Resource,
 	}}
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -293,7 +293,7 @@ func (s *AddPendingResourcesSuite) TestWithURLUpload(c *gc.C) {
 //func (s *AddPendingResourcesSuite) TestUnknownResource(c *gc.C) {
 //	_, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
 //	apiRes1.Origin = charmresource.OriginStore.String()
-//	facade, err := server.NewFacade(s.data, s.newCSClient)
+//	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 //	c.Assert(err, jc.ErrorIsNil)
 //
 //	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -329,7 +329,7 @@ func (s *AddPendingResourcesSuite) TestUnknownResource(c *gc.C) {
 	s.csClient.ReturnListResources = [][]charmresource.Resource{{
 		res1.Resource,
 	}}
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
@@ -358,7 +358,7 @@ func (s *AddPendingResourcesSuite) TestDataStoreError(c *gc.C) {
 	_, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
 	failure := errors.New("<failure>")
 	s.stub.SetErrors(failure)
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	result, err := facade.AddPendingResources(api.AddPendingResourcesArgs{
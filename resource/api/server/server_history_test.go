@@ -0,0 +1,141 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/server"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestHistoryOkay(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "spamspam")
+	superseded := time.Now()
+	s.data.ReturnResourceHistory = []resource.HistoryEntry{{
+		Resource:   res1,
+		Index:      2,
+		Superseded: superseded,
+	}, {
+		Resource:   res2,
+		Index:      1,
+		Superseded: superseded,
+	}}
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ResourceHistory(api.ResourceHistoryArgs{
+		Resources: []api.ResourceArg{{
+			Entity: params.Entity{Tag: "application-a-application"},
+			Name:   "spam",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, api.ResourceHistoryResults{
+		Results: []api.ResourceHistoryResult{{
+			History: []api.HistoryEntry{{
+				Resource:   apiRes1,
+				Index:      2,
+				Superseded: superseded,
+			}, {
+				Resource:   apiRes2,
+				Index:      1,
+				Superseded: superseded,
+			}},
+		}},
+	})
+	s.stub.CheckCallNames(c, "ResourceHistory")
+	s.stub.CheckCall(c, 0, "ResourceHistory", "a-application", "spam")
+}
+
+func (s *ResourceHistorySuite) TestHistoryError(c *gc.C) {
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ResourceHistory(api.ResourceHistoryArgs{
+		Resources: []api.ResourceArg{{
+			Entity: params.Entity{Tag: "application-a-application"},
+			Name:   "spam",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "<failure>")
+}
+
+func (s *ResourceHistorySuite) TestHistoryBadTag(c *gc.C) {
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ResourceHistory(api.ResourceHistoryArgs{
+		Resources: []api.ResourceArg{{
+			Entity: params.Entity{Tag: "unit-a-application-0"},
+			Name:   "spam",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(params.IsBadRequest(results.Results[0].Error), jc.IsTrue)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourceHistorySuite) TestRollbackOkay(c *gc.C) {
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.RollbackResources(api.RollbackResourcesArgs{
+		Resources: []api.RollbackResourceArg{{
+			ResourceArg: api.ResourceArg{
+				Entity: params.Entity{Tag: "application-a-application"},
+				Name:   "spam",
+			},
+			Index: 2,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.stub.CheckCallNames(c, "RollbackResource")
+	s.stub.CheckCall(c, 0, "RollbackResource", "a-application", "spam", 2)
+}
+
+func (s *ResourceHistorySuite) TestRollbackNotFound(c *gc.C) {
+	s.stub.SetErrors(errors.NotFoundf("revision 2 of resource %q", "a-application/spam"))
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.RollbackResources(api.RollbackResourcesArgs{
+		Resources: []api.RollbackResourceArg{{
+			ResourceArg: api.ResourceArg{
+				Entity: params.Entity{Tag: "application-a-application"},
+				Name:   "spam",
+			},
+			Index: 2,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(params.IsCodeNotFound(results.Results[0].Error), jc.IsTrue)
+}
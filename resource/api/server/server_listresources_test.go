@@ -62,7 +62,7 @@ func (s *ListResourcesSuite) TestOkay(c *gc.C) {
 		},
 	}
 
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	results, err := facade.ListResources(api.ListResourcesArgs{
@@ -107,7 +107,7 @@ func (s *ListResourcesSuite) TestOkay(c *gc.C) {
 }
 
 func (s *ListResourcesSuite) TestEmpty(c *gc.C) {
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	results, err := facade.ListResources(api.ListResourcesArgs{
@@ -126,7 +126,7 @@ func (s *ListResourcesSuite) TestEmpty(c *gc.C) {
 func (s *ListResourcesSuite) TestError(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stub.SetErrors(failure)
-	facade, err := server.NewFacade(s.data, s.newCSClient)
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 	c.Assert(err, jc.ErrorIsNil)
 
 	results, err := facade.ListResources(api.ListResourcesArgs{
@@ -0,0 +1,176 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package server_test
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource/api"
+	"github.com/juju/juju/resource/api/server"
+)
+
+var _ = gc.Suite(&SetResourcesFromURLSuite{})
+
+// checkFetchIP holds the real address check, which the suite replaces
+// so that it can serve resources from a loopback address.
+var checkFetchIP = *server.CheckFetchIP
+
+type SetResourcesFromURLSuite struct {
+	BaseSuite
+
+	content string
+	server  *httptest.Server
+}
+
+func (s *SetResourcesFromURLSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.content = "spamspamspam"
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/spam.tgz" {
+			http.NotFound(w, req)
+			return
+		}
+		fmt.Fprint(w, s.content)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+	// The test server listens on a loopback address.
+	s.PatchValue(server.CheckFetchIP, func(net.IP, []net.IP) error { return nil })
+}
+
+func (s *SetResourcesFromURLSuite) setFromURL(c *gc.C, url string) params.ErrorResults {
+	facade, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.SetResourcesFromURL(api.SetResourcesFromURLArgs{
+		Resources: []api.ResourceURL{{
+			ResourceArg: api.ResourceArg{
+				Entity: params.Entity{Tag: "application-a-application"},
+				Name:   "spam",
+			},
+			URL: url,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results
+}
+
+func (s *SetResourcesFromURLSuite) TestOkay(c *gc.C) {
+	res, _ := newResource(c, "spam", "a-user", "eggs")
+	res.Size = int64(len(s.content))
+	s.data.ReturnGetResource = res
+
+	results := s.setFromURL(c, s.server.URL+"/spam.tgz")
+
+	c.Check(results.Results[0].Error, gc.IsNil)
+	s.stub.CheckCallNames(c, "GetResource", "SetResource")
+	s.stub.CheckCall(c, 0, "GetResource", "a-application", "spam")
+	args := s.stub.Calls()[1].Args
+	c.Check(args[0], gc.Equals, "a-application")
+	c.Check(args[1], gc.Equals, "a-user")
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(s.content))
+	c.Assert(err, jc.ErrorIsNil)
+	expected := res.Resource
+	expected.Origin = charmresource.OriginUpload
+	expected.Revision = 0
+	expected.Fingerprint = fp
+	expected.Size = int64(len(s.content))
+	c.Check(args[2], jc.DeepEquals, expected)
+}
+
+func (s *SetResourcesFromURLSuite) TestUnsupportedScheme(c *gc.C) {
+	results := s.setFromURL(c, "ftp://example.com/spam.tgz")
+
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `resource URL "ftp://example.com/spam.tgz" \(only http and https are supported\) not valid`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *SetResourcesFromURLSuite) TestBadExtension(c *gc.C) {
+	res, _ := newResource(c, "spam", "a-user", "eggs")
+	s.data.ReturnGetResource = res
+
+	results := s.setFromURL(c, s.server.URL+"/spam.zip")
+
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `incorrect extension on resource URL .*, expected ".tgz"`)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
+func (s *SetResourcesFromURLSuite) TestFetchFailure(c *gc.C) {
+	res, _ := newResource(c, "spam", "a-user", "eggs")
+	res.Path = "eggs.tgz"
+	s.data.ReturnGetResource = res
+
+	results := s.setFromURL(c, s.server.URL+"/eggs.tgz")
+
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `cannot fetch resource "spam": bad HTTP response: 404 Not Found`)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
+func (s *SetResourcesFromURLSuite) TestTooLarge(c *gc.C) {
+	res, _ := newResource(c, "spam", "a-user", "eggs")
+	s.data.ReturnGetResource = res
+
+	results := s.setFromURL(c, s.server.URL+"/spam.tgz")
+
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `cannot fetch resource "spam": resource too large: 12 bytes, expected at most 4`)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
+func (s *SetResourcesFromURLSuite) TestLoopbackAddress(c *gc.C) {
+	s.PatchValue(server.CheckFetchIP, checkFetchIP)
+	res, _ := newResource(c, "spam", "a-user", "eggs")
+	s.data.ReturnGetResource = res
+
+	results := s.setFromURL(c, s.server.URL+"/spam.tgz")
+
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `cannot fetch resource "spam": .*cannot fetch resources from address 127.0.0.1`)
+	s.stub.CheckCallNames(c, "GetResource")
+}
+
+func (s *SetResourcesFromURLSuite) TestCheckFetchIP(c *gc.C) {
+	controllerIPs := []net.IP{net.ParseIP("10.0.0.1")}
+	for i, test := range []struct {
+		ip  string
+		err string
+	}{
+		{ip: "203.0.113.7"},
+		{ip: "127.0.0.1", err: "cannot fetch resources from address 127.0.0.1"},
+		{ip: "::1", err: "cannot fetch resources from address ::1"},
+		{ip: "169.254.169.254", err: "cannot fetch resources from address 169.254.169.254"},
+		{ip: "fe80::1", err: "cannot fetch resources from address fe80::1"},
+		{ip: "10.0.0.1", err: "cannot fetch resources from controller address 10.0.0.1"},
+	} {
+		c.Logf("test %d: %s", i, test.ip)
+		err := checkFetchIP(net.ParseIP(test.ip), controllerIPs)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SetResourcesFromURLSuite) TestStoreFailure(c *gc.C) {
+	res, _ := newResource(c, "spam", "a-user", "eggs")
+	res.Size = int64(len(s.content))
+	s.data.ReturnGetResource = res
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, failure)
+
+	results := s.setFromURL(c, s.server.URL+"/spam.tgz")
+
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "<failure>")
+	s.stub.CheckCallNames(c, "GetResource", "SetResource")
+}
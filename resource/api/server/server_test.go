@@ -17,19 +17,19 @@ type FacadeSuite struct {
 }
 
 func (s *FacadeSuite) TestNewFacadeOkay(c *gc.C) {
-	_, err := server.NewFacade(s.data, s.newCSClient)
+	_, err := server.NewFacade(s.data, s.newCSClient, "a-user", nil)
 
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *FacadeSuite) TestNewFacadeMissingDataStore(c *gc.C) {
-	_, err := server.NewFacade(nil, s.newCSClient)
+	_, err := server.NewFacade(nil, s.newCSClient, "a-user", nil)
 
 	c.Check(err, gc.ErrorMatches, `missing data store`)
 }
 
 func (s *FacadeSuite) TestNewFacadeMissingCSClientFactory(c *gc.C) {
-	_, err := server.NewFacade(s.data, nil)
+	_, err := server.NewFacade(s.data, nil, "a-user", nil)
 
 	c.Check(err, gc.ErrorMatches, `missing factory for new charm store clients`)
 }
@@ -0,0 +1,48 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+)
+
+// CachedResource describes a revision of a charm store resource that
+// the controller fetched ahead of time, so that the application's units
+// can get it even when the charm store cannot be reached.
+type CachedResource struct {
+	charmresource.Resource
+
+	// ApplicationID identifies the application for which the resource
+	// was fetched.
+	ApplicationID string
+
+	// Fetched indicates when the resource was fetched from the charm
+	// store.
+	Fetched time.Time
+
+	// LastUsed indicates when the cached resource was last needed,
+	// either by one of the application's units or because the
+	// application might use it.
+	LastUsed time.Time
+}
+
+// Validate ensures that the cached resource is valid.
+func (res CachedResource) Validate() error {
+	if err := res.Resource.Validate(); err != nil {
+		return errors.Annotate(err, "bad info")
+	}
+	if res.Origin != charmresource.OriginStore {
+		return errors.NewNotValid(nil, "only charm store resources may be cached")
+	}
+	if res.ApplicationID == "" {
+		return errors.NewNotValid(nil, "missing application ID")
+	}
+	if res.Fetched.IsZero() {
+		return errors.NewNotValid(nil, "missing fetched timestamp")
+	}
+	return nil
+}
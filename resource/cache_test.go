@@ -0,0 +1,70 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/resource"
+)
+
+type CachedResourceSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CachedResourceSuite{})
+
+func (CachedResourceSuite) TestValidateOkay(c *gc.C) {
+	res := newCachedResource(c, "spam")
+
+	err := res.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (CachedResourceSuite) TestValidateUploadOrigin(c *gc.C) {
+	res := newCachedResource(c, "spam")
+	res.Origin = charmresource.OriginUpload
+
+	err := res.Validate()
+
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `only charm store resources may be cached`)
+}
+
+func (CachedResourceSuite) TestValidateMissingApplicationID(c *gc.C) {
+	res := newCachedResource(c, "spam")
+	res.ApplicationID = ""
+
+	err := res.Validate()
+
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `missing application ID`)
+}
+
+func (CachedResourceSuite) TestValidateMissingFetched(c *gc.C) {
+	res := newCachedResource(c, "spam")
+	res.Fetched = time.Time{}
+
+	err := res.Validate()
+
+	c.Check(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `missing fetched timestamp`)
+}
+
+func newCachedResource(c *gc.C, name string) resource.CachedResource {
+	chRes := newFullCharmResource(c, name)
+	chRes.Origin = charmresource.OriginStore
+	return resource.CachedResource{
+		Resource:      chRes,
+		ApplicationID: "a-application",
+		Fetched:       time.Now(),
+	}
+}
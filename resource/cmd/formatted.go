@@ -69,8 +69,27 @@ type FormattedDetailResource struct {
 type FormattedServiceDetails struct {
 	Resources []FormattedDetailResource `json:"resources,omitempty" yaml:"resources,omitempty"`
 	Updates   []FormattedCharmResource  `json:"updates,omitempty" yaml:"updates,omitempty"`
+	Cache     []FormattedCachedResource `json:"cache,omitempty" yaml:"cache,omitempty"`
+}
+
+// FormattedCachedResource holds the formatted representation of a charm
+// store resource the controller fetched ahead of time.
+type FormattedCachedResource struct {
+	Name     string    `json:"name" yaml:"name"`
+	Revision int       `json:"revision" yaml:"revision"`
+	Size     int64     `json:"size" yaml:"size"`
+	Fetched  time.Time `json:"fetched" yaml:"fetched"`
+	LastUsed time.Time `json:"last-used" yaml:"last-used"`
 }
 
 // FormattedDetailResource is the data for the tabular output for juju resources
 // <unit> --details.
 type FormattedUnitDetails []FormattedDetailResource
+
+// FormattedHistoryEntry holds the formatted representation of a previous
+// revision of an application's resource.
+type FormattedHistoryEntry struct {
+	Index      int                  `json:"index" yaml:"index"`
+	Superseded time.Time            `json:"superseded" yaml:"superseded"`
+	Resource   FormattedSvcResource `json:"resource" yaml:"resource"`
+}
@@ -77,6 +77,15 @@ func FormatSvcResource(res resource.Resource) FormattedSvcResource {
 	}
 }
 
+// FormatHistoryEntry converts the history entry into a FormattedHistoryEntry.
+func FormatHistoryEntry(entry resource.HistoryEntry) FormattedHistoryEntry {
+	return FormattedHistoryEntry{
+		Index:      entry.Index,
+		Superseded: entry.Superseded,
+		Resource:   FormatSvcResource(entry.Resource),
+	}
+}
+
 func formatServiceResources(sr resource.ServiceResources) (FormattedServiceInfo, error) {
 	var formatted FormattedServiceInfo
 	updates, err := sr.Updates()
@@ -116,9 +125,24 @@ func FormatServiceDetails(sr resource.ServiceResources) (FormattedServiceDetails
 	for i, u := range updates {
 		formatted.Updates[i] = FormatCharmResource(u)
 	}
+	for _, cached := range sr.Cache {
+		formatted.Cache = append(formatted.Cache, FormatCachedResource(cached))
+	}
 	return formatted, nil
 }
 
+// FormatCachedResource converts the resource info into a
+// FormattedCachedResource.
+func FormatCachedResource(res resource.CachedResource) FormattedCachedResource {
+	return FormattedCachedResource{
+		Name:     res.Name,
+		Revision: res.Revision,
+		Size:     res.Size,
+		Fetched:  res.Fetched,
+		LastUsed: res.LastUsed,
+	}
+}
+
 // FormatDetailResource converts the arguments into a FormattedServiceResource.
 func FormatDetailResource(tag names.UnitTag, svc, unit resource.Resource, progress int64) (FormattedDetailResource, error) {
 	// note that the unit resource can be a zero value here, to indicate that
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"launchpad.net/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/resource"
+)
+
+// HistoryClient has the API client methods needed by HistoryCommand.
+type HistoryClient interface {
+	// ResourceHistory returns the previous revisions of the resource.
+	ResourceHistory(service, name string) ([]resource.HistoryEntry, error)
+
+	// Close closes the connection.
+	Close() error
+}
+
+// HistoryDeps is a type that contains external functions that History
+// depends on to function.
+type HistoryDeps struct {
+	// NewClient returns the value that wraps the API for listing the
+	// history of resources on the server.
+	NewClient func(*HistoryCommand) (HistoryClient, error)
+}
+
+// HistoryCommand implements the resource-history command.
+type HistoryCommand struct {
+	modelcmd.ModelCommandBase
+
+	deps    HistoryDeps
+	out     cmd.Output
+	service string
+	name    string
+}
+
+// NewHistoryCommand returns a new command that lists the previous
+// revisions of an application's resource.
+func NewHistoryCommand(deps HistoryDeps) *HistoryCommand {
+	return &HistoryCommand{deps: deps}
+}
+
+// Info implements cmd.Command.Info.
+func (c *HistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resource-history",
+		Args:    "application resource",
+		Purpose: "show the previous revisions of an application's resource",
+		Doc: `
+This command shows the revisions of a resource that an application used
+before its current one, most recent first. The application may be rolled
+back to any of them with "juju rollback-resource".
+`,
+	}
+}
+
+// SetFlags implements cmd.Command.SetFlags.
+func (c *HistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	const defaultFlag = "tabular"
+	c.out.AddFlags(f, defaultFlag, map[string]cmd.Formatter{
+		defaultFlag: FormatHistoryTabular,
+		"yaml":      cmd.FormatYaml,
+		"json":      cmd.FormatJson,
+	})
+}
+
+// Init implements cmd.Command.Init. It will return an error satisfying
+// errors.BadRequest if you give it an incorrect number of arguments.
+func (c *HistoryCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.BadRequestf("missing application name")
+	case 1:
+		return errors.BadRequestf("missing resource name")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.service = args[0]
+	c.name = args[1]
+	if err := cmd.CheckEmpty(args[2:]); err != nil {
+		return errors.NewBadRequest(err, "")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.Run.
+func (c *HistoryCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.deps.NewClient(c)
+	if err != nil {
+		return errors.Annotatef(err, "can't connect to %s", c.ConnectionName())
+	}
+	defer apiclient.Close()
+
+	history, err := apiclient.ResourceHistory(c.service, c.name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	formatted := make([]FormattedHistoryEntry, len(history))
+	for i, entry := range history {
+		formatted[i] = FormatHistoryEntry(entry)
+	}
+	return c.out.Write(ctx, formatted)
+}
@@ -0,0 +1,158 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/resource"
+)
+
+var _ = gc.Suite(&HistorySuite{})
+
+type HistorySuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	client *stubHistoryClient
+}
+
+func (s *HistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.client = &stubHistoryClient{stub: s.stub}
+}
+
+func (s *HistorySuite) newClient(c *HistoryCommand) (HistoryClient, error) {
+	s.stub.AddCall("NewClient", c)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.client, nil
+}
+
+func (*HistorySuite) TestInitEmpty(c *gc.C) {
+	var command HistoryCommand
+
+	err := command.Init([]string{})
+	c.Check(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*HistorySuite) TestInitMissingResource(c *gc.C) {
+	var command HistoryCommand
+
+	err := command.Init([]string{"svc"})
+	c.Check(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*HistorySuite) TestInitBadApplication(c *gc.C) {
+	var command HistoryCommand
+
+	err := command.Init([]string{"svc/0", "website"})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*HistorySuite) TestInitTooManyArgs(c *gc.C) {
+	var command HistoryCommand
+
+	err := command.Init([]string{"svc", "website", "extra"})
+	c.Check(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*HistorySuite) TestInitGood(c *gc.C) {
+	var command HistoryCommand
+
+	err := command.Init([]string{"svc", "website"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.service, gc.Equals, "svc")
+	c.Check(command.name, gc.Equals, "website")
+}
+
+func (s *HistorySuite) TestRun(c *gc.C) {
+	s.client.ReturnResourceHistory = []resource.HistoryEntry{{
+		Resource: resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{
+					Name: "website",
+				},
+				Origin: charmresource.OriginUpload,
+			},
+			Username:  "Bill User",
+			Timestamp: time.Date(2012, 12, 12, 12, 12, 12, 0, time.UTC),
+		},
+		Index:      2,
+		Superseded: time.Date(2013, 1, 2, 3, 4, 5, 0, time.UTC),
+	}, {
+		Resource: resource.Resource{
+			Resource: charmresource.Resource{
+				Meta: charmresource.Meta{
+					Name: "website",
+				},
+				Origin:   charmresource.OriginStore,
+				Revision: 7,
+			},
+			Timestamp: time.Date(2012, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Index:      1,
+		Superseded: time.Date(2012, 12, 12, 12, 12, 12, 0, time.UTC),
+	}}
+	command := &HistoryCommand{
+		deps: HistoryDeps{
+			NewClient: s.newClient,
+		},
+	}
+
+	code, stdout, stderr := runCmd(c, command, "svc", "website")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "")
+
+	c.Check(stdout, gc.Equals, `
+INDEX SUPPLIED BY REVISION         SUPERSEDED
+2     Bill User   2012-12-12T12:12 2013-01-02T03:04
+1     charmstore  7                2012-12-12T12:12
+`[1:])
+	s.stub.CheckCallNames(c, "NewClient", "ResourceHistory", "Close")
+	s.stub.CheckCall(c, 1, "ResourceHistory", "svc", "website")
+}
+
+type stubHistoryClient struct {
+	stub *testing.Stub
+
+	ReturnResourceHistory []resource.HistoryEntry
+}
+
+func (s *stubHistoryClient) ResourceHistory(service, name string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ResourceHistory", service, name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnResourceHistory, nil
+}
+
+func (s *stubHistoryClient) RollbackResource(service, name string, index int) error {
+	s.stub.AddCall("RollbackResource", service, name, index)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubHistoryClient) Close() error {
+	s.stub.AddCall("Close")
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
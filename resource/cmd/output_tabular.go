@@ -104,6 +104,24 @@ func writeUpdates(updates []FormattedCharmResource, out *bytes.Buffer, tw *tabwr
 	tw.Flush()
 }
 
+func writeCache(cache []FormattedCachedResource, out *bytes.Buffer, tw *tabwriter.Writer) {
+	if len(cache) > 0 {
+		fmt.Fprintln(out, "")
+		fmt.Fprintln(out, "[Cache]")
+		fmt.Fprintln(tw, "RESOURCE\tREVISION\tSIZE\tLAST USED")
+		for _, r := range cache {
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n",
+				r.Name,
+				r.Revision,
+				r.Size,
+				r.LastUsed.Format("2006-01-02T15:04"),
+			)
+		}
+	}
+
+	tw.Flush()
+}
+
 func formatUnitTabular(resources []FormattedUnitResource) []byte {
 	// TODO(ericsnow) sort the rows first?
 
@@ -156,6 +174,7 @@ func formatServiceDetailTabular(resources FormattedServiceDetails) []byte {
 	tw.Flush()
 
 	writeUpdates(resources.Updates, &out, tw)
+	writeCache(resources.Cache, &out, tw)
 
 	return out.Bytes()
 }
@@ -185,6 +204,36 @@ func formatUnitDetailTabular(resources FormattedUnitDetails) []byte {
 	return out.Bytes()
 }
 
+// FormatHistoryTabular returns a tabular summary of a resource's history.
+func FormatHistoryTabular(value interface{}) ([]byte, error) {
+	history, valueConverted := value.([]FormattedHistoryEntry)
+	if !valueConverted {
+		return nil, errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+
+	var out bytes.Buffer
+
+	// To format things into columns.
+	tw := tabwriter.NewWriter(&out, 0, 1, 1, ' ', 0)
+
+	// Write the header.
+	fmt.Fprintln(tw, "INDEX\tSUPPLIED BY\tREVISION\tSUPERSEDED")
+
+	// Print each entry to its own row, most recent first.
+	for _, entry := range history {
+		// the column headers must be kept in sync with these.
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n",
+			entry.Index,
+			entry.Resource.combinedOrigin,
+			entry.Resource.combinedRevision,
+			entry.Superseded.Format("2006-01-02T15:04"),
+		)
+	}
+	tw.Flush()
+
+	return out.Bytes(), nil
+}
+
 type byUnitID []FormattedDetailResource
 
 func (b byUnitID) Len() int      { return len(b) }
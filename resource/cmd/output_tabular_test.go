@@ -317,6 +317,41 @@ spam     1
 `[1:])
 }
 
+func (s *DetailsTabularSuite) TestFormatServiceDetailsCache(c *gc.C) {
+	data := FormattedServiceDetails{
+		Resources: []FormattedDetailResource{
+			{
+				UnitID:      "svc/5",
+				unitNumber:  5,
+				Unit:        fakeFmtSvcRes("data", "1"),
+				Expected:    fakeFmtSvcRes("data", "1"),
+				revProgress: "combRev1",
+			},
+		},
+		Cache: []FormattedCachedResource{
+			{
+				Name:     "data",
+				Revision: 2,
+				Size:     1024,
+				LastUsed: time.Date(2016, 8, 8, 12, 30, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	output, err := FormatSvcTabular(data)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(string(output), gc.Equals, `
+[Units]
+UNIT RESOURCE REVISION EXPECTED
+5    data     combRev1 combRev1
+
+[Cache]
+RESOURCE REVISION SIZE LAST USED
+data     2        1024 2016-08-08T12:30
+`[1:])
+}
+
 func (s *DetailsTabularSuite) TestFormatUnitDetailsOkay(c *gc.C) {
 	data := FormattedUnitDetails{
 		{
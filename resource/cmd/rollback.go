@@ -0,0 +1,125 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// RollbackClient has the API client methods needed by RollbackCommand.
+type RollbackClient interface {
+	HistoryClient
+
+	// RollbackResource makes the previous revision of the resource
+	// with the given history index the active revision again.
+	RollbackResource(service, name string, index int) error
+}
+
+// RollbackDeps is a type that contains external functions that Rollback
+// depends on to function.
+type RollbackDeps struct {
+	// NewClient returns the value that wraps the API for rolling back
+	// resources on the server.
+	NewClient func(*RollbackCommand) (RollbackClient, error)
+}
+
+// RollbackCommand implements the rollback-resource command.
+type RollbackCommand struct {
+	modelcmd.ModelCommandBase
+
+	deps    RollbackDeps
+	service string
+	name    string
+	index   int
+}
+
+// NewRollbackCommand returns a new command that rolls an application's
+// resource back to one of its previous revisions.
+func NewRollbackCommand(deps RollbackDeps) *RollbackCommand {
+	return &RollbackCommand{deps: deps}
+}
+
+// Info implements cmd.Command.Info.
+func (c *RollbackCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "rollback-resource",
+		Args:    "application resource [<index>]",
+		Purpose: "roll an application's resource back to a previous revision",
+		Doc: `
+This command makes a previous revision of an application's resource, as
+listed by "juju resource-history", the application's current revision
+again. If no index is given, the most recently replaced revision is used.
+
+The revision being replaced is added to the resource's history, so the
+rollback can itself be undone. The application's units are notified of
+the change through their upgrade-charm hook.
+
+Examples:
+    juju rollback-resource mysql backup
+    juju rollback-resource mysql backup 3
+`,
+	}
+}
+
+// Init implements cmd.Command.Init. It will return an error satisfying
+// errors.BadRequest if you give it an incorrect number of arguments.
+func (c *RollbackCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.BadRequestf("missing application name")
+	case 1:
+		return errors.BadRequestf("missing resource name")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.service = args[0]
+	c.name = args[1]
+	args = args[2:]
+	if len(args) > 0 {
+		index, err := strconv.Atoi(args[0])
+		if err != nil || index <= 0 {
+			return errors.NotValidf("history index %q", args[0])
+		}
+		c.index = index
+		args = args[1:]
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.NewBadRequest(err, "")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.Run.
+func (c *RollbackCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.deps.NewClient(c)
+	if err != nil {
+		return errors.Annotatef(err, "can't connect to %s", c.ConnectionName())
+	}
+	defer apiclient.Close()
+
+	index := c.index
+	if index == 0 {
+		history, err := apiclient.ResourceHistory(c.service, c.name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(history) == 0 {
+			return errors.Errorf("resource %q of application %q has no previous revisions", c.name, c.service)
+		}
+		index = history[0].Index
+	}
+
+	if err := apiclient.RollbackResource(c.service, c.name, index); err != nil {
+		return errors.Annotatef(err, "failed to roll back resource %q", c.name)
+	}
+	ctx.Infof("resource %q of application %q rolled back to revision %d from its history", c.name, c.service, index)
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cmd
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource"
+)
+
+var _ = gc.Suite(&RollbackSuite{})
+
+type RollbackSuite struct {
+	testing.IsolationSuite
+
+	stub   *testing.Stub
+	client *stubHistoryClient
+}
+
+func (s *RollbackSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.client = &stubHistoryClient{stub: s.stub}
+}
+
+func (s *RollbackSuite) newCommand() *RollbackCommand {
+	return &RollbackCommand{
+		deps: RollbackDeps{
+			NewClient: func(c *RollbackCommand) (RollbackClient, error) {
+				s.stub.AddCall("NewClient", c)
+				if err := s.stub.NextErr(); err != nil {
+					return nil, errors.Trace(err)
+				}
+				return s.client, nil
+			},
+		},
+	}
+}
+
+func (*RollbackSuite) TestInitEmpty(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{})
+	c.Check(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*RollbackSuite) TestInitMissingResource(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{"svc"})
+	c.Check(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*RollbackSuite) TestInitBadIndex(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{"svc", "website", "0"})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	err = command.Init([]string{"svc", "website", "latest"})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (*RollbackSuite) TestInitTooManyArgs(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{"svc", "website", "2", "extra"})
+	c.Check(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*RollbackSuite) TestInitGood(c *gc.C) {
+	var command RollbackCommand
+
+	err := command.Init([]string{"svc", "website", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(command.service, gc.Equals, "svc")
+	c.Check(command.name, gc.Equals, "website")
+	c.Check(command.index, gc.Equals, 2)
+}
+
+func (s *RollbackSuite) TestRunIndex(c *gc.C) {
+	code, _, stderr := runCmd(c, s.newCommand(), "svc", "website", "2")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "resource \"website\" of application \"svc\" rolled back to revision 2 from its history\n")
+
+	s.stub.CheckCallNames(c, "NewClient", "RollbackResource", "Close")
+	s.stub.CheckCall(c, 1, "RollbackResource", "svc", "website", 2)
+}
+
+func (s *RollbackSuite) TestRunMostRecent(c *gc.C) {
+	s.client.ReturnResourceHistory = []resource.HistoryEntry{{Index: 3}, {Index: 2}}
+
+	code, _, _ := runCmd(c, s.newCommand(), "svc", "website")
+	c.Check(code, gc.Equals, 0)
+
+	s.stub.CheckCallNames(c, "NewClient", "ResourceHistory", "RollbackResource", "Close")
+	s.stub.CheckCall(c, 2, "RollbackResource", "svc", "website", 3)
+}
+
+func (s *RollbackSuite) TestRunNoHistory(c *gc.C) {
+	code, _, stderr := runCmd(c, s.newCommand(), "svc", "website")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stderr, gc.Equals, "error: resource \"website\" of application \"svc\" has no previous revisions\n")
+
+	s.stub.CheckCallNames(c, "NewClient", "ResourceHistory", "Close")
+}
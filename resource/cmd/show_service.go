@@ -69,7 +69,7 @@ func (c *ShowServiceCommand) SetFlags(f *gnuflag.FlagSet) {
 		"json":      cmd.FormatJson,
 	})
 
-	f.BoolVar(&c.details, "details", false, "show detailed information about resources used by each unit.")
+	f.BoolVar(&c.details, "details", false, "show detailed information about resources used by each unit, and those cached by the controller.")
 }
 
 // Init implements cmd.Command.Init. It will return an error satisfying
@@ -59,6 +59,15 @@ func (s *stubAPIClient) Upload(service, name, filename string, resource io.ReadS
 	return nil
 }
 
+func (s *stubAPIClient) UploadFromURL(service, name, url string) error {
+	s.stub.AddCall("UploadFromURL", service, name, url)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 func (s *stubAPIClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"io"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -17,6 +18,9 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(service, name, filename string, resource io.ReadSeeker) error
 
+	// UploadFromURL has Juju fetch the resource from the given URL.
+	UploadFromURL(service, name, url string) error
+
 	// Close closes the client.
 	Close() error
 }
@@ -55,11 +59,19 @@ func NewUploadCommand(deps UploadDeps) *UploadCommand {
 func (c *UploadCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "attach",
-		Args:    "application name=file",
+		Args:    "application name=file|url",
 		Purpose: "upload a file as a resource for an application",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+If an HTTP or HTTPS URL is given instead of a file, the controller downloads
+the resource from that URL itself, so the content never passes through the
+client.
+
+Examples:
+    juju attach mysql backup=./backup.tgz
+    juju attach mysql backup=https://example.com/backup.tgz
 `,
 	}
 }
@@ -114,6 +126,13 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if isResourceURL(c.resourceFile.filename) {
+		err := apiclient.UploadFromURL(c.resourceFile.service, c.resourceFile.name, c.resourceFile.filename)
+		if err != nil {
+			return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
+		}
+		return nil
+	}
 	if err := c.upload(c.resourceFile, apiclient); err != nil {
 		return errors.Annotatef(err, "failed to upload resource %q", c.resourceFile.name)
 	}
@@ -131,3 +150,10 @@ func (c *UploadCommand) upload(rf resourceFile, client UploadClient) error {
 	err = client.Upload(rf.service, rf.name, rf.filename, f)
 	return errors.Trace(err)
 }
+
+// isResourceURL reports whether the resource should be fetched by
+// the controller from the given location, rather than read from the
+// local disk.
+func isResourceURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
@@ -90,11 +90,19 @@ func (s *UploadSuite) TestInfo(c *gc.C) {
 
 	c.Check(info, jc.DeepEquals, &jujucmd.Info{
 		Name:    "attach",
-		Args:    "application name=file",
+		Args:    "application name=file|url",
 		Purpose: "upload a file as a resource for an application",
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+If an HTTP or HTTPS URL is given instead of a file, the controller downloads
+the resource from that URL itself, so the content never passes through the
+client.
+
+Examples:
+    juju attach mysql backup=./backup.tgz
+    juju attach mysql backup=https://example.com/backup.tgz
 `,
 	})
 }
@@ -129,6 +137,31 @@ func (s *UploadSuite) TestRun(c *gc.C) {
 	s.stub.CheckCall(c, 2, "Upload", "svc", "foo", "bar", file)
 }
 
+func (s *UploadSuite) TestRunURL(c *gc.C) {
+	u := UploadCommand{
+		deps: UploadDeps{
+			NewClient:    s.stubDeps.NewClient,
+			OpenResource: s.stubDeps.OpenResource,
+		},
+		resourceFile: resourceFile{
+			service:  "svc",
+			name:     "foo",
+			filename: "https://example.com/bar.tgz",
+		},
+		service: "svc",
+	}
+
+	err := u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"UploadFromURL",
+		"Close",
+	)
+	s.stub.CheckCall(c, 1, "UploadFromURL", "svc", "foo", "https://example.com/bar.tgz")
+}
+
 type stubUploadDeps struct {
 	stub   *testing.Stub
 	file   ReadSeekCloser
@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+import (
+	"time"
+)
+
+// HistoryEntry describes a previous revision of an application's
+// resource. The application may be rolled back to any revision in
+// the resource's history.
+type HistoryEntry struct {
+	Resource
+
+	// Index identifies the revision within the resource's history.
+	// Revisions that were replaced more recently have higher indices.
+	Index int
+
+	// Superseded indicates when the revision stopped being the
+	// application's active revision of the resource.
+	Superseded time.Time
+}
@@ -4,9 +4,11 @@
 package resourceadapters
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -31,13 +33,74 @@ func NewPublicFacade(st *corestate.State, _ *common.Resources, authorizer common
 	newClient := func() (server.CharmStore, error) {
 		return newCharmStoreClient(st)
 	}
-	facade, err := server.NewFacade(rst, newClient)
+	var username string
+	if tag, ok := authorizer.GetAuthTag().(names.UserTag); ok {
+		username = tag.Name()
+	}
+	hostPorts, err := st.APIHostPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var controllerAddrs []string
+	for _, hps := range hostPorts {
+		for _, hostPort := range hps {
+			controllerAddrs = append(controllerAddrs, hostPort.Value)
+		}
+	}
+	store := applicationAccessStore{
+		DataStore: rst,
+		access:    common.NewApplicationAuthFunc(st, authorizer),
+	}
+	facade, err := server.NewFacade(store, newClient, username, controllerAddrs)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return facade, nil
 }
 
+// applicationAccessStore is a server.DataStore that only changes the
+// resources of applications the authenticated user may manage.
+type applicationAccessStore struct {
+	server.DataStore
+	access common.ApplicationAuthFunc
+}
+
+func (s applicationAccessStore) checkManage(applicationID string) error {
+	return s.access(names.NewApplicationTag(applicationID), corestate.ManageAccess)
+}
+
+// AddPendingResource implements server.DataStore.
+func (s applicationAccessStore) AddPendingResource(applicationID, userID string, chRes charmresource.Resource, r io.Reader) (string, error) {
+	if err := s.checkManage(applicationID); err != nil {
+		return "", errors.Trace(err)
+	}
+	return s.DataStore.AddPendingResource(applicationID, userID, chRes, r)
+}
+
+// SetResource implements server.DataStore.
+func (s applicationAccessStore) SetResource(applicationID, userID string, chRes charmresource.Resource, r io.Reader) (resource.Resource, error) {
+	if err := s.checkManage(applicationID); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return s.DataStore.SetResource(applicationID, userID, chRes, r)
+}
+
+// UpdatePendingResource implements server.DataStore.
+func (s applicationAccessStore) UpdatePendingResource(applicationID, pendingID, userID string, chRes charmresource.Resource, r io.Reader) (resource.Resource, error) {
+	if err := s.checkManage(applicationID); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return s.DataStore.UpdatePendingResource(applicationID, pendingID, userID, chRes, r)
+}
+
+// RollbackResource implements server.DataStore.
+func (s applicationAccessStore) RollbackResource(applicationID, name string, index int) error {
+	if err := s.checkManage(applicationID); err != nil {
+		return errors.Trace(err)
+	}
+	return s.DataStore.RollbackResource(applicationID, name, index)
+}
+
 // NewUploadHandler returns a new HTTP handler for the given args.
 func NewUploadHandler(args apihttp.NewHandlerArgs) http.Handler {
 	return server.NewLegacyHTTPHandler(
@@ -50,7 +50,11 @@ func (ro *resourceOpener) OpenResource(name string) (resource.Opened, error) {
 	}
 
 	res, reader, err := charmstore.GetResource(charmstore.GetResourceArgs{
-		Client:  client,
+		Client: &prefetchedClient{
+			StoreResourceGetter: client,
+			st:                  ro.res,
+			applicationID:       ro.unit.ApplicationName(),
+		},
 		Cache:   cache,
 		CharmID: id,
 		Name:    name,
@@ -65,3 +69,27 @@ func (ro *resourceOpener) OpenResource(name string) (resource.Opened, error) {
 	}
 	return opened, nil
 }
+
+// prefetchedClient serves the resources the controller fetched ahead
+// of time from its cache, and the others from the charm store.
+type prefetchedClient struct {
+	charmstore.StoreResourceGetter
+	st            corestate.Resources
+	applicationID string
+}
+
+// GetResource implements charmstore.StoreResourceGetter.
+func (client *prefetchedClient) GetResource(req csclient.ResourceRequest) (csclient.ResourceData, error) {
+	cached, reader, err := client.st.OpenCachedResource(client.applicationID, req.Name, req.Revision)
+	if err == nil {
+		logger.Debugf("using cached revision %d of resource %q", req.Revision, req.Name)
+		return csclient.ResourceData{
+			ReadCloser: reader,
+			Resource:   cached.Resource,
+		}, nil
+	}
+	if !errors.IsNotFound(err) {
+		logger.Warningf("cannot open cached resource %q: %v", req.Name, err)
+	}
+	return client.StoreResourceGetter.GetResource(req)
+}
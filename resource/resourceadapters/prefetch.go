@@ -0,0 +1,181 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourceadapters
+
+import (
+	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/resource/workers"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewPrefetchBackend returns a workers.PrefetchBackend which caches the
+// charm store resources of the applications in all the controller's
+// models.
+func NewPrefetchBackend(st *state.State) workers.PrefetchBackend {
+	return &prefetchBackend{st}
+}
+
+type prefetchBackend struct {
+	*state.State
+}
+
+// forModel calls fn with the state of the identified model.
+func (b *prefetchBackend) forModel(modelUUID string, fn func(*state.State) error) error {
+	if modelUUID == b.ModelUUID() {
+		return fn(b.State)
+	}
+	st, err := b.ForModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	return fn(st)
+}
+
+// forEachModel calls fn with the state of each of the controller's models.
+func (b *prefetchBackend) forEachModel(fn func(*state.State) error) error {
+	models, err := b.AllModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, model := range models {
+		if err := b.forModel(model.UUID(), fn); err != nil {
+			return errors.Annotatef(err, "model %s", model.UUID())
+		}
+	}
+	return nil
+}
+
+// WantedResources is part of the workers.PrefetchBackend interface.
+func (b *prefetchBackend) WantedResources() ([]workers.CacheEntry, error) {
+	var wanted []workers.CacheEntry
+	err := b.forEachModel(func(st *state.State) error {
+		resources, err := st.Resources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		applications, err := st.AllApplications()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, app := range applications {
+			if cURL, _ := app.CharmURL(); cURL.Schema != "cs" {
+				continue
+			}
+			svcRes, err := resources.ListResources(app.Name())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			add := func(chRes charmresource.Resource) {
+				if chRes.Origin != charmresource.OriginStore {
+					return
+				}
+				entry := workers.CacheEntry{ModelUUID: st.ModelUUID()}
+				entry.Resource = chRes
+				entry.ApplicationID = app.Name()
+				wanted = append(wanted, entry)
+			}
+			for _, res := range svcRes.Resources {
+				add(res.Resource)
+			}
+			for _, chRes := range svcRes.CharmStoreResources {
+				add(chRes)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return wanted, nil
+}
+
+// CachedResources is part of the workers.PrefetchBackend interface.
+func (b *prefetchBackend) CachedResources() ([]workers.CacheEntry, error) {
+	var cached []workers.CacheEntry
+	err := b.forEachModel(func(st *state.State) error {
+		resources, err := st.Resources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cache, err := resources.ListCachedResources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, res := range cache {
+			cached = append(cached, workers.CacheEntry{
+				CachedResource: res,
+				ModelUUID:      st.ModelUUID(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cached, nil
+}
+
+// FetchResource is part of the workers.PrefetchBackend interface.
+func (b *prefetchBackend) FetchResource(entry workers.CacheEntry) error {
+	return b.forModel(entry.ModelUUID, func(st *state.State) error {
+		app, err := st.Application(entry.ApplicationID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cURL, _ := app.CharmURL()
+		client, err := newCharmStoreClient(st)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		data, err := client.GetResource(charmstore.ResourceRequest{
+			Charm:    cURL,
+			Channel:  app.Channel(),
+			Name:     entry.Name,
+			Revision: entry.Revision,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer data.Close()
+
+		resources, err := st.Resources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = resources.CacheResource(entry.ApplicationID, data.Resource, data)
+		return errors.Trace(err)
+	})
+}
+
+// TouchCachedResource is part of the workers.PrefetchBackend interface.
+func (b *prefetchBackend) TouchCachedResource(entry workers.CacheEntry) error {
+	return b.forModel(entry.ModelUUID, func(st *state.State) error {
+		resources, err := st.Resources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return resources.TouchCachedResource(entry.ApplicationID, entry.Name, entry.Revision)
+	})
+}
+
+// EvictCachedResource is part of the workers.PrefetchBackend interface.
+func (b *prefetchBackend) EvictCachedResource(entry workers.CacheEntry) error {
+	return b.forModel(entry.ModelUUID, func(st *state.State) error {
+		resources, err := st.Resources()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return resources.EvictCachedResource(entry.ApplicationID, entry.Name, entry.Revision)
+	})
+}
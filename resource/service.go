@@ -26,6 +26,10 @@ type ServiceResources struct {
 	// UnitResources reports the currenly-in-use version of resources for each
 	// unit.
 	UnitResources []UnitResources
+
+	// Cache holds the charm store resources that the controller has
+	// fetched ahead of time for the application.
+	Cache []CachedResource
 }
 
 // Updates returns the list of charm store resources corresponding to
@@ -56,6 +56,34 @@ type resourcePersistence interface {
 	// NewResolvePendingResourceOps generates mongo transaction operations
 	// to set the identified resource as active.
 	NewResolvePendingResourceOps(resID, pendingID string) ([]txn.Op, error)
+
+	// ListResourceHistory returns the previous revisions of the
+	// identified resource, most recent first.
+	ListResourceHistory(id string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the previous revision of the resource
+	// with the given history index the active revision again.
+	RollbackResource(id string, index int) error
+
+	// ListCachedResources returns the charm store resources that were
+	// fetched ahead of time for the model's applications.
+	ListCachedResources() ([]resource.CachedResource, error)
+
+	// GetCachedResource returns the cached charm store revision of
+	// the identified resource, along with the path of its blob.
+	GetCachedResource(id string, revision int) (res resource.CachedResource, storagePath string, _ error)
+
+	// SetCachedResource records the charm store resource as fetched
+	// ahead of time, with its blob stored at the given path.
+	SetCachedResource(res resource.CachedResource, storagePath string) error
+
+	// TouchCachedResource records that the cached charm store revision
+	// of the identified resource was needed at the given time.
+	TouchCachedResource(id string, revision int, lastUsed time.Time) error
+
+	// RemoveCachedResource evicts the cached charm store revision of
+	// the identified resource.
+	RemoveCachedResource(id string, revision int) error
 }
 
 // StagedResource represents resource info that has been added to the
@@ -169,6 +197,36 @@ func (st resourceState) GetPendingResource(applicationID, name, pendingID string
 	return res, errors.NotFoundf("pending resource %q (%s)", name, pendingID)
 }
 
+// ResourceHistory returns the previous revisions of the identified
+// resource, most recent first.
+func (st resourceState) ResourceHistory(applicationID, name string) ([]resource.HistoryEntry, error) {
+	id := newResourceID(applicationID, name)
+	history, err := st.persist.ListResourceHistory(id)
+	if err != nil {
+		if err := st.raw.VerifyService(applicationID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return history, nil
+}
+
+// RollbackResource makes the previous revision of the identified
+// resource with the given history index the active revision again.
+// The units of the application are then notified through their
+// upgrade-charm hook.
+func (st resourceState) RollbackResource(applicationID, name string, index int) error {
+	logger.Tracef("rolling back resource %q for application %q to revision %d", name, applicationID, index)
+	id := newResourceID(applicationID, name)
+	if err := st.persist.RollbackResource(id, index); err != nil {
+		if err := st.raw.VerifyService(applicationID); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // TODO(ericsnow) Separate setting the metadata from storing the blob?
 
 // SetResource stores the resource in the Juju model.
@@ -247,7 +305,17 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	// is stored separately and adding to both should be an atomic
 	// operation.
 
-	storagePath := storagePath(res.Name, res.ApplicationID, res.PendingID)
+	uploadID := res.PendingID
+	if uploadID == "" {
+		// Each upload is stored separately, so that the blobs of the
+		// previous revisions in the resource's history are kept.
+		var err error
+		uploadID, err = st.newPendingID()
+		if err != nil {
+			return errors.Annotate(err, "could not generate resource ID")
+		}
+	}
+	storagePath := storagePath(res.Name, res.ApplicationID, uploadID)
 	staged, err := st.persist.StageResource(res, storagePath)
 	if err != nil {
 		return errors.Trace(err)
@@ -351,6 +419,97 @@ func (st resourceState) SetCharmStoreResources(applicationID string, info []char
 	return nil
 }
 
+// ListCachedResources returns the charm store resources that were
+// fetched ahead of time for the model's applications.
+func (st resourceState) ListCachedResources() ([]resource.CachedResource, error) {
+	cache, err := st.persist.ListCachedResources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cache, nil
+}
+
+// CacheResource stores the content of the given charm store resource
+// in the model ahead of time, so that it is available without another
+// round trip to the charm store when the application needs it.
+func (st resourceState) CacheResource(applicationID string, chRes charmresource.Resource, r io.Reader) (resource.CachedResource, error) {
+	logger.Tracef("caching revision %d of resource %q for application %q", chRes.Revision, chRes.Name, applicationID)
+	res := resource.CachedResource{
+		Resource:      chRes,
+		ApplicationID: applicationID,
+		Fetched:       st.currentTimestamp(),
+	}
+	res.LastUsed = res.Fetched
+	if err := res.Validate(); err != nil {
+		return res, errors.Annotate(err, "bad resource metadata")
+	}
+
+	storagePath := cacheStoragePath(chRes.Name, applicationID, chRes.Revision)
+	hash := chRes.Fingerprint.String()
+	if err := st.storage.PutAndCheckHash(storagePath, r, chRes.Size, hash); err != nil {
+		return res, errors.Trace(err)
+	}
+
+	if err := st.persist.SetCachedResource(res, storagePath); err != nil {
+		if err := st.storage.Remove(storagePath); err != nil {
+			logger.Errorf("could not remove cached resource %q (application %q) from storage: %v", chRes.Name, applicationID, err)
+		}
+		return res, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// OpenCachedResource returns metadata about the cached charm store
+// revision of the resource, and a reader for its content. The cache
+// entry is recorded as used.
+func (st resourceState) OpenCachedResource(applicationID, name string, revision int) (resource.CachedResource, io.ReadCloser, error) {
+	id := newResourceID(applicationID, name)
+	res, storagePath, err := st.persist.GetCachedResource(id, revision)
+	if err != nil {
+		return res, nil, errors.Trace(err)
+	}
+
+	resourceReader, resSize, err := st.storage.Get(storagePath)
+	if err != nil {
+		return res, nil, errors.Annotate(err, "while retrieving resource data")
+	}
+	if resSize != res.Size {
+		resourceReader.Close()
+		msg := "storage returned a size (%d) which doesn't match resource metadata (%d)"
+		return res, nil, errors.Errorf(msg, resSize, res.Size)
+	}
+
+	res.LastUsed = st.currentTimestamp()
+	if err := st.persist.TouchCachedResource(id, revision, res.LastUsed); err != nil {
+		// The content is still good, so the stale timestamp only
+		// affects which entries get evicted first.
+		logger.Warningf("could not record use of cached resource %q (application %q): %v", name, applicationID, err)
+	}
+	return res, resourceReader, nil
+}
+
+// TouchCachedResource records that the cached charm store revision of
+// the resource is still wanted, so that it isn't evicted as unused.
+func (st resourceState) TouchCachedResource(applicationID, name string, revision int) error {
+	id := newResourceID(applicationID, name)
+	if err := st.persist.TouchCachedResource(id, revision, st.currentTimestamp()); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// EvictCachedResource removes the cached charm store revision of the
+// resource from the model. Evicting an entry that isn't cached is
+// not an error.
+func (st resourceState) EvictCachedResource(applicationID, name string, revision int) error {
+	logger.Tracef("evicting cached revision %d of resource %q for application %q", revision, name, applicationID)
+	id := newResourceID(applicationID, name)
+	if err := st.persist.RemoveCachedResource(id, revision); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
 // TODO(ericsnow) Rename NewResolvePendingResourcesOps to reflect that
 // it has more meat to it?
 
@@ -416,6 +575,13 @@ func storagePath(name, applicationID, pendingID string) string {
 	return path.Join("application-"+applicationID, "resources", id)
 }
 
+// cacheStoragePath returns the path used as the location where the
+// given charm store revision of the resource is cached in state storage.
+func cacheStoragePath(name, applicationID string, revision int) string {
+	id := fmt.Sprintf("%s-cache-%d", name, revision)
+	return path.Join("application-"+applicationID, "resources", id)
+}
+
 // unitSetter records the resource as in use by a unit when the wrapped
 // reader has been fully read.
 type unitSetter struct {
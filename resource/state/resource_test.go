@@ -144,15 +144,75 @@ func (s *ResourceSuite) TestGetPendingResource(c *gc.C) {
 	c.Check(res, jc.DeepEquals, resources[1])
 }
 
+func (s *ResourceSuite) TestResourceHistory(c *gc.C) {
+	resources := newUploadResources(c, "spam", "spam")
+	expected := []resource.HistoryEntry{{
+		Resource:   resources[0],
+		Index:      2,
+		Superseded: s.timestamp,
+	}, {
+		Resource:   resources[1],
+		Index:      1,
+		Superseded: s.timestamp,
+	}}
+	s.persist.ReturnListResourceHistory = expected
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	history, err := st.ResourceHistory("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "ListResourceHistory")
+	s.stub.CheckCall(c, 0, "ListResourceHistory", "a-application/spam")
+	c.Check(history, jc.DeepEquals, expected)
+}
+
+func (s *ResourceSuite) TestResourceHistoryError(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+
+	_, err := st.ResourceHistory("a-application", "spam")
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c, "ListResourceHistory", "VerifyService")
+}
+
+func (s *ResourceSuite) TestRollbackResource(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	err := st.RollbackResource("a-application", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "RollbackResource")
+	s.stub.CheckCall(c, 0, "RollbackResource", "a-application/spam", 2)
+}
+
+func (s *ResourceSuite) TestRollbackResourceError(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(failure)
+
+	err := st.RollbackResource("a-application", "spam", 2)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c, "RollbackResource", "VerifyService")
+}
+
 func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	chRes := expected.Resource
 	hash := chRes.Fingerprint.String()
-	path := "application-a-application/resources/spam"
+	path := "application-a-application/resources/spam-upload-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "upload-id"
 	s.stub.ResetCalls()
 
 	res, err := st.SetResource("a-application", "a-user", chRes, file)
@@ -160,12 +220,13 @@ func (s *ResourceSuite) TestSetResourceOkay(c *gc.C) {
 
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, res.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, res.Size, hash)
 	c.Check(res, jc.DeepEquals, resource.Resource{
 		Resource:      chRes,
 		ID:            "a-application/" + res.Name,
@@ -216,133 +277,147 @@ func (s *ResourceSuite) TestSetResourceBadResource(c *gc.C) {
 func (s *ResourceSuite) TestSetResourceStagingFailure(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
-	path := "application-a-application/resources/spam"
+	path := "application-a-application/resources/spam-upload-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "upload-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, failure, ignoredErr)
+	s.stub.SetErrors(nil, nil, failure, ignoredErr)
 
 	_, err := st.SetResource("a-application", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
-	s.stub.CheckCallNames(c, "currentTimestamp", "StageResource")
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
+	s.stub.CheckCallNames(c, "currentTimestamp", "newPendingID", "StageResource")
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
 }
 
 func (s *ResourceSuite) TestSetResourcePutFailureBasic(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "application-a-application/resources/spam"
+	path := "application-a-application/resources/spam-upload-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "upload-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, failure, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, nil, ignoredErr)
 
 	_, err := st.SetResource("a-application", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
 }
 
 func (s *ResourceSuite) TestSetResourcePutFailureExtra(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "application-a-application/resources/spam"
+	path := "application-a-application/resources/spam-upload-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "upload-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	extraErr := errors.New("<just not your day>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, failure, extraErr, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, failure, extraErr, ignoredErr)
 
 	_, err := st.SetResource("a-application", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureBasic(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "application-a-application/resources/spam"
+	path := "application-a-application/resources/spam-upload-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "upload-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, failure, nil, nil, ignoredErr)
 
 	_, err := st.SetResource("a-application", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 		"Remove",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 5, "Remove", path)
 }
 
 func (s *ResourceSuite) TestSetResourceSetFailureExtra(c *gc.C) {
 	expected := newUploadResource(c, "spam", "spamspamspam")
 	expected.Timestamp = s.timestamp
 	hash := expected.Fingerprint.String()
-	path := "application-a-application/resources/spam"
+	path := "application-a-application/resources/spam-upload-id"
 	file := &stubReader{stub: s.stub}
 	st := NewState(s.raw)
 	st.currentTimestamp = s.now
+	st.newPendingID = s.newPendingID
+	s.pendingID = "upload-id"
 	s.stub.ResetCalls()
 	failure := errors.New("<failure>")
 	extraErr1 := errors.New("<just not your day>")
 	extraErr2 := errors.New("<wow...just wow>")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, failure, extraErr1, extraErr2, ignoredErr)
 
 	_, err := st.SetResource("a-application", "a-user", expected.Resource, file)
 
 	c.Check(errors.Cause(err), gc.Equals, failure)
 	s.stub.CheckCallNames(c,
 		"currentTimestamp",
+		"newPendingID",
 		"StageResource",
 		"PutAndCheckHash",
 		"Activate",
 		"Remove",
 		"Unstage",
 	)
-	s.stub.CheckCall(c, 1, "StageResource", expected, path)
-	s.stub.CheckCall(c, 2, "PutAndCheckHash", path, file, expected.Size, hash)
-	s.stub.CheckCall(c, 4, "Remove", path)
+	s.stub.CheckCall(c, 2, "StageResource", expected, path)
+	s.stub.CheckCall(c, 3, "PutAndCheckHash", path, file, expected.Size, hash)
+	s.stub.CheckCall(c, 5, "Remove", path)
 }
 
 func (s *ResourceSuite) TestUpdatePendingResourceOkay(c *gc.C) {
@@ -555,6 +630,104 @@ func (s *ResourceSuite) TestSetCharmStoreResources(c *gc.C) {
 	s.stub.CheckCall(c, 1, "SetCharmStoreResource", "a-application/eggs", "a-application", info[1], lastPolled)
 }
 
+func (s *ResourceSuite) TestCacheResourceOkay(c *gc.C) {
+	chRes := newCharmResource(c, "spam", "spamspamspam", 3)
+	path := "application-a-application/resources/spam-cache-3"
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+
+	res, err := st.CacheResource("a-application", chRes, file)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"PutAndCheckHash",
+		"SetCachedResource",
+	)
+	expected := resource.CachedResource{
+		Resource:      chRes,
+		ApplicationID: "a-application",
+		Fetched:       s.timestamp,
+		LastUsed:      s.timestamp,
+	}
+	s.stub.CheckCall(c, 1, "PutAndCheckHash", path, file, chRes.Size, chRes.Fingerprint.String())
+	s.stub.CheckCall(c, 2, "SetCachedResource", expected, path)
+	c.Check(res, jc.DeepEquals, expected)
+}
+
+func (s *ResourceSuite) TestCacheResourceSetFailed(c *gc.C) {
+	chRes := newCharmResource(c, "spam", "spamspamspam", 3)
+	file := &stubReader{stub: s.stub}
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+	failure := errors.New("<failure>")
+	s.stub.SetErrors(nil, nil, failure)
+
+	_, err := st.CacheResource("a-application", chRes, file)
+
+	c.Check(errors.Cause(err), gc.Equals, failure)
+	s.stub.CheckCallNames(c,
+		"currentTimestamp",
+		"PutAndCheckHash",
+		"SetCachedResource",
+		"Remove",
+	)
+	s.stub.CheckCall(c, 3, "Remove", "application-a-application/resources/spam-cache-3")
+}
+
+func (s *ResourceSuite) TestOpenCachedResourceOkay(c *gc.C) {
+	data := "some data"
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-application", data)
+	cached := resource.CachedResource{
+		Resource:      opened.Resource.Resource,
+		ApplicationID: "a-application",
+	}
+	s.persist.ReturnGetCachedResource = cached
+	s.persist.ReturnGetCachedResourcePath = "application-a-application/resources/spam-cache-1"
+	s.storage.ReturnGet = opened.Content()
+	st := NewState(s.raw)
+	st.currentTimestamp = s.now
+	s.stub.ResetCalls()
+
+	info, reader, err := st.OpenCachedResource("a-application", "spam", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "GetCachedResource", "Get", "currentTimestamp", "TouchCachedResource")
+	s.stub.CheckCall(c, 0, "GetCachedResource", "a-application/spam", 1)
+	s.stub.CheckCall(c, 3, "TouchCachedResource", "a-application/spam", 1, s.timestamp)
+	cached.LastUsed = s.timestamp
+	c.Check(info, jc.DeepEquals, cached)
+
+	b, err := ioutil.ReadAll(reader)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(string(b), gc.Equals, data)
+}
+
+func (s *ResourceSuite) TestOpenCachedResourceNotFound(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+	s.stub.SetErrors(errors.NotFoundf("cached resource"))
+
+	_, _, err := st.OpenCachedResource("a-application", "spam", 1)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	s.stub.CheckCallNames(c, "GetCachedResource")
+}
+
+func (s *ResourceSuite) TestEvictCachedResource(c *gc.C) {
+	st := NewState(s.raw)
+	s.stub.ResetCalls()
+
+	err := st.EvictCachedResource("a-application", "spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "RemoveCachedResource")
+	s.stub.CheckCall(c, 0, "RemoveCachedResource", "a-application/spam", 2)
+}
+
 func (s *ResourceSuite) TestNewResourcePendingResourcesOps(c *gc.C) {
 	doc1 := map[string]string{"a": "1"}
 	doc2 := map[string]string{"b": "2"}
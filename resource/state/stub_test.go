@@ -66,6 +66,10 @@ type stubPersistence struct {
 	ReturnGetResourcePath              string
 	ReturnStageResource                *stubStagedResource
 	ReturnNewResolvePendingResourceOps [][]txn.Op
+	ReturnListResourceHistory          []resource.HistoryEntry
+	ReturnListCachedResources          []resource.CachedResource
+	ReturnGetCachedResource            resource.CachedResource
+	ReturnGetCachedResourcePath        string
 
 	CallsForNewResolvePendingResourceOps map[string]string
 }
@@ -161,6 +165,69 @@ func (s *stubPersistence) NewResolvePendingResourceOps(resID, pendingID string)
 	return ops, nil
 }
 
+func (s *stubPersistence) ListResourceHistory(id string) ([]resource.HistoryEntry, error) {
+	s.stub.AddCall("ListResourceHistory", id)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResourceHistory, nil
+}
+
+func (s *stubPersistence) RollbackResource(id string, index int) error {
+	s.stub.AddCall("RollbackResource", id, index)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubPersistence) ListCachedResources() ([]resource.CachedResource, error) {
+	s.stub.AddCall("ListCachedResources")
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListCachedResources, nil
+}
+
+func (s *stubPersistence) GetCachedResource(id string, revision int) (resource.CachedResource, string, error) {
+	s.stub.AddCall("GetCachedResource", id, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.CachedResource{}, "", errors.Trace(err)
+	}
+
+	return s.ReturnGetCachedResource, s.ReturnGetCachedResourcePath, nil
+}
+
+func (s *stubPersistence) SetCachedResource(res resource.CachedResource, storagePath string) error {
+	s.stub.AddCall("SetCachedResource", res, storagePath)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubPersistence) TouchCachedResource(id string, revision int, lastUsed time.Time) error {
+	s.stub.AddCall("TouchCachedResource", id, revision, lastUsed)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
+func (s *stubPersistence) RemoveCachedResource(id string, revision int) error {
+	s.stub.AddCall("RemoveCachedResource", id, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	return nil
+}
+
 type stubStagedResource struct {
 	stub *testing.Stub
 }
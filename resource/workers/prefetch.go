@@ -0,0 +1,271 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.resource.workers")
+
+// CacheEntry identifies a revision of a charm store resource of an
+// application in one of the controller's models. For a revision that
+// has not been fetched yet, Fetched and LastUsed are zero.
+type CacheEntry struct {
+	resource.CachedResource
+
+	// ModelUUID identifies the model of the application.
+	ModelUUID string
+}
+
+func (entry CacheEntry) key() string {
+	return fmt.Sprintf("%s/%s/%s/%d", entry.ModelUUID, entry.ApplicationID, entry.Name, entry.Revision)
+}
+
+// PrefetchBackend exposes the functionality of the controller's models
+// needed by the resource prefetcher.
+type PrefetchBackend interface {
+	// ControllerConfig returns the controller config, which holds the
+	// resource cache policy.
+	ControllerConfig() (controller.Config, error)
+
+	// WantedResources returns the charm store resources the
+	// applications of all models might use: the revisions they
+	// currently use and the latest revisions in the charm store.
+	WantedResources() ([]CacheEntry, error)
+
+	// CachedResources returns the charm store resources cached in all
+	// models.
+	CachedResources() ([]CacheEntry, error)
+
+	// FetchResource fetches the revision of the resource from the
+	// charm store and caches it in the application's model.
+	FetchResource(entry CacheEntry) error
+
+	// TouchCachedResource records that the cached revision of the
+	// resource is still wanted.
+	TouchCachedResource(entry CacheEntry) error
+
+	// EvictCachedResource removes the cached revision of the resource
+	// from the application's model.
+	EvictCachedResource(entry CacheEntry) error
+}
+
+// CachePolicy bounds the charm store resources cached by the controller.
+type CachePolicy struct {
+	// Size is the maximum total size, in bytes, of the cached
+	// resources. Nothing is cached when it is zero.
+	Size int64
+
+	// MaxAge is how long a cached resource that is no longer wanted is
+	// kept. Such resources are only evicted to make room when it is
+	// zero.
+	MaxAge time.Duration
+}
+
+// NewCachePolicy returns the resource cache policy set in the
+// controller config.
+func NewCachePolicy(cfg controller.Config) CachePolicy {
+	return CachePolicy{
+		Size:   int64(cfg.ResourceCacheSize()) * 1024 * 1024,
+		MaxAge: cfg.ResourceCacheMaxAge(),
+	}
+}
+
+// Prefetch brings the resource cache in line with the policy. Wanted
+// resources that are not cached yet are fetched from the charm store,
+// evicting the least recently used of the unwanted ones to make room.
+// Unwanted resources older than the policy's MaxAge are evicted too.
+// Failing to fetch, touch or evict a resource is logged, and does not
+// stop the others from being handled.
+func Prefetch(backend PrefetchBackend, policy CachePolicy, now time.Time) error {
+	var wanted []CacheEntry
+	if policy.Size > 0 {
+		all, err := backend.WantedResources()
+		if err != nil {
+			return errors.Annotate(err, "cannot list wanted resources")
+		}
+		seen := make(map[string]bool)
+		for _, entry := range all {
+			if !seen[entry.key()] {
+				seen[entry.key()] = true
+				wanted = append(wanted, entry)
+			}
+		}
+	}
+	isWanted := make(map[string]bool)
+	for _, entry := range wanted {
+		isWanted[entry.key()] = true
+	}
+
+	cached, err := backend.CachedResources()
+	if err != nil {
+		return errors.Annotate(err, "cannot list cached resources")
+	}
+	// evict removes the cached resource, reporting whether it did.
+	evict := func(entry CacheEntry) bool {
+		if err := backend.EvictCachedResource(entry); err != nil {
+			logger.Errorf("cannot evict revision %d of resource %q of application %q: %v", entry.Revision, entry.Name, entry.ApplicationID, err)
+			return false
+		}
+		logger.Debugf("evicted revision %d of resource %q of application %q", entry.Revision, entry.Name, entry.ApplicationID)
+		return true
+	}
+
+	isCached := make(map[string]bool)
+	var size int64
+	var unwanted []CacheEntry
+	for _, entry := range cached {
+		if isWanted[entry.key()] {
+			if err := backend.TouchCachedResource(entry); err != nil {
+				logger.Errorf("cannot touch revision %d of resource %q of application %q: %v", entry.Revision, entry.Name, entry.ApplicationID, err)
+			}
+			isCached[entry.key()] = true
+			size += entry.Size
+			continue
+		}
+		if policy.Size <= 0 || (policy.MaxAge > 0 && now.Sub(entry.LastUsed) > policy.MaxAge) {
+			if !evict(entry) {
+				// It still takes up room, but is not tried again.
+				size += entry.Size
+			}
+			continue
+		}
+		unwanted = append(unwanted, entry)
+		size += entry.Size
+	}
+	sort.Sort(byLastUsed(unwanted))
+
+	// makeRoom evicts the least recently used unwanted resources until
+	// the given size fits in the cache, reporting whether it does.
+	// Resources that cannot be evicted are not tried again.
+	makeRoom := func(need int64) bool {
+		for size+need > policy.Size && len(unwanted) > 0 {
+			if evict(unwanted[0]) {
+				size -= unwanted[0].Size
+			}
+			unwanted = unwanted[1:]
+		}
+		return size+need <= policy.Size
+	}
+	makeRoom(0)
+
+	for _, entry := range wanted {
+		if isCached[entry.key()] {
+			continue
+		}
+		if !makeRoom(entry.Size) {
+			logger.Warningf("no room to cache revision %d of resource %q of application %q (%d bytes)", entry.Revision, entry.Name, entry.ApplicationID, entry.Size)
+			continue
+		}
+		if err := backend.FetchResource(entry); err != nil {
+			logger.Errorf("cannot fetch revision %d of resource %q of application %q: %v", entry.Revision, entry.Name, entry.ApplicationID, err)
+			continue
+		}
+		logger.Debugf("cached revision %d of resource %q of application %q", entry.Revision, entry.Name, entry.ApplicationID)
+		size += entry.Size
+	}
+	return nil
+}
+
+type byLastUsed []CacheEntry
+
+func (sorted byLastUsed) Len() int           { return len(sorted) }
+func (sorted byLastUsed) Swap(i, j int)      { sorted[i], sorted[j] = sorted[j], sorted[i] }
+func (sorted byLastUsed) Less(i, j int) bool { return sorted[i].LastUsed.Before(sorted[j].LastUsed) }
+
+// PrefetchConfig holds the dependencies and configuration necessary to
+// run a resource prefetcher.
+type PrefetchConfig struct {
+	Backend PrefetchBackend
+	Clock   clock.Clock
+
+	// Interval is how often the resource cache is brought in line with
+	// the policy.
+	Interval time.Duration
+}
+
+// Validate returns an error if config cannot be expected to drive a
+// functional resource prefetcher.
+func (config PrefetchConfig) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// NewPrefetcher returns a worker which fetches the charm store
+// resources the applications of all models might use into the
+// controller when it starts and every interval after that, following the resource cache policy set
+// in the controller config. This worker is intended to run just once,
+// on the MongoDB master.
+func NewPrefetcher(config PrefetchConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := &prefetcher{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &p.catacomb,
+		Work: p.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return p, nil
+}
+
+type prefetcher struct {
+	catacomb catacomb.Catacomb
+	config   PrefetchConfig
+}
+
+// Kill is part of the worker.Worker interface.
+func (p *prefetcher) Kill() {
+	p.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (p *prefetcher) Wait() error {
+	return p.catacomb.Wait()
+}
+
+func (p *prefetcher) loop() error {
+	for {
+		if err := p.prefetch(); err != nil {
+			return errors.Annotate(err, "cannot prefetch resources")
+		}
+		select {
+		case <-p.catacomb.Dying():
+			return p.catacomb.ErrDying()
+		case <-p.config.Clock.After(p.config.Interval):
+		}
+	}
+}
+
+func (p *prefetcher) prefetch() error {
+	backend := p.config.Backend
+	controllerConfig, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := NewCachePolicy(controllerConfig)
+	return errors.Trace(Prefetch(backend, policy, p.config.Clock.Now()))
+}
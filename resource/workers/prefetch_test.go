@@ -0,0 +1,276 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workers_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/resource/workers"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type PrefetchSuite struct {
+	testing.IsolationSuite
+
+	stub    *testing.Stub
+	backend *stubPrefetchBackend
+	now     time.Time
+}
+
+var _ = gc.Suite(&PrefetchSuite{})
+
+func (s *PrefetchSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.backend = &stubPrefetchBackend{
+		Stub:       s.stub,
+		calls:      make(chan string, 10),
+		ReturnConf: controller.Config{},
+	}
+	s.now = time.Date(2016, time.August, 8, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *PrefetchSuite) newEntry(c *gc.C, name string, revision int, lastUsed time.Time) workers.CacheEntry {
+	chRes := resourcetesting.NewCharmResource(c, name, "<"+name+" data>")
+	chRes.Origin = charmresource.OriginStore
+	chRes.Revision = revision
+	entry := workers.CacheEntry{
+		CachedResource: resource.CachedResource{
+			Resource:      chRes,
+			ApplicationID: "a-application",
+			Fetched:       lastUsed,
+			LastUsed:      lastUsed,
+		},
+		ModelUUID: coretesting.ModelTag.Id(),
+	}
+	return entry
+}
+
+func (s *PrefetchSuite) wanted(entry workers.CacheEntry) workers.CacheEntry {
+	entry.Fetched = time.Time{}
+	entry.LastUsed = time.Time{}
+	return entry
+}
+
+func (s *PrefetchSuite) TestFetchesMissing(c *gc.C) {
+	spam := s.newEntry(c, "spam", 2, s.now.Add(-time.Hour))
+	eggs := s.newEntry(c, "eggs", 3, time.Time{})
+	s.backend.ReturnWanted = []workers.CacheEntry{s.wanted(spam), s.wanted(eggs), s.wanted(eggs)}
+	s.backend.ReturnCached = []workers.CacheEntry{spam}
+
+	err := workers.Prefetch(s.backend, workers.CachePolicy{Size: 1024}, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "WantedResources", "CachedResources", "TouchCachedResource", "FetchResource")
+	s.stub.CheckCall(c, 2, "TouchCachedResource", spam)
+	s.stub.CheckCall(c, 3, "FetchResource", s.wanted(eggs))
+}
+
+func (s *PrefetchSuite) TestEvictsLeastRecentlyUsed(c *gc.C) {
+	older := s.newEntry(c, "older", 1, s.now.Add(-2*time.Hour))
+	newer := s.newEntry(c, "newer", 1, s.now.Add(-time.Hour))
+	wanted := s.newEntry(c, "wanted", 1, time.Time{})
+	s.backend.ReturnWanted = []workers.CacheEntry{wanted}
+	s.backend.ReturnCached = []workers.CacheEntry{newer, older}
+	policy := workers.CachePolicy{Size: newer.Size + wanted.Size}
+
+	err := workers.Prefetch(s.backend, policy, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "WantedResources", "CachedResources", "EvictCachedResource", "FetchResource")
+	s.stub.CheckCall(c, 2, "EvictCachedResource", older)
+}
+
+func (s *PrefetchSuite) TestEvictsExpired(c *gc.C) {
+	expired := s.newEntry(c, "expired", 1, s.now.Add(-48*time.Hour))
+	recent := s.newEntry(c, "recent", 1, s.now.Add(-time.Hour))
+	s.backend.ReturnCached = []workers.CacheEntry{expired, recent}
+	policy := workers.CachePolicy{Size: 1024, MaxAge: 24 * time.Hour}
+
+	err := workers.Prefetch(s.backend, policy, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "WantedResources", "CachedResources", "EvictCachedResource")
+	s.stub.CheckCall(c, 2, "EvictCachedResource", expired)
+}
+
+func (s *PrefetchSuite) TestNoRoom(c *gc.C) {
+	wanted := s.newEntry(c, "wanted", 1, time.Time{})
+	s.backend.ReturnWanted = []workers.CacheEntry{wanted}
+	policy := workers.CachePolicy{Size: wanted.Size - 1}
+
+	err := workers.Prefetch(s.backend, policy, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "WantedResources", "CachedResources")
+}
+
+func (s *PrefetchSuite) TestDisabledEvictsAll(c *gc.C) {
+	spam := s.newEntry(c, "spam", 1, s.now)
+	s.backend.ReturnCached = []workers.CacheEntry{spam}
+
+	err := workers.Prefetch(s.backend, workers.CachePolicy{}, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "CachedResources", "EvictCachedResource")
+	s.stub.CheckCall(c, 1, "EvictCachedResource", spam)
+}
+
+func (s *PrefetchSuite) TestFetchErrorContinues(c *gc.C) {
+	spam := s.newEntry(c, "spam", 1, time.Time{})
+	eggs := s.newEntry(c, "eggs", 1, time.Time{})
+	s.backend.ReturnWanted = []workers.CacheEntry{spam, eggs}
+	s.stub.SetErrors(nil, nil, errors.New("boom"))
+
+	err := workers.Prefetch(s.backend, workers.CachePolicy{Size: 1024}, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "WantedResources", "CachedResources", "FetchResource", "FetchResource")
+	s.stub.CheckCall(c, 3, "FetchResource", eggs)
+}
+
+func (s *PrefetchSuite) TestTouchErrorContinues(c *gc.C) {
+	spam := s.newEntry(c, "spam", 1, s.now)
+	eggs := s.newEntry(c, "eggs", 1, time.Time{})
+	s.backend.ReturnWanted = []workers.CacheEntry{s.wanted(spam), eggs}
+	s.backend.ReturnCached = []workers.CacheEntry{spam}
+	s.stub.SetErrors(nil, nil, errors.New("boom"))
+
+	err := workers.Prefetch(s.backend, workers.CachePolicy{Size: 1024}, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "WantedResources", "CachedResources", "TouchCachedResource", "FetchResource")
+	s.stub.CheckCall(c, 3, "FetchResource", eggs)
+}
+
+func (s *PrefetchSuite) TestEvictErrorContinues(c *gc.C) {
+	spam := s.newEntry(c, "spam", 1, s.now)
+	eggs := s.newEntry(c, "eggs", 1, s.now)
+	s.backend.ReturnCached = []workers.CacheEntry{spam, eggs}
+	s.stub.SetErrors(nil, errors.New("boom"))
+
+	err := workers.Prefetch(s.backend, workers.CachePolicy{}, s.now)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "CachedResources", "EvictCachedResource", "EvictCachedResource")
+	s.stub.CheckCall(c, 1, "EvictCachedResource", spam)
+	s.stub.CheckCall(c, 2, "EvictCachedResource", eggs)
+}
+
+func (s *PrefetchSuite) TestNewCachePolicy(c *gc.C) {
+	policy := workers.NewCachePolicy(controller.Config{
+		controller.ResourceCacheSize:   2,
+		controller.ResourceCacheMaxAge: "1h",
+	})
+
+	c.Check(policy, jc.DeepEquals, workers.CachePolicy{
+		Size:   2 * 1024 * 1024,
+		MaxAge: time.Hour,
+	})
+}
+
+func (s *PrefetchSuite) TestValidate(c *gc.C) {
+	clock := coretesting.NewClock(s.now)
+	_, err := workers.NewPrefetcher(workers.PrefetchConfig{Clock: clock, Interval: time.Minute})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = workers.NewPrefetcher(workers.PrefetchConfig{Backend: s.backend, Interval: time.Minute})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+
+	_, err = workers.NewPrefetcher(workers.PrefetchConfig{Backend: s.backend, Clock: clock})
+	c.Check(err, gc.ErrorMatches, "non-positive Interval not valid")
+}
+
+func (s *PrefetchSuite) TestPrefetchesEveryInterval(c *gc.C) {
+	clock := coretesting.NewClock(s.now)
+	w, err := workers.NewPrefetcher(workers.PrefetchConfig{
+		Backend:  s.backend,
+		Clock:    clock,
+		Interval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// Resources are prefetched as soon as the worker starts.
+	select {
+	case call := <-s.backend.calls:
+		c.Check(call, gc.Equals, "ControllerConfig")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for resources to be prefetched")
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-clock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for worker to wait")
+		}
+		clock.Advance(time.Hour)
+		select {
+		case call := <-s.backend.calls:
+			c.Check(call, gc.Equals, "ControllerConfig")
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for resources to be prefetched")
+		}
+	}
+}
+
+type stubPrefetchBackend struct {
+	*testing.Stub
+
+	calls chan string
+
+	ReturnConf   controller.Config
+	ReturnWanted []workers.CacheEntry
+	ReturnCached []workers.CacheEntry
+}
+
+func (s *stubPrefetchBackend) ControllerConfig() (controller.Config, error) {
+	s.calls <- "ControllerConfig"
+	return s.ReturnConf, nil
+}
+
+func (s *stubPrefetchBackend) WantedResources() ([]workers.CacheEntry, error) {
+	s.AddCall("WantedResources")
+	if err := s.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnWanted, nil
+}
+
+func (s *stubPrefetchBackend) CachedResources() ([]workers.CacheEntry, error) {
+	s.AddCall("CachedResources")
+	if err := s.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnCached, nil
+}
+
+func (s *stubPrefetchBackend) FetchResource(entry workers.CacheEntry) error {
+	s.AddCall("FetchResource", entry)
+	return s.NextErr()
+}
+
+func (s *stubPrefetchBackend) TouchCachedResource(entry workers.CacheEntry) error {
+	s.AddCall("TouchCachedResource", entry)
+	return s.NextErr()
+}
+
+func (s *stubPrefetchBackend) EvictCachedResource(entry workers.CacheEntry) error {
+	s.AddCall("EvictCachedResource", entry)
+	return s.NextErr()
+}
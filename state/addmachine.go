@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -942,7 +943,13 @@ func (st *State) enableHAIntentions(info *ControllerInfo, placement []string) (*
 		return nil, errors.Errorf("unsupported placement directive %q", s)
 	}
 
+	removing := set.NewStrings(info.RemovingMachineIds...)
 	for _, mid := range info.MachineIds {
+		if removing.Contains(mid) {
+			// The machine is on its way out; leave it to the
+			// peergrouper to finish removing it.
+			continue
+		}
 		m, err := st.Machine(mid)
 		if err != nil {
 			return nil, err
@@ -99,6 +99,17 @@ func allCollections() collectionSchema {
 		// This collection holds Juju GUI current version and other settings.
 		guisettingsC: {global: true},
 
+		// These collections hold the charms published to the charm
+		// repository hosted by the controller, and the metadata of
+		// their archives.
+		repositoryCharmsC: {
+			global: true,
+			indexes: []mgo.Index{{
+				Key: []string{"name", "revision"},
+			}},
+		},
+		repositoryCharmArchivesC: {global: true},
+
 		// This collection holds model information; in particular its
 		// Life and its UUID.
 		modelsC: {global: true},
@@ -130,12 +141,25 @@ func allCollections() collectionSchema {
 			global: true,
 		},
 
+		// This collection holds groups of users, and their members.
+		userGroupsC: {
+			global: true,
+		},
+
 		// This collection holds the last time the user connected to the API server.
 		userLastLoginC: {
 			global:    true,
 			rawAccess: true,
 		},
 
+		// This collection holds the most recent logins of each local
+		// user. Like userLastLoginC, it is updated outside of
+		// transactions.
+		userLoginHistoryC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection is used as a unique key restraint. The _id field is
 		// a concatenation of multiple fields that form a compound index,
 		// allowing us to ensure users cannot have the same name for two
@@ -223,6 +247,11 @@ func allCollections() collectionSchema {
 		// These collections hold information associated with applications.
 		charmsC:       {},
 		applicationsC: {},
+
+		// This collection holds the charm upgrade policy of each
+		// application that has been given one.
+		charmUpgradePoliciesC: {},
+
 		unitsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "application"},
@@ -260,6 +289,10 @@ func allCollections() collectionSchema {
 		rebootC:        {},
 		sshHostKeysC:   {},
 
+		// This collection holds the plan for rolling out a new agent
+		// version to the machines in a model in batches.
+		upgradePlansC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -303,6 +336,11 @@ func allCollections() collectionSchema {
 		ipAddressesC:          {},
 		endpointBindingsC:     {},
 		openedPortsC:          {},
+		ipAddressReservationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "machine-id"},
+			}},
+		},
 
 		// -----
 
@@ -384,6 +422,7 @@ const (
 	blockDevicesC            = "blockdevices"
 	blocksC                  = "blocks"
 	charmsC                  = "charms"
+	charmUpgradePoliciesC    = "charmupgradepolicies"
 	cleanupsC                = "cleanups"
 	cloudimagemetadataC      = "cloudimagemetadata"
 	cloudCredentialsC        = "cloudCredentials"
@@ -416,6 +455,8 @@ const (
 	rebootC                  = "reboot"
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
+	repositoryCharmArchivesC = "repositorycharmarchives"
+	repositoryCharmsC        = "repositorycharms"
 	restoreInfoC             = "restoreInfo"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
@@ -433,14 +474,18 @@ const (
 	linkLayerDevicesC        = "linklayerdevices"
 	linkLayerDevicesRefsC    = "linklayerdevicesrefs"
 	ipAddressesC             = "ip.addresses"
+	ipAddressReservationsC   = "ip.addresses.reservations"
 	toolsmetadataC           = "toolsmetadata"
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
 	unitsC                   = "units"
 	upgradeInfoC             = "upgradeInfo"
+	upgradePlansC            = "upgradePlans"
 	userLastLoginC           = "userLastLogin"
+	userLoginHistoryC        = "userloginhistory"
 	usermodelnameC           = "usermodelname"
 	usersC                   = "users"
+	userGroupsC              = "usergroups"
 	volumeAttachmentsC       = "volumeattachments"
 	volumesC                 = "volumes"
 	// "payloads" (see payload/persistence/mongo.go)
@@ -269,6 +269,7 @@ func (s *Application) removeOps(asserts bson.D) []txn.Op {
 		removeLeadershipSettingsOp(s.Name()),
 		removeStatusOp(s.st, s.globalKey()),
 		removeModelServiceRefOp(s.st, s.Name()),
+		removeCharmUpgradePolicyOp(s.globalKey()),
 	}
 	// For local charms, we also delete the charm itself since the
 	// charm is associated 1:1 with the service. Each different deploy
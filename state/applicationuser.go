@@ -0,0 +1,151 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ValidApplicationAccess returns whether the given access level can be
+// granted on an application.
+func ValidApplicationAccess(access Access) bool {
+	switch access {
+	case OperateAccess, ManageAccess:
+		return true
+	}
+	return false
+}
+
+// ApplicationAccess returns the level of access the given user
+// has been granted on the named application. A NotFound error is
+// returned if the user has not been granted any.
+func (st *State) ApplicationAccess(application string, user names.UserTag) (Access, error) {
+	perm, err := st.userPermission(applicationGlobalKey(application), modelUserGlobalKey(modelUserID(user)))
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// ApplicationHasGrants returns whether any user has been granted
+// access on the named application. Such applications may only be acted
+// upon by the users granted access and by model administrators.
+func (st *State) ApplicationHasGrants(application string) (bool, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	count, err := permissions.Find(bson.D{{"object-global-key", applicationGlobalKey(application)}}).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// ManagesApplications returns whether the given user has been granted
+// manage access on any application in the model.
+func (st *State) ManagesApplications(user names.UserTag) (bool, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	count, err := permissions.Find(bson.D{
+		{"object-global-key", bson.D{{"$regex", "^" + applicationGlobalKey("")}}},
+		{"subject-global-key", modelUserGlobalKey(modelUserID(user))},
+		{"access", ManageAccess},
+	}).Count()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return count > 0, nil
+}
+
+// SetApplicationAccess sets the level of access the given user has on
+// the named application, replacing any access previously granted. The
+// application must be alive, and the user must have access to the
+// model, either directly or through a group.
+func (st *State) SetApplicationAccess(application string, user names.UserTag, access Access) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set access to application %q for %q", application, user.Canonical())
+	if !ValidApplicationAccess(access) {
+		return errors.NotValidf("application access %q", access)
+	}
+	objectKey := applicationGlobalKey(application)
+	subjectKey := modelUserGlobalKey(modelUserID(user))
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		app, err := st.Application(application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application is not alive")
+		}
+		if _, err := st.EffectiveModelAccess(user); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		_, err = st.userPermission(objectKey, subjectKey)
+		if errors.IsNotFound(err) {
+			return append(ops, createPermissionOp(objectKey, subjectKey, access)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, updatePermissionOp(objectKey, subjectKey, access)), nil
+	}
+	return st.run(buildTxn)
+}
+
+// RemoveApplicationAccess removes all access the given user has
+// been granted on the named application.
+func (st *State) RemoveApplicationAccess(application string, user names.UserTag) error {
+	op := removePermissionOp(applicationGlobalKey(application), modelUserGlobalKey(modelUserID(user)))
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("access to application %q for %q", application, user.Canonical())
+	}
+	return errors.Trace(err)
+}
+
+// removeApplicationPermissionsOps returns the operations required to
+// remove all access granted on the named application. It is used when
+// adding an application, so that access granted on an earlier
+// application of the same name does not carry over.
+func (st *State) removeApplicationPermissionsOps(application string) ([]txn.Op, error) {
+	return st.removePermissionsOps(bson.D{{"object-global-key", applicationGlobalKey(application)}})
+}
+
+// removeUserApplicationPermissionsOps returns the operations required
+// to remove all access the given user has been granted on
+// applications.
+func (st *State) removeUserApplicationPermissionsOps(user names.UserTag) ([]txn.Op, error) {
+	return st.removePermissionsOps(bson.D{
+		{"object-global-key", bson.D{{"$regex", "^" + applicationGlobalKey("")}}},
+		{"subject-global-key", modelUserGlobalKey(modelUserID(user))},
+	})
+}
+
+// removePermissionsOps returns the operations required to remove the
+// permissions matching the given query.
+func (st *State) removePermissionsOps(query bson.D) ([]txn.Op, error) {
+	permissions, closer := st.getCollection(permissionsC)
+	defer closer()
+
+	var docs []permissionDoc
+	if err := permissions.Find(query).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      permissionsC,
+			Id:     doc.ID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationUserSuite struct {
+	ConnSuite
+	application *state.Application
+	user        names.UserTag
+}
+
+var _ = gc.Suite(&ApplicationUserSuite{})
+
+func (s *ApplicationUserSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.Factory.MakeApplication(c, nil)
+	s.user = s.Factory.MakeUser(c, &factory.UserParams{Access: state.ReadAccess}).UserTag()
+}
+
+func (s *ApplicationUserSuite) TestNoAccessByDefault(c *gc.C) {
+	_, err := s.State.ApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccess(c *gc.C) {
+	err := s.State.SetApplicationAccess(s.application.Name(), s.user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.OperateAccess)
+
+	err = s.State.SetApplicationAccess(s.application.Name(), s.user, state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.ApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ManageAccess)
+}
+
+func (s *ApplicationUserSuite) TestApplicationHasGrants(c *gc.C) {
+	restricted, err := s.State.ApplicationHasGrants(s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restricted, jc.IsFalse)
+
+	err = s.State.SetApplicationAccess(s.application.Name(), s.user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	restricted, err = s.State.ApplicationHasGrants(s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(restricted, jc.IsTrue)
+}
+
+func (s *ApplicationUserSuite) TestManagesApplications(c *gc.C) {
+	manages, err := s.State.ManagesApplications(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manages, jc.IsFalse)
+
+	err = s.State.SetApplicationAccess(s.application.Name(), s.user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	manages, err = s.State.ManagesApplications(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manages, jc.IsFalse)
+
+	err = s.State.SetApplicationAccess(s.application.Name(), s.user, state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	manages, err = s.State.ManagesApplications(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(manages, jc.IsTrue)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessInvalid(c *gc.C) {
+	err := s.State.SetApplicationAccess(s.application.Name(), s.user, state.AdminAccess)
+	c.Assert(err, gc.ErrorMatches, `cannot set access to application "mysql" for ".*": application access "admin" not valid`)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessNoModelAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetApplicationAccess(s.application.Name(), user.UserTag(), state.OperateAccess)
+	c.Assert(err, gc.ErrorMatches, `cannot set access to application "mysql" for ".*": access for ".*" not found`)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessGroupMember(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true}).UserTag()
+	group, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(user), jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("devs", state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetApplicationAccess(s.application.Name(), user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ApplicationAccess(s.application.Name(), user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.OperateAccess)
+}
+
+func (s *ApplicationUserSuite) TestSetApplicationAccessDeadApplication(c *gc.C) {
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetApplicationAccess(s.application.Name(), s.user, state.OperateAccess)
+	c.Assert(err, gc.ErrorMatches, `cannot set access to application "mysql" for ".*": application "mysql" not found`)
+}
+
+func (s *ApplicationUserSuite) TestRemoveApplicationAccess(c *gc.C) {
+	err := s.State.SetApplicationAccess(s.application.Name(), s.user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationUserSuite) TestAccessDoesNotOutliveApplication(c *gc.C) {
+	err := s.State.SetApplicationAccess(s.application.Name(), s.user, state.ManageAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := s.application.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	_, err = s.State.ApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationUserSuite) TestAccessDoesNotOutliveModelUser(c *gc.C) {
+	err := s.State.SetApplicationAccess(s.application.Name(), s.user, state.OperateAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveModelUser(s.user)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ApplicationAccess(s.application.Name(), s.user)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
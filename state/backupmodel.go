@@ -0,0 +1,43 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
+)
+
+// ExportFromDatabase exports the model with the given tag from a copy
+// of the juju database held in the named database, such as one loaded
+// from a backup archive. The copy is only read from; no workers are
+// started against it.
+func (st *State) ExportFromDatabase(dbName string, modelTag names.ModelTag) (description.Model, error) {
+	if dbName == jujuDB {
+		return nil, errors.NotValidf("exporting from the live juju database")
+	}
+	session := st.session.Copy()
+	database, err := allCollections().Load(session.DB(dbName), modelTag.Id())
+	if err != nil {
+		session.Close()
+		return nil, errors.Trace(err)
+	}
+	copySt := &State{
+		modelTag:      modelTag,
+		controllerTag: st.controllerTag,
+		mongoInfo:     st.mongoInfo,
+		session:       session,
+		database:      database,
+		policy:        st.policy,
+		leaseClientId: st.leaseClientId,
+	}
+	defer copySt.Close()
+
+	model, err := copySt.Export()
+	if err != nil {
+		return nil, errors.Annotatef(err, "exporting model %s from %q", modelTag.Id(), dbName)
+	}
+	return model, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/description"
+)
+
+type ExportFromDatabaseSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ExportFromDatabaseSuite{})
+
+func (s *ExportFromDatabaseSuite) copyDatabase(c *gc.C, dbName string) {
+	session := s.State.MongoSession()
+	source := session.DB("juju")
+	target := session.DB(dbName)
+	s.AddCleanup(func(c *gc.C) {
+		c.Check(target.DropDatabase(), jc.ErrorIsNil)
+	})
+	names, err := source.CollectionNames()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range names {
+		var docs []bson.M
+		err := source.C(name).Find(nil).All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, doc := range docs {
+			err := target.C(name).Insert(doc)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	}
+}
+
+func (s *ExportFromDatabaseSuite) TestExportFromDatabase(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	s.copyDatabase(c, "juju-backup-copy")
+
+	// Changes to the live database after the copy is taken are not
+	// seen when exporting from the copy.
+	live, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeMachine(c, nil)
+
+	copied, err := s.State.ExportFromDatabase("juju-backup-copy", s.State.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(copied.Machines(), gc.HasLen, 1)
+
+	liveBytes, err := description.Serialize(live)
+	c.Assert(err, jc.ErrorIsNil)
+	copiedBytes, err := description.Serialize(copied)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(copiedBytes), gc.Equals, string(liveBytes))
+}
+
+func (s *ExportFromDatabaseSuite) TestExportFromLiveDatabase(c *gc.C) {
+	_, err := s.State.ExportFromDatabase("juju", s.State.ModelTag())
+	c.Check(err, gc.ErrorMatches, "exporting from the live juju database not valid")
+}
@@ -22,6 +22,8 @@ const (
 	filesBundle  = "root.tar"
 	dbDumpDir    = "dump"
 	metadataFile = "metadata.json"
+	manifestFile = "manifest.json"
+	manifestSig  = "manifest.sig"
 )
 
 var legacyVersion = version.Number{Major: 1, Minor: 20}
@@ -47,6 +49,14 @@ type ArchivePaths struct {
 
 	// MetadataFile is the path to the metadata file.
 	MetadataFile string
+
+	// ManifestFile is the path to the manifest listing the hash of
+	// every other file in the archive.
+	ManifestFile string
+
+	// ManifestSignatureFile is the path to the signature of the
+	// manifest, made with the controller's CA key.
+	ManifestSignatureFile string
 }
 
 // NewCanonicalArchivePaths composes a new ArchivePaths with default
@@ -55,10 +65,12 @@ type ArchivePaths struct {
 // resolving the paths in a backup archive file (which is a tar file).
 func NewCanonicalArchivePaths() ArchivePaths {
 	return ArchivePaths{
-		ContentDir:   contentDir,
-		FilesBundle:  path.Join(contentDir, filesBundle),
-		DBDumpDir:    path.Join(contentDir, dbDumpDir),
-		MetadataFile: path.Join(contentDir, metadataFile),
+		ContentDir:            contentDir,
+		FilesBundle:           path.Join(contentDir, filesBundle),
+		DBDumpDir:             path.Join(contentDir, dbDumpDir),
+		MetadataFile:          path.Join(contentDir, metadataFile),
+		ManifestFile:          path.Join(contentDir, manifestFile),
+		ManifestSignatureFile: path.Join(contentDir, manifestSig),
 	}
 }
 
@@ -69,10 +81,12 @@ func NewCanonicalArchivePaths() ArchivePaths {
 // been unpacked.
 func NewNonCanonicalArchivePaths(rootDir string) ArchivePaths {
 	return ArchivePaths{
-		ContentDir:   filepath.Join(rootDir, contentDir),
-		FilesBundle:  filepath.Join(rootDir, contentDir, filesBundle),
-		DBDumpDir:    filepath.Join(rootDir, contentDir, dbDumpDir),
-		MetadataFile: filepath.Join(rootDir, contentDir, metadataFile),
+		ContentDir:            filepath.Join(rootDir, contentDir),
+		FilesBundle:           filepath.Join(rootDir, contentDir, filesBundle),
+		DBDumpDir:             filepath.Join(rootDir, contentDir, dbDumpDir),
+		MetadataFile:          filepath.Join(rootDir, contentDir, metadataFile),
+		ManifestFile:          filepath.Join(rootDir, contentDir, manifestFile),
+		ManifestSignatureFile: filepath.Join(rootDir, contentDir, manifestSig),
 	}
 }
 
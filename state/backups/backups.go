@@ -43,6 +43,8 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/utils/filestorage"
 	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/description"
 )
 
 const (
@@ -84,8 +86,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates and stores a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	// the provided metadata. If key is not nil the archive is
+	// encrypted with it.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, key *ArchiveKey) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -103,6 +106,10 @@ type Backups interface {
 	// it returns the tag string for the machine where the backup originated
 	// or error if the process fails.
 	Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (names.Tag, error)
+
+	// ExportModel extracts a single model from the backup archive,
+	// leaving juju's state untouched.
+	ExportModel(backupId string, args ExportModelArgs) (description.Model, error)
 }
 
 type backups struct {
@@ -119,9 +126,15 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive and updates the
 // provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, key *ArchiveKey) error {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
+	if key != nil {
+		if err := key.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		meta.Encryption = key.Method()
+	}
 
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
@@ -142,7 +155,14 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "while preparing for DB dump")
 	}
-	args := createArgs{filesToBackUp, dumper, metadataFile}
+	args := createArgs{
+		filesToBackUp:  filesToBackUp,
+		db:             dumper,
+		metadataReader: metadataFile,
+		caCert:         meta.CACert,
+		caKey:          meta.CAPrivateKey,
+		key:            key,
+	}
 	result, err := runCreate(&args)
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
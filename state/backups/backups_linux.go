@@ -69,12 +69,24 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 
 	defer backupReader.Close()
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	// Nothing on the machine is touched until the archive has been
+	// checked against its checksum and its signed manifest.
+	archive, err := verifyArchive(meta, backupReader, args.Key)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot verify backup %q", backupId)
+	}
+	defer archive.Close()
+
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
 	defer workspace.Close()
 
+	if err := workspace.VerifyManifest(args.CACert); err != nil {
+		return nil, errors.Annotatef(err, "cannot verify backup %q", backupId)
+	}
+
 	// This might actually work, but we don't have a guarantee so we don't allow it.
 	if meta.Origin.Series != args.NewInstSeries {
 		return nil, errors.Errorf("cannot restore a backup made in a machine with series %q into a machine with series %q, %#v", meta.Origin.Series, args.NewInstSeries, meta)
@@ -31,6 +31,11 @@ type createArgs struct {
 	filesToBackUp  []string
 	db             DBDumper
 	metadataReader io.Reader
+	// caCert and caKey are used to sign the archive manifest.
+	caCert string
+	caKey  string
+	// key, if set, is used to encrypt the archive.
+	key *ArchiveKey
 }
 
 type createResult struct {
@@ -47,6 +52,9 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.caCert = args.caCert
+	builder.caKey = args.caKey
+	builder.key = args.key
 	defer func() {
 		if cerr := builder.cleanUp(); cerr != nil {
 			cerr.Log(logger)
@@ -103,6 +111,11 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// caCert and caKey are used to sign the archive manifest.
+	caCert string
+	caKey  string
+	// key, if set, is used to encrypt the archive file.
+	key *ArchiveKey
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -285,6 +298,15 @@ func (b *builder) buildDBDump() error {
 	return nil
 }
 
+func (b *builder) buildManifest() error {
+	logger.Infof("writing signed manifest")
+	if b.caKey == "" {
+		return errors.New("missing CA key to sign manifest")
+	}
+	err := writeSignedManifest(b.archivePaths, b.caCert, b.caKey)
+	return errors.Trace(err)
+}
+
 func (b *builder) buildArchive(outFile io.Writer) error {
 	tarball := gzip.NewWriter(outFile)
 	defer tarball.Close()
@@ -312,9 +334,25 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	//
+	// If the archive is encrypted, the hash is of the encrypted file,
+	// which is what is stored and what a restore will check.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.key == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		encrypter, err := newEncryptingWriter(hasher, *b.key)
+		if err != nil {
+			return errors.Annotate(err, "while preparing archive encryption")
+		}
+		if err := b.buildArchive(encrypter); err != nil {
+			return errors.Trace(err)
+		}
+		if err := encrypter.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -337,6 +375,11 @@ func (b *builder) buildAll() error {
 		return errors.Trace(err)
 	}
 
+	// Record what went into the archive.
+	if err := b.buildManifest(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Bundle it all into a tarball.
 	if err := b.buildArchiveAndChecksum(); err != nil {
 		return errors.Trace(err)
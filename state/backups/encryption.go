@@ -0,0 +1,371 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"hash"
+	"io"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// The supported backup archive encryption methods. The method used for
+// an archive is recorded in its metadata.
+const (
+	// EncryptionPassphrase identifies archives encrypted with a key
+	// derived from a user-supplied passphrase.
+	EncryptionPassphrase = "passphrase"
+
+	// EncryptionPublicKey identifies archives encrypted with a random
+	// key that is wrapped with a user-supplied RSA public key.
+	EncryptionPublicKey = "public-key"
+)
+
+const (
+	// encryptedMagic starts every encrypted archive. Plain archives
+	// are gzip files, so the two cannot be confused.
+	encryptedMagic = "JUJU-BACKUP-ENCRYPTED-1\n"
+
+	// maxHeaderSize limits the size of the encryption header we are
+	// willing to read from an archive.
+	maxHeaderSize = 64 * 1024
+
+	pbkdf2Iterations = 100000
+	saltSize         = 32
+	cipherKeySize    = 32
+	macKeySize       = 32
+	macSize          = sha256.Size
+)
+
+// ArchiveKey holds the user-supplied key material used to encrypt or
+// decrypt a backup archive. Encryption uses either the passphrase or
+// the public key. Decryption uses either the passphrase or the
+// private key matching the public key.
+type ArchiveKey struct {
+	// Passphrase is used to derive the archive key.
+	Passphrase string
+
+	// PublicKey is the PEM-encoded RSA public key (or certificate)
+	// the archive is encrypted for.
+	PublicKey string
+
+	// PrivateKey is the PEM-encoded RSA private key used to decrypt
+	// an archive encrypted for the matching public key.
+	PrivateKey string
+}
+
+// Method returns the encryption method the key is suitable for
+// encrypting with.
+func (k ArchiveKey) Method() string {
+	if k.Passphrase != "" {
+		return EncryptionPassphrase
+	}
+	if k.PublicKey != "" || k.PrivateKey != "" {
+		return EncryptionPublicKey
+	}
+	return ""
+}
+
+// Validate ensures the key holds exactly one usable set of key
+// material.
+func (k ArchiveKey) Validate() error {
+	if k.Passphrase == "" && k.PublicKey == "" && k.PrivateKey == "" {
+		return errors.NotValidf("empty archive key")
+	}
+	if k.Passphrase != "" && (k.PublicKey != "" || k.PrivateKey != "") {
+		return errors.NotValidf("archive key with both passphrase and RSA key")
+	}
+	return nil
+}
+
+// encryptionHeader is written, JSON-encoded, at the start of an
+// encrypted archive.
+type encryptionHeader struct {
+	Method     string `json:"method"`
+	Salt       []byte `json:"salt,omitempty"`
+	Iterations int    `json:"iterations,omitempty"`
+	WrappedKey []byte `json:"wrapped-key,omitempty"`
+	IV         []byte `json:"iv"`
+}
+
+// IsEncryptedArchive reports whether the archive was encrypted. The
+// reader is left positioned at the start of the archive.
+func IsEncryptedArchive(archive io.ReadSeeker) (bool, error) {
+	magic := make([]byte, len(encryptedMagic))
+	n, err := io.ReadFull(archive, magic)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return false, errors.Trace(err)
+	}
+	if _, err := archive.Seek(0, 0); err != nil {
+		return false, errors.Trace(err)
+	}
+	return string(magic[:n]) == encryptedMagic, nil
+}
+
+// newEncryptingWriter returns a writer that encrypts everything
+// written to it into w. The encryption header is written immediately;
+// the integrity check is written when the returned writer is closed.
+// Closing it does not close w.
+func newEncryptingWriter(w io.Writer, key ArchiveKey) (io.WriteCloser, error) {
+	if err := key.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	header := encryptionHeader{
+		Method: key.Method(),
+		IV:     make([]byte, aes.BlockSize),
+	}
+	if _, err := io.ReadFull(rand.Reader, header.IV); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var keys []byte
+	switch header.Method {
+	case EncryptionPassphrase:
+		header.Salt = make([]byte, saltSize)
+		if _, err := io.ReadFull(rand.Reader, header.Salt); err != nil {
+			return nil, errors.Trace(err)
+		}
+		header.Iterations = pbkdf2Iterations
+		keys = passphraseKeys(key.Passphrase, header.Salt, header.Iterations)
+	case EncryptionPublicKey:
+		if key.PublicKey == "" {
+			return nil, errors.NotValidf("archive key without public key")
+		}
+		pub, err := parsePublicKey(key.PublicKey)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		keys = make([]byte, cipherKeySize+macKeySize)
+		if _, err := io.ReadFull(rand.Reader, keys); err != nil {
+			return nil, errors.Trace(err)
+		}
+		header.WrappedKey, err = rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, keys, nil)
+		if err != nil {
+			return nil, errors.Annotate(err, "while wrapping archive key")
+		}
+	}
+
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var prefix bytes.Buffer
+	prefix.WriteString(encryptedMagic)
+	binary.Write(&prefix, binary.BigEndian, uint32(len(headerData)))
+	prefix.Write(headerData)
+
+	stream, mac, err := newArchiveCipher(keys, header.IV)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mac.Write(prefix.Bytes())
+	if _, err := w.Write(prefix.Bytes()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &encryptingWriter{w: w, stream: stream, mac: mac}, nil
+}
+
+type encryptingWriter struct {
+	w      io.Writer
+	stream cipher.Stream
+	mac    hash.Hash
+	buf    []byte
+}
+
+// Write implements io.Writer.
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	if cap(e.buf) < len(p) {
+		e.buf = make([]byte, len(p))
+	}
+	out := e.buf[:len(p)]
+	e.stream.XORKeyStream(out, p)
+	e.mac.Write(out)
+	n, err := e.w.Write(out)
+	return n, errors.Trace(err)
+}
+
+// Close writes the integrity check for the encrypted data.
+func (e *encryptingWriter) Close() error {
+	_, err := e.w.Write(e.mac.Sum(nil))
+	return errors.Trace(err)
+}
+
+// DecryptArchive decrypts the encrypted archive read from r, writing
+// the plain archive to w. The integrity of the encrypted data is only
+// known once all of it has been read, so if an error is returned
+// anything already written to w must be discarded.
+func DecryptArchive(w io.Writer, r io.Reader, key ArchiveKey) error {
+	if err := key.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	var prefix bytes.Buffer
+	tee := io.TeeReader(r, &prefix)
+
+	magic := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(tee, magic); err != nil || string(magic) != encryptedMagic {
+		return errors.New("backup archive is not encrypted")
+	}
+	var headerSize uint32
+	if err := binary.Read(tee, binary.BigEndian, &headerSize); err != nil {
+		return errors.Annotate(err, "while reading encryption header")
+	}
+	if headerSize > maxHeaderSize {
+		return errors.Errorf("encryption header too large (%d bytes)", headerSize)
+	}
+	headerData := make([]byte, headerSize)
+	if _, err := io.ReadFull(tee, headerData); err != nil {
+		return errors.Annotate(err, "while reading encryption header")
+	}
+	var header encryptionHeader
+	if err := json.Unmarshal(headerData, &header); err != nil {
+		return errors.Annotate(err, "while reading encryption header")
+	}
+
+	var keys []byte
+	switch header.Method {
+	case EncryptionPassphrase:
+		if key.Passphrase == "" {
+			return errors.New("backup archive is encrypted with a passphrase")
+		}
+		keys = passphraseKeys(key.Passphrase, header.Salt, header.Iterations)
+	case EncryptionPublicKey:
+		if key.PrivateKey == "" {
+			return errors.New("backup archive is encrypted with a public key; a private key is needed")
+		}
+		priv, err := parsePrivateKey(key.PrivateKey)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		keys, err = rsa.DecryptOAEP(sha256.New(), rand.Reader, priv, header.WrappedKey, nil)
+		if err != nil {
+			return errors.New("cannot unwrap archive key; wrong private key?")
+		}
+	default:
+		return errors.NotSupportedf("backup archive encryption method %q", header.Method)
+	}
+
+	stream, mac, err := newArchiveCipher(keys, header.IV)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mac.Write(prefix.Bytes())
+
+	// The integrity check makes up the last macSize bytes of the
+	// archive, so we always hold that much back from decryption.
+	buf := make([]byte, 32*1024+macSize)
+	out := make([]byte, len(buf))
+	held := 0
+	for {
+		n, rerr := r.Read(buf[held:])
+		held += n
+		if held > macSize {
+			data := buf[:held-macSize]
+			mac.Write(data)
+			stream.XORKeyStream(out[:len(data)], data)
+			if _, err := w.Write(out[:len(data)]); err != nil {
+				return errors.Trace(err)
+			}
+			copy(buf, buf[held-macSize:held])
+			held = macSize
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return errors.Trace(rerr)
+		}
+	}
+	if held < macSize {
+		return errors.New("backup archive is truncated")
+	}
+	if !hmac.Equal(mac.Sum(nil), buf[:macSize]) {
+		return errors.New("backup archive failed integrity check; wrong passphrase or corrupted archive")
+	}
+	return nil
+}
+
+func passphraseKeys(passphrase string, salt []byte, iterations int) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, iterations, cipherKeySize+macKeySize, sha256.New)
+}
+
+func newArchiveCipher(keys, iv []byte) (cipher.Stream, hash.Hash, error) {
+	if len(keys) != cipherKeySize+macKeySize {
+		return nil, nil, errors.Errorf("invalid archive key length %d", len(keys))
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, nil, errors.Errorf("invalid archive IV length %d", len(iv))
+	}
+	block, err := aes.NewCipher(keys[:cipherKeySize])
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return cipher.NewCTR(block, iv), hmac.New(sha256.New, keys[cipherKeySize:]), nil
+}
+
+// parsePublicKey parses a PEM-encoded RSA public key, either bare or
+// as part of an X509 certificate.
+func parsePublicKey(keyPEM string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.NotValidf("public key (no PEM data found)")
+	}
+	var pub interface{}
+	switch block.Type {
+	case "PUBLIC KEY":
+		var err error
+		if pub, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, errors.Annotate(err, "cannot parse public key")
+		}
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot parse certificate")
+		}
+		pub = cert.PublicKey
+	default:
+		return nil, errors.NotSupportedf("public key PEM block %q", block.Type)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.NotSupportedf("public key type %T", pub)
+	}
+	return rsaPub, nil
+}
+
+// parsePrivateKey parses a PEM-encoded RSA private key in either
+// PKCS#1 or PKCS#8 form.
+func parsePrivateKey(keyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, errors.NotValidf("private key (no PEM data found)")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		return key, errors.Annotate(err, "cannot parse private key")
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot parse private key")
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.NotSupportedf("private key type %T", key)
+		}
+		return rsaKey, nil
+	}
+	return nil, errors.NotSupportedf("private key PEM block %q", block.Type)
+}
@@ -0,0 +1,182 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"runtime"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type encryptionSuite struct {
+	LegacySuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+func (s *encryptionSuite) create(c *gc.C, key *backups.ArchiveKey) (*os.File, string, []tarContent) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently does not work on windows, see comments inside backups.create function")
+	}
+	meta := backupstesting.NewMetadataStarted()
+	metadataFile, err := meta.AsJSONBuffer()
+	c.Assert(err, jc.ErrorIsNil)
+	_, testFiles, expected := s.createTestFiles(c)
+
+	args := backups.NewTestCreateArgs(testFiles, &TestDBDumper{}, metadataFile)
+	backups.SetTestCreateKey(args, key)
+	result, err := backups.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile, _, checksum := backups.ExposeCreateResult(result)
+	s.AddCleanup(func(*gc.C) { archiveFile.Close() })
+	return archiveFile.(*os.File), checksum, expected
+}
+
+func (s *encryptionSuite) decrypt(c *gc.C, archive *os.File, key backups.ArchiveKey) (*os.File, error) {
+	plain, err := ioutil.TempFile(c.MkDir(), "plain")
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { plain.Close() })
+	if err := backups.DecryptArchive(plain, archive, key); err != nil {
+		return nil, err
+	}
+	_, err = plain.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	return plain, nil
+}
+
+func (s *encryptionSuite) TestPlainArchiveNotEncrypted(c *gc.C) {
+	archive, _, _ := s.create(c, nil)
+
+	encrypted, err := backups.IsEncryptedArchive(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsFalse)
+}
+
+func (s *encryptionSuite) TestPassphrase(c *gc.C) {
+	key := &backups.ArchiveKey{Passphrase: "sekrit"}
+	archive, checksum, expected := s.create(c, key)
+	s.checkChecksum(c, archive, checksum)
+
+	encrypted, err := backups.IsEncryptedArchive(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+
+	plain, err := s.decrypt(c, archive, *key)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkArchive(c, plain, expected)
+}
+
+func (s *encryptionSuite) TestWrongPassphrase(c *gc.C) {
+	archive, _, _ := s.create(c, &backups.ArchiveKey{Passphrase: "sekrit"})
+
+	_, err := s.decrypt(c, archive, backups.ArchiveKey{Passphrase: "guess"})
+	c.Check(err, gc.ErrorMatches, "backup archive failed integrity check; wrong passphrase or corrupted archive")
+}
+
+func (s *encryptionSuite) TestPublicKey(c *gc.C) {
+	archive, _, expected := s.create(c, &backups.ArchiveKey{PublicKey: testing.CACert})
+
+	_, err := s.decrypt(c, archive, backups.ArchiveKey{PrivateKey: testing.OtherCAKey})
+	c.Check(err, gc.ErrorMatches, "cannot unwrap archive key; wrong private key\\?")
+	_, err = archive.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plain, err := s.decrypt(c, archive, backups.ArchiveKey{PrivateKey: testing.CAKey})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkArchive(c, plain, expected)
+}
+
+func (s *encryptionSuite) TestTampered(c *gc.C) {
+	key := backups.ArchiveKey{Passphrase: "sekrit"}
+	archive, _, _ := s.create(c, &key)
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	data[len(data)/2] ^= 0xff
+
+	var out bytes.Buffer
+	err = backups.DecryptArchive(&out, bytes.NewReader(data), key)
+	c.Check(err, gc.ErrorMatches, "backup archive failed integrity check; .*")
+}
+
+func (s *encryptionSuite) TestInvalidKey(c *gc.C) {
+	var out bytes.Buffer
+	err := backups.DecryptArchive(&out, bytes.NewReader(nil), backups.ArchiveKey{})
+	c.Check(err, gc.ErrorMatches, "empty archive key not valid")
+
+	key := backups.ArchiveKey{Passphrase: "sekrit", PublicKey: testing.CACert}
+	c.Check(key.Validate(), gc.ErrorMatches, "archive key with both passphrase and RSA key not valid")
+}
+
+func (s *encryptionSuite) TestVerifyArchive(c *gc.C) {
+	key := &backups.ArchiveKey{Passphrase: "sekrit"}
+	archive, checksum, _ := s.create(c, key)
+	meta := backupstesting.NewMetadataStarted()
+	meta.Encryption = backups.EncryptionPassphrase
+
+	c.Assert(meta.SetFileInfo(0, "bogus", "SHA-1, base64 encoded"), jc.ErrorIsNil)
+	_, err := backups.VerifyArchive(meta, archive, key)
+	c.Check(err, gc.ErrorMatches, `backup archive checksum mismatch: expected "bogus", got .*`)
+
+	meta = backupstesting.NewMetadataStarted()
+	meta.Encryption = backups.EncryptionPassphrase
+	c.Assert(meta.SetFileInfo(0, checksum, "SHA-1, base64 encoded"), jc.ErrorIsNil)
+	_, err = backups.VerifyArchive(meta, archive, nil)
+	c.Check(err, gc.ErrorMatches, `backup archive is encrypted \(passphrase\); a key is needed to restore it`)
+
+	_, err = archive.Seek(0, os.SEEK_SET)
+	c.Assert(err, jc.ErrorIsNil)
+	plain, err := backups.VerifyArchive(meta, archive, key)
+	c.Assert(err, jc.ErrorIsNil)
+	defer plain.Close()
+
+	ws, err := backups.NewArchiveWorkspaceReader(plain)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ws.Close()
+	c.Check(ws.VerifyManifest(testing.CACert), jc.ErrorIsNil)
+}
+
+func (s *encryptionSuite) TestVerifyManifest(c *gc.C) {
+	archive, _, _ := s.create(c, nil)
+	ws, err := backups.NewArchiveWorkspaceReader(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ws.Close()
+
+	c.Check(ws.VerifyManifest(testing.CACert), jc.ErrorIsNil)
+	err = ws.VerifyManifest(testing.OtherCACert)
+	c.Check(err, gc.ErrorMatches, "backup archive manifest was not signed by this controller's CA")
+
+	err = ioutil.WriteFile(ws.MetadataFile, []byte("{}"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ws.VerifyManifest(testing.CACert)
+	c.Check(err, gc.ErrorMatches, `backup archive does not match its manifest: \[metadata.json\]`)
+
+	c.Assert(os.Remove(ws.ManifestSignatureFile), jc.ErrorIsNil)
+	err = ws.VerifyManifest(testing.CACert)
+	c.Check(err, gc.ErrorMatches, "backup archive manifest is not signed")
+}
+
+func (s *encryptionSuite) TestVerifyLegacyArchive(c *gc.C) {
+	// Backups made before checksums and manifests were recorded are
+	// restored without those checks.
+	meta := backupstesting.NewMetadataStarted()
+	archive, err := backupstesting.NewArchiveBasic(meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	plain, err := backups.VerifyArchive(meta, archive, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer plain.Close()
+
+	ws, err := backups.NewArchiveWorkspaceReader(plain)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ws.Close()
+	c.Check(ws.VerifyManifest(testing.CACert), jc.ErrorIsNil)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/utils/filestorage"
 
 	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 var (
@@ -94,16 +95,36 @@ func ExposeCreateResult(result *createResult) (io.ReadCloser, int64, string) {
 	return result.archiveFile, result.size, result.checksum
 }
 
-// NewTestCreateArgs builds a new args value for create() calls.
+// NewTestCreateArgs builds a new args value for create() calls. The
+// manifest is signed with the testing CA key.
 func NewTestCreateArgs(filesToBackUp []string, db DBDumper, metar io.Reader) *createArgs {
 	args := createArgs{
 		filesToBackUp:  filesToBackUp,
 		db:             db,
 		metadataReader: metar,
+		caCert:         coretesting.CACert,
+		caKey:          coretesting.CAKey,
 	}
 	return &args
 }
 
+// SetTestCreateKey sets the key used to encrypt the archive in the
+// create() args.
+func SetTestCreateKey(args *createArgs, key *ArchiveKey) {
+	args.key = key
+}
+
+// ExposeCreateArgsKey extracts the archive key from a create() args
+// value.
+func ExposeCreateArgsKey(args *createArgs) *ArchiveKey {
+	return args.key
+}
+
+// VerifyArchive exposes verifyArchive.
+func VerifyArchive(meta *Metadata, archive io.Reader, key *ArchiveKey) (io.ReadCloser, error) {
+	return verifyArchive(meta, archive, key)
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) ([]string, DBDumper) {
 	return args.filesToBackUp, args.db
@@ -167,3 +188,6 @@ func NewTestArchiveStorer(failure string) func(filestorage.FileStorage, *Metadat
 
 // Export for patching in tests
 var RestorePath = &getMongorestorePath
+
+// LoadModelDump exposes loadModelDump.
+var LoadModelDump = loadModelDump
@@ -0,0 +1,161 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cert"
+)
+
+// Manifest lists the SHA-256 hash of every file in a backup archive's
+// content directory. The manifest is signed with the controller's CA
+// key, so a restore can check that the archive was produced by the
+// controller and has not been altered since.
+type Manifest struct {
+	// Files maps the slash-separated path of each file, relative to
+	// the content directory, to its hex-encoded SHA-256 hash.
+	Files map[string]string `json:"files"`
+}
+
+// buildManifest hashes every file under the content directory, except
+// for the manifest and its signature.
+func buildManifest(paths ArchivePaths) (*Manifest, error) {
+	manifest := Manifest{Files: make(map[string]string)}
+	err := filepath.Walk(paths.ContentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if info.IsDir() || path == paths.ManifestFile || path == paths.ManifestSignatureFile {
+			return nil
+		}
+		rel, err := filepath.Rel(paths.ContentDir, path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		manifest.Files[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "while building manifest")
+	}
+	return &manifest, nil
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// writeSignedManifest writes the manifest for the content directory,
+// along with its signature by the given CA key.
+func writeSignedManifest(paths ArchivePaths, caCert, caKey string) error {
+	_, key, err := cert.ParseCertAndKey(caCert, caKey)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse CA certificate and key")
+	}
+	manifest, err := buildManifest(paths)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	digest := sha256.Sum256(data)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return errors.Annotate(err, "while signing manifest")
+	}
+	if err := ioutil.WriteFile(paths.ManifestFile, data, 0600); err != nil {
+		return errors.Annotate(err, "while writing manifest")
+	}
+	if err := ioutil.WriteFile(paths.ManifestSignatureFile, sig, 0600); err != nil {
+		return errors.Annotate(err, "while writing manifest signature")
+	}
+	return nil
+}
+
+// VerifyManifest checks that the manifest in the unpacked archive was
+// signed by the given CA certificate, and that the files in the
+// archive are exactly those listed in the manifest, unchanged. Backups
+// made before manifests were introduced have none, and are accepted
+// with a warning.
+func (ws *ArchiveWorkspace) VerifyManifest(caCert string) error {
+	data, err := ioutil.ReadFile(ws.ManifestFile)
+	if os.IsNotExist(err) {
+		logger.Warningf("backup archive has no manifest; assuming it is a legacy backup and skipping its verification")
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "while reading manifest")
+	}
+	sig, err := ioutil.ReadFile(ws.ManifestSignatureFile)
+	if os.IsNotExist(err) {
+		return errors.New("backup archive manifest is not signed")
+	} else if err != nil {
+		return errors.Annotate(err, "while reading manifest signature")
+	}
+
+	ca, err := cert.ParseCert(caCert)
+	if err != nil {
+		return errors.Annotate(err, "cannot parse CA certificate")
+	}
+	pub, ok := ca.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.NotSupportedf("CA public key type %T", ca.PublicKey)
+	}
+	digest := sha256.Sum256(data)
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+		return errors.New("backup archive manifest was not signed by this controller's CA")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return errors.Annotate(err, "while reading manifest")
+	}
+	actual, err := buildManifest(ws.ArchivePaths)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var mismatched []string
+	for name, sum := range manifest.Files {
+		if actual.Files[name] != sum {
+			mismatched = append(mismatched, name)
+		}
+	}
+	for name := range actual.Files {
+		if _, ok := manifest.Files[name]; !ok {
+			mismatched = append(mismatched, name)
+		}
+	}
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		return errors.Errorf("backup archive does not match its manifest: %v", mismatched)
+	}
+	return nil
+}
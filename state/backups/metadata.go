@@ -67,6 +67,20 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled is true for backups taken on a schedule by the
+	// controller. Only these are subject to a RetentionPolicy.
+	Scheduled bool
+
+	// Encryption is the method used to encrypt the archive (see
+	// EncryptionPassphrase and EncryptionPublicKey). It is empty if
+	// the archive is not encrypted.
+	Encryption string
+
+	// StorageTarget is the name of the backups storage target holding
+	// the archive (see controller.BackupsStorage). It is only known for
+	// stored backups.
+	StorageTarget string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -161,6 +175,7 @@ type flatMetadata struct {
 	Started     time.Time
 	Finished    time.Time
 	Notes       string
+	Scheduled   bool
 	Environment string
 	Machine     string
 	Hostname    string
@@ -184,6 +199,7 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 
 		Started:      m.Started,
 		Notes:        m.Notes,
+		Scheduled:    m.Scheduled,
 		Environment:  m.Origin.Model,
 		Machine:      m.Origin.Machine,
 		Hostname:     m.Origin.Hostname,
@@ -233,6 +249,7 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Scheduled = flat.Scheduled
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
@@ -0,0 +1,239 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/description"
+)
+
+const (
+	// dumpedJujuDB is the name of the juju state database in the
+	// archive's database dump.
+	dumpedJujuDB = "juju"
+
+	// modelDBPrefix prefixes the names of the temporary databases
+	// that models are loaded into from backup archives.
+	modelDBPrefix = "juju-backup-model-"
+
+	// maxDumpDocSize limits the size of a single document we are
+	// willing to read from a database dump.
+	maxDumpDocSize = 48 * 1024 * 1024
+
+	// dumpInsertBatch is the number of documents inserted at once
+	// when loading a database dump.
+	dumpInsertBatch = 500
+)
+
+// ModelExporter exports a model from a copy of the juju database held
+// in a differently named database. It is satisfied by *state.State.
+type ModelExporter interface {
+	// MongoSession returns the session used to load the copy.
+	MongoSession() *mgo.Session
+
+	// ExportFromDatabase exports the model from the named database.
+	ExportFromDatabase(dbName string, modelTag names.ModelTag) (description.Model, error)
+}
+
+// ExportModelArgs holds the arguments to Backups.ExportModel.
+type ExportModelArgs struct {
+	// ModelTag identifies the model to extract from the backup.
+	ModelTag names.ModelTag
+
+	// CACert is the controller's CA certificate. The archive's
+	// manifest must be signed with the matching key.
+	CACert string
+
+	// Key is used to decrypt an encrypted archive.
+	Key *ArchiveKey
+
+	// Exporter is used to export the model once its documents have
+	// been loaded from the backup.
+	Exporter ModelExporter
+}
+
+// ExportModel extracts a single model from the stored backup. The
+// model's documents, along with the controller-wide documents they
+// refer to, are loaded from the archive's database dump into a
+// temporary database, from which the model is exported. The temporary
+// database is dropped before returning.
+func (b *backups) ExportModel(backupId string, args ExportModelArgs) (description.Model, error) {
+	if args.Exporter == nil {
+		return nil, errors.NotValidf("nil Exporter")
+	}
+	meta, backupReader, err := b.Get(backupId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "could not fetch backup %q", backupId)
+	}
+	defer backupReader.Close()
+
+	archive, err := verifyArchive(meta, backupReader, args.Key)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot verify backup %q", backupId)
+	}
+	defer archive.Close()
+
+	workspace, err := NewArchiveWorkspaceReader(archive)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unpack backup file")
+	}
+	defer workspace.Close()
+	if err := workspace.VerifyManifest(args.CACert); err != nil {
+		return nil, errors.Annotatef(err, "cannot verify backup %q", backupId)
+	}
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbName := modelDBPrefix + uuid.String()
+	session := args.Exporter.MongoSession().Copy()
+	defer session.Close()
+	db := session.DB(dbName)
+	defer func() {
+		if err := db.DropDatabase(); err != nil {
+			logger.Errorf("cannot drop temporary database %q: %v", dbName, err)
+		}
+	}()
+
+	dumpDir := filepath.Join(workspace.DBDumpDir, dumpedJujuDB)
+	if err := loadModelDump(dumpDir, args.ModelTag.Id(), db); err != nil {
+		return nil, errors.Annotate(err, "cannot load model from backup")
+	}
+	model, err := args.Exporter.ExportFromDatabase(dbName, args.ModelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return model, nil
+}
+
+// loadModelDump loads the model's documents from the dumped juju
+// database in dumpDir into db. Documents in collections shared by all
+// models are only loaded if they belong to the model; documents in
+// controller-wide collections are all loaded, except for the
+// transaction log, which is never needed to export a model.
+func loadModelDump(dumpDir, modelUUID string, db *mgo.Database) error {
+	infos, err := ioutil.ReadDir(dumpDir)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("juju database in backup")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, ".bson") {
+			continue
+		}
+		collName := strings.TrimSuffix(name, ".bson")
+		if strings.HasPrefix(collName, "system.") || strings.HasPrefix(collName, "txns") {
+			continue
+		}
+		if _, err := loadCollectionDump(filepath.Join(dumpDir, name), modelUUID, db.C(collName)); err != nil {
+			return errors.Annotatef(err, "collection %q", collName)
+		}
+	}
+	n, err := db.C("models").FindId(modelUUID).Count()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n == 0 {
+		return errors.NotFoundf("model %q in backup", modelUUID)
+	}
+	return nil
+}
+
+// loadCollectionDump inserts the documents in the mongodump file that
+// belong to the model, or to no model, into the collection. It returns
+// the number of documents inserted.
+func loadCollectionDump(filename, modelUUID string, coll *mgo.Collection) (int, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	count := 0
+	var batch []interface{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := coll.Insert(batch...); err != nil {
+			return errors.Trace(err)
+		}
+		count += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		doc, err := readDumpDoc(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return count, errors.Trace(err)
+		}
+		if uuid, ok := docModelUUID(doc); ok && uuid != modelUUID {
+			continue
+		}
+		batch = append(batch, doc)
+		if len(batch) >= dumpInsertBatch {
+			if err := flush(); err != nil {
+				return count, errors.Trace(err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return count, errors.Trace(err)
+	}
+	return count, nil
+}
+
+// readDumpDoc reads the next document from a mongodump file, which is
+// a sequence of BSON documents, each starting with its length.
+func readDumpDoc(r io.Reader) (bson.D, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, errors.Annotate(err, "truncated database dump")
+	}
+	size := int(binary.LittleEndian.Uint32(header))
+	if size < 5 || size > maxDumpDocSize {
+		return nil, errors.Errorf("invalid document size %d in database dump", size)
+	}
+	data := make([]byte, size)
+	copy(data, header)
+	if _, err := io.ReadFull(r, data[4:]); err != nil {
+		return nil, errors.Annotate(err, "truncated database dump")
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "invalid document in database dump")
+	}
+	return doc, nil
+}
+
+func docModelUUID(doc bson.D) (string, bool) {
+	for _, elem := range doc {
+		if elem.Name == "model-uuid" {
+			uuid, ok := elem.Value.(string)
+			return uuid, ok
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,113 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type modelDumpSuite struct {
+	gitjujutesting.MgoSuite
+	testing.BaseSuite
+	dumpDir string
+}
+
+var _ = gc.Suite(&modelDumpSuite{})
+
+func (s *modelDumpSuite) SetUpSuite(c *gc.C) {
+	s.BaseSuite.SetUpSuite(c)
+	s.MgoSuite.SetUpSuite(c)
+}
+
+func (s *modelDumpSuite) TearDownSuite(c *gc.C) {
+	s.MgoSuite.TearDownSuite(c)
+	s.BaseSuite.TearDownSuite(c)
+}
+
+func (s *modelDumpSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.MgoSuite.SetUpTest(c)
+	s.dumpDir = filepath.Join(c.MkDir(), "juju")
+	err := os.Mkdir(s.dumpDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelDumpSuite) TearDownTest(c *gc.C) {
+	s.MgoSuite.TearDownTest(c)
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *modelDumpSuite) writeDump(c *gc.C, collection string, docs ...bson.M) {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	err := ioutil.WriteFile(filepath.Join(s.dumpDir, collection+".bson"), buf.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *modelDumpSuite) TestLoadModelDump(c *gc.C) {
+	s.writeDump(c, "models",
+		bson.M{"_id": "uuid-1", "name": "one"},
+		bson.M{"_id": "uuid-2", "name": "two"},
+	)
+	s.writeDump(c, "machines",
+		bson.M{"_id": "uuid-1:0", "model-uuid": "uuid-1", "machineid": "0"},
+		bson.M{"_id": "uuid-2:0", "model-uuid": "uuid-2", "machineid": "0"},
+		bson.M{"_id": "uuid-1:1", "model-uuid": "uuid-1", "machineid": "1"},
+	)
+	s.writeDump(c, "txns", bson.M{"_id": "txn"})
+	s.writeDump(c, "system.indexes", bson.M{"_id": "index"})
+
+	db := s.Session.DB("juju-backup-model-test")
+	err := backups.LoadModelDump(s.dumpDir, "uuid-1", db)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Controller-wide documents are all loaded.
+	n, err := db.C("models").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(n, gc.Equals, 2)
+
+	// Only the model's own documents are loaded from shared collections.
+	var machines []bson.M
+	err = db.C("machines").Find(nil).Sort("_id").All(&machines)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+	c.Check(machines[0]["_id"], gc.Equals, "uuid-1:0")
+	c.Check(machines[1]["_id"], gc.Equals, "uuid-1:1")
+
+	names, err := db.CollectionNames()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.Not(jc.Contains), "txns")
+}
+
+func (s *modelDumpSuite) TestLoadModelDumpModelNotFound(c *gc.C) {
+	s.writeDump(c, "models", bson.M{"_id": "uuid-2", "name": "two"})
+
+	err := backups.LoadModelDump(s.dumpDir, "uuid-1", s.Session.DB("juju-backup-model-test"))
+	c.Check(err, gc.ErrorMatches, `model "uuid-1" in backup not found`)
+}
+
+func (s *modelDumpSuite) TestLoadModelDumpTruncated(c *gc.C) {
+	data, err := bson.Marshal(bson.M{"_id": "uuid-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(s.dumpDir, "models.bson"), data[:len(data)-2], 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = backups.LoadModelDump(s.dumpDir, "uuid-1", s.Session.DB("juju-backup-model-test"))
+	c.Check(err, gc.ErrorMatches, `collection "models": truncated database dump: .*`)
+}
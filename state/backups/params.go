@@ -16,4 +16,11 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// CACert is the controller's CA certificate. The archive's
+	// manifest must be signed with the matching key.
+	CACert string
+
+	// Key is used to decrypt an encrypted archive.
+	Key *ArchiveKey
 }
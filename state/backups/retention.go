@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"sort"
+)
+
+// ScheduledNotes is the annotation of backups taken on a schedule by the
+// controller. It is only informative: whether a backup is subject to a
+// RetentionPolicy depends on Metadata.Scheduled, so backups created by
+// users are never removed by it, whatever their notes.
+const ScheduledNotes = "scheduled backup"
+
+// RetentionPolicy describes which scheduled backups to keep. A backup is
+// kept when any of the rules selects it. The zero value keeps everything.
+type RetentionPolicy struct {
+	// KeepLast is the number of most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days, counting back from the most
+	// recent backup, for which the last backup of each day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of ISO weeks, counting back from the most
+	// recent backup, for which the last backup of each week is kept.
+	KeepWeekly int
+}
+
+// IsZero reports whether the policy has no rules, i.e. keeps everything.
+func (p RetentionPolicy) IsZero() bool {
+	return p.KeepLast <= 0 && p.KeepDaily <= 0 && p.KeepWeekly <= 0
+}
+
+// Expired returns the IDs of the scheduled backups in metaList which are
+// not kept by the policy, oldest first. Days and weeks are in UTC.
+func (p RetentionPolicy) Expired(metaList []*Metadata) []string {
+	if p.IsZero() {
+		return nil
+	}
+
+	var scheduled []*Metadata
+	for _, meta := range metaList {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Sort(byStartedDesc(scheduled))
+
+	kept := make(map[string]bool)
+	for i := 0; i < p.KeepLast && i < len(scheduled); i++ {
+		kept[scheduled[i].ID()] = true
+	}
+	keepLastInPeriod(scheduled, p.KeepDaily, kept, func(meta *Metadata) interface{} {
+		year, month, day := meta.Started.UTC().Date()
+		return [3]int{year, int(month), day}
+	})
+	keepLastInPeriod(scheduled, p.KeepWeekly, kept, func(meta *Metadata) interface{} {
+		year, week := meta.Started.UTC().ISOWeek()
+		return [2]int{year, week}
+	})
+
+	var expired []string
+	for i := len(scheduled) - 1; i >= 0; i-- {
+		if id := scheduled[i].ID(); !kept[id] {
+			expired = append(expired, id)
+		}
+	}
+	return expired
+}
+
+// keepLastInPeriod marks as kept the most recent backup of each of the
+// count most recent periods, as returned by period. The given backups must
+// be sorted with the most recent first.
+func keepLastInPeriod(sorted []*Metadata, count int, kept map[string]bool, period func(*Metadata) interface{}) {
+	seen := make(map[interface{}]bool)
+	for _, meta := range sorted {
+		if len(seen) >= count {
+			return
+		}
+		key := period(meta)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept[meta.ID()] = true
+	}
+}
+
+type byStartedDesc []*Metadata
+
+func (b byStartedDesc) Len() int           { return len(b) }
+func (b byStartedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStartedDesc) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
@@ -0,0 +1,71 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type retentionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+func newRetentionMetadata(id string, scheduled bool, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Notes = backups.ScheduledNotes
+	meta.Scheduled = scheduled
+	meta.Started = started
+	return meta
+}
+
+func (s *retentionSuite) metaList() []*backups.Metadata {
+	at := func(day, hour int) time.Time {
+		return time.Date(2016, time.August, day, hour, 0, 0, 0, time.UTC)
+	}
+	// Days 1 and 3 are in ISO week 31, day 8 is in week 32. The manual
+	// backup has the notes of a scheduled one, but isn't scheduled.
+	return []*backups.Metadata{
+		newRetentionMetadata("b4", true, at(8, 1)),
+		newRetentionMetadata("manual", false, at(1, 0)),
+		newRetentionMetadata("b1", true, at(1, 1)),
+		newRetentionMetadata("b5", true, at(8, 13)),
+		newRetentionMetadata("b2", true, at(1, 13)),
+		newRetentionMetadata("b3", true, at(3, 13)),
+	}
+}
+
+func (s *retentionSuite) TestZeroPolicyKeepsEverything(c *gc.C) {
+	policy := backups.RetentionPolicy{}
+	c.Check(policy.IsZero(), jc.IsTrue)
+	c.Check(policy.Expired(s.metaList()), gc.HasLen, 0)
+}
+
+func (s *retentionSuite) TestKeepLast(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepLast: 2}
+	c.Check(policy.Expired(s.metaList()), jc.DeepEquals, []string{"b1", "b2", "b3"})
+}
+
+func (s *retentionSuite) TestKeepDaily(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepDaily: 2}
+	c.Check(policy.Expired(s.metaList()), jc.DeepEquals, []string{"b1", "b2", "b4"})
+}
+
+func (s *retentionSuite) TestKeepWeekly(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepWeekly: 5}
+	c.Check(policy.Expired(s.metaList()), jc.DeepEquals, []string{"b1", "b2", "b4"})
+}
+
+func (s *retentionSuite) TestCombinedRules(c *gc.C) {
+	policy := backups.RetentionPolicy{KeepLast: 2, KeepDaily: 3, KeepWeekly: 1}
+	c.Check(policy.Expired(s.metaList()), jc.DeepEquals, []string{"b1"})
+}
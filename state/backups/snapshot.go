@@ -0,0 +1,72 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package backups
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+)
+
+// SnapshotDatabase dumps the juju databases into the given directory,
+// replacing any earlier snapshot there, so that they can be put back
+// with RestoreSnapshot. It is used to roll back failed upgrades.
+func SnapshotDatabase(dir string, mgoInfo *mongo.MongoInfo, session DBSession) error {
+	dbInfo, err := NewDBInfo(mgoInfo, session)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	dumper, err := NewDBDumper(dbInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return errors.Annotate(err, "cannot remove old snapshot")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Annotate(err, "cannot create snapshot directory")
+	}
+	if err := dumper.Dump(dir); err != nil {
+		return errors.Annotate(err, "cannot snapshot database")
+	}
+	return nil
+}
+
+// RestoreSnapshot replaces the contents of the juju databases with the
+// snapshot taken by SnapshotDatabase into the given directory. It must
+// be called on the controller that holds the snapshot, whose agent
+// config provides the credentials used to reach mongo.
+func RestoreSnapshot(dir string, agentConfig agent.Config) error {
+	dialInfo, err := newDialInfo("localhost", agentConfig)
+	if err != nil {
+		return errors.Annotate(err, "cannot produce dial information")
+	}
+	tagUser, tagUserPassword, err := tagUserCredentials(agentConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	restorer, err := NewDBRestorer(RestorerArgs{
+		DialInfo:        dialInfo,
+		Version:         agentConfig.MongoVersion(),
+		TagUser:         tagUser,
+		TagUserPassword: tagUserPassword,
+		RunCommandFn:    runCommandFn,
+		StartMongo:      mongo.StartService,
+		StopMongo:       mongo.StopService,
+		NewMongoSession: NewMongoSession,
+		GetDB:           GetDB,
+	})
+	if err != nil {
+		return errors.Annotate(err, "error preparing for restore")
+	}
+	if err := restorer.Restore(dir, dialInfo); err != nil {
+		return errors.Annotate(err, "cannot restore database snapshot")
+	}
+	return nil
+}
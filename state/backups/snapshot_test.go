@@ -0,0 +1,66 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type snapshotSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) TestSnapshotDatabase(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "snapshot")
+	err := os.MkdirAll(dir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "stale"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchValue(backups.GetMongodumpPath, func() (string, error) {
+		return "bogusmongodump", nil
+	})
+	var ranArgs []string
+	s.PatchValue(backups.RunCommand, func(cmd string, args ...string) error {
+		ranArgs = args
+		for _, db := range []string{"juju", "presence"} {
+			if err := os.Mkdir(filepath.Join(dir, db), 0700); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	mgoInfo := &mongo.MongoInfo{
+		Info: mongo.Info{
+			Addrs: []string{"localhost:37017"},
+		},
+		Tag:      names.NewMachineTag("0"),
+		Password: "eggs",
+	}
+	session := &fakeSession{dbNames: []string{"juju", "presence"}}
+	err = backups.SnapshotDatabase(dir, mgoInfo, session)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(strings.Join(ranArgs, " "), jc.Contains, "--out "+dir)
+
+	entries, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Check(entries[0].Name(), gc.Equals, "juju")
+}
@@ -0,0 +1,23 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+)
+
+// SnapshotDatabase is not supported on windows, which does not run
+// controllers.
+func SnapshotDatabase(dir string, mgoInfo *mongo.MongoInfo, session DBSession) error {
+	return errors.NotSupportedf("database snapshots on windows")
+}
+
+// RestoreSnapshot is not supported on windows, which does not run
+// controllers.
+func RestoreSnapshot(dir string, agentConfig agent.Config) error {
+	return errors.NotSupportedf("database snapshots on windows")
+}
@@ -43,9 +43,11 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started    int64  `bson:"started,minsize"`
+	Finished   int64  `bson:"finished,minsize"`
+	Notes      string `bson:"notes,omitempty"`
+	Scheduled  bool   `bson:"scheduled,omitempty"`
+	Encryption string `bson:"encryption,omitempty"`
 
 	// origin
 
@@ -54,6 +56,14 @@ type storageMetaDoc struct {
 	Hostname string         `bson:"hostname"`
 	Version  version.Number `bson:"version"`
 	Series   string         `bson:"series"`
+
+	// Target is the name of the backups storage target holding the
+	// archive. It is empty for archives in the mongo blobstore.
+	Target string `bson:"target,omitempty"`
+
+	// TargetLocation records where the archive is in a non-mongo
+	// target.
+	TargetLocation *archiveLocation `bson:"target-location,omitempty"`
 }
 
 func (doc *storageMetaDoc) isFileInfoComplete() bool {
@@ -123,6 +133,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
+	meta.Encryption = doc.Encryption
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -130,6 +142,11 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta.Origin.Version = doc.Version
 	meta.Origin.Series = doc.Series
 
+	meta.StorageTarget = doc.Target
+	if meta.StorageTarget == "" {
+		meta.StorageTarget = controller.BackupsStorageMongo
+	}
+
 	meta.SetID(doc.ID)
 
 	if doc.Finished != 0 {
@@ -174,6 +191,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
+	doc.Encryption = meta.Encryption
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -549,14 +568,19 @@ type DB interface {
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
+// archives (and metadata). The metadata is always stored in mongo, while
+// new archives are stored in the target set by the backups-storage
+// controller setting.
 func NewStorage(st DB) filestorage.FileStorage {
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
+	archives := newArchiveStorage(dbWrap, st)
 	docs := newMetadataStorage(dbWrap)
-	return filestorage.NewFileStorage(docs, files)
+	return &backupsStorage{
+		FileStorage: filestorage.NewFileStorage(docs, archives),
+		archives:    archives,
+	}
 }
@@ -0,0 +1,361 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
+)
+
+//---------------------------
+// archive storage targets
+
+// archiveStorage is a filestorage.RawFileStorage which stores new backup
+// archives in the target set by the backups-storage controller setting,
+// and reads or removes each archive from the target and location recorded
+// in its metadata. This way archives stored before the settings changed
+// remain available.
+type archiveStorage struct {
+	dbWrap *storageDBWrapper
+	mongo  filestorage.RawFileStorage
+
+	// controllerConfig holds the settings of the non-mongo targets, as
+	// read when the storage was created. If reading them failed,
+	// controllerConfigErr is returned when a non-mongo target is used.
+	controllerConfig    controller.Config
+	controllerConfigErr error
+
+	mu sync.Mutex
+	// knownTargets caches the target of each archive looked up, so it can
+	// still be removed after its metadata is gone.
+	knownTargets map[string]archiveTarget
+}
+
+// archiveTarget identifies where an archive is stored.
+type archiveTarget struct {
+	name     string
+	location *archiveLocation
+}
+
+// archiveLocation records the settings of a non-mongo target that locate
+// an archive, as they were when the archive was stored. The credentials
+// of the s3 target are not recorded; the current ones are used.
+type archiveLocation struct {
+	Dir        string `bson:"dir,omitempty"`
+	S3Endpoint string `bson:"s3-endpoint,omitempty"`
+	S3Region   string `bson:"s3-region,omitempty"`
+	S3Bucket   string `bson:"s3-bucket,omitempty"`
+}
+
+func newArchiveStorage(dbWrap *storageDBWrapper, st DB) *archiveStorage {
+	controllerConfig, err := st.ControllerConfig()
+	return &archiveStorage{
+		dbWrap:              dbWrap.Copy(),
+		mongo:               newFileStorage(dbWrap, backupStorageRoot),
+		controllerConfig:    controllerConfig,
+		controllerConfigErr: errors.Annotate(err, "cannot get backups storage settings"),
+		knownTargets:        make(map[string]archiveTarget),
+	}
+}
+
+// currentTarget returns the target new archives are stored in, located
+// by the current settings.
+func (s *archiveStorage) currentTarget() archiveTarget {
+	if s.controllerConfigErr != nil {
+		return archiveTarget{name: controller.BackupsStorageMongo}
+	}
+	switch name := s.controllerConfig.BackupsStorage(); name {
+	case controller.BackupsStorageLocal:
+		return archiveTarget{name, &archiveLocation{
+			Dir: s.controllerConfig.BackupsStorageDir(),
+		}}
+	case controller.BackupsStorageS3:
+		cfg := s.controllerConfig.BackupsS3Config()
+		return archiveTarget{name, &archiveLocation{
+			S3Endpoint: cfg.Endpoint,
+			S3Region:   cfg.Region,
+			S3Bucket:   cfg.Bucket,
+		}}
+	default:
+		return archiveTarget{name: name}
+	}
+}
+
+// storage returns the raw storage of the given target. Archives stored
+// before their location was recorded are located by the current
+// settings of their target.
+func (s *archiveStorage) storage(target archiveTarget) (filestorage.RawFileStorage, error) {
+	if target.name == "" || target.name == controller.BackupsStorageMongo {
+		return s.mongo, nil
+	}
+	if s.controllerConfigErr != nil {
+		return nil, s.controllerConfigErr
+	}
+	location := target.location
+	switch target.name {
+	case controller.BackupsStorageLocal:
+		dir := s.controllerConfig.BackupsStorageDir()
+		if location != nil {
+			dir = location.Dir
+		}
+		return newDirStorage(dir), nil
+	case controller.BackupsStorageS3:
+		cfg := s.controllerConfig.BackupsS3Config()
+		if location != nil {
+			cfg.Endpoint = location.S3Endpoint
+			cfg.Region = location.S3Region
+			cfg.Bucket = location.S3Bucket
+		}
+		return newS3Storage(cfg)
+	}
+	return nil, errors.NotValidf("backups storage %q", target.name)
+}
+
+// targetOf returns the target holding the archive with the given ID.
+func (s *archiveStorage) targetOf(id string) (archiveTarget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if target, ok := s.knownTargets[id]; ok {
+		return target, nil
+	}
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+	doc, err := getStorageMetadata(dbWrap, id)
+	if err != nil {
+		return archiveTarget{}, errors.Trace(err)
+	}
+	target := archiveTarget{doc.Target, doc.TargetLocation}
+	s.knownTargets[id] = target
+	return target, nil
+}
+
+// File returns the identified file from the target holding it.
+func (s *archiveStorage) File(id string) (io.ReadCloser, error) {
+	target, err := s.targetOf(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor, err := s.storage(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := stor.File(id)
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to the configured target, and records the target
+// and the archive's location in it in the backup's metadata.
+func (s *archiveStorage) AddFile(id string, file io.Reader, size int64) error {
+	target := s.currentTarget()
+	stor, err := s.storage(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := stor.AddFile(id, file, size); err != nil {
+		return errors.Annotatef(err, "cannot store archive in %s backups storage", target.name)
+	}
+	if target.name == controller.BackupsStorageMongo {
+		// The default target isn't recorded.
+		return nil
+	}
+
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+	op := dbWrap.txnOpUpdate(id,
+		bson.DocElem{"target", target.name},
+		bson.DocElem{"target-location", target.location},
+	)
+	if err := dbWrap.runTransaction([]txn.Op{op}); err != nil {
+		return errors.Annotate(err, "cannot record backups storage")
+	}
+	s.mu.Lock()
+	s.knownTargets[id] = target
+	s.mu.Unlock()
+	return nil
+}
+
+// RemoveFile removes the identified file from the target holding it.
+func (s *archiveStorage) RemoveFile(id string) error {
+	target, err := s.targetOf(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stor, err := s.storage(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(stor.RemoveFile(id))
+}
+
+// Close closes the storage.
+func (s *archiveStorage) Close() error {
+	s.dbWrap.Close()
+	return s.mongo.Close()
+}
+
+// backupsStorage is the filestorage.FileStorage returned by NewStorage.
+type backupsStorage struct {
+	filestorage.FileStorage
+	archives *archiveStorage
+}
+
+// Remove removes the backup's metadata and archive. The target holding
+// the archive is looked up first, as the metadata recording it may be
+// removed before the archive.
+func (s *backupsStorage) Remove(id string) error {
+	if _, err := s.archives.targetOf(id); err != nil {
+		return errors.Trace(err)
+	}
+	return s.FileStorage.Remove(id)
+}
+
+//---------------------------
+// local directory target
+
+// dirStorage stores backup archives as files in a directory of the
+// controller machine.
+type dirStorage struct {
+	dir string
+}
+
+func newDirStorage(dir string) filestorage.RawFileStorage {
+	return &dirStorage{dir: dir}
+}
+
+func (s *dirStorage) path(id string) string {
+	return filepath.Join(s.dir, id+".tar.gz")
+}
+
+// File returns the identified file from the directory.
+func (s *dirStorage) File(id string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile writes the file to the directory. The archive only appears
+// under its final name once completely written.
+func (s *dirStorage) AddFile(id string, file io.Reader, size int64) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	tempFile, err := ioutil.TempFile(s.dir, "."+id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	written, err := io.Copy(tempFile, file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if written != size {
+		return errors.Errorf("expected %d bytes, got %d", size, written)
+	}
+	if err := tempFile.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(tempFile.Name(), s.path(id)))
+}
+
+// RemoveFile removes the identified file from the directory.
+func (s *dirStorage) RemoveFile(id string) error {
+	err := os.Remove(s.path(id))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close is part of the filestorage.RawFileStorage interface.
+func (s *dirStorage) Close() error {
+	return nil
+}
+
+//---------------------------
+// S3-compatible object store target
+
+// s3Storage stores backup archives as objects in a bucket of an
+// S3-compatible object store.
+type s3Storage struct {
+	bucket *s3.Bucket
+}
+
+func newS3Storage(cfg controller.BackupsS3Config) (filestorage.RawFileStorage, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	region, ok := aws.Regions[cfg.Region]
+	if cfg.Endpoint != "" {
+		region = aws.Region{
+			Name:       cfg.Region,
+			S3Endpoint: cfg.Endpoint,
+		}
+	} else if !ok {
+		return nil, errors.NotValidf("%s %q", controller.BackupsS3Region, cfg.Region)
+	}
+	auth := aws.Auth{
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s3Storage{bucket: bucket}, nil
+}
+
+func (s *s3Storage) path(id string) string {
+	// Use of path.Join instead of filepath.Join is intentional - this
+	// is an object name not a filesystem path.
+	return path.Join(backupStorageRoot, id+".tar.gz")
+}
+
+// File returns the identified object from the bucket.
+func (s *s3Storage) File(id string) (io.ReadCloser, error) {
+	file, err := s.bucket.GetReader(s.path(id))
+	if s3err, ok := err.(*s3.Error); ok && s3err.StatusCode == 404 {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile uploads the file to the bucket, creating the bucket if needed.
+func (s *s3Storage) AddFile(id string, file io.Reader, size int64) error {
+	err := s.bucket.PutBucket(s3.Private)
+	if s3err, ok := err.(*s3.Error); ok && s3err.Code == "BucketAlreadyOwnedByYou" {
+		err = nil
+	}
+	if err != nil {
+		return errors.Annotatef(err, "cannot create bucket %q", s.bucket.Name)
+	}
+	err = s.bucket.PutReader(s.path(id), file, size, "application/x-gzip", s3.Private)
+	return errors.Trace(err)
+}
+
+// RemoveFile removes the identified object from the bucket.
+func (s *s3Storage) RemoveFile(id string) error {
+	return errors.Trace(s.bucket.Del(s.path(id)))
+}
+
+// Close is part of the filestorage.RawFileStorage interface.
+func (s *s3Storage) Close() error {
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// targetState overrides the controller config of the wrapped State, to
+// select the backups storage target.
+type targetState struct {
+	*state.State
+	config controller.Config
+}
+
+func (st *targetState) ControllerConfig() (controller.Config, error) {
+	return st.config, nil
+}
+
+const archiveData = "<compressed archive data>"
+
+func (s *storageSuite) addArchive(c *gc.C, stor backups.DB) string {
+	meta := backups.NewMetadata()
+	meta.Origin.Model = s.State.ModelUUID()
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	err := meta.MarkComplete(int64(len(archiveData)), "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+
+	storage := backups.NewStorage(stor)
+	defer storage.Close()
+	id, err := storage.Add(meta, bytes.NewBufferString(archiveData))
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *storageSuite) checkArchive(c *gc.C, stor backups.DB, id, target string) {
+	storage := backups.NewStorage(stor)
+	defer storage.Close()
+
+	doc, file, err := storage.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	c.Check(doc.(*backups.Metadata).StorageTarget, gc.Equals, target)
+	data, err := ioutil.ReadAll(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archiveData)
+}
+
+func (s *storageSuite) TestLocalStorageTarget(c *gc.C) {
+	dir := c.MkDir()
+	st := &targetState{s.State, controller.Config{
+		controller.BackupsStorage:    "local",
+		controller.BackupsStorageDir: dir,
+	}}
+
+	id := s.addArchive(c, st)
+	data, err := ioutil.ReadFile(filepath.Join(dir, id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archiveData)
+	s.checkArchive(c, st, id, "local")
+
+	storage := backups.NewStorage(st)
+	defer storage.Close()
+	err = storage.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(dir, id+".tar.gz"))
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+	_, err = storage.Metadata(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestS3StorageTarget(c *gc.C) {
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Quit()
+	st := &targetState{s.State, controller.Config{
+		controller.BackupsStorage:     "s3",
+		controller.BackupsS3Endpoint:  srv.URL(),
+		controller.BackupsS3Region:    "test",
+		controller.BackupsS3Bucket:    "juju-backups",
+		controller.BackupsS3AccessKey: "access",
+		controller.BackupsS3SecretKey: "secret",
+	}}
+
+	id := s.addArchive(c, st)
+	s.checkArchive(c, st, id, "s3")
+
+	storage := backups.NewStorage(st)
+	defer storage.Close()
+	err = storage.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = storage.Metadata(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storageSuite) TestArchivesReadFromTheirTarget(c *gc.C) {
+	mongoID := s.addArchive(c, &targetState{s.State, controller.Config{}})
+
+	st := &targetState{s.State, controller.Config{
+		controller.BackupsStorage:    "local",
+		controller.BackupsStorageDir: c.MkDir(),
+	}}
+	s.checkArchive(c, st, mongoID, "mongo")
+}
+
+func (s *storageSuite) TestArchivesReadAfterTargetChanges(c *gc.C) {
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Quit()
+	s3Config := controller.Config{
+		controller.BackupsStorage:     "s3",
+		controller.BackupsS3Endpoint:  srv.URL(),
+		controller.BackupsS3Region:    "test",
+		controller.BackupsS3Bucket:    "juju-backups",
+		controller.BackupsS3AccessKey: "access",
+		controller.BackupsS3SecretKey: "secret",
+	}
+	s3ID := s.addArchive(c, &targetState{s.State, s3Config})
+	localID := s.addArchive(c, &targetState{s.State, controller.Config{
+		controller.BackupsStorage:    "local",
+		controller.BackupsStorageDir: c.MkDir(),
+	}})
+
+	// The controller now stores archives in another directory, and
+	// the bucket has moved; the s3 credentials are kept.
+	s3Config[controller.BackupsStorage] = "local"
+	s3Config[controller.BackupsStorageDir] = c.MkDir()
+	s3Config[controller.BackupsS3Bucket] = "other-bucket"
+	st := &targetState{s.State, s3Config}
+	s.checkArchive(c, st, s3ID, "s3")
+	s.checkArchive(c, st, localID, "local")
+
+	storage := backups.NewStorage(st)
+	defer storage.Close()
+	err = storage.Remove(localID)
+	c.Assert(err, jc.ErrorIsNil)
+	err = storage.Remove(s3ID)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storageSuite) TestInvalidStorageTarget(c *gc.C) {
+	st := &targetState{s.State, controller.Config{
+		controller.BackupsStorage: "s3",
+	}}
+	meta := s.metadata(c)
+	storage := backups.NewStorage(st)
+	defer storage.Close()
+	_, err := storage.Add(meta, bytes.NewBufferString(archiveData))
+	c.Check(err, gc.ErrorMatches, "missing backups-s3-endpoint and backups-s3-region not valid")
+}
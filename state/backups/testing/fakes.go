@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/description"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/state/backups"
 )
@@ -38,6 +39,12 @@ type FakeBackups struct {
 	DBInfoArg *backups.DBInfo
 	// MetaArg holds the backup metadata that was passed in.
 	MetaArg *backups.Metadata
+	// KeyArg holds the archive key that was passed in.
+	KeyArg *backups.ArchiveKey
+	// Model holds the model description to return.
+	Model description.Model
+	// ExportModelArgs holds the ExportModel args that were passed in.
+	ExportModelArgs *backups.ExportModelArgs
 	// PrivateAddr Holds the address for the internal network of the machine.
 	PrivateAddr string
 	// InstanceId Is the id of the machine to be restored.
@@ -50,12 +57,13 @@ var _ backups.Backups = (*FakeBackups)(nil)
 
 // Create creates and stores a new juju backup archive and returns
 // its associated metadata.
-func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo) error {
+func (b *FakeBackups) Create(meta *backups.Metadata, paths *backups.Paths, dbInfo *backups.DBInfo, key *backups.ArchiveKey) error {
 	b.Calls = append(b.Calls, "Create")
 
 	b.PathsArg = paths
 	b.DBInfoArg = dbInfo
 	b.MetaArg = meta
+	b.KeyArg = key
 
 	if b.Meta != nil {
 		*meta = *b.Meta
@@ -104,6 +112,17 @@ func (b *FakeBackups) Restore(bkpId string, dbInfo *backups.DBInfo, args backups
 	return nil, errors.Trace(b.Error)
 }
 
+// ExportModel returns the model description.
+func (b *FakeBackups) ExportModel(bkpId string, args backups.ExportModelArgs) (description.Model, error) {
+	b.Calls = append(b.Calls, "ExportModel")
+	b.IDArg = bkpId
+	b.ExportModelArgs = &args
+	if b.Error != nil {
+		return nil, errors.Trace(b.Error)
+	}
+	return b.Model, nil
+}
+
 // TODO(ericsnow) FakeStorage should probably move over to the utils repo.
 
 // FakeStorage is a FileStorage implementation to use when testing
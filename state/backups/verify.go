@@ -0,0 +1,95 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto/sha1"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
+)
+
+// tempArchive is an archive file in the host's temporary directory,
+// removed when closed.
+type tempArchive struct {
+	*os.File
+}
+
+func newTempArchive() (*tempArchive, error) {
+	f, err := ioutil.TempFile("", tempPrefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while creating temporary archive file")
+	}
+	return &tempArchive{f}, nil
+}
+
+// Close closes and removes the file.
+func (t *tempArchive) Close() error {
+	t.File.Close()
+	return errors.Trace(os.Remove(t.Name()))
+}
+
+func (t *tempArchive) rewind() error {
+	_, err := t.Seek(0, os.SEEK_SET)
+	return errors.Trace(err)
+}
+
+// verifyArchive checks the stored archive against the checksum
+// recorded in its metadata and, if the archive is encrypted, decrypts
+// it with the given key. It returns the plain archive, which the
+// caller must close. Legacy backups without a recorded checksum are
+// not checked.
+func verifyArchive(meta *Metadata, archive io.Reader, key *ArchiveKey) (io.ReadCloser, error) {
+	if meta.Checksum() == "" {
+		logger.Warningf("backup %q has no checksum; assuming it is a legacy backup and skipping its verification", meta.ID())
+	} else if meta.ChecksumFormat() != checksumFormat {
+		return nil, errors.NotSupportedf("checksum format %q", meta.ChecksumFormat())
+	}
+	if meta.Encryption != "" && key == nil {
+		return nil, errors.Errorf("backup archive is encrypted (%s); a key is needed to restore it", meta.Encryption)
+	}
+
+	stored, err := newTempArchive()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := copyAndVerify(stored, archive, meta.Checksum()); err != nil {
+		stored.Close()
+		return nil, errors.Trace(err)
+	}
+	if meta.Encryption == "" {
+		return stored, nil
+	}
+	defer stored.Close()
+
+	plain, err := newTempArchive()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := DecryptArchive(plain, stored, *key); err != nil {
+		plain.Close()
+		return nil, errors.Trace(err)
+	}
+	if err := plain.rewind(); err != nil {
+		plain.Close()
+		return nil, errors.Trace(err)
+	}
+	return plain, nil
+}
+
+// copyAndVerify copies the archive into the temporary file, checking
+// it against the expected checksum, if any, and rewinds the file.
+func copyAndVerify(target *tempArchive, archive io.Reader, expected string) error {
+	hasher := hash.NewHashingWriter(target, sha1.New())
+	if _, err := io.Copy(hasher, archive); err != nil {
+		return errors.Annotate(err, "while reading backup archive")
+	}
+	if checksum := hasher.Base64Sum(); expected != "" && checksum != expected {
+		return errors.Errorf("backup archive checksum mismatch: expected %q, got %q", expected, checksum)
+	}
+	return target.rewind()
+}
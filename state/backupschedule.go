@@ -0,0 +1,90 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const scheduledBackupRunKey = "scheduledBackupRun"
+
+// ScheduledBackupRun records the outcome of the most recent scheduled
+// controller backup.
+type ScheduledBackupRun struct {
+	// Started is when the scheduled backup was started.
+	Started time.Time `bson:"started"`
+
+	// Finished is when the backup and the removal of expired backups
+	// completed, successfully or not.
+	Finished time.Time `bson:"finished"`
+
+	// BackupID is the ID of the backup created, if any.
+	BackupID string `bson:"backup-id,omitempty"`
+
+	// Removed holds the IDs of the backups removed by the retention policy.
+	Removed []string `bson:"removed,omitempty"`
+
+	// Error describes why the run failed, if it did.
+	Error string `bson:"error,omitempty"`
+}
+
+// LastScheduledBackupRun returns the outcome of the most recent scheduled
+// backup. An error satisfying errors.IsNotFound() is returned if no
+// scheduled backup has run yet.
+func (st *State) LastScheduledBackupRun() (ScheduledBackupRun, error) {
+	controllers, closer := st.getCollection(controllersC)
+	defer closer()
+
+	var run ScheduledBackupRun
+	err := controllers.FindId(scheduledBackupRunKey).One(&run)
+	if err == mgo.ErrNotFound {
+		return ScheduledBackupRun{}, errors.NotFoundf("scheduled backup run")
+	} else if err != nil {
+		return ScheduledBackupRun{}, errors.Annotate(err, "cannot get last scheduled backup run")
+	}
+	return run, nil
+}
+
+// SetLastScheduledBackupRun records the outcome of the most recent
+// scheduled backup, replacing any previous record.
+func (st *State) SetLastScheduledBackupRun(run ScheduledBackupRun) error {
+	run.Started = run.Started.UTC()
+	run.Finished = run.Finished.UTC()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := st.LastScheduledBackupRun()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     scheduledBackupRunKey,
+				Assert: txn.DocMissing,
+				Insert: run,
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     scheduledBackupRunKey,
+			Assert: txn.DocExists,
+			Update: bson.D{
+				{"$set", bson.D{
+					{"started", run.Started},
+					{"finished", run.Finished},
+					{"backup-id", run.BackupID},
+					{"removed", run.Removed},
+					{"error", run.Error},
+				}},
+			},
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set last scheduled backup run")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type backupScheduleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&backupScheduleSuite{})
+
+func (s *backupScheduleSuite) TestLastScheduledBackupRunNotFound(c *gc.C) {
+	_, err := s.State.LastScheduledBackupRun()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *backupScheduleSuite) TestSetLastScheduledBackupRun(c *gc.C) {
+	started := time.Date(2016, time.August, 8, 1, 0, 0, 0, time.UTC)
+	err := s.State.SetLastScheduledBackupRun(state.ScheduledBackupRun{
+		Started:  started,
+		Finished: started.Add(time.Minute),
+		BackupID: "backup-1",
+		Removed:  []string{"backup-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	run, err := s.State.LastScheduledBackupRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(run.Started.Equal(started), jc.IsTrue)
+	c.Check(run.Finished.Equal(started.Add(time.Minute)), jc.IsTrue)
+	c.Check(run.BackupID, gc.Equals, "backup-1")
+	c.Check(run.Removed, jc.DeepEquals, []string{"backup-0"})
+	c.Check(run.Error, gc.Equals, "")
+
+	err = s.State.SetLastScheduledBackupRun(state.ScheduledBackupRun{
+		Started:  started.Add(time.Hour),
+		Finished: started.Add(time.Hour),
+		Error:    "HA not ready",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	run, err = s.State.LastScheduledBackupRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(run.Started.Equal(started.Add(time.Hour)), jc.IsTrue)
+	c.Check(run.BackupID, gc.Equals, "")
+	c.Check(run.Removed, gc.HasLen, 0)
+	c.Check(run.Error, gc.Equals, "HA not ready")
+}
@@ -167,6 +167,31 @@ func (s *binaryStorage) AllMetadata() ([]Metadata, error) {
 	return list, nil
 }
 
+// Remove implements Storage.Remove.
+func (s *binaryStorage) Remove(version string) error {
+	var path string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := s.findMetadata(version)
+		if err != nil {
+			return nil, err
+		}
+		path = doc.Path
+		return []txn.Op{{
+			C:      s.metadataCollection.Name(),
+			Id:     doc.Id,
+			Assert: bson.D{{"path", path}},
+			Remove: true,
+		}}, nil
+	}
+	if err := s.txnRunner.Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot remove binary metadata")
+	}
+	if err := s.managedStorage.RemoveForBucket(s.modelUUID, path); err != nil {
+		return errors.Annotate(err, "cannot remove binary file")
+	}
+	return nil
+}
+
 type metadataDoc struct {
 	Id      string `bson:"_id"`
 	Version string `bson:"version"`
@@ -269,6 +269,23 @@ func (s *binaryStorageSuite) TestAddRemovesBlobOnFailureRemoveFails(c *gc.C) {
 	r.Close()
 }
 
+func (s *binaryStorageSuite) TestRemove(c *gc.C) {
+	s.testAdd(c, "abc")
+	err := s.storage.Remove(current)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storage.Metadata(current)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	path := fmt.Sprintf("tools/%s-hash(abc)", current)
+	_, _, err = s.managedStorage.GetForBucket("my-uuid", path)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *binaryStorageSuite) TestRemoveNotFound(c *gc.C) {
+	err := s.storage.Remove(current)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *binaryStorageSuite) TestAddSame(c *gc.C) {
 	metadata := binarystorage.Metadata{Version: current, Size: 1, SHA256: "0"}
 	for i := 0; i < 2; i++ {
@@ -31,6 +31,11 @@ type Storage interface {
 	// Metadata returns the Metadata for the specified version if it exists,
 	// else an error satisfying errors.IsNotFound.
 	Metadata(version string) (Metadata, error)
+
+	// Remove removes the binary file and metadata for the specified
+	// version if it exists, else returns an error satisfying
+	// errors.IsNotFound.
+	Remove(version string) error
 }
 
 // StorageCloser extends the Storage interface with a Close method.
@@ -30,6 +30,13 @@ func (s layeredStorage) Add(r io.Reader, m Metadata) error {
 	return s[0].Add(r, m)
 }
 
+// Remove implements Storage.Remove.
+//
+// This method operates on the first Storage passed to NewLayeredStorage.
+func (s layeredStorage) Remove(v string) error {
+	return s[0].Remove(v)
+}
+
 // Open implements Storage.Open.
 //
 // This method calls Open for each Storage passed to NewLayeredStorage in
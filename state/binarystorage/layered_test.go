@@ -64,6 +64,15 @@ func (s *layeredStorageSuite) TestAdd(c *gc.C) {
 	s.stores[1].CheckNoCalls(c)
 }
 
+func (s *layeredStorageSuite) TestRemove(c *gc.C) {
+	expectedErr := errors.New("wut")
+	s.stores[0].SetErrors(expectedErr)
+	err := s.store.Remove("1.0")
+	c.Assert(err, gc.Equals, expectedErr)
+	s.stores[0].CheckCalls(c, []testing.StubCall{{"Remove", []interface{}{"1.0"}}})
+	s.stores[1].CheckNoCalls(c)
+}
+
 func (s *layeredStorageSuite) TestAllMetadata(c *gc.C) {
 	all, err := s.store.AllMetadata()
 	c.Assert(err, jc.ErrorIsNil)
@@ -181,4 +190,9 @@ func (s *mockStorage) Open(version string) (binarystorage.Metadata, io.ReadClose
 	return s.metadata[0], &s.rc, s.NextErr()
 }
 
+func (s *mockStorage) Remove(version string) error {
+	s.MethodCall(s, "Remove", version)
+	return s.NextErr()
+}
+
 type readCloser struct{ io.ReadCloser }
@@ -74,6 +74,10 @@ type charmDoc struct {
 	PendingUpload bool   `bson:"pendingupload"`
 	Placeholder   bool   `bson:"placeholder"`
 	Macaroon      []byte `bson:"macaroon"`
+
+	// RepositoryURL holds the URL of the charm in the controller's
+	// charm repository that a local charm was copied from.
+	RepositoryURL string `bson:"repository-url,omitempty"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -83,6 +87,10 @@ type CharmInfo struct {
 	StoragePath string
 	SHA256      string
 	Macaroon    macaroon.Slice
+
+	// RepositoryURL holds the URL of the charm in the controller's
+	// charm repository that a local charm was copied from, if any.
+	RepositoryURL string
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -93,15 +101,16 @@ func insertCharmOps(st *State, info CharmInfo) ([]txn.Op, error) {
 	}
 
 	doc := charmDoc{
-		DocID:        info.ID.String(),
-		URL:          info.ID,
-		ModelUUID:    st.ModelTag().Id(),
-		Meta:         info.Charm.Meta(),
-		Config:       safeConfig(info.Charm),
-		Metrics:      info.Charm.Metrics(),
-		Actions:      info.Charm.Actions(),
-		BundleSha256: info.SHA256,
-		StoragePath:  info.StoragePath,
+		DocID:         info.ID.String(),
+		URL:           info.ID,
+		ModelUUID:     st.ModelTag().Id(),
+		Meta:          info.Charm.Meta(),
+		Config:        safeConfig(info.Charm),
+		Metrics:       info.Charm.Metrics(),
+		Actions:       info.Charm.Actions(),
+		BundleSha256:  info.SHA256,
+		StoragePath:   info.StoragePath,
+		RepositoryURL: info.RepositoryURL,
 	}
 	if info.Macaroon != nil {
 		mac, err := info.Macaroon.MarshalBinary()
@@ -179,6 +188,9 @@ func updateCharmOps(
 		}
 		data = append(data, bson.DocElem{"macaroon", mac})
 	}
+	if info.RepositoryURL != "" {
+		data = append(data, bson.DocElem{"repository-url", info.RepositoryURL})
+	}
 
 	updateFields := bson.D{{"$set", data}}
 	return []txn.Op{{
@@ -359,6 +371,13 @@ func (c *Charm) IsPlaceholder() bool {
 	return c.doc.Placeholder
 }
 
+// RepositoryURL returns the URL of the charm in the controller's charm
+// repository that this local charm was copied from, or the empty string
+// if it did not come from the repository.
+func (c *Charm) RepositoryURL() string {
+	return c.doc.RepositoryURL
+}
+
 // Macaroon return the macaroon that can be used to request data about the charm
 // from the charmstore, or nil if the charm is not private.
 func (c *Charm) Macaroon() (macaroon.Slice, error) {
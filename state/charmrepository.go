@@ -0,0 +1,431 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/state/binarystorage"
+	"github.com/juju/juju/state/storage"
+)
+
+// RepositoryCharm is a revision of a charm published to the charm
+// repository hosted by the controller. Revisions are numbered from zero
+// for each charm name; a new revision is not in any channel until it is
+// promoted to one.
+type RepositoryCharm struct {
+	st  *State
+	doc repositoryCharmDoc
+}
+
+type repositoryCharmDoc struct {
+	DocID      string    `bson:"_id"`
+	Name       string    `bson:"name"`
+	Revision   int       `bson:"revision"`
+	Summary    string    `bson:"summary"`
+	Series     []string  `bson:"series"`
+	Channels   []string  `bson:"channels"`
+	Publisher  string    `bson:"publisher"`
+	Published  time.Time `bson:"published"`
+	Size       int64     `bson:"size"`
+	SHA256     string    `bson:"sha256"`
+	ArchiveKey string    `bson:"archive-key"`
+}
+
+func repositoryCharmID(name string, revision int) string {
+	return fmt.Sprintf("%s-%d", name, revision)
+}
+
+// RepositoryCharmStorage returns a new binarystorage.StorageCloser that
+// stores the archives of the charms published to the charm repository
+// in the "juju" database "repositorycharmarchives" collection.
+func (st *State) RepositoryCharmStorage() (binarystorage.StorageCloser, error) {
+	return st.newBinaryStorageCloser(repositoryCharmArchivesC, st.controllerTag.Id()), nil
+}
+
+// PublishRepositoryCharmArgs holds the arguments for publishing a charm
+// to the charm repository.
+type PublishRepositoryCharmArgs struct {
+	// Charm holds the metadata of the charm.
+	Charm charm.Charm
+
+	// Archive holds the charm archive.
+	Archive io.Reader
+
+	// Size is the size of the archive in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA-256 hash of the archive.
+	SHA256 string
+
+	// Publisher is the name of the user publishing the charm.
+	Publisher string
+}
+
+// PublishRepositoryCharm stores a new revision of a charm in the charm
+// repository, with the next revision number for the charm's name. The
+// new revision is not in any channel.
+func (st *State) PublishRepositoryCharm(args PublishRepositoryCharmArgs) (*RepositoryCharm, error) {
+	meta := args.Charm.Meta()
+	if !charm.IsValidName(meta.Name) {
+		return nil, errors.NotValidf("charm name %q", meta.Name)
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Each archive is stored under a key of its own, so that concurrent
+	// publishers cannot replace each other's archives while racing for
+	// the same revision.
+	archiveKey := fmt.Sprintf("%s-%s", meta.Name, uuid)
+	archives, err := st.RepositoryCharmStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archives.Close()
+	err = archives.Add(args.Archive, binarystorage.Metadata{
+		Version: archiveKey,
+		Size:    args.Size,
+		SHA256:  args.SHA256,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot store charm archive")
+	}
+
+	doc := repositoryCharmDoc{
+		Name:       meta.Name,
+		Summary:    meta.Summary,
+		Series:     meta.Series,
+		Channels:   []string{},
+		Publisher:  args.Publisher,
+		Published:  nowToTheSecond(),
+		Size:       args.Size,
+		SHA256:     args.SHA256,
+		ArchiveKey: archiveKey,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		revisions, err := st.RepositoryCharms(meta.Name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc.Revision = 0
+		if len(revisions) > 0 {
+			doc.Revision = revisions[len(revisions)-1].Revision() + 1
+		}
+		doc.DocID = repositoryCharmID(doc.Name, doc.Revision)
+		return []txn.Op{{
+			C:      repositoryCharmsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		if err := archives.Remove(archiveKey); err != nil {
+			logger.Errorf("cannot remove unsuccessfully published charm archive from storage: %v", err)
+		}
+		return nil, errors.Annotatef(err, "cannot publish charm %q", meta.Name)
+	}
+	return &RepositoryCharm{st: st, doc: doc}, nil
+}
+
+// RepositoryCharm returns the given revision of the named charm in the
+// charm repository.
+func (st *State) RepositoryCharm(name string, revision int) (*RepositoryCharm, error) {
+	charms, closer := st.getCollection(repositoryCharmsC)
+	defer closer()
+
+	ch := &RepositoryCharm{st: st}
+	err := charms.FindId(repositoryCharmID(name, revision)).One(&ch.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("revision %d of charm %q", revision, name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+// RepositoryCharms returns the revisions of the named charm in the charm
+// repository, sorted by revision. If the name is empty, it returns the
+// revisions of all charms, sorted by name and revision.
+func (st *State) RepositoryCharms(name string) ([]*RepositoryCharm, error) {
+	var query bson.D
+	if name != "" {
+		query = bson.D{{"name", name}}
+	}
+	return st.findRepositoryCharms(query)
+}
+
+func (st *State) findRepositoryCharms(query bson.D) ([]*RepositoryCharm, error) {
+	charms, closer := st.getCollection(repositoryCharmsC)
+	defer closer()
+
+	var docs []repositoryCharmDoc
+	if err := charms.Find(query).Sort("name", "revision").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*RepositoryCharm, len(docs))
+	for i, doc := range docs {
+		result[i] = &RepositoryCharm{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// ResolveRepositoryCharm returns the revision of the charm identified
+// by the URL. A URL without a revision identifies the revision in the
+// given channel, or in the default channel if none is given.
+func (st *State) ResolveRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (*RepositoryCharm, error) {
+	if curl.Revision >= 0 {
+		return st.RepositoryCharm(curl.Name, curl.Revision)
+	}
+	if channel == csparams.NoChannel {
+		channel = charmrepository.DefaultChannel
+	}
+	if err := charmrepository.ValidateChannel(channel); err != nil {
+		return nil, errors.Trace(err)
+	}
+	charms, err := st.findRepositoryCharms(bson.D{
+		{"name", curl.Name},
+		{"channels", string(channel)},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(charms) == 0 {
+		return nil, errors.NotFoundf("charm %q in channel %q", curl.Name, channel)
+	}
+	return charms[0], nil
+}
+
+// PromoteRepositoryCharm puts the given revision of the named charm in
+// the channel, taking the channel from the revision that was in it.
+func (st *State) PromoteRepositoryCharm(name string, revision int, channel csparams.Channel) error {
+	if err := charmrepository.ValidateChannel(channel); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ch, err := st.RepositoryCharm(name, revision)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ch.InChannel(channel) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := []txn.Op{{
+			C:      repositoryCharmsC,
+			Id:     ch.doc.DocID,
+			Assert: bson.D{{"channels", bson.D{{"$ne", string(channel)}}}},
+			Update: bson.D{{"$addToSet", bson.D{{"channels", string(channel)}}}},
+		}}
+		current, err := st.ResolveRepositoryCharm(&charmrepository.URL{Name: name, Revision: -1}, channel)
+		if err == nil {
+			ops = append(ops, txn.Op{
+				C:      repositoryCharmsC,
+				Id:     current.doc.DocID,
+				Assert: bson.D{{"channels", string(channel)}},
+				Update: bson.D{{"$pull", bson.D{{"channels", string(channel)}}}},
+			})
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return ops, nil
+	}
+	err := st.run(buildTxn)
+	return errors.Annotatef(err, "cannot promote revision %d of charm %q to %q", revision, name, channel)
+}
+
+// AddRepositoryCharm copies the revision of the charm identified by the
+// URL, resolved as by ResolveRepositoryCharm, into the model as a local
+// charm for the given series. If the URL has no series, the charm's
+// default series is used. A revision already copied for the series is
+// returned as it is.
+func (st *State) AddRepositoryCharm(curl *charmrepository.URL, channel csparams.Channel) (*Charm, error) {
+	ch, err := st.ResolveRepositoryCharm(curl, channel)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	series := curl.Series
+	if series == "" {
+		if len(ch.doc.Series) == 0 {
+			return nil, errors.Errorf("series not specified and charm %q does not define any", ch.URL())
+		}
+		series = ch.doc.Series[0]
+	}
+	repoURL := ch.URL().String()
+
+	// Reuse a copy of the revision made earlier.
+	charms, closer := st.getCollection(charmsC)
+	defer closer()
+	var docs []charmDoc
+	err = charms.Find(bson.D{{"repository-url", repoURL}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, doc := range docs {
+		if doc.URL.Series == series && !doc.PendingUpload {
+			return newCharm(st, &doc), nil
+		}
+	}
+
+	_, archive, err := ch.Open()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm archive")
+	}
+	archiveCharm, err := charm.ReadCharmArchiveBytes(data)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read charm archive")
+	}
+
+	curlToAdd := &charm.URL{
+		Schema:   "local",
+		Name:     ch.Name(),
+		Series:   series,
+		Revision: ch.Revision(),
+	}
+	curlToAdd, err = st.PrepareLocalCharmUpload(curlToAdd)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storagePath := fmt.Sprintf("charms/%s-%s", curlToAdd, uuid)
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(storagePath, bytes.NewReader(data), ch.Size()); err != nil {
+		return nil, errors.Annotate(err, "cannot add charm to storage")
+	}
+	added, err := st.UpdateUploadedCharm(CharmInfo{
+		Charm:         archiveCharm,
+		ID:            curlToAdd,
+		StoragePath:   storagePath,
+		SHA256:        ch.SHA256(),
+		RepositoryURL: repoURL,
+	})
+	if err != nil {
+		if err := stor.Remove(storagePath); err != nil {
+			logger.Errorf("cannot remove unsuccessfully recorded charm archive from storage: %v", err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return added, nil
+}
+
+// Name returns the name of the charm.
+func (ch *RepositoryCharm) Name() string {
+	return ch.doc.Name
+}
+
+// Revision returns the revision of the charm.
+func (ch *RepositoryCharm) Revision() int {
+	return ch.doc.Revision
+}
+
+// URL returns the charm repository URL of this revision of the charm.
+func (ch *RepositoryCharm) URL() *charmrepository.URL {
+	return &charmrepository.URL{Name: ch.doc.Name, Revision: ch.doc.Revision}
+}
+
+// Summary returns the summary from the charm's metadata.
+func (ch *RepositoryCharm) Summary() string {
+	return ch.doc.Summary
+}
+
+// Series returns the series supported by the charm, as declared in its
+// metadata.
+func (ch *RepositoryCharm) Series() []string {
+	return ch.doc.Series
+}
+
+// Channels returns the channels this revision of the charm is in.
+func (ch *RepositoryCharm) Channels() []csparams.Channel {
+	var channels []csparams.Channel
+	for _, channel := range charmrepository.Channels {
+		if ch.InChannel(channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// InChannel returns whether this revision of the charm is in the
+// channel.
+func (ch *RepositoryCharm) InChannel(channel csparams.Channel) bool {
+	for _, c := range ch.doc.Channels {
+		if c == string(channel) {
+			return true
+		}
+	}
+	return false
+}
+
+// Publisher returns the name of the user that published this revision
+// of the charm.
+func (ch *RepositoryCharm) Publisher() string {
+	return ch.doc.Publisher
+}
+
+// Published returns when this revision of the charm was published, in
+// UTC.
+func (ch *RepositoryCharm) Published() time.Time {
+	return ch.doc.Published.UTC()
+}
+
+// Size returns the size of the charm archive in bytes.
+func (ch *RepositoryCharm) Size() int64 {
+	return ch.doc.Size
+}
+
+// SHA256 returns the hex-encoded SHA-256 hash of the charm archive.
+func (ch *RepositoryCharm) SHA256() string {
+	return ch.doc.SHA256
+}
+
+// Open returns the metadata and contents of the charm archive. The
+// caller is responsible for closing the contents.
+func (ch *RepositoryCharm) Open() (binarystorage.Metadata, io.ReadCloser, error) {
+	archives, err := ch.st.RepositoryCharmStorage()
+	if err != nil {
+		return binarystorage.Metadata{}, nil, errors.Trace(err)
+	}
+	meta, r, err := archives.Open(ch.doc.ArchiveKey)
+	if err != nil {
+		archives.Close()
+		return binarystorage.Metadata{}, nil, errors.Annotatef(err, "cannot open archive of charm %q", ch.URL())
+	}
+	return meta, &repositoryCharmArchive{r, archives}, nil
+}
+
+// repositoryCharmArchive closes the charm repository storage along with
+// the archive read from it.
+type repositoryCharmArchive struct {
+	io.ReadCloser
+	storage binarystorage.StorageCloser
+}
+
+// Close is part of the io.Closer interface.
+func (a *repositoryCharmArchive) Close() error {
+	err := a.ReadCloser.Close()
+	a.storage.Close()
+	return errors.Trace(err)
+}
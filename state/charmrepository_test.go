@@ -0,0 +1,169 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testcharms"
+)
+
+type RepositoryCharmSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RepositoryCharmSuite{})
+
+func (s *RepositoryCharmSuite) publish(c *gc.C, name string) *state.RepositoryCharm {
+	return publishRepositoryCharm(c, s.State, name)
+}
+
+func publishRepositoryCharm(c *gc.C, st *state.State, name string) *state.RepositoryCharm {
+	path := testcharms.Repo.CharmArchivePath(c.MkDir(), name)
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmArchiveBytes(data)
+	c.Assert(err, jc.ErrorIsNil)
+	ch2, err := st.PublishRepositoryCharm(state.PublishRepositoryCharmArgs{
+		Charm:     ch,
+		Archive:   bytes.NewReader(data),
+		Size:      int64(len(data)),
+		SHA256:    fmt.Sprintf("%x", sha256.Sum256(data)),
+		Publisher: "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return ch2
+}
+
+func (s *RepositoryCharmSuite) TestPublish(c *gc.C) {
+	first := s.publish(c, "multi-series")
+	c.Check(first.URL().String(), gc.Equals, "repo:multi-series-0")
+	c.Check(first.Publisher(), gc.Equals, "admin")
+	c.Check(first.Series(), jc.DeepEquals, []string{"precise", "trusty"})
+	c.Check(first.Channels(), gc.HasLen, 0)
+
+	second := s.publish(c, "multi-series")
+	c.Check(second.Revision(), gc.Equals, 1)
+
+	_, r, err := second.Open()
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fmt.Sprintf("%x", sha256.Sum256(data)), gc.Equals, second.SHA256())
+
+	all, err := s.State.RepositoryCharms("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Check(all[0].Revision(), gc.Equals, 0)
+	c.Check(all[1].Revision(), gc.Equals, 1)
+}
+
+func (s *RepositoryCharmSuite) TestPublishRemovesArchiveOnFailure(c *gc.C) {
+	// Keep publishing the revision being published, so that
+	// publishing fails with excessive contention.
+	publish := func() { s.publish(c, "multi-series") }
+	defer state.SetBeforeHooks(c, s.State, publish, publish, publish).Check()
+
+	path := testcharms.Repo.CharmArchivePath(c.MkDir(), "multi-series")
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := charm.ReadCharmArchiveBytes(data)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.PublishRepositoryCharm(state.PublishRepositoryCharmArgs{
+		Charm:     ch,
+		Archive:   bytes.NewReader(data),
+		Size:      int64(len(data)),
+		SHA256:    fmt.Sprintf("%x", sha256.Sum256(data)),
+		Publisher: "admin",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot publish charm "multi-series": state changing too quickly; try again soon`)
+
+	// Only the archives of the charms published by the hooks remain.
+	archives, err := s.State.RepositoryCharmStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer archives.Close()
+	all, err := archives.AllMetadata()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 3)
+}
+
+func (s *RepositoryCharmSuite) TestRepositoryCharmNotFound(c *gc.C) {
+	_, err := s.State.RepositoryCharm("dummy", 3)
+	c.Assert(err, gc.ErrorMatches, `revision 3 of charm "dummy" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RepositoryCharmSuite) TestPromote(c *gc.C) {
+	s.publish(c, "dummy")
+	s.publish(c, "dummy")
+	curl := charmrepository.MustParseURL("repo:dummy")
+
+	_, err := s.State.ResolveRepositoryCharm(curl, csparams.NoChannel)
+	c.Assert(err, gc.ErrorMatches, `charm "dummy" in channel "stable" not found`)
+
+	err = s.State.PromoteRepositoryCharm("dummy", 0, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.PromoteRepositoryCharm("dummy", 1, csparams.EdgeChannel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch, err := s.State.ResolveRepositoryCharm(curl, csparams.NoChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.Revision(), gc.Equals, 0)
+	ch, err = s.State.ResolveRepositoryCharm(curl, csparams.EdgeChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.Revision(), gc.Equals, 1)
+
+	// Promoting a revision takes the channel from the previous one.
+	err = s.State.PromoteRepositoryCharm("dummy", 1, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err = s.State.ResolveRepositoryCharm(curl, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.Revision(), gc.Equals, 1)
+	c.Check(ch.Channels(), jc.DeepEquals, []csparams.Channel{csparams.StableChannel, csparams.EdgeChannel})
+	ch, err = s.State.RepositoryCharm("dummy", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.Channels(), gc.HasLen, 0)
+}
+
+func (s *RepositoryCharmSuite) TestPromoteInvalidChannel(c *gc.C) {
+	s.publish(c, "dummy")
+	err := s.State.PromoteRepositoryCharm("dummy", 0, "bleeding")
+	c.Assert(err, gc.ErrorMatches, `channel "bleeding" not valid`)
+}
+
+func (s *RepositoryCharmSuite) TestAddRepositoryCharm(c *gc.C) {
+	s.publish(c, "dummy")
+	curl := charmrepository.MustParseURL("repo:quantal/dummy-0")
+
+	ch, err := s.State.AddRepositoryCharm(curl, csparams.NoChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.URL().Schema, gc.Equals, "local")
+	c.Check(ch.URL().Series, gc.Equals, "quantal")
+	c.Check(ch.Meta().Name, gc.Equals, "dummy")
+	c.Check(ch.RepositoryURL(), gc.Equals, "repo:dummy-0")
+	c.Check(ch.IsUploaded(), jc.IsTrue)
+
+	// Adding the same revision again returns the existing copy.
+	ch2, err := s.State.AddRepositoryCharm(curl, csparams.NoChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch2.URL(), jc.DeepEquals, ch.URL())
+}
+
+func (s *RepositoryCharmSuite) TestAddRepositoryCharmNoSeries(c *gc.C) {
+	s.publish(c, "dummy")
+	_, err := s.State.AddRepositoryCharm(charmrepository.MustParseURL("repo:dummy-0"), csparams.NoChannel)
+	c.Assert(err, gc.ErrorMatches, `series not specified and charm "repo:dummy-0" does not define any`)
+}
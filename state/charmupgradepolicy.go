@@ -0,0 +1,183 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/core/charmupgrade"
+)
+
+// charmUpgradePolicyDoc records how an application is upgraded when
+// newer revisions of its charm become available.
+type charmUpgradePolicyDoc struct {
+	DocID          string        `bson:"_id"`
+	ModelUUID      string        `bson:"model-uuid"`
+	Application    string        `bson:"application"`
+	Mode           string        `bson:"mode"`
+	Channel        string        `bson:"channel,omitempty"`
+	WindowStart    time.Duration `bson:"window-start,omitempty"`
+	WindowDuration time.Duration `bson:"window-duration,omitempty"`
+}
+
+func (doc charmUpgradePolicyDoc) policy() charmupgrade.Policy {
+	return charmupgrade.Policy{
+		Mode:    charmupgrade.Mode(doc.Mode),
+		Channel: csparams.Channel(doc.Channel),
+		Window: charmupgrade.Window{
+			Start:    doc.WindowStart,
+			Duration: doc.WindowDuration,
+		},
+	}
+}
+
+func removeCharmUpgradePolicyOp(key string) txn.Op {
+	return txn.Op{
+		C:      charmUpgradePoliciesC,
+		Id:     key,
+		Remove: true,
+	}
+}
+
+// CharmUpgradePolicy returns the charm upgrade policy of the
+// application, or charmupgrade.DefaultPolicy if none has been set.
+func (s *Application) CharmUpgradePolicy() (charmupgrade.Policy, error) {
+	coll, closer := s.st.getCollection(charmUpgradePoliciesC)
+	defer closer()
+
+	var doc charmUpgradePolicyDoc
+	err := coll.FindId(s.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return charmupgrade.DefaultPolicy, nil
+	}
+	if err != nil {
+		return charmupgrade.Policy{}, errors.Annotatef(err, "cannot get charm upgrade policy for application %q", s.Name())
+	}
+	return doc.policy(), nil
+}
+
+// SetCharmUpgradePolicy replaces the charm upgrade policy of the
+// application.
+func (s *Application) SetCharmUpgradePolicy(policy charmupgrade.Policy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set charm upgrade policy for application %q", s.Name())
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if policy.Mode != charmupgrade.AutoInWindow {
+		policy.Window = charmupgrade.Window{}
+	}
+	coll, closer := s.st.getCollection(charmUpgradePoliciesC)
+	defer closer()
+
+	key := s.globalKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := s.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if s.Life() != Alive {
+			return nil, errors.New("application is not alive")
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     s.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		n, err := coll.FindId(key).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return append(ops, txn.Op{
+				C:      charmUpgradePoliciesC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: &charmUpgradePolicyDoc{
+					Application:    s.Name(),
+					Mode:           string(policy.Mode),
+					Channel:        string(policy.Channel),
+					WindowStart:    policy.Window.Start,
+					WindowDuration: policy.Window.Duration,
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      charmUpgradePoliciesC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"mode", string(policy.Mode)},
+				{"channel", string(policy.Channel)},
+				{"window-start", policy.Window.Start},
+				{"window-duration", policy.Window.Duration},
+			}}},
+		}), nil
+	}
+	return s.st.run(buildTxn)
+}
+
+// AvailableCharmUpgrade returns the URL of a newer revision of the
+// application's charm, or "" if none is known. For charm store charms
+// this is the latest revision recorded by the charm revision updater;
+// for charms copied from the controller charm repository, it is the
+// revision in the channel of the application's upgrade policy, as a
+// repo: URL. Other local charms are never upgraded.
+func (s *Application) AvailableCharmUpgrade() (string, error) {
+	curl, _ := s.CharmURL()
+	switch curl.Schema {
+	case "cs":
+		latest, err := s.st.LatestPlaceholderCharm(curl)
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if latest.Revision() <= curl.Revision {
+			return "", nil
+		}
+		return latest.String(), nil
+	case "local":
+		return s.availableRepositoryCharmUpgrade(curl)
+	}
+	return "", nil
+}
+
+func (s *Application) availableRepositoryCharmUpgrade(curl *charm.URL) (string, error) {
+	ch, err := s.st.Charm(curl)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if ch.RepositoryURL() == "" {
+		return "", nil
+	}
+	current, err := charmrepository.ParseURL(ch.RepositoryURL())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	policy, err := s.CharmUpgradePolicy()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	latest, err := s.st.ResolveRepositoryCharm(current.WithRevision(-1), policy.Channel)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if latest.Revision() <= current.Revision {
+		return "", nil
+	}
+	return latest.URL().String(), nil
+}
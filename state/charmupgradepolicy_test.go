@@ -0,0 +1,119 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/charmrepository"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CharmUpgradePolicySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CharmUpgradePolicySuite{})
+
+func (s *CharmUpgradePolicySuite) TestDefaultPolicy(c *gc.C) {
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	policy, err := app.CharmUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, charmupgrade.DefaultPolicy)
+}
+
+func (s *CharmUpgradePolicySuite) TestSetCharmUpgradePolicy(c *gc.C) {
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	policy := charmupgrade.Policy{
+		Mode:    charmupgrade.AutoInWindow,
+		Channel: csparams.EdgeChannel,
+		Window:  charmupgrade.Window{Start: 2 * time.Hour, Duration: time.Hour},
+	}
+	err := app.SetCharmUpgradePolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err := app.CharmUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, policy)
+
+	// The window is dropped for policies that don't use it.
+	policy.Mode = charmupgrade.None
+	err = app.SetCharmUpgradePolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	got, err = app.CharmUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(got, gc.Equals, charmupgrade.Policy{
+		Mode:    charmupgrade.None,
+		Channel: csparams.EdgeChannel,
+	})
+}
+
+func (s *CharmUpgradePolicySuite) TestSetCharmUpgradePolicyInvalid(c *gc.C) {
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := app.SetCharmUpgradePolicy(charmupgrade.Policy{Mode: charmupgrade.AutoInWindow})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm upgrade policy for application "wordpress": "auto-in-window" policy without maintenance window not valid`)
+}
+
+func (s *CharmUpgradePolicySuite) TestPolicyRemovedWithApplication(c *gc.C) {
+	app := s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := app.SetCharmUpgradePolicy(charmupgrade.Policy{Mode: charmupgrade.None})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	policy, err := app.CharmUpgradePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, charmupgrade.DefaultPolicy)
+}
+
+func (s *CharmUpgradePolicySuite) TestAvailableCharmUpgradeStore(c *gc.C) {
+	f := factory.NewFactory(s.State)
+	ch := f.MakeCharm(c, &factory.CharmParams{URL: "cs:quantal/mysql-1"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+
+	latest, err := app.AvailableCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, "")
+
+	err = s.State.AddStoreCharmPlaceholder(charm.MustParseURL("cs:quantal/mysql-3"))
+	c.Assert(err, jc.ErrorIsNil)
+	latest, err = app.AvailableCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, "cs:quantal/mysql-3")
+}
+
+func (s *CharmUpgradePolicySuite) TestAvailableCharmUpgradeRepository(c *gc.C) {
+	publishRepositoryCharm(c, s.State, "dummy")
+	err := s.State.PromoteRepositoryCharm("dummy", 0, csparams.StableChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	ch, err := s.State.AddRepositoryCharm(charmrepository.MustParseURL("repo:quantal/dummy"), csparams.NoChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingService(c, "dummy", ch)
+
+	latest, err := app.AvailableCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, "")
+
+	publishRepositoryCharm(c, s.State, "dummy")
+	err = s.State.PromoteRepositoryCharm("dummy", 1, csparams.EdgeChannel)
+	c.Assert(err, jc.ErrorIsNil)
+	latest, err = app.AvailableCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, "")
+
+	err = app.SetCharmUpgradePolicy(charmupgrade.Policy{
+		Mode:    charmupgrade.Notify,
+		Channel: csparams.EdgeChannel,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	latest, err = app.AvailableCharmUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(latest, gc.Equals, "repo:dummy-1")
+}
@@ -0,0 +1,240 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// RemoveControllerMemberArgs holds the arguments to
+// RemoveControllerMember.
+type RemoveControllerMemberArgs struct {
+	// MachineId identifies the controller machine to remove.
+	MachineId string
+
+	// Force allows a controller machine whose agent is still
+	// alive to be removed.
+	Force bool
+
+	// Replace causes a replacement controller machine to be
+	// provided in the same transaction.
+	Replace bool
+
+	// Placement, if set, is where the replacement is placed. It
+	// may name an existing machine, which is converted to be a
+	// controller, or be a provider placement directive such as
+	// "zone=us-east-1a". Setting it implies Replace.
+	Placement string
+
+	// Constraints and Series are used for a new replacement
+	// machine. They default to those of the removed machine.
+	Constraints constraints.Value
+	Series      string
+}
+
+// RemoveControllerMember starts removing a controller machine that has
+// been lost for good. If the machine has no vote in the replica set it
+// is removed immediately. Otherwise it is demoted, and recorded in the
+// controller info's RemovingMachineIds; the peergrouper worker then
+// takes away its vote, stops publishing its API addresses, and calls
+// CompleteControllerMemberRemoval to finish the job.
+func (st *State) RemoveControllerMember(args RemoveControllerMemberArgs) (ControllersChanges, error) {
+	if !names.IsValidMachine(args.MachineId) {
+		return ControllersChanges{}, errors.NotValidf("machine id %q", args.MachineId)
+	}
+	replace := args.Replace || args.Placement != ""
+	var change ControllersChanges
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		change = ControllersChanges{}
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !set.NewStrings(info.MachineIds...).Contains(args.MachineId) {
+			return nil, errors.Errorf("machine %s is not a controller", args.MachineId)
+		}
+		if set.NewStrings(info.RemovingMachineIds...).Contains(args.MachineId) {
+			return nil, errors.Errorf("machine %s is already being removed", args.MachineId)
+		}
+		m, err := st.Machine(args.MachineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !args.Force {
+			available, err := controllerAvailable(m)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if available {
+				return nil, errors.Errorf("controller machine %s is still available", m.Id())
+			}
+		}
+		voting := set.NewStrings(info.VotingMachineIds...)
+		voting.Remove(m.Id())
+		if voting.IsEmpty() {
+			return nil, errors.New("cannot remove the last voting controller machine")
+		}
+
+		var ops []txn.Op
+		if !m.HasVote() {
+			ops = dropControllerOps(m)
+			change.Removed = append(change.Removed, m.Id())
+		} else {
+			ops = append(ops, txn.Op{
+				C:      controllersC,
+				Id:     modelGlobalKey,
+				Assert: bson.D{{"removingmachineids", bson.D{{"$ne", m.Id()}}}},
+				Update: bson.D{{"$addToSet", bson.D{{"removingmachineids", m.Id()}}}},
+			})
+			if m.WantsVote() {
+				ops = append(ops, demoteControllerOps(m)...)
+				change.Demoted = append(change.Demoted, m.Id())
+			}
+		}
+		if replace {
+			replaceOps, err := st.replaceControllerOps(m, info, args)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, replaceOps.ops...)
+			change.Added = append(change.Added, replaceOps.added...)
+			change.Converted = append(change.Converted, replaceOps.converted...)
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return ControllersChanges{}, errors.Annotatef(err, "cannot remove controller machine %s", args.MachineId)
+	}
+	return change, nil
+}
+
+type replaceControllerOps struct {
+	ops              []txn.Op
+	added, converted []string
+}
+
+// replaceControllerOps returns the operations needed to provide a
+// replacement for the given controller machine.
+func (st *State) replaceControllerOps(
+	m *Machine, info *ControllerInfo, args RemoveControllerMemberArgs,
+) (*replaceControllerOps, error) {
+	var result replaceControllerOps
+	if args.Placement != "" {
+		p, err := instance.ParsePlacement(args.Placement)
+		if err == nil && p.Scope == instance.MachineScope {
+			if names.IsContainerMachine(p.Directive) {
+				return nil, errors.New("container placement directives not supported")
+			}
+			target, err := st.Machine(p.Directive)
+			if err != nil {
+				return nil, errors.Annotatef(err, "can't find machine for placement directive %q", args.Placement)
+			}
+			if target.IsManager() {
+				return nil, errors.Errorf("machine for placement directive %q is already a controller", args.Placement)
+			}
+			result.ops = convertControllerOps(target)
+			result.converted = []string{target.Id()}
+			return &result, nil
+		}
+		if err != instance.ErrPlacementScopeMissing {
+			return nil, errors.Errorf("unsupported placement directive %q", args.Placement)
+		}
+	}
+
+	series := args.Series
+	if series == "" {
+		series = m.Series()
+	}
+	cons := args.Constraints
+	if constraints.IsEmpty(&cons) {
+		var err error
+		if cons, err = m.Constraints(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	mdoc, addOps, err := st.addMachineOps(MachineTemplate{
+		Series:      series,
+		Jobs:        []MachineJob{JobHostUnits, JobManageModel},
+		Constraints: cons,
+		Placement:   args.Placement,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ssOps, err := st.maintainControllersOps([]*machineDoc{mdoc}, info)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot prepare machine add operations")
+	}
+	result.ops = append(addOps, ssOps...)
+	result.added = []string{mdoc.Id}
+	return &result, nil
+}
+
+// CompleteControllerMemberRemoval finishes the removal of a controller
+// machine started by RemoveControllerMember, once the machine no longer
+// has a vote in the replica set. The machine loses its JobManageModel
+// job and is dropped from the controller info; the peergrouper then
+// removes its member from the replica set.
+func (st *State) CompleteControllerMemberRemoval(machineId string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		info, err := st.ControllerInfo()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !set.NewStrings(info.RemovingMachineIds...).Contains(machineId) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		m, err := st.Machine(machineId)
+		if errors.IsNotFound(err) {
+			return []txn.Op{pullControllerOp(machineId)}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.HasVote() {
+			return nil, errors.Errorf("machine %s still has a vote", machineId)
+		}
+		if !m.IsManager() {
+			return []txn.Op{pullControllerOp(machineId)}, nil
+		}
+		return dropControllerOps(m), nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot complete removal of controller machine %s", machineId)
+	}
+	return nil
+}
+
+// dropControllerOps returns the operations needed to remove a
+// controller machine that has no vote from the controller entirely.
+func dropControllerOps(m *Machine) []txn.Op {
+	return []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: bson.D{{"hasvote", false}},
+		Update: bson.D{
+			{"$pull", bson.D{{"jobs", JobManageModel}}},
+			{"$set", bson.D{{"novote", false}}},
+		},
+	}, pullControllerOp(m.doc.Id)}
+}
+
+func pullControllerOp(machineId string) txn.Op {
+	return txn.Op{
+		C:  controllersC,
+		Id: modelGlobalKey,
+		Update: bson.D{{"$pull", bson.D{
+			{"machineids", machineId},
+			{"votingmachineids", machineId},
+			{"removingmachineids", machineId},
+		}}},
+	}
+}
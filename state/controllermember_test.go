@@ -0,0 +1,188 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+)
+
+// enableHAWithVotes makes 3 controller machines that all have a vote,
+// and patches controller availability so that machine 2 is lost.
+func (s *StateSuite) enableHAWithVotes(c *gc.C) {
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Added, gc.HasLen, 3)
+	for _, id := range changes.Added {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		err = m.SetHasVote(true)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return m.Id() != "2", nil
+	})
+}
+
+func (s *StateSuite) TestRemoveControllerMember(c *gc.C) {
+	s.enableHAWithVotes(c)
+
+	changes, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{Demoted: []string{"2"}})
+
+	// The machine stays a controller until the peergrouper
+	// has taken away its vote.
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "1"}, nil)
+	info, err := s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.RemovingMachineIds, jc.DeepEquals, []string{"2"})
+	m2, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m2.WantsVote(), jc.IsFalse)
+	c.Check(m2.IsManager(), jc.IsTrue)
+
+	err = s.State.CompleteControllerMemberRemoval("2")
+	c.Assert(err, gc.ErrorMatches, "cannot complete removal of controller machine 2: machine 2 still has a vote")
+
+	err = m2.SetHasVote(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CompleteControllerMemberRemoval("2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertControllerInfo(c, []string{"0", "1"}, []string{"0", "1"}, nil)
+	info, err = s.State.ControllerInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.RemovingMachineIds, gc.HasLen, 0)
+	err = m2.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m2.IsManager(), jc.IsFalse)
+
+	// Completing the removal again does nothing.
+	err = s.State.CompleteControllerMemberRemoval("2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StateSuite) TestRemoveControllerMemberWithoutVote(c *gc.C) {
+	s.enableHAWithVotes(c)
+	m2, err := s.State.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m2.SetHasVote(false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{Removed: []string{"2"}})
+	s.assertControllerInfo(c, []string{"0", "1"}, []string{"0", "1"}, nil)
+	err = m2.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m2.IsManager(), jc.IsFalse)
+}
+
+func (s *StateSuite) TestRemoveControllerMemberStillAvailable(c *gc.C) {
+	s.enableHAWithVotes(c)
+
+	_, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "1"})
+	c.Assert(err, gc.ErrorMatches, "cannot remove controller machine 1: controller machine 1 is still available")
+
+	changes, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "1", Force: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes.Demoted, jc.DeepEquals, []string{"1"})
+}
+
+func (s *StateSuite) TestRemoveControllerMemberErrors(c *gc.C) {
+	s.enableHAWithVotes(c)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "3"})
+	c.Check(err, gc.ErrorMatches, "cannot remove controller machine 3: machine 3 is not a controller")
+
+	_, err = s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "foo"})
+	c.Check(err, gc.ErrorMatches, `machine id "foo" not valid`)
+
+	_, err = s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "2"})
+	c.Check(err, gc.ErrorMatches, "cannot remove controller machine 2: machine 2 is already being removed")
+}
+
+func (s *StateSuite) TestRemoveControllerMemberLastVoter(c *gc.C) {
+	m0, err := s.State.AddMachine("quantal", state.JobHostUnits, state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m0.SetHasVote(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{
+		MachineId: "0",
+		Force:     true,
+		Replace:   true,
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot remove controller machine 0: cannot remove the last voting controller machine")
+}
+
+func (s *StateSuite) TestRemoveControllerMemberReplaceInZone(c *gc.C) {
+	s.enableHAWithVotes(c)
+
+	changes, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{
+		MachineId: "2",
+		Placement: "zone=az2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Demoted: []string{"2"},
+		Added:   []string{"3"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3"}, []string{"0", "1", "3"}, []string{"", "", "", "zone=az2"})
+	m3, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m3.Series(), gc.Equals, "quantal")
+	c.Check(m3.WantsVote(), jc.IsTrue)
+}
+
+func (s *StateSuite) TestRemoveControllerMemberReplaceOnMachine(c *gc.C) {
+	s.enableHAWithVotes(c)
+	m3, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{
+		MachineId: "2",
+		Placement: m3.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes, jc.DeepEquals, state.ControllersChanges{
+		Demoted:   []string{"2"},
+		Converted: []string{"3"},
+	})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3"}, []string{"0", "1", "3"}, nil)
+	err = m3.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m3.IsManager(), jc.IsTrue)
+
+	_, err = s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{
+		MachineId: "1",
+		Force:     true,
+		Placement: "0",
+	})
+	c.Check(err, gc.ErrorMatches, `cannot remove controller machine 1: machine for placement directive "0" is already a controller`)
+}
+
+func (s *StateSuite) TestEnableHAIgnoresRemovingMachines(c *gc.C) {
+	s.enableHAWithVotes(c)
+	_, err := s.State.RemoveControllerMember(state.RemoveControllerMemberArgs{MachineId: "2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Even if the machine comes back, it is not promoted again.
+	s.PatchValue(state.ControllerAvailable, func(m *state.Machine) (bool, error) {
+		return true, nil
+	})
+	changes, err := s.State.EnableHA(3, constraints.Value{}, "quantal", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes.Promoted, gc.HasLen, 0)
+	c.Check(changes.Added, jc.DeepEquals, []string{"3"})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3"}, []string{"0", "1", "3"}, nil)
+}
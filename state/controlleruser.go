@@ -0,0 +1,117 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// controllerGlobalKey is the key for the controller as the object of
+// controller-scoped permissions. Controller permissions are stored in
+// the permissions collection of the controller model.
+const controllerGlobalKey = "c"
+
+func controllerUserGlobalKey(userID string) string {
+	// cu stands for controller user.
+	return fmt.Sprintf("cu#%s", userID)
+}
+
+// controllerUserID returns the subject key used for the controller
+// permission of the given user.
+func controllerUserID(user names.UserTag) string {
+	return controllerUserGlobalKey(strings.ToLower(user.Canonical()))
+}
+
+// ValidControllerAccess returns whether the given access level can be
+// granted on the controller.
+func ValidControllerAccess(access Access) bool {
+	switch access {
+	case LoginAccess, AddModelAccess, SuperuserAccess:
+		return true
+	}
+	return false
+}
+
+// controllerState returns a State for the controller model, along with
+// a function that must be called to release it.
+func (st *State) controllerState() (*State, func(), error) {
+	if st.IsController() {
+		return st, func() {}, nil
+	}
+	controllerSt, err := st.ForModel(st.controllerTag)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return controllerSt, func() { controllerSt.Close() }, nil
+}
+
+// ControllerAccess returns the level of access the given user has on
+// the controller. A NotFound error is returned if the user has not
+// been granted any.
+func (st *State) ControllerAccess(user names.UserTag) (Access, error) {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	defer closer()
+
+	perm, err := controllerSt.userPermission(controllerGlobalKey, controllerUserID(user))
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// SetControllerAccess sets the level of access the given user has on
+// the controller, replacing any access previously granted. Local users
+// must exist.
+func (st *State) SetControllerAccess(user names.UserTag, access Access) error {
+	if !ValidControllerAccess(access) {
+		return errors.NotValidf("controller access %q", access)
+	}
+	if user.IsLocal() {
+		if _, err := st.User(user); err != nil {
+			return errors.Annotatef(err, "user %q does not exist locally", user.Name())
+		}
+	}
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	subjectKey := controllerUserID(user)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := controllerSt.userPermission(controllerGlobalKey, subjectKey)
+		if errors.IsNotFound(err) {
+			return []txn.Op{createPermissionOp(controllerGlobalKey, subjectKey, access)}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{updatePermissionOp(controllerGlobalKey, subjectKey, access)}, nil
+	}
+	return errors.Annotatef(controllerSt.run(buildTxn), "cannot set controller access for %q", user.Canonical())
+}
+
+// RemoveControllerAccess removes all access the given user has on the
+// controller.
+func (st *State) RemoveControllerAccess(user names.UserTag) error {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	op := removePermissionOp(controllerGlobalKey, controllerUserID(user))
+	err = controllerSt.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("controller access for %q", user.Canonical())
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ControllerUserSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ControllerUserSuite{})
+
+func (s *ControllerUserSuite) TestOwnerIsSuperuser(c *gc.C) {
+	access, err := s.State.ControllerAccess(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.SuperuserAccess)
+}
+
+func (s *ControllerUserSuite) TestNewUserHasLoginAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.LoginAccess)
+}
+
+func (s *ControllerUserSuite) TestUnknownUserHasNoAccess(c *gc.C) {
+	_, err := s.State.ControllerAccess(names.NewUserTag("bob@external"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), state.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.AddModelAccess)
+
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsFalse)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessExternalUser(c *gc.C) {
+	user := names.NewUserTag("bob@external")
+	err := s.State.SetControllerAccess(user, state.LoginAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.ControllerAccess(user)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.LoginAccess)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessInvalid(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), state.WriteAccess)
+	c.Assert(err, gc.ErrorMatches, `controller access "write" not valid`)
+}
+
+func (s *ControllerUserSuite) TestSetControllerAccessNoLocalUser(c *gc.C) {
+	err := s.State.SetControllerAccess(names.NewLocalUserTag("nobody"), state.LoginAccess)
+	c.Assert(err, gc.ErrorMatches, `user "nobody" does not exist locally: user "nobody" not found`)
+}
+
+func (s *ControllerUserSuite) TestSuperuserIsControllerAdministrator(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.SetControllerAccess(user.UserTag(), state.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	isAdmin, err := s.State.IsControllerAdministrator(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+}
+
+func (s *ControllerUserSuite) TestControllerAccessFromHostedModel(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+
+	err := otherState.SetControllerAccess(user.UserTag(), state.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.AddModelAccess)
+}
+
+func (s *ControllerUserSuite) TestRemoveControllerAccess(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	err := s.State.RemoveControllerAccess(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.ControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveControllerAccess(user.UserTag())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -461,6 +461,19 @@ func UpdateModelUserLastConnection(e *ModelUser, when time.Time) error {
 	return e.updateLastConnection(when)
 }
 
+func SetUserPasswordChanged(u *User, when time.Time) error {
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"passwordchanged", when}}}},
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		return err
+	}
+	return u.Refresh()
+}
+
 func RemoveEndpointBindingsForService(c *gc.C, service *Application) {
 	globalKey := service.globalKey()
 	removeOp := removeEndpointBindingsOp(globalKey)
@@ -0,0 +1,261 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ipAddressReservationDoc describes the persistent state of an IP address
+// reserved by a user in one of the subnets of a space, optionally for a
+// specific machine (usually a container).
+type ipAddressReservationDoc struct {
+	// DocID is the reserved IP address value, prefixed by ModelUUID.
+	DocID string `bson:"_id"`
+
+	// ModelUUID is the UUID of the model this reservation belongs to.
+	ModelUUID string `bson:"model-uuid"`
+
+	// Value is the reserved IP address, e.g. 10.20.0.42 or 2001:db8::42.
+	Value string `bson:"value"`
+
+	// SubnetCIDR is the CIDR of the subnet the reserved address comes from.
+	// It always matches a known subnet associated with a space.
+	SubnetCIDR string `bson:"subnet-cidr"`
+
+	// MachineID is the ID of the machine the address is reserved for. Can be
+	// empty when the address is only held back from allocation.
+	MachineID string `bson:"machine-id,omitempty"`
+}
+
+// IPAddressReservationArgs contains the arguments accepted by
+// State.AddIPAddressReservation().
+type IPAddressReservationArgs struct {
+	// Value is the IP address to reserve, without a CIDR mask.
+	Value string
+
+	// SubnetCIDR is the CIDR of the subnet containing Value. The subnet must
+	// exist, be alive, and be part of a space.
+	SubnetCIDR string
+
+	// MachineID is the optional ID of the machine the address is reserved
+	// for. When set, the machine must exist and be alive.
+	MachineID string
+}
+
+// IPAddressReservation represents an IP address reserved in one of the
+// subnets of a space. Reservations are kept when the machine they are for
+// is removed, until they are released.
+type IPAddressReservation struct {
+	st  *State
+	doc ipAddressReservationDoc
+}
+
+func newIPAddressReservation(st *State, doc ipAddressReservationDoc) *IPAddressReservation {
+	return &IPAddressReservation{st: st, doc: doc}
+}
+
+// DocID returns the globally unique ID of the reservation, including the
+// model UUID as prefix.
+func (r *IPAddressReservation) DocID() string {
+	return r.st.docID(r.doc.DocID)
+}
+
+// Value returns the reserved IP address.
+func (r *IPAddressReservation) Value() string {
+	return r.doc.Value
+}
+
+// SubnetCIDR returns the CIDR of the subnet the reserved address comes from.
+func (r *IPAddressReservation) SubnetCIDR() string {
+	return r.doc.SubnetCIDR
+}
+
+// Subnet returns the Subnet the reserved address comes from.
+func (r *IPAddressReservation) Subnet() (*Subnet, error) {
+	return r.st.Subnet(r.doc.SubnetCIDR)
+}
+
+// MachineID returns the ID of the machine the address is reserved for, if
+// any.
+func (r *IPAddressReservation) MachineID() string {
+	return r.doc.MachineID
+}
+
+// String returns a human-readable representation of the reservation.
+func (r *IPAddressReservation) String() string {
+	if r.doc.MachineID == "" {
+		return fmt.Sprintf("reserved address %q", r.doc.Value)
+	}
+	return fmt.Sprintf("reserved address %q for machine %q", r.doc.Value, r.doc.MachineID)
+}
+
+// Remove releases the reservation, if it exists. No error is returned when
+// the reservation was already removed.
+func (r *IPAddressReservation) Remove() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove %s", r)
+
+	ops := []txn.Op{{
+		C:      ipAddressReservationsC,
+		Id:     r.doc.DocID,
+		Remove: true,
+	}}
+	return r.st.runTransaction(ops)
+}
+
+// AddIPAddressReservation reserves the IP address described by args, so it is
+// requested when allocating static addresses for the given machine. When no
+// machine is given, the reservation only stops the address from being
+// reserved for any machine; the provider may still allocate it. Errors
+// satisfying errors.IsNotValid() are returned for invalid args,
+// errors.IsNotFound() when the subnet or machine do not exist, and
+// errors.IsAlreadyExists() when the address is already reserved or assigned
+// to a different machine.
+func (st *State) AddIPAddressReservation(args IPAddressReservationArgs) (_ *IPAddressReservation, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot reserve address %q", args.Value)
+
+	ip := net.ParseIP(args.Value)
+	if ip == nil {
+		return nil, errors.NotValidf("address value %q", args.Value)
+	}
+	_, ipNet, err := net.ParseCIDR(args.SubnetCIDR)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "SubnetCIDR")
+	}
+	if !ipNet.Contains(ip) {
+		return nil, errors.NotValidf("address outside of subnet %q", args.SubnetCIDR)
+	}
+
+	value := ip.String()
+	newDoc := ipAddressReservationDoc{
+		DocID:      st.docID(value),
+		ModelUUID:  st.ModelUUID(),
+		Value:      value,
+		SubnetCIDR: ipNet.String(),
+		MachineID:  args.MachineID,
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if _, err := st.IPAddressReservation(value); err == nil {
+				return nil, errors.AlreadyExistsf("reservation for address %q", value)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := checkModelActive(st); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		subnet, err := st.Subnet(newDoc.SubnetCIDR)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if subnet.Life() != Alive {
+			return nil, errors.Errorf("subnet %q is not alive", subnet.CIDR())
+		}
+		if subnet.SpaceName() == "" {
+			return nil, errors.NotValidf("subnet %q without a space", subnet.CIDR())
+		}
+		if err := st.verifyAddressNotInUse(value, newDoc.MachineID); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{
+			assertModelActiveOp(st.ModelUUID()),
+			{
+				C:      subnetsC,
+				Id:     st.docID(newDoc.SubnetCIDR),
+				Assert: isAliveDoc,
+			}, {
+				C:      ipAddressReservationsC,
+				Id:     newDoc.DocID,
+				Assert: txn.DocMissing,
+				Insert: newDoc,
+			},
+		}
+		if newDoc.MachineID != "" {
+			machine, err := st.Machine(newDoc.MachineID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := machine.isStillAlive(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, machine.assertAliveOp())
+		}
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newIPAddressReservation(st, newDoc), nil
+}
+
+// verifyAddressNotInUse returns an error satisfying errors.IsAlreadyExists()
+// when the given address value is assigned to a device of any machine other
+// than the one with machineID.
+func (st *State) verifyAddressNotInUse(value, machineID string) error {
+	addresses, closer := st.getCollection(ipAddressesC)
+	defer closer()
+
+	var doc ipAddressDoc
+	err := addresses.Find(bson.D{{"value", value}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if doc.MachineID == machineID {
+		return nil
+	}
+	return errors.AlreadyExistsf("address %q assigned to machine %q", value, doc.MachineID)
+}
+
+// IPAddressReservation returns the reservation of the given IP address value.
+// Returns an error satisfying errors.IsNotFound() if it does not exist.
+func (st *State) IPAddressReservation(value string) (*IPAddressReservation, error) {
+	reservations, closer := st.getCollection(ipAddressReservationsC)
+	defer closer()
+
+	var doc ipAddressReservationDoc
+	err := reservations.FindId(value).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("reservation for address %q", value)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get reservation for address %q", value)
+	}
+	return newIPAddressReservation(st, doc), nil
+}
+
+// AllIPAddressReservations returns all IP address reservations in the model.
+func (st *State) AllIPAddressReservations() ([]*IPAddressReservation, error) {
+	return st.findIPAddressReservations(nil)
+}
+
+// IPAddressReservations returns all IP addresses reserved for the machine.
+func (m *Machine) IPAddressReservations() ([]*IPAddressReservation, error) {
+	return m.st.findIPAddressReservations(bson.D{{"machine-id", m.doc.Id}})
+}
+
+func (st *State) findIPAddressReservations(findQuery bson.D) ([]*IPAddressReservation, error) {
+	reservations, closer := st.getCollection(ipAddressReservationsC)
+	defer closer()
+
+	var docs []ipAddressReservationDoc
+	if err := reservations.Find(findQuery).Sort("value").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get IP address reservations")
+	}
+	results := make([]*IPAddressReservation, len(docs))
+	for i, doc := range docs {
+		results[i] = newIPAddressReservation(st, doc)
+	}
+	return results, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+// ipAddressReservationsSuite contains tests for reserving IP addresses in the
+// subnets of a space, which include access to mongo.
+type ipAddressReservationsSuite struct {
+	ConnSuite
+
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&ipAddressReservationsSuite{})
+
+func (s *ipAddressReservationsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddSpace("dmz", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR:      "10.20.0.0/16",
+		SpaceName: "dmz",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSubnet(state.SubnetInfo{
+		CIDR: "10.30.0.0/24",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationSuccess(c *gc.C) {
+	reservation, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.20.0.42",
+		SubnetCIDR: "10.20.0.0/16",
+		MachineID:  s.machine.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reservation.Value(), gc.Equals, "10.20.0.42")
+	c.Check(reservation.SubnetCIDR(), gc.Equals, "10.20.0.0/16")
+	c.Check(reservation.MachineID(), gc.Equals, s.machine.Id())
+	c.Check(reservation.String(), gc.Equals, `reserved address "10.20.0.42" for machine "0"`)
+
+	result, err := s.State.IPAddressReservation("10.20.0.42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.DocID(), gc.Equals, reservation.DocID())
+
+	subnet, err := result.Subnet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnet.SpaceName(), gc.Equals, "dmz")
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationWithoutMachine(c *gc.C) {
+	reservation, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.20.0.42",
+		SubnetCIDR: "10.20.0.0/16",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reservation.MachineID(), gc.Equals, "")
+	c.Check(reservation.String(), gc.Equals, `reserved address "10.20.0.42"`)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsWithInvalidValue(c *gc.C) {
+	_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "bogus",
+		SubnetCIDR: "10.20.0.0/16",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot reserve address "bogus": address value "bogus" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsOutsideSubnet(c *gc.C) {
+	_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.99.0.1",
+		SubnetCIDR: "10.20.0.0/16",
+	})
+	c.Assert(err, gc.ErrorMatches, `.*address outside of subnet "10.20.0.0/16" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsWithUnknownSubnet(c *gc.C) {
+	_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.40.0.1",
+		SubnetCIDR: "10.40.0.0/24",
+	})
+	c.Assert(err, gc.ErrorMatches, `.*subnet "10.40.0.0/24" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsWithSubnetNotInSpace(c *gc.C) {
+	_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.30.0.5",
+		SubnetCIDR: "10.30.0.0/24",
+	})
+	c.Assert(err, gc.ErrorMatches, `.*subnet "10.30.0.0/24" without a space not valid`)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsWithUnknownMachine(c *gc.C) {
+	_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.20.0.42",
+		SubnetCIDR: "10.20.0.0/16",
+		MachineID:  "42",
+	})
+	c.Assert(err, gc.ErrorMatches, `.*machine 42 not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsWhenAlreadyReserved(c *gc.C) {
+	args := state.IPAddressReservationArgs{
+		Value:      "10.20.0.42",
+		SubnetCIDR: "10.20.0.0/16",
+	}
+	_, err := s.State.AddIPAddressReservation(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddIPAddressReservation(args)
+	c.Assert(err, gc.ErrorMatches, `.*reservation for address "10.20.0.42" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ipAddressReservationsSuite) TestAddIPAddressReservationFailsWhenAssignedToOtherMachine(c *gc.C) {
+	err := s.machine.SetLinkLayerDevices(state.LinkLayerDeviceArgs{
+		Name: "eth0",
+		Type: state.EthernetDevice,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetDevicesAddresses(state.LinkLayerDeviceAddress{
+		DeviceName:   "eth0",
+		ConfigMethod: state.StaticAddress,
+		CIDRAddress:  "10.20.0.42/16",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	otherMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.20.0.42",
+		SubnetCIDR: "10.20.0.0/16",
+		MachineID:  otherMachine.Id(),
+	})
+	c.Assert(err, gc.ErrorMatches, `.*address "10.20.0.42" assigned to machine "0" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ipAddressReservationsSuite) TestMachineIPAddressReservations(c *gc.C) {
+	for _, value := range []string{"10.20.0.3", "10.20.0.2"} {
+		_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+			Value:      value,
+			SubnetCIDR: "10.20.0.0/16",
+			MachineID:  s.machine.Id(),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.20.0.1",
+		SubnetCIDR: "10.20.0.0/16",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	reservations, err := s.machine.IPAddressReservations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reservations, gc.HasLen, 2)
+	c.Check(reservations[0].Value(), gc.Equals, "10.20.0.2")
+	c.Check(reservations[1].Value(), gc.Equals, "10.20.0.3")
+
+	all, err := s.State.AllIPAddressReservations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 3)
+}
+
+func (s *ipAddressReservationsSuite) TestRemoveTwiceStillSucceeds(c *gc.C) {
+	reservation, err := s.State.AddIPAddressReservation(state.IPAddressReservationArgs{
+		Value:      "10.20.0.42",
+		SubnetCIDR: "10.20.0.0/16",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(reservation.Remove(), jc.ErrorIsNil)
+	c.Assert(reservation.Remove(), jc.ErrorIsNil)
+
+	_, err = s.State.IPAddressReservation("10.20.0.42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -147,6 +147,13 @@ func (addr *Address) Subnet() (*Subnet, error) {
 	return addr.st.Subnet(addr.doc.SubnetCIDR)
 }
 
+// Reservation returns the IPAddressReservation matching the value of this IP
+// address. Returns an error satisfying errors.IsNotFound() when the address is
+// not reserved.
+func (addr *Address) Reservation() (*IPAddressReservation, error) {
+	return addr.st.IPAddressReservation(addr.doc.Value)
+}
+
 // ConfigMethod returns the AddressConfigMethod used for this IP address.
 func (addr *Address) ConfigMethod() AddressConfigMethod {
 	return addr.doc.ConfigMethod
@@ -61,9 +61,15 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// The charm repository is controller global; models hold
+		// their own copies of any repository charms they use.
+		repositoryCharmsC,
+		repositoryCharmArchivesC,
 		// Users aren't migrated.
 		usersC,
+		userGroupsC,
 		userLastLoginC,
+		userLoginHistoryC,
 		// userenvnameC is just to provide a unique key constraint.
 		usermodelnameC,
 		// Metrics aren't migrated.
@@ -73,6 +79,8 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// upgradeInfoC is used to coordinate upgrades and schema migrations,
 		// and aren't needed for model migrations.
 		upgradeInfoC,
+		// Upgrade plans only last for the duration of an upgrade.
+		upgradePlansC,
 		// Not exported, but the tools will possibly need to be either bundled
 		// with the representation or sent separately.
 		toolsmetadataC,
@@ -129,6 +137,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		"payloads",
 		"resources",
 		endpointBindingsC,
+		charmUpgradePoliciesC,
 
 		// storage
 		blockDevicesC,
@@ -142,6 +151,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// network
 		ipAddressesC,
+		ipAddressReservationsC,
 		providerIDsC,
 		linkLayerDevicesC,
 		linkLayerDevicesRefsC,
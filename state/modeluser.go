@@ -321,8 +321,14 @@ func (st *State) RemoveModelUser(user names.UserTag) error {
 			Assert: txn.DocExists,
 			Remove: true,
 		}}
+	// Access granted on applications goes with the model user.
+	appOps, err := st.removeUserApplicationPermissionsOps(user)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, appOps...)
 
-	err := st.runTransaction(ops)
+	err = st.runTransaction(ops)
 	if err == txn.ErrAborted {
 		err = errors.NewNotFound(nil, fmt.Sprintf("model user %q does not exist", user.Canonical()))
 	}
@@ -356,11 +362,11 @@ func (e *UserModel) LastConnection() (time.Time, error) {
 }
 
 // ModelsForUser returns a list of models that the user
-// is able to access.
+// is able to access, either as a model user or through a group.
 func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
-	// Since there are no groups at this stage, the simplest way to get all
-	// the models that a particular user can see is to look through the
-	// model user collection. A raw collection is required to support
+	// The models that a particular user can see are those in which
+	// they are a model user, along with those their groups have been
+	// granted access to. Raw collections are required to support
 	// queries across multiple models.
 	modelUsers, userCloser := st.getRawCollection(modelUsersC)
 	defer userCloser()
@@ -370,10 +376,46 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	if err != nil {
 		return nil, err
 	}
+	var modelUUIDs []string
+	seen := make(map[string]bool)
+	for _, doc := range userSlice {
+		modelUUIDs = append(modelUUIDs, doc.ModelUUID)
+		seen[doc.ModelUUID] = true
+	}
+
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(groups) > 0 {
+		groupKeys := make([]string, len(groups))
+		for i, group := range groups {
+			groupKeys[i] = userGroupGlobalKey(group.doc.DocID)
+		}
+		permissions, permCloser := st.getRawCollection(permissionsC)
+		defer permCloser()
+
+		var permSlice []struct {
+			ModelUUID string `bson:"model-uuid"`
+		}
+		err := permissions.Find(bson.D{
+			{"object-global-key", modelGlobalKey},
+			{"subject-global-key", bson.D{{"$in", groupKeys}}},
+		}).Select(bson.D{{"model-uuid", 1}}).All(&permSlice)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, doc := range permSlice {
+			if !seen[doc.ModelUUID] {
+				modelUUIDs = append(modelUUIDs, doc.ModelUUID)
+				seen[doc.ModelUUID] = true
+			}
+		}
+	}
 
 	var result []*UserModel
-	for _, doc := range userSlice {
-		modelTag := names.NewModelTag(doc.ModelUUID)
+	for _, modelUUID := range modelUUIDs {
+		modelTag := names.NewModelTag(modelUUID)
 		env, err := st.GetModel(modelTag)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -385,9 +427,17 @@ func (st *State) ModelsForUser(user names.UserTag) ([]*UserModel, error) {
 	return result, nil
 }
 
-// IsControllerAdministrator returns true if the user specified has access to the
-// controller model (the system model).
+// IsControllerAdministrator returns true if the user specified has
+// superuser access to the controller, directly or through a group, or
+// admin access to the controller model (the system model).
 func (st *State) IsControllerAdministrator(user names.UserTag) (bool, error) {
+	access, err := st.EffectiveControllerAccess(user)
+	if err == nil && access == SuperuserAccess {
+		return true, nil
+	} else if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+
 	ssinfo, err := st.ControllerInfo()
 	if err != nil {
 		return false, errors.Annotate(err, "could not get controller info")
@@ -228,6 +228,7 @@ func Initialize(args InitializeParams) (_ *State, err error) {
 
 	ops := []txn.Op{
 		createInitialUserOp(st, args.ControllerModelArgs.Owner, args.MongoInfo.Password, salt),
+		createPermissionOp(controllerGlobalKey, controllerUserID(args.ControllerModelArgs.Owner), SuperuserAccess),
 		{
 			C:      controllersC,
 			Id:     modelGlobalKey,
@@ -43,6 +43,34 @@ type Resources interface {
 	// OpenResourceForUniter returns the metadata for a resource and a reader for the resource.
 	OpenResourceForUniter(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error)
 
+	// ResourceHistory returns the previous revisions of the identified
+	// resource, most recent first.
+	ResourceHistory(applicationID, name string) ([]resource.HistoryEntry, error)
+
+	// RollbackResource makes the previous revision of the resource with
+	// the given history index the active revision again.
+	RollbackResource(applicationID, name string, index int) error
+
+	// ListCachedResources returns the charm store resources that were
+	// fetched ahead of time for the model's applications.
+	ListCachedResources() ([]resource.CachedResource, error)
+
+	// CacheResource stores the content of the charm store resource in
+	// the model ahead of time.
+	CacheResource(applicationID string, chRes charmresource.Resource, r io.Reader) (resource.CachedResource, error)
+
+	// OpenCachedResource returns the metadata for a cached charm store
+	// resource and a reader for its content.
+	OpenCachedResource(applicationID, name string, revision int) (resource.CachedResource, io.ReadCloser, error)
+
+	// TouchCachedResource records that the cached charm store resource
+	// is still wanted.
+	TouchCachedResource(applicationID, name string, revision int) error
+
+	// EvictCachedResource removes the cached charm store resource from
+	// the model.
+	EvictCachedResource(applicationID, name string, revision int) error
+
 	// SetCharmStoreResources sets the "polled" resources for the
 	// service to the provided values.
 	SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error
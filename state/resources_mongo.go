@@ -5,6 +5,8 @@ package state
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -20,6 +22,10 @@ const (
 
 	resourcesStagedIDSuffix     = "#staged"
 	resourcesCharmstoreIDSuffix = "#charmstore"
+
+	// resourceHistorySize is the number of previous revisions kept
+	// for each application resource.
+	resourceHistorySize = 5
 )
 
 // resourceID converts an external resource ID into an internal one.
@@ -46,6 +52,16 @@ func unitResourceID(id, unitID string) string {
 	return resourceID(id, "unit", unitID)
 }
 
+func historyResourceID(id string, index int) string {
+	return resourceID(id, "history", strconv.Itoa(index))
+}
+
+// cachedResourceID converts an external resource ID into an internal
+// one for the cached charm store revision of the resource.
+func cachedResourceID(id string, revision int) string {
+	return resourceID(id, "cache", strconv.Itoa(revision))
+}
+
 // stagedResourceID converts an external resource ID into an internal
 // staged one.
 func stagedResourceID(id string) string {
@@ -189,6 +205,61 @@ func newRemoveResourcesOps(docs []resourceDoc) []txn.Op {
 	return ops
 }
 
+// newSupersedeResourceOps generates transaction operations that add
+// the current revision of a resource to the given history of the
+// resource, dropping the oldest revisions once there are more than
+// resourceHistorySize of them. The revision with the exclude index is
+// not counted, since it is about to leave the history. The storage
+// paths of the dropped revisions are also returned, so that their
+// blobs may be removed.
+func newSupersedeResourceOps(current resourceDoc, history []resourceDoc, exclude int, superseded time.Time) ([]txn.Op, []string) {
+	index := 1
+	var kept []resourceDoc
+	for _, doc := range history {
+		if doc.HistoryIndex >= index {
+			index = doc.HistoryIndex + 1
+		}
+		if doc.HistoryIndex != exclude {
+			kept = append(kept, doc)
+		}
+	}
+	sort.Sort(byHistoryIndex(kept))
+
+	doc := current // a copy
+	doc.DocID = historyResourceID(current.ID, index)
+	doc.HistoryIndex = index
+	doc.Superseded = superseded
+	ops := []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+
+	var storagePaths []string
+	for len(kept) >= resourceHistorySize {
+		oldest := kept[0]
+		kept = kept[1:]
+		ops = append(ops, txn.Op{
+			C:      resourcesC,
+			Id:     oldest.DocID,
+			Remove: true,
+		})
+		// Older revisions may share their blob with the current one.
+		if oldest.StoragePath != "" && oldest.StoragePath != current.StoragePath {
+			storagePaths = append(storagePaths, oldest.StoragePath)
+		}
+	}
+	return ops, storagePaths
+}
+
+// byHistoryIndex sorts resource history docs, oldest first.
+type byHistoryIndex []resourceDoc
+
+func (docs byHistoryIndex) Len() int           { return len(docs) }
+func (docs byHistoryIndex) Swap(i, j int)      { docs[i], docs[j] = docs[j], docs[i] }
+func (docs byHistoryIndex) Less(i, j int) bool { return docs[i].HistoryIndex < docs[j].HistoryIndex }
+
 // newResolvePendingResourceOps generates transaction operations that
 // will resolve a pending resource doc and make it active.
 //
@@ -265,6 +336,20 @@ func (p ResourcePersistence) resources(applicationID string) ([]resourceDoc, err
 	return docs, nil
 }
 
+// resourceHistory returns the history docs for the given resource.
+func (p ResourcePersistence) resourceHistory(resID string) ([]resourceDoc, error) {
+	logger.Tracef("querying db for history of resource %q", resID)
+	var docs []resourceDoc
+	query := bson.D{
+		{"resource-id", resID},
+		{"history-index", bson.D{{"$gt", 0}}},
+	}
+	if err := p.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
+
 func (p ResourcePersistence) unitResources(unitID string) ([]resourceDoc, error) {
 	var docs []resourceDoc
 	query := bson.D{{"unit-id", unitID}}
@@ -323,6 +408,16 @@ type resourceDoc struct {
 	DownloadProgress *int64 `bson:"download-progress,omitempty"`
 
 	LastPolled time.Time `bson:"timestamp-when-last-polled"`
+
+	// HistoryIndex and Superseded are only set for the previous
+	// revisions of a resource, kept in the resource's history.
+	HistoryIndex int       `bson:"history-index,omitempty"`
+	Superseded   time.Time `bson:"timestamp-when-superseded,omitempty"`
+
+	// Cached and LastUsed are only set for the charm store revisions
+	// of a resource that were fetched ahead of time.
+	Cached   bool      `bson:"cached,omitempty"`
+	LastUsed time.Time `bson:"timestamp-when-last-used,omitempty"`
 }
 
 func charmStoreResource2Doc(id string, res charmStoreResource) *resourceDoc {
@@ -373,6 +468,60 @@ func resource2doc(id string, stored storedResource) *resourceDoc {
 	}
 }
 
+// newInsertCachedResourceOps returns the transaction operations that
+// add the cached resource.
+func newInsertCachedResourceOps(res resource.CachedResource, storagePath string) []txn.Op {
+	doc := cachedResource2doc(res, storagePath)
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+}
+
+// cachedResource2doc converts the cached resource into a DB doc.
+func cachedResource2doc(res resource.CachedResource, storagePath string) *resourceDoc {
+	id := fmt.Sprintf("%s/%s", res.ApplicationID, res.Name)
+	return &resourceDoc{
+		DocID: cachedResourceID(id, res.Revision),
+		ID:    id,
+
+		ApplicationID: res.ApplicationID,
+
+		Name:        res.Name,
+		Type:        res.Type.String(),
+		Path:        res.Path,
+		Description: res.Description,
+
+		Origin:      res.Origin.String(),
+		Revision:    res.Revision,
+		Fingerprint: res.Fingerprint.Bytes(),
+		Size:        res.Size,
+
+		Timestamp: res.Fetched,
+
+		StoragePath: storagePath,
+
+		Cached:   true,
+		LastUsed: res.LastUsed,
+	}
+}
+
+// doc2cachedResource returns the cached resource represented by the doc.
+func doc2cachedResource(doc resourceDoc) (resource.CachedResource, error) {
+	res, err := doc2basicResource(doc)
+	if err != nil {
+		return resource.CachedResource{}, errors.Trace(err)
+	}
+	return resource.CachedResource{
+		Resource:      res.Resource,
+		ApplicationID: doc.ApplicationID,
+		Fetched:       doc.Timestamp,
+		LastUsed:      doc.LastUsed,
+	}, nil
+}
+
 // doc2resource returns the resource info represented by the doc.
 func doc2resource(doc resourceDoc) (storedResource, error) {
 	res, err := doc2basicResource(doc)
@@ -4,12 +4,14 @@
 package state
 
 import (
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/resource"
@@ -78,7 +80,15 @@ func (p ResourcePersistence) ListResources(applicationID string) (resource.Servi
 
 	var results resource.ServiceResources
 	for _, doc := range docs {
-		if doc.PendingID != "" {
+		if doc.Cached {
+			cached, err := doc2cachedResource(doc)
+			if err != nil {
+				return resource.ServiceResources{}, errors.Trace(err)
+			}
+			results.Cache = append(results.Cache, cached)
+			continue
+		}
+		if doc.PendingID != "" || doc.HistoryIndex != 0 {
 			continue
 		}
 
@@ -359,13 +369,113 @@ func (p ResourcePersistence) NewResolvePendingResourceOps(resID, pendingID strin
 	}
 
 	exists := true
-	if _, err := p.getOne(resID); errors.IsNotFound(err) {
+	current, err := p.getOne(resID)
+	if errors.IsNotFound(err) {
 		exists = false
 	} else if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	ops := newResolvePendingResourceOps(pending, exists)
+	if exists && !current.Timestamp.IsZero() {
+		historyOps, err := p.newSupersedeResourceOps(current, 0)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, historyOps...)
+	}
+	return ops, nil
+}
+
+// ListResourceHistory returns the previous revisions of the identified
+// resource, most recent first.
+func (p ResourcePersistence) ListResourceHistory(id string) ([]resource.HistoryEntry, error) {
+	docs, err := p.resourceHistory(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Sort(sort.Reverse(byHistoryIndex(docs)))
+
+	var history []resource.HistoryEntry
+	for _, doc := range docs {
+		res, err := doc2basicResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		history = append(history, resource.HistoryEntry{
+			Resource:   res,
+			Index:      doc.HistoryIndex,
+			Superseded: doc.Superseded,
+		})
+	}
+	return history, nil
+}
+
+// RollbackResource makes the previous revision of the resource with
+// the given history index the active revision again. The replaced
+// revision is added to the resource's history, and the application's
+// CharmModifiedVersion is incremented so that its units run their
+// upgrade-charm hook.
+func (p ResourcePersistence) RollbackResource(id string, index int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		var target resourceDoc
+		err := p.base.One(resourcesC, historyResourceID(id, index), &target)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("revision %d of resource %q", index, id)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		restored, err := doc2resource(target)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{{
+			C:      resourcesC,
+			Id:     target.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}
+		current, err := p.getOne(id)
+		switch {
+		case errors.IsNotFound(err):
+			ops = append(ops, newInsertResourceOps(restored)...)
+		case err != nil:
+			return nil, errors.Trace(err)
+		default:
+			if !current.Timestamp.IsZero() {
+				historyOps, err := p.newSupersedeResourceOps(current, index)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, historyOps...)
+			}
+			ops = append(ops, newUpdateResourceOps(restored)...)
+		}
+		ops = append(ops, p.base.ApplicationExistsOps(restored.ApplicationID)...)
+		ops = append(ops, p.base.IncCharmModifiedVersionOps(restored.ApplicationID)...)
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// newSupersedeResourceOps returns mgo transaction operations that add
+// the current revision of the resource to its history. The revision
+// with the exclude index is about to leave the history, so it does not
+// count towards the history's size.
+func (p ResourcePersistence) newSupersedeResourceOps(current resourceDoc, exclude int) ([]txn.Op, error) {
+	history, err := p.resourceHistory(current.ID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// TODO(perrito666) 2016-05-02 lp:1558657
+	ops, storagePaths := newSupersedeResourceOps(current, history, exclude, time.Now().UTC())
+	for _, storagePath := range storagePaths {
+		ops = append(ops, p.base.NewCleanupOp(CleanupKindResourceBlob, storagePath))
+	}
 	return ops, nil
 }
 
@@ -397,3 +507,105 @@ func (p ResourcePersistence) NewRemoveResourcesOps(applicationID string) ([]txn.
 	}
 	return ops, nil
 }
+
+// ListCachedResources returns the charm store resources that were
+// fetched ahead of time for all the model's applications.
+func (p ResourcePersistence) ListCachedResources() ([]resource.CachedResource, error) {
+	var docs []resourceDoc
+	query := bson.D{{"cached", true}}
+	if err := p.base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var cache []resource.CachedResource
+	for _, doc := range docs {
+		cached, err := doc2cachedResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cache = append(cache, cached)
+	}
+	return cache, nil
+}
+
+// GetCachedResource returns the cached charm store revision of the
+// identified resource, along with the path of its blob.
+func (p ResourcePersistence) GetCachedResource(id string, revision int) (res resource.CachedResource, storagePath string, _ error) {
+	var doc resourceDoc
+	if err := p.base.One(resourcesC, cachedResourceID(id, revision), &doc); err != nil {
+		return res, "", errors.Trace(err)
+	}
+	res, err := doc2cachedResource(doc)
+	if err != nil {
+		return res, "", errors.Trace(err)
+	}
+	return res, doc.StoragePath, nil
+}
+
+// SetCachedResource records the charm store resource as fetched ahead
+// of time, with its blob stored at the given path.
+func (p ResourcePersistence) SetCachedResource(res resource.CachedResource, storagePath string) error {
+	if err := res.Validate(); err != nil {
+		return errors.Annotate(err, "bad resource")
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			id := cachedResource2doc(res, storagePath).ID
+			if _, _, err := p.GetCachedResource(id, res.Revision); err == nil {
+				return nil, errors.AlreadyExistsf("cached revision %d of resource %q", res.Revision, id)
+			}
+			return nil, errors.NotFoundf("application %q", res.ApplicationID)
+		}
+		ops := newInsertCachedResourceOps(res, storagePath)
+		ops = append(ops, p.base.ApplicationExistsOps(res.ApplicationID)...)
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// TouchCachedResource records that the cached charm store revision of
+// the identified resource was needed at the given time.
+func (p ResourcePersistence) TouchCachedResource(id string, revision int, lastUsed time.Time) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			return nil, errors.NotFoundf("cached revision %d of resource %q", revision, id)
+		}
+		return []txn.Op{{
+			C:      resourcesC,
+			Id:     cachedResourceID(id, revision),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"timestamp-when-last-used", lastUsed}}}},
+		}}, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveCachedResource evicts the cached charm store revision of the
+// identified resource. Its blob is removed by a cleanup.
+func (p ResourcePersistence) RemoveCachedResource(id string, revision int) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, storagePath, err := p.GetCachedResource(id, revision)
+		if errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      resourcesC,
+			Id:     cachedResourceID(id, revision),
+			Assert: txn.DocExists,
+			Remove: true,
+		}, p.base.NewCleanupOp(CleanupKindResourceBlob, storagePath)}, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
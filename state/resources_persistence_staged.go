@@ -73,6 +73,14 @@ func (staged StagedResource) Activate() error {
 			ops = newInsertResourceOps(staged.stored)
 		case 1:
 			ops = newUpdateResourceOps(staged.stored)
+			if staged.stored.PendingID == "" {
+				// Keep the revision being replaced in the history.
+				historyOps, err := staged.newSupersedeResourceOps()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, historyOps...)
+			}
 		default:
 			return nil, errors.New("setting the resource failed")
 		}
@@ -106,6 +114,23 @@ func (staged StagedResource) Activate() error {
 	return nil
 }
 
+// newSupersedeResourceOps returns the operations that add the active
+// revision of the resource, if it is not a placeholder, to its history.
+func (staged StagedResource) newSupersedeResourceOps() ([]txn.Op, error) {
+	var current resourceDoc
+	err := staged.base.One(resourcesC, applicationResourceID(staged.stored.ID), &current)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "couldn't read existing resource")
+	}
+	if current.Timestamp.IsZero() {
+		return nil, nil
+	}
+	p := ResourcePersistence{base: staged.base}
+	return p.newSupersedeResourceOps(current, 0)
+}
+
 func (staged StagedResource) hasNewBytes() (bool, error) {
 	var current resourceDoc
 	err := staged.base.One(resourcesC, staged.stored.ID, &current)
@@ -144,12 +144,12 @@ func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
 	ignoredErr := errors.New("<never reached>")
-	s.stub.SetErrors(nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, ignoredErr)
+	s.stub.SetErrors(nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction", "One", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
 	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
 		C:      "resources",
@@ -165,8 +165,9 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		Id:     "resource#a-application/spam#staged",
 		Remove: true,
 	}})
-	s.stub.CheckCall(c, 7, "IncCharmModifiedVersionOps", "a-application")
-	s.stub.CheckCall(c, 8, "RunTransaction", []txn.Op{{
+	s.stub.CheckCall(c, 5, "One", "resources", "resource#a-application/spam", &resourceDoc{})
+	s.stub.CheckCall(c, 8, "IncCharmModifiedVersionOps", "a-application")
+	s.stub.CheckCall(c, 9, "RunTransaction", []txn.Op{{
 		C:      "resources",
 		Id:     "resource#a-application/spam",
 		Assert: txn.DocExists,
@@ -186,3 +187,27 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		Remove: true,
 	}})
 }
+
+func (s *StagedResourceSuite) TestActivateKeepsHistory(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	current.StoragePath = "application-a-application/resources/spam-previous"
+	s.base.ReturnOne = current
+	ignoredErr := errors.New("<never reached>")
+	// The content is unchanged, so CharmModifiedVersion is not
+	// incremented.
+	s.stub.SetErrors(nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "RunTransaction", "One", "All", "ApplicationExistsOps", "One", "RunTransaction")
+	ops := s.stub.Calls()[8].Args[0].([]txn.Op)
+	c.Assert(ops, gc.HasLen, 5)
+	c.Check(ops[1].Insert, jc.DeepEquals, &doc)
+	history := ops[2].Insert.(*resourceDoc)
+	c.Check(ops[2].Id, gc.Equals, "resource#a-application/spam#history-1")
+	c.Check(history.HistoryIndex, gc.Equals, 1)
+	c.Check(history.StoragePath, gc.Equals, "application-a-application/resources/spam-previous")
+	c.Check(history.Superseded.IsZero(), jc.IsFalse)
+}
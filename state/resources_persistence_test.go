@@ -4,12 +4,14 @@
 package state
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -481,7 +483,15 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 	res := ops[4].Insert.(*resourceDoc)
 	res.LastPolled = res.LastPolled.Round(time.Second)
 
-	s.stub.CheckCallNames(c, "One", "One")
+	// The resource being replaced is kept in its history.
+	historyDoc := doc
+	historyDoc.DocID = "resource#a-application/spam#history-1"
+	historyDoc.HistoryIndex = 1
+	historyDoc.Superseded = lastPolled
+	superseded := ops[5].Insert.(*resourceDoc)
+	superseded.Superseded = superseded.Superseded.Round(time.Second)
+
+	s.stub.CheckCallNames(c, "One", "One", "All")
 	s.stub.CheckCall(c, 0, "One", "resources", "resource#a-application/spam#pending-some-unique-ID-001", &doc)
 	c.Check(ops, jc.DeepEquals, []txn.Op{
 		{
@@ -512,6 +522,12 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 			Assert: txn.DocMissing,
 			Insert: &csresourceDoc,
 		},
+		{
+			C:      "resources",
+			Id:     historyDoc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &historyDoc,
+		},
 	})
 }
 
@@ -565,6 +581,269 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *
 	})
 }
 
+func (s *ResourcePersistenceSuite) TestListResourcesIgnoreHistory(c *gc.C) {
+	expected, docs := newPersistenceResources(c, "a-application", "spam")
+	_, historyDoc := newPersistenceResource(c, "a-application", "spam")
+	historyDoc.DocID = historyResourceID(historyDoc.ID, 1)
+	historyDoc.HistoryIndex = 1
+	s.base.ReturnAll = append(docs, historyDoc)
+	p := NewResourcePersistence(s.base)
+
+	resources, err := p.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkResources(c, resources, expected)
+}
+
+func (s *ResourcePersistenceSuite) TestListResourceHistory(c *gc.C) {
+	var docs []resourceDoc
+	var expected []resource.HistoryEntry
+	for index := 1; index <= 3; index++ {
+		stored, doc := newPersistenceResource(c, "a-application", "spam")
+		doc.DocID = historyResourceID(doc.ID, index)
+		doc.HistoryIndex = index
+		doc.Superseded = stored.Timestamp
+		docs = append(docs, doc)
+		expected = append([]resource.HistoryEntry{{
+			Resource:   stored.Resource,
+			Index:      index,
+			Superseded: stored.Timestamp,
+		}}, expected...)
+	}
+	s.base.ReturnAll = docs
+	p := NewResourcePersistence(s.base)
+
+	history, err := p.ListResourceHistory("a-application/spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	s.stub.CheckCall(c, 0, "All",
+		"resources",
+		bson.D{
+			{"resource-id", "a-application/spam"},
+			{"history-index", bson.D{{"$gt", 0}}},
+		},
+		&docs,
+	)
+	c.Check(history, jc.DeepEquals, expected)
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResource(c *gc.C) {
+	_, target := newPersistenceResource(c, "a-application", "spam")
+	target.DocID = historyResourceID(target.ID, 2)
+	target.StoragePath += "-previous"
+	target.HistoryIndex = 2
+	target.Superseded = target.Timestamp
+	s.base.ReturnOne = target
+	s.base.ReturnAll = []resourceDoc{target}
+	s.base.ReturnIncCharmModifiedVersionOps = []txn.Op{{
+		C:      "application",
+		Id:     "a-application",
+		Update: bson.D{{"$inc", bson.D{{"charmmodifiedversion", 1}}}},
+	}}
+	p := NewResourcePersistence(s.base)
+
+	err := p.RollbackResource("a-application/spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "One", "All", "ApplicationExistsOps", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCall(c, 1, "One", "resources", "resource#a-application/spam#history-2", &target)
+	ops := s.stub.Calls()[6].Args[0].([]txn.Op)
+	c.Assert(ops, gc.HasLen, 5)
+	c.Check(ops[0], jc.DeepEquals, txn.Op{
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-2",
+		Assert: txn.DocExists,
+		Remove: true,
+	})
+	// The replaced revision gets a new index in the history.
+	c.Check(ops[1].Id, gc.Equals, "resource#a-application/spam#history-3")
+	restored := ops[3].Insert.(*resourceDoc)
+	c.Check(ops[3].Id, gc.Equals, "resource#a-application/spam")
+	c.Check(restored.HistoryIndex, gc.Equals, 0)
+	c.Check(restored.Superseded.IsZero(), jc.IsTrue)
+	c.Check(restored.StoragePath, gc.Equals, "application-a-application/resources/spam-previous")
+	c.Check(ops[4], jc.DeepEquals, s.base.ReturnIncCharmModifiedVersionOps[0])
+}
+
+func (s *ResourcePersistenceSuite) TestRollbackResourceNotFound(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+
+	err := p.RollbackResource("a-application/spam", 2)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 2 of resource "a-application/spam" not found`)
+}
+
+func (s *ResourcePersistenceSuite) TestSupersedeResourceOpsDropsOldest(c *gc.C) {
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	var history []resourceDoc
+	for index := 1; index <= resourceHistorySize; index++ {
+		_, doc := newPersistenceResource(c, "a-application", "spam")
+		doc.DocID = historyResourceID(doc.ID, index)
+		doc.StoragePath += fmt.Sprintf("-%d", index)
+		doc.HistoryIndex = index
+		history = append(history, doc)
+	}
+	superseded := time.Now().UTC()
+
+	ops, storagePaths := newSupersedeResourceOps(current, history, 0, superseded)
+
+	c.Assert(ops, gc.HasLen, 2)
+	c.Check(ops[0].Id, gc.Equals, historyResourceID(current.ID, resourceHistorySize+1))
+	c.Check(ops[1], jc.DeepEquals, txn.Op{
+		C:      "resources",
+		Id:     historyResourceID(current.ID, 1),
+		Remove: true,
+	})
+	c.Check(storagePaths, jc.DeepEquals, []string{"application-a-application/resources/spam-1"})
+
+	// A revision that is leaving the history does not count.
+	ops, storagePaths = newSupersedeResourceOps(current, history, 3, superseded)
+	c.Check(ops, gc.HasLen, 1)
+	c.Check(storagePaths, gc.HasLen, 0)
+}
+
+func (s *ResourcePersistenceSuite) TestListResourcesCached(c *gc.C) {
+	expected, docs := newPersistenceResources(c, "a-application", "spam")
+	cached, cachedDoc := newPersistenceCachedResource(c, "a-application", "spam", 2)
+	s.base.ReturnAll = append(docs, cachedDoc)
+	p := NewResourcePersistence(s.base)
+
+	resources, err := p.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	checkResources(c, resources, expected)
+	c.Check(resources.Cache, jc.DeepEquals, []resource.CachedResource{cached})
+}
+
+func (s *ResourcePersistenceSuite) TestListCachedResources(c *gc.C) {
+	cached, doc := newPersistenceCachedResource(c, "a-application", "spam", 2)
+	docs := []resourceDoc{doc}
+	s.base.ReturnAll = docs
+	p := NewResourcePersistence(s.base)
+
+	cache, err := p.ListCachedResources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All")
+	s.stub.CheckCall(c, 0, "All", "resources", bson.D{{"cached", true}}, &docs)
+	c.Check(cache, jc.DeepEquals, []resource.CachedResource{cached})
+}
+
+func (s *ResourcePersistenceSuite) TestSetCachedResourceOkay(c *gc.C) {
+	cached, doc := newPersistenceCachedResource(c, "a-application", "spam", 2)
+	p := NewResourcePersistence(s.base)
+
+	err := p.SetCachedResource(cached, doc.StoragePath)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "RunTransaction")
+	s.stub.CheckCall(c, 2, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-application/spam#cache-2",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "application",
+		Id:     "a-application",
+		Assert: txn.DocExists,
+	}})
+}
+
+func (s *ResourcePersistenceSuite) TestSetCachedResourceExists(c *gc.C) {
+	cached, doc := newPersistenceCachedResource(c, "a-application", "spam", 2)
+	s.base.ReturnOne = doc
+	s.stub.SetErrors(nil, nil, txn.ErrAborted)
+	p := NewResourcePersistence(s.base)
+
+	err := p.SetCachedResource(cached, doc.StoragePath)
+
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "RunTransaction", "One")
+}
+
+func (s *ResourcePersistenceSuite) TestTouchCachedResourceNotFound(c *gc.C) {
+	s.stub.SetErrors(nil, txn.ErrAborted)
+	p := NewResourcePersistence(s.base)
+
+	err := p.TouchCachedResource("a-application/spam", 2, time.Now().UTC())
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	s.stub.CheckCallNames(c, "Run", "RunTransaction")
+}
+
+func (s *ResourcePersistenceSuite) TestRemoveCachedResourceOkay(c *gc.C) {
+	_, doc := newPersistenceCachedResource(c, "a-application", "spam", 2)
+	s.base.ReturnOne = doc
+	cleanupOp := txn.Op{C: "cleanups", Id: "some-cleanup"}
+	s.base.ReturnNewCleanupOp = &cleanupOp
+	p := NewResourcePersistence(s.base)
+
+	err := p.RemoveCachedResource("a-application/spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "NewCleanupOp", "RunTransaction")
+	s.stub.CheckCall(c, 2, "NewCleanupOp", CleanupKindResourceBlob, "application-a-application/resources/spam-cache-2")
+	s.stub.CheckCall(c, 3, "RunTransaction", []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-application/spam#cache-2",
+		Assert: txn.DocExists,
+		Remove: true,
+	}, cleanupOp})
+}
+
+func (s *ResourcePersistenceSuite) TestRemoveCachedResourceNotCached(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+
+	err := p.RemoveCachedResource("a-application/spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One")
+}
+
+func (s *ResourcePersistenceSuite) TestNewRemoveResourcesOpsIncludesCache(c *gc.C) {
+	_, doc := newPersistenceResource(c, "a-application", "spam")
+	_, cachedDoc := newPersistenceCachedResource(c, "a-application", "spam", 2)
+	docs := []resourceDoc{doc, cachedDoc}
+	s.base.ReturnAll = docs
+	cleanupOp := txn.Op{C: "cleanups", Id: "some-cleanup"}
+	s.base.ReturnNewCleanupOp = &cleanupOp
+	p := NewResourcePersistence(s.base)
+
+	ops, err := p.NewRemoveResourcesOps("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "All", "NewCleanupOp", "NewCleanupOp")
+	s.stub.CheckCall(c, 0, "All", "resources", bson.D{{"application-id", "a-application"}}, &docs)
+	s.stub.CheckCall(c, 1, "NewCleanupOp", CleanupKindResourceBlob, doc.StoragePath)
+	s.stub.CheckCall(c, 2, "NewCleanupOp", CleanupKindResourceBlob, cachedDoc.StoragePath)
+	c.Check(ops, jc.DeepEquals, []txn.Op{{
+		C:      "resources",
+		Id:     doc.DocID,
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     cachedDoc.DocID,
+		Remove: true,
+	}, cleanupOp, cleanupOp})
+}
+
+func newPersistenceCachedResource(c *gc.C, serviceID, name string, revision int) (resource.CachedResource, resourceDoc) {
+	stored, _ := newPersistenceResource(c, serviceID, name)
+	chRes := stored.Resource.Resource
+	chRes.Origin = charmresource.OriginStore
+	chRes.Revision = revision
+	fetched := time.Now().UTC()
+	cached := resource.CachedResource{
+		Resource:      chRes,
+		ApplicationID: serviceID,
+		Fetched:       fetched,
+		LastUsed:      fetched,
+	}
+	storagePath := fmt.Sprintf("application-%s/resources/%s-cache-%d", serviceID, name, revision)
+	return cached, *cachedResource2doc(cached, storagePath)
+}
+
 func newPersistenceUnitResources(c *gc.C, serviceID, unitID string, resources []resource.Resource) ([]resource.Resource, []resourceDoc) {
 	var unitResources []resource.Resource
 	var docs []resourceDoc
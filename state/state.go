@@ -1058,6 +1058,14 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		ops = append(ops, resOps...)
 	}
 
+	// Access granted on an earlier application of the same name must
+	// not carry over to this one.
+	permOps, err := st.removeApplicationPermissionsOps(args.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, permOps...)
+
 	// Collect unit-adding operations.
 	for x := 0; x < args.NumUnits; x++ {
 		unitName, unitOps, err := svc.addServiceUnitOps(applicationAddUnitOpsArgs{cons: args.Constraints, storageCons: args.Storage})
@@ -1693,13 +1701,14 @@ func (st *State) SetAdminMongoPassword(password string) error {
 }
 
 type controllersDoc struct {
-	Id               string `bson:"_id"`
-	CloudName        string `bson:"cloud"`
-	ModelUUID        string `bson:"model-uuid"`
-	MachineIds       []string
-	VotingMachineIds []string
-	MongoSpaceName   string `bson:"mongo-space-name"`
-	MongoSpaceState  string `bson:"mongo-space-state"`
+	Id                 string `bson:"_id"`
+	CloudName          string `bson:"cloud"`
+	ModelUUID          string `bson:"model-uuid"`
+	MachineIds         []string
+	VotingMachineIds   []string
+	RemovingMachineIds []string
+	MongoSpaceName     string `bson:"mongo-space-name"`
+	MongoSpaceState    string `bson:"mongo-space-state"`
 }
 
 // ControllerInfo holds information about currently
@@ -1723,6 +1732,12 @@ type ControllerInfo struct {
 	// in peer election.
 	VotingMachineIds []string
 
+	// RemovingMachineIds holds the ids of controller machines
+	// that are being removed from the controller. They are
+	// still included in MachineIds until the peergrouper has
+	// taken away their vote in the replica set.
+	RemovingMachineIds []string
+
 	// MongoSpaceName is the space that contains all Mongo servers.
 	MongoSpaceName string
 
@@ -1767,12 +1782,13 @@ func readRawControllerInfo(session *mgo.Session) (*ControllerInfo, error) {
 		return nil, errors.Annotatef(err, "cannot get controllers document")
 	}
 	return &ControllerInfo{
-		CloudName:        doc.CloudName,
-		ModelTag:         names.NewModelTag(doc.ModelUUID),
-		MachineIds:       doc.MachineIds,
-		VotingMachineIds: doc.VotingMachineIds,
-		MongoSpaceName:   doc.MongoSpaceName,
-		MongoSpaceState:  MongoSpaceStates(doc.MongoSpaceState),
+		CloudName:          doc.CloudName,
+		ModelTag:           names.NewModelTag(doc.ModelUUID),
+		MachineIds:         doc.MachineIds,
+		VotingMachineIds:   doc.VotingMachineIds,
+		RemovingMachineIds: doc.RemovingMachineIds,
+		MongoSpaceName:     doc.MongoSpaceName,
+		MongoSpaceState:    MongoSpaceStates(doc.MongoSpaceState),
 	}, nil
 }
 
@@ -31,6 +31,14 @@ SetControllerDone when complete.
 
 6. Once the final controller calls SetControllerDone, the status is
 changed to UpgradeComplete and the upgradeInfo document is archived.
+
+Before running its upgrade steps, the master controller records a
+snapshot of the database with SetSnapshot. If its upgrade steps fail,
+it sets the status to UpgradeFailed and waits. When there is a single
+controller, RollbackCurrentUpgrade then changes the status to
+UpgradeRollingBack, and the master controller restores the snapshot,
+archives the upgradeInfo document as aborted, and reverts the model's
+agent version.
 */
 
 package state
@@ -70,6 +78,15 @@ const (
 	// to some problem.
 	UpgradeAborted UpgradeStatus = "aborted"
 
+	// UpgradeFailed indicates that the master controller's upgrade
+	// steps failed, and that it is waiting for the upgrade to be
+	// rolled back.
+	UpgradeFailed UpgradeStatus = "failed"
+
+	// UpgradeRollingBack indicates that the master controller has been
+	// asked to roll back a failed upgrade.
+	UpgradeRollingBack UpgradeStatus = "rolling-back"
+
 	// currentUpgradeId is the mongo _id of the current upgrade info document.
 	currentUpgradeId = "current"
 )
@@ -82,6 +99,11 @@ type upgradeInfoDoc struct {
 	Started          time.Time      `bson:"started"`
 	ControllersReady []string       `bson:"controllersReady"`
 	ControllersDone  []string       `bson:"controllersDone"`
+
+	// SnapshotMachineId and SnapshotDir record where the snapshot
+	// of the database taken before the upgrade steps ran is held.
+	SnapshotMachineId string `bson:"snapshotMachineId,omitempty"`
+	SnapshotDir       string `bson:"snapshotDir,omitempty"`
 }
 
 // UpgradeInfo is used to synchronise controller upgrades.
@@ -126,6 +148,42 @@ func (info *UpgradeInfo) ControllersDone() []string {
 	return result
 }
 
+// SnapshotMachineId returns the id of the controller machine holding
+// the pre-upgrade snapshot of the database, if one was taken.
+func (info *UpgradeInfo) SnapshotMachineId() string {
+	return info.doc.SnapshotMachineId
+}
+
+// SnapshotDir returns the directory on the snapshot machine that
+// holds the pre-upgrade snapshot of the database.
+func (info *UpgradeInfo) SnapshotDir() string {
+	return info.doc.SnapshotDir
+}
+
+// SetSnapshot records that a snapshot of the database was taken
+// before the upgrade steps ran, and is held in the given directory on
+// the given controller machine.
+func (info *UpgradeInfo) SetSnapshot(machineId, dir string) error {
+	if info.doc.Id != currentUpgradeId {
+		return errors.New("cannot set snapshot on non-current upgrade")
+	}
+	ops := []txn.Op{{
+		C:      upgradeInfoC,
+		Id:     currentUpgradeId,
+		Assert: assertExpectedVersions(info.doc.PreviousVersion, info.doc.TargetVersion),
+		Update: bson.D{{"$set", bson.D{
+			{"snapshotMachineId", machineId},
+			{"snapshotDir", dir},
+		}}},
+	}}
+	if err := info.st.runTransaction(ops); err != nil {
+		return errors.Annotate(err, "cannot record upgrade snapshot")
+	}
+	info.doc.SnapshotMachineId = machineId
+	info.doc.SnapshotDir = dir
+	return nil
+}
+
 // Refresh updates the contents of the UpgradeInfo from underlying state.
 func (info *UpgradeInfo) Refresh() error {
 	doc, err := currentUpgradeInfoDoc(info.st)
@@ -218,13 +276,23 @@ func (info *UpgradeInfo) SetStatus(status UpgradeStatus) error {
 	case UpgradePending, UpgradeComplete, UpgradeAborted:
 		return errors.Errorf("cannot explicitly set upgrade status to \"%s\"", status)
 	case UpgradeRunning:
+		// A failed upgrade is run again if the master
+		// controller restarts before it is rolled back.
 		assertSane = bson.D{{"status", bson.D{{"$in",
-			[]UpgradeStatus{UpgradePending, UpgradeRunning},
+			[]UpgradeStatus{UpgradePending, UpgradeRunning, UpgradeFailed},
 		}}}}
 	case UpgradeFinishing:
 		assertSane = bson.D{{"status", bson.D{{"$in",
 			[]UpgradeStatus{UpgradeRunning, UpgradeFinishing},
 		}}}}
+	case UpgradeFailed:
+		assertSane = bson.D{{"status", bson.D{{"$in",
+			[]UpgradeStatus{UpgradeRunning, UpgradeFailed},
+		}}}}
+	case UpgradeRollingBack:
+		assertSane = bson.D{{"status", bson.D{{"$in",
+			[]UpgradeStatus{UpgradeFailed, UpgradeRollingBack},
+		}}}}
 	default:
 		return errors.Errorf("unknown upgrade status: %s", status)
 	}
@@ -480,6 +548,48 @@ func (st *State) AbortCurrentUpgrade() error {
 
 }
 
+// RollbackCurrentUpgrade asks the master controller to roll back the
+// current upgrade, whose upgrade steps have failed, to the snapshot
+// taken before they ran. The snapshot is restored into the master
+// controller's database only, so upgrades can only be rolled back when
+// there is a single controller.
+func (st *State) RollbackCurrentUpgrade() error {
+	doc, err := currentUpgradeInfoDoc(st)
+	if errors.IsNotFound(err) {
+		return errors.New("no upgrade in progress")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	switch doc.Status {
+	case UpgradeRollingBack:
+		return nil
+	case UpgradeFailed:
+	default:
+		return errors.Errorf("cannot roll back upgrade with status %q", doc.Status)
+	}
+	if doc.SnapshotDir == "" {
+		return errors.New("cannot roll back upgrade: no snapshot was taken")
+	}
+	if err := checkSingleController(st); err != nil {
+		return errors.Annotate(err, "cannot roll back upgrade")
+	}
+	info := &UpgradeInfo{st: st, doc: *doc}
+	return errors.Trace(info.SetStatus(UpgradeRollingBack))
+}
+
+// checkSingleController returns an error if there is more than one
+// controller machine.
+func checkSingleController(st *State) error {
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(info.MachineIds) > 1 {
+		return errors.Errorf("snapshots cannot be restored with %d controllers", len(info.MachineIds))
+	}
+	return nil
+}
+
 func currentUpgradeInfoDoc(st *State) (*upgradeInfoDoc, error) {
 	var doc upgradeInfoDoc
 	upgradeInfo, closer := st.getCollection(upgradeInfoC)
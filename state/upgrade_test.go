@@ -454,6 +454,99 @@ func (s *UpgradeSuite) TestSetStatus(c *gc.C) {
 	assertStatus(state.UpgradeFinishing)
 }
 
+func (s *UpgradeSuite) TestSetStatusFailed(c *gc.C) {
+	v123 := vers("1.2.3")
+	v234 := vers("2.3.4")
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, v123, v234)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = info.SetStatus(state.UpgradeFailed)
+	c.Assert(err, gc.ErrorMatches, `cannot set upgrade status to "failed": `+
+		"Another status change may have occurred concurrently")
+	err = info.SetStatus(state.UpgradeRollingBack)
+	c.Assert(err, gc.ErrorMatches, `cannot set upgrade status to "rolling-back": `+
+		"Another status change may have occurred concurrently")
+
+	err = info.SetStatus(state.UpgradeRunning)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetStatus(state.UpgradeFailed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A failed upgrade may be run again.
+	err = info.SetStatus(state.UpgradeRunning)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetStatus(state.UpgradeFailed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = info.SetStatus(state.UpgradeRollingBack)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status(), gc.Equals, state.UpgradeRollingBack)
+}
+
+func (s *UpgradeSuite) TestSetSnapshot(c *gc.C) {
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotDir(), gc.Equals, "")
+
+	err = info.SetSnapshot(s.serverIdA, "/var/lib/juju/upgrade-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotMachineId(), gc.Equals, s.serverIdA)
+
+	info, err = s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.SnapshotMachineId(), gc.Equals, s.serverIdA)
+	c.Assert(info.SnapshotDir(), gc.Equals, "/var/lib/juju/upgrade-snapshot")
+}
+
+func (s *UpgradeSuite) TestRollbackCurrentUpgrade(c *gc.C) {
+	err := s.State.RollbackCurrentUpgrade()
+	c.Assert(err, gc.ErrorMatches, "no upgrade in progress")
+
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetStatus(state.UpgradeRunning)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RollbackCurrentUpgrade()
+	c.Assert(err, gc.ErrorMatches, `cannot roll back upgrade with status "running"`)
+
+	err = info.SetStatus(state.UpgradeFailed)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RollbackCurrentUpgrade()
+	c.Assert(err, gc.ErrorMatches, "cannot roll back upgrade: no snapshot was taken")
+
+	err = info.SetSnapshot(s.serverIdA, "/var/lib/juju/upgrade-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RollbackCurrentUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status(), gc.Equals, state.UpgradeRollingBack)
+
+	// Asking again is fine.
+	err = s.State.RollbackCurrentUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSuite) TestRollbackCurrentUpgradeMultipleControllers(c *gc.C) {
+	s.addControllers(c)
+	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetStatus(state.UpgradeRunning)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetStatus(state.UpgradeFailed)
+	c.Assert(err, jc.ErrorIsNil)
+	err = info.SetSnapshot(s.serverIdA, "/var/lib/juju/upgrade-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RollbackCurrentUpgrade()
+	c.Assert(err, gc.ErrorMatches, "cannot roll back upgrade: snapshots cannot be restored with 3 controllers")
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Status(), gc.Equals, state.UpgradeFailed)
+}
+
 func (s *UpgradeSuite) TestSetControllerDone(c *gc.C) {
 	info, err := s.State.EnsureUpgradeInfo(s.serverIdA, vers("1.2.3"), vers("2.3.4"))
 	c.Assert(err, jc.ErrorIsNil)
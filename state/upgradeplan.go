@@ -0,0 +1,366 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// currentUpgradePlanId is the local id of the upgrade plan document
+// of a model; a model has at most one upgrade plan.
+const currentUpgradePlanId = "current"
+
+// upgradePlanDoc records how the machine agents in a model are rolled
+// out to a new version: the machines in each batch are upgraded in turn,
+// and the next batch is released once every machine in the released
+// batches is running the new version and the pause has passed.
+type upgradePlanDoc struct {
+	DocID           string         `bson:"_id"`
+	ModelUUID       string         `bson:"model-uuid"`
+	PreviousVersion version.Number `bson:"previous-version"`
+	TargetVersion   version.Number `bson:"target-version"`
+	Batches         [][]string     `bson:"batches"`
+	Pause           time.Duration  `bson:"pause"`
+
+	// Released is the number of batches whose machines have been
+	// released to upgrade to the target version.
+	Released int `bson:"released"`
+
+	// BatchDoneAt is when every machine in the released batches was
+	// first seen running the target version, in nanoseconds since the
+	// epoch. It is zero while they are still upgrading.
+	BatchDoneAt int64 `bson:"batch-done-at"`
+}
+
+// UpgradePlanArgs holds the arguments for SetUpgradePlan.
+type UpgradePlanArgs struct {
+	// TargetVersion is the version the model's agents are upgraded to.
+	TargetVersion version.Number
+
+	// Batches holds the ids of the machines upgraded together, in the
+	// order the batches are upgraded. The first batch holds the canary
+	// machines, which are upgraded as soon as the model's agent
+	// version is set to the target version.
+	Batches [][]string
+
+	// Pause is how long to wait after every machine in a batch is
+	// running the target version before releasing the next batch.
+	Pause time.Duration
+}
+
+// UpgradePlan describes how the machine agents in a model are rolled out
+// to a new version.
+type UpgradePlan struct {
+	st  *State
+	doc upgradePlanDoc
+}
+
+// PreviousVersion returns the version the model is being upgraded from.
+func (p *UpgradePlan) PreviousVersion() version.Number {
+	return p.doc.PreviousVersion
+}
+
+// TargetVersion returns the version the model is being upgraded to.
+func (p *UpgradePlan) TargetVersion() version.Number {
+	return p.doc.TargetVersion
+}
+
+// Batches returns the ids of the machines in each batch, in the order
+// the batches are upgraded.
+func (p *UpgradePlan) Batches() [][]string {
+	batches := make([][]string, len(p.doc.Batches))
+	for i, batch := range p.doc.Batches {
+		batches[i] = append([]string(nil), batch...)
+	}
+	return batches
+}
+
+// Pause returns how long to wait between batches.
+func (p *UpgradePlan) Pause() time.Duration {
+	return p.doc.Pause
+}
+
+// Released returns the number of batches that have been released to
+// upgrade.
+func (p *UpgradePlan) Released() int {
+	return p.doc.Released
+}
+
+// MachineTargetVersion returns the version the agent of the given
+// machine should be running. Containers are upgraded with the machine
+// that hosts them, unless they are named in the plan themselves, and
+// machines not named in the plan are upgraded with the last batch.
+func (p *UpgradePlan) MachineTargetVersion(machineId string) version.Number {
+	if p.batchOf(machineId) < p.doc.Released {
+		return p.doc.TargetVersion
+	}
+	return p.doc.PreviousVersion
+}
+
+func (p *UpgradePlan) batchOf(machineId string) int {
+	for id := machineId; id != ""; id = ParentId(id) {
+		for i, batch := range p.doc.Batches {
+			for _, batchId := range batch {
+				if batchId == id {
+					return i
+				}
+			}
+		}
+	}
+	return len(p.doc.Batches) - 1
+}
+
+// releasedMachinesUpgraded returns whether every machine in the
+// released batches is running the target version. Machines that have
+// since been removed are ignored.
+func (p *UpgradePlan) releasedMachinesUpgraded() (bool, error) {
+	for _, batch := range p.doc.Batches[:p.doc.Released] {
+		for _, id := range batch {
+			machine, err := p.st.Machine(id)
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			tools, err := machine.AgentTools()
+			if errors.IsNotFound(err) {
+				return false, nil
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+			if tools.Version.Number != p.doc.TargetVersion {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// UpgradePlan returns the model's upgrade plan. An error satisfying
+// errors.IsNotFound() is returned if the model has no upgrade plan.
+func (st *State) UpgradePlan() (*UpgradePlan, error) {
+	plans, closer := st.getCollection(upgradePlansC)
+	defer closer()
+
+	var doc upgradePlanDoc
+	err := plans.FindId(currentUpgradePlanId).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("upgrade plan")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get upgrade plan")
+	}
+	return &UpgradePlan{st: st, doc: doc}, nil
+}
+
+// SetUpgradePlan records how the machine agents in the model are to be
+// rolled out to a new version. The plan takes effect once the model's
+// agent version is set to the target version, when the machines in the
+// first batch are released to upgrade. Controller machines cannot be
+// planned; they are always upgraded first.
+func (st *State) SetUpgradePlan(args UpgradePlanArgs) error {
+	if len(args.Batches) == 0 {
+		return errors.NotValidf("upgrade plan without batches")
+	}
+	if args.Pause < 0 {
+		return errors.NotValidf("negative pause %v", args.Pause)
+	}
+	seen := set.NewStrings()
+	for i, batch := range args.Batches {
+		if len(batch) == 0 {
+			return errors.NotValidf("empty batch %d", i+1)
+		}
+		for _, id := range batch {
+			if seen.Contains(id) {
+				return errors.NotValidf("machine %s in more than one batch", id)
+			}
+			seen.Add(id)
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.UpgradePlan(); err == nil {
+			return nil, errors.AlreadyExistsf("upgrade plan")
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		cfg, err := st.ModelConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		currentVersion, ok := cfg.AgentVersion()
+		if !ok {
+			return nil, errors.New("no agent version set in the model")
+		}
+		if args.TargetVersion.Compare(currentVersion) <= 0 {
+			return nil, errors.Errorf("cannot plan upgrade from %s to %s", currentVersion, args.TargetVersion)
+		}
+		var ops []txn.Op
+		for _, id := range seen.SortedValues() {
+			machine, err := st.Machine(id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if machine.IsManager() {
+				return nil, errors.Errorf("machine %s is a controller", id)
+			}
+			ops = append(ops, txn.Op{
+				C:      machinesC,
+				Id:     machine.doc.DocID,
+				Assert: notDeadDoc,
+			})
+		}
+		ops = append(ops, txn.Op{
+			C:      upgradePlansC,
+			Id:     st.docID(currentUpgradePlanId),
+			Assert: txn.DocMissing,
+			Insert: &upgradePlanDoc{
+				DocID:           st.docID(currentUpgradePlanId),
+				ModelUUID:       st.ModelUUID(),
+				PreviousVersion: currentVersion,
+				TargetVersion:   args.TargetVersion,
+				Batches:         args.Batches,
+				Pause:           args.Pause,
+				Released:        1,
+			},
+		})
+		return ops, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set upgrade plan")
+	}
+	return nil
+}
+
+// RemoveUpgradePlan removes the model's upgrade plan, if it has one.
+// Any machines still held back by the plan are then upgraded to the
+// model's agent version.
+func (st *State) RemoveUpgradePlan() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.UpgradePlan(); errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      upgradePlansC,
+			Id:     st.docID(currentUpgradePlanId),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if err := st.run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot remove upgrade plan")
+	}
+	return nil
+}
+
+// AdvanceUpgradePlan releases the next batch of the model's upgrade
+// plan, if every machine in the released batches is running the target
+// version and the plan's pause has passed since they were first seen
+// doing so. The plan is removed once every batch has been upgraded.
+func (st *State) AdvanceUpgradePlan(now time.Time) error {
+	plan, err := st.UpgradePlan()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if agentVersion, _ := cfg.AgentVersion(); agentVersion != plan.doc.TargetVersion {
+		// The upgrade has not started yet.
+		return nil
+	}
+	done, err := plan.releasedMachinesUpgraded()
+	if err != nil || !done {
+		return errors.Trace(err)
+	}
+
+	docID := st.docID(currentUpgradePlanId)
+	assertReleased := bson.D{{"released", plan.doc.Released}}
+	var ops []txn.Op
+	doneAt := time.Unix(0, plan.doc.BatchDoneAt)
+	switch {
+	case plan.doc.Released == len(plan.doc.Batches):
+		logger.Infof("upgrade of model %s to %s complete", st.ModelUUID(), plan.doc.TargetVersion)
+		ops = []txn.Op{{
+			C:      upgradePlansC,
+			Id:     docID,
+			Assert: assertReleased,
+			Remove: true,
+		}}
+	case plan.doc.BatchDoneAt == 0 && plan.doc.Pause > 0:
+		ops = []txn.Op{{
+			C:      upgradePlansC,
+			Id:     docID,
+			Assert: assertReleased,
+			Update: bson.D{{"$set", bson.D{{"batch-done-at", now.UnixNano()}}}},
+		}}
+	case plan.doc.BatchDoneAt == 0 || !now.Before(doneAt.Add(plan.doc.Pause)):
+		logger.Infof("releasing batch %d of %d of upgrade of model %s to %s",
+			plan.doc.Released+1, len(plan.doc.Batches), st.ModelUUID(), plan.doc.TargetVersion,
+		)
+		ops = []txn.Op{{
+			C:      upgradePlansC,
+			Id:     docID,
+			Assert: assertReleased,
+			Update: bson.D{{"$set", bson.D{
+				{"released", plan.doc.Released + 1},
+				{"batch-done-at", int64(0)},
+			}}},
+		}}
+	default:
+		return nil
+	}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		// The plan was changed concurrently; try again next time.
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "cannot advance upgrade plan")
+	}
+	return nil
+}
+
+// AdvanceUpgradePlans advances the upgrade plan of every model that has
+// one; see AdvanceUpgradePlan.
+func (st *State) AdvanceUpgradePlans(now time.Time) error {
+	plans, closer := st.getRawCollection(upgradePlansC)
+	defer closer()
+
+	var docs []struct {
+		ModelUUID string `bson:"model-uuid"`
+	}
+	if err := plans.Find(nil).Select(bson.D{{"model-uuid", 1}}).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot read upgrade plans")
+	}
+	for _, doc := range docs {
+		if err := st.advanceModelUpgradePlan(doc.ModelUUID, now); err != nil {
+			return errors.Annotatef(err, "model %s", doc.ModelUUID)
+		}
+	}
+	return nil
+}
+
+func (st *State) advanceModelUpgradePlan(modelUUID string, now time.Time) error {
+	if modelUUID == st.ModelUUID() {
+		return st.AdvanceUpgradePlan(now)
+	}
+	modelSt, err := st.ForModel(names.NewModelTag(modelUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer modelSt.Close()
+	return modelSt.AdvanceUpgradePlan(now)
+}
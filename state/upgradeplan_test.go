@@ -0,0 +1,254 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type UpgradePlanSuite struct {
+	ConnSuite
+	current version.Number
+	target  version.Number
+}
+
+var _ = gc.Suite(&UpgradePlanSuite{})
+
+func (s *UpgradePlanSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	var ok bool
+	s.current, ok = cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	s.target = s.current
+	s.target.Minor++
+}
+
+func (s *UpgradePlanSuite) addMachine(c *gc.C, jobs ...state.MachineJob) *state.Machine {
+	if len(jobs) == 0 {
+		jobs = []state.MachineJob{state.JobHostUnits}
+	}
+	machine, err := s.State.AddMachine("quantal", jobs...)
+	c.Assert(err, jc.ErrorIsNil)
+	s.setAgentVersion(c, machine, s.current)
+	return machine
+}
+
+func (s *UpgradePlanSuite) setAgentVersion(c *gc.C, machine *state.Machine, vers version.Number) {
+	err := machine.SetAgentVersion(version.Binary{
+		Number: vers,
+		Series: "quantal",
+		Arch:   "amd64",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradePlanSuite) TestSetUpgradePlan(c *gc.C) {
+	m0 := s.addMachine(c)
+	m1 := s.addMachine(c)
+	err := s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}, {m1.Id()}},
+		Pause:         time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	plan, err := s.State.UpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(plan.PreviousVersion(), gc.Equals, s.current)
+	c.Check(plan.TargetVersion(), gc.Equals, s.target)
+	c.Check(plan.Batches(), jc.DeepEquals, [][]string{{m0.Id()}, {m1.Id()}})
+	c.Check(plan.Pause(), gc.Equals, time.Hour)
+	c.Check(plan.Released(), gc.Equals, 1)
+}
+
+func (s *UpgradePlanSuite) TestUpgradePlanNotFound(c *gc.C) {
+	_, err := s.State.UpgradePlan()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradePlanSuite) TestSetUpgradePlanInvalid(c *gc.C) {
+	m0 := s.addMachine(c)
+	controller := s.addMachine(c, state.JobManageModel)
+
+	for i, test := range []struct {
+		args   state.UpgradePlanArgs
+		expect string
+	}{{
+		args:   state.UpgradePlanArgs{TargetVersion: s.target},
+		expect: "upgrade plan without batches not valid",
+	}, {
+		args: state.UpgradePlanArgs{
+			TargetVersion: s.target,
+			Batches:       [][]string{{m0.Id()}, {}},
+		},
+		expect: "empty batch 2 not valid",
+	}, {
+		args: state.UpgradePlanArgs{
+			TargetVersion: s.target,
+			Batches:       [][]string{{m0.Id()}, {m0.Id()}},
+		},
+		expect: "machine 0 in more than one batch not valid",
+	}, {
+		args: state.UpgradePlanArgs{
+			TargetVersion: s.target,
+			Batches:       [][]string{{m0.Id()}},
+			Pause:         -time.Minute,
+		},
+		expect: "negative pause -1m0s not valid",
+	}, {
+		args: state.UpgradePlanArgs{
+			TargetVersion: s.current,
+			Batches:       [][]string{{m0.Id()}},
+		},
+		expect: "cannot set upgrade plan: cannot plan upgrade from .* to .*",
+	}, {
+		args: state.UpgradePlanArgs{
+			TargetVersion: s.target,
+			Batches:       [][]string{{m0.Id(), controller.Id()}},
+		},
+		expect: "cannot set upgrade plan: machine 1 is a controller",
+	}, {
+		args: state.UpgradePlanArgs{
+			TargetVersion: s.target,
+			Batches:       [][]string{{"42"}},
+		},
+		expect: "cannot set upgrade plan: machine 42 not found",
+	}} {
+		c.Logf("test %d: %s", i, test.expect)
+		err := s.State.SetUpgradePlan(test.args)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+
+	err := s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot set upgrade plan: upgrade plan already exists")
+}
+
+func (s *UpgradePlanSuite) TestMachineTargetVersion(c *gc.C) {
+	m0 := s.addMachine(c)
+	m1 := s.addMachine(c)
+	m2 := s.addMachine(c)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m1.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}, {m1.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	plan, err := s.State.UpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(plan.MachineTargetVersion(m0.Id()), gc.Equals, s.target)
+	c.Check(plan.MachineTargetVersion(m1.Id()), gc.Equals, s.current)
+	c.Check(plan.MachineTargetVersion(container.Id()), gc.Equals, s.current)
+	c.Check(plan.MachineTargetVersion(m2.Id()), gc.Equals, s.current)
+}
+
+func (s *UpgradePlanSuite) TestAdvanceUpgradePlan(c *gc.C) {
+	m0 := s.addMachine(c)
+	m1 := s.addMachine(c)
+	err := s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}, {m1.Id()}},
+		Pause:         time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+
+	assertReleased := func(expect int) {
+		plan, err := s.State.UpgradePlan()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(plan.Released(), gc.Equals, expect)
+	}
+
+	// The canaries are running the current version, but the upgrade
+	// has not started yet.
+	err = s.State.AdvanceUpgradePlan(now)
+	c.Assert(err, jc.ErrorIsNil)
+	assertReleased(1)
+
+	err = s.State.SetModelAgentVersion(s.target)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AdvanceUpgradePlan(now)
+	c.Assert(err, jc.ErrorIsNil)
+	assertReleased(1)
+
+	// The canaries have upgraded; the next batch waits for the pause.
+	s.setAgentVersion(c, m0, s.target)
+	err = s.State.AdvanceUpgradePlan(now)
+	c.Assert(err, jc.ErrorIsNil)
+	assertReleased(1)
+	err = s.State.AdvanceUpgradePlan(now.Add(30 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	assertReleased(1)
+	err = s.State.AdvanceUpgradePlan(now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	assertReleased(2)
+
+	// Once the last batch has upgraded, the plan is done.
+	s.setAgentVersion(c, m1, s.target)
+	err = s.State.AdvanceUpgradePlans(now.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UpgradePlan()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UpgradePlanSuite) TestRemoveUpgradePlan(c *gc.C) {
+	m0 := s.addMachine(c)
+	err := s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UpgradePlan()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a missing plan is not an error.
+	err = s.State.RemoveUpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradePlanSuite) TestWatchUpgradePlan(c *gc.C) {
+	m0 := s.addMachine(c)
+	w := s.State.WatchUpgradePlan()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetUpgradePlan(state.UpgradePlanArgs{
+		TargetVersion: s.target,
+		Batches:       [][]string{{m0.Id()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveUpgradePlan()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
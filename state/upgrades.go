@@ -249,3 +249,55 @@ func addDefaultBindingsToServices(st *State) error {
 func AddDefaultEndpointBindingsToServices(st *State) error {
 	return runForAllEnvStates(st, addDefaultBindingsToServices)
 }
+
+// AddControllerUserPermissions grants controller access to the users of
+// controllers created before controller permissions were recorded. The
+// owner and the administrators of the controller model become
+// superusers, and every other local user may log in. Access already
+// granted on the controller is left alone.
+func AddControllerUserPermissions(st *State) error {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+	model, err := controllerSt.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	grants := make(map[string]Access)
+	users, err := controllerSt.AllUsers(true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, user := range users {
+		grants[controllerUserID(user.UserTag())] = LoginAccess
+	}
+	modelUsers, err := model.Users()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, modelUser := range modelUsers {
+		if modelUser.IsAdmin() {
+			grants[controllerUserID(modelUser.UserTag())] = SuperuserAccess
+		}
+	}
+	grants[controllerUserID(model.Owner())] = SuperuserAccess
+
+	var ops []txn.Op
+	for subjectKey, access := range grants {
+		_, err := controllerSt.userPermission(controllerGlobalKey, subjectKey)
+		if err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		upgradesLogger.Debugf("granting %q controller access to %q", access, subjectKey)
+		ops = append(ops, createPermissionOp(controllerGlobalKey, subjectKey, access))
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	return errors.Trace(controllerSt.runTransaction(ops))
+}
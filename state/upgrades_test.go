@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -586,3 +587,54 @@ func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServices(c *gc.C) {
 func (s *upgradesSuite) TestAddDefaultEndpointBindingsToServicesIdempotent(c *gc.C) {
 	s.testAddDefaultEndpointBindingsToServices(c, true)
 }
+
+func (s *upgradesSuite) TestAddControllerUserPermissions(c *gc.C) {
+	_, err := s.state.AddUser("bob", "Bob", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.state.AddUser("dave", "Dave", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	dave := names.NewLocalUserTag("dave")
+	_, err = s.state.AddUser("mary", "Mary", "password", s.owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	mary := names.NewLocalUserTag("mary")
+	_, err = s.state.AddModelUser(ModelUserSpec{
+		User:      mary,
+		CreatedBy: s.owner,
+		Access:    AdminAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Controllers created before controller permissions were recorded
+	// have none at all.
+	permissions, closer := s.state.getRawCollection(permissionsC)
+	defer closer()
+	_, err = permissions.RemoveAll(bson.D{{"object-global-key", controllerGlobalKey}})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.state.ControllerAccess(s.owner)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Access granted since is left alone.
+	bob := names.NewLocalUserTag("bob")
+	err = s.state.SetControllerAccess(bob, AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertAccess := func() {
+		for user, expected := range map[names.UserTag]Access{
+			s.owner: SuperuserAccess,
+			mary:    SuperuserAccess,
+			bob:     AddModelAccess,
+			dave:    LoginAccess,
+		} {
+			access, err := s.state.ControllerAccess(user)
+			c.Check(err, jc.ErrorIsNil)
+			c.Check(access, gc.Equals, expected, gc.Commentf("user %q", user.Canonical()))
+		}
+	}
+	err = AddControllerUserPermissions(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	assertAccess()
+
+	err = AddControllerUserPermissions(s.state)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("idempotency check failed!"))
+	assertAccess()
+}
@@ -81,17 +81,31 @@ func (st *State) addUser(name, displayName, password, creator string, secretKey
 		if err != nil {
 			return nil, err
 		}
+		if err := st.checkPasswordPolicy(password); err != nil {
+			return nil, errors.Trace(err)
+		}
 		user.doc.PasswordHash = utils.UserPasswordHash(password, salt)
 		user.doc.PasswordSalt = salt
+		user.doc.PasswordChanged = user.doc.DateCreated
 	}
 
+	// New users may log in to the controller, but need to be granted
+	// further controller access explicitly. Controller permissions
+	// live in the controller model, so the transaction is run there.
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer()
 	ops := []txn.Op{{
 		C:      usersC,
 		Id:     nameToLower,
 		Assert: txn.DocMissing,
 		Insert: &user.doc,
-	}}
-	err := st.runTransaction(ops)
+	}, createPermissionOp(
+		controllerGlobalKey, controllerUserID(names.NewLocalUserTag(name)), LoginAccess,
+	)}
+	err = controllerSt.runTransaction(ops)
 	if err == txn.ErrAborted {
 		err = errors.AlreadyExistsf("user")
 	}
@@ -112,6 +126,7 @@ func createInitialUserOp(st *State, user names.UserTag, password, salt string) t
 		CreatedBy:    user.Name(),
 		DateCreated:  nowToTheSecond(),
 	}
+	doc.PasswordChanged = doc.DateCreated
 	return txn.Op{
 		C:      usersC,
 		Id:     nameToLower,
@@ -193,6 +208,16 @@ type userDoc struct {
 	PasswordSalt string    `bson:"passwordsalt"`
 	CreatedBy    string    `bson:"createdby"`
 	DateCreated  time.Time `bson:"datecreated"`
+	// PasswordChanged is when the password was last set. It is zero
+	// for users whose password was set before it was recorded.
+	PasswordChanged time.Time `bson:"passwordchanged,omitempty"`
+	// FailedLogins counts the consecutive failed password logins
+	// since the last successful one.
+	FailedLogins int  `bson:"failedlogins,omitempty"`
+	LockedOut    bool `bson:"lockedout,omitempty"`
+	// SSHKeys holds the user's public SSH keys, which are installed
+	// on the machines of every model the user may change.
+	SSHKeys []string `bson:"sshkeys,omitempty"`
 }
 
 type userLastLoginDoc struct {
@@ -314,8 +339,12 @@ func (u *User) SecretKey() []byte {
 	return u.doc.SecretKey
 }
 
-// SetPassword sets the password associated with the User.
+// SetPassword sets the password associated with the User. The
+// password must follow the controller's password policy.
 func (u *User) SetPassword(password string) error {
+	if err := u.st.checkPasswordPolicy(password); err != nil {
+		return errors.Trace(err)
+	}
 	salt, err := utils.RandomSalt()
 	if err != nil {
 		return err
@@ -327,9 +356,11 @@ func (u *User) SetPassword(password string) error {
 // password. If the User has a secret key set then it
 // will be cleared.
 func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
+	changed := nowToTheSecond()
 	update := bson.D{{"$set", bson.D{
 		{"passwordhash", pwHash},
 		{"passwordsalt", pwSalt},
+		{"passwordchanged", changed},
 	}}}
 	if u.doc.SecretKey != nil {
 		update = append(update,
@@ -347,6 +378,7 @@ func (u *User) SetPasswordHash(pwHash string, pwSalt string) error {
 	}
 	u.doc.PasswordHash = pwHash
 	u.doc.PasswordSalt = pwSalt
+	u.doc.PasswordChanged = changed
 	u.doc.SecretKey = nil
 	return nil
 }
@@ -0,0 +1,338 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UserGroup represents a named group of users. Local groups have their
+// members managed within Juju; external groups, whose names carry the
+// domain of an identity provider (e.g. "devs@external"), have their
+// members recorded from the identity provider when users log in.
+type UserGroup struct {
+	st  *State
+	doc userGroupDoc
+}
+
+type userGroupDoc struct {
+	DocID       string    `bson:"_id"`
+	Name        string    `bson:"name"`
+	Members     []string  `bson:"members"`
+	CreatedBy   string    `bson:"createdby"`
+	DateCreated time.Time `bson:"datecreated"`
+}
+
+func userGroupID(name string) string {
+	return strings.ToLower(name)
+}
+
+func userGroupGlobalKey(groupID string) string {
+	// ug stands for user group.
+	return fmt.Sprintf("ug#%s", groupID)
+}
+
+// IsValidUserGroupName returns whether name is a valid group name. Group
+// names follow the same rules as user names, including the optional
+// domain of an external identity provider.
+func IsValidUserGroupName(name string) bool {
+	return names.IsValidUser(name)
+}
+
+// AddUserGroup adds a group with the given name.
+func (st *State) AddUserGroup(name, creator string) (*UserGroup, error) {
+	if !IsValidUserGroupName(name) {
+		return nil, errors.NotValidf("group name %q", name)
+	}
+	group := &UserGroup{
+		st: st,
+		doc: userGroupDoc{
+			DocID:       userGroupID(name),
+			Name:        name,
+			Members:     []string{},
+			CreatedBy:   creator,
+			DateCreated: nowToTheSecond(),
+		},
+	}
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     group.doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &group.doc,
+	}}
+	err := st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.AlreadyExistsf("group %q", name)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// UserGroup returns the group with the given name.
+func (st *State) UserGroup(name string) (*UserGroup, error) {
+	groups, closer := st.getCollection(userGroupsC)
+	defer closer()
+
+	group := &UserGroup{st: st}
+	err := groups.FindId(userGroupID(name)).One(&group.doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("group %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return group, nil
+}
+
+// AllUserGroups returns all the groups, sorted by name.
+func (st *State) AllUserGroups() ([]*UserGroup, error) {
+	return st.findUserGroups(nil)
+}
+
+// UserGroupsForUser returns the groups the given user is a member of,
+// sorted by name.
+func (st *State) UserGroupsForUser(user names.UserTag) ([]*UserGroup, error) {
+	return st.findUserGroups(bson.D{{"members", userGroupMemberID(user)}})
+}
+
+func (st *State) findUserGroups(query bson.D) ([]*UserGroup, error) {
+	groups, closer := st.getCollection(userGroupsC)
+	defer closer()
+
+	var docs []userGroupDoc
+	if err := groups.Find(query).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*UserGroup, len(docs))
+	for i, doc := range docs {
+		result[i] = &UserGroup{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveUserGroup removes the named group, along with the access it has
+// been granted on the controller and on models.
+func (st *State) RemoveUserGroup(name string) error {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	groupKey := userGroupGlobalKey(userGroupID(name))
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     userGroupID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	permOps, err := controllerSt.removePermissionsOps(bson.D{
+		{"object-global-key", controllerGlobalKey},
+		{"subject-global-key", groupKey},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, permOps...)
+	err = controllerSt.runTransaction(ops)
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("group %q", name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// The group is gone, so any access left behind on models is no
+	// longer reachable; remove it so that it does not apply to a later
+	// group of the same name.
+	models, err := st.AllModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, model := range models {
+		modelSt, err := st.ForModel(model.ModelTag())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = modelSt.RemoveModelGroupAccess(name)
+		modelSt.Close()
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing access to model %q", model.Name())
+		}
+	}
+	return nil
+}
+
+// Name returns the name of the group.
+func (g *UserGroup) Name() string {
+	return g.doc.Name
+}
+
+// CreatedBy returns the name of the user that created the group.
+func (g *UserGroup) CreatedBy() string {
+	return g.doc.CreatedBy
+}
+
+// DateCreated returns when the group was created in UTC.
+func (g *UserGroup) DateCreated() time.Time {
+	return g.doc.DateCreated.UTC()
+}
+
+// IsExternal returns whether the group is defined by an external
+// identity provider.
+func (g *UserGroup) IsExternal() bool {
+	return !names.NewUserTag(g.doc.Name).IsLocal()
+}
+
+// Members returns the users that are members of the group, sorted by
+// name.
+func (g *UserGroup) Members() []names.UserTag {
+	members := make([]string, len(g.doc.Members))
+	copy(members, g.doc.Members)
+	sort.Strings(members)
+	result := make([]names.UserTag, len(members))
+	for i, member := range members {
+		result[i] = names.NewUserTag(member)
+	}
+	return result
+}
+
+// Refresh reloads the group from the database.
+func (g *UserGroup) Refresh() error {
+	group, err := g.st.UserGroup(g.doc.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	g.doc = group.doc
+	return nil
+}
+
+// userGroupMemberID returns the identifier under which the given user is
+// recorded as a group member.
+func userGroupMemberID(user names.UserTag) string {
+	return strings.ToLower(user.Canonical())
+}
+
+// AddMember adds the given user to the group. Local users must exist.
+// The members of external groups are managed by the identity provider
+// and may not be added here.
+func (g *UserGroup) AddMember(user names.UserTag) error {
+	if g.IsExternal() {
+		return errors.Errorf("members of external group %q are managed by the identity provider", g.doc.Name)
+	}
+	if user.IsLocal() {
+		if _, err := g.st.User(user); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	memberID := userGroupMemberID(user)
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: bson.D{{"members", bson.D{{"$ne", memberID}}}},
+		Update: bson.D{{"$addToSet", bson.D{{"members", memberID}}}},
+	}}
+	err := g.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		if err := g.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.AlreadyExistsf("%q in group %q", user.Canonical(), g.doc.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return g.Refresh()
+}
+
+// RemoveMember removes the given user from the group.
+func (g *UserGroup) RemoveMember(user names.UserTag) error {
+	if g.IsExternal() {
+		return errors.Errorf("members of external group %q are managed by the identity provider", g.doc.Name)
+	}
+	memberID := userGroupMemberID(user)
+	ops := []txn.Op{{
+		C:      userGroupsC,
+		Id:     g.doc.DocID,
+		Assert: bson.D{{"members", memberID}},
+		Update: bson.D{{"$pull", bson.D{{"members", memberID}}}},
+	}}
+	err := g.st.runTransaction(ops)
+	if err == txn.ErrAborted {
+		if err := g.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.NotFoundf("%q in group %q", user.Canonical(), g.doc.Name)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return g.Refresh()
+}
+
+// SetExternalUserGroups records the groups an external user belongs to,
+// as reported by the identity provider. Group names are reported without
+// a domain; they are matched against the external groups with the
+// user's domain. Groups unknown to Juju are ignored.
+func (st *State) SetExternalUserGroups(user names.UserTag, groupNames []string) error {
+	if user.IsLocal() {
+		return errors.Errorf("%q is not an external user", user.Canonical())
+	}
+	wanted := make(map[string]bool)
+	for _, name := range groupNames {
+		wanted[userGroupID(name+"@"+user.Domain())] = true
+	}
+	memberID := userGroupMemberID(user)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		groups, err := st.findUserGroups(bson.D{
+			{"_id", bson.D{{"$regex", "@" + regexp.QuoteMeta(strings.ToLower(user.Domain())) + "$"}}},
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for _, group := range groups {
+			isMember := false
+			for _, member := range group.doc.Members {
+				if member == memberID {
+					isMember = true
+					break
+				}
+			}
+			switch {
+			case wanted[group.doc.DocID] && !isMember:
+				ops = append(ops, txn.Op{
+					C:      userGroupsC,
+					Id:     group.doc.DocID,
+					Assert: bson.D{{"members", bson.D{{"$ne", memberID}}}},
+					Update: bson.D{{"$addToSet", bson.D{{"members", memberID}}}},
+				})
+			case !wanted[group.doc.DocID] && isMember:
+				ops = append(ops, txn.Op{
+					C:      userGroupsC,
+					Id:     group.doc.DocID,
+					Assert: bson.D{{"members", memberID}},
+					Update: bson.D{{"$pull", bson.D{{"members", memberID}}}},
+				})
+			}
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return errors.Annotatef(st.run(buildTxn), "cannot set groups for %q", user.Canonical())
+}
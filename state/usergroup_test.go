@@ -0,0 +1,235 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func memberNames(group *state.UserGroup) []string {
+	var result []string
+	for _, member := range group.Members() {
+		result = append(result, member.Canonical())
+	}
+	return result
+}
+
+type UserGroupSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserGroupSuite{})
+
+func (s *UserGroupSuite) TestAddUserGroup(c *gc.C) {
+	group, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+	c.Assert(group.CreatedBy(), gc.Equals, s.Owner.Name())
+	c.Assert(group.IsExternal(), jc.IsFalse)
+	c.Assert(group.Members(), gc.HasLen, 0)
+
+	group, err = s.State.UserGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Name(), gc.Equals, "devs")
+
+	_, err = s.State.AddUserGroup("Devs", s.Owner.Name())
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *UserGroupSuite) TestAddUserGroupInvalidName(c *gc.C) {
+	_, err := s.State.AddUserGroup("not/valid", s.Owner.Name())
+	c.Assert(err, gc.ErrorMatches, `group name "not/valid" not valid`)
+}
+
+func (s *UserGroupSuite) TestAllUserGroups(c *gc.C) {
+	_, err := s.State.AddUserGroup("ops", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	groups, err := s.State.AllUserGroups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 2)
+	c.Assert(groups[0].Name(), gc.Equals, "devs")
+	c.Assert(groups[1].Name(), gc.Equals, "ops")
+}
+
+func (s *UserGroupSuite) TestMembers(c *gc.C) {
+	group, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	ann := names.NewUserTag("ann@external")
+
+	c.Assert(group.AddMember(bob), jc.ErrorIsNil)
+	c.Assert(group.AddMember(ann), jc.ErrorIsNil)
+	c.Assert(memberNames(group), jc.DeepEquals, []string{"ann@external", "bob@local"})
+
+	err = group.AddMember(bob)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	groups, err := s.State.UserGroupsForUser(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	c.Assert(groups[0].Name(), gc.Equals, "devs")
+
+	c.Assert(group.RemoveMember(bob), jc.ErrorIsNil)
+	c.Assert(memberNames(group), jc.DeepEquals, []string{"ann@external"})
+	err = group.RemoveMember(bob)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UserGroupSuite) TestAddMemberUnknownLocalUser(c *gc.C) {
+	group, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(names.NewLocalUserTag("nobody"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UserGroupSuite) TestExternalGroupMembership(c *gc.C) {
+	group, err := s.State.AddUserGroup("devs@external", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.IsExternal(), jc.IsTrue)
+	ann := names.NewUserTag("ann@external")
+
+	err = group.AddMember(ann)
+	c.Assert(err, gc.ErrorMatches, `members of external group "devs@external" are managed by the identity provider`)
+
+	err = s.State.SetExternalUserGroups(ann, []string{"devs", "unknown"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Refresh(), jc.ErrorIsNil)
+	c.Assert(memberNames(group), jc.DeepEquals, []string{"ann@external"})
+
+	err = s.State.SetExternalUserGroups(ann, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.Refresh(), jc.ErrorIsNil)
+	c.Assert(group.Members(), gc.HasLen, 0)
+}
+
+func (s *UserGroupSuite) TestEffectiveModelAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", Access: state.ReadAccess}).UserTag()
+	group, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(bob), jc.ErrorIsNil)
+
+	access, err := s.State.EffectiveModelAccess(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ReadAccess)
+
+	err = s.State.SetModelGroupAccess("devs", state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.EffectiveModelAccess(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.WriteAccess)
+
+	err = s.State.RemoveModelGroupAccess("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	access, err = s.State.EffectiveModelAccess(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ReadAccess)
+}
+
+func (s *UserGroupSuite) TestEffectiveModelAccessGroupOnly(c *gc.C) {
+	ann := names.NewUserTag("ann@external")
+	_, err := s.State.EffectiveModelAccess(ann)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	group, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(ann), jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("devs", state.ReadAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	access, err := s.State.EffectiveModelAccess(ann)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.ReadAccess)
+
+	models, err := s.State.ModelsForUser(ann)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 1)
+	c.Assert(models[0].UUID(), gc.Equals, s.State.ModelUUID())
+}
+
+func (s *UserGroupSuite) TestSetModelGroupAccessInvalid(c *gc.C) {
+	_, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("devs", state.SuperuserAccess)
+	c.Assert(err, gc.ErrorMatches, `model access "superuser" not valid`)
+	err = s.State.SetModelGroupAccess("ops", state.ReadAccess)
+	c.Assert(err, gc.ErrorMatches, `cannot set model access for group "ops": group "ops" not found`)
+}
+
+func (s *UserGroupSuite) TestControllerGroupAccess(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob", NoModelUser: true}).UserTag()
+	group, err := s.State.AddUserGroup("admins", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(group.AddMember(bob), jc.ErrorIsNil)
+
+	err = s.State.SetControllerGroupAccess("admins", state.SuperuserAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	access, err := s.State.ControllerGroupAccess("admins")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.SuperuserAccess)
+
+	access, err = s.State.EffectiveControllerAccess(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.SuperuserAccess)
+	isAdmin, err := s.State.IsControllerAdministrator(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(isAdmin, jc.IsTrue)
+
+	// The user's own access is unchanged.
+	access, err = s.State.ControllerAccess(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(access, gc.Equals, state.LoginAccess)
+}
+
+func (s *UserGroupSuite) TestRemoveUserGroupRemovesAccess(c *gc.C) {
+	_, err := s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("devs", state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetControllerGroupAccess("devs", state.AddModelAccess)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveUserGroup("devs")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.UserGroup("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.State.AddUserGroup("devs", s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelGroupAccess("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ControllerGroupAccess("devs")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveUserGroup("ops")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *UserGroupSuite) TestAccessIncludes(c *gc.C) {
+	for i, test := range []struct {
+		access, other state.Access
+		includes      bool
+	}{
+		{state.AdminAccess, state.WriteAccess, true},
+		{state.ReadAccess, state.WriteAccess, false},
+		{state.WriteAccess, state.WriteAccess, true},
+		{state.SuperuserAccess, state.LoginAccess, true},
+		{state.LoginAccess, state.AddModelAccess, false},
+		{state.ManageAccess, state.OperateAccess, true},
+		{state.OperateAccess, state.ManageAccess, false},
+		{state.ReadAccess, state.UndefinedAccess, true},
+		{state.UndefinedAccess, state.ReadAccess, false},
+	} {
+		c.Logf("test %d: %q includes %q", i, test.access, test.other)
+		c.Check(test.access.Includes(test.other), gc.Equals, test.includes)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// greaterAccess returns the greater of the two given access levels.
+func greaterAccess(a, b Access) Access {
+	if a.Includes(b) {
+		return a
+	}
+	return b
+}
+
+func validModelAccess(access Access) bool {
+	switch access {
+	case ReadAccess, WriteAccess, AdminAccess:
+		return true
+	}
+	return false
+}
+
+// ModelGroupAccess returns the level of access the named group has on
+// the model. A NotFound error is returned if the group has not been
+// granted any.
+func (st *State) ModelGroupAccess(group string) (Access, error) {
+	perm, err := st.userPermission(modelGlobalKey, userGroupGlobalKey(userGroupID(group)))
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// SetModelGroupAccess sets the level of access the members of the named
+// group have on the model, replacing any access previously granted.
+func (st *State) SetModelGroupAccess(group string, access Access) error {
+	if !validModelAccess(access) {
+		return errors.NotValidf("model access %q", access)
+	}
+	return errors.Annotatef(
+		st.setGroupAccess(st, modelGlobalKey, group, access),
+		"cannot set model access for group %q", group,
+	)
+}
+
+// RemoveModelGroupAccess removes all access the named group has on the
+// model.
+func (st *State) RemoveModelGroupAccess(group string) error {
+	op := removePermissionOp(modelGlobalKey, userGroupGlobalKey(userGroupID(group)))
+	err := st.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("model access for group %q", group)
+	}
+	return errors.Trace(err)
+}
+
+// ControllerGroupAccess returns the level of access the named group has
+// on the controller. A NotFound error is returned if the group has not
+// been granted any.
+func (st *State) ControllerGroupAccess(group string) (Access, error) {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	defer closer()
+
+	perm, err := controllerSt.userPermission(controllerGlobalKey, userGroupGlobalKey(userGroupID(group)))
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	return perm.access(), nil
+}
+
+// SetControllerGroupAccess sets the level of access the members of the
+// named group have on the controller, replacing any access previously
+// granted.
+func (st *State) SetControllerGroupAccess(group string, access Access) error {
+	if !ValidControllerAccess(access) {
+		return errors.NotValidf("controller access %q", access)
+	}
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	return errors.Annotatef(
+		st.setGroupAccess(controllerSt, controllerGlobalKey, group, access),
+		"cannot set controller access for group %q", group,
+	)
+}
+
+// RemoveControllerGroupAccess removes all access the named group has on
+// the controller.
+func (st *State) RemoveControllerGroupAccess(group string) error {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+
+	op := removePermissionOp(controllerGlobalKey, userGroupGlobalKey(userGroupID(group)))
+	err = controllerSt.runTransaction([]txn.Op{op})
+	if err == txn.ErrAborted {
+		err = errors.NotFoundf("controller access for group %q", group)
+	}
+	return errors.Trace(err)
+}
+
+// setGroupAccess grants the named group access to the object with the
+// given key, in the permissions of permSt.
+func (st *State) setGroupAccess(permSt *State, objectKey, group string, access Access) error {
+	groupID := userGroupID(group)
+	subjectKey := userGroupGlobalKey(groupID)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.UserGroup(group); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      userGroupsC,
+			Id:     groupID,
+			Assert: txn.DocExists,
+		}}
+		_, err := permSt.userPermission(objectKey, subjectKey)
+		if errors.IsNotFound(err) {
+			return append(ops, createPermissionOp(objectKey, subjectKey, access)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, updatePermissionOp(objectKey, subjectKey, access)), nil
+	}
+	return permSt.run(buildTxn)
+}
+
+// EffectiveModelAccess returns the greatest level of access the given
+// user has on the model, whether granted to the user directly or to
+// any of the groups the user is a member of. A NotFound error is
+// returned if the user has no access to the model.
+func (st *State) EffectiveModelAccess(user names.UserTag) (Access, error) {
+	return st.effectiveAccess(st, modelGlobalKey, modelUserGlobalKey(modelUserID(user)), user)
+}
+
+// EffectiveControllerAccess returns the greatest level of access the
+// given user has on the controller, whether granted to the user
+// directly or to any of the groups the user is a member of. A NotFound
+// error is returned if the user has no access to the controller.
+func (st *State) EffectiveControllerAccess(user names.UserTag) (Access, error) {
+	controllerSt, closer, err := st.controllerState()
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	defer closer()
+
+	return st.effectiveAccess(controllerSt, controllerGlobalKey, controllerUserID(user), user)
+}
+
+func (st *State) effectiveAccess(permSt *State, objectKey, userKey string, user names.UserTag) (Access, error) {
+	result := UndefinedAccess
+	perm, err := permSt.userPermission(objectKey, userKey)
+	if err == nil {
+		result = perm.access()
+	} else if !errors.IsNotFound(err) {
+		return UndefinedAccess, errors.Trace(err)
+	}
+
+	groups, err := st.UserGroupsForUser(user)
+	if err != nil {
+		return UndefinedAccess, errors.Trace(err)
+	}
+	for _, group := range groups {
+		perm, err := permSt.userPermission(objectKey, userGroupGlobalKey(group.doc.DocID))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return UndefinedAccess, errors.Trace(err)
+		}
+		result = greaterAccess(result, perm.access())
+	}
+	if result == UndefinedAccess {
+		return UndefinedAccess, errors.NotFoundf("access for %q", user.Canonical())
+	}
+	return result, nil
+}
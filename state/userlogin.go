@@ -0,0 +1,222 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// checkPasswordPolicy returns an error if the password does not follow
+// the controller's password policy.
+func (st *State) checkPasswordPolicy(password string) error {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot read password policy")
+	}
+	return errors.Trace(cfg.PasswordPolicy().Check(password))
+}
+
+// isControllerOwner returns whether the user owns the controller model.
+// The controller owner is never locked out, so that there is always an
+// administrator able to unlock other users.
+func (u *User) isControllerOwner() (bool, error) {
+	model, err := u.st.ControllerModel()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return u.doc.Name == model.Owner().Name(), nil
+}
+
+// PasswordChanged returns when the user's password was last set in UTC.
+// Users whose password was set before this was recorded report the
+// time they were created.
+func (u *User) PasswordChanged() time.Time {
+	if u.doc.PasswordChanged.IsZero() {
+		return u.DateCreated()
+	}
+	return u.doc.PasswordChanged.UTC()
+}
+
+// PasswordExpired returns whether the user's password was set longer
+// than maxAge ago. Passwords never expire if maxAge is zero, and the
+// password of the controller owner never expires.
+func (u *User) PasswordExpired(maxAge time.Duration) (bool, error) {
+	if maxAge <= 0 || nowToTheSecond().Sub(u.PasswordChanged()) <= maxAge {
+		return false, nil
+	}
+	owner, err := u.isControllerOwner()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return !owner, nil
+}
+
+// FailedLogins returns the number of consecutive failed password logins
+// since the user last logged in successfully or was unlocked.
+func (u *User) FailedLogins() int {
+	return u.doc.FailedLogins
+}
+
+// IsLockedOut returns whether the user has been locked out after too
+// many failed logins.
+func (u *User) IsLockedOut() bool {
+	// Like IsDisabled, this is a cached value.
+	return u.doc.LockedOut
+}
+
+// RecordFailedLogin counts a failed password login for the user, and
+// locks the user out once maxAttempts consecutive logins have failed.
+// Users are never locked out if maxAttempts is zero, and the controller
+// owner is never locked out.
+func (u *User) RecordFailedLogin(maxAttempts int) error {
+	owner, err := u.isControllerOwner()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		failed := u.doc.FailedLogins + 1
+		set := bson.D{{"failedlogins", failed}}
+		if maxAttempts > 0 && failed >= maxAttempts && !owner {
+			set = append(set, bson.DocElem{"lockedout", true})
+		}
+		return []txn.Op{{
+			C:      usersC,
+			Id:     u.doc.DocID,
+			Assert: failedLoginsIs(u.doc.FailedLogins),
+			Update: bson.D{{"$set", set}},
+		}}, nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot record failed login of user %q", u.Name())
+	}
+	return u.Refresh()
+}
+
+// failedLoginsIs returns an assertion that the user document records
+// the given number of failed logins. The field is omitted when zero.
+func failedLoginsIs(n int) bson.D {
+	if n == 0 {
+		return bson.D{{"failedlogins", bson.D{{"$in", []interface{}{0, nil}}}}}
+	}
+	return bson.D{{"failedlogins", n}}
+}
+
+// ResetFailedLogins clears the count of failed logins after the user
+// logs in successfully.
+func (u *User) ResetFailedLogins() error {
+	if u.doc.FailedLogins == 0 {
+		return nil
+	}
+	return errors.Annotatef(u.clearFailedLogins(), "cannot reset failed logins of user %q", u.Name())
+}
+
+// Unlock allows a user that was locked out after too many failed logins
+// to log in again.
+func (u *User) Unlock() error {
+	return errors.Annotatef(u.clearFailedLogins(), "cannot unlock user %q", u.Name())
+}
+
+// clearFailedLogins resets the count of failed logins, and with it any
+// lockout.
+func (u *User) clearFailedLogins() error {
+	ops := []txn.Op{{
+		C:      usersC,
+		Id:     u.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{
+			{"lockedout", false},
+			{"failedlogins", 0},
+		}}},
+	}}
+	if err := u.st.runTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			err = errors.New("user no longer exists")
+		}
+		return err
+	}
+	u.doc.LockedOut = false
+	u.doc.FailedLogins = 0
+	return nil
+}
+
+// LoginRecord describes a successful login of a user.
+type LoginRecord struct {
+	// Time is when the user logged in, in UTC.
+	Time time.Time
+
+	// Address is the network address the user connected from.
+	Address string
+}
+
+type userLoginHistoryDoc struct {
+	DocID string `bson:"_id"`
+	// Logins holds the most recent logins, oldest first. Like the
+	// last login time, it is not updated using mgo.txn and should
+	// never appear in transaction asserts.
+	Logins []loginRecordDoc `bson:"logins"`
+}
+
+type loginRecordDoc struct {
+	Time    time.Time `bson:"time"`
+	Address string    `bson:"address"`
+}
+
+// RecordLogin adds a login from the given address to the user's login
+// history, keeping only the historySize most recent logins.
+func (u *User) RecordLogin(address string, historySize int) error {
+	if historySize <= 0 {
+		return errors.NotValidf("login history size %d", historySize)
+	}
+	history, closer := u.st.getCollection(userLoginHistoryC)
+	defer closer()
+
+	historyW := history.Writeable()
+
+	// As with the last login time, don't require write majority
+	// nor sync to disk.
+	session := historyW.Underlying().Database.Session
+	session.SetSafe(&mgo.Safe{})
+
+	record := loginRecordDoc{
+		Time:    nowToTheSecond(),
+		Address: address,
+	}
+	_, err := historyW.UpsertId(u.doc.DocID, bson.D{{"$push", bson.D{{"logins", bson.D{
+		{"$each", []loginRecordDoc{record}},
+		{"$slice", -historySize},
+	}}}}})
+	return errors.Trace(err)
+}
+
+// LoginHistory returns the user's most recent logins, newest first.
+func (u *User) LoginHistory() ([]LoginRecord, error) {
+	history, closer := u.st.getRawCollection(userLoginHistoryC)
+	defer closer()
+
+	var doc userLoginHistoryDoc
+	err := history.FindId(u.doc.DocID).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	records := make([]LoginRecord, len(doc.Logins))
+	for i, login := range doc.Logins {
+		records[len(doc.Logins)-1-i] = LoginRecord{
+			Time:    login.Time.UTC(),
+			Address: login.Address,
+		}
+	}
+	return records, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserLoginSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserLoginSuite{})
+
+func (s *UserLoginSuite) updateControllerConfig(c *gc.C, attrs map[string]interface{}) {
+	settings, err := s.State.ReadSettings(state.ControllersC, "controllerSettings")
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Update(attrs)
+	_, err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UserLoginSuite) TestPasswordPolicy(c *gc.C) {
+	s.updateControllerConfig(c, map[string]interface{}{
+		controller.PasswordMinLength:   8,
+		controller.PasswordCharClasses: 3,
+	})
+
+	_, err := s.State.AddUser("bob", "", "weak", "admin")
+	c.Assert(err, gc.ErrorMatches, `password shorter than 8 characters not valid`)
+
+	user, err := s.State.AddUser("bob", "", "Str0ngPassword", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.SetPassword("lowercaseonly")
+	c.Assert(err, gc.ErrorMatches, `password with fewer than 3 of .* not valid`)
+	c.Assert(user.PasswordValid("Str0ngPassword"), jc.IsTrue)
+
+	err = user.SetPassword("An0therPassword")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.PasswordValid("An0therPassword"), jc.IsTrue)
+}
+
+func (s *UserLoginSuite) TestPasswordChanged(c *gc.C) {
+	now := state.NowToTheSecond()
+	user := s.Factory.MakeUser(c, nil)
+	c.Assert(user.PasswordChanged().Before(now), jc.IsFalse)
+
+	expired, err := user.PasswordExpired(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, jc.IsFalse)
+
+	err = state.SetUserPasswordChanged(user, now.Add(-2*time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.PasswordChanged(), gc.Equals, now.Add(-2*time.Hour))
+	expired, err = user.PasswordExpired(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, jc.IsTrue)
+	expired, err = user.PasswordExpired(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, jc.IsFalse)
+
+	err = user.SetPassword("new-password")
+	c.Assert(err, jc.ErrorIsNil)
+	expired, err = user.PasswordExpired(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, jc.IsFalse)
+}
+
+func (s *UserLoginSuite) TestControllerOwnerPasswordNeverExpires(c *gc.C) {
+	owner, err := s.State.User(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = state.SetUserPasswordChanged(owner, state.NowToTheSecond().Add(-2*time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	expired, err := owner.PasswordExpired(time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expired, jc.IsFalse)
+}
+
+func (s *UserLoginSuite) TestLockout(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	for i := 0; i < 2; i++ {
+		err := user.RecordFailedLogin(3)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(user.IsLockedOut(), jc.IsFalse)
+	}
+	c.Assert(user.FailedLogins(), gc.Equals, 2)
+
+	err := user.RecordFailedLogin(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.IsLockedOut(), jc.IsTrue)
+
+	other, err := s.State.User(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.IsLockedOut(), jc.IsTrue)
+	c.Assert(other.FailedLogins(), gc.Equals, 3)
+
+	err = other.Unlock()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.IsLockedOut(), jc.IsFalse)
+	c.Assert(other.FailedLogins(), gc.Equals, 0)
+	err = user.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.IsLockedOut(), jc.IsFalse)
+}
+
+func (s *UserLoginSuite) TestResetFailedLogins(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	err := user.RecordFailedLogin(3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = user.RecordFailedLogin(3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = user.ResetFailedLogins()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.FailedLogins(), gc.Equals, 0)
+
+	// The count starts again, so one more failure doesn't lock the
+	// user out.
+	err = user.RecordFailedLogin(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.IsLockedOut(), jc.IsFalse)
+}
+
+func (s *UserLoginSuite) TestNoLockoutWithoutMaxAttempts(c *gc.C) {
+	user := s.Factory.MakeUser(c, nil)
+	for i := 0; i < 5; i++ {
+		err := user.RecordFailedLogin(0)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(user.FailedLogins(), gc.Equals, 5)
+	c.Assert(user.IsLockedOut(), jc.IsFalse)
+}
+
+func (s *UserLoginSuite) TestControllerOwnerNeverLockedOut(c *gc.C) {
+	owner, err := s.State.User(s.Owner)
+	c.Assert(err, jc.ErrorIsNil)
+	err = owner.RecordFailedLogin(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owner.IsLockedOut(), jc.IsFalse)
+}
+
+func (s *UserLoginSuite) TestLoginHistory(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	history, err := user.LoginHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+
+	now := state.NowToTheSecond()
+	for _, addr := range []string{"10.0.0.1:4321", "10.0.0.2:4321", "10.0.0.3:4321"} {
+		err := user.RecordLogin(addr, 2)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history, err = user.LoginHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Address, gc.Equals, "10.0.0.3:4321")
+	c.Assert(history[1].Address, gc.Equals, "10.0.0.2:4321")
+	c.Assert(history[0].Time.Before(now), jc.IsFalse)
+
+	err = user.RecordLogin("10.0.0.4:4321", 0)
+	c.Assert(err, gc.ErrorMatches, `login history size 0 not valid`)
+}
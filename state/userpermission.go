@@ -39,6 +39,9 @@ func (st *State) userPermission(objectKey, subjectKey string) (*permission, erro
 	if err == mgo.ErrNotFound {
 		return nil, errors.NotFoundf("user permissions for user %q", subjectKey)
 	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return userPermission, nil
 
 }
@@ -118,7 +121,8 @@ func createPermissionOp(objectGlobalKey, subjectGlobalKey string, access Access)
 	}
 }
 
-// Access represents the level of access granted to a user on a model.
+// Access represents the level of access granted to a user on a model
+// or on the controller.
 type Access string
 
 const (
@@ -135,4 +139,47 @@ const (
 
 	// AdminAccess allows a user full control over the model.
 	AdminAccess Access = "admin"
+
+	// LoginAccess allows a user to log in to the controller itself.
+	// Users can log in to the models they have been given access to
+	// without it.
+	LoginAccess Access = "login"
+
+	// AddModelAccess allows a user to log in to the controller and to
+	// create models of their own.
+	AddModelAccess Access = "add-model"
+
+	// SuperuserAccess allows a user full control over the controller
+	// and every model it hosts.
+	SuperuserAccess Access = "superuser"
+
+	// OperateAccess allows a model user to configure an application
+	// and to run actions on its units.
+	OperateAccess Access = "operate"
+
+	// ManageAccess allows a model user to upgrade, scale and remove an
+	// application, as well as everything OperateAccess allows.
+	ManageAccess Access = "manage"
 )
+
+// accessLevels orders the access levels of each kind of object; a
+// level includes all the lower levels of the same kind.
+var accessLevels = map[Access]int{
+	ReadAccess:  1,
+	WriteAccess: 2,
+	AdminAccess: 3,
+
+	LoginAccess:     1,
+	AddModelAccess:  2,
+	SuperuserAccess: 3,
+
+	OperateAccess: 1,
+	ManageAccess:  2,
+}
+
+// Includes returns whether a grants everything other does. Both must
+// be levels of the same kind of object, or UndefinedAccess, which
+// every level includes.
+func (a Access) Includes(other Access) bool {
+	return accessLevels[a] >= accessLevels[other]
+}
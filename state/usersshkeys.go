@@ -0,0 +1,328 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	"github.com/juju/utils/ssh"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/state/watcher"
+)
+
+// SSHKeys returns the user's public SSH keys.
+func (u *User) SSHKeys() []string {
+	return u.doc.SSHKeys
+}
+
+// AddSSHKeys adds the given public SSH keys to the user. An error is
+// returned, and no key is added, if any key is invalid or has the
+// same fingerprint as one of the user's existing keys.
+func (u *User) AddSSHKeys(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		fingerprints := make(set.Strings)
+		for _, key := range u.doc.SSHKeys {
+			if fingerprint, _, err := ssh.KeyFingerprint(key); err == nil {
+				fingerprints.Add(fingerprint)
+			}
+		}
+		for _, key := range keys {
+			fingerprint, _, err := ssh.KeyFingerprint(key)
+			if err != nil {
+				return nil, errors.NotValidf("ssh key %q", key)
+			}
+			if fingerprints.Contains(fingerprint) {
+				return nil, errors.AlreadyExistsf("ssh key %q", key)
+			}
+			fingerprints.Add(fingerprint)
+		}
+		return []txn.Op{{
+			C:      usersC,
+			Id:     u.doc.DocID,
+			Assert: sshKeysAre(u.doc.SSHKeys),
+			Update: bson.D{{"$push", bson.D{{"sshkeys", bson.D{{"$each", keys}}}}}},
+		}}, nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot add ssh keys to user %q", u.Name())
+	}
+	return u.Refresh()
+}
+
+// RemoveSSHKeys removes the public SSH keys identified by the given
+// fingerprints or comments from the user. An error is returned, and
+// no key is removed, if any of them does not identify a key of the
+// user.
+func (u *User) RemoveSSHKeys(keyIds []string) error {
+	if len(keyIds) == 0 {
+		return nil
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		byId := make(map[string]string)
+		for _, key := range u.doc.SSHKeys {
+			fingerprint, comment, err := ssh.KeyFingerprint(key)
+			if err != nil {
+				continue
+			}
+			byId[fingerprint] = key
+			if comment != "" {
+				byId[comment] = key
+			}
+		}
+		var remove []string
+		for _, keyId := range keyIds {
+			key, ok := byId[keyId]
+			if !ok {
+				return nil, errors.NotFoundf("ssh key %q", keyId)
+			}
+			remove = append(remove, key)
+		}
+		return []txn.Op{{
+			C:      usersC,
+			Id:     u.doc.DocID,
+			Assert: sshKeysAre(u.doc.SSHKeys),
+			Update: bson.D{{"$pullAll", bson.D{{"sshkeys", remove}}}},
+		}}, nil
+	}
+	if err := u.st.run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot remove ssh keys from user %q", u.Name())
+	}
+	return u.Refresh()
+}
+
+// sshKeysAre returns an assertion that the user document holds exactly
+// the given SSH keys. The field is omitted when there are none.
+func sshKeysAre(keys []string) bson.D {
+	if len(keys) == 0 {
+		return bson.D{{"sshkeys.0", bson.D{{"$exists", false}}}}
+	}
+	return bson.D{{"sshkeys", keys}}
+}
+
+// sshKeysAccess holds the levels of model access that allow a user's
+// SSH keys to be installed on the model's machines. Users that may
+// only read the model are not allowed to log in to its machines.
+var sshKeysAccess = set.NewStrings(string(WriteAccess), string(AdminAccess))
+
+// AuthorisedKeys returns the public SSH keys to be installed on the
+// model's machines: the keys in the model's authorized-keys config,
+// followed by the keys of every enabled local user with write or admin
+// access to the model, whether granted directly or through a group.
+func (st *State) AuthorisedKeys() ([]string, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	keys := ssh.SplitAuthorisedKeys(cfg.AuthorizedKeys())
+	seen := set.NewStrings(keys...)
+
+	users, closer := st.getCollection(usersC)
+	defer closer()
+
+	var docs []userDoc
+	err = users.Find(bson.D{
+		{"deactivated", false},
+		{"sshkeys.0", bson.D{{"$exists", true}}},
+	}).Sort("_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read user ssh keys")
+	}
+	for _, doc := range docs {
+		access, err := st.EffectiveModelAccess(names.NewLocalUserTag(doc.Name))
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !sshKeysAccess.Contains(string(access)) {
+			continue
+		}
+		for _, key := range doc.SSHKeys {
+			if !seen.Contains(key) {
+				seen.Add(key)
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// WatchAuthorisedKeys returns a NotifyWatcher that notifies when the
+// keys returned by AuthorisedKeys may have changed: when the model
+// config, user groups or the model's permissions change, or when a
+// user's keys change or the user is disabled or enabled.
+func (st *State) WatchAuthorisedKeys() NotifyWatcher {
+	return newAuthorisedKeysWatcher(st)
+}
+
+// authorisedKeysWatcher notifies of changes to any of the documents
+// that determine a model's authorised SSH keys.
+type authorisedKeysWatcher struct {
+	commonWatcher
+	out chan struct{}
+}
+
+var _ Watcher = (*authorisedKeysWatcher)(nil)
+
+func newAuthorisedKeysWatcher(st *State) NotifyWatcher {
+	w := &authorisedKeysWatcher{
+		commonWatcher: newCommonWatcher(st),
+		out:           make(chan struct{}),
+	}
+	go func() {
+		defer w.tomb.Done()
+		defer close(w.out)
+		w.tomb.Kill(w.loop())
+	}()
+	return w
+}
+
+// Changes returns the event channel for w.
+func (w *authorisedKeysWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *authorisedKeysWatcher) loop() error {
+	in := make(chan watcher.Change)
+	configKey := w.st.docID(modelGlobalKey)
+	isModelConfig := func(id interface{}) bool {
+		return id == configKey
+	}
+	w.watcher.WatchCollectionWithFilter(settingsC, in, isModelConfig)
+	defer w.watcher.UnwatchCollection(settingsC, in)
+	userKeys, err := w.loadUserKeys()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	usersCh := make(chan watcher.Change)
+	w.watcher.WatchCollection(usersC, usersCh)
+	defer w.watcher.UnwatchCollection(usersC, usersCh)
+	w.watcher.WatchCollection(userGroupsC, in)
+	defer w.watcher.UnwatchCollection(userGroupsC, in)
+	w.watcher.WatchCollectionWithFilter(permissionsC, in, isLocalID(w.st))
+	defer w.watcher.UnwatchCollection(permissionsC, in)
+
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case ch := <-in:
+			if _, ok := collect(ch, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			out = w.out
+		case ch := <-usersCh:
+			ids, ok := collect(ch, usersCh, w.tomb.Dying())
+			if !ok {
+				return tomb.ErrDying
+			}
+			changed, err := w.updateUserKeys(userKeys, ids)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if changed {
+				out = w.out
+			}
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// userKeysDoc holds the fields of a user document that affect the
+// authorised keys. Other changes to users, such as recording failed
+// logins, are not reported by the authorised keys watcher.
+type userKeysDoc struct {
+	DocID       string   `bson:"_id"`
+	SSHKeys     []string `bson:"sshkeys,omitempty"`
+	Deactivated bool     `bson:"deactivated"`
+}
+
+var userKeysFields = bson.D{{"_id", 1}, {"sshkeys", 1}, {"deactivated", 1}}
+
+func (doc userKeysDoc) equal(other userKeysDoc) bool {
+	if doc.Deactivated != other.Deactivated || len(doc.SSHKeys) != len(other.SSHKeys) {
+		return false
+	}
+	for i, key := range doc.SSHKeys {
+		if other.SSHKeys[i] != key {
+			return false
+		}
+	}
+	return true
+}
+
+// loadUserKeys returns the key fields of every user, by document id.
+func (w *authorisedKeysWatcher) loadUserKeys() (map[string]userKeysDoc, error) {
+	users, closer := w.st.getCollection(usersC)
+	defer closer()
+
+	result := make(map[string]userKeysDoc)
+	var doc userKeysDoc
+	iter := users.Find(nil).Select(userKeysFields).Iter()
+	for iter.Next(&doc) {
+		result[doc.DocID] = doc
+		doc = userKeysDoc{}
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// updateUserKeys refreshes userKeys for the changed user documents,
+// and reports whether the keys or deactivation of any user changed.
+func (w *authorisedKeysWatcher) updateUserKeys(userKeys map[string]userKeysDoc, ids map[interface{}]bool) (bool, error) {
+	users, closer := w.st.getCollection(usersC)
+	defer closer()
+
+	changed := false
+	for id, exists := range ids {
+		docID, ok := id.(string)
+		if !ok {
+			return false, errors.Errorf("unexpected user id %#v", id)
+		}
+		// Unknown users are treated as having no keys.
+		old := userKeys[docID]
+		var doc userKeysDoc
+		if exists {
+			err := users.FindId(docID).Select(userKeysFields).One(&doc)
+			if err == mgo.ErrNotFound {
+				exists = false
+			} else if err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+		if !exists {
+			delete(userKeys, docID)
+		} else {
+			userKeys[docID] = doc
+		}
+		if !doc.equal(old) {
+			changed = true
+		}
+	}
+	return changed, nil
+}
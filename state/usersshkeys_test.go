@@ -0,0 +1,150 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/ssh"
+	sshtesting "github.com/juju/utils/ssh/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UserSSHKeysSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&UserSSHKeysSuite{})
+
+var (
+	sshKeyOne   = sshtesting.ValidKeyOne.Key + " bob@laptop"
+	sshKeyTwo   = sshtesting.ValidKeyTwo.Key + " bob@desktop"
+	sshKeyThree = sshtesting.ValidKeyThree.Key + " carol@laptop"
+)
+
+func (s *UserSSHKeysSuite) modelKeys(c *gc.C) []string {
+	cfg, err := s.State.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	return ssh.SplitAuthorisedKeys(cfg.AuthorizedKeys())
+}
+
+func (s *UserSSHKeysSuite) TestAddSSHKeys(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	c.Assert(user.SSHKeys(), gc.HasLen, 0)
+
+	err := user.AddSSHKeys([]string{sshKeyOne, sshKeyTwo})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SSHKeys(), jc.DeepEquals, []string{sshKeyOne, sshKeyTwo})
+
+	user, err = s.State.User(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SSHKeys(), jc.DeepEquals, []string{sshKeyOne, sshKeyTwo})
+}
+
+func (s *UserSSHKeysSuite) TestAddSSHKeysInvalid(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := user.AddSSHKeys([]string{sshKeyOne, "not-a-key"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(user.SSHKeys(), gc.HasLen, 0)
+}
+
+func (s *UserSSHKeysSuite) TestAddSSHKeysDuplicate(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := user.AddSSHKeys([]string{sshKeyOne})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The same key with a different comment is a duplicate.
+	err = user.AddSSHKeys([]string{sshKeyTwo, sshtesting.ValidKeyOne.Key + " other"})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(user.SSHKeys(), jc.DeepEquals, []string{sshKeyOne})
+}
+
+func (s *UserSSHKeysSuite) TestRemoveSSHKeys(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := user.AddSSHKeys([]string{sshKeyOne, sshKeyTwo, sshKeyThree})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = user.RemoveSSHKeys([]string{sshtesting.ValidKeyOne.Fingerprint, "carol@laptop"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(user.SSHKeys(), jc.DeepEquals, []string{sshKeyTwo})
+
+	err = user.RemoveSSHKeys([]string{"bob@desktop", "unknown"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(user.SSHKeys(), jc.DeepEquals, []string{sshKeyTwo})
+}
+
+func (s *UserSSHKeysSuite) TestAuthorisedKeys(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	err := bob.AddSSHKeys([]string{sshKeyOne})
+	c.Assert(err, jc.ErrorIsNil)
+	carol := s.Factory.MakeUser(c, &factory.UserParams{Name: "carol", Access: state.ReadAccess})
+	err = carol.AddSSHKeys([]string{sshKeyThree})
+	c.Assert(err, jc.ErrorIsNil)
+	dave := s.Factory.MakeUser(c, &factory.UserParams{Name: "dave", NoModelUser: true})
+	err = dave.AddSSHKeys([]string{sshKeyTwo})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only bob may change the model.
+	keys, err := s.State.AuthorisedKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, append(s.modelKeys(c), sshKeyOne))
+
+	// Access granted through a group counts.
+	group, err := s.State.AddUserGroup("ops", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = group.AddMember(dave.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelGroupAccess("ops", state.WriteAccess)
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err = s.State.AuthorisedKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, append(s.modelKeys(c), sshKeyOne, sshKeyTwo))
+
+	// Revoking access or disabling a user removes their keys.
+	err = s.State.RemoveModelUser(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	err = dave.Disable()
+	c.Assert(err, jc.ErrorIsNil)
+	keys, err = s.State.AuthorisedKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keys, jc.DeepEquals, s.modelKeys(c))
+}
+
+func (s *UserSSHKeysSuite) TestWatchAuthorisedKeys(c *gc.C) {
+	bob := s.Factory.MakeUser(c, &factory.UserParams{Name: "bob"})
+	w := s.State.WatchAuthorisedKeys()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := bob.AddSSHKeys([]string{sshKeyOne})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = bob.RecordFailedLogin(0)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = bob.Disable()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = bob.Enable()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveModelUser(bob.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.UpdateModelConfig(map[string]interface{}{
+		"authorized-keys": sshKeyThree,
+	}, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -1295,6 +1295,12 @@ func (st *State) WatchUpgradeInfo() NotifyWatcher {
 	return newEntityWatcher(st, upgradeInfoC, currentUpgradeId)
 }
 
+// WatchUpgradePlan returns a watcher for observing changes to the
+// model's upgrade plan.
+func (st *State) WatchUpgradePlan() NotifyWatcher {
+	return newEntityWatcher(st, upgradePlansC, st.docID(currentUpgradePlanId))
+}
+
 // WatchRestoreInfoChanges returns a NotifyWatcher that will inform
 // when the restore status changes.
 func (st *State) WatchRestoreInfoChanges() NotifyWatcher {
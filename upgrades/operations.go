@@ -23,6 +23,10 @@ var stateUpgradeOperations = func() []Operation {
 			version.MustParse("1.26-placeholder1"),
 			[]Step{},
 		},
+		upgradeToVersion{
+			version.MustParse("2.0.0"),
+			stateStepsFor20(),
+		},
 	}
 	return steps
 }
@@ -11,13 +11,14 @@ import (
 	"github.com/juju/utils/series"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
 )
 
 // PreUpgradeSteps runs various checks and prepares for performing an upgrade.
 // If any check fails, an error is returned which aborts the upgrade.
 func PreUpgradeSteps(st *state.State, agentConf agent.Config, isController, isMaster bool) error {
-	if err := checkDiskSpace(agentConf.DataDir()); err != nil {
+	if err := PreUpgradeChecks(agentConf.DataDir()); err != nil {
 		return errors.Trace(err)
 	}
 	if isController {
@@ -31,6 +32,33 @@ func PreUpgradeSteps(st *state.State, agentConf agent.Config, isController, isMa
 	return nil
 }
 
+// PreUpgradeChecks runs the checks made by PreUpgradeSteps, without
+// changing anything. It allows an upgrade to be checked before it is
+// started.
+func PreUpgradeChecks(dataDir string) error {
+	return checkDiskSpace(dataDir)
+}
+
+// MinMongoVersion is the oldest version of mongo that an upgrade can
+// be started with.
+var MinMongoVersion = mongo.Mongo24
+
+// CheckMongoVersion returns an error if the controller's mongo, with
+// the given version string as reported by mongo, cannot be upgraded.
+func CheckMongoVersion(version string) error {
+	v, err := mongo.NewVersion(version)
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse mongo version %q", version)
+	}
+	if v.StorageEngine == mongo.Upgrading {
+		return errors.New("mongo is being upgraded")
+	}
+	if v.NewerThan(MinMongoVersion) < 0 {
+		return errors.Errorf("mongo version %s is too old for upgrade: require %s", v, MinMongoVersion)
+	}
+	return nil
+}
+
 // We'll be conservative and require at least 250MiB of disk space for an upgrade.
 var MinDiskSpaceMib = uint64(250)
 
@@ -28,6 +28,44 @@ func (s *preupgradechecksSuite) TestCheckFreeDiskSpace(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "not enough free disk space for upgrade: .*")
 }
 
+func (s *preupgradechecksSuite) TestPreUpgradeChecks(c *gc.C) {
+	s.PatchValue(&upgrades.MinDiskSpaceMib, uint64(humanize.PiByte/humanize.MiByte))
+	err := upgrades.PreUpgradeChecks("/")
+	c.Assert(err, gc.ErrorMatches, "not enough free disk space for upgrade: .*")
+
+	s.PatchValue(&upgrades.MinDiskSpaceMib, uint64(0))
+	err = upgrades.PreUpgradeChecks("/")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *preupgradechecksSuite) TestCheckMongoVersion(c *gc.C) {
+	for i, test := range []struct {
+		version string
+		err     string
+	}{{
+		version: "2.4.10",
+	}, {
+		version: "3.2.4/wiredTiger",
+	}, {
+		version: "2.2.4",
+		err:     `mongo version 2.2.4/mmapv1 is too old for upgrade: require 2.4/mmapv1`,
+	}, {
+		version: "0.0/Upgrading",
+		err:     "mongo is being upgraded",
+	}, {
+		version: "x.y",
+		err:     `cannot parse mongo version "x.y": .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.version)
+		err := upgrades.CheckMongoVersion(test.version)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
 func (s *preupgradechecksSuite) TestUpdateDistroInfo(c *gc.C) {
 	s.PatchValue(&upgrades.MinDiskSpaceMib, uint64(0))
 	expectedAptCommandArgs := [][]string{
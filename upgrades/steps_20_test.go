@@ -0,0 +1,26 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+var v200 = version.MustParse("2.0.0")
+
+type steps20Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps20Suite{})
+
+func (s *steps20Suite) TestStateStepsFor20(c *gc.C) {
+	expected := []string{
+		"add controller permissions for existing users",
+	}
+	assertStateSteps(c, v200, expected)
+}
@@ -25,6 +25,16 @@ type Step interface {
 	Run(Context) error
 }
 
+// ReversibleStep is a Step that can undo the changes it makes, so that
+// a failed upgrade can be rolled back.
+type ReversibleStep interface {
+	Step
+
+	// Reverse undoes the changes made by Run. Like Run, it must be
+	// idempotent, and it may be called even if Run did not complete.
+	Reverse(Context) error
+}
+
 // Operation defines what steps to perform to upgrade to a target version.
 type Operation interface {
 	// The Juju version for which this operation is applicable.
@@ -90,6 +100,44 @@ func AreUpgradesDefined(from version.Number) bool {
 	return newUpgradeOpsIterator(from).Next() || newStateUpgradeOpsIterator(from).Next()
 }
 
+// PlannedStep describes an upgrade step that an upgrade would run.
+type PlannedStep struct {
+	// Version is the target version of the operation that holds
+	// the step.
+	Version version.Number
+
+	// Description is the step's description.
+	Description string
+
+	// Targets are the machine types the step runs on.
+	Targets []Target
+}
+
+// PlannedSteps returns the upgrade steps that upgrading from one version
+// to another would run, without running any of them. State-based steps
+// come first, as they do in PerformUpgrade. The steps are defined by the
+// version being upgraded to, so the result is only complete when to is
+// not later than the running version of Juju.
+func PlannedSteps(from, to version.Number) []PlannedStep {
+	var planned []PlannedStep
+	for _, ops := range []*opsIterator{
+		newOpsIterator(from, to, stateUpgradeOperations()),
+		newOpsIterator(from, to, upgradeOperations()),
+	} {
+		for ops.Next() {
+			op := ops.Get()
+			for _, step := range op.Steps() {
+				planned = append(planned, PlannedStep{
+					Version:     op.TargetVersion(),
+					Description: step.Description(),
+					Targets:     step.Targets(),
+				})
+			}
+		}
+	}
+	return planned
+}
+
 // PerformUpgrade runs the business logic needed to upgrade the current "from" version to this
 // version of Juju on the "target" type of machine.
 func PerformUpgrade(from version.Number, targets []Target, context Context) error {
@@ -118,6 +166,27 @@ func hasStateTarget(targets []Target) bool {
 	return false
 }
 
+// ReverseUpgrade undoes the upgrade steps that PerformUpgrade runs when
+// upgrading from the "from" version on the "target" type of machine.
+// The steps are reversed in the opposite order to that in which they
+// run. Steps that are not reversible are skipped.
+func ReverseUpgrade(from version.Number, targets []Target, context Context) error {
+	ops := newUpgradeOpsIterator(from)
+	if err := reverseUpgradeSteps(ops, targets, context.APIContext()); err != nil {
+		return err
+	}
+
+	if hasStateTarget(targets) {
+		ops := newStateUpgradeOpsIterator(from)
+		if err := reverseUpgradeSteps(ops, targets, context.StateContext()); err != nil {
+			return err
+		}
+	}
+
+	logger.Infof("All upgrade steps reversed successfully")
+	return nil
+}
+
 // runUpgradeSteps finds all the upgrade operations relevant to
 // the targets given and runs the associated upgrade steps.
 //
@@ -143,6 +212,35 @@ func runUpgradeSteps(ops *opsIterator, targets []Target, context Context) error
 	return nil
 }
 
+// reverseUpgradeSteps finds all the upgrade operations relevant to the
+// targets given and reverses the associated upgrade steps, last first.
+func reverseUpgradeSteps(ops *opsIterator, targets []Target, context Context) error {
+	var steps []Step
+	for ops.Next() {
+		for _, step := range ops.Get().Steps() {
+			if targetsMatch(targets, step.Targets()) {
+				steps = append(steps, step)
+			}
+		}
+	}
+	for i := len(steps) - 1; i >= 0; i-- {
+		step, ok := steps[i].(ReversibleStep)
+		if !ok {
+			logger.Warningf("upgrade step %q cannot be reversed", steps[i].Description())
+			continue
+		}
+		logger.Infof("reversing upgrade step: %v", step.Description())
+		if err := step.Reverse(context); err != nil {
+			logger.Errorf("reversing upgrade step %q failed: %v", step.Description(), err)
+			return &upgradeError{
+				description: "reversing " + step.Description(),
+				err:         err,
+			}
+		}
+	}
+	return nil
+}
+
 // targetsMatch returns true if any machineTargets match any of
 // stepTargets.
 func targetsMatch(machineTargets []Target, stepTargets []Target) bool {
@@ -179,3 +277,16 @@ func (step *upgradeStep) Targets() []Target {
 func (step *upgradeStep) Run(context Context) error {
 	return step.run(context)
 }
+
+// reversibleUpgradeStep is a default ReversibleStep implementation.
+type reversibleUpgradeStep struct {
+	upgradeStep
+	reverse func(Context) error
+}
+
+var _ ReversibleStep = (*reversibleUpgradeStep)(nil)
+
+// Reverse is defined on the ReversibleStep interface.
+func (step *reversibleUpgradeStep) Reverse(context Context) error {
+	return step.reverse(context)
+}
@@ -110,6 +110,23 @@ func (u *mockUpgradeStep) Run(ctx upgrades.Context) error {
 	return nil
 }
 
+type mockReversibleStep struct {
+	mockUpgradeStep
+}
+
+func (u *mockReversibleStep) Reverse(ctx upgrades.Context) error {
+	if strings.HasSuffix(u.msg, "reverse error") {
+		return errors.New("reverse error occurred")
+	}
+	context := ctx.(*mockContext)
+	context.messages = append(context.messages, "reverse "+u.msg)
+	return nil
+}
+
+func newReversibleStep(msg string, targets ...upgrades.Target) *mockReversibleStep {
+	return &mockReversibleStep{*newUpgradeStep(msg, targets...)}
+}
+
 func newUpgradeStep(msg string, targets ...upgrades.Target) *mockUpgradeStep {
 	if len(targets) < 1 {
 		panic(fmt.Sprintf("step %q must have at least one target", msg))
@@ -369,6 +386,32 @@ func (s *upgradeSuite) TestAreUpgradesDefined(c *gc.C) {
 	}
 }
 
+func (s *upgradeSuite) TestPlannedSteps(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, stateUpgradeOperations)
+	s.PatchValue(upgrades.UpgradeOperations, upgradeOperations)
+	planned := upgrades.PlannedSteps(version.MustParse("1.20.0"), version.MustParse("1.21.0"))
+	c.Assert(planned, jc.DeepEquals, []upgrades.PlannedStep{{
+		Version:     version.MustParse("1.21.0"),
+		Description: "state step 1 - 1.21.0",
+		Targets:     targets(upgrades.DatabaseMaster),
+	}, {
+		Version:     version.MustParse("1.21.0"),
+		Description: "state step 2 - 1.21.0",
+		Targets:     targets(upgrades.Controller),
+	}, {
+		Version:     version.MustParse("1.21.0"),
+		Description: "step 1 - 1.21.0",
+		Targets:     targets(upgrades.AllMachines),
+	}})
+}
+
+func (s *upgradeSuite) TestPlannedStepsNone(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, stateUpgradeOperations)
+	s.PatchValue(upgrades.UpgradeOperations, upgradeOperations)
+	planned := upgrades.PlannedSteps(version.MustParse("1.13.0"), version.MustParse("1.14.1"))
+	c.Assert(planned, gc.HasLen, 0)
+}
+
 type upgradeTest struct {
 	about         string
 	fromVersion   string
@@ -560,6 +603,70 @@ func (s *upgradeSuite) TestPerformUpgrade(c *gc.C) {
 	}
 }
 
+func (s *upgradeSuite) TestReverseUpgrade(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("state step 1", upgrades.DatabaseMaster),
+					newReversibleStep("state step 2", upgrades.Controller),
+				},
+			},
+		}
+	})
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.20.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("step 1", upgrades.AllMachines),
+				},
+			},
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps: []upgrades.Step{
+					newUpgradeStep("step 2", upgrades.Controller),
+					newReversibleStep("step 3", upgrades.HostMachine),
+					newReversibleStep("step 4", upgrades.Controller),
+				},
+			},
+		}
+	})
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+
+	ctx := &mockContext{}
+	err := upgrades.ReverseUpgrade(version.MustParse("1.19.0"), targets(upgrades.Controller, upgrades.DatabaseMaster), ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.messages, jc.DeepEquals, []string{
+		"reverse step 4",
+		"reverse step 1",
+		"reverse state step 2",
+		"reverse state step 1",
+	})
+}
+
+func (s *upgradeSuite) TestReverseUpgradeError(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation { return nil })
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{
+			&mockUpgradeOperation{
+				targetVersion: version.MustParse("1.21.0"),
+				steps: []upgrades.Step{
+					newReversibleStep("step 1", upgrades.AllMachines),
+					newReversibleStep("step 2 reverse error", upgrades.AllMachines),
+				},
+			},
+		}
+	})
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+
+	ctx := &mockContext{}
+	err := upgrades.ReverseUpgrade(version.MustParse("1.20.0"), targets(upgrades.HostMachine), ctx)
+	c.Assert(err, gc.ErrorMatches, "reversing step 2 reverse error: reverse error occurred")
+	c.Assert(ctx.messages, gc.HasLen, 0)
+}
+
 type contextStep struct {
 	useAPI bool
 }
@@ -670,6 +777,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 	versions := extractUpgradeVersions(c, (*upgrades.StateUpgradeOperations)())
 	c.Assert(versions, gc.DeepEquals, []string{
 		"1.26-placeholder1",
+		"2.0.0",
 	})
 }
 
@@ -685,7 +793,9 @@ func extractUpgradeVersions(c *gc.C, ops []upgrades.Operation) []string {
 	for _, utv := range ops {
 		vers := utv.TargetVersion()
 		// Upgrade steps should only be targeted at final versions (not alpha/beta).
-		c.Check(vers.Tag, gc.Equals, "placeholder")
+		if vers.Tag != "placeholder" {
+			c.Check(vers.Tag, gc.Equals, "")
+		}
 		versions = append(versions, vers.String())
 	}
 	return versions
@@ -20,6 +20,8 @@ var logger = loggo.GetLogger("juju.worker.apiaddressupdater")
 //
 // In practice, APIAddressUpdater is used by a machine agent to watch
 // API addresses in state and write the changes to the agent's config file.
+// When the controller has a juju-mgmt-space configured, the API server only
+// reports addresses in that space.
 type APIAddressUpdater struct {
 	addresser APIAddresser
 	setter    APIAddressSetter
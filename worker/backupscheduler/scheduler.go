@@ -0,0 +1,176 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker which takes controller
+// backups on the schedule set in the controller config, and removes the
+// scheduled backups no longer kept by its retention policy.
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend defines the functionality used by the backup scheduler.
+type Backend interface {
+	// ControllerConfig returns the controller config, which holds the
+	// backup schedule and retention policy.
+	ControllerConfig() (controller.Config, error)
+
+	// LastScheduledBackupRun returns the outcome of the most recent
+	// scheduled backup, or an error satisfying errors.IsNotFound().
+	LastScheduledBackupRun() (state.ScheduledBackupRun, error)
+
+	// SetLastScheduledBackupRun records the outcome of a scheduled backup.
+	SetLastScheduledBackupRun(state.ScheduledBackupRun) error
+
+	// CreateScheduledBackup creates a new backup, recorded as taken
+	// on a schedule.
+	CreateScheduledBackup() (*backups.Metadata, error)
+
+	// ListBackups returns the metadata of all stored backups.
+	ListBackups() ([]*backups.Metadata, error)
+
+	// RemoveBackup removes the stored backup with the given ID.
+	RemoveBackup(id string) error
+}
+
+// Config holds the dependencies and configuration necessary to run a
+// backup scheduler.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+}
+
+// Validate returns an error if config cannot be expected to drive a
+// functional backup scheduler.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// New returns a worker which takes a backup of the controller every
+// backup-interval, as set in the controller config. Failing to create a
+// backup does not stop the worker; the failure is recorded as the outcome
+// of the run instead. This worker is intended to run just once, on the
+// MongoDB master.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	s := &scheduler{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &s.catacomb,
+		Work: s.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+type scheduler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (s *scheduler) Kill() {
+	s.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (s *scheduler) Wait() error {
+	return s.catacomb.Wait()
+}
+
+func (s *scheduler) loop() error {
+	backend := s.config.Backend
+	controllerConfig, err := backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	interval := controllerConfig.BackupInterval()
+	if interval <= 0 {
+		logger.Debugf("scheduled backups disabled")
+		<-s.catacomb.Dying()
+		return s.catacomb.ErrDying()
+	}
+	policy := backups.RetentionPolicy{
+		KeepLast:   controllerConfig.BackupKeepLast(),
+		KeepDaily:  controllerConfig.BackupKeepDaily(),
+		KeepWeekly: controllerConfig.BackupKeepWeekly(),
+	}
+
+	// Pick up the schedule where the last run, possibly on another
+	// controller, left it. Without a previous run, back up right away.
+	next := s.config.Clock.Now()
+	lastRun, err := backend.LastScheduledBackupRun()
+	if err == nil {
+		next = lastRun.Started.Add(interval)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	for {
+		logger.Debugf("next scheduled backup at %v", next)
+		select {
+		case <-s.catacomb.Dying():
+			return s.catacomb.ErrDying()
+		case <-s.config.Clock.After(next.Sub(s.config.Clock.Now())):
+		}
+		run := s.backup(policy)
+		if err := backend.SetLastScheduledBackupRun(run); err != nil {
+			return errors.Trace(err)
+		}
+		next = run.Started.Add(interval)
+	}
+}
+
+// backup creates a scheduled backup and removes the expired ones,
+// returning the outcome.
+func (s *scheduler) backup(policy backups.RetentionPolicy) state.ScheduledBackupRun {
+	backend := s.config.Backend
+	run := state.ScheduledBackupRun{Started: s.config.Clock.Now()}
+	fail := func(err error) state.ScheduledBackupRun {
+		logger.Errorf("scheduled backup failed: %v", err)
+		run.Error = err.Error()
+		run.Finished = s.config.Clock.Now()
+		return run
+	}
+
+	meta, err := backend.CreateScheduledBackup()
+	if err != nil {
+		return fail(errors.Annotate(err, "cannot create backup"))
+	}
+	run.BackupID = meta.ID()
+	logger.Infof("created scheduled backup %q", run.BackupID)
+
+	metaList, err := backend.ListBackups()
+	if err != nil {
+		return fail(errors.Annotate(err, "cannot list backups"))
+	}
+	for _, id := range policy.Expired(metaList) {
+		if err := backend.RemoveBackup(id); err != nil {
+			return fail(errors.Annotatef(err, "cannot remove expired backup %q", id))
+		}
+		logger.Infof("removed expired backup %q", id)
+		run.Removed = append(run.Removed, id)
+	}
+	run.Finished = s.config.Clock.Now()
+	return run
+}
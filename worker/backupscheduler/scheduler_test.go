@@ -0,0 +1,231 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type schedulerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *coretesting.Clock
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&schedulerSuite{})
+
+func (s *schedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, time.August, 8, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		clock: s.clock,
+		config: controller.Config{
+			controller.BackupInterval: "1h",
+			controller.BackupKeepLast: 2,
+		},
+		runs: make(chan state.ScheduledBackupRun, 10),
+	}
+}
+
+func (s *schedulerSuite) newScheduler(c *gc.C) worker.Worker {
+	w, err := backupscheduler.New(backupscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *schedulerSuite) nextRun(c *gc.C) state.ScheduledBackupRun {
+	select {
+	case run := <-s.backend.runs:
+		return run
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scheduled backup")
+	}
+	panic("unreachable")
+}
+
+func (s *schedulerSuite) assertNoRun(c *gc.C) {
+	select {
+	case run := <-s.backend.runs:
+		c.Fatalf("unexpected scheduled backup: %#v", run)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *schedulerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scheduler to wait")
+	}
+}
+
+func (s *schedulerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.New(backupscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = backupscheduler.New(backupscheduler.Config{Backend: s.backend})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *schedulerSuite) TestDisabled(c *gc.C) {
+	delete(s.backend.config, controller.BackupInterval)
+	w := s.newScheduler(c)
+	defer workertest.CleanKill(c, w)
+
+	s.clock.Advance(24 * time.Hour)
+	s.assertNoRun(c)
+}
+
+func (s *schedulerSuite) TestBacksUpImmediatelyWithoutPreviousRun(c *gc.C) {
+	w := s.newScheduler(c)
+	defer workertest.CleanKill(c, w)
+
+	run := s.nextRun(c)
+	c.Check(run.Started, gc.Equals, s.clock.Now())
+	c.Check(run.BackupID, gc.Equals, "backup-0")
+	c.Check(run.Error, gc.Equals, "")
+}
+
+func (s *schedulerSuite) TestAppliesRetentionPolicy(c *gc.C) {
+	w := s.newScheduler(c)
+	defer workertest.CleanKill(c, w)
+
+	run := s.nextRun(c)
+	c.Check(run.Removed, gc.HasLen, 0)
+	// The first backup was taken without waiting.
+	s.waitAlarm(c)
+	for i := 1; i <= 2; i++ {
+		s.waitAlarm(c)
+		s.clock.Advance(time.Hour)
+		run = s.nextRun(c)
+	}
+	c.Check(run.BackupID, gc.Equals, "backup-2")
+	c.Check(run.Removed, jc.DeepEquals, []string{"backup-0"})
+	c.Check(s.backend.backupIDs(), jc.DeepEquals, []string{"manual", "backup-1", "backup-2"})
+}
+
+func (s *schedulerSuite) TestResumesSchedule(c *gc.C) {
+	s.backend.lastRun = &state.ScheduledBackupRun{
+		Started: s.clock.Now().Add(-30 * time.Minute),
+	}
+	w := s.newScheduler(c)
+	defer workertest.CleanKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(29 * time.Minute)
+	s.assertNoRun(c)
+	s.clock.Advance(time.Minute)
+	run := s.nextRun(c)
+	c.Check(run.BackupID, gc.Equals, "backup-0")
+}
+
+func (s *schedulerSuite) TestRecordsFailure(c *gc.C) {
+	s.backend.createErr = errors.New("HA not ready")
+	w := s.newScheduler(c)
+	defer workertest.CleanKill(c, w)
+
+	run := s.nextRun(c)
+	c.Check(run.BackupID, gc.Equals, "")
+	c.Check(run.Error, gc.Equals, "cannot create backup: HA not ready")
+}
+
+type fakeBackend struct {
+	mu        sync.Mutex
+	clock     *coretesting.Clock
+	config    controller.Config
+	lastRun   *state.ScheduledBackupRun
+	runs      chan state.ScheduledBackupRun
+	created   int
+	metaList  []*backups.Metadata
+	createErr error
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, nil
+}
+
+func (b *fakeBackend) LastScheduledBackupRun() (state.ScheduledBackupRun, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.lastRun == nil {
+		return state.ScheduledBackupRun{}, errors.NotFoundf("scheduled backup run")
+	}
+	return *b.lastRun, nil
+}
+
+func (b *fakeBackend) SetLastScheduledBackupRun(run state.ScheduledBackupRun) error {
+	b.mu.Lock()
+	b.lastRun = &run
+	b.mu.Unlock()
+	b.runs <- run
+	return nil
+}
+
+func (b *fakeBackend) CreateScheduledBackup() (*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.createErr != nil {
+		return nil, b.createErr
+	}
+	if b.metaList == nil {
+		manual := backups.NewMetadata()
+		manual.SetID("manual")
+		manual.Started = b.clock.Now().Add(-time.Hour)
+		b.metaList = append(b.metaList, manual)
+	}
+	meta := backups.NewMetadata()
+	meta.SetID(fmt.Sprintf("backup-%d", b.created))
+	meta.Started = b.clock.Now()
+	meta.Scheduled = true
+	b.created++
+	b.metaList = append(b.metaList, meta)
+	return meta, nil
+}
+
+func (b *fakeBackend) ListBackups() ([]*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*backups.Metadata(nil), b.metaList...), nil
+}
+
+func (b *fakeBackend) RemoveBackup(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, meta := range b.metaList {
+		if meta.ID() == id {
+			b.metaList = append(b.metaList[:i], b.metaList[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("backup %q", id)
+}
+
+func (b *fakeBackend) backupIDs() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ids := make([]string, len(b.metaList))
+	for i, meta := range b.metaList {
+		ids[i] = meta.ID()
+	}
+	return ids
+}
@@ -0,0 +1,80 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackend returns a Backend which backs up the controller state
+// from the machine with the given ID, using the given data and log paths.
+func NewStateBackend(st *state.State, machineID string, paths backups.Paths) Backend {
+	return &stateBackend{
+		State:     st,
+		machineID: machineID,
+		paths:     paths,
+	}
+}
+
+type stateBackend struct {
+	*state.State
+	machineID string
+	paths     backups.Paths
+}
+
+// CreateScheduledBackup is part of the Backend interface.
+func (b *stateBackend) CreateScheduledBackup() (*backups.Metadata, error) {
+	stor := backups.NewStorage(b.State)
+	defer stor.Close()
+
+	session := b.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+	dbInfo, err := backups.NewDBInfo(b.MongoConnectionInfo(), session)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := b.Machine(b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.State, b.machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = backups.ScheduledNotes
+	meta.Scheduled = true
+
+	if err := backups.NewBackups(stor).Create(meta, &b.paths, dbInfo, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// ListBackups is part of the Backend interface.
+func (b *stateBackend) ListBackups() ([]*backups.Metadata, error) {
+	stor := backups.NewStorage(b.State)
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b *stateBackend) RemoveBackup(id string) error {
+	stor := backups.NewStorage(b.State)
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
@@ -0,0 +1,67 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/charmupgrader"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/dependency"
+)
+
+// ManifoldConfig describes how to create a worker that applies charm
+// upgrades to the applications of a model according to their policies.
+type ManifoldConfig struct {
+	APICallerName string
+	ClockName     string
+
+	Period    time.Duration
+	NewFacade func(base.APICaller) (Facade, error)
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// Manifold returns a dependency.Manifold that runs a charm upgrader
+// worker according to the supplied configuration.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.ClockName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			var clock clock.Clock
+			if err := context.Get(config.ClockName, &clock); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			facade, err := config.NewFacade(apiCaller)
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot create facade")
+			}
+			worker, err := config.NewWorker(Config{
+				Facade: facade,
+				Clock:  clock,
+				Period: config.Period,
+			})
+			if err != nil {
+				return nil, errors.Annotatef(err, "cannot create worker")
+			}
+			return worker, nil
+		},
+	}
+}
+
+// NewFacade returns a Facade backed by the supplied APICaller. It's a
+// sensible value for ManifoldConfig.NewFacade.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return charmupgrader.NewFacade(apiCaller), nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"launchpad.net/tomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmupgrade"
+	"github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.charmupgrader")
+
+// Facade exposes the controller capabilities required by the worker.
+type Facade interface {
+
+	// UpgradesAvailable returns the charm, upgrade policy and newer
+	// charm revision, if any, of every application in the model.
+	UpgradesAvailable() ([]params.CharmUpgradeInfo, error)
+
+	// UpgradeCharms upgrades the applications to the given charms,
+	// returning one error, possibly nil, per upgrade.
+	UpgradeCharms([]params.UpgradeApplicationCharm) ([]error, error)
+}
+
+// Config defines the operation of a charm upgrader worker.
+type Config struct {
+
+	// Facade is the worker's view of the controller.
+	Facade Facade
+
+	// Clock is the worker's view of time. Maintenance windows are
+	// checked against it.
+	Clock clock.Clock
+
+	// Period is the time between checks for upgrades to apply.
+	Period time.Duration
+}
+
+// Validate returns an error if the configuration cannot be expected
+// to start a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that, once when started and subsequently
+// every Period, upgrades the charms of applications that have a newer
+// revision available and whose auto-in-window upgrade policy has its
+// maintenance window open.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &upgraderWorker{
+		config: config,
+	}
+	go func() {
+		defer w.tomb.Done()
+		w.tomb.Kill(w.loop())
+	}()
+	return w, nil
+}
+
+type upgraderWorker struct {
+	tomb   tomb.Tomb
+	config Config
+}
+
+func (w *upgraderWorker) loop() error {
+	var delay time.Duration
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.config.Clock.After(delay):
+			if err := w.upgrade(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		delay = w.config.Period
+	}
+}
+
+// upgrade applies the upgrades allowed at the current time. Failing
+// to upgrade an application is logged rather than stopping the worker,
+// so one broken charm doesn't hold back the others.
+func (w *upgraderWorker) upgrade() error {
+	infos, err := w.config.Facade.UpgradesAvailable()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	var upgrades []params.UpgradeApplicationCharm
+	for _, info := range infos {
+		if info.Available == "" {
+			continue
+		}
+		allowed, err := policyAllows(info.Policy, now)
+		if err != nil {
+			logger.Warningf("ignoring upgrade policy of %s: %v", info.ApplicationTag, err)
+			continue
+		}
+		if !allowed {
+			continue
+		}
+		upgrades = append(upgrades, params.UpgradeApplicationCharm{
+			ApplicationTag: info.ApplicationTag,
+			CharmURL:       info.Available,
+		})
+	}
+	if len(upgrades) == 0 {
+		return nil
+	}
+	errs, err := w.config.Facade.UpgradeCharms(upgrades)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, err := range errs {
+		upgrade := upgrades[i]
+		if err != nil {
+			logger.Errorf("cannot upgrade %s to %q: %v", upgrade.ApplicationTag, upgrade.CharmURL, err)
+			continue
+		}
+		logger.Infof("upgraded %s to %q", upgrade.ApplicationTag, upgrade.CharmURL)
+	}
+	return nil
+}
+
+func policyAllows(p params.CharmUpgradePolicy, now time.Time) (bool, error) {
+	window, err := charmupgrade.ParseWindow(p.Window)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	policy := charmupgrade.Policy{
+		Mode:   charmupgrade.Mode(p.Mode),
+		Window: window,
+	}
+	return policy.Allows(now), nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *upgraderWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *upgraderWorker) Wait() error {
+	return w.tomb.Wait()
+}
@@ -0,0 +1,191 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/charmupgrader"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+// The window opens 30 minutes after the fixture's clock starts.
+var startTime = time.Date(2016, time.August, 1, 1, 30, 0, 0, time.UTC)
+
+var upgradeInfos = []params.CharmUpgradeInfo{{
+	ApplicationTag: "application-mysql",
+	CharmURL:       "cs:trusty/mysql-3",
+	Available:      "cs:trusty/mysql-5",
+	Policy:         params.CharmUpgradePolicy{Mode: "auto-in-window", Window: "02:00-03:00"},
+}, {
+	ApplicationTag: "application-wordpress",
+	CharmURL:       "cs:trusty/wordpress-1",
+	Available:      "cs:trusty/wordpress-2",
+	Policy:         params.CharmUpgradePolicy{Mode: "notify"},
+}, {
+	ApplicationTag: "application-haproxy",
+	CharmURL:       "cs:trusty/haproxy-7",
+	Policy:         params.CharmUpgradePolicy{Mode: "auto-in-window", Window: "02:00-03:00"},
+}}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := charmupgrader.NewWorker(charmupgrader.Config{})
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestNoUpgradeOutsideWindow(c *gc.C) {
+	fix := newFixture()
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.facade.stub.CheckCallNames(c, "UpgradesAvailable")
+}
+
+func (s *WorkerSuite) TestUpgradeInWindow(c *gc.C) {
+	fix := newFixture()
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		// Wait for the initial and the periodic alarm.
+		fix.waitAlarms(c, 2)
+		fix.clock.Advance(30 * time.Minute)
+		fix.waitCall(c)
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.facade.stub.CheckCallNames(c, "UpgradesAvailable", "UpgradesAvailable", "UpgradeCharms")
+	fix.facade.stub.CheckCall(c, 2, "UpgradeCharms", []params.UpgradeApplicationCharm{{
+		ApplicationTag: "application-mysql",
+		CharmURL:       "cs:trusty/mysql-5",
+	}})
+}
+
+func (s *WorkerSuite) TestUpgradeFailureLogged(c *gc.C) {
+	fix := newFixture()
+	fix.clock = coretesting.NewClock(startTime.Add(time.Hour))
+	fix.facade.upgradeErrs = []error{errors.New("bad charm")}
+	fix.cleanTest(c, func(_ worker.Worker) {
+		fix.waitCall(c)
+		fix.waitCall(c)
+		fix.waitNoCall(c)
+	})
+	fix.facade.stub.CheckCallNames(c, "UpgradesAvailable", "UpgradeCharms")
+}
+
+func (s *WorkerSuite) TestUpgradesAvailableError(c *gc.C) {
+	fix := newFixture()
+	fix.facade.stub.SetErrors(errors.New("no upgrades for you"))
+	fix.dirtyTest(c, func(w worker.Worker) {
+		fix.waitCall(c)
+		c.Check(w.Wait(), gc.ErrorMatches, "no upgrades for you")
+	})
+}
+
+// workerFixture isolates a charmupgrader worker for testing.
+type workerFixture struct {
+	facade *mockFacade
+	clock  *coretesting.Clock
+}
+
+func newFixture() *workerFixture {
+	return &workerFixture{
+		facade: &mockFacade{
+			stub:  &testing.Stub{},
+			calls: make(chan struct{}, 1000),
+			infos: upgradeInfos,
+		},
+		clock: coretesting.NewClock(startTime),
+	}
+}
+
+type testFunc func(worker.Worker)
+
+func (fix *workerFixture) cleanTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, true)
+}
+
+func (fix *workerFixture) dirtyTest(c *gc.C, test testFunc) {
+	fix.runTest(c, test, false)
+}
+
+func (fix *workerFixture) runTest(c *gc.C, test testFunc, checkWaitErr bool) {
+	w, err := charmupgrader.NewWorker(charmupgrader.Config{
+		Facade: fix.facade,
+		Clock:  fix.clock,
+		Period: 30 * time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := worker.Stop(w)
+		if checkWaitErr {
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}()
+	test(w)
+}
+
+func (fix *workerFixture) waitCall(c *gc.C) {
+	select {
+	case <-fix.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out")
+	}
+}
+
+func (fix *workerFixture) waitAlarms(c *gc.C, n int) {
+	for i := 0; i < n; i++ {
+		select {
+		case <-fix.clock.Alarms():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for alarm %d", i)
+		}
+	}
+}
+
+func (fix *workerFixture) waitNoCall(c *gc.C) {
+	select {
+	case <-fix.facade.calls:
+		c.Fatalf("unexpected facade call")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+// mockFacade records (and notifies of) calls made to the facade.
+type mockFacade struct {
+	stub        *testing.Stub
+	calls       chan struct{}
+	infos       []params.CharmUpgradeInfo
+	upgradeErrs []error
+}
+
+func (mock *mockFacade) UpgradesAvailable() ([]params.CharmUpgradeInfo, error) {
+	mock.stub.AddCall("UpgradesAvailable")
+	mock.calls <- struct{}{}
+	return mock.infos, mock.stub.NextErr()
+}
+
+func (mock *mockFacade) UpgradeCharms(upgrades []params.UpgradeApplicationCharm) ([]error, error) {
+	mock.stub.AddCall("UpgradeCharms", upgrades)
+	mock.calls <- struct{}{}
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	if mock.upgradeErrs != nil {
+		return mock.upgradeErrs, nil
+	}
+	return make([]error, len(upgrades)), nil
+}
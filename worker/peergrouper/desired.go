@@ -8,6 +8,7 @@ import (
 	"sort"
 
 	"github.com/juju/replicaset"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/network"
 )
@@ -22,6 +23,10 @@ type peerGroupInfo struct {
 	statuses        []replicaset.MemberStatus
 	members         []replicaset.Member
 	mongoSpace      network.SpaceName
+
+	// removing holds the ids of machines that are being
+	// removed from the controller.
+	removing set.Strings
 }
 
 // desiredPeerGroup returns the mongo peer group according to the given
@@ -82,6 +87,16 @@ func desiredPeerGroup(info *peerGroupInfo) ([]replicaset.Member, map[*machineTra
 	}
 	adjustVotes(toRemoveVote, toAddVote, setVoting)
 
+	// Machines that are being removed lose their vote even if that
+	// leaves an even number of votes; they are not coming back, and
+	// a lost voter is no better than no voter at all.
+	for _, m := range toRemoveVote {
+		if info.removing.Contains(m.Id()) && machineVoting[m] {
+			logger.Warningf("removing vote from machine %q being removed; the number of votes may now be even", m.Id())
+			setVoting(m, false)
+		}
+	}
+
 	addNewMembers(members, toKeep, maxId, setVoting, info.mongoSpace)
 	if updateAddresses(members, info.machineTrackers, info.mongoSpace) {
 		changed = true
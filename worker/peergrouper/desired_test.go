@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/replicaset"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/network"
@@ -238,6 +239,35 @@ func (*desiredPeerGroupSuite) TestDesiredPeerGroup(c *gc.C) {
 	})
 }
 
+func (*desiredPeerGroupSuite) TestDesiredPeerGroupRemovingMachine(c *gc.C) {
+	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
+		machines := mkMachines("11v 12v 13", ipVersion)
+		trackerMap := make(map[string]*machineTracker)
+		for _, m := range machines {
+			trackerMap[m.Id()] = m
+		}
+		info := &peerGroupInfo{
+			machineTrackers: trackerMap,
+			statuses:        mkStatuses("1p 2s 3H", ipVersion),
+			members:         mkMembers("1v 2v 3v", ipVersion),
+		}
+
+		// Without a replacement, a machine that no longer wants
+		// the vote keeps it, so that the number of votes stays odd.
+		members, _, err := desiredPeerGroup(info)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(members, gc.IsNil)
+
+		// A machine that is being removed loses it regardless.
+		info.removing = set.NewStrings("13")
+		members, voting, err := desiredPeerGroup(info)
+		c.Assert(err, jc.ErrorIsNil)
+		sort.Sort(membersById(members))
+		c.Check(members, jc.DeepEquals, mkMembers("1v 2v 3", ipVersion))
+		c.Check(voting[machines[2]], jc.IsFalse)
+	})
+}
+
 func countVotes(members []replicaset.Member) int {
 	tot := 0
 	for _, m := range members {
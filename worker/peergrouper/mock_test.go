@@ -19,6 +19,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -39,6 +40,7 @@ type fakeState struct {
 	statuses    voyeur.Value // of statuses collection
 	session     *fakeMongoSession
 	check       func(st *fakeState) error
+	mgmtSpace   string
 }
 
 var (
@@ -220,6 +222,35 @@ func (st *fakeState) setControllers(ids ...string) {
 	})
 }
 
+// setRemoving marks the given controller machines as being removed.
+func (st *fakeState) setRemoving(ids ...string) {
+	info := deepCopy(st.controllers.Get()).(*state.ControllerInfo)
+	info.RemovingMachineIds = ids
+	st.controllers.Set(info)
+}
+
+func (st *fakeState) CompleteControllerMemberRemoval(id string) error {
+	if err := st.errors.errorFor("State.CompleteControllerMemberRemoval", id); err != nil {
+		return err
+	}
+	info := deepCopy(st.controllers.Get()).(*state.ControllerInfo)
+	info.MachineIds = removeString(info.MachineIds, id)
+	info.VotingMachineIds = removeString(info.VotingMachineIds, id)
+	info.RemovingMachineIds = removeString(info.RemovingMachineIds, id)
+	st.controllers.Set(info)
+	return nil
+}
+
+func removeString(ss []string, s string) []string {
+	var result []string
+	for _, v := range ss {
+		if v != s {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
 func (st *fakeState) ControllerInfo() (*state.ControllerInfo, error) {
 	if err := st.errors.errorFor("State.ControllerInfo"); err != nil {
 		return nil, err
@@ -273,6 +304,20 @@ func (st *fakeState) getMongoSpaceState() state.MongoSpaceStates {
 	return inf.MongoSpaceState
 }
 
+func (st *fakeState) setManagementSpace(space string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.mgmtSpace = space
+}
+
+func (st *fakeState) ControllerConfig() (controller.Config, error) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	cfg := coretesting.FakeControllerConfig()
+	cfg[controller.JujuManagementSpace] = st.mgmtSpace
+	return cfg, nil
+}
+
 func (st *fakeState) ModelConfig() (*config.Config, error) {
 	attrs := coretesting.FakeConfig()
 	cfg, err := config.New(config.NoDefaults, attrs)
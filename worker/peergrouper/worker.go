@@ -10,8 +10,10 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/replicaset"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/apiserver/common/networkingcommon"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
@@ -33,6 +35,8 @@ type stateInterface interface {
 	SetOrGetMongoSpaceName(spaceName network.SpaceName) (network.SpaceName, error)
 	SetMongoSpaceState(mongoSpaceState state.MongoSpaceStates) error
 	ModelConfig() (*config.Config, error)
+	ControllerConfig() (controller.Config, error)
+	CompleteControllerMemberRemoval(machineId string) error
 }
 
 type stateMachine interface {
@@ -99,6 +103,10 @@ type pgWorker struct {
 	// are currently watching (all the controller machines).
 	machineTrackers map[string]*machineTracker
 
+	// removing holds the ids of the controller machines that are
+	// being removed from the controller.
+	removing set.Strings
+
 	// publisher holds the implementation of the API
 	// address publisher.
 	publisher publisherInterface
@@ -195,6 +203,9 @@ func (w *pgWorker) loop() error {
 				}
 				logger.Errorf("cannot set replicaset: %v", err)
 				ok = false
+			} else if err := w.completeRemovals(); err != nil {
+				logger.Errorf("cannot remove controller machines: %v", err)
+				ok = false
 			}
 			if ok {
 				// Update the replica set members occasionally
@@ -258,6 +269,13 @@ func (w *pgWorker) updateControllerMachines() (bool, error) {
 	logger.Debugf("controller machines in state: %#v", info.MachineIds)
 	changed := false
 
+	removing := set.NewStrings(info.RemovingMachineIds...)
+	if !removing.Difference(w.removing).IsEmpty() {
+		logger.Infof("removing controller machines: %v", removing.SortedValues())
+		changed = true
+	}
+	w.removing = removing
+
 	// Stop machine goroutines that no longer correspond to controller
 	// machines.
 	for _, m := range w.machineTrackers {
@@ -324,7 +342,7 @@ func (w *pgWorker) apiPublishInfo() ([][]network.HostPort, []instance.Id, error)
 	servers := make([][]network.HostPort, 0, len(w.machineTrackers))
 	instanceIds := make([]instance.Id, 0, len(w.machineTrackers))
 	for _, m := range w.machineTrackers {
-		if len(m.APIHostPorts()) == 0 {
+		if len(m.APIHostPorts()) == 0 || w.removing.Contains(m.Id()) {
 			continue
 		}
 		instanceId, err := m.stm.InstanceId()
@@ -354,6 +372,7 @@ func (w *pgWorker) peerGroupInfo() (*peerGroupInfo, error) {
 		return nil, fmt.Errorf("cannot get replica set members: %v", err)
 	}
 	info.machineTrackers = w.machineTrackers
+	info.removing = w.removing
 
 	spaceName, err := w.getMongoSpace(mongoAddresses(info.machineTrackers))
 	if err != nil {
@@ -377,9 +396,19 @@ func mongoAddresses(machines map[string]*machineTracker) [][]network.Address {
 }
 
 // getMongoSpace updates info with the space that Mongo servers should exist in.
+// The juju-mgmt-space controller setting, when set, takes precedence over the
+// space discovered from the machine addresses.
 func (w *pgWorker) getMongoSpace(addrs [][]network.Address) (network.SpaceName, error) {
 	unset := network.SpaceName("")
 
+	controllerConfig, err := w.st.ControllerConfig()
+	if err != nil {
+		return unset, errors.Annotate(err, "cannot get controller config")
+	}
+	if space := controllerConfig.JujuManagementSpace(); space != "" {
+		return network.SpaceName(space), nil
+	}
+
 	stateInfo, err := w.st.ControllerInfo()
 	if err != nil {
 		return unset, errors.Annotate(err, "cannot get state server info")
@@ -500,6 +529,23 @@ func (w *pgWorker) updateReplicaset() error {
 	return nil
 }
 
+// completeRemovals finishes removing the controller machines that are
+// being removed, once they no longer have a vote. The machines are then
+// dropped from the controller info, which in turn causes their members
+// to be removed from the replica set.
+func (w *pgWorker) completeRemovals() error {
+	for _, id := range w.removing.SortedValues() {
+		if m, ok := w.machineTrackers[id]; ok && m.stm.HasVote() {
+			continue
+		}
+		logger.Infof("completing removal of controller machine %q", id)
+		if err := w.st.CompleteControllerMemberRemoval(id); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // setHasVote sets the HasVote status of all the given
 // machines to hasVote.
 func setHasVote(ms []*machineTracker, hasVote bool) error {
@@ -580,6 +580,38 @@ func (s *workerSuite) TestMongoSpaceNotOverwritten(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestMongoUsesManagementSpace(c *gc.C) {
+	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
+		st, machines, hostPorts := mongoSpaceTestCommonSetup(c, ipVersion, false)
+
+		// All machines get host ports in spaces one, two and three.
+		for _, machine := range machines {
+			st.machine(machine).setMongoHostPorts(hostPorts)
+		}
+		st.setManagementSpace("three")
+
+		memberWatcher := st.session.members.Watch()
+		w := startWorkerSupportingSpaces(c, st, ipVersion)
+		defer workertest.CleanKill(c, w)
+
+		// The space three address is 0.0.0.3 giving us the host port of
+		// 0.0.0.3:4711 for all machines.
+		expected := fmt.Sprintf(ipVersion.formatHostPort, 3, 4711)
+		for {
+			members := mustNext(c, memberWatcher).([]replicaset.Member)
+			if len(members) == 3 &&
+				members[0].Address == expected &&
+				members[1].Address == expected &&
+				members[2].Address == expected {
+				break
+			}
+		}
+
+		// The management space is not recorded as the discovered Mongo space.
+		c.Assert(st.getMongoSpaceName(), gc.Equals, "")
+	})
+}
+
 func (s *workerSuite) TestMongoSpaceNotCalculatedWhenSpacesNotSupported(c *gc.C) {
 	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
 		st, machines, hostPorts := mongoSpaceTestCommonSetup(c, ipVersion, false)
@@ -674,6 +706,59 @@ func (s *workerSuite) TestWorkerPublishesInstanceIds(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestRemovesControllerMember(c *gc.C) {
+	DoTestForIPv4AndIPv6(func(ipVersion TestIPVersion) {
+		s.PatchValue(&pollInterval, coretesting.LongWait+time.Second)
+
+		publishCh := make(chan []instance.Id, 100)
+		publish := func(apiServers [][]network.HostPort, instanceIds []instance.Id) error {
+			publishCh <- instanceIds
+			return nil
+		}
+		st := NewFakeState()
+		InitState(c, st, 3, ipVersion)
+		// Removing a lost voter may leave an even number of votes.
+		st.check = nil
+		for _, id := range []string{"10", "11", "12"} {
+			st.machine(id).SetHasVote(true)
+		}
+		st.session.Set(mkMembers("0v 1v 2v", ipVersion))
+		st.session.setStatus(mkStatuses("0p 1s 2H", ipVersion))
+
+		// Machine 12 has been lost, and is being removed.
+		st.machine("12").setWantsVote(false)
+		st.setRemoving("12")
+
+		memberWatcher := st.session.members.Watch()
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v 1v 2v", ipVersion))
+
+		w, err := newWorker(st, PublisherFunc(publish), false)
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, w)
+
+		// The API addresses of the machine are no longer published.
+		select {
+		case instanceIds := <-publishCh:
+			c.Assert(instanceIds, jc.SameContents, []instance.Id{"id-10", "id-11"})
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for publish")
+		}
+
+		// The machine loses its vote, and is then removed.
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v 1v 2", ipVersion))
+		mustNext(c, memberWatcher)
+		assertMembers(c, memberWatcher.Value(), mkMembers("0v 1v", ipVersion))
+
+		info, err := st.ControllerInfo()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(info.MachineIds, jc.SameContents, []string{"10", "11"})
+		c.Check(info.RemovingMachineIds, gc.HasLen, 0)
+		c.Check(st.machine("12").HasVote(), jc.IsFalse)
+	})
+}
+
 // mustNext waits for w's value to be set and returns it.
 func mustNext(c *gc.C, w *voyeur.Watcher) (val interface{}) {
 	type voyeurResult struct {
@@ -0,0 +1,96 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package upgraderollout provides a worker which rolls out model
+// upgrades in batches, releasing the next batch of each model's upgrade
+// plan once the machines in the previous batches have upgraded.
+package upgraderollout
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// Backend defines the functionality used by the rollout worker.
+type Backend interface {
+	// AdvanceUpgradePlans releases the next batch of every model's
+	// upgrade plan that is ready for it.
+	AdvanceUpgradePlans(now time.Time) error
+}
+
+// Config holds the dependencies and configuration necessary to run a
+// rollout worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+
+	// Interval is how often the upgrade plans are checked.
+	Interval time.Duration
+}
+
+// Validate returns an error if config cannot be expected to drive a
+// functional rollout worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a worker which checks the upgrade plans of all models
+// every interval, releasing the batches that are ready. This worker is
+// intended to run just once, on the MongoDB master.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &rollout{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &r.catacomb,
+		Work: r.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+type rollout struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (r *rollout) Kill() {
+	r.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (r *rollout) Wait() error {
+	return r.catacomb.Wait()
+}
+
+func (r *rollout) loop() error {
+	for {
+		select {
+		case <-r.catacomb.Dying():
+			return r.catacomb.ErrDying()
+		case <-r.config.Clock.After(r.config.Interval):
+		}
+		now := r.config.Clock.Now()
+		if err := r.config.Backend.AdvanceUpgradePlans(now); err != nil {
+			return errors.Annotate(err, "cannot advance upgrade plans")
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2016 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgraderollout_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/upgraderollout"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type rolloutSuite struct {
+	coretesting.BaseSuite
+
+	clock   *coretesting.Clock
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&rolloutSuite{})
+
+func (s *rolloutSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = coretesting.NewClock(time.Date(2016, time.August, 8, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{calls: make(chan time.Time, 10)}
+}
+
+func (s *rolloutSuite) newWorker(c *gc.C) worker.Worker {
+	w, err := upgraderollout.New(upgraderollout.Config{
+		Backend:  s.backend,
+		Clock:    s.clock,
+		Interval: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *rolloutSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to wait")
+	}
+}
+
+func (s *rolloutSuite) TestValidate(c *gc.C) {
+	_, err := upgraderollout.New(upgraderollout.Config{Clock: s.clock, Interval: time.Minute})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = upgraderollout.New(upgraderollout.Config{Backend: s.backend, Interval: time.Minute})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+
+	_, err = upgraderollout.New(upgraderollout.Config{Backend: s.backend, Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "non-positive Interval not valid")
+}
+
+func (s *rolloutSuite) TestAdvancesEveryInterval(c *gc.C) {
+	w := s.newWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		s.waitAlarm(c)
+		select {
+		case now := <-s.backend.calls:
+			c.Fatalf("unexpected call at %v", now)
+		default:
+		}
+		s.clock.Advance(time.Minute)
+		select {
+		case now := <-s.backend.calls:
+			c.Check(now, gc.Equals, s.clock.Now())
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for upgrade plans to be advanced")
+		}
+	}
+}
+
+func (s *rolloutSuite) TestError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w := s.newWorker(c)
+	defer workertest.DirtyKill(c, w)
+
+	s.waitAlarm(c)
+	s.clock.Advance(time.Minute)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot advance upgrade plans: boom")
+}
+
+type fakeBackend struct {
+	calls chan time.Time
+	err   error
+}
+
+func (b *fakeBackend) AdvanceUpgradePlans(now time.Time) error {
+	b.calls <- now
+	return b.err
+}
@@ -5,6 +5,7 @@ package upgradesteps
 
 import (
 	"fmt"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
@@ -18,6 +19,7 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/status"
 	"github.com/juju/juju/upgrades"
@@ -32,6 +34,13 @@ var logger = loggo.GetLogger("juju.worker.upgradesteps")
 
 var (
 	PerformUpgrade = upgrades.PerformUpgrade // Allow patching
+	ReverseUpgrade = upgrades.ReverseUpgrade // Allow patching
+
+	// SnapshotDatabase and RestoreSnapshot take and restore the
+	// snapshot of the database that a failed upgrade is rolled back
+	// to.
+	SnapshotDatabase = snapshotDatabase
+	RestoreSnapshot  = backups.RestoreSnapshot
 
 	// The maximum time a master controller will wait for other
 	// controllers to come up and indicate they are ready to begin
@@ -242,6 +251,11 @@ func (w *upgradesteps) runUpgrades() error {
 	}
 
 	if err := w.agent.ChangeConfig(w.runUpgradeSteps); err != nil {
+		// The upgrade steps have failed on every retry; the upgrade
+		// can only be rolled back if a snapshot was taken before them.
+		if w.canRollback(upgradeInfo) && !isAPILostDuringUpgrade(err) {
+			return w.waitForRollback(upgradeInfo, err)
+		}
 		return err
 	}
 
@@ -289,12 +303,53 @@ func (w *upgradesteps) prepareForUpgrade() (*state.UpgradeInfo, error) {
 	}
 	if w.isMaster {
 		logger.Infof("finished waiting - all controllers are ready to run upgrade steps")
+		if err := w.takeSnapshot(info); err != nil {
+			return nil, errors.Trace(err)
+		}
 	} else {
 		logger.Infof("finished waiting - the master has completed its upgrade steps")
 	}
 	return info, nil
 }
 
+// takeSnapshot snapshots the database before the master controller
+// runs its upgrade steps, so that the upgrade can be rolled back if
+// they fail. The snapshot can only be restored into this controller's
+// database, so none is taken when there is more than one controller.
+func (w *upgradesteps) takeSnapshot(info *state.UpgradeInfo) error {
+	if info.SnapshotMachineId() == w.tag.Id() {
+		// The snapshot was taken before an earlier attempt at the
+		// upgrade, since when the database may have changed.
+		return nil
+	}
+	controllerInfo, err := w.st.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(controllerInfo.MachineIds); n > 1 {
+		logger.Infof("not taking snapshot of database with %d controllers; the upgrade cannot be rolled back", n)
+		return nil
+	}
+	logger.Infof("taking snapshot of database before upgrade")
+	dir, err := SnapshotDatabase(w.st, w.agent.CurrentConfig())
+	if err != nil {
+		return errors.Annotate(err, "cannot snapshot database before upgrade")
+	}
+	return errors.Trace(info.SetSnapshot(w.tag.Id(), dir))
+}
+
+func snapshotDatabase(st *state.State, agentConfig agent.Config) (string, error) {
+	mongoInfo, ok := agentConfig.MongoInfo()
+	if !ok {
+		return "", errors.New("no mongo info in agent config")
+	}
+	dir := filepath.Join(agentConfig.DataDir(), "upgrade-snapshot")
+	if err := backups.SnapshotDatabase(dir, mongoInfo, st.MongoSession()); err != nil {
+		return "", errors.Trace(err)
+	}
+	return dir, nil
+}
+
 func (w *upgradesteps) waitForOtherControllers(info *state.UpgradeInfo) error {
 	watcher := info.Watch()
 	defer watcher.Stop()
@@ -370,6 +425,106 @@ func (w *upgradesteps) runUpgradeSteps(agentConfig agent.ConfigSetter) error {
 	return nil
 }
 
+// canRollback reports whether this controller took the snapshot of the
+// database that a failed upgrade is rolled back to.
+func (w *upgradesteps) canRollback(info *state.UpgradeInfo) bool {
+	return w.isMaster && info != nil && info.SnapshotMachineId() == w.tag.Id()
+}
+
+// waitForRollback marks the upgrade as failed after the master
+// controller's upgrade steps have failed, and waits for it to be rolled
+// back with "juju upgrade-juju --abort". The upgrade error is returned
+// once the upgrade has been rolled back.
+func (w *upgradesteps) waitForRollback(info *state.UpgradeInfo, upgradeErr error) error {
+	if err := info.SetStatus(state.UpgradeFailed); err != nil {
+		logger.Errorf("cannot mark upgrade as failed: %v", err)
+		return upgradeErr
+	}
+	logger.Errorf("upgrade steps failed, waiting for the upgrade to be rolled back: %v", upgradeErr)
+	w.machine.SetStatus(status.StatusError, fmt.Sprintf(
+		`upgrade to %v failed: %v; run "juju upgrade-juju --abort" to roll back`,
+		w.toVersion, upgradeErr,
+	), nil)
+
+	watcher := info.Watch()
+	defer watcher.Stop()
+	for {
+		select {
+		case <-watcher.Changes():
+			if err := info.Refresh(); errors.IsNotFound(err) {
+				// The upgrade has been reset some other way.
+				return upgradeErr
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+			if info.Status() != state.UpgradeRollingBack {
+				continue
+			}
+			if err := w.rollback(info); err != nil {
+				return errors.Annotatef(err, "cannot roll back upgrade after %v", upgradeErr)
+			}
+			return errors.Annotate(upgradeErr, "upgrade rolled back")
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		}
+	}
+}
+
+// rollback reverses the upgrade steps, restores the snapshot of the
+// database taken before they ran, and reverts the model's agent
+// version, so that the agents go back to the version they were
+// upgraded from. The snapshot is restored into this controller's
+// database only, so rolling back is refused when there is more than
+// one controller.
+func (w *upgradesteps) rollback(info *state.UpgradeInfo) error {
+	if info.SnapshotMachineId() != w.tag.Id() {
+		return errors.Errorf("upgrade snapshot is held by machine %s", info.SnapshotMachineId())
+	}
+	controllerInfo, err := w.st.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(controllerInfo.MachineIds); n > 1 {
+		return errors.Errorf("cannot restore upgrade snapshot with %d controllers", n)
+	}
+	logger.Infof("rolling back upgrade from %v to %v", w.fromVersion, w.toVersion)
+	if err := w.agent.ChangeConfig(w.reverseUpgradeSteps); err != nil {
+		return errors.Annotate(err, "cannot reverse upgrade steps")
+	}
+	logger.Infof("restoring database snapshot from %s", info.SnapshotDir())
+	if err := RestoreSnapshot(info.SnapshotDir(), w.agent.CurrentConfig()); err != nil {
+		return errors.Trace(err)
+	}
+
+	// Mongo may have been restarted to restore the snapshot.
+	st, err := w.openState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Close()
+	// The snapshot holds the upgrade as it was before the upgrade
+	// steps ran; archive it so the agent version can be changed.
+	if err := st.AbortCurrentUpgrade(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.SetModelAgentVersion(w.fromVersion); err != nil {
+		return errors.Annotate(err, "cannot revert model agent version")
+	}
+	logger.Infof("upgrade rolled back to %v", w.fromVersion)
+	return nil
+}
+
+// reverseUpgradeSteps reverses the upgrade steps run by
+// runUpgradeSteps.
+//
+// This function conforms to the agent.ConfigMutator type and is
+// designed to be called via a machine agent's ChangeConfig method.
+func (w *upgradesteps) reverseUpgradeSteps(agentConfig agent.ConfigSetter) error {
+	context := upgrades.NewContext(agentConfig, w.apiConn, w.st)
+	targets := jobsToTargets(w.jobs, w.isMaster)
+	return ReverseUpgrade(w.fromVersion, targets, context)
+}
+
 func (w *upgradesteps) reportUpgradeFailure(err error, willRetry bool) {
 	retryText := "will retry"
 	if !willRetry {
@@ -86,6 +86,10 @@ func (s *UpgradeSuite) SetUpTest(c *gc.C) {
 	}
 	s.PatchValue(&IsMachineMaster, fakeIsMachineMaster)
 
+	// Taking a real database snapshot needs mongodump.
+	s.PatchValue(&SnapshotDatabase, func(*state.State, agent.Config) (string, error) {
+		return "/var/lib/juju/upgrade-snapshot", nil
+	})
 }
 
 func (s *UpgradeSuite) captureLogs(c *gc.C) {
@@ -320,6 +324,118 @@ func (s *UpgradeSuite) TestSuccessSecondary(c *gc.C) {
 	s.checkSuccess(c, "controller", mungeInfo)
 }
 
+func (s *UpgradeSuite) TestRollbackMaster(c *gc.C) {
+	// This test checks that when the upgrade steps fail on the master
+	// controller, the upgrade is rolled back once requested.
+	// Snapshots can only be restored with a single controller.
+	err := s.State.SetModelAgentVersion(jujuversion.Current)
+	c.Assert(err, jc.ErrorIsNil)
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+	machineIdA := machine.Id()
+	s.setMachineAlive(c, machineIdA)
+	err = machine.SetAgentVersion(version.Binary{
+		Number: jujuversion.Current,
+		Arch:   arch.HostArch(),
+		Series: series.HostSeries(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	vPrevious := s.oldVersion.Number
+	vNext := jujuversion.Current
+	info, err := s.State.EnsureUpgradeInfo(machineIdA, vPrevious, vNext)
+	c.Assert(err, jc.ErrorIsNil)
+
+	attemptsP := s.countUpgradeAttempts(errors.New("boom"))
+	var reversedFrom version.Number
+	s.PatchValue(&ReverseUpgrade, func(from version.Number, _ []upgrades.Target, _ upgrades.Context) error {
+		reversedFrom = from
+		return nil
+	})
+	var restoredDir string
+	s.PatchValue(&RestoreSnapshot, func(dir string, _ agent.Config) error {
+		restoredDir = dir
+		return nil
+	})
+
+	s.setInstantRetryStrategy(c)
+	config := s.makeFakeConfig()
+	agent := NewFakeAgent(config)
+	doneLock, err := NewLock(agent)
+	c.Assert(err, jc.ErrorIsNil)
+	machineStatus := &testStatusSetter{}
+	jobs := []multiwatcher.MachineJob{multiwatcher.JobManageModel}
+	w, err := NewWorker(doneLock, agent, nil, jobs, s.openStateForUpgrade, s.preUpgradeSteps, machineStatus)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err := info.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		if info.Status() == state.UpgradeFailed {
+			break
+		}
+		if !a.HasNext() {
+			c.Fatalf("upgrade not marked as failed")
+		}
+	}
+	c.Check(info.SnapshotMachineId(), gc.Equals, machineIdA)
+	c.Check(info.SnapshotDir(), gc.Equals, "/var/lib/juju/upgrade-snapshot")
+	err = s.State.RollbackCurrentUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(w.Wait(), jc.ErrorIsNil)
+	// The upgrade steps were retried before the upgrade was marked
+	// as failed.
+	c.Check(*attemptsP, gc.Equals, maxUpgradeRetries)
+	c.Check(reversedFrom, gc.Equals, vPrevious)
+	c.Check(restoredDir, gc.Equals, "/var/lib/juju/upgrade-snapshot")
+	c.Check(config.Version, gc.Equals, vPrevious) // Upgrade didn't finish
+	c.Check(doneLock.IsUnlocked(), jc.IsFalse)
+	s.assertEnvironAgentVersion(c, vPrevious)
+
+	upgrading, err := s.State.IsUpgrading()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrading, jc.IsFalse)
+
+	lastCall := machineStatus.Calls[len(machineStatus.Calls)-1]
+	c.Check(lastCall, jc.DeepEquals, StatusCall{
+		status.StatusError,
+		fmt.Sprintf("upgrade to %s failed (giving up): upgrade rolled back: boom", vNext),
+	})
+}
+
+func (s *UpgradeSuite) TestNoRollbackWithSeveralControllers(c *gc.C) {
+	// This test checks that no snapshot is taken when there is more
+	// than one controller, so the master controller does not wait for
+	// a rollback after its upgrade steps fail.
+	_, machineIdB, machineIdC := s.create3Controllers(c)
+	vPrevious := s.oldVersion.Number
+	vNext := jujuversion.Current
+	info, err := s.State.EnsureUpgradeInfo(machineIdB, vPrevious, vNext)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.EnsureUpgradeInfo(machineIdC, vPrevious, vNext)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.PatchValue(&SnapshotDatabase, func(*state.State, agent.Config) (string, error) {
+		c.Fatalf("snapshot taken with several controllers")
+		return "", nil
+	})
+	attemptsP := s.countUpgradeAttempts(errors.New("boom"))
+
+	workerErr, config, _, doneLock := s.runUpgradeWorker(c, multiwatcher.JobManageModel)
+
+	c.Check(workerErr, gc.IsNil)
+	c.Check(*attemptsP, gc.Equals, maxUpgradeRetries)
+	c.Check(config.Version, gc.Equals, s.oldVersion.Number) // Upgrade didn't finish
+	c.Check(doneLock.IsUnlocked(), jc.IsFalse)
+
+	err = info.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.SnapshotMachineId(), gc.Equals, "")
+	c.Check(info.Status(), gc.Equals, state.UpgradeRunning)
+}
+
 func (s *UpgradeSuite) checkSuccess(c *gc.C, target string, mungeInfo func(*state.UpgradeInfo)) *state.UpgradeInfo {
 	_, machineIdB, machineIdC := s.create3Controllers(c)
 